@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sklinkert/go-ddd/internal/infrastructure/config"
+	postgres2 "github.com/sklinkert/go-ddd/internal/infrastructure/db/postgres"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/exchangerate"
+)
+
+// runExchangeRates implements "marketplace exchange-rates": it loads a rates
+// file, in the format EXCHANGE_RATES_FILE uses, into the exchange_rates
+// table and returns the exit code. A rate feed runs it on a schedule. All
+// rates are stored in one transaction; loading the same file again
+// replaces its rates with themselves.
+//
+//	marketplace exchange-rates -file rates.json
+func runExchangeRates(args []string) int {
+	flags := flag.NewFlagSet("exchange-rates", flag.ContinueOnError)
+	file := flags.String("file", "", "JSON file with the rates to store")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "-file is required")
+		return 2
+	}
+
+	rates, err := exchangerate.ReadFile(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := postgres2.NewConnection(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to connect to database:", err)
+		return 1
+	}
+	defer pool.Close()
+
+	repo := postgres2.NewSqlcExchangeRateRepository(pool)
+	err = postgres2.NewTransactor(pool).WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, rate := range rates {
+			if err := repo.Save(ctx, rate); err != nil {
+				return fmt.Errorf("%s to %s at %s: %w", rate.From(), rate.To(), rate.EffectiveAt().Format(time.RFC3339), err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to store exchange rates:", err)
+		return 1
+	}
+
+	fmt.Printf("stored %d exchange rates\n", len(rates))
+	return 0
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -10,16 +11,31 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
 	"github.com/sklinkert/go-ddd/internal/application/services"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/blobstore"
 	"github.com/sklinkert/go-ddd/internal/infrastructure/config"
 	postgres2 "github.com/sklinkert/go-ddd/internal/infrastructure/db/postgres"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/exchangerate"
 	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox/kafka"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox/natsjs"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/webhook"
 	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		os.Exit(runReplay(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "exchange-rates" {
+		os.Exit(runExchangeRates(os.Args[2:]))
+	}
+
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	slog.SetDefault(logger)
 
@@ -40,11 +56,56 @@ func main() {
 	queries := postgres2.NewQueries(pool)
 
 	productRepo := postgres2.NewSqlcProductRepository(pool)
-	sellerRepo := postgres2.NewSqlcSellerRepository(queries)
+	sellerRepo := postgres2.NewSqlcSellerRepository(pool)
 	idempotencyRepo := postgres2.NewSqlcIdempotencyRepository(queries)
 
-	productService := services.NewProductService(productRepo, sellerRepo, idempotencyRepo)
+	exchangeRates, err := newExchangeRateProvider(pool, cfg)
+	if err != nil {
+		logger.Error("failed to load exchange rates", slog.Any("error", err))
+		os.Exit(1)
+	}
+	promotionRepo := postgres2.NewSqlcPromotionRepository(pool)
+	productService := services.NewProductService(productRepo, sellerRepo, promotionRepo, idempotencyRepo, services.NewConversionService(exchangeRates))
 	sellerService := services.NewSellerService(sellerRepo, idempotencyRepo)
+	promotionService := services.NewPromotionService(promotionRepo, sellerRepo, productRepo, idempotencyRepo)
+	categoryService := services.NewCategoryService(postgres2.NewSqlcCategoryRepository(pool), idempotencyRepo)
+	transactor := postgres2.NewTransactor(pool)
+	inventoryService := services.NewInventoryService(postgres2.NewSqlcInventoryRepository(pool), productRepo, transactor, idempotencyRepo)
+	go services.NewReservationSweeper(inventoryService, cfg.ReservationExpiryInterval).Start(ctx)
+	orderService := services.NewOrderService(postgres2.NewSqlcOrderRepository(pool), productRepo, promotionRepo, inventoryService, transactor, idempotencyRepo, cfg.OrderReservationTTL)
+	cartService := services.NewCartService(postgres2.NewSqlcCartRepository(pool), productRepo, promotionRepo, orderService, transactor, idempotencyRepo, cfg.CartTTL)
+	go services.NewCartSweeper(cartService, cfg.CartExpiryInterval).Start(ctx)
+	pricingService := services.NewPricingService(
+		productRepo,
+		postgres2.NewSqlcPriceHistoryRepository(pool),
+		postgres2.NewSqlcScheduledPriceChangeRepository(pool),
+		transactor,
+		idempotencyRepo,
+	)
+	go services.NewPriceScheduler(pricingService, cfg.PriceScheduleInterval).Start(ctx)
+
+	blobStore, err := newBlobStore(cfg)
+	if err != nil {
+		logger.Error("failed to set up blob store", slog.Any("error", err))
+		os.Exit(1)
+	}
+	mediaService := services.NewMediaService(productRepo, blobStore, postgres2.NewSqlcBlobDeletionRepository(queries), idempotencyRepo, int64(cfg.MaxImageBytes))
+	go services.NewBlobCleaner(mediaService, cfg.BlobCleanupInterval).Start(ctx)
+
+	// The inbox applies messages from external systems, e.g. KYC results,
+	// exactly once each.
+	inboxService := services.NewInboxService(
+		postgres2.NewSqlcInboxRepository(queries),
+		transactor,
+		[]interfaces.InboxConsumer{services.NewSellerVerificationConsumer(sellerRepo)},
+		outbox.RetryPolicy{
+			MaxAttempts: cfg.InboxMaxAttempts,
+			BaseDelay:   cfg.InboxRetryBaseDelay,
+			MaxDelay:    cfg.InboxRetryMaxDelay,
+		},
+		cfg.InboxPollInterval,
+	)
+	go inboxService.Start(ctx)
 
 	e := echo.New()
 	e.HideBanner = true
@@ -53,13 +114,70 @@ func main() {
 	e.Use(requestLogger(logger))
 
 	rest.NewProductController(e, productService)
+	rest.NewMediaController(e, mediaService, int64(cfg.MaxImageBytes))
 	rest.NewSellerController(e, sellerService)
+	rest.NewPromotionController(e, promotionService)
+	rest.NewCategoryController(e, categoryService, productService)
+	rest.NewInventoryController(e, inventoryService)
+	rest.NewOrderController(e, orderService)
+	rest.NewCartController(e, cartService)
+	rest.NewPriceController(e, pricingService)
+	rest.NewInboxController(e, inboxService)
 	rest.NewHealthController(e, pool)
+	rest.NewOutboxController(e, services.NewDeadLetterService(postgres2.NewSqlcDeadLetterRepository(queries)))
+	// Webhooks must not reach into the server's own network, neither when
+	// subscribing nor, should DNS change, when delivering.
+	webhookTargets, err := webhook.NewTargetPolicy(cfg.WebhookAllowedHosts)
+	if err != nil {
+		logger.Error("invalid webhook configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	rest.NewWebhookController(e, services.NewWebhookService(postgres2.NewSqlcWebhookSubscriptionRepository(queries), webhookTargets))
+
+	publisher, closePublisher, err := newPublisher(ctx, cfg)
+	if err != nil {
+		logger.Error("failed to set up event publisher", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer closePublisher()
+
+	// Webhook deliveries are retried per subscription by their own dispatcher.
+	webhookDispatcher := webhook.NewDispatcher(queries, webhookTargets.NewHTTPClient(10*time.Second), cfg.WebhookPollInterval, outbox.RetryPolicy{
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		BaseDelay:   cfg.WebhookRetryBaseDelay,
+		MaxDelay:    cfg.WebhookRetryMaxDelay,
+	})
+	go webhookDispatcher.Start(ctx)
+	// Replays re-emit history to the broker (or just the log); webhook
+	// deliveries are unique per event, so they are not a replay target.
+	replays := postgres2.NewSqlcOutboxReplayRepository(queries, map[string]outbox.Publisher{
+		"log":              outbox.SlogPublisher{},
+		publisherKind(cfg): publisher,
+	})
+	rest.NewOutboxReplayController(e, services.NewOutboxReplayService(replays))
+
+	publisher = outbox.FanOut{publisher, webhook.NewPublisher(queries, cfg.EventSource, webhookDispatcher)}
 
 	// The outbox relay publishes stored domain events (at-least-once).
-	relay := outbox.NewRelay(queries, outbox.SlogPublisher{}, 5*time.Second)
+	relay := outbox.NewRelay(pool, publisher, cfg.OutboxPollInterval, outbox.RetryPolicy{
+		MaxAttempts: cfg.OutboxMaxAttempts,
+		BaseDelay:   cfg.OutboxRetryBaseDelay,
+		MaxDelay:    cfg.OutboxRetryMaxDelay,
+	})
 	go relay.Start(ctx)
 
+	// Published events are archived (or deleted) after the retention period
+	// so the outbox table stays small.
+	if cfg.OutboxRetentionDays > 0 {
+		retentionMode, err := outbox.ParseRetentionMode(cfg.OutboxRetentionMode)
+		if err != nil {
+			logger.Error("invalid outbox retention configuration", slog.Any("error", err))
+			os.Exit(1)
+		}
+		retain := time.Duration(cfg.OutboxRetentionDays) * 24 * time.Hour
+		go outbox.NewRetention(queries, retain, cfg.OutboxRetentionInterval, retentionMode).Start(ctx)
+	}
+
 	// Start the server in the background so we can wait for shutdown signals.
 	srvErr := make(chan error, 1)
 	go func() {
@@ -87,6 +205,95 @@ func main() {
 	}
 }
 
+// newPublisher builds the publisher selected by OUTBOX_PUBLISHER. Every
+// publisher except "log" sends CloudEvents in CLOUDEVENTS_MODE. The returned
+// func releases its connection.
+func newPublisher(ctx context.Context, cfg config.Config) (outbox.Publisher, func(), error) {
+	kind := publisherKind(cfg)
+	if kind == "log" {
+		return outbox.SlogPublisher{}, func() {}, nil
+	}
+
+	mode, err := outbox.ParseContentMode(cfg.CloudEventsMode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch kind {
+	case "http":
+		if cfg.CloudEventsURL == "" {
+			return nil, nil, errors.New("OUTBOX_PUBLISHER=http requires CLOUDEVENTS_URL")
+		}
+		client := &http.Client{Timeout: 10 * time.Second}
+		return outbox.NewHTTPPublisher(client, cfg.CloudEventsURL, cfg.EventSource, mode), func() {}, nil
+	case "nats":
+		publisher, err := natsjs.New(ctx, natsjs.Config{
+			URL:           cfg.NATSURL,
+			Stream:        cfg.NATSStream,
+			SubjectPrefix: cfg.NATSSubjectPrefix,
+			Source:        cfg.EventSource,
+			Mode:          mode,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return publisher, publisher.Close, nil
+	case "kafka":
+		publisher, err := kafka.New(kafka.Config{
+			Brokers: cfg.KafkaBrokers,
+			Topic:   cfg.KafkaTopic,
+			Source:  cfg.EventSource,
+			Mode:    mode,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return publisher, publisher.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown OUTBOX_PUBLISHER %q (want log, http, nats or kafka)", kind)
+	}
+}
+
+// publisherKind resolves an empty OUTBOX_PUBLISHER to "http" if
+// CLOUDEVENTS_URL is set and "log" otherwise.
+func publisherKind(cfg config.Config) string {
+	switch {
+	case cfg.OutboxPublisher != "":
+		return cfg.OutboxPublisher
+	case cfg.CloudEventsURL != "":
+		return "http"
+	default:
+		return "log"
+	}
+}
+
+// newExchangeRateProvider reads rates from EXCHANGE_RATES_FILE if it is set
+// and from the exchange_rates table otherwise.
+func newExchangeRateProvider(pool *pgxpool.Pool, cfg config.Config) (repositories.ExchangeRateProvider, error) {
+	if cfg.ExchangeRatesFile != "" {
+		return exchangerate.NewStaticProvider(cfg.ExchangeRatesFile)
+	}
+	return postgres2.NewSqlcExchangeRateRepository(pool), nil
+}
+
+// newBlobStore builds the blob store selected by BLOB_STORE.
+func newBlobStore(cfg config.Config) (repositories.BlobStore, error) {
+	switch cfg.BlobStore {
+	case "filesystem":
+		return blobstore.NewFilesystemStore(cfg.BlobStoreDir)
+	case "s3":
+		return blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyId:     cfg.S3AccessKeyId,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		}, &http.Client{Timeout: time.Minute})
+	default:
+		return nil, fmt.Errorf("unknown BLOB_STORE %q (want filesystem or s3)", cfg.BlobStore)
+	}
+}
+
 // requestLogger emits one structured log line per request via slog.
 func requestLogger(logger *slog.Logger) echo.MiddlewareFunc {
 	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/config"
+	postgres2 "github.com/sklinkert/go-ddd/internal/infrastructure/db/postgres"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+)
+
+// runReplay implements "marketplace replay": it re-emits published events to
+// a publisher in the foreground, printing progress after every batch, and
+// returns the exit code. The replay is recorded like one started over the
+// admin API, so either side can resume it after a failure or Ctrl-C.
+//
+//	marketplace replay -target kafka -aggregate-id 0198c0de-...
+//	marketplace replay -target nats -event-name product.created -from 2026-01-01T00:00:00Z
+//	marketplace replay -resume 0198c0df-...
+func runReplay(args []string) int {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	target := flags.String("target", "", "Publisher to replay to: log, http, nats or kafka (default: OUTBOX_PUBLISHER)")
+	aggregateId := flags.String("aggregate-id", "", "Only events of this aggregate")
+	eventName := flags.String("event-name", "", "Only events with this name, e.g. product.created")
+	from := flags.String("from", "", "Only events that occurred at or after this RFC 3339 time")
+	to := flags.String("to", "", "Only events that occurred before this RFC 3339 time")
+	resume := flags.String("resume", "", "Continue the replay with this id from its last checkpoint")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var id uuid.UUID
+	var filter entities.OutboxReplayFilter
+	var err error
+	if *resume != "" {
+		if id, err = uuid.Parse(*resume); err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -resume:", err)
+			return 2
+		}
+	} else if filter, err = parseReplayFilter(*aggregateId, *eventName, *from, *to); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	cfg := config.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := postgres2.NewConnection(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to connect to database:", err)
+		return 1
+	}
+	defer pool.Close()
+	queries := postgres2.NewQueries(pool)
+
+	// A resumed replay keeps the target it was created with.
+	if id != uuid.Nil {
+		existing, err := postgres2.NewSqlcOutboxReplayRepository(queries, nil).FindById(ctx, id)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		*target = existing.Target
+	}
+
+	if *target != "" {
+		cfg.OutboxPublisher = *target
+	}
+	*target = publisherKind(cfg)
+	publisher, closePublisher, err := newPublisher(ctx, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to set up publisher:", err)
+		return 1
+	}
+	defer closePublisher()
+
+	replays := postgres2.NewSqlcOutboxReplayRepository(queries, map[string]outbox.Publisher{*target: publisher})
+	if id == uuid.Nil {
+		created, err := replays.Create(ctx, filter, *target)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		id = created.Id
+		fmt.Printf("replay %s: %d events to %s\n", id, created.Total, *target)
+	}
+
+	replay, err := replays.Run(ctx, id, func(r *entities.OutboxReplay) {
+		fmt.Printf("replay %s: %d/%d events\n", r.Id, r.Replayed, r.Total)
+	})
+	if replay == nil {
+		// The replay was not found, has completed or is running elsewhere.
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay %s stopped after %d events: %v\nresume with: marketplace replay -resume %s\n",
+			id, replay.Replayed, err, id)
+		return 1
+	}
+
+	fmt.Printf("replay %s: completed, %d events\n", id, replay.Replayed)
+	return 0
+}
+
+func parseReplayFilter(aggregateId, eventName, from, to string) (entities.OutboxReplayFilter, error) {
+	filter := entities.OutboxReplayFilter{EventName: eventName}
+	var err error
+	if aggregateId != "" {
+		if filter.AggregateId, err = uuid.Parse(aggregateId); err != nil {
+			return entities.OutboxReplayFilter{}, fmt.Errorf("invalid -aggregate-id: %w", err)
+		}
+	}
+	if from != "" {
+		if filter.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return entities.OutboxReplayFilter{}, fmt.Errorf("invalid -from: %w", err)
+		}
+	}
+	if to != "" {
+		if filter.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return entities.OutboxReplayFilter{}, fmt.Errorf("invalid -to: %w", err)
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,25 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type AddCartItemCommand struct {
+	IdempotencyKey string
+	BuyerId        uuid.UUID
+	ProductId      uuid.UUID
+	// VariantId names the product variant; uuid.Nil picks the only variant
+	// of a single-variant product.
+	VariantId uuid.UUID
+	// Quantity is added to the line's quantity if the product is already in
+	// the cart.
+	Quantity int
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type AddCartItemCommandResult struct {
+	Result *common.CartResult
+}
@@ -0,0 +1,21 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type AdjustStockCommand struct {
+	IdempotencyKey string
+	ProductId      uuid.UUID
+	// VariantId names the product variant; uuid.Nil picks the only variant
+	// of a single-variant product.
+	VariantId uuid.UUID
+	// Delta is added to the units on hand; negative values remove units.
+	Delta  int
+	Reason string
+}
+
+type AdjustStockCommandResult struct {
+	Result *common.InventoryResult
+}
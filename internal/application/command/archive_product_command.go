@@ -0,0 +1,19 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// ArchiveProductCommand retires a product from the listings.
+type ArchiveProductCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type ArchiveProductCommandResult struct {
+	Result *common.ProductResult
+}
@@ -0,0 +1,20 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// CancelOrderCommand calls off a pending order and releases its stock.
+type CancelOrderCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	Reason         string
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type CancelOrderCommandResult struct {
+	Result *common.OrderResult
+}
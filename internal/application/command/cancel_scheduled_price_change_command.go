@@ -0,0 +1,11 @@
+package command
+
+import "github.com/google/uuid"
+
+type CancelScheduledPriceChangeCommand struct {
+	IdempotencyKey string
+	ProductId      uuid.UUID
+	Id             uuid.UUID
+}
+
+type CancelScheduledPriceChangeCommandResult struct{}
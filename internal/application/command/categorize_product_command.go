@@ -0,0 +1,21 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// CategorizeProductCommand replaces the categories a product is listed in;
+// an empty CategoryIds removes it from all of them.
+type CategorizeProductCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	CategoryIds    []uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type CategorizeProductCommandResult struct {
+	Result *common.ProductResult
+}
@@ -0,0 +1,18 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type CheckoutCartCommand struct {
+	IdempotencyKey string
+	BuyerId        uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type CheckoutCartCommandResult struct {
+	Result *common.OrderResult
+}
@@ -0,0 +1,16 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type CommitReservationCommand struct {
+	// VariantId names the variant whose stock holds the reservation.
+	VariantId     uuid.UUID
+	ReservationId uuid.UUID
+}
+
+type CommitReservationCommandResult struct {
+	Result *common.InventoryResult
+}
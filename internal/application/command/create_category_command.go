@@ -0,0 +1,18 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type CreateCategoryCommand struct {
+	IdempotencyKey string
+	Name           string
+	// ParentId places the category below an existing one; nil creates a
+	// root category.
+	ParentId *uuid.UUID
+}
+
+type CreateCategoryCommandResult struct {
+	Result *common.CategoryResult
+}
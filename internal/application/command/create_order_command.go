@@ -0,0 +1,25 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type CreateOrderCommand struct {
+	IdempotencyKey string
+	BuyerId        uuid.UUID
+	Items          []CreateOrderItem
+}
+
+// CreateOrderItem names a product variant and how many units to buy. Name,
+// SKU and price are taken from the product at checkout.
+type CreateOrderItem struct {
+	ProductId uuid.UUID
+	// VariantId may be uuid.Nil for a single-variant product.
+	VariantId uuid.UUID
+	Quantity  int
+}
+
+type CreateOrderCommandResult struct {
+	Result *common.OrderResult
+}
@@ -13,6 +13,19 @@ type CreateProductCommand struct {
 	PriceMinorUnits int64
 	Currency        entities.Currency
 	SellerId        uuid.UUID
+	// Variants are optional; without them the product gets a single
+	// default variant.
+	Variants []ProductVariantInput
+}
+
+// ProductVariantInput describes a variant to create or the new state of an
+// existing one.
+type ProductVariantInput struct {
+	Sku        string
+	Attributes map[string]string
+	// PriceOverrideMinorUnits is in the product's currency; nil means the
+	// variant sells at the product's price.
+	PriceOverrideMinorUnits *int64
 }
 
 type CreateProductCommandResult struct {
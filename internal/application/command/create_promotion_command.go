@@ -0,0 +1,32 @@
+package command
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// CreatePromotionCommand creates a discount on some or all of a seller's
+// products. A percentage discount sets BasisPoints, a fixed-amount one
+// AmountMinorUnits and Currency.
+type CreatePromotionCommand struct {
+	IdempotencyKey   string
+	SellerId         uuid.UUID
+	Name             string
+	DiscountKind     entities.DiscountKind
+	BasisPoints      int64
+	AmountMinorUnits int64
+	Currency         entities.Currency
+	ProductIds       []uuid.UUID
+	// StartsAt defaults to now.
+	StartsAt   time.Time
+	EndsAt     *time.Time
+	Stackable  bool
+	UsageLimit int
+}
+
+type CreatePromotionCommandResult struct {
+	Result *common.PromotionResult
+}
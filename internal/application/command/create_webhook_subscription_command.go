@@ -0,0 +1,15 @@
+package command
+
+import "github.com/sklinkert/go-ddd/internal/application/common"
+
+type CreateWebhookSubscriptionCommand struct {
+	URL string
+	// EventTypes are event names ("product.created"), prefixes
+	// ("product.*") or "*" for every event.
+	EventTypes []string
+	Secret     string
+}
+
+type CreateWebhookSubscriptionCommandResult struct {
+	Result *common.WebhookSubscriptionResult
+}
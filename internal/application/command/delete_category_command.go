@@ -0,0 +1,17 @@
+package command
+
+import (
+	"github.com/google/uuid"
+)
+
+type DeleteCategoryCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type DeleteCategoryCommandResult struct {
+	Success bool
+}
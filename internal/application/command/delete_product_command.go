@@ -7,6 +7,9 @@ import (
 type DeleteProductCommand struct {
 	IdempotencyKey string
 	Id             uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
 }
 
 type DeleteProductCommandResult struct {
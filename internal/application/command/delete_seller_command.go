@@ -7,6 +7,9 @@ import (
 type DeleteSellerCommand struct {
 	IdempotencyKey string
 	Id             uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
 }
 
 type DeleteSellerCommandResult struct {
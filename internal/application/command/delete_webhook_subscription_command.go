@@ -0,0 +1,11 @@
+package command
+
+import "github.com/google/uuid"
+
+type DeleteWebhookSubscriptionCommand struct {
+	Id uuid.UUID
+}
+
+type DeleteWebhookSubscriptionCommandResult struct {
+	Success bool
+}
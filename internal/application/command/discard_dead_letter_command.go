@@ -0,0 +1,11 @@
+package command
+
+import "github.com/google/uuid"
+
+type DiscardDeadLetterCommand struct {
+	Id uuid.UUID
+}
+
+type DiscardDeadLetterCommandResult struct {
+	Success bool
+}
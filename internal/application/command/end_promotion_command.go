@@ -0,0 +1,19 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// EndPromotionCommand stops a promotion now.
+type EndPromotionCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type EndPromotionCommandResult struct {
+	Result *common.PromotionResult
+}
@@ -0,0 +1,20 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// PayOrderCommand records the payment of a pending order; the order's stock
+// reservations become sales.
+type PayOrderCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type PayOrderCommandResult struct {
+	Result *common.OrderResult
+}
@@ -0,0 +1,16 @@
+package command
+
+type ReceiveInboxMessageCommand struct {
+	// Source and MessageId identify the message; the sender keeps them
+	// stable across redeliveries.
+	Source    string
+	MessageId string
+	Type      string
+	Payload   []byte
+}
+
+type ReceiveInboxMessageCommandResult struct {
+	// Duplicate is set when the message had been received before; it is not
+	// handled again.
+	Duplicate bool
+}
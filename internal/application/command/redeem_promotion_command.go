@@ -0,0 +1,20 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// RedeemPromotionCommand counts one use of a promotion against its usage
+// limit.
+type RedeemPromotionCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type RedeemPromotionCommandResult struct {
+	Result *common.PromotionResult
+}
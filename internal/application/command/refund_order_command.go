@@ -0,0 +1,19 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type RefundOrderCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	Reason         string
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type RefundOrderCommandResult struct {
+	Result *common.OrderResult
+}
@@ -0,0 +1,22 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type RemoveCartItemCommand struct {
+	IdempotencyKey string
+	BuyerId        uuid.UUID
+	ProductId      uuid.UUID
+	// VariantId names the product variant; uuid.Nil picks the only variant
+	// of a single-variant product.
+	VariantId uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type RemoveCartItemCommandResult struct {
+	Result *common.CartResult
+}
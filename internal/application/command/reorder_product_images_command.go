@@ -0,0 +1,20 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type ReorderProductImagesCommand struct {
+	IdempotencyKey string
+	ProductId      uuid.UUID
+	// ImageIds is the new display order; it names every image once.
+	ImageIds []uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type ReorderProductImagesCommandResult struct {
+	Result *common.ProductImagesResult
+}
@@ -0,0 +1,11 @@
+package command
+
+import "github.com/google/uuid"
+
+type ReplayDeadLetterCommand struct {
+	Id uuid.UUID
+}
+
+type ReplayDeadLetterCommandResult struct {
+	Success bool
+}
@@ -0,0 +1,23 @@
+package command
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type ReserveStockCommand struct {
+	ProductId uuid.UUID
+	// VariantId names the product variant; uuid.Nil picks the only variant
+	// of a single-variant product.
+	VariantId uuid.UUID
+	Quantity  int
+	// TTL is how long the units are held before the reservation expires.
+	TTL time.Duration
+}
+
+type ReserveStockCommandResult struct {
+	Reservation common.ReservationResult
+	Result      *common.InventoryResult
+}
@@ -0,0 +1,14 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type ResumeOutboxReplayCommand struct {
+	Id uuid.UUID
+}
+
+type ResumeOutboxReplayCommandResult struct {
+	Result *common.OutboxReplayResult
+}
@@ -0,0 +1,21 @@
+package command
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type SchedulePriceChangeCommand struct {
+	IdempotencyKey  string
+	ProductId       uuid.UUID
+	PriceMinorUnits int64
+	Currency        entities.Currency
+	EffectiveAt     time.Time
+}
+
+type SchedulePriceChangeCommandResult struct {
+	Result *common.ScheduledPriceChangeResult
+}
@@ -0,0 +1,19 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type SetPrimaryProductImageCommand struct {
+	IdempotencyKey string
+	ProductId      uuid.UUID
+	ImageId        uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type SetPrimaryProductImageCommandResult struct {
+	Result *common.ProductImagesResult
+}
@@ -0,0 +1,19 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type ShipOrderCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	TrackingNumber string
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type ShipOrderCommandResult struct {
+	Result *common.OrderResult
+}
@@ -0,0 +1,23 @@
+package command
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// StartOutboxReplayCommand replays the published events matching the
+// filter fields to Target. Zero filter fields match every event; From and
+// To bound occurred_at as [From, To).
+type StartOutboxReplayCommand struct {
+	Target      string
+	AggregateId uuid.UUID
+	EventName   string
+	From        time.Time
+	To          time.Time
+}
+
+type StartOutboxReplayCommandResult struct {
+	Result *common.OutboxReplayResult
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type UpdateCartItemCommand struct {
+	IdempotencyKey string
+	BuyerId        uuid.UUID
+	ProductId      uuid.UUID
+	// VariantId names the product variant; uuid.Nil picks the only variant
+	// of a single-variant product.
+	VariantId uuid.UUID
+	// Quantity replaces the line's quantity; 0 removes the line.
+	Quantity int
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type UpdateCartItemCommandResult struct {
+	Result *common.CartResult
+}
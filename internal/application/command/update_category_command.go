@@ -0,0 +1,22 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// UpdateCategoryCommand renames a category and moves it, with all its
+// descendants, below ParentId (nil moves it to the root level).
+type UpdateCategoryCommand struct {
+	IdempotencyKey string
+	Id             uuid.UUID
+	Name           string
+	ParentId       *uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type UpdateCategoryCommandResult struct {
+	Result *common.CategoryResult
+}
@@ -13,6 +13,9 @@ type UpdateProductCommand struct {
 	PriceMinorUnits int64
 	Currency        entities.Currency
 	SellerId        uuid.UUID
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
 }
 
 type UpdateProductCommandResult struct {
@@ -0,0 +1,22 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// UpdateProductVariantCommand replaces the variant's SKU, attributes and
+// price override.
+type UpdateProductVariantCommand struct {
+	IdempotencyKey string
+	ProductId      uuid.UUID
+	VariantId      uuid.UUID
+	Variant        ProductVariantInput
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type UpdateProductVariantCommandResult struct {
+	Result *common.ProductResult
+}
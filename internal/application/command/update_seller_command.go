@@ -9,6 +9,9 @@ type UpdateSellerCommand struct {
 	IdempotencyKey string
 	Id             uuid.UUID
 	Name           string
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
 }
 
 type UpdateSellerCommandResult struct {
@@ -0,0 +1,28 @@
+package command
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type UploadProductImageCommand struct {
+	IdempotencyKey string
+	ProductId      uuid.UUID
+	// Data is the uploaded file. It stays out of the idempotency
+	// fingerprint, which records its hash instead.
+	Data []byte `json:"-"`
+	// ContentType is the type the client declared; it must match what the
+	// data actually is.
+	ContentType string
+	// Primary makes the image the product's primary image; the first image
+	// of a product always is.
+	Primary bool
+	// ExpectedVersion, when set, makes the command conditional: it fails with
+	// entities.ErrVersionConflict unless the stored version still matches.
+	ExpectedVersion *int
+}
+
+type UploadProductImageCommandResult struct {
+	Image  *common.ProductImageResult
+	Result *common.ProductImagesResult
+}
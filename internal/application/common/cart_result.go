@@ -0,0 +1,29 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type CartResult struct {
+	BuyerId uuid.UUID
+	Lines   []CartLineResult
+	// Total is the zero Money for an empty cart.
+	Total     entities.Money
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+	// Version is 0 for a cart that was never saved.
+	Version int
+}
+
+type CartLineResult struct {
+	ProductId   uuid.UUID
+	VariantId   uuid.UUID
+	Sku         string
+	ProductName string
+	UnitPrice   entities.Money
+	Quantity    int
+	Total       entities.Money
+}
@@ -0,0 +1,18 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CategoryResult struct {
+	Id       uuid.UUID
+	Name     string
+	ParentId *uuid.UUID
+	// AncestorIds lists the category's ancestors, root first.
+	AncestorIds []uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Version     int
+}
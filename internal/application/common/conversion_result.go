@@ -0,0 +1,11 @@
+package common
+
+import "github.com/sklinkert/go-ddd/internal/domain/entities"
+
+// ConversionResult is an amount converted into another currency. Rate is
+// the rate that was applied, or nil if the amount already was in that
+// currency.
+type ConversionResult struct {
+	Amount entities.Money
+	Rate   *entities.ExchangeRate
+}
@@ -0,0 +1,19 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeadLetterResult struct {
+	Id          uuid.UUID
+	AggregateId uuid.UUID
+	EventName   string
+	// Payload is the event's JSON data as stored in the outbox.
+	Payload        []byte
+	OccurredAt     time.Time
+	Attempts       int
+	LastError      string
+	DeadLetteredAt time.Time
+}
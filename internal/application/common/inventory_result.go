@@ -0,0 +1,24 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InventoryResult struct {
+	ProductId    uuid.UUID
+	VariantId    uuid.UUID
+	OnHand       int
+	Reserved     int
+	Available    int
+	Reservations []ReservationResult
+	UpdatedAt    time.Time
+	Version      int
+}
+
+type ReservationResult struct {
+	Id        uuid.UUID
+	Quantity  int
+	ExpiresAt time.Time
+}
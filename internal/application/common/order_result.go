@@ -0,0 +1,30 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type OrderResult struct {
+	Id             uuid.UUID
+	BuyerId        uuid.UUID
+	Status         string
+	Items          []OrderItemResult
+	Totals         []entities.Money
+	TrackingNumber string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Version        int
+}
+
+type OrderItemResult struct {
+	ProductId   uuid.UUID
+	VariantId   uuid.UUID
+	Sku         string
+	ProductName string
+	UnitPrice   entities.Money
+	Quantity    int
+	Total       entities.Money
+}
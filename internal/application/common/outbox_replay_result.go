@@ -0,0 +1,26 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxReplayResult struct {
+	Id     uuid.UUID
+	Target string
+	// AggregateId, EventName, From and To echo the filter; unset fields
+	// matched every event.
+	AggregateId *uuid.UUID
+	EventName   string
+	From        *time.Time
+	To          *time.Time
+	// Status is one of "pending", "running", "failed" or "completed".
+	Status      string
+	Total       int64
+	Replayed    int64
+	LastError   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
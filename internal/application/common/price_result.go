@@ -0,0 +1,21 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type PricePointResult struct {
+	Price       entities.Money
+	EffectiveAt time.Time
+}
+
+type ScheduledPriceChangeResult struct {
+	Id          uuid.UUID
+	ProductId   uuid.UUID
+	Price       entities.Money
+	EffectiveAt time.Time
+	CreatedAt   time.Time
+}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductImageResult struct {
+	Id          uuid.UUID
+	ContentType string
+	SizeBytes   int64
+	// Position is the image's place in display order, starting at 0.
+	Position  int
+	Primary   bool
+	CreatedAt time.Time
+}
+
+// ProductImagesResult is a product's images in display order, with the
+// product version a conditional change of them must name.
+type ProductImagesResult struct {
+	ProductId uuid.UUID
+	Images    []ProductImageResult
+	Version   int
+}
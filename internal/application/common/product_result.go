@@ -8,10 +8,33 @@ import (
 )
 
 type ProductResult struct {
-	Id        uuid.UUID
-	Name      string
-	Price     entities.Money
-	SellerId  uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Id          uuid.UUID
+	Name        string
+	Price       entities.Money
+	SellerId    uuid.UUID
+	Status      string
+	CategoryIds []uuid.UUID
+	Variants    []ProductVariantResult
+	Images      []ProductImageResult
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Version     int
+	// DisplayPrice is Price converted for display; nil unless a display
+	// currency was requested.
+	DisplayPrice *ConversionResult
+	// EffectivePrice is Price after the promotions running now.
+	EffectivePrice *EffectivePriceResult
+}
+
+type ProductVariantResult struct {
+	Id         uuid.UUID
+	Sku        string
+	Attributes map[string]string
+	// PriceOverride is nil when the variant sells at the product's price.
+	PriceOverride *entities.Money
+	// Price is what the variant costs: PriceOverride, or else the
+	// product's price.
+	Price entities.Money
+	// EffectivePrice is Price after the promotions running now.
+	EffectivePrice *EffectivePriceResult
 }
@@ -0,0 +1,11 @@
+package common
+
+// ProductSearchResult is a product found by a full-text search.
+type ProductSearchResult struct {
+	Product *ProductResult
+	// Rank grows with the relevance of the match.
+	Rank float32
+	// Highlight is the HTML-escaped product name with every matching word
+	// wrapped in <mark> and </mark>.
+	Highlight string
+}
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type PromotionResult struct {
+	Id           uuid.UUID
+	SellerId     uuid.UUID
+	Name         string
+	DiscountKind string
+	BasisPoints  int64
+	// Amount is only set for fixed-amount discounts.
+	Amount     *entities.Money
+	ProductIds []uuid.UUID
+	StartsAt   time.Time
+	EndsAt     *time.Time
+	Stackable  bool
+	UsageLimit int
+	UsageCount int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Version    int
+}
+
+// EffectivePriceResult is a product's price after promotions and the
+// promotions that were applied; without any, Price is the list price.
+type EffectivePriceResult struct {
+	Price        entities.Money
+	PromotionIds []uuid.UUID
+}
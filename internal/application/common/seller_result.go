@@ -7,8 +7,10 @@ import (
 )
 
 type SellerResult struct {
-	Id        uuid.UUID
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Id                 uuid.UUID
+	Name               string
+	VerificationStatus string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Version            int
 }
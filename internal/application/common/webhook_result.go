@@ -0,0 +1,30 @@
+package common
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscriptionResult never includes the secret.
+type WebhookSubscriptionResult struct {
+	Id         uuid.UUID
+	URL        string
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+type WebhookDeliveryResult struct {
+	Id        uuid.UUID
+	EventId   uuid.UUID
+	EventName string
+	// Status is one of "pending", "delivered" or "failed".
+	Status         string
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	// NextAttemptAt is only set while the delivery is pending.
+	NextAttemptAt *time.Time
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time
+}
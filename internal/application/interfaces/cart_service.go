@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+type CartService interface {
+	GetCart(ctx context.Context, cartQuery *query.GetCartQuery) (*query.GetCartQueryResult, error)
+	AddCartItem(ctx context.Context, cartCommand *command.AddCartItemCommand) (*command.AddCartItemCommandResult, error)
+	UpdateCartItem(ctx context.Context, cartCommand *command.UpdateCartItemCommand) (*command.UpdateCartItemCommandResult, error)
+	RemoveCartItem(ctx context.Context, cartCommand *command.RemoveCartItemCommand) (*command.RemoveCartItemCommandResult, error)
+	CheckoutCart(ctx context.Context, cartCommand *command.CheckoutCartCommand) (*command.CheckoutCartCommandResult, error)
+	// DeleteExpiredCarts deletes up to limit expired carts and returns how
+	// many it deleted.
+	DeleteExpiredCarts(ctx context.Context, limit int) (int, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, categoryCommand *command.CreateCategoryCommand) (*command.CreateCategoryCommandResult, error)
+	FindCategories(ctx context.Context, categoryQuery *query.GetCategoriesQuery) (*query.GetCategoriesQueryResult, error)
+	FindCategoryById(ctx context.Context, categoryQuery *query.GetCategoryByIdQuery) (*query.GetCategoryByIdQueryResult, error)
+	UpdateCategory(ctx context.Context, categoryCommand *command.UpdateCategoryCommand) (*command.UpdateCategoryCommandResult, error)
+	DeleteCategory(ctx context.Context, categoryCommand *command.DeleteCategoryCommand) (*command.DeleteCategoryCommandResult, error)
+}
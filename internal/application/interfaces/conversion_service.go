@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type ConversionService interface {
+	// Convert converts amount into currency at the rate in effect at the
+	// given time.
+	Convert(ctx context.Context, amount entities.Money, currency entities.Currency, at time.Time) (*common.ConversionResult, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+// DeadLetterService is the operator view on outbox events the relay gave
+// up on. Replay and Discard fail with entities.ErrDeadLetterNotFound for an
+// event that is not dead-lettered.
+type DeadLetterService interface {
+	FindDeadLetters(ctx context.Context, deadLetterQuery *query.GetDeadLettersQuery) (*query.GetDeadLettersQueryResult, error)
+	ReplayDeadLetter(ctx context.Context, deadLetterCommand *command.ReplayDeadLetterCommand) (*command.ReplayDeadLetterCommandResult, error)
+	DiscardDeadLetter(ctx context.Context, deadLetterCommand *command.DiscardDeadLetterCommand) (*command.DiscardDeadLetterCommandResult, error)
+}
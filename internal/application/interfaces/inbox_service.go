@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// InboxService accepts messages from external systems. Receiving only
+// stores the message; its consumer runs later, exactly once per message.
+type InboxService interface {
+	Receive(ctx context.Context, receiveCommand *command.ReceiveInboxMessageCommand) (*command.ReceiveInboxMessageCommandResult, error)
+}
+
+// InboxConsumer applies one type of external message. Handle runs inside
+// the transaction that marks the message processed, so the repository calls
+// it makes with ctx commit together with that mark or not at all; it must
+// not have side effects outside the database. Errors wrapping
+// entities.ErrValidation are permanent and park the message at once; any
+// other error is retried.
+type InboxConsumer interface {
+	MessageType() string
+	Handle(ctx context.Context, message *entities.InboxMessage) error
+}
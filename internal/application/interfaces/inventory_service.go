@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+type InventoryService interface {
+	GetInventory(ctx context.Context, inventoryQuery *query.GetInventoryQuery) (*query.GetInventoryQueryResult, error)
+	AdjustStock(ctx context.Context, adjustCommand *command.AdjustStockCommand) (*command.AdjustStockCommandResult, error)
+	ReserveStock(ctx context.Context, reserveCommand *command.ReserveStockCommand) (*command.ReserveStockCommandResult, error)
+	ReleaseReservation(ctx context.Context, releaseCommand *command.ReleaseReservationCommand) (*command.ReleaseReservationCommandResult, error)
+	CommitReservation(ctx context.Context, commitCommand *command.CommitReservationCommand) (*command.CommitReservationCommandResult, error)
+	// ReleaseExpiredReservations releases expired reservations of up to
+	// limit products and returns how many reservations it released.
+	ReleaseExpiredReservations(ctx context.Context, limit int) (int, error)
+}
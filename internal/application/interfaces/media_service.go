@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+type MediaService interface {
+	GetProductImages(ctx context.Context, imagesQuery *query.GetProductImagesQuery) (*query.GetProductImagesQueryResult, error)
+	// OpenProductImage returns the image with its data; the caller closes
+	// the result's Body.
+	OpenProductImage(ctx context.Context, imageQuery *query.GetProductImageQuery) (*query.GetProductImageQueryResult, error)
+	UploadProductImage(ctx context.Context, uploadCommand *command.UploadProductImageCommand) (*command.UploadProductImageCommandResult, error)
+	RemoveProductImage(ctx context.Context, removeCommand *command.RemoveProductImageCommand) (*command.RemoveProductImageCommandResult, error)
+	ReorderProductImages(ctx context.Context, reorderCommand *command.ReorderProductImagesCommand) (*command.ReorderProductImagesCommandResult, error)
+	SetPrimaryProductImage(ctx context.Context, primaryCommand *command.SetPrimaryProductImageCommand) (*command.SetPrimaryProductImageCommandResult, error)
+	// PurgeDeletedBlobs deletes up to limit blobs of removed images and
+	// deleted products and returns how many it deleted.
+	PurgeDeletedBlobs(ctx context.Context, limit int) (int, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, orderCommand *command.CreateOrderCommand) (*command.CreateOrderCommandResult, error)
+	PayOrder(ctx context.Context, orderCommand *command.PayOrderCommand) (*command.PayOrderCommandResult, error)
+	ShipOrder(ctx context.Context, orderCommand *command.ShipOrderCommand) (*command.ShipOrderCommandResult, error)
+	DeliverOrder(ctx context.Context, orderCommand *command.DeliverOrderCommand) (*command.DeliverOrderCommandResult, error)
+	CancelOrder(ctx context.Context, orderCommand *command.CancelOrderCommand) (*command.CancelOrderCommandResult, error)
+	RefundOrder(ctx context.Context, orderCommand *command.RefundOrderCommand) (*command.RefundOrderCommandResult, error)
+	FindAllOrders(ctx context.Context, query *query.GetAllOrdersQuery) (*query.GetAllOrdersQueryResult, error)
+	FindOrderById(ctx context.Context, query *query.GetOrderByIdQuery) (*query.GetOrderByIdQueryResult, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+// OutboxReplayService re-emits published events to rebuild downstream
+// consumers. StartOutboxReplay and ResumeOutboxReplay return as soon as the
+// replay runs in the background.
+type OutboxReplayService interface {
+	StartOutboxReplay(ctx context.Context, replayCommand *command.StartOutboxReplayCommand) (*command.StartOutboxReplayCommandResult, error)
+	ResumeOutboxReplay(ctx context.Context, replayCommand *command.ResumeOutboxReplayCommand) (*command.ResumeOutboxReplayCommandResult, error)
+	FindOutboxReplayById(ctx context.Context, replayQuery *query.GetOutboxReplayByIdQuery) (*query.GetOutboxReplayByIdQueryResult, error)
+	FindOutboxReplays(ctx context.Context, replayQuery *query.GetOutboxReplaysQuery) (*query.GetOutboxReplaysQueryResult, error)
+}
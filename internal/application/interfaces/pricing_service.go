@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+type PricingService interface {
+	GetPriceHistory(ctx context.Context, historyQuery *query.GetPriceHistoryQuery) (*query.GetPriceHistoryQueryResult, error)
+	GetLowestPrice(ctx context.Context, lowestQuery *query.GetLowestPriceQuery) (*query.GetLowestPriceQueryResult, error)
+	GetScheduledPriceChanges(ctx context.Context, scheduleQuery *query.GetScheduledPriceChangesQuery) (*query.GetScheduledPriceChangesQueryResult, error)
+	SchedulePriceChange(ctx context.Context, scheduleCommand *command.SchedulePriceChangeCommand) (*command.SchedulePriceChangeCommandResult, error)
+	CancelScheduledPriceChange(ctx context.Context, cancelCommand *command.CancelScheduledPriceChangeCommand) (*command.CancelScheduledPriceChangeCommandResult, error)
+	// ApplyDuePriceChanges applies up to limit scheduled changes that are
+	// due and returns how many it applied.
+	ApplyDuePriceChanges(ctx context.Context, limit int) (int, error)
+}
@@ -10,7 +10,14 @@ import (
 type ProductService interface {
 	CreateProduct(ctx context.Context, productCommand *command.CreateProductCommand) (*command.CreateProductCommandResult, error)
 	UpdateProduct(ctx context.Context, productCommand *command.UpdateProductCommand) (*command.UpdateProductCommandResult, error)
+	PublishProduct(ctx context.Context, productCommand *command.PublishProductCommand) (*command.PublishProductCommandResult, error)
+	ArchiveProduct(ctx context.Context, productCommand *command.ArchiveProductCommand) (*command.ArchiveProductCommandResult, error)
+	CategorizeProduct(ctx context.Context, productCommand *command.CategorizeProductCommand) (*command.CategorizeProductCommandResult, error)
+	AddProductVariant(ctx context.Context, productCommand *command.AddProductVariantCommand) (*command.AddProductVariantCommandResult, error)
+	UpdateProductVariant(ctx context.Context, productCommand *command.UpdateProductVariantCommand) (*command.UpdateProductVariantCommandResult, error)
+	RemoveProductVariant(ctx context.Context, productCommand *command.RemoveProductVariantCommand) (*command.RemoveProductVariantCommandResult, error)
 	DeleteProduct(ctx context.Context, productCommand *command.DeleteProductCommand) (*command.DeleteProductCommandResult, error)
-	FindAllProducts(ctx context.Context) (*query.GetAllProductsQueryResult, error)
+	FindAllProducts(ctx context.Context, query *query.GetAllProductsQuery) (*query.GetAllProductsQueryResult, error)
+	SearchProducts(ctx context.Context, query *query.SearchProductsQuery) (*query.SearchProductsQueryResult, error)
 	FindProductById(ctx context.Context, query *query.GetProductByIdQuery) (*query.GetProductByIdQueryResult, error)
 }
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, promotionCommand *command.CreatePromotionCommand) (*command.CreatePromotionCommandResult, error)
+	EndPromotion(ctx context.Context, promotionCommand *command.EndPromotionCommand) (*command.EndPromotionCommandResult, error)
+	RedeemPromotion(ctx context.Context, promotionCommand *command.RedeemPromotionCommand) (*command.RedeemPromotionCommandResult, error)
+	FindPromotionById(ctx context.Context, promotionQuery *query.GetPromotionByIdQuery) (*query.GetPromotionByIdQueryResult, error)
+	FindPromotionsBySeller(ctx context.Context, promotionQuery *query.GetPromotionsBySellerQuery) (*query.GetPromotionsBySellerQueryResult, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+// WebhookService manages webhook subscriptions. A subscription makes this
+// service POST to an arbitrary URL, so its endpoints are admin-only.
+type WebhookService interface {
+	CreateWebhookSubscription(ctx context.Context, subscriptionCommand *command.CreateWebhookSubscriptionCommand) (*command.CreateWebhookSubscriptionCommandResult, error)
+	FindAllWebhookSubscriptions(ctx context.Context) (*query.GetAllWebhookSubscriptionsQueryResult, error)
+	FindWebhookSubscriptionById(ctx context.Context, subscriptionQuery *query.GetWebhookSubscriptionByIdQuery) (*query.GetWebhookSubscriptionByIdQueryResult, error)
+	DeleteWebhookSubscription(ctx context.Context, subscriptionCommand *command.DeleteWebhookSubscriptionCommand) (*command.DeleteWebhookSubscriptionCommandResult, error)
+	FindWebhookDeliveries(ctx context.Context, deliveryQuery *query.GetWebhookDeliveriesQuery) (*query.GetWebhookDeliveriesQueryResult, error)
+}
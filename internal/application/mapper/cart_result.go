@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func NewCartResultFromEntity(cart *entities.Cart) *common.CartResult {
+	if cart == nil {
+		return nil
+	}
+
+	// Stored carts were validated, so their totals cannot overflow.
+	total, _ := cart.Total()
+	result := &common.CartResult{
+		BuyerId:   cart.BuyerId,
+		Total:     total,
+		UpdatedAt: cart.UpdatedAt,
+		ExpiresAt: cart.ExpiresAt,
+		Version:   cart.Version,
+	}
+	for _, line := range cart.Lines {
+		lineTotal, _ := line.Total()
+		result.Lines = append(result.Lines, common.CartLineResult{
+			ProductId:   line.ProductId,
+			VariantId:   line.VariantId,
+			Sku:         line.Sku,
+			ProductName: line.ProductName,
+			UnitPrice:   line.UnitPrice,
+			Quantity:    line.Quantity,
+			Total:       lineTotal,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,22 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func NewCategoryResultFromEntity(category *entities.Category) *common.CategoryResult {
+	if category == nil {
+		return nil
+	}
+
+	return &common.CategoryResult{
+		Id:          category.Id,
+		Name:        category.Name,
+		ParentId:    category.ParentId,
+		AncestorIds: category.AncestorIds(),
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+		Version:     category.Version,
+	}
+}
@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func NewDeadLetterResultFromEntity(deadLetter *entities.DeadLetter) *common.DeadLetterResult {
+	return &common.DeadLetterResult{
+		Id:             deadLetter.Id,
+		AggregateId:    deadLetter.AggregateId,
+		EventName:      deadLetter.EventName,
+		Payload:        deadLetter.Payload,
+		OccurredAt:     deadLetter.OccurredAt,
+		Attempts:       deadLetter.Attempts,
+		LastError:      deadLetter.LastError,
+		DeadLetteredAt: deadLetter.DeadLetteredAt,
+	}
+}
@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func NewInventoryResultFromEntity(inventory *entities.Inventory) *common.InventoryResult {
+	if inventory == nil {
+		return nil
+	}
+
+	result := &common.InventoryResult{
+		ProductId: inventory.ProductId,
+		VariantId: inventory.VariantId,
+		OnHand:    inventory.OnHand,
+		Reserved:  inventory.Reserved(),
+		Available: inventory.Available(),
+		UpdatedAt: inventory.UpdatedAt,
+		Version:   inventory.Version,
+	}
+	for _, reservation := range inventory.Reservations {
+		result.Reservations = append(result.Reservations, common.ReservationResult{
+			Id:        reservation.Id,
+			Quantity:  reservation.Quantity,
+			ExpiresAt: reservation.ExpiresAt,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func NewOrderResultFromEntity(order *entities.Order) *common.OrderResult {
+	if order == nil {
+		return nil
+	}
+
+	result := &common.OrderResult{
+		Id:             order.Id,
+		BuyerId:        order.BuyerId,
+		Status:         string(order.Status),
+		Totals:         order.Totals(),
+		TrackingNumber: order.TrackingNumber,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+		Version:        order.Version,
+	}
+	for _, item := range order.Items {
+		// Stored orders were validated, so their line totals cannot overflow.
+		total, _ := item.Total()
+		result.Items = append(result.Items, common.OrderItemResult{
+			ProductId:   item.ProductId,
+			VariantId:   item.VariantId,
+			Sku:         item.Sku,
+			ProductName: item.ProductName,
+			UnitPrice:   item.UnitPrice,
+			Quantity:    item.Quantity,
+			Total:       total,
+		})
+	}
+
+	return result
+}
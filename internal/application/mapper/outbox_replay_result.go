@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func NewOutboxReplayResultFromEntity(replay *entities.OutboxReplay) *common.OutboxReplayResult {
+	result := &common.OutboxReplayResult{
+		Id:          replay.Id,
+		Target:      replay.Target,
+		EventName:   replay.Filter.EventName,
+		From:        optionalTime(replay.Filter.From),
+		To:          optionalTime(replay.Filter.To),
+		Status:      string(replay.Status),
+		Total:       replay.Total,
+		Replayed:    replay.Replayed,
+		LastError:   replay.LastError,
+		CreatedAt:   replay.CreatedAt,
+		UpdatedAt:   replay.UpdatedAt,
+		CompletedAt: replay.CompletedAt,
+	}
+	if replay.Filter.AggregateId != uuid.Nil {
+		aggregateId := replay.Filter.AggregateId
+		result.AggregateId = &aggregateId
+	}
+	return result
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
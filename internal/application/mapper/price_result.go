@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func NewPricePointResultFromEntity(point entities.PricePoint) *common.PricePointResult {
+	return &common.PricePointResult{
+		Price:       point.Price,
+		EffectiveAt: point.EffectiveAt,
+	}
+}
+
+func NewScheduledPriceChangeResultFromEntity(change *entities.ScheduledPriceChange) *common.ScheduledPriceChangeResult {
+	if change == nil {
+		return nil
+	}
+
+	return &common.ScheduledPriceChangeResult{
+		Id:          change.Id,
+		ProductId:   change.ProductId,
+		Price:       change.Price,
+		EffectiveAt: change.EffectiveAt,
+		CreatedAt:   change.CreatedAt,
+	}
+}
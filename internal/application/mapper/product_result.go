@@ -14,12 +14,59 @@ func NewProductResultFromEntity(product *entities.Product) *common.ProductResult
 		return nil
 	}
 
+	variants := make([]common.ProductVariantResult, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		variants = append(variants, common.ProductVariantResult{
+			Id:            variant.Id,
+			Sku:           variant.Sku,
+			Attributes:    variant.Attributes,
+			PriceOverride: variant.PriceOverride,
+			Price:         product.VariantPrice(variant),
+		})
+	}
+
 	return &common.ProductResult{
-		Id:        product.Id,
-		Name:      product.Name,
-		Price:     product.Price,
-		SellerId:  product.SellerId,
-		CreatedAt: product.CreatedAt,
-		UpdatedAt: product.UpdatedAt,
+		Id:          product.Id,
+		Name:        product.Name,
+		Price:       product.Price,
+		SellerId:    product.SellerId,
+		Status:      string(product.Status),
+		CategoryIds: product.CategoryIds,
+		Variants:    variants,
+		Images:      NewProductImageResults(product.Images),
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
+	}
+}
+
+func NewProductImagesResultFromEntity(product *entities.Product) *common.ProductImagesResult {
+	if product == nil {
+		return nil
+	}
+
+	return &common.ProductImagesResult{
+		ProductId: product.Id,
+		Images:    NewProductImageResults(product.Images),
+		Version:   product.Version,
+	}
+}
+
+func NewProductImageResults(images []entities.ProductImage) []common.ProductImageResult {
+	results := make([]common.ProductImageResult, 0, len(images))
+	for position, image := range images {
+		results = append(results, NewProductImageResult(image, position))
+	}
+	return results
+}
+
+func NewProductImageResult(image entities.ProductImage, position int) common.ProductImageResult {
+	return common.ProductImageResult{
+		Id:          image.Id,
+		ContentType: image.ContentType,
+		SizeBytes:   image.SizeBytes,
+		Position:    position,
+		Primary:     image.Primary,
+		CreatedAt:   image.CreatedAt,
 	}
 }
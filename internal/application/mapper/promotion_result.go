@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func NewPromotionResultFromEntity(promotion *entities.Promotion) *common.PromotionResult {
+	if promotion == nil {
+		return nil
+	}
+
+	result := &common.PromotionResult{
+		Id:           promotion.Id,
+		SellerId:     promotion.SellerId,
+		Name:         promotion.Name,
+		DiscountKind: string(promotion.Discount.Kind),
+		BasisPoints:  promotion.Discount.BasisPoints,
+		ProductIds:   promotion.ProductIds,
+		StartsAt:     promotion.StartsAt,
+		EndsAt:       promotion.EndsAt,
+		Stackable:    promotion.Stackable,
+		UsageLimit:   promotion.UsageLimit,
+		UsageCount:   promotion.UsageCount,
+		CreatedAt:    promotion.CreatedAt,
+		UpdatedAt:    promotion.UpdatedAt,
+		Version:      promotion.Version,
+	}
+	if promotion.Discount.Kind == entities.DiscountFixedAmount {
+		amount := promotion.Discount.Amount
+		result.Amount = &amount
+	}
+
+	return result
+}
+
+func NewPromotionResultFromValidatedEntity(promotion *entities.ValidatedPromotion) *common.PromotionResult {
+	return NewPromotionResultFromEntity(&promotion.Promotion)
+}
+
+func NewEffectivePriceResult(price entities.Money, applied []*entities.Promotion) *common.EffectivePriceResult {
+	result := &common.EffectivePriceResult{Price: price}
+	for _, promotion := range applied {
+		result.PromotionIds = append(result.PromotionIds, promotion.Id)
+	}
+
+	return result
+}
@@ -37,6 +37,7 @@ func TestNewSellerResultFromValidatedEntity(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, validated.Id, result.Id)
 	assert.Equal(t, "Acme", result.Name)
+	assert.Equal(t, "unverified", result.VerificationStatus)
 }
 
 func TestNewProductResultFromEntity(t *testing.T) {
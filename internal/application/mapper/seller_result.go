@@ -15,9 +15,11 @@ func NewSellerResultFromEntity(seller *entities.Seller) *common.SellerResult {
 	}
 
 	return &common.SellerResult{
-		Id:        seller.Id,
-		Name:      seller.Name,
-		CreatedAt: seller.CreatedAt,
-		UpdatedAt: seller.UpdatedAt,
+		Id:                 seller.Id,
+		Name:               seller.Name,
+		VerificationStatus: string(seller.VerificationStatus),
+		CreatedAt:          seller.CreatedAt,
+		UpdatedAt:          seller.UpdatedAt,
+		Version:            seller.Version,
 	}
 }
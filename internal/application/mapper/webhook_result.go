@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func NewWebhookSubscriptionResultFromEntity(subscription *entities.WebhookSubscription) *common.WebhookSubscriptionResult {
+	return &common.WebhookSubscriptionResult{
+		Id:         subscription.Id,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func NewWebhookDeliveryResultFromEntity(delivery *entities.WebhookDelivery) *common.WebhookDeliveryResult {
+	result := &common.WebhookDeliveryResult{
+		Id:             delivery.Id,
+		EventId:        delivery.EventId,
+		EventName:      delivery.EventName,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == entities.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		result.NextAttemptAt = &nextAttemptAt
+	}
+	return result
+}
@@ -0,0 +1,22 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// GetAllOrdersQuery lists one page of orders, newest first. Cursor is the
+// opaque NextCursor of the previous page; an empty Cursor starts at the
+// first page.
+type GetAllOrdersQuery struct {
+	Cursor string
+	Limit  int
+	// BuyerId restricts the listing to one buyer; uuid.Nil lists all orders.
+	BuyerId uuid.UUID
+}
+
+type GetAllOrdersQueryResult struct {
+	Result []*common.OrderResult
+	// NextCursor fetches the following page; empty on the last page.
+	NextCursor string
+}
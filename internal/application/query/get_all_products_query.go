@@ -0,0 +1,30 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// GetAllProductsQuery lists one page of products. Cursor is the opaque
+// NextCursor of the previous page; an empty Cursor starts at the first page.
+// Zero-valued filters are ignored.
+type GetAllProductsQuery struct {
+	Cursor string
+	Limit  int
+	// SortBy is one of "created_at" (default, newest first), "name" or "price".
+	SortBy string
+
+	// SellerId scopes the listing to one seller, whose products are listed
+	// in every status unless Status narrows them. Without it only published
+	// products are listed.
+	SellerId           uuid.UUID
+	Status             entities.ProductStatus
+	Currency           entities.Currency
+	MinPriceMinorUnits *int64
+	MaxPriceMinorUnits *int64
+	// CategoryId lists the products in the category and its descendants.
+	CategoryId uuid.UUID
+
+	// DisplayCurrency, if set, adds each price converted into it.
+	DisplayCurrency entities.Currency
+}
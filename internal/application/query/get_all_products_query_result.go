@@ -4,4 +4,6 @@ import "github.com/sklinkert/go-ddd/internal/application/common"
 
 type GetAllProductsQueryResult struct {
 	Result []*common.ProductResult
+	// NextCursor fetches the following page; empty on the last page.
+	NextCursor string
 }
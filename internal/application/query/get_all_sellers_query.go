@@ -0,0 +1,10 @@
+package query
+
+// GetAllSellersQuery lists one page of sellers. Cursor is the opaque
+// NextCursor of the previous page; an empty Cursor starts at the first page.
+type GetAllSellersQuery struct {
+	Cursor string
+	Limit  int
+	// SortBy is one of "created_at" (default, newest first) or "name".
+	SortBy string
+}
@@ -0,0 +1,14 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type GetCartQuery struct {
+	BuyerId uuid.UUID
+}
+
+type GetCartQueryResult struct {
+	Result *common.CartResult
+}
@@ -0,0 +1,24 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type GetCategoryByIdQuery struct {
+	Id uuid.UUID
+}
+
+type GetCategoryByIdQueryResult struct {
+	Result *common.CategoryResult
+}
+
+// GetCategoriesQuery lists the direct subcategories of ParentId, or the
+// root categories if ParentId is uuid.Nil.
+type GetCategoriesQuery struct {
+	ParentId uuid.UUID
+}
+
+type GetCategoriesQueryResult struct {
+	Result []*common.CategoryResult
+}
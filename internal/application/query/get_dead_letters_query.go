@@ -0,0 +1,13 @@
+package query
+
+import "github.com/sklinkert/go-ddd/internal/application/common"
+
+// GetDeadLettersQuery lists the oldest dead-lettered outbox events. A zero
+// Limit applies the default page size.
+type GetDeadLettersQuery struct {
+	Limit int
+}
+
+type GetDeadLettersQueryResult struct {
+	Result []*common.DeadLetterResult
+}
@@ -0,0 +1,17 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type GetInventoryQuery struct {
+	ProductId uuid.UUID
+	// VariantId names the product variant; uuid.Nil picks the only variant
+	// of a single-variant product.
+	VariantId uuid.UUID
+}
+
+type GetInventoryQueryResult struct {
+	Result *common.InventoryResult
+}
@@ -0,0 +1,14 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type GetOrderByIdQuery struct {
+	Id uuid.UUID
+}
+
+type GetOrderByIdQueryResult struct {
+	Result *common.OrderResult
+}
@@ -0,0 +1,24 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type GetOutboxReplayByIdQuery struct {
+	Id uuid.UUID
+}
+
+type GetOutboxReplayByIdQueryResult struct {
+	Result *common.OutboxReplayResult
+}
+
+// GetOutboxReplaysQuery lists the newest replays. A zero Limit applies the
+// default page size.
+type GetOutboxReplaysQuery struct {
+	Limit int
+}
+
+type GetOutboxReplaysQueryResult struct {
+	Result []*common.OutboxReplayResult
+}
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+// GetPriceHistoryQuery lists the prices in effect between From and To.
+// A zero To means now; a zero From means 30 days before To.
+type GetPriceHistoryQuery struct {
+	ProductId uuid.UUID
+	From      time.Time
+	To        time.Time
+}
+
+type GetPriceHistoryQueryResult struct {
+	// Result starts with the price in effect at From, oldest first.
+	Result []*common.PricePointResult
+	From   time.Time
+	To     time.Time
+}
+
+// GetLowestPriceQuery asks for the lowest price of the Window before now;
+// zero means 30 days.
+type GetLowestPriceQuery struct {
+	ProductId uuid.UUID
+	Window    time.Duration
+}
+
+type GetLowestPriceQueryResult struct {
+	Result *common.PricePointResult
+	From   time.Time
+	To     time.Time
+}
+
+type GetScheduledPriceChangesQuery struct {
+	ProductId uuid.UUID
+}
+
+type GetScheduledPriceChangesQueryResult struct {
+	Result []*common.ScheduledPriceChangeResult
+}
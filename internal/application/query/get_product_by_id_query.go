@@ -3,10 +3,13 @@ package query
 import (
 	"github.com/google/uuid"
 	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
 )
 
 type GetProductByIdQuery struct {
 	Id uuid.UUID
+	// DisplayCurrency, if set, adds the price converted into it.
+	DisplayCurrency entities.Currency
 }
 
 type GetProductByIdQueryResult struct {
@@ -0,0 +1,27 @@
+package query
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type GetProductImagesQuery struct {
+	ProductId uuid.UUID
+}
+
+type GetProductImagesQueryResult struct {
+	Result *common.ProductImagesResult
+}
+
+type GetProductImageQuery struct {
+	ProductId uuid.UUID
+	ImageId   uuid.UUID
+}
+
+type GetProductImageQueryResult struct {
+	Image *common.ProductImageResult
+	// Body streams the image data; the caller closes it.
+	Body io.ReadCloser
+}
@@ -0,0 +1,22 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type GetPromotionByIdQuery struct {
+	Id uuid.UUID
+}
+
+type GetPromotionByIdQueryResult struct {
+	Result *common.PromotionResult
+}
+
+type GetPromotionsBySellerQuery struct {
+	SellerId uuid.UUID
+}
+
+type GetPromotionsBySellerQueryResult struct {
+	Result []*common.PromotionResult
+}
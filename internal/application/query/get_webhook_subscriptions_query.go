@@ -0,0 +1,29 @@
+package query
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+)
+
+type GetWebhookSubscriptionByIdQuery struct {
+	Id uuid.UUID
+}
+
+type GetWebhookSubscriptionByIdQueryResult struct {
+	Result *common.WebhookSubscriptionResult
+}
+
+type GetAllWebhookSubscriptionsQueryResult struct {
+	Result []*common.WebhookSubscriptionResult
+}
+
+// GetWebhookDeliveriesQuery lists a subscription's most recent deliveries.
+// A zero Limit applies the default page size.
+type GetWebhookDeliveriesQuery struct {
+	SubscriptionId uuid.UUID
+	Limit          int
+}
+
+type GetWebhookDeliveriesQueryResult struct {
+	Result []*common.WebhookDeliveryResult
+}
@@ -0,0 +1,27 @@
+package query
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// SearchProductsQuery searches the published products by free text. Every
+// word of Q has to match a word in the product name or in the name of one
+// of its categories, either stemmed or as a prefix; punctuation is
+// ignored. Cursor is the opaque NextCursor of the previous page of the
+// same search.
+type SearchProductsQuery struct {
+	Q      string
+	Cursor string
+	Limit  int
+
+	// DisplayCurrency, if set, adds each price converted into it.
+	DisplayCurrency entities.Currency
+}
+
+type SearchProductsQueryResult struct {
+	// Result holds the best matches first.
+	Result []*common.ProductSearchResult
+	// NextCursor fetches the following page; empty on the last page.
+	NextCursor string
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// CartService keeps one cart per buyer and turns it into an order at
+// checkout, after comparing every line with the live product and the
+// promotions running then.
+type CartService struct {
+	cartRepository      repositories.CartRepository
+	productRepository   repositories.ProductRepository
+	promotionRepository repositories.PromotionRepository
+	orders              interfaces.OrderService
+	transactor          repositories.Transactor
+	idempotencyRepo     repositories.IdempotencyRepository
+	// cartTTL is how long a cart lives without changes.
+	cartTTL time.Duration
+}
+
+func NewCartService(
+	cartRepository repositories.CartRepository,
+	productRepository repositories.ProductRepository,
+	promotionRepository repositories.PromotionRepository,
+	orders interfaces.OrderService,
+	transactor repositories.Transactor,
+	idempotencyRepo repositories.IdempotencyRepository,
+	cartTTL time.Duration,
+) interfaces.CartService {
+	return &CartService{
+		cartRepository:      cartRepository,
+		productRepository:   productRepository,
+		promotionRepository: promotionRepository,
+		orders:              orders,
+		transactor:          transactor,
+		idempotencyRepo:     idempotencyRepo,
+		cartTTL:             cartTTL,
+	}
+}
+
+// GetCart returns the buyer's cart. A buyer without one, or whose cart
+// expired, gets an empty cart.
+func (s *CartService) GetCart(ctx context.Context, cartQuery *query.GetCartQuery) (*query.GetCartQueryResult, error) {
+	cart, err := s.load(ctx, cartQuery.BuyerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.GetCartQueryResult{Result: mapper.NewCartResultFromEntity(cart)}, nil
+}
+
+func (s *CartService) AddCartItem(ctx context.Context, cartCommand *command.AddCartItemCommand) (*command.AddCartItemCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, cartCommand.IdempotencyKey, cartCommand, func() (*command.AddCartItemCommandResult, error) {
+		product, err := s.productRepository.FindById(ctx, cartCommand.ProductId)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			return nil, entities.ErrProductNotFound
+		}
+
+		now := time.Now()
+		promotions, err := s.promotionRepository.FindActive(ctx, []uuid.UUID{product.SellerId}, now)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := s.modify(ctx, cartCommand.BuyerId, cartCommand.ExpectedVersion, func(cart *entities.Cart) error {
+			return cart.AddItem(product, cartCommand.VariantId, cartCommand.Quantity, promotions, now)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.AddCartItemCommandResult{Result: result}, nil
+	})
+}
+
+func (s *CartService) UpdateCartItem(ctx context.Context, cartCommand *command.UpdateCartItemCommand) (*command.UpdateCartItemCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, cartCommand.IdempotencyKey, cartCommand, func() (*command.UpdateCartItemCommandResult, error) {
+		result, err := s.modify(ctx, cartCommand.BuyerId, cartCommand.ExpectedVersion, func(cart *entities.Cart) error {
+			return cart.SetQuantity(cartCommand.ProductId, cartCommand.VariantId, cartCommand.Quantity)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.UpdateCartItemCommandResult{Result: result}, nil
+	})
+}
+
+func (s *CartService) RemoveCartItem(ctx context.Context, cartCommand *command.RemoveCartItemCommand) (*command.RemoveCartItemCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, cartCommand.IdempotencyKey, cartCommand, func() (*command.RemoveCartItemCommandResult, error) {
+		result, err := s.modify(ctx, cartCommand.BuyerId, cartCommand.ExpectedVersion, func(cart *entities.Cart) error {
+			return cart.RemoveItem(cartCommand.ProductId, cartCommand.VariantId)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.RemoveCartItemCommandResult{Result: result}, nil
+	})
+}
+
+// CheckoutCart places an order for the cart's lines and deletes the cart.
+// Products may have been repriced or deleted, or promotions may have
+// started or ended, since they were added; then the cart is updated
+// instead, nothing is ordered and ErrCartChanged lists the differences, so
+// the buyer never pays a price they did not see. A price that changes
+// between this comparison and the order, e.g. a promotion used up by
+// another checkout, fails the checkout with ErrCartChanged as well.
+func (s *CartService) CheckoutCart(ctx context.Context, cartCommand *command.CheckoutCartCommand) (*command.CheckoutCartCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, cartCommand.IdempotencyKey, cartCommand, func() (*command.CheckoutCartCommandResult, error) {
+		var (
+			order   *common.OrderResult
+			changes []entities.CartChange
+		)
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			cart, err := s.load(ctx, cartCommand.BuyerId)
+			if err != nil {
+				return err
+			}
+			if err := checkExpectedVersion(cartCommand.ExpectedVersion, cart.Version); err != nil {
+				return err
+			}
+			if len(cart.Lines) == 0 {
+				return fmt.Errorf("%w: the cart is empty", entities.ErrValidation)
+			}
+
+			current := make(map[uuid.UUID]*entities.Product, len(cart.Lines))
+			var sellerIds []uuid.UUID
+			for _, line := range cart.Lines {
+				if _, loaded := current[line.ProductId]; loaded {
+					continue
+				}
+				product, err := s.productRepository.FindById(ctx, line.ProductId)
+				if err != nil {
+					return err
+				}
+				current[line.ProductId] = product
+				if product != nil && !slices.Contains(sellerIds, product.SellerId) {
+					sellerIds = append(sellerIds, product.SellerId)
+				}
+			}
+			now := time.Now()
+			promotions, err := s.promotionRepository.FindActive(ctx, sellerIds, now)
+			if err != nil {
+				return err
+			}
+
+			// The updated cart must be committed, so the changes are
+			// reported after the transaction instead of failing it.
+			if changes, err = cart.Revalidate(current, promotions, now); err != nil {
+				return err
+			}
+			if len(changes) > 0 {
+				_, err := s.save(ctx, cart)
+				return err
+			}
+
+			items := make([]command.CreateOrderItem, 0, len(cart.Lines))
+			for _, line := range cart.Lines {
+				items = append(items, command.CreateOrderItem{ProductId: line.ProductId, VariantId: line.VariantId, Quantity: line.Quantity})
+			}
+			created, err := s.orders.CreateOrder(ctx, &command.CreateOrderCommand{BuyerId: cart.BuyerId, Items: items})
+			if err != nil {
+				return err
+			}
+			for _, item := range created.Result.Items {
+				for _, line := range cart.Lines {
+					if line.VariantId == item.VariantId && line.UnitPrice != item.UnitPrice {
+						return fmt.Errorf("%w: %s now costs %s instead of %s", entities.ErrCartChanged, line.ProductName, item.UnitPrice, line.UnitPrice)
+					}
+				}
+			}
+			order = created.Result
+
+			return s.cartRepository.Delete(ctx, cart)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 {
+			descriptions := make([]string, 0, len(changes))
+			for _, change := range changes {
+				descriptions = append(descriptions, change.String())
+			}
+			return nil, fmt.Errorf("%w: %s", entities.ErrCartChanged, strings.Join(descriptions, "; "))
+		}
+
+		return &command.CheckoutCartCommandResult{Result: order}, nil
+	})
+}
+
+func (s *CartService) DeleteExpiredCarts(ctx context.Context, limit int) (int, error) {
+	return s.cartRepository.DeleteExpired(ctx, limit)
+}
+
+// load returns the buyer's cart, a new one if there is none, or the stored
+// one emptied if it expired; an expired cart keeps its version, so the next
+// save overwrites it.
+func (s *CartService) load(ctx context.Context, buyerId uuid.UUID) (*entities.Cart, error) {
+	cart, err := s.cartRepository.FindByBuyerId(ctx, buyerId)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return entities.NewCart(buyerId, s.cartTTL), nil
+	}
+	if cart.IsExpired(time.Now()) {
+		cart.Empty()
+	}
+
+	return cart, nil
+}
+
+func (s *CartService) modify(ctx context.Context, buyerId uuid.UUID, expectedVersion *int, change func(cart *entities.Cart) error) (*common.CartResult, error) {
+	cart, err := s.load(ctx, buyerId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExpectedVersion(expectedVersion, cart.Version); err != nil {
+		return nil, err
+	}
+
+	if err := change(cart); err != nil {
+		return nil, err
+	}
+	saved, err := s.save(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.NewCartResultFromEntity(saved), nil
+}
+
+func (s *CartService) save(ctx context.Context, cart *entities.Cart) (*entities.Cart, error) {
+	cart.Touch(s.cartTTL)
+	validatedCart, err := entities.NewValidatedCart(cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cartRepository.Save(ctx, validatedCart)
+}
+
+// NewCartSweeper periodically deletes expired carts.
+func NewCartSweeper(carts interfaces.CartService, interval time.Duration) *PeriodicJob {
+	return NewPeriodicJob("delete expired carts", interval, func(ctx context.Context) (int, error) {
+		return carts.DeleteExpiredCarts(ctx, 500)
+	})
+}
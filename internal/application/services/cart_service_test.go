@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// MockCartRepository stores copies and checks versions like the real
+// repository.
+type MockCartRepository struct {
+	carts map[uuid.UUID]entities.Cart
+}
+
+func NewMockCartRepository() *MockCartRepository {
+	return &MockCartRepository{carts: make(map[uuid.UUID]entities.Cart)}
+}
+
+func (m *MockCartRepository) FindByBuyerId(ctx context.Context, buyerId uuid.UUID) (*entities.Cart, error) {
+	stored, ok := m.carts[buyerId]
+	if !ok {
+		return nil, nil
+	}
+	stored.Lines = append([]entities.CartLine(nil), stored.Lines...)
+	return &stored, nil
+}
+
+func (m *MockCartRepository) Save(ctx context.Context, cart *entities.ValidatedCart) (*entities.Cart, error) {
+	if m.carts[cart.BuyerId].Version != cart.Version {
+		return nil, entities.ErrVersionConflict
+	}
+	saved := cart.Cart
+	saved.Lines = append([]entities.CartLine(nil), cart.Lines...)
+	saved.Version++
+	m.carts[cart.BuyerId] = saved
+	return m.FindByBuyerId(ctx, cart.BuyerId)
+}
+
+func (m *MockCartRepository) Delete(ctx context.Context, cart *entities.Cart) error {
+	stored, ok := m.carts[cart.BuyerId]
+	if !ok || stored.Version != cart.Version {
+		return entities.ErrVersionConflict
+	}
+	delete(m.carts, cart.BuyerId)
+	return nil
+}
+
+func (m *MockCartRepository) DeleteExpired(ctx context.Context, limit int) (int, error) {
+	deleted := 0
+	for buyerId, cart := range m.carts {
+		if deleted < limit && cart.IsExpired(time.Now()) {
+			delete(m.carts, buyerId)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func addCartItem(t *testing.T, service interfaces.CartService, buyerId uuid.UUID, product *entities.ValidatedProduct, quantity int) {
+	t.Helper()
+	_, err := service.AddCartItem(context.Background(), &command.AddCartItemCommand{BuyerId: buyerId, ProductId: product.Id, Quantity: quantity})
+	require.NoError(t, err)
+}
+
+func TestCartService_AddUpdateRemove(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(NewMockInventoryRepository(productRepo), productRepo, transactor, NewMockIdempotencyRepository())
+	promotionRepo := &MockPromotionRepository{}
+	orderService := NewOrderService(&MockOrderRepository{}, productRepo, promotionRepo, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	cartRepo := NewMockCartRepository()
+	service := NewCartService(cartRepo, productRepo, promotionRepo, orderService, transactor, NewMockIdempotencyRepository(), time.Hour)
+	ctx := context.Background()
+	buyerId := uuid.New()
+	widget := createPublishedProduct(t, productRepo, createPersistedSeller(t, &MockSellerRepository{}), "Widget", 999, entities.USD)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	gadget := createPublishedProduct(t, productRepo, createPersistedSeller(t, &MockSellerRepository{}), "Gadget", 2500, entities.EUR)
+
+	empty, err := service.GetCart(ctx, &query.GetCartQuery{BuyerId: buyerId})
+	require.NoError(t, err)
+	assert.Empty(t, empty.Result.Lines)
+	assert.Zero(t, empty.Result.Version, "nothing is stored until the first change")
+
+	addCartItem(t, service, buyerId, widget, 2)
+	updated, err := service.UpdateCartItem(ctx, &command.UpdateCartItemCommand{BuyerId: buyerId, ProductId: widget.Id, Quantity: 3})
+	require.NoError(t, err)
+	require.Len(t, updated.Result.Lines, 1)
+	assert.Equal(t, "Widget", updated.Result.Lines[0].ProductName)
+	assert.Equal(t, int64(2997), updated.Result.Total.MinorUnits())
+	assert.Equal(t, 2, updated.Result.Version)
+
+	_, err = service.AddCartItem(ctx, &command.AddCartItemCommand{BuyerId: buyerId, ProductId: gadget.Id, Quantity: 1})
+	assert.ErrorIs(t, err, entities.ErrValidation, "gadgets cost EUR, the cart holds USD")
+	_, err = service.AddCartItem(ctx, &command.AddCartItemCommand{BuyerId: buyerId, ProductId: uuid.New(), Quantity: 1})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+
+	stale := 1
+	_, err = service.RemoveCartItem(ctx, &command.RemoveCartItemCommand{BuyerId: buyerId, ProductId: widget.Id, ExpectedVersion: &stale})
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	removed, err := service.RemoveCartItem(ctx, &command.RemoveCartItemCommand{BuyerId: buyerId, ProductId: widget.Id})
+	require.NoError(t, err)
+	assert.Empty(t, removed.Result.Lines)
+	_, err = service.RemoveCartItem(ctx, &command.RemoveCartItemCommand{BuyerId: buyerId, ProductId: widget.Id})
+	assert.ErrorIs(t, err, entities.ErrCartItemNotFound)
+}
+
+func TestCartService_Checkout(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	inventoryRepo := NewMockInventoryRepository(productRepo)
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(inventoryRepo, productRepo, transactor, NewMockIdempotencyRepository())
+	promotionRepo := &MockPromotionRepository{}
+	orderService := NewOrderService(&MockOrderRepository{}, productRepo, promotionRepo, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	cartRepo := NewMockCartRepository()
+	service := NewCartService(cartRepo, productRepo, promotionRepo, orderService, transactor, NewMockIdempotencyRepository(), time.Hour)
+	ctx := context.Background()
+	buyerId := uuid.New()
+	widget := createPublishedProduct(t, productRepo, createPersistedSeller(t, &MockSellerRepository{}), "Widget", 999, entities.USD)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	addCartItem(t, service, buyerId, widget, 2)
+
+	checkedOut, err := service.CheckoutCart(ctx, &command.CheckoutCartCommand{BuyerId: buyerId})
+	require.NoError(t, err)
+	assert.Equal(t, buyerId, checkedOut.Result.BuyerId)
+	assert.Equal(t, string(entities.OrderPending), checkedOut.Result.Status)
+	require.Len(t, checkedOut.Result.Items, 1)
+	assert.Equal(t, 2, checkedOut.Result.Items[0].Quantity)
+	assert.Equal(t, 2, inventoryRepo.load(widget.Variants[0].Id).Reserved())
+	assert.Empty(t, cartRepo.carts, "checkout deletes the cart")
+
+	_, err = service.CheckoutCart(ctx, &command.CheckoutCartCommand{BuyerId: buyerId})
+	assert.ErrorIs(t, err, entities.ErrValidation, "an empty cart cannot be checked out")
+}
+
+func TestCartService_Checkout_RevalidatesPrices(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	orderRepo := &MockOrderRepository{}
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(NewMockInventoryRepository(productRepo), productRepo, transactor, NewMockIdempotencyRepository())
+	promotionRepo := &MockPromotionRepository{}
+	orderService := NewOrderService(orderRepo, productRepo, promotionRepo, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	service := NewCartService(NewMockCartRepository(), productRepo, promotionRepo, orderService, transactor, NewMockIdempotencyRepository(), time.Hour)
+	ctx := context.Background()
+	buyerId := uuid.New()
+	widget := createPublishedProduct(t, productRepo, createPersistedSeller(t, &MockSellerRepository{}), "Widget", 999, entities.USD)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	addCartItem(t, service, buyerId, widget, 1)
+
+	repriced, err := entities.NewMoney(1299, entities.USD)
+	require.NoError(t, err)
+	widget.Price = repriced
+
+	_, err = service.CheckoutCart(ctx, &command.CheckoutCartCommand{BuyerId: buyerId})
+	require.ErrorIs(t, err, entities.ErrCartChanged)
+	assert.Contains(t, err.Error(), "Widget now costs 12.99 USD instead of 9.99 USD")
+	assert.Empty(t, orderRepo.orders, "nothing is ordered at a price the buyer did not see")
+
+	cart, err := service.GetCart(ctx, &query.GetCartQuery{BuyerId: buyerId})
+	require.NoError(t, err)
+	assert.Equal(t, repriced, cart.Result.Lines[0].UnitPrice, "the cart shows the new price")
+
+	_, err = service.CheckoutCart(ctx, &command.CheckoutCartCommand{BuyerId: buyerId})
+	require.NoError(t, err, "the second attempt accepts the reviewed cart")
+	assert.Equal(t, repriced, orderRepo.orders[0].Items[0].UnitPrice)
+}
+
+func TestCartService_Checkout_PricesWithPromotions(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	orderRepo := &MockOrderRepository{}
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(NewMockInventoryRepository(productRepo), productRepo, transactor, NewMockIdempotencyRepository())
+	promotionRepo := &MockPromotionRepository{}
+	orderService := NewOrderService(orderRepo, productRepo, promotionRepo, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	service := NewCartService(NewMockCartRepository(), productRepo, promotionRepo, orderService, transactor, NewMockIdempotencyRepository(), time.Hour)
+	ctx := context.Background()
+	seller := createPersistedSeller(t, &MockSellerRepository{})
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 999, entities.USD)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	promotion, err := entities.NewValidatedPromotion(entities.NewPromotion(seller.Id, "Spring sale", entities.NewPercentageDiscount(2000), entities.PromotionTerms{
+		StartsAt:   time.Now().Add(-time.Minute),
+		UsageLimit: 1,
+	}))
+	require.NoError(t, err)
+	_, err = promotionRepo.Create(ctx, promotion)
+	require.NoError(t, err)
+
+	first, second := uuid.New(), uuid.New()
+	addCartItem(t, service, first, widget, 1)
+	addCartItem(t, service, second, widget, 1)
+	cart, err := service.GetCart(ctx, &query.GetCartQuery{BuyerId: second})
+	require.NoError(t, err)
+	assert.Equal(t, int64(799), cart.Result.Lines[0].UnitPrice.MinorUnits(), "the cart shows the discounted price")
+
+	checkedOut, err := service.CheckoutCart(ctx, &command.CheckoutCartCommand{BuyerId: first})
+	require.NoError(t, err)
+	assert.Equal(t, int64(799), checkedOut.Result.Items[0].UnitPrice.MinorUnits(), "and checkout charges it")
+
+	// The first checkout used up the only redemption.
+	_, err = service.CheckoutCart(ctx, &command.CheckoutCartCommand{BuyerId: second})
+	require.ErrorIs(t, err, entities.ErrCartChanged)
+	assert.Contains(t, err.Error(), "Widget now costs 9.99 USD instead of 7.99 USD")
+	assert.Len(t, orderRepo.orders, 1)
+}
+
+func TestCartService_Checkout_RemovesDeletedProducts(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(NewMockInventoryRepository(productRepo), productRepo, transactor, NewMockIdempotencyRepository())
+	promotionRepo := &MockPromotionRepository{}
+	orderService := NewOrderService(&MockOrderRepository{}, productRepo, promotionRepo, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	cartRepo := NewMockCartRepository()
+	service := NewCartService(cartRepo, productRepo, promotionRepo, orderService, transactor, NewMockIdempotencyRepository(), time.Hour)
+	ctx := context.Background()
+	buyerId := uuid.New()
+	widget := createPublishedProduct(t, productRepo, createPersistedSeller(t, &MockSellerRepository{}), "Widget", 999, entities.USD)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	addCartItem(t, service, buyerId, widget, 1)
+	require.NoError(t, productRepo.Delete(ctx, &widget.Product))
+
+	_, err := service.CheckoutCart(ctx, &command.CheckoutCartCommand{BuyerId: buyerId})
+	require.ErrorIs(t, err, entities.ErrCartChanged)
+	assert.Contains(t, err.Error(), "Widget is no longer available")
+
+	cart, err := service.GetCart(ctx, &query.GetCartQuery{BuyerId: buyerId})
+	require.NoError(t, err)
+	assert.Empty(t, cart.Result.Lines)
+}
+
+func TestCartService_Expiry(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(NewMockInventoryRepository(productRepo), productRepo, transactor, NewMockIdempotencyRepository())
+	promotionRepo := &MockPromotionRepository{}
+	orderService := NewOrderService(&MockOrderRepository{}, productRepo, promotionRepo, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	cartRepo := NewMockCartRepository()
+	service := NewCartService(cartRepo, productRepo, promotionRepo, orderService, transactor, NewMockIdempotencyRepository(), time.Hour)
+	ctx := context.Background()
+	buyerId := uuid.New()
+	widget := createPublishedProduct(t, productRepo, createPersistedSeller(t, &MockSellerRepository{}), "Widget", 999, entities.USD)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	addCartItem(t, service, buyerId, widget, 1)
+
+	stored := cartRepo.carts[buyerId]
+	stored.ExpiresAt = time.Now().Add(-time.Second)
+	cartRepo.carts[buyerId] = stored
+
+	cart, err := service.GetCart(ctx, &query.GetCartQuery{BuyerId: buyerId})
+	require.NoError(t, err)
+	assert.Empty(t, cart.Result.Lines, "expired carts count as empty")
+
+	deleted, err := service.DeleteExpiredCarts(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.Empty(t, cartRepo.carts)
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+type CategoryService struct {
+	repo            repositories.CategoryRepository
+	idempotencyRepo repositories.IdempotencyRepository
+}
+
+func NewCategoryService(repo repositories.CategoryRepository, idempotencyRepo repositories.IdempotencyRepository) interfaces.CategoryService {
+	return &CategoryService{
+		repo:            repo,
+		idempotencyRepo: idempotencyRepo,
+	}
+}
+
+func (s *CategoryService) CreateCategory(ctx context.Context, categoryCommand *command.CreateCategoryCommand) (*command.CreateCategoryCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, categoryCommand.IdempotencyKey, categoryCommand, func() (*command.CreateCategoryCommandResult, error) {
+		parent, err := s.findParent(ctx, categoryCommand.ParentId)
+		if err != nil {
+			return nil, err
+		}
+
+		validatedCategory, err := entities.NewValidatedCategory(entities.NewCategory(categoryCommand.Name, parent))
+		if err != nil {
+			return nil, err
+		}
+
+		createdCategory, err := s.repo.Create(ctx, validatedCategory)
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.CreateCategoryCommandResult{
+			Result: mapper.NewCategoryResultFromEntity(createdCategory),
+		}, nil
+	})
+}
+
+// FindCategories lists one level of the taxonomy.
+func (s *CategoryService) FindCategories(ctx context.Context, categoryQuery *query.GetCategoriesQuery) (*query.GetCategoriesQueryResult, error) {
+	categories, err := s.repo.FindChildren(ctx, categoryQuery.ParentId)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResult query.GetCategoriesQueryResult
+	for _, category := range categories {
+		queryResult.Result = append(queryResult.Result, mapper.NewCategoryResultFromEntity(category))
+	}
+
+	return &queryResult, nil
+}
+
+func (s *CategoryService) FindCategoryById(ctx context.Context, categoryQuery *query.GetCategoryByIdQuery) (*query.GetCategoryByIdQueryResult, error) {
+	category, err := s.repo.FindById(ctx, categoryQuery.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Not found: let the caller translate this into a 404.
+	if category == nil {
+		return nil, nil
+	}
+
+	return &query.GetCategoryByIdQueryResult{Result: mapper.NewCategoryResultFromEntity(category)}, nil
+}
+
+func (s *CategoryService) UpdateCategory(ctx context.Context, categoryCommand *command.UpdateCategoryCommand) (*command.UpdateCategoryCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, categoryCommand.IdempotencyKey, categoryCommand, func() (*command.UpdateCategoryCommandResult, error) {
+		category, err := s.repo.FindById(ctx, categoryCommand.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		if category == nil {
+			return nil, entities.ErrCategoryNotFound
+		}
+
+		if err := checkExpectedVersion(categoryCommand.ExpectedVersion, category.Version); err != nil {
+			return nil, err
+		}
+
+		if err := category.Rename(categoryCommand.Name); err != nil {
+			return nil, err
+		}
+
+		parent, err := s.findParent(ctx, categoryCommand.ParentId)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := category.MoveTo(parent); err != nil {
+			return nil, err
+		}
+
+		validatedCategory, err := entities.NewValidatedCategory(category)
+		if err != nil {
+			return nil, err
+		}
+
+		updatedCategory, err := s.repo.Update(ctx, validatedCategory)
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.UpdateCategoryCommandResult{
+			Result: mapper.NewCategoryResultFromEntity(updatedCategory),
+		}, nil
+	})
+}
+
+func (s *CategoryService) DeleteCategory(ctx context.Context, categoryCommand *command.DeleteCategoryCommand) (*command.DeleteCategoryCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, categoryCommand.IdempotencyKey, categoryCommand, func() (*command.DeleteCategoryCommandResult, error) {
+		category, err := s.repo.FindById(ctx, categoryCommand.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		if category == nil {
+			return nil, entities.ErrCategoryNotFound
+		}
+
+		if err := checkExpectedVersion(categoryCommand.ExpectedVersion, category.Version); err != nil {
+			return nil, err
+		}
+
+		category.Delete()
+
+		if err := s.repo.Delete(ctx, category); err != nil {
+			return nil, err
+		}
+
+		return &command.DeleteCategoryCommandResult{Success: true}, nil
+	})
+}
+
+// findParent loads the parent a category is placed below; a nil id is the
+// root level.
+func (s *CategoryService) findParent(ctx context.Context, parentId *uuid.UUID) (*entities.Category, error) {
+	if parentId == nil {
+		return nil, nil
+	}
+
+	parent, err := s.repo.FindById(ctx, *parentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent == nil {
+		return nil, entities.ErrCategoryNotFound
+	}
+
+	return parent, nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// MockCategoryRepository keeps categories in memory. Unlike the real
+// repository it does not rewrite the paths of a moved category's
+// descendants.
+type MockCategoryRepository struct {
+	categories []*entities.Category
+	// nonEmpty marks categories that Delete refuses.
+	nonEmpty map[uuid.UUID]bool
+}
+
+func (m *MockCategoryRepository) Create(ctx context.Context, category *entities.ValidatedCategory) (*entities.Category, error) {
+	stored := category.Category
+	m.categories = append(m.categories, &stored)
+	created := stored
+	return &created, nil
+}
+
+func (m *MockCategoryRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	for _, category := range m.categories {
+		if category.Id == id {
+			found := *category
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCategoryRepository) FindChildren(ctx context.Context, parentId uuid.UUID) ([]*entities.Category, error) {
+	var children []*entities.Category
+	for _, category := range m.categories {
+		if (category.ParentId == nil && parentId == uuid.Nil) || (category.ParentId != nil && *category.ParentId == parentId) {
+			children = append(children, category)
+		}
+	}
+	return children, nil
+}
+
+func (m *MockCategoryRepository) Update(ctx context.Context, category *entities.ValidatedCategory) (*entities.Category, error) {
+	for index, stored := range m.categories {
+		if stored.Id != category.Id {
+			continue
+		}
+		if stored.Version != category.Version {
+			return nil, entities.ErrVersionConflict
+		}
+		updated := category.Category
+		updated.Version++
+		m.categories[index] = &updated
+		result := updated
+		return &result, nil
+	}
+	return nil, entities.ErrCategoryNotFound
+}
+
+func (m *MockCategoryRepository) Delete(ctx context.Context, category *entities.Category) error {
+	if m.nonEmpty[category.Id] {
+		return entities.ErrCategoryNotEmpty
+	}
+	for index, stored := range m.categories {
+		if stored.Id == category.Id {
+			m.categories = append(m.categories[:index], m.categories[index+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func TestCategoryService_CreateAndFind(t *testing.T) {
+	repo := &MockCategoryRepository{}
+	service := NewCategoryService(repo, NewMockIdempotencyRepository())
+	ctx := context.Background()
+
+	furniture, err := service.CreateCategory(ctx, &command.CreateCategoryCommand{Name: "Furniture"})
+	require.NoError(t, err)
+	assert.Nil(t, furniture.Result.ParentId)
+	assert.Empty(t, furniture.Result.AncestorIds)
+
+	chairs, err := service.CreateCategory(ctx, &command.CreateCategoryCommand{Name: "Chairs", ParentId: &furniture.Result.Id})
+	require.NoError(t, err)
+	assert.Equal(t, furniture.Result.Id, *chairs.Result.ParentId)
+	assert.Equal(t, []uuid.UUID{furniture.Result.Id}, chairs.Result.AncestorIds)
+
+	unknown := uuid.New()
+	_, err = service.CreateCategory(ctx, &command.CreateCategoryCommand{Name: "Orphan", ParentId: &unknown})
+	assert.ErrorIs(t, err, entities.ErrCategoryNotFound)
+	_, err = service.CreateCategory(ctx, &command.CreateCategoryCommand{Name: ""})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+
+	roots, err := service.FindCategories(ctx, &query.GetCategoriesQuery{})
+	require.NoError(t, err)
+	require.Len(t, roots.Result, 1)
+	assert.Equal(t, "Furniture", roots.Result[0].Name)
+
+	children, err := service.FindCategories(ctx, &query.GetCategoriesQuery{ParentId: furniture.Result.Id})
+	require.NoError(t, err)
+	require.Len(t, children.Result, 1)
+	assert.Equal(t, "Chairs", children.Result[0].Name)
+
+	found, err := service.FindCategoryById(ctx, &query.GetCategoryByIdQuery{Id: chairs.Result.Id})
+	require.NoError(t, err)
+	assert.Equal(t, "Chairs", found.Result.Name)
+
+	missing, err := service.FindCategoryById(ctx, &query.GetCategoryByIdQuery{Id: uuid.New()})
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestCategoryService_UpdateCategory(t *testing.T) {
+	repo := &MockCategoryRepository{}
+	service := NewCategoryService(repo, NewMockIdempotencyRepository())
+	ctx := context.Background()
+
+	furniture, err := service.CreateCategory(ctx, &command.CreateCategoryCommand{Name: "Furniture"})
+	require.NoError(t, err)
+	garden, err := service.CreateCategory(ctx, &command.CreateCategoryCommand{Name: "Garden"})
+	require.NoError(t, err)
+	chairs, err := service.CreateCategory(ctx, &command.CreateCategoryCommand{Name: "Chairs", ParentId: &furniture.Result.Id})
+	require.NoError(t, err)
+
+	stale := 0
+	_, err = service.UpdateCategory(ctx, &command.UpdateCategoryCommand{Id: chairs.Result.Id, Name: "Chairs", ExpectedVersion: &stale})
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	moved, err := service.UpdateCategory(ctx, &command.UpdateCategoryCommand{
+		Id:              chairs.Result.Id,
+		Name:            "Garden chairs",
+		ParentId:        &garden.Result.Id,
+		ExpectedVersion: &chairs.Result.Version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Garden chairs", moved.Result.Name)
+	assert.Equal(t, []uuid.UUID{garden.Result.Id}, moved.Result.AncestorIds)
+	assert.Equal(t, 2, moved.Result.Version)
+
+	_, err = service.UpdateCategory(ctx, &command.UpdateCategoryCommand{Id: garden.Result.Id, Name: "Garden", ParentId: &chairs.Result.Id})
+	assert.ErrorIs(t, err, entities.ErrCategoryCycle)
+
+	rooted, err := service.UpdateCategory(ctx, &command.UpdateCategoryCommand{Id: chairs.Result.Id, Name: "Chairs"})
+	require.NoError(t, err)
+	assert.Nil(t, rooted.Result.ParentId, "no parent moves the category to the root level")
+
+	_, err = service.UpdateCategory(ctx, &command.UpdateCategoryCommand{Id: uuid.New(), Name: "Nothing"})
+	assert.ErrorIs(t, err, entities.ErrCategoryNotFound)
+}
+
+func TestCategoryService_DeleteCategory(t *testing.T) {
+	repo := &MockCategoryRepository{nonEmpty: map[uuid.UUID]bool{}}
+	service := NewCategoryService(repo, NewMockIdempotencyRepository())
+	ctx := context.Background()
+
+	furniture, err := service.CreateCategory(ctx, &command.CreateCategoryCommand{Name: "Furniture"})
+	require.NoError(t, err)
+
+	repo.nonEmpty[furniture.Result.Id] = true
+	_, err = service.DeleteCategory(ctx, &command.DeleteCategoryCommand{Id: furniture.Result.Id})
+	assert.ErrorIs(t, err, entities.ErrCategoryNotEmpty)
+
+	repo.nonEmpty[furniture.Result.Id] = false
+	deleted, err := service.DeleteCategory(ctx, &command.DeleteCategoryCommand{Id: furniture.Result.Id, ExpectedVersion: &furniture.Result.Version})
+	require.NoError(t, err)
+	assert.True(t, deleted.Success)
+
+	_, err = service.DeleteCategory(ctx, &command.DeleteCategoryCommand{Id: furniture.Result.Id})
+	assert.ErrorIs(t, err, entities.ErrCategoryNotFound)
+}
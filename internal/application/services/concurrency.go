@@ -0,0 +1,20 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// checkExpectedVersion rejects a command whose caller read an older (or
+// newer) version of the aggregate than the one currently stored. A nil
+// expectation means the caller did not ask for a conditional write. The
+// repository re-checks the version atomically on write, so this early check
+// only avoids pointless work; it is not the concurrency guard itself.
+func checkExpectedVersion(expected *int, actual int) error {
+	if expected != nil && *expected != actual {
+		return fmt.Errorf("%w: expected version %d, current version is %d", entities.ErrVersionConflict, *expected, actual)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// ConversionService converts Money between currencies for display. It never
+// changes what is stored: prices stay in the seller's currency, and orders
+// are still charged in it.
+type ConversionService struct {
+	rates repositories.ExchangeRateProvider
+}
+
+func NewConversionService(rates repositories.ExchangeRateProvider) interfaces.ConversionService {
+	return &ConversionService{rates: rates}
+}
+
+func (s *ConversionService) Convert(ctx context.Context, amount entities.Money, currency entities.Currency, at time.Time) (*common.ConversionResult, error) {
+	if _, ok := currency.Exponent(); !ok {
+		return nil, fmt.Errorf("%w: unsupported currency %q", entities.ErrValidation, currency)
+	}
+	if amount.Currency() == currency {
+		return &common.ConversionResult{Amount: amount}, nil
+	}
+
+	rate, err := s.rates.Rate(ctx, amount.Currency(), currency, at)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := rate.Convert(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.ConversionResult{Amount: converted, Rate: &rate}, nil
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// MockExchangeRateProvider serves the latest of its rates that took effect
+// by the requested time and counts lookups.
+type MockExchangeRateProvider struct {
+	rates   []entities.ExchangeRate
+	lookups int
+}
+
+func (m *MockExchangeRateProvider) Rate(ctx context.Context, from, to entities.Currency, at time.Time) (entities.ExchangeRate, error) {
+	m.lookups++
+	var found *entities.ExchangeRate
+	for i, rate := range m.rates {
+		if rate.From() == from && rate.To() == to && !rate.EffectiveAt().After(at) &&
+			(found == nil || rate.EffectiveAt().After(found.EffectiveAt())) {
+			found = &m.rates[i]
+		}
+	}
+	if found == nil {
+		return entities.ExchangeRate{}, fmt.Errorf("%w: %s to %s", entities.ErrExchangeRateNotFound, from, to)
+	}
+	return *found, nil
+}
+
+func newMockExchangeRateProvider(t *testing.T, rates ...[3]string) *MockExchangeRateProvider {
+	t.Helper()
+	provider := &MockExchangeRateProvider{}
+	for _, rate := range rates {
+		exchangeRate, err := entities.NewExchangeRate(entities.Currency(rate[0]), entities.Currency(rate[1]), rate[2], time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		provider.rates = append(provider.rates, exchangeRate)
+	}
+	return provider
+}
+
+func TestConversionService_Convert(t *testing.T) {
+	provider := newMockExchangeRateProvider(t, [3]string{"USD", "JPY", "151.25"})
+	service := NewConversionService(provider)
+	price, err := entities.NewMoney(1999, entities.USD)
+	require.NoError(t, err)
+
+	result, err := service.Convert(context.Background(), price, entities.JPY, time.Now())
+	require.NoError(t, err)
+
+	// 19.99 USD * 151.25 = 3023.4875 JPY, which has no minor unit.
+	expected, err := entities.NewMoney(3023, entities.JPY)
+	require.NoError(t, err)
+	assert.Equal(t, expected, result.Amount)
+	require.NotNil(t, result.Rate)
+	assert.Equal(t, "151.25", result.Rate.Rate())
+}
+
+func TestConversionService_Convert_SameCurrency(t *testing.T) {
+	provider := &MockExchangeRateProvider{}
+	service := NewConversionService(provider)
+	price, err := entities.NewMoney(1999, entities.USD)
+	require.NoError(t, err)
+
+	result, err := service.Convert(context.Background(), price, entities.USD, time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, price, result.Amount)
+	assert.Nil(t, result.Rate)
+	assert.Zero(t, provider.lookups)
+}
+
+func TestConversionService_Convert_Errors(t *testing.T) {
+	service := NewConversionService(&MockExchangeRateProvider{})
+	price, err := entities.NewMoney(1999, entities.USD)
+	require.NoError(t, err)
+
+	_, err = service.Convert(context.Background(), price, entities.EUR, time.Now())
+	assert.ErrorIs(t, err, entities.ErrExchangeRateNotFound)
+
+	_, err = service.Convert(context.Background(), price, "XXX", time.Now())
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
+
+func TestProductService_DisplayCurrency(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	provider := newMockExchangeRateProvider(t, [3]string{"USD", "EUR", "0.92"})
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(provider))
+	ctx := context.Background()
+
+	seller := createPersistedSeller(t, sellerRepo)
+	created, err := service.CreateProduct(ctx, getCreateProductCommand("Example1", 10000, seller.Id))
+	require.NoError(t, err)
+	_, err = service.CreateProduct(ctx, getCreateProductCommand("Example2", 2500, seller.Id))
+	require.NoError(t, err)
+
+	products, err := service.FindAllProducts(ctx, &query.GetAllProductsQuery{DisplayCurrency: entities.EUR})
+	require.NoError(t, err)
+	require.Len(t, products.Result, 2)
+	assert.Equal(t, int64(9200), products.Result[0].DisplayPrice.Amount.MinorUnits())
+	assert.Equal(t, int64(2300), products.Result[1].DisplayPrice.Amount.MinorUnits())
+	assert.Equal(t, entities.EUR, products.Result[1].DisplayPrice.Amount.Currency())
+	// Stored prices are untouched.
+	assert.Equal(t, entities.USD, products.Result[0].Price.Currency())
+	// One rate per source currency and page.
+	assert.Equal(t, 1, provider.lookups)
+
+	product, err := service.FindProductById(ctx, &query.GetProductByIdQuery{Id: created.Result.Id, DisplayCurrency: entities.EUR})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9200), product.Result.DisplayPrice.Amount.MinorUnits())
+	assert.Equal(t, "0.92", product.Result.DisplayPrice.Rate.Rate())
+
+	product, err = service.FindProductById(ctx, &query.GetProductByIdQuery{Id: created.Result.Id})
+	require.NoError(t, err)
+	assert.Nil(t, product.Result.DisplayPrice)
+
+	_, err = service.FindAllProducts(ctx, &query.GetAllProductsQuery{DisplayCurrency: entities.JPY})
+	assert.ErrorIs(t, err, entities.ErrExchangeRateNotFound)
+}
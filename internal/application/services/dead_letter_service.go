@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+const (
+	defaultDeadLetterPageSize = 50
+	maxDeadLetterPageSize     = 500
+)
+
+type DeadLetterService struct {
+	repo repositories.DeadLetterRepository
+}
+
+func NewDeadLetterService(repo repositories.DeadLetterRepository) interfaces.DeadLetterService {
+	return &DeadLetterService{repo: repo}
+}
+
+// FindDeadLetters returns the oldest dead letters, at most
+// maxDeadLetterPageSize of them.
+func (s *DeadLetterService) FindDeadLetters(ctx context.Context, deadLetterQuery *query.GetDeadLettersQuery) (*query.GetDeadLettersQueryResult, error) {
+	limit := deadLetterQuery.Limit
+	switch {
+	case limit < 0:
+		return nil, fmt.Errorf("%w: limit must not be negative", entities.ErrValidation)
+	case limit == 0:
+		limit = defaultDeadLetterPageSize
+	case limit > maxDeadLetterPageSize:
+		limit = maxDeadLetterPageSize
+	}
+
+	deadLetters, err := s.repo.FindAll(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.GetDeadLettersQueryResult{Result: make([]*common.DeadLetterResult, 0, len(deadLetters))}
+	for _, deadLetter := range deadLetters {
+		queryResult.Result = append(queryResult.Result, mapper.NewDeadLetterResultFromEntity(deadLetter))
+	}
+
+	return &queryResult, nil
+}
+
+func (s *DeadLetterService) ReplayDeadLetter(ctx context.Context, deadLetterCommand *command.ReplayDeadLetterCommand) (*command.ReplayDeadLetterCommandResult, error) {
+	if err := s.repo.Replay(ctx, deadLetterCommand.Id); err != nil {
+		return nil, err
+	}
+	return &command.ReplayDeadLetterCommandResult{Success: true}, nil
+}
+
+func (s *DeadLetterService) DiscardDeadLetter(ctx context.Context, deadLetterCommand *command.DiscardDeadLetterCommand) (*command.DiscardDeadLetterCommandResult, error) {
+	if err := s.repo.Discard(ctx, deadLetterCommand.Id); err != nil {
+		return nil, err
+	}
+	return &command.DiscardDeadLetterCommandResult{Success: true}, nil
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// MockDeadLetterRepository holds dead letters oldest first and records the
+// limit of the last FindAll.
+type MockDeadLetterRepository struct {
+	deadLetters []*entities.DeadLetter
+	replayed    []uuid.UUID
+	limit       int
+}
+
+func (m *MockDeadLetterRepository) FindAll(ctx context.Context, limit int) ([]*entities.DeadLetter, error) {
+	m.limit = limit
+	return m.deadLetters[:min(limit, len(m.deadLetters))], nil
+}
+
+func (m *MockDeadLetterRepository) Replay(ctx context.Context, id uuid.UUID) error {
+	if err := m.remove(id); err != nil {
+		return err
+	}
+	m.replayed = append(m.replayed, id)
+	return nil
+}
+
+func (m *MockDeadLetterRepository) Discard(ctx context.Context, id uuid.UUID) error {
+	return m.remove(id)
+}
+
+func (m *MockDeadLetterRepository) remove(id uuid.UUID) error {
+	for index, deadLetter := range m.deadLetters {
+		if deadLetter.Id == id {
+			m.deadLetters = append(m.deadLetters[:index], m.deadLetters[index+1:]...)
+			return nil
+		}
+	}
+	return entities.ErrDeadLetterNotFound
+}
+
+func TestDeadLetterService_FindDeadLetters(t *testing.T) {
+	repo := &MockDeadLetterRepository{deadLetters: []*entities.DeadLetter{
+		{Id: uuid.New(), EventName: "product.created", Attempts: 10, LastError: "broker unavailable"},
+	}}
+	service := NewDeadLetterService(repo)
+	ctx := context.Background()
+
+	result, err := service.FindDeadLetters(ctx, &query.GetDeadLettersQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultDeadLetterPageSize, repo.limit)
+	require.Len(t, result.Result, 1)
+	assert.Equal(t, "product.created", result.Result[0].EventName)
+	assert.Equal(t, "broker unavailable", result.Result[0].LastError)
+
+	_, err = service.FindDeadLetters(ctx, &query.GetDeadLettersQuery{Limit: 10000})
+	require.NoError(t, err)
+	assert.Equal(t, maxDeadLetterPageSize, repo.limit)
+
+	_, err = service.FindDeadLetters(ctx, &query.GetDeadLettersQuery{Limit: -1})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
+
+func TestDeadLetterService_ReplayAndDiscard(t *testing.T) {
+	replayed, discarded := uuid.New(), uuid.New()
+	repo := &MockDeadLetterRepository{deadLetters: []*entities.DeadLetter{{Id: replayed}, {Id: discarded}}}
+	service := NewDeadLetterService(repo)
+	ctx := context.Background()
+
+	_, err := service.ReplayDeadLetter(ctx, &command.ReplayDeadLetterCommand{Id: replayed})
+	require.NoError(t, err)
+	_, err = service.DiscardDeadLetter(ctx, &command.DiscardDeadLetterCommand{Id: discarded})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{replayed}, repo.replayed)
+	assert.Empty(t, repo.deadLetters)
+
+	_, err = service.ReplayDeadLetter(ctx, &command.ReplayDeadLetterCommand{Id: discarded})
+	assert.ErrorIs(t, err, entities.ErrDeadLetterNotFound)
+}
@@ -15,7 +15,7 @@ import (
 // --- Product service: error paths ---
 
 func TestProductService_CreateProduct_SellerNotFound(t *testing.T) {
-	service := NewProductService(&MockProductRepository{}, &MockSellerRepository{}, NewMockIdempotencyRepository())
+	service := NewProductService(&MockProductRepository{}, &MockSellerRepository{}, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	_, err := service.CreateProduct(context.Background(), &command.CreateProductCommand{
 		Name:            "Widget",
@@ -30,7 +30,7 @@ func TestProductService_CreateProduct_SellerNotFound(t *testing.T) {
 
 func TestProductService_CreateProduct_InvalidCurrency(t *testing.T) {
 	sellerRepo := &MockSellerRepository{}
-	service := NewProductService(&MockProductRepository{}, sellerRepo, NewMockIdempotencyRepository())
+	service := NewProductService(&MockProductRepository{}, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	seller := createPersistedSeller(t, sellerRepo)
 
@@ -46,7 +46,7 @@ func TestProductService_CreateProduct_InvalidCurrency(t *testing.T) {
 }
 
 func TestProductService_UpdateProduct_NotFound(t *testing.T) {
-	service := NewProductService(&MockProductRepository{}, &MockSellerRepository{}, NewMockIdempotencyRepository())
+	service := NewProductService(&MockProductRepository{}, &MockSellerRepository{}, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	_, err := service.UpdateProduct(context.Background(), &command.UpdateProductCommand{
 		Id:              uuid.New(),
@@ -62,7 +62,7 @@ func TestProductService_UpdateProduct_NotFound(t *testing.T) {
 func TestProductService_UpdateProduct_ValidationError(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
-	service := NewProductService(productRepo, sellerRepo, NewMockIdempotencyRepository())
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	seller := createPersistedSeller(t, sellerRepo)
 	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, seller.Id))
@@ -84,7 +84,7 @@ func TestProductService_UpdateProduct_ValidationError(t *testing.T) {
 func TestProductService_UpdateProduct_Success(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
-	service := NewProductService(productRepo, sellerRepo, NewMockIdempotencyRepository())
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	seller := createPersistedSeller(t, sellerRepo)
 	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, seller.Id))
@@ -106,7 +106,7 @@ func TestProductService_UpdateProduct_Success(t *testing.T) {
 }
 
 func TestProductService_DeleteProduct_NotFound(t *testing.T) {
-	service := NewProductService(&MockProductRepository{}, &MockSellerRepository{}, NewMockIdempotencyRepository())
+	service := NewProductService(&MockProductRepository{}, &MockSellerRepository{}, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	_, err := service.DeleteProduct(context.Background(), &command.DeleteProductCommand{Id: uuid.New()})
 
@@ -116,7 +116,7 @@ func TestProductService_DeleteProduct_NotFound(t *testing.T) {
 func TestProductService_DeleteProduct_Success(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
-	service := NewProductService(productRepo, sellerRepo, NewMockIdempotencyRepository())
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	seller := createPersistedSeller(t, sellerRepo)
 	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, seller.Id))
@@ -129,12 +129,81 @@ func TestProductService_DeleteProduct_Success(t *testing.T) {
 	assert.Empty(t, productRepo.products)
 }
 
+// --- Product service: optimistic concurrency ---
+
+func TestProductService_UpdateProduct_BumpsVersion(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+
+	seller := createPersistedSeller(t, sellerRepo)
+	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, seller.Id))
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.Result.Version)
+
+	updated, err := service.UpdateProduct(context.Background(), &command.UpdateProductCommand{
+		Id:              created.Result.Id,
+		Name:            "Widget v2",
+		PriceMinorUnits: 999,
+		Currency:        entities.USD,
+		SellerId:        seller.Id,
+		ExpectedVersion: &created.Result.Version,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Result.Version)
+}
+
+func TestProductService_UpdateProduct_VersionConflict(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+
+	seller := createPersistedSeller(t, sellerRepo)
+	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, seller.Id))
+	require.NoError(t, err)
+
+	staleVersion := 7
+	_, err = service.UpdateProduct(context.Background(), &command.UpdateProductCommand{
+		Id:              created.Result.Id,
+		Name:            "Widget v2",
+		PriceMinorUnits: 999,
+		Currency:        entities.USD,
+		SellerId:        seller.Id,
+		ExpectedVersion: &staleVersion,
+	})
+
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+	stored, err := productRepo.FindById(context.Background(), created.Result.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Widget", stored.Name)
+}
+
+func TestProductService_DeleteProduct_VersionConflict(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+
+	seller := createPersistedSeller(t, sellerRepo)
+	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, seller.Id))
+	require.NoError(t, err)
+
+	staleVersion := 2
+	_, err = service.DeleteProduct(context.Background(), &command.DeleteProductCommand{
+		Id:              created.Result.Id,
+		ExpectedVersion: &staleVersion,
+	})
+
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+	assert.Len(t, productRepo.products, 1)
+}
+
 // --- Product service: idempotency replay ---
 
 func TestProductService_CreateProduct_IdempotentReplay(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
-	service := NewProductService(productRepo, sellerRepo, NewMockIdempotencyRepository())
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	seller := createPersistedSeller(t, sellerRepo)
 	cmd := getCreateProductCommand("Widget", 999, seller.Id)
@@ -155,7 +224,7 @@ func TestProductService_CreateProduct_IdempotentReplay(t *testing.T) {
 func TestProductService_UpdateProduct_SellerChangedNotFound(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
-	service := NewProductService(productRepo, sellerRepo, NewMockIdempotencyRepository())
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	seller := createPersistedSeller(t, sellerRepo)
 	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, seller.Id))
@@ -176,7 +245,7 @@ func TestProductService_UpdateProduct_SellerChangedNotFound(t *testing.T) {
 func TestProductService_UpdateProduct_SellerChangedSuccess(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
-	service := NewProductService(productRepo, sellerRepo, NewMockIdempotencyRepository())
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	sellerA := createPersistedSeller(t, sellerRepo)
 	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, sellerA.Id))
@@ -203,7 +272,7 @@ func TestProductService_UpdateProduct_SellerChangedSuccess(t *testing.T) {
 func TestProductService_UpdateProduct_IdempotentReplay(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
-	service := NewProductService(productRepo, sellerRepo, NewMockIdempotencyRepository())
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	seller := createPersistedSeller(t, sellerRepo)
 	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, seller.Id))
@@ -230,7 +299,7 @@ func TestProductService_UpdateProduct_IdempotentReplay(t *testing.T) {
 func TestProductService_DeleteProduct_IdempotentReplay(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
-	service := NewProductService(productRepo, sellerRepo, NewMockIdempotencyRepository())
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
 
 	seller := createPersistedSeller(t, sellerRepo)
 	created, err := service.CreateProduct(context.Background(), getCreateProductCommand("Widget", 999, seller.Id))
@@ -294,6 +363,40 @@ func TestSellerService_DeleteSeller_Success(t *testing.T) {
 	assert.Empty(t, repo.sellers)
 }
 
+func TestSellerService_UpdateSeller_VersionConflict(t *testing.T) {
+	repo := &MockSellerRepository{}
+	service := NewSellerService(repo, NewMockIdempotencyRepository())
+
+	created, err := service.CreateSeller(context.Background(), getCreateSellerCommand("Acme"))
+	require.NoError(t, err)
+
+	staleVersion := 3
+	_, err = service.UpdateSeller(context.Background(), &command.UpdateSellerCommand{
+		Id:              created.Result.Id,
+		Name:            "Acme Corp",
+		ExpectedVersion: &staleVersion,
+	})
+
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+}
+
+func TestSellerService_DeleteSeller_VersionConflict(t *testing.T) {
+	repo := &MockSellerRepository{}
+	service := NewSellerService(repo, NewMockIdempotencyRepository())
+
+	created, err := service.CreateSeller(context.Background(), getCreateSellerCommand("Acme"))
+	require.NoError(t, err)
+
+	staleVersion := 3
+	_, err = service.DeleteSeller(context.Background(), &command.DeleteSellerCommand{
+		Id:              created.Result.Id,
+		ExpectedVersion: &staleVersion,
+	})
+
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+	assert.Len(t, repo.sellers, 1)
+}
+
 func TestSellerService_CreateSeller_IdempotentReplay(t *testing.T) {
 	repo := &MockSellerRepository{}
 	service := NewSellerService(repo, NewMockIdempotencyRepository())
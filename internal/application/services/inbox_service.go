@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// InboxRetryPolicy decides when a failing message is handled again;
+// outbox.RetryPolicy implements it. attempts counts failures including the
+// current one.
+type InboxRetryPolicy interface {
+	Backoff(attempts int) time.Duration
+	Exhausted(attempts int) bool
+}
+
+// InboxService stores external messages and dispatches them to their
+// consumers. Dispatching claims one message at a time in a transaction,
+// runs the consumer in a savepoint of it and marks the message processed
+// (or records the failure) in the same transaction, so a consumer's writes
+// and the processed mark commit together: redeliveries and crashes never
+// apply a message twice. Several instances can dispatch side by side.
+type InboxService struct {
+	repo       repositories.InboxRepository
+	transactor repositories.Transactor
+	consumers  map[string]interfaces.InboxConsumer
+	retry      InboxRetryPolicy
+	interval   time.Duration
+	wake       chan struct{}
+}
+
+func NewInboxService(repo repositories.InboxRepository, transactor repositories.Transactor, consumers []interfaces.InboxConsumer, retry InboxRetryPolicy, interval time.Duration) *InboxService {
+	byType := make(map[string]interfaces.InboxConsumer, len(consumers))
+	for _, consumer := range consumers {
+		byType[consumer.MessageType()] = consumer
+	}
+
+	return &InboxService{
+		repo:       repo,
+		transactor: transactor,
+		consumers:  byType,
+		retry:      retry,
+		interval:   interval,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Receive stores the message unless it was received before. Only types
+// with a consumer are accepted, so the sender learns about a typo right
+// away instead of the message being parked.
+func (s *InboxService) Receive(ctx context.Context, receiveCommand *command.ReceiveInboxMessageCommand) (*command.ReceiveInboxMessageCommandResult, error) {
+	switch {
+	case receiveCommand.Source == "" || receiveCommand.MessageId == "":
+		return nil, fmt.Errorf("%w: source and message id are required", entities.ErrValidation)
+	case s.consumers[receiveCommand.Type] == nil:
+		return nil, fmt.Errorf("%w: unsupported message type %q", entities.ErrValidation, receiveCommand.Type)
+	case !json.Valid(receiveCommand.Payload):
+		return nil, fmt.Errorf("%w: payload must be JSON", entities.ErrValidation)
+	}
+
+	message := entities.NewInboxMessage(receiveCommand.Source, receiveCommand.MessageId, receiveCommand.Type, receiveCommand.Payload)
+	stored, err := s.repo.Save(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	if stored {
+		s.Wake()
+	}
+
+	return &command.ReceiveInboxMessageCommandResult{Duplicate: !stored}, nil
+}
+
+// Wake makes a running dispatcher look for due messages right away.
+func (s *InboxService) Wake() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start dispatches due messages until ctx is cancelled.
+func (s *InboxService) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+			s.drain(ctx)
+		case <-ticker.C:
+			s.drain(ctx)
+		}
+	}
+}
+
+func (s *InboxService) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		dispatched, err := s.DispatchNext(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "inbox dispatch failed", slog.Any("error", err))
+			return
+		}
+		if !dispatched {
+			return
+		}
+	}
+}
+
+// DispatchNext handles the oldest due message and reports whether there was
+// one. A consumer failure is recorded, not returned; the error is about
+// the inbox itself.
+func (s *InboxService) DispatchNext(ctx context.Context) (bool, error) {
+	dispatched := false
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		message, err := s.repo.ClaimNext(ctx)
+		if err != nil || message == nil {
+			return err
+		}
+		dispatched = true
+
+		handleErr := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return s.handle(ctx, message)
+		})
+		if handleErr == nil {
+			return s.repo.MarkProcessed(ctx, message)
+		}
+		if ctx.Err() != nil {
+			// Shutting down; rolling back leaves the message due.
+			return ctx.Err()
+		}
+
+		attempts := message.Attempts + 1
+		giveUp := errors.Is(handleErr, entities.ErrValidation) || s.retry.Exhausted(attempts)
+		slog.WarnContext(ctx, "inbox message failed",
+			slog.String("source", message.Source), slog.String("message_id", message.MessageId),
+			slog.String("type", message.Type), slog.Int("attempts", attempts), slog.Bool("gave_up", giveUp),
+			slog.Any("error", handleErr))
+
+		return s.repo.RecordFailure(ctx, message, handleErr, s.retry.Backoff(attempts), giveUp)
+	})
+
+	return dispatched, err
+}
+
+func (s *InboxService) handle(ctx context.Context, message *entities.InboxMessage) error {
+	consumer := s.consumers[message.Type]
+	if consumer == nil {
+		// Stored by an instance that knows the type, e.g. during a rollout.
+		return fmt.Errorf("no consumer for message type %q", message.Type)
+	}
+
+	return consumer.Handle(ctx, message)
+}
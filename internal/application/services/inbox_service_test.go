@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// inboxEntry is a stored message with the state the dispatcher writes.
+type inboxEntry struct {
+	message   entities.InboxMessage
+	processed bool
+	parked    bool
+	retryIn   time.Duration
+	lastError string
+}
+
+// MockInboxRepository keeps messages in receive order; every stored
+// message is due.
+type MockInboxRepository struct {
+	entries []*inboxEntry
+}
+
+func (m *MockInboxRepository) find(message *entities.InboxMessage) *inboxEntry {
+	for _, entry := range m.entries {
+		if entry.message.Source == message.Source && entry.message.MessageId == message.MessageId {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (m *MockInboxRepository) Save(ctx context.Context, message *entities.InboxMessage) (bool, error) {
+	if m.find(message) != nil {
+		return false, nil
+	}
+	m.entries = append(m.entries, &inboxEntry{message: *message})
+	return true, nil
+}
+
+func (m *MockInboxRepository) ClaimNext(ctx context.Context) (*entities.InboxMessage, error) {
+	for _, entry := range m.entries {
+		if !entry.processed && !entry.parked && entry.retryIn == 0 {
+			claimed := entry.message
+			return &claimed, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockInboxRepository) MarkProcessed(ctx context.Context, message *entities.InboxMessage) error {
+	entry := m.find(message)
+	entry.message.Attempts++
+	entry.processed = true
+	return nil
+}
+
+func (m *MockInboxRepository) RecordFailure(ctx context.Context, message *entities.InboxMessage, cause error, retryIn time.Duration, giveUp bool) error {
+	entry := m.find(message)
+	entry.message.Attempts++
+	entry.lastError = cause.Error()
+	entry.retryIn = retryIn
+	entry.parked = giveUp
+	return nil
+}
+
+// MockTransactor runs fn directly and counts how deep transactions nest.
+type MockTransactor struct {
+	depth, maxDepth int
+}
+
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.depth++
+	m.maxDepth = max(m.maxDepth, m.depth)
+	defer func() { m.depth-- }()
+	return fn(ctx)
+}
+
+type stubConsumer struct {
+	messageType string
+	err         error
+	handled     []string
+}
+
+func (c *stubConsumer) MessageType() string { return c.messageType }
+
+func (c *stubConsumer) Handle(ctx context.Context, message *entities.InboxMessage) error {
+	c.handled = append(c.handled, message.MessageId)
+	return c.err
+}
+
+// fixedRetry retries after a second, up to three attempts.
+type fixedRetry struct{}
+
+func (fixedRetry) Backoff(int) time.Duration   { return time.Second }
+func (fixedRetry) Exhausted(attempts int) bool { return attempts >= 3 }
+
+func newTestInboxService(consumers ...interfaces.InboxConsumer) (*InboxService, *MockInboxRepository, *MockTransactor) {
+	repo := &MockInboxRepository{}
+	transactor := &MockTransactor{}
+	return NewInboxService(repo, transactor, consumers, fixedRetry{}, time.Hour), repo, transactor
+}
+
+func receiveCommand(messageId, messageType string) *command.ReceiveInboxMessageCommand {
+	return &command.ReceiveInboxMessageCommand{
+		Source:    "kyc",
+		MessageId: messageId,
+		Type:      messageType,
+		Payload:   []byte(`{}`),
+	}
+}
+
+func TestInboxService_Receive_FlagsDuplicates(t *testing.T) {
+	consumer := &stubConsumer{messageType: "kyc.test"}
+	service, repo, _ := newTestInboxService(consumer)
+	ctx := context.Background()
+
+	result, err := service.Receive(ctx, receiveCommand("m-1", "kyc.test"))
+	require.NoError(t, err)
+	assert.False(t, result.Duplicate)
+
+	result, err = service.Receive(ctx, receiveCommand("m-1", "kyc.test"))
+	require.NoError(t, err)
+	assert.True(t, result.Duplicate)
+	assert.Len(t, repo.entries, 1)
+
+	otherSource := receiveCommand("m-1", "kyc.test")
+	otherSource.Source = "crm"
+	result, err = service.Receive(ctx, otherSource)
+	require.NoError(t, err)
+	assert.False(t, result.Duplicate, "message ids are scoped to their source")
+}
+
+func TestInboxService_Receive_Validation(t *testing.T) {
+	service, repo, _ := newTestInboxService(&stubConsumer{messageType: "kyc.test"})
+	ctx := context.Background()
+
+	_, err := service.Receive(ctx, receiveCommand("", "kyc.test"))
+	assert.ErrorIs(t, err, entities.ErrValidation)
+
+	_, err = service.Receive(ctx, receiveCommand("m-1", "kyc.unknown"))
+	assert.ErrorIs(t, err, entities.ErrValidation)
+
+	invalid := receiveCommand("m-1", "kyc.test")
+	invalid.Payload = []byte(`{`)
+	_, err = service.Receive(ctx, invalid)
+	assert.ErrorIs(t, err, entities.ErrValidation)
+
+	assert.Empty(t, repo.entries)
+}
+
+func TestInboxService_DispatchNext_HandlesEachMessageOnce(t *testing.T) {
+	consumer := &stubConsumer{messageType: "kyc.test"}
+	service, repo, transactor := newTestInboxService(consumer)
+	ctx := context.Background()
+
+	for _, id := range []string{"m-1", "m-2", "m-1"} {
+		_, err := service.Receive(ctx, receiveCommand(id, "kyc.test"))
+		require.NoError(t, err)
+	}
+
+	for {
+		dispatched, err := service.DispatchNext(ctx)
+		require.NoError(t, err)
+		if !dispatched {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"m-1", "m-2"}, consumer.handled)
+	assert.True(t, repo.entries[0].processed)
+	assert.True(t, repo.entries[1].processed)
+	assert.Equal(t, 2, transactor.maxDepth, "the consumer runs in a savepoint of the claiming transaction")
+}
+
+func TestInboxService_DispatchNext_RetriesThenParks(t *testing.T) {
+	consumer := &stubConsumer{messageType: "kyc.test", err: errors.New("database unavailable")}
+	service, repo, _ := newTestInboxService(consumer)
+	ctx := context.Background()
+
+	_, err := service.Receive(ctx, receiveCommand("m-1", "kyc.test"))
+	require.NoError(t, err)
+	entry := repo.entries[0]
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		dispatched, err := service.DispatchNext(ctx)
+		require.NoError(t, err, "consumer failures are recorded, not returned")
+		require.True(t, dispatched)
+		assert.Equal(t, attempt, entry.message.Attempts)
+		assert.Equal(t, "database unavailable", entry.lastError)
+		assert.Equal(t, time.Second, entry.retryIn)
+		assert.Equal(t, attempt == 3, entry.parked)
+		entry.retryIn = 0 // due again
+	}
+
+	dispatched, err := service.DispatchNext(ctx)
+	require.NoError(t, err)
+	assert.False(t, dispatched, "parked messages are not handled again")
+	assert.False(t, entry.processed)
+}
+
+func TestInboxService_DispatchNext_ParksPermanentFailures(t *testing.T) {
+	consumer := &stubConsumer{messageType: "kyc.test", err: entities.ErrValidation}
+	service, repo, _ := newTestInboxService(consumer)
+	ctx := context.Background()
+
+	_, err := service.Receive(ctx, receiveCommand("m-1", "kyc.test"))
+	require.NoError(t, err)
+
+	_, err = service.DispatchNext(ctx)
+	require.NoError(t, err)
+	assert.True(t, repo.entries[0].parked)
+	assert.Equal(t, 1, repo.entries[0].message.Attempts)
+}
+
+func TestSellerVerificationConsumer_AppliesResult(t *testing.T) {
+	seller := entities.NewSeller("Acme")
+	validated, err := entities.NewValidatedSeller(seller)
+	require.NoError(t, err)
+	repo := &MockSellerRepository{sellers: []*entities.ValidatedSeller{validated}}
+	consumer := NewSellerVerificationConsumer(repo)
+	ctx := context.Background()
+
+	payload, err := json.Marshal(map[string]string{"seller_id": seller.Id.String(), "result": "verified"})
+	require.NoError(t, err)
+	message := entities.NewInboxMessage("kyc", "m-1", SellerVerificationCompletedType, payload)
+	require.NoError(t, consumer.Handle(ctx, message))
+
+	stored, err := repo.FindById(ctx, seller.Id)
+	require.NoError(t, err)
+	assert.Equal(t, entities.SellerVerified, stored.VerificationStatus)
+	assert.Equal(t, 2, stored.Version)
+}
+
+func TestSellerVerificationConsumer_PermanentFailures(t *testing.T) {
+	seller := entities.NewSeller("Acme")
+	validated, err := entities.NewValidatedSeller(seller)
+	require.NoError(t, err)
+	consumer := NewSellerVerificationConsumer(&MockSellerRepository{sellers: []*entities.ValidatedSeller{validated}})
+	ctx := context.Background()
+
+	for name, payload := range map[string]string{
+		"malformed":      `{"seller_id": 42}`,
+		"unknown seller": `{"seller_id": "` + uuid.NewString() + `", "result": "verified"}`,
+		"unknown result": `{"seller_id": "` + seller.Id.String() + `", "result": "pending"}`,
+	} {
+		message := entities.NewInboxMessage("kyc", "m-1", SellerVerificationCompletedType, []byte(payload))
+		assert.ErrorIs(t, consumer.Handle(ctx, message), entities.ErrValidation, name)
+	}
+}
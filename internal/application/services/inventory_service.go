@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// InventoryService runs every stock change as lock, change, save in one
+// transaction, so concurrent reservations of the same variant serialize on
+// its inventory row.
+type InventoryService struct {
+	inventoryRepository repositories.InventoryRepository
+	productRepository   repositories.ProductRepository
+	transactor          repositories.Transactor
+	idempotencyRepo     repositories.IdempotencyRepository
+}
+
+func NewInventoryService(
+	inventoryRepository repositories.InventoryRepository,
+	productRepository repositories.ProductRepository,
+	transactor repositories.Transactor,
+	idempotencyRepo repositories.IdempotencyRepository,
+) interfaces.InventoryService {
+	return &InventoryService{
+		inventoryRepository: inventoryRepository,
+		productRepository:   productRepository,
+		transactor:          transactor,
+		idempotencyRepo:     idempotencyRepo,
+	}
+}
+
+// GetInventory reports the current availability. Reservations that expired
+// but were not released yet are left out, so the numbers are exact even
+// between two expiry sweeps.
+func (s *InventoryService) GetInventory(ctx context.Context, inventoryQuery *query.GetInventoryQuery) (*query.GetInventoryQueryResult, error) {
+	variantId, err := s.resolveVariant(ctx, inventoryQuery.ProductId, inventoryQuery.VariantId)
+	if err != nil {
+		return nil, err
+	}
+	inventory, err := s.inventoryRepository.FindByVariantId(ctx, variantId)
+	if err != nil {
+		return nil, err
+	}
+	if inventory == nil {
+		inventory = entities.NewInventory(inventoryQuery.ProductId, variantId)
+	}
+	inventory.ReleaseExpired(time.Now())
+
+	return &query.GetInventoryQueryResult{Result: mapper.NewInventoryResultFromEntity(inventory)}, nil
+}
+
+func (s *InventoryService) AdjustStock(ctx context.Context, adjustCommand *command.AdjustStockCommand) (*command.AdjustStockCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, adjustCommand.IdempotencyKey, adjustCommand, func() (*command.AdjustStockCommandResult, error) {
+		variantId, err := s.resolveVariant(ctx, adjustCommand.ProductId, adjustCommand.VariantId)
+		if err != nil {
+			return nil, err
+		}
+
+		inventory, err := s.modify(ctx, variantId, func(inventory *entities.Inventory) error {
+			return inventory.AdjustStock(adjustCommand.Delta, adjustCommand.Reason)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.AdjustStockCommandResult{Result: mapper.NewInventoryResultFromEntity(inventory)}, nil
+	})
+}
+
+func (s *InventoryService) ReserveStock(ctx context.Context, reserveCommand *command.ReserveStockCommand) (*command.ReserveStockCommandResult, error) {
+	variantId, err := s.resolveVariant(ctx, reserveCommand.ProductId, reserveCommand.VariantId)
+	if err != nil {
+		return nil, err
+	}
+
+	var reservation entities.Reservation
+	inventory, err := s.modify(ctx, variantId, func(inventory *entities.Inventory) error {
+		var err error
+		reservation, err = inventory.Reserve(reserveCommand.Quantity, reserveCommand.TTL)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.ReserveStockCommandResult{
+		Reservation: common.ReservationResult{
+			Id:        reservation.Id,
+			Quantity:  reservation.Quantity,
+			ExpiresAt: reservation.ExpiresAt,
+		},
+		Result: mapper.NewInventoryResultFromEntity(inventory),
+	}, nil
+}
+
+func (s *InventoryService) ReleaseReservation(ctx context.Context, releaseCommand *command.ReleaseReservationCommand) (*command.ReleaseReservationCommandResult, error) {
+	inventory, err := s.modify(ctx, releaseCommand.VariantId, func(inventory *entities.Inventory) error {
+		return inventory.Release(releaseCommand.ReservationId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.ReleaseReservationCommandResult{Result: mapper.NewInventoryResultFromEntity(inventory)}, nil
+}
+
+func (s *InventoryService) CommitReservation(ctx context.Context, commitCommand *command.CommitReservationCommand) (*command.CommitReservationCommandResult, error) {
+	inventory, err := s.modify(ctx, commitCommand.VariantId, func(inventory *entities.Inventory) error {
+		return inventory.Commit(commitCommand.ReservationId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.CommitReservationCommandResult{Result: mapper.NewInventoryResultFromEntity(inventory)}, nil
+}
+
+// ReleaseExpiredReservations also covers products deleted meanwhile, so
+// their reservations do not linger.
+func (s *InventoryService) ReleaseExpiredReservations(ctx context.Context, limit int) (int, error) {
+	variantIds, err := s.inventoryRepository.FindVariantIdsWithExpiredReservations(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, variantId := range variantIds {
+		_, err := s.modify(ctx, variantId, func(inventory *entities.Inventory) error {
+			released += inventory.ReleaseExpired(time.Now())
+			return nil
+		})
+		if err != nil {
+			return released, err
+		}
+	}
+
+	return released, nil
+}
+
+// modify locks the variant's inventory, applies change and saves the result
+// with its events. Nothing is written if change fails.
+func (s *InventoryService) modify(ctx context.Context, variantId uuid.UUID, change func(inventory *entities.Inventory) error) (*entities.Inventory, error) {
+	var saved *entities.Inventory
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		inventory, err := s.inventoryRepository.Lock(ctx, variantId)
+		if err != nil {
+			return err
+		}
+
+		if err := change(inventory); err != nil {
+			return err
+		}
+
+		validatedInventory, err := entities.NewValidatedInventory(inventory)
+		if err != nil {
+			return err
+		}
+
+		saved, err = s.inventoryRepository.Save(ctx, validatedInventory)
+		return err
+	})
+
+	return saved, err
+}
+
+// resolveVariant checks that the product exists and returns the Id of the
+// variant meant by variantId (see Product.ResolveVariant).
+func (s *InventoryService) resolveVariant(ctx context.Context, productId, variantId uuid.UUID) (uuid.UUID, error) {
+	product, err := s.productRepository.FindById(ctx, productId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if product == nil {
+		return uuid.Nil, entities.ErrProductNotFound
+	}
+
+	variant, err := product.ResolveVariant(variantId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return variant.Id, nil
+}
+
+// NewReservationSweeper periodically releases expired reservations, so
+// their StockReleased events go out even if nobody touches the product.
+func NewReservationSweeper(inventory interfaces.InventoryService, interval time.Duration) *PeriodicJob {
+	return NewPeriodicJob("release expired reservations", interval, func(ctx context.Context) (int, error) {
+		return inventory.ReleaseExpiredReservations(ctx, 100)
+	})
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+// MockInventoryRepository stores copies, so a change that is never saved
+// (e.g. because it failed) leaves no trace, like a rolled back transaction.
+// Inventories are keyed by variant id; Lock looks the variant up in products.
+type MockInventoryRepository struct {
+	inventories map[uuid.UUID]entities.Inventory
+	events      []events.DomainEvent
+	products    *MockProductRepository
+}
+
+func NewMockInventoryRepository(products *MockProductRepository) *MockInventoryRepository {
+	return &MockInventoryRepository{inventories: make(map[uuid.UUID]entities.Inventory), products: products}
+}
+
+func (m *MockInventoryRepository) load(variantId uuid.UUID) *entities.Inventory {
+	stored, ok := m.inventories[variantId]
+	if !ok {
+		return nil
+	}
+	stored.Reservations = append([]entities.Reservation(nil), stored.Reservations...)
+	return &stored
+}
+
+func (m *MockInventoryRepository) FindByVariantId(ctx context.Context, variantId uuid.UUID) (*entities.Inventory, error) {
+	return m.load(variantId), nil
+}
+
+func (m *MockInventoryRepository) Lock(ctx context.Context, variantId uuid.UUID) (*entities.Inventory, error) {
+	if _, ok := m.inventories[variantId]; !ok {
+		productId := uuid.Nil
+		for _, product := range m.products.products {
+			if _, ok := product.Variant(variantId); ok {
+				productId = product.Id
+			}
+		}
+		if productId == uuid.Nil {
+			return nil, entities.ErrVariantNotFound
+		}
+		m.inventories[variantId] = *entities.NewInventory(productId, variantId)
+	}
+	return m.load(variantId), nil
+}
+
+func (m *MockInventoryRepository) Save(ctx context.Context, inventory *entities.ValidatedInventory) (*entities.Inventory, error) {
+	m.events = append(m.events, inventory.PullEvents()...)
+	saved := inventory.Inventory
+	saved.Version++
+	m.inventories[inventory.VariantId] = saved
+	return m.load(inventory.VariantId), nil
+}
+
+func (m *MockInventoryRepository) FindVariantIdsWithExpiredReservations(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	var variantIds []uuid.UUID
+	for variantId, inventory := range m.inventories {
+		for _, reservation := range inventory.Reservations {
+			if !reservation.ExpiresAt.After(time.Now()) {
+				variantIds = append(variantIds, variantId)
+				break
+			}
+		}
+	}
+	return variantIds, nil
+}
+
+// newTestInventoryService returns the service and the id of a product with
+// a single variant.
+func newTestInventoryService(t *testing.T) (*InventoryService, *MockInventoryRepository, uuid.UUID) {
+	t.Helper()
+	seller, err := entities.NewValidatedSeller(entities.NewSeller("Acme"))
+	require.NoError(t, err)
+	price, err := entities.NewMoney(999, entities.USD)
+	require.NoError(t, err)
+	product, err := entities.NewValidatedProduct(entities.NewProduct("Widget", price, *seller))
+	require.NoError(t, err)
+
+	productRepo := &MockProductRepository{products: []*entities.ValidatedProduct{product}}
+	inventoryRepo := NewMockInventoryRepository(productRepo)
+	service := NewInventoryService(inventoryRepo, productRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	return service.(*InventoryService), inventoryRepo, product.Id
+}
+
+func TestInventoryService_AdjustAndReserve(t *testing.T) {
+	service, repo, productId := newTestInventoryService(t)
+	ctx := context.Background()
+
+	adjusted, err := service.AdjustStock(ctx, &command.AdjustStockCommand{ProductId: productId, Delta: 5, Reason: "delivery"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, adjusted.Result.Available)
+	assert.Equal(t, 2, adjusted.Result.Version)
+
+	reserved, err := service.ReserveStock(ctx, &command.ReserveStockCommand{ProductId: productId, Quantity: 2, TTL: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, 2, reserved.Reservation.Quantity)
+	assert.Equal(t, 3, reserved.Result.Available)
+	assert.Equal(t, 2, reserved.Result.Reserved)
+
+	_, err = service.ReserveStock(ctx, &command.ReserveStockCommand{ProductId: productId, Quantity: 4, TTL: time.Minute})
+	assert.ErrorIs(t, err, entities.ErrInsufficientStock)
+
+	committed, err := service.CommitReservation(ctx, &command.CommitReservationCommand{VariantId: reserved.Result.VariantId, ReservationId: reserved.Reservation.Id})
+	require.NoError(t, err)
+	assert.Equal(t, 3, committed.Result.OnHand)
+	assert.Zero(t, committed.Result.Reserved)
+
+	_, err = service.ReleaseReservation(ctx, &command.ReleaseReservationCommand{VariantId: reserved.Result.VariantId, ReservationId: reserved.Reservation.Id})
+	assert.ErrorIs(t, err, entities.ErrReservationNotFound)
+
+	assert.Equal(t, []string{events.StockAdjustedEventName, events.StockReservedEventName, events.StockCommittedEventName}, eventNames(repo.events),
+		"failed changes store no events")
+}
+
+func TestInventoryService_UnknownProduct(t *testing.T) {
+	service, _, _ := newTestInventoryService(t)
+	ctx := context.Background()
+
+	_, err := service.GetInventory(ctx, &query.GetInventoryQuery{ProductId: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+	_, err = service.AdjustStock(ctx, &command.AdjustStockCommand{ProductId: uuid.New(), Delta: 1})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+	_, err = service.ReserveStock(ctx, &command.ReserveStockCommand{ProductId: uuid.New(), Quantity: 1, TTL: time.Minute})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+}
+
+func TestInventoryService_GetInventory(t *testing.T) {
+	service, repo, productId := newTestInventoryService(t)
+	ctx := context.Background()
+
+	empty, err := service.GetInventory(ctx, &query.GetInventoryQuery{ProductId: productId})
+	require.NoError(t, err)
+	assert.Zero(t, empty.Result.OnHand, "products start without stock")
+
+	_, err = service.AdjustStock(ctx, &command.AdjustStockCommand{ProductId: productId, Delta: 3})
+	require.NoError(t, err)
+	_, err = service.ReserveStock(ctx, &command.ReserveStockCommand{ProductId: productId, Quantity: 3, TTL: time.Minute})
+	require.NoError(t, err)
+	expireReservations(repo, empty.Result.VariantId)
+
+	found, err := service.GetInventory(ctx, &query.GetInventoryQuery{ProductId: productId})
+	require.NoError(t, err)
+	assert.Equal(t, 3, found.Result.Available, "expired reservations do not count")
+	assert.Empty(t, found.Result.Reservations)
+	assert.Len(t, repo.inventories[empty.Result.VariantId].Reservations, 1, "reads do not write")
+}
+
+func TestInventoryService_ReleaseExpiredReservations(t *testing.T) {
+	service, repo, productId := newTestInventoryService(t)
+	ctx := context.Background()
+
+	adjusted, err := service.AdjustStock(ctx, &command.AdjustStockCommand{ProductId: productId, Delta: 3})
+	require.NoError(t, err)
+	variantId := adjusted.Result.VariantId
+	_, err = service.ReserveStock(ctx, &command.ReserveStockCommand{ProductId: productId, Quantity: 1, TTL: time.Minute})
+	require.NoError(t, err)
+	_, err = service.ReserveStock(ctx, &command.ReserveStockCommand{ProductId: productId, Quantity: 1, TTL: time.Minute})
+	require.NoError(t, err)
+	expireReservations(repo, variantId)
+
+	released, err := service.ReleaseExpiredReservations(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, released)
+	assert.Empty(t, repo.inventories[variantId].Reservations)
+	assert.Equal(t, events.StockReleasedEventName, repo.events[len(repo.events)-1].EventName())
+
+	released, err = service.ReleaseExpiredReservations(ctx, 10)
+	require.NoError(t, err)
+	assert.Zero(t, released)
+}
+
+func TestInventoryService_Variants(t *testing.T) {
+	seller, err := entities.NewValidatedSeller(entities.NewSeller("Acme"))
+	require.NoError(t, err)
+	price, err := entities.NewMoney(999, entities.USD)
+	require.NoError(t, err)
+	small := entities.NewProductVariant("TEE-S", map[string]string{"size": "S"}, nil)
+	large := entities.NewProductVariant("TEE-L", map[string]string{"size": "L"}, nil)
+	shirt, err := entities.NewValidatedProduct(entities.NewProduct("T-shirt", price, *seller, small, large))
+	require.NoError(t, err)
+	productRepo := &MockProductRepository{products: []*entities.ValidatedProduct{shirt}}
+	service := NewInventoryService(NewMockInventoryRepository(productRepo), productRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	ctx := context.Background()
+
+	_, err = service.AdjustStock(ctx, &command.AdjustStockCommand{ProductId: shirt.Id, Delta: 5})
+	assert.ErrorIs(t, err, entities.ErrValidation, "the variant must be named")
+	_, err = service.AdjustStock(ctx, &command.AdjustStockCommand{ProductId: shirt.Id, VariantId: uuid.New(), Delta: 5})
+	assert.ErrorIs(t, err, entities.ErrVariantNotFound)
+
+	adjusted, err := service.AdjustStock(ctx, &command.AdjustStockCommand{ProductId: shirt.Id, VariantId: large.Id, Delta: 5})
+	require.NoError(t, err)
+	assert.Equal(t, large.Id, adjusted.Result.VariantId)
+	assert.Equal(t, shirt.Id, adjusted.Result.ProductId)
+
+	other, err := service.GetInventory(ctx, &query.GetInventoryQuery{ProductId: shirt.Id, VariantId: small.Id})
+	require.NoError(t, err)
+	assert.Zero(t, other.Result.OnHand, "each variant has its own stock")
+	_, err = service.ReserveStock(ctx, &command.ReserveStockCommand{ProductId: shirt.Id, VariantId: small.Id, Quantity: 1, TTL: time.Minute})
+	assert.ErrorIs(t, err, entities.ErrInsufficientStock)
+}
+
+func expireReservations(repo *MockInventoryRepository, variantId uuid.UUID) {
+	inventory := repo.inventories[variantId]
+	for i := range inventory.Reservations {
+		inventory.Reservations[i].ExpiresAt = time.Now().Add(-time.Second)
+	}
+}
+
+func eventNames(domainEvents []events.DomainEvent) []string {
+	names := make([]string, 0, len(domainEvents))
+	for _, event := range domainEvents {
+		names = append(names, event.EventName())
+	}
+	return names
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// MediaService manages product images. The product keeps their metadata;
+// the data lives in the blob store.
+type MediaService struct {
+	productRepository repositories.ProductRepository
+	blobStore         repositories.BlobStore
+	blobDeletions     repositories.BlobDeletionRepository
+	idempotencyRepo   repositories.IdempotencyRepository
+	maxImageBytes     int64
+}
+
+func NewMediaService(
+	productRepository repositories.ProductRepository,
+	blobStore repositories.BlobStore,
+	blobDeletions repositories.BlobDeletionRepository,
+	idempotencyRepo repositories.IdempotencyRepository,
+	maxImageBytes int64,
+) interfaces.MediaService {
+	return &MediaService{
+		productRepository: productRepository,
+		blobStore:         blobStore,
+		blobDeletions:     blobDeletions,
+		idempotencyRepo:   idempotencyRepo,
+		maxImageBytes:     maxImageBytes,
+	}
+}
+
+func (s *MediaService) GetProductImages(ctx context.Context, imagesQuery *query.GetProductImagesQuery) (*query.GetProductImagesQueryResult, error) {
+	product, err := s.findProduct(ctx, imagesQuery.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.GetProductImagesQueryResult{Result: mapper.NewProductImagesResultFromEntity(product)}, nil
+}
+
+func (s *MediaService) OpenProductImage(ctx context.Context, imageQuery *query.GetProductImageQuery) (*query.GetProductImageQueryResult, error) {
+	product, err := s.findProduct(ctx, imageQuery.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	position := -1
+	for index, image := range product.Images {
+		if image.Id == imageQuery.ImageId {
+			position = index
+		}
+	}
+	if position < 0 {
+		return nil, fmt.Errorf("%w: %s of %s", entities.ErrImageNotFound, imageQuery.ImageId, product.Name)
+	}
+
+	image := product.Images[position]
+	body, err := s.blobStore.Get(ctx, image.BlobKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := mapper.NewProductImageResult(image, position)
+	return &query.GetProductImageQueryResult{Image: &result, Body: body}, nil
+}
+
+// UploadProductImage stores the data in the blob store before the image is
+// added to the product, so a stored image always has its data. When the
+// product cannot be saved the blob is deleted again.
+func (s *MediaService) UploadProductImage(ctx context.Context, uploadCommand *command.UploadProductImageCommand) (*command.UploadProductImageCommandResult, error) {
+	dataHash := sha256.Sum256(uploadCommand.Data)
+	fingerprint := struct {
+		*command.UploadProductImageCommand
+		DataSha256 string
+	}{uploadCommand, hex.EncodeToString(dataHash[:])}
+
+	return withIdempotency(ctx, s.idempotencyRepo, uploadCommand.IdempotencyKey, fingerprint, func() (*command.UploadProductImageCommandResult, error) {
+		contentType, err := s.checkUpload(uploadCommand)
+		if err != nil {
+			return nil, err
+		}
+
+		product, err := s.findProduct(ctx, uploadCommand.ProductId)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkExpectedVersion(uploadCommand.ExpectedVersion, product.Version); err != nil {
+			return nil, err
+		}
+
+		image, err := entities.NewProductImage(product.Id, contentType, int64(len(uploadCommand.Data)))
+		if err != nil {
+			return nil, err
+		}
+		if err := product.AddImage(image, uploadCommand.Primary); err != nil {
+			return nil, err
+		}
+		validatedProduct, err := entities.NewValidatedProduct(product)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.blobStore.Put(ctx, image.BlobKey, bytes.NewReader(uploadCommand.Data), image.SizeBytes, image.ContentType); err != nil {
+			return nil, err
+		}
+		updatedProduct, err := s.productRepository.Update(ctx, validatedProduct)
+		if err != nil {
+			if deleteErr := s.blobStore.Delete(ctx, image.BlobKey); deleteErr != nil {
+				slog.ErrorContext(ctx, "failed to delete blob of unsaved image", slog.String("blob_key", image.BlobKey), slog.Any("error", deleteErr))
+			}
+			return nil, err
+		}
+
+		result := &command.UploadProductImageCommandResult{Result: mapper.NewProductImagesResultFromEntity(updatedProduct)}
+		for index := range result.Result.Images {
+			if result.Result.Images[index].Id == image.Id {
+				result.Image = &result.Result.Images[index]
+			}
+		}
+		return result, nil
+	})
+}
+
+// checkUpload enforces the size limit and returns the data's content type.
+// The type is sniffed from the data rather than trusted: a declared type
+// that does not match is ErrUnsupportedMediaType, as is anything but an
+// accepted image format.
+func (s *MediaService) checkUpload(uploadCommand *command.UploadProductImageCommand) (string, error) {
+	if int64(len(uploadCommand.Data)) > s.maxImageBytes {
+		return "", fmt.Errorf("%w: %d bytes exceed the limit of %d bytes", entities.ErrImageTooLarge, len(uploadCommand.Data), s.maxImageBytes)
+	}
+	if len(uploadCommand.Data) == 0 {
+		return "", fmt.Errorf("%w: image must not be empty", entities.ErrValidation)
+	}
+
+	detected := http.DetectContentType(uploadCommand.Data)
+	if !entities.IsImageContentType(detected) {
+		return "", fmt.Errorf("%w: %s is not a JPEG, PNG, GIF or WebP image", entities.ErrUnsupportedMediaType, detected)
+	}
+	if uploadCommand.ContentType != "" && uploadCommand.ContentType != detected {
+		return "", fmt.Errorf("%w: declared as %s but is %s", entities.ErrUnsupportedMediaType, uploadCommand.ContentType, detected)
+	}
+
+	return detected, nil
+}
+
+func (s *MediaService) RemoveProductImage(ctx context.Context, removeCommand *command.RemoveProductImageCommand) (*command.RemoveProductImageCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, removeCommand.IdempotencyKey, removeCommand, func() (*command.RemoveProductImageCommandResult, error) {
+		product, err := s.modify(ctx, removeCommand.ProductId, removeCommand.ExpectedVersion, func(product *entities.Product) error {
+			return product.RemoveImage(removeCommand.ImageId)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.RemoveProductImageCommandResult{Result: mapper.NewProductImagesResultFromEntity(product)}, nil
+	})
+}
+
+func (s *MediaService) ReorderProductImages(ctx context.Context, reorderCommand *command.ReorderProductImagesCommand) (*command.ReorderProductImagesCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, reorderCommand.IdempotencyKey, reorderCommand, func() (*command.ReorderProductImagesCommandResult, error) {
+		product, err := s.modify(ctx, reorderCommand.ProductId, reorderCommand.ExpectedVersion, func(product *entities.Product) error {
+			return product.ReorderImages(reorderCommand.ImageIds)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.ReorderProductImagesCommandResult{Result: mapper.NewProductImagesResultFromEntity(product)}, nil
+	})
+}
+
+func (s *MediaService) SetPrimaryProductImage(ctx context.Context, primaryCommand *command.SetPrimaryProductImageCommand) (*command.SetPrimaryProductImageCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, primaryCommand.IdempotencyKey, primaryCommand, func() (*command.SetPrimaryProductImageCommandResult, error) {
+		product, err := s.modify(ctx, primaryCommand.ProductId, primaryCommand.ExpectedVersion, func(product *entities.Product) error {
+			return product.SetPrimaryImage(primaryCommand.ImageId)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.SetPrimaryProductImageCommandResult{Result: mapper.NewProductImagesResultFromEntity(product)}, nil
+	})
+}
+
+// PurgeDeletedBlobs deletes each blob before unscheduling it, so a failure
+// in between only leads to a second, harmless delete.
+func (s *MediaService) PurgeDeletedBlobs(ctx context.Context, limit int) (int, error) {
+	keys, err := s.blobDeletions.FindDue(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, key := range keys {
+		if err := s.blobStore.Delete(ctx, key); err != nil {
+			return purged, err
+		}
+		if err := s.blobDeletions.Remove(ctx, key); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// modify loads the product, applies change and stores it with a versioned
+// update.
+func (s *MediaService) modify(ctx context.Context, id uuid.UUID, expectedVersion *int, change func(*entities.Product) error) (*entities.Product, error) {
+	product, err := s.findProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExpectedVersion(expectedVersion, product.Version); err != nil {
+		return nil, err
+	}
+
+	if err := change(product); err != nil {
+		return nil, err
+	}
+
+	validatedProduct, err := entities.NewValidatedProduct(product)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.productRepository.Update(ctx, validatedProduct)
+}
+
+func (s *MediaService) findProduct(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
+	product, err := s.productRepository.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	return product, nil
+}
+
+// NewBlobCleaner periodically deletes the blobs of removed images and
+// deleted products.
+func NewBlobCleaner(media interfaces.MediaService, interval time.Duration) *PeriodicJob {
+	return NewPeriodicJob("delete blobs", interval, func(ctx context.Context) (int, error) {
+		return media.PurgeDeletedBlobs(ctx, 100)
+	})
+}
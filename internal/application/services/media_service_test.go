@@ -0,0 +1,254 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngData starts with the PNG signature, which is all content sniffing
+// looks at.
+var pngData = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 32)...)
+
+// MockBlobStore is an in-memory implementation of the BlobStore interface.
+type MockBlobStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+	// deleteErr, if set, fails every Delete.
+	deleteErr error
+}
+
+func NewMockBlobStore() *MockBlobStore {
+	return &MockBlobStore{blobs: make(map[string][]byte)}
+}
+
+func (m *MockBlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.blobs[key] = data
+	return nil
+}
+
+func (m *MockBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.blobs[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", entities.ErrBlobNotFound, key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	delete(m.blobs, key)
+	return nil
+}
+
+// MockBlobDeletionRepository keeps scheduled blob keys in order.
+type MockBlobDeletionRepository struct {
+	keys []string
+}
+
+func (m *MockBlobDeletionRepository) FindDue(ctx context.Context, limit int) ([]string, error) {
+	return m.keys[:min(limit, len(m.keys))], nil
+}
+
+func (m *MockBlobDeletionRepository) Remove(ctx context.Context, key string) error {
+	for index, scheduled := range m.keys {
+		if scheduled == key {
+			m.keys = append(m.keys[:index:index], m.keys[index+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// failingUpdateProductRepository fails every Update.
+type failingUpdateProductRepository struct {
+	*MockProductRepository
+}
+
+func (r failingUpdateProductRepository) Update(ctx context.Context, product *entities.ValidatedProduct) (*entities.Product, error) {
+	return nil, entities.ErrVersionConflict
+}
+
+func createPersistedProduct(t *testing.T, productRepo *MockProductRepository) *entities.Product {
+	t.Helper()
+	seller, err := entities.NewValidatedSeller(entities.NewSeller("John Doe"))
+	require.NoError(t, err)
+	price, err := entities.NewMoney(999, entities.USD)
+	require.NoError(t, err)
+	product, err := entities.NewValidatedProduct(entities.NewProduct("Widget", price, *seller))
+	require.NoError(t, err)
+	created, err := productRepo.Create(context.Background(), product)
+	require.NoError(t, err)
+	return created
+}
+
+func TestMediaService_ProductImages(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	blobs := NewMockBlobStore()
+	service := NewMediaService(productRepo, blobs, &MockBlobDeletionRepository{}, NewMockIdempotencyRepository(), 1024)
+	ctx := context.Background()
+	product := createPersistedProduct(t, productRepo)
+
+	first, err := service.UploadProductImage(ctx, &command.UploadProductImageCommand{
+		ProductId:       product.Id,
+		Data:            pngData,
+		ContentType:     "image/png",
+		ExpectedVersion: &product.Version,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, first.Image)
+	assert.True(t, first.Image.Primary, "the first image is primary")
+	assert.Equal(t, int64(len(pngData)), first.Image.SizeBytes)
+	assert.Len(t, blobs.blobs, 1)
+
+	second, err := service.UploadProductImage(ctx, &command.UploadProductImageCommand{
+		ProductId: product.Id,
+		Data:      pngData,
+		Primary:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", second.Image.ContentType, "the type is sniffed when none is declared")
+	assert.Equal(t, 1, second.Image.Position)
+	assert.True(t, second.Result.Images[1].Primary)
+	assert.False(t, second.Result.Images[0].Primary)
+
+	opened, err := service.OpenProductImage(ctx, &query.GetProductImageQuery{ProductId: product.Id, ImageId: first.Image.Id})
+	require.NoError(t, err)
+	data, err := io.ReadAll(opened.Body)
+	require.NoError(t, err)
+	assert.Equal(t, pngData, data)
+	_, err = service.OpenProductImage(ctx, &query.GetProductImageQuery{ProductId: product.Id, ImageId: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrImageNotFound)
+
+	reordered, err := service.ReorderProductImages(ctx, &command.ReorderProductImagesCommand{
+		ProductId: product.Id,
+		ImageIds:  []uuid.UUID{second.Image.Id, first.Image.Id},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, second.Image.Id, reordered.Result.Images[0].Id)
+
+	primary, err := service.SetPrimaryProductImage(ctx, &command.SetPrimaryProductImageCommand{ProductId: product.Id, ImageId: first.Image.Id})
+	require.NoError(t, err)
+	assert.True(t, primary.Result.Images[1].Primary)
+
+	removed, err := service.RemoveProductImage(ctx, &command.RemoveProductImageCommand{ProductId: product.Id, ImageId: first.Image.Id})
+	require.NoError(t, err)
+	require.Len(t, removed.Result.Images, 1)
+	assert.True(t, removed.Result.Images[0].Primary)
+	_, err = service.RemoveProductImage(ctx, &command.RemoveProductImageCommand{ProductId: product.Id, ImageId: first.Image.Id})
+	assert.ErrorIs(t, err, entities.ErrImageNotFound)
+
+	images, err := service.GetProductImages(ctx, &query.GetProductImagesQuery{ProductId: product.Id})
+	require.NoError(t, err)
+	assert.Equal(t, removed.Result, images.Result)
+	_, err = service.GetProductImages(ctx, &query.GetProductImagesQuery{ProductId: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+}
+
+func TestMediaService_UploadProductImage_Rejected(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	blobs := NewMockBlobStore()
+	service := NewMediaService(productRepo, blobs, &MockBlobDeletionRepository{}, NewMockIdempotencyRepository(), int64(len(pngData)))
+	ctx := context.Background()
+	product := createPersistedProduct(t, productRepo)
+	stale := product.Version + 1
+
+	for name, tc := range map[string]struct {
+		cmd command.UploadProductImageCommand
+		err error
+	}{
+		"too large":     {command.UploadProductImageCommand{ProductId: product.Id, Data: append(pngData, 0)}, entities.ErrImageTooLarge},
+		"empty":         {command.UploadProductImageCommand{ProductId: product.Id}, entities.ErrValidation},
+		"not an image":  {command.UploadProductImageCommand{ProductId: product.Id, Data: []byte("%PDF-1.7 ...")}, entities.ErrUnsupportedMediaType},
+		"wrong type":    {command.UploadProductImageCommand{ProductId: product.Id, Data: pngData, ContentType: "image/jpeg"}, entities.ErrUnsupportedMediaType},
+		"stale version": {command.UploadProductImageCommand{ProductId: product.Id, Data: pngData, ExpectedVersion: &stale}, entities.ErrVersionConflict},
+		"no product":    {command.UploadProductImageCommand{ProductId: uuid.New(), Data: pngData}, entities.ErrProductNotFound},
+	} {
+		_, err := service.UploadProductImage(ctx, &tc.cmd)
+		assert.ErrorIs(t, err, tc.err, name)
+	}
+	assert.Empty(t, blobs.blobs, "rejected uploads store nothing")
+}
+
+func TestMediaService_UploadProductImage_UnsavedProductDeletesBlob(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	blobs := NewMockBlobStore()
+	service := NewMediaService(failingUpdateProductRepository{productRepo}, blobs, &MockBlobDeletionRepository{}, NewMockIdempotencyRepository(), 1024)
+	product := createPersistedProduct(t, productRepo)
+
+	_, err := service.UploadProductImage(context.Background(), &command.UploadProductImageCommand{ProductId: product.Id, Data: pngData})
+
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+	assert.Empty(t, blobs.blobs)
+}
+
+func TestMediaService_UploadProductImage_Idempotent(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	blobs := NewMockBlobStore()
+	service := NewMediaService(productRepo, blobs, &MockBlobDeletionRepository{}, NewMockIdempotencyRepository(), 1024)
+	ctx := context.Background()
+	product := createPersistedProduct(t, productRepo)
+
+	first, err := service.UploadProductImage(ctx, &command.UploadProductImageCommand{IdempotencyKey: "upload-1", ProductId: product.Id, Data: pngData})
+	require.NoError(t, err)
+	replayed, err := service.UploadProductImage(ctx, &command.UploadProductImageCommand{IdempotencyKey: "upload-1", ProductId: product.Id, Data: pngData})
+	require.NoError(t, err)
+	assert.Equal(t, first.Image.Id, replayed.Image.Id)
+	assert.Len(t, blobs.blobs, 1, "a replay uploads nothing")
+
+	_, err = service.UploadProductImage(ctx, &command.UploadProductImageCommand{IdempotencyKey: "upload-1", ProductId: product.Id, Data: append(pngData, 1)})
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReuse, "other data under the same key")
+}
+
+func TestMediaService_PurgeDeletedBlobs(t *testing.T) {
+	blobs := NewMockBlobStore()
+	blobs.blobs["a.png"] = pngData
+	blobs.blobs["b.png"] = pngData
+	blobs.blobs["kept.png"] = pngData
+	deletions := &MockBlobDeletionRepository{keys: []string{"a.png", "b.png", "gone.png"}}
+	service := NewMediaService(&MockProductRepository{}, blobs, deletions, NewMockIdempotencyRepository(), 1024)
+	ctx := context.Background()
+
+	purged, err := service.PurgeDeletedBlobs(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	assert.Equal(t, []string{"gone.png"}, deletions.keys)
+	assert.Equal(t, []string{"kept.png"}, keys(blobs.blobs))
+
+	blobs.deleteErr = errors.New("store unavailable")
+	purged, err = service.PurgeDeletedBlobs(ctx, 10)
+	assert.Error(t, err)
+	assert.Zero(t, purged)
+	assert.Equal(t, []string{"gone.png"}, deletions.keys, "a failed delete stays scheduled")
+}
+
+func keys(blobs map[string][]byte) []string {
+	var names []string
+	for name := range blobs {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,364 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// OrderService runs checkout and every status change in one transaction
+// with the stock changes they imply: creating an order reserves its units,
+// paying commits them, cancelling releases them.
+type OrderService struct {
+	orderRepository     repositories.OrderRepository
+	productRepository   repositories.ProductRepository
+	promotionRepository repositories.PromotionRepository
+	inventory           interfaces.InventoryService
+	transactor          repositories.Transactor
+	idempotencyRepo     repositories.IdempotencyRepository
+	// reservationTTL is how long a pending order holds its stock. Paying
+	// after that fails unless the units are still reserved.
+	reservationTTL time.Duration
+}
+
+func NewOrderService(
+	orderRepository repositories.OrderRepository,
+	productRepository repositories.ProductRepository,
+	promotionRepository repositories.PromotionRepository,
+	inventory interfaces.InventoryService,
+	transactor repositories.Transactor,
+	idempotencyRepo repositories.IdempotencyRepository,
+	reservationTTL time.Duration,
+) interfaces.OrderService {
+	return &OrderService{
+		orderRepository:     orderRepository,
+		productRepository:   productRepository,
+		promotionRepository: promotionRepository,
+		inventory:           inventory,
+		transactor:          transactor,
+		idempotencyRepo:     idempotencyRepo,
+		reservationTTL:      reservationTTL,
+	}
+}
+
+// CreateOrder checks out: it snapshots each variant's current name, SKU and
+// effective price, reserves the units and redeems the promotions the prices
+// used, once per order each. If any product or variant is missing, not
+// published or out of stock, or a promotion ran out of redemptions since
+// the prices were computed, nothing is reserved or redeemed.
+func (s *OrderService) CreateOrder(ctx context.Context, orderCommand *command.CreateOrderCommand) (*command.CreateOrderCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, orderCommand.IdempotencyKey, orderCommand, func() (*command.CreateOrderCommandResult, error) {
+		var created *entities.Order
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			products := make([]*entities.Product, 0, len(orderCommand.Items))
+			variants := make([]entities.ProductVariant, 0, len(orderCommand.Items))
+			var sellerIds []uuid.UUID
+			for _, line := range orderCommand.Items {
+				product, err := s.productRepository.FindById(ctx, line.ProductId)
+				if err != nil {
+					return err
+				}
+				if product == nil {
+					return fmt.Errorf("%w: %s", entities.ErrProductNotFound, line.ProductId)
+				}
+				if !product.IsPublished() {
+					return fmt.Errorf("%w: %s", entities.ErrProductNotPublished, product.Name)
+				}
+				variant, err := product.ResolveVariant(line.VariantId)
+				if err != nil {
+					return err
+				}
+				products = append(products, product)
+				variants = append(variants, variant)
+				if !slices.Contains(sellerIds, product.SellerId) {
+					sellerIds = append(sellerIds, product.SellerId)
+				}
+			}
+
+			now := time.Now()
+			promotions, err := s.promotionRepository.FindActive(ctx, sellerIds, now)
+			if err != nil {
+				return err
+			}
+
+			items := make([]entities.OrderItem, 0, len(orderCommand.Items))
+			var redeemed []*entities.Promotion
+			for index, line := range orderCommand.Items {
+				product, variant := products[index], variants[index]
+				unitPrice, applied, err := entities.EffectiveVariantPrice(product, variant, promotions, now)
+				if err != nil {
+					return err
+				}
+				for _, promotion := range applied {
+					if !slices.Contains(redeemed, promotion) {
+						redeemed = append(redeemed, promotion)
+					}
+				}
+				items = append(items, entities.OrderItem{
+					ProductId:   product.Id,
+					VariantId:   variant.Id,
+					Sku:         variant.Sku,
+					ProductName: product.Name,
+					UnitPrice:   unitPrice,
+					Quantity:    line.Quantity,
+				})
+			}
+
+			validatedOrder, err := entities.NewValidatedOrder(entities.NewOrder(orderCommand.BuyerId, items))
+			if err != nil {
+				return err
+			}
+
+			// Inventory rows are locked in variant id order, so two
+			// checkouts of the same variants cannot deadlock.
+			lines := make([]*entities.OrderItem, 0, len(validatedOrder.Items))
+			for i := range validatedOrder.Items {
+				lines = append(lines, &validatedOrder.Items[i])
+			}
+			slices.SortFunc(lines, func(a, b *entities.OrderItem) int {
+				return cmp.Compare(a.VariantId.String(), b.VariantId.String())
+			})
+			for _, line := range lines {
+				reserved, err := s.inventory.ReserveStock(ctx, &command.ReserveStockCommand{
+					ProductId: line.ProductId,
+					VariantId: line.VariantId,
+					Quantity:  line.Quantity,
+					TTL:       s.reservationTTL,
+				})
+				if err != nil {
+					return err
+				}
+				line.ReservationId = reserved.Reservation.Id
+			}
+
+			if err := s.redeemPromotions(ctx, redeemed, now); err != nil {
+				return err
+			}
+
+			created, err = s.orderRepository.Create(ctx, validatedOrder)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.CreateOrderCommandResult{Result: mapper.NewOrderResultFromEntity(created)}, nil
+	})
+}
+
+// redeemPromotions counts one use of each promotion. Each redemption is a
+// single conditional increment, so concurrent checkouts of the same
+// promotion do not conflict; only one that would go over the usage limit
+// fails, with ErrPromotionExhausted. Like stock, promotions are locked in id
+// order, so two checkouts cannot deadlock.
+func (s *OrderService) redeemPromotions(ctx context.Context, promotions []*entities.Promotion, at time.Time) error {
+	slices.SortFunc(promotions, func(a, b *entities.Promotion) int {
+		return cmp.Compare(a.Id.String(), b.Id.String())
+	})
+	for _, promotion := range promotions {
+		if _, err := s.promotionRepository.Redeem(ctx, promotion.Id, at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PayOrder turns the order's reservations into sales. A reservation that
+// expired meanwhile fails the payment with ErrInsufficientStock.
+func (s *OrderService) PayOrder(ctx context.Context, orderCommand *command.PayOrderCommand) (*command.PayOrderCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, orderCommand.IdempotencyKey, orderCommand, func() (*command.PayOrderCommandResult, error) {
+		result, err := s.transition(ctx, orderCommand.Id, orderCommand.ExpectedVersion, func(ctx context.Context, order *entities.Order) error {
+			if err := order.MarkPaid(); err != nil {
+				return err
+			}
+			for _, item := range order.Items {
+				_, err := s.inventory.CommitReservation(ctx, &command.CommitReservationCommand{
+					VariantId:     item.VariantId,
+					ReservationId: item.ReservationId,
+				})
+				if errors.Is(err, entities.ErrReservationNotFound) {
+					return fmt.Errorf("%w: the reservation of %s expired", entities.ErrInsufficientStock, item.ProductName)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.PayOrderCommandResult{Result: result}, nil
+	})
+}
+
+func (s *OrderService) ShipOrder(ctx context.Context, orderCommand *command.ShipOrderCommand) (*command.ShipOrderCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, orderCommand.IdempotencyKey, orderCommand, func() (*command.ShipOrderCommandResult, error) {
+		result, err := s.transition(ctx, orderCommand.Id, orderCommand.ExpectedVersion, func(ctx context.Context, order *entities.Order) error {
+			return order.Ship(orderCommand.TrackingNumber)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.ShipOrderCommandResult{Result: result}, nil
+	})
+}
+
+func (s *OrderService) DeliverOrder(ctx context.Context, orderCommand *command.DeliverOrderCommand) (*command.DeliverOrderCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, orderCommand.IdempotencyKey, orderCommand, func() (*command.DeliverOrderCommandResult, error) {
+		result, err := s.transition(ctx, orderCommand.Id, orderCommand.ExpectedVersion, func(ctx context.Context, order *entities.Order) error {
+			return order.Deliver()
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.DeliverOrderCommandResult{Result: result}, nil
+	})
+}
+
+// CancelOrder releases the order's reservations. Expired ones were already
+// released and are skipped.
+func (s *OrderService) CancelOrder(ctx context.Context, orderCommand *command.CancelOrderCommand) (*command.CancelOrderCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, orderCommand.IdempotencyKey, orderCommand, func() (*command.CancelOrderCommandResult, error) {
+		result, err := s.transition(ctx, orderCommand.Id, orderCommand.ExpectedVersion, func(ctx context.Context, order *entities.Order) error {
+			if err := order.Cancel(orderCommand.Reason); err != nil {
+				return err
+			}
+			for _, item := range order.Items {
+				_, err := s.inventory.ReleaseReservation(ctx, &command.ReleaseReservationCommand{
+					VariantId:     item.VariantId,
+					ReservationId: item.ReservationId,
+				})
+				if err != nil && !errors.Is(err, entities.ErrReservationNotFound) {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.CancelOrderCommandResult{Result: result}, nil
+	})
+}
+
+// RefundOrder does not restock: whether returned goods can be sold again
+// is decided when they arrive, via a stock adjustment.
+func (s *OrderService) RefundOrder(ctx context.Context, orderCommand *command.RefundOrderCommand) (*command.RefundOrderCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, orderCommand.IdempotencyKey, orderCommand, func() (*command.RefundOrderCommandResult, error) {
+		result, err := s.transition(ctx, orderCommand.Id, orderCommand.ExpectedVersion, func(ctx context.Context, order *entities.Order) error {
+			return order.Refund(orderCommand.Reason)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.RefundOrderCommandResult{Result: result}, nil
+	})
+}
+
+// FindAllOrders returns one page of orders. It fetches one row more than
+// requested to learn whether a next page exists without a COUNT.
+func (s *OrderService) FindAllOrders(ctx context.Context, orderQuery *query.GetAllOrdersQuery) (*query.GetAllOrdersQueryResult, error) {
+	limit, err := pageSize(orderQuery.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := repositories.OrderListCriteria{BuyerId: orderQuery.BuyerId, Limit: limit + 1}
+	cursor, err := decodeCursor(orderQuery.Cursor, orderSortOrder)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != nil {
+		criteria.After = &repositories.OrderCursor{Id: cursor.Id, CreatedAt: cursor.CreatedAt}
+	}
+
+	storedOrders, err := s.orderRepository.FindAll(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryListResult query.GetAllOrdersQueryResult
+	if len(storedOrders) > limit {
+		storedOrders = storedOrders[:limit]
+		last := storedOrders[limit-1]
+		queryListResult.NextCursor = encodeCursor(pageCursor{Sort: orderSortOrder, Id: last.Id, CreatedAt: last.CreatedAt})
+	}
+
+	for _, order := range storedOrders {
+		queryListResult.Result = append(queryListResult.Result, mapper.NewOrderResultFromEntity(order))
+	}
+
+	return &queryListResult, nil
+}
+
+// orderSortOrder is the only order listing order: newest first.
+const orderSortOrder = "created_at"
+
+func (s *OrderService) FindOrderById(ctx context.Context, orderQuery *query.GetOrderByIdQuery) (*query.GetOrderByIdQueryResult, error) {
+	storedOrder, err := s.orderRepository.FindById(ctx, orderQuery.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Not found: let the caller translate this into a 404.
+	if storedOrder == nil {
+		return nil, nil
+	}
+
+	return &query.GetOrderByIdQueryResult{Result: mapper.NewOrderResultFromEntity(storedOrder)}, nil
+}
+
+// transition loads the order, applies change and saves the order in one
+// transaction with whatever change wrote, e.g. stock commits.
+func (s *OrderService) transition(ctx context.Context, id uuid.UUID, expectedVersion *int, change func(ctx context.Context, order *entities.Order) error) (*common.OrderResult, error) {
+	var updated *entities.Order
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.orderRepository.FindById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return entities.ErrOrderNotFound
+		}
+
+		if err := checkExpectedVersion(expectedVersion, order.Version); err != nil {
+			return err
+		}
+
+		if err := change(ctx, order); err != nil {
+			return err
+		}
+
+		validatedOrder, err := entities.NewValidatedOrder(order)
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.orderRepository.Update(ctx, validatedOrder)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.NewOrderResultFromEntity(updated), nil
+}
@@ -0,0 +1,444 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// MockOrderRepository keeps orders in creation order and checks versions
+// like the real repository.
+type MockOrderRepository struct {
+	orders []entities.Order
+	events []events.DomainEvent
+}
+
+func (m *MockOrderRepository) Create(ctx context.Context, order *entities.ValidatedOrder) (*entities.Order, error) {
+	m.events = append(m.events, order.PullEvents()...)
+	m.orders = append(m.orders, order.Order)
+	return m.FindById(ctx, order.Id)
+}
+
+func (m *MockOrderRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	for _, order := range m.orders {
+		if order.Id == id {
+			order.Items = append([]entities.OrderItem(nil), order.Items...)
+			return &order, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockOrderRepository) FindAll(ctx context.Context, criteria repositories.OrderListCriteria) ([]*entities.Order, error) {
+	var orders []*entities.Order
+	skipping := criteria.After != nil
+	for i := len(m.orders) - 1; i >= 0 && len(orders) < criteria.Limit; i-- {
+		order := m.orders[i]
+		if skipping {
+			skipping = order.Id != criteria.After.Id
+			continue
+		}
+		if criteria.BuyerId != uuid.Nil && order.BuyerId != criteria.BuyerId {
+			continue
+		}
+		orders = append(orders, &order)
+	}
+	return orders, nil
+}
+
+func (m *MockOrderRepository) Update(ctx context.Context, order *entities.ValidatedOrder) (*entities.Order, error) {
+	for i, stored := range m.orders {
+		if stored.Id != order.Id {
+			continue
+		}
+		if stored.Version != order.Version {
+			return nil, entities.ErrVersionConflict
+		}
+		m.events = append(m.events, order.PullEvents()...)
+		m.orders[i] = order.Order
+		m.orders[i].Version++
+		return m.FindById(ctx, order.Id)
+	}
+	return nil, entities.ErrOrderNotFound
+}
+
+func createPublishedProduct(t *testing.T, productRepo *MockProductRepository, seller *entities.ValidatedSeller, name string, minorUnits int64, currency entities.Currency, variants ...entities.ProductVariant) *entities.ValidatedProduct {
+	t.Helper()
+	price, err := entities.NewMoney(minorUnits, currency)
+	require.NoError(t, err)
+	draft := entities.NewProduct(name, price, *seller, variants...)
+	require.NoError(t, draft.Publish())
+	product, err := entities.NewValidatedProduct(draft)
+	require.NoError(t, err)
+	productRepo.products = append(productRepo.products, product)
+	return product
+}
+
+func adjustStock(t *testing.T, inventoryService interfaces.InventoryService, product *entities.ValidatedProduct, variantId uuid.UUID, delta int) {
+	t.Helper()
+	_, err := inventoryService.AdjustStock(context.Background(), &command.AdjustStockCommand{ProductId: product.Id, VariantId: variantId, Delta: delta})
+	require.NoError(t, err)
+}
+
+// checkout orders two widgets and one gadget.
+func checkout(t *testing.T, service interfaces.OrderService, widget, gadget *entities.ValidatedProduct) *common.OrderResult {
+	t.Helper()
+	created, err := service.CreateOrder(context.Background(), &command.CreateOrderCommand{
+		BuyerId: uuid.New(),
+		Items: []command.CreateOrderItem{
+			{ProductId: widget.Id, Quantity: 2},
+			{ProductId: gadget.Id, Quantity: 1},
+		},
+	})
+	require.NoError(t, err)
+	return created.Result
+}
+
+func TestOrderService_CreateOrder_SnapshotsAndReserves(t *testing.T) {
+	orderRepo := &MockOrderRepository{}
+	productRepo := &MockProductRepository{}
+	inventoryRepo := NewMockInventoryRepository(productRepo)
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(inventoryRepo, productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(orderRepo, productRepo, &MockPromotionRepository{}, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	seller := createPersistedSeller(t, &MockSellerRepository{})
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 999, entities.USD)
+	gadget := createPublishedProduct(t, productRepo, seller, "Gadget", 2500, entities.EUR)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	adjustStock(t, inventoryService, gadget, uuid.Nil, 2)
+
+	result := checkout(t, service, widget, gadget)
+
+	assert.Equal(t, string(entities.OrderPending), result.Status)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, "Widget", result.Items[0].ProductName)
+	assert.Equal(t, widget.Price, result.Items[0].UnitPrice)
+	assert.Equal(t, int64(1998), result.Items[0].Total.MinorUnits())
+	require.Len(t, result.Totals, 2, "one total per currency")
+	assert.Equal(t, entities.EUR, result.Totals[0].Currency())
+	assert.Equal(t, int64(2500), result.Totals[0].MinorUnits())
+	assert.Equal(t, int64(1998), result.Totals[1].MinorUnits())
+
+	assert.Equal(t, 2, inventoryRepo.load(widget.Variants[0].Id).Reserved())
+	assert.Equal(t, 1, inventoryRepo.load(gadget.Variants[0].Id).Reserved())
+	assert.Equal(t, []string{events.OrderCreatedEventName}, eventNames(orderRepo.events))
+}
+
+func TestOrderService_CreateOrder_ChargesAndRedeemsPromotions(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	promotionRepo := &MockPromotionRepository{}
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(NewMockInventoryRepository(productRepo), productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(&MockOrderRepository{}, productRepo, promotionRepo, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	ctx := context.Background()
+	seller := createPersistedSeller(t, &MockSellerRepository{})
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 999, entities.USD)
+	gadget := createPublishedProduct(t, productRepo, seller, "Gadget", 2500, entities.EUR)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 6)
+	adjustStock(t, inventoryService, gadget, uuid.Nil, 3)
+	promotion, err := entities.NewValidatedPromotion(entities.NewPromotion(seller.Id, "Spring sale", entities.NewPercentageDiscount(2000), entities.PromotionTerms{
+		StartsAt:   time.Now().Add(-time.Minute),
+		UsageLimit: 1,
+	}))
+	require.NoError(t, err)
+	_, err = promotionRepo.Create(ctx, promotion)
+	require.NoError(t, err)
+
+	discounted := checkout(t, service, widget, gadget)
+	assert.Equal(t, int64(799), discounted.Items[0].UnitPrice.MinorUnits())
+	assert.Equal(t, int64(2000), discounted.Items[1].UnitPrice.MinorUnits())
+
+	redeemed, err := promotionRepo.FindById(ctx, promotion.Id)
+	require.NoError(t, err)
+	assert.Equal(t, 1, redeemed.UsageCount, "one redemption per order, however many lines it discounts")
+
+	// The only redemption is used up: the next order pays the list price.
+	full := checkout(t, service, widget, gadget)
+	assert.Equal(t, widget.Price, full.Items[0].UnitPrice)
+	assert.Equal(t, gadget.Price, full.Items[1].UnitPrice)
+
+	giveaway, err := entities.NewValidatedPromotion(entities.NewPromotion(seller.Id, "Free gadget", entities.NewPercentageDiscount(10000), entities.PromotionTerms{
+		ProductIds: []uuid.UUID{gadget.Id},
+		StartsAt:   time.Now().Add(-time.Minute),
+	}))
+	require.NoError(t, err)
+	_, err = promotionRepo.Create(ctx, giveaway)
+	require.NoError(t, err)
+
+	free := checkout(t, service, widget, gadget)
+	assert.Zero(t, free.Items[1].UnitPrice.MinorUnits(), "a fully discounted item is ordered at zero")
+	assert.Equal(t, int64(1998), free.Totals[1].MinorUnits(), "totals are ordered by currency, EUR first")
+}
+
+// usedUpPromotionRepository prices with its promotions but finds them used
+// up when checkout redeems them, as if concurrent orders took the last
+// redemptions in between.
+type usedUpPromotionRepository struct {
+	*MockPromotionRepository
+}
+
+func (r usedUpPromotionRepository) Redeem(ctx context.Context, id uuid.UUID, at time.Time) (*entities.Promotion, error) {
+	return nil, entities.ErrPromotionExhausted
+}
+
+func TestOrderService_CreateOrder_PromotionUsedUpMeanwhile(t *testing.T) {
+	orderRepo := &MockOrderRepository{}
+	productRepo := &MockProductRepository{}
+	inventoryRepo := NewMockInventoryRepository(productRepo)
+	promotionRepo := &MockPromotionRepository{}
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(inventoryRepo, productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(orderRepo, productRepo, usedUpPromotionRepository{promotionRepo}, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	ctx := context.Background()
+	seller := createPersistedSeller(t, &MockSellerRepository{})
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 999, entities.USD)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	promotion, err := entities.NewValidatedPromotion(entities.NewPromotion(seller.Id, "Flash sale", entities.NewPercentageDiscount(5000), entities.PromotionTerms{
+		StartsAt:   time.Now().Add(-time.Minute),
+		UsageLimit: 1,
+	}))
+	require.NoError(t, err)
+	_, err = promotionRepo.Create(ctx, promotion)
+	require.NoError(t, err)
+
+	_, err = service.CreateOrder(ctx, &command.CreateOrderCommand{
+		BuyerId: uuid.New(),
+		Items:   []command.CreateOrderItem{{ProductId: widget.Id, Quantity: 1}},
+	})
+	assert.ErrorIs(t, err, entities.ErrPromotionExhausted, "the buyer is not charged more than the price they saw")
+	assert.Empty(t, orderRepo.orders)
+}
+
+func TestOrderService_CreateOrder_Variants(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	inventoryRepo := NewMockInventoryRepository(productRepo)
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(inventoryRepo, productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(&MockOrderRepository{}, productRepo, &MockPromotionRepository{}, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	ctx := context.Background()
+	override, err := entities.NewMoney(1299, entities.USD)
+	require.NoError(t, err)
+	small := entities.NewProductVariant("TEE-S", map[string]string{"size": "S"}, nil)
+	large := entities.NewProductVariant("TEE-L", map[string]string{"size": "L"}, &override)
+	shirt := createPublishedProduct(t, productRepo, createPersistedSeller(t, &MockSellerRepository{}), "T-shirt", 999, entities.USD, small, large)
+	adjustStock(t, inventoryService, shirt, large.Id, 1)
+
+	_, err = service.CreateOrder(ctx, &command.CreateOrderCommand{
+		BuyerId: uuid.New(),
+		Items:   []command.CreateOrderItem{{ProductId: shirt.Id, Quantity: 1}},
+	})
+	assert.ErrorIs(t, err, entities.ErrValidation, "the variant must be named")
+	_, err = service.CreateOrder(ctx, &command.CreateOrderCommand{
+		BuyerId: uuid.New(),
+		Items:   []command.CreateOrderItem{{ProductId: shirt.Id, VariantId: small.Id, Quantity: 1}},
+	})
+	assert.ErrorIs(t, err, entities.ErrInsufficientStock, "stock is kept per variant")
+
+	created, err := service.CreateOrder(ctx, &command.CreateOrderCommand{
+		BuyerId: uuid.New(),
+		Items:   []command.CreateOrderItem{{ProductId: shirt.Id, VariantId: large.Id, Quantity: 1}},
+	})
+	require.NoError(t, err)
+	item := created.Result.Items[0]
+	assert.Equal(t, large.Id, item.VariantId)
+	assert.Equal(t, "TEE-L", item.Sku)
+	assert.Equal(t, override, item.UnitPrice, "the price override applies")
+	assert.Equal(t, 1, inventoryRepo.load(large.Id).Reserved())
+}
+
+func TestOrderService_CreateOrder_Rejections(t *testing.T) {
+	orderRepo := &MockOrderRepository{}
+	productRepo := &MockProductRepository{}
+	inventoryRepo := NewMockInventoryRepository(productRepo)
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(inventoryRepo, productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(orderRepo, productRepo, &MockPromotionRepository{}, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	ctx := context.Background()
+	gadget := createPublishedProduct(t, productRepo, createPersistedSeller(t, &MockSellerRepository{}), "Gadget", 2500, entities.EUR)
+	adjustStock(t, inventoryService, gadget, uuid.Nil, 2)
+
+	_, err := service.CreateOrder(ctx, &command.CreateOrderCommand{
+		BuyerId: uuid.New(),
+		Items:   []command.CreateOrderItem{{ProductId: gadget.Id, Quantity: 3}},
+	})
+	assert.ErrorIs(t, err, entities.ErrInsufficientStock)
+
+	_, err = service.CreateOrder(ctx, &command.CreateOrderCommand{
+		BuyerId: uuid.New(),
+		Items:   []command.CreateOrderItem{{ProductId: uuid.New(), Quantity: 1}},
+	})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+
+	_, err = service.CreateOrder(ctx, &command.CreateOrderCommand{BuyerId: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+
+	require.NoError(t, gadget.Archive())
+	_, err = service.CreateOrder(ctx, &command.CreateOrderCommand{
+		BuyerId: uuid.New(),
+		Items:   []command.CreateOrderItem{{ProductId: gadget.Id, Quantity: 1}},
+	})
+	assert.ErrorIs(t, err, entities.ErrProductNotPublished)
+
+	assert.Empty(t, orderRepo.orders)
+	assert.Zero(t, inventoryRepo.load(gadget.Variants[0].Id).Reserved())
+}
+
+func TestOrderService_PayShipDeliverRefund(t *testing.T) {
+	orderRepo := &MockOrderRepository{}
+	productRepo := &MockProductRepository{}
+	inventoryRepo := NewMockInventoryRepository(productRepo)
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(inventoryRepo, productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(orderRepo, productRepo, &MockPromotionRepository{}, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	ctx := context.Background()
+	seller := createPersistedSeller(t, &MockSellerRepository{})
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 999, entities.USD)
+	gadget := createPublishedProduct(t, productRepo, seller, "Gadget", 2500, entities.EUR)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	adjustStock(t, inventoryService, gadget, uuid.Nil, 2)
+	orderId := checkout(t, service, widget, gadget).Id
+
+	paid, err := service.PayOrder(ctx, &command.PayOrderCommand{Id: orderId})
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.OrderPaid), paid.Result.Status)
+	widgetStock := inventoryRepo.inventories[widget.Variants[0].Id]
+	assert.Equal(t, 3, widgetStock.OnHand, "paying commits the reserved units")
+	assert.Zero(t, widgetStock.Reserved())
+
+	_, err = service.PayOrder(ctx, &command.PayOrderCommand{Id: orderId})
+	assert.ErrorIs(t, err, entities.ErrInvalidOrderTransition)
+
+	shipped, err := service.ShipOrder(ctx, &command.ShipOrderCommand{Id: orderId, TrackingNumber: "1Z999"})
+	require.NoError(t, err)
+	assert.Equal(t, "1Z999", shipped.Result.TrackingNumber)
+
+	_, err = service.DeliverOrder(ctx, &command.DeliverOrderCommand{Id: orderId})
+	require.NoError(t, err)
+
+	refunded, err := service.RefundOrder(ctx, &command.RefundOrderCommand{Id: orderId, Reason: "damaged"})
+	require.NoError(t, err)
+	assert.Equal(t, string(entities.OrderRefunded), refunded.Result.Status)
+	assert.Equal(t, 5, refunded.Result.Version)
+	assert.Equal(t, 3, inventoryRepo.inventories[widget.Variants[0].Id].OnHand, "refunds do not restock")
+
+	assert.Equal(t, []string{
+		events.OrderCreatedEventName, events.OrderPaidEventName, events.OrderShippedEventName,
+		events.OrderDeliveredEventName, events.OrderRefundedEventName,
+	}, eventNames(orderRepo.events))
+}
+
+func TestOrderService_PayOrder_ExpiredReservation(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	inventoryRepo := NewMockInventoryRepository(productRepo)
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(inventoryRepo, productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(&MockOrderRepository{}, productRepo, &MockPromotionRepository{}, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	seller := createPersistedSeller(t, &MockSellerRepository{})
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 999, entities.USD)
+	gadget := createPublishedProduct(t, productRepo, seller, "Gadget", 2500, entities.EUR)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	adjustStock(t, inventoryService, gadget, uuid.Nil, 2)
+	orderId := checkout(t, service, widget, gadget).Id
+	expireReservations(inventoryRepo, gadget.Variants[0].Id)
+
+	_, err := service.PayOrder(context.Background(), &command.PayOrderCommand{Id: orderId})
+	assert.ErrorIs(t, err, entities.ErrInsufficientStock)
+}
+
+func TestOrderService_CancelOrder_ReleasesStock(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	inventoryRepo := NewMockInventoryRepository(productRepo)
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(inventoryRepo, productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(&MockOrderRepository{}, productRepo, &MockPromotionRepository{}, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	ctx := context.Background()
+	seller := createPersistedSeller(t, &MockSellerRepository{})
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 999, entities.USD)
+	gadget := createPublishedProduct(t, productRepo, seller, "Gadget", 2500, entities.EUR)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	adjustStock(t, inventoryService, gadget, uuid.Nil, 2)
+	orderId := checkout(t, service, widget, gadget).Id
+	expireReservations(inventoryRepo, gadget.Variants[0].Id)
+
+	cancelled, err := service.CancelOrder(ctx, &command.CancelOrderCommand{Id: orderId, Reason: "changed my mind"})
+	require.NoError(t, err, "expired reservations are skipped")
+	assert.Equal(t, string(entities.OrderCancelled), cancelled.Result.Status)
+	assert.Zero(t, inventoryRepo.load(widget.Variants[0].Id).Reserved())
+	assert.Equal(t, 5, inventoryRepo.inventories[widget.Variants[0].Id].OnHand)
+
+	_, err = service.ShipOrder(ctx, &command.ShipOrderCommand{Id: orderId})
+	assert.ErrorIs(t, err, entities.ErrInvalidOrderTransition)
+}
+
+func TestOrderService_Transitions_NotFoundAndVersion(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(NewMockInventoryRepository(productRepo), productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(&MockOrderRepository{}, productRepo, &MockPromotionRepository{}, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	ctx := context.Background()
+	seller := createPersistedSeller(t, &MockSellerRepository{})
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 999, entities.USD)
+	gadget := createPublishedProduct(t, productRepo, seller, "Gadget", 2500, entities.EUR)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	adjustStock(t, inventoryService, gadget, uuid.Nil, 2)
+	orderId := checkout(t, service, widget, gadget).Id
+
+	_, err := service.ShipOrder(ctx, &command.ShipOrderCommand{Id: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrOrderNotFound)
+
+	stale := 7
+	_, err = service.PayOrder(ctx, &command.PayOrderCommand{Id: orderId, ExpectedVersion: &stale})
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+}
+
+func TestOrderService_FindOrders(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	transactor := &MockTransactor{}
+	inventoryService := NewInventoryService(NewMockInventoryRepository(productRepo), productRepo, transactor, NewMockIdempotencyRepository())
+	service := NewOrderService(&MockOrderRepository{}, productRepo, &MockPromotionRepository{}, inventoryService, transactor, NewMockIdempotencyRepository(), time.Minute)
+	ctx := context.Background()
+	seller := createPersistedSeller(t, &MockSellerRepository{})
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 999, entities.USD)
+	gadget := createPublishedProduct(t, productRepo, seller, "Gadget", 2500, entities.EUR)
+	adjustStock(t, inventoryService, widget, uuid.Nil, 5)
+	adjustStock(t, inventoryService, gadget, uuid.Nil, 2)
+	first := checkout(t, service, widget, gadget)
+	second := checkout(t, service, widget, gadget)
+
+	found, err := service.FindOrderById(ctx, &query.GetOrderByIdQuery{Id: first.Id})
+	require.NoError(t, err)
+	assert.Equal(t, first.Id, found.Result.Id)
+
+	missing, err := service.FindOrderById(ctx, &query.GetOrderByIdQuery{Id: uuid.New()})
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	page, err := service.FindAllOrders(ctx, &query.GetAllOrdersQuery{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Result, 1)
+	assert.Equal(t, second.Id, page.Result[0].Id, "newest first")
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = service.FindAllOrders(ctx, &query.GetAllOrdersQuery{Limit: 1, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page.Result, 1)
+	assert.Equal(t, first.Id, page.Result[0].Id)
+	assert.Empty(t, page.NextCursor)
+
+	byBuyer, err := service.FindAllOrders(ctx, &query.GetAllOrdersQuery{BuyerId: first.BuyerId})
+	require.NoError(t, err)
+	require.Len(t, byBuyer.Result, 1)
+	assert.Equal(t, first.Id, byBuyer.Result[0].Id)
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+const (
+	defaultOutboxReplayPageSize = 20
+	maxOutboxReplayPageSize     = 100
+)
+
+type OutboxReplayService struct {
+	repo repositories.OutboxReplayRepository
+}
+
+func NewOutboxReplayService(repo repositories.OutboxReplayRepository) interfaces.OutboxReplayService {
+	return &OutboxReplayService{repo: repo}
+}
+
+// StartOutboxReplay records a replay and runs it in the background. If the
+// process stops first, the replay can be resumed once its lease expires.
+func (s *OutboxReplayService) StartOutboxReplay(ctx context.Context, replayCommand *command.StartOutboxReplayCommand) (*command.StartOutboxReplayCommandResult, error) {
+	filter := entities.OutboxReplayFilter{
+		AggregateId: replayCommand.AggregateId,
+		EventName:   replayCommand.EventName,
+		From:        replayCommand.From,
+		To:          replayCommand.To,
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, filter, replayCommand.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	replay, err := s.repo.Resume(ctx, created.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.StartOutboxReplayCommandResult{
+		Result: mapper.NewOutboxReplayResultFromEntity(replay),
+	}, nil
+}
+
+func (s *OutboxReplayService) ResumeOutboxReplay(ctx context.Context, replayCommand *command.ResumeOutboxReplayCommand) (*command.ResumeOutboxReplayCommandResult, error) {
+	replay, err := s.repo.Resume(ctx, replayCommand.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.ResumeOutboxReplayCommandResult{
+		Result: mapper.NewOutboxReplayResultFromEntity(replay),
+	}, nil
+}
+
+func (s *OutboxReplayService) FindOutboxReplayById(ctx context.Context, replayQuery *query.GetOutboxReplayByIdQuery) (*query.GetOutboxReplayByIdQueryResult, error) {
+	replay, err := s.repo.FindById(ctx, replayQuery.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.GetOutboxReplayByIdQueryResult{
+		Result: mapper.NewOutboxReplayResultFromEntity(replay),
+	}, nil
+}
+
+// FindOutboxReplays returns the newest replays, at most
+// maxOutboxReplayPageSize of them.
+func (s *OutboxReplayService) FindOutboxReplays(ctx context.Context, replayQuery *query.GetOutboxReplaysQuery) (*query.GetOutboxReplaysQueryResult, error) {
+	limit := replayQuery.Limit
+	switch {
+	case limit < 0:
+		return nil, fmt.Errorf("%w: limit must not be negative", entities.ErrValidation)
+	case limit == 0:
+		limit = defaultOutboxReplayPageSize
+	case limit > maxOutboxReplayPageSize:
+		limit = maxOutboxReplayPageSize
+	}
+
+	replays, err := s.repo.FindAll(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.GetOutboxReplaysQueryResult{Result: make([]*common.OutboxReplayResult, 0, len(replays))}
+	for _, replay := range replays {
+		queryResult.Result = append(queryResult.Result, mapper.NewOutboxReplayResultFromEntity(replay))
+	}
+
+	return &queryResult, nil
+}
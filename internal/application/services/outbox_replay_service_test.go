@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// MockOutboxReplayRepository keeps replays in memory; Resume marks a
+// replay running instead of publishing anything.
+type MockOutboxReplayRepository struct {
+	targets []string
+	replays []*entities.OutboxReplay
+	limit   int
+}
+
+func (m *MockOutboxReplayRepository) Create(ctx context.Context, filter entities.OutboxReplayFilter, target string) (*entities.OutboxReplay, error) {
+	found := false
+	for _, known := range m.targets {
+		found = found || known == target
+	}
+	if !found {
+		return nil, fmt.Errorf("%w %q", entities.ErrUnknownReplayTarget, target)
+	}
+
+	replay := &entities.OutboxReplay{Id: uuid.New(), Target: target, Filter: filter, Status: entities.OutboxReplayPending}
+	m.replays = append(m.replays, replay)
+	return replay, nil
+}
+
+func (m *MockOutboxReplayRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.OutboxReplay, error) {
+	for _, replay := range m.replays {
+		if replay.Id == id {
+			return replay, nil
+		}
+	}
+	return nil, entities.ErrOutboxReplayNotFound
+}
+
+func (m *MockOutboxReplayRepository) FindAll(ctx context.Context, limit int) ([]*entities.OutboxReplay, error) {
+	m.limit = limit
+	return m.replays[:min(limit, len(m.replays))], nil
+}
+
+func (m *MockOutboxReplayRepository) Resume(ctx context.Context, id uuid.UUID) (*entities.OutboxReplay, error) {
+	replay, err := m.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if replay.Status == entities.OutboxReplayRunning || replay.Status == entities.OutboxReplayCompleted {
+		return nil, entities.ErrOutboxReplayNotResumable
+	}
+	replay.Status = entities.OutboxReplayRunning
+	return replay, nil
+}
+
+func TestOutboxReplayService_StartOutboxReplay(t *testing.T) {
+	repo := &MockOutboxReplayRepository{targets: []string{"log", "kafka"}}
+	service := NewOutboxReplayService(repo)
+	ctx := context.Background()
+
+	aggregateId := uuid.New()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	started, err := service.StartOutboxReplay(ctx, &command.StartOutboxReplayCommand{
+		Target:      "kafka",
+		AggregateId: aggregateId,
+		From:        from,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "running", started.Result.Status)
+	require.NotNil(t, started.Result.AggregateId)
+	assert.Equal(t, aggregateId, *started.Result.AggregateId)
+	require.NotNil(t, started.Result.From)
+	assert.Equal(t, from, *started.Result.From)
+	assert.Nil(t, started.Result.To, "an open end is not echoed as the zero time")
+
+	_, err = service.ResumeOutboxReplay(ctx, &command.ResumeOutboxReplayCommand{Id: started.Result.Id})
+	assert.ErrorIs(t, err, entities.ErrOutboxReplayNotResumable)
+	_, err = service.ResumeOutboxReplay(ctx, &command.ResumeOutboxReplayCommand{Id: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrOutboxReplayNotFound)
+}
+
+func TestOutboxReplayService_StartOutboxReplay_Invalid(t *testing.T) {
+	repo := &MockOutboxReplayRepository{targets: []string{"log"}}
+	service := NewOutboxReplayService(repo)
+	ctx := context.Background()
+
+	from := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	_, err := service.StartOutboxReplay(ctx, &command.StartOutboxReplayCommand{Target: "log", From: from, To: from.Add(-time.Hour)})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+
+	_, err = service.StartOutboxReplay(ctx, &command.StartOutboxReplayCommand{Target: "carrier-pigeon"})
+	assert.ErrorIs(t, err, entities.ErrUnknownReplayTarget)
+	assert.ErrorIs(t, err, entities.ErrValidation)
+	assert.Empty(t, repo.replays)
+}
+
+func TestOutboxReplayService_FindOutboxReplays(t *testing.T) {
+	repo := &MockOutboxReplayRepository{replays: []*entities.OutboxReplay{{Id: uuid.New(), Status: entities.OutboxReplayCompleted}}}
+	service := NewOutboxReplayService(repo)
+	ctx := context.Background()
+
+	result, err := service.FindOutboxReplays(ctx, &query.GetOutboxReplaysQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultOutboxReplayPageSize, repo.limit)
+	require.Len(t, result.Result, 1)
+	assert.Equal(t, "completed", result.Result[0].Status)
+
+	_, err = service.FindOutboxReplays(ctx, &query.GetOutboxReplaysQuery{Limit: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, maxOutboxReplayPageSize, repo.limit)
+
+	_, err = service.FindOutboxReplays(ctx, &query.GetOutboxReplaysQuery{Limit: -1})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
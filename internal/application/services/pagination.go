@@ -0,0 +1,74 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// pageCursor is the decoded form of the opaque cursor handed to clients: the
+// keyset position of the last row on a page. The sort order is embedded so a
+// cursor cannot be replayed against a different ordering.
+type pageCursor struct {
+	Sort            string    `json:"s"`
+	Id              uuid.UUID `json:"id"`
+	CreatedAt       time.Time `json:"c,omitzero"`
+	Name            string    `json:"n,omitempty"`
+	PriceMinorUnits int64     `json:"p,omitempty"`
+	// Rank and Query position a search; the query is kept so the cursor
+	// cannot continue a different search.
+	Rank  float32 `json:"r,omitempty"`
+	Query string  `json:"q,omitempty"`
+}
+
+func encodeCursor(cursor pageCursor) string {
+	// pageCursor only holds JSON-safe fields, so Marshal cannot fail.
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a cursor produced by encodeCursor. It returns nil for
+// an empty cursor (first page).
+func decodeCursor(raw string, sort string) (*pageCursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", entities.ErrValidation)
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Id == uuid.Nil {
+		return nil, fmt.Errorf("%w: malformed cursor", entities.ErrValidation)
+	}
+	if cursor.Sort != sort {
+		return nil, fmt.Errorf("%w: cursor was issued for sort %q, not %q", entities.ErrValidation, cursor.Sort, sort)
+	}
+
+	return &cursor, nil
+}
+
+// pageSize applies the default to an unset limit and caps oversized ones.
+func pageSize(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, fmt.Errorf("%w: limit must not be negative", entities.ErrValidation)
+	case limit == 0:
+		return defaultPageSize, nil
+	case limit > maxPageSize:
+		return maxPageSize, nil
+	default:
+		return limit, nil
+	}
+}
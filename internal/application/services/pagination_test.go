@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	original := pageCursor{
+		Sort:            "price",
+		Id:              uuid.Must(uuid.NewV7()),
+		CreatedAt:       time.Now().UTC().Truncate(time.Microsecond),
+		Name:            "Widget",
+		PriceMinorUnits: 999,
+	}
+
+	decoded, err := decodeCursor(encodeCursor(original), "price")
+	require.NoError(t, err)
+	assert.Equal(t, original, *decoded)
+}
+
+func TestDecodeCursor_Empty(t *testing.T) {
+	cursor, err := decodeCursor("", "name")
+	assert.NoError(t, err)
+	assert.Nil(t, cursor)
+}
+
+func TestDecodeCursor_Malformed(t *testing.T) {
+	for _, raw := range []string{"!!!", "bm90LWpzb24", "e30"} {
+		_, err := decodeCursor(raw, "name")
+		assert.ErrorIs(t, err, entities.ErrValidation, raw)
+	}
+}
+
+func TestDecodeCursor_SortMismatch(t *testing.T) {
+	raw := encodeCursor(pageCursor{Sort: "name", Id: uuid.New(), Name: "Widget"})
+
+	_, err := decodeCursor(raw, "price")
+
+	assert.ErrorIs(t, err, entities.ErrValidation)
+	assert.ErrorContains(t, err, `cursor was issued for sort "name"`)
+}
+
+func TestPageSize(t *testing.T) {
+	size, err := pageSize(0)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultPageSize, size)
+
+	size, err = pageSize(maxPageSize + 1)
+	assert.NoError(t, err)
+	assert.Equal(t, maxPageSize, size)
+
+	size, err = pageSize(10)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, size)
+
+	_, err = pageSize(-1)
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
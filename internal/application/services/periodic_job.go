@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// PeriodicJob runs a batch operation, such as a sweep of expired rows,
+// every interval. The operation returns how many items it processed; a
+// failure is logged and retried on the next tick.
+type PeriodicJob struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) (int, error)
+}
+
+// NewPeriodicJob names the job after what run does, e.g. "delete expired
+// carts"; the name appears in its log lines.
+func NewPeriodicJob(name string, interval time.Duration, run func(ctx context.Context) (int, error)) *PeriodicJob {
+	return &PeriodicJob{name: name, interval: interval, run: run}
+}
+
+// Start blocks until ctx is cancelled.
+func (j *PeriodicJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			processed, err := j.run(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "periodic job failed", slog.String("job", j.name), slog.Any("error", err))
+			}
+			if processed > 0 {
+				slog.InfoContext(ctx, "periodic job processed items", slog.String("job", j.name), slog.Int("processed", processed))
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodicJob_RunsEveryIntervalUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+	job := NewPeriodicJob("test job", time.Millisecond, func(ctx context.Context) (int, error) {
+		if runs.Add(1) == 1 {
+			return 0, errors.New("database unavailable")
+		}
+		if runs.Load() == 3 {
+			cancel()
+		}
+		return 1, nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		job.Start(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return after ctx was cancelled")
+	}
+	assert.GreaterOrEqual(t, runs.Load(), int32(3), "a failed run does not stop the job")
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// defaultPriceWindow is the look-back of price history and lowest-price
+// queries: advertising a discount in the EU requires showing the lowest
+// price of the previous 30 days.
+const defaultPriceWindow = 30 * 24 * time.Hour
+
+// maxPriceWindow bounds how much history one query reads.
+const maxPriceWindow = 366 * 24 * time.Hour
+
+// PricingService answers what a product cost over time and schedules price
+// changes. The history itself is written by ProductRepository, so every
+// price change is recorded whichever service made it.
+type PricingService struct {
+	productRepository  repositories.ProductRepository
+	historyRepository  repositories.PriceHistoryRepository
+	scheduleRepository repositories.ScheduledPriceChangeRepository
+	transactor         repositories.Transactor
+	idempotencyRepo    repositories.IdempotencyRepository
+}
+
+func NewPricingService(
+	productRepository repositories.ProductRepository,
+	historyRepository repositories.PriceHistoryRepository,
+	scheduleRepository repositories.ScheduledPriceChangeRepository,
+	transactor repositories.Transactor,
+	idempotencyRepo repositories.IdempotencyRepository,
+) interfaces.PricingService {
+	return &PricingService{
+		productRepository:  productRepository,
+		historyRepository:  historyRepository,
+		scheduleRepository: scheduleRepository,
+		transactor:         transactor,
+		idempotencyRepo:    idempotencyRepo,
+	}
+}
+
+func (s *PricingService) GetPriceHistory(ctx context.Context, historyQuery *query.GetPriceHistoryQuery) (*query.GetPriceHistoryQueryResult, error) {
+	from, to := historyQuery.From, historyQuery.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultPriceWindow)
+	}
+	if err := checkPriceWindow(from, to); err != nil {
+		return nil, err
+	}
+	if _, err := s.findProduct(ctx, historyQuery.ProductId); err != nil {
+		return nil, err
+	}
+
+	points, err := s.historyRepository.FindByProductId(ctx, historyQuery.ProductId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &query.GetPriceHistoryQueryResult{
+		Result: make([]*common.PricePointResult, 0, len(points)),
+		From:   from,
+		To:     to,
+	}
+	for _, point := range points {
+		result.Result = append(result.Result, mapper.NewPricePointResultFromEntity(point))
+	}
+
+	return result, nil
+}
+
+// GetLowestPrice returns the lowest price the product had in the window, in
+// its current currency. The price already in effect when the window began
+// counts, so a product repriced once a year still has a lowest price.
+func (s *PricingService) GetLowestPrice(ctx context.Context, lowestQuery *query.GetLowestPriceQuery) (*query.GetLowestPriceQueryResult, error) {
+	window := lowestQuery.Window
+	if window == 0 {
+		window = defaultPriceWindow
+	}
+	to := time.Now()
+	from := to.Add(-window)
+	if err := checkPriceWindow(from, to); err != nil {
+		return nil, err
+	}
+
+	product, err := s.findProduct(ctx, lowestQuery.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	points, err := s.historyRepository.FindByProductId(ctx, product.Id, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	lowest, ok := entities.LowestPrice(points, product.Price.Currency())
+	if !ok {
+		// No recorded history, e.g. the product predates it: the current
+		// price is all that is known.
+		lowest = entities.PricePoint{Price: product.Price, EffectiveAt: product.UpdatedAt}
+	}
+
+	return &query.GetLowestPriceQueryResult{
+		Result: mapper.NewPricePointResultFromEntity(lowest),
+		From:   from,
+		To:     to,
+	}, nil
+}
+
+func checkPriceWindow(from, to time.Time) error {
+	if !from.Before(to) {
+		return fmt.Errorf("%w: from must be before to", entities.ErrValidation)
+	}
+	if to.Sub(from) > maxPriceWindow {
+		return fmt.Errorf("%w: the window must not exceed %d days", entities.ErrValidation, maxPriceWindow/(24*time.Hour))
+	}
+	return nil
+}
+
+func (s *PricingService) GetScheduledPriceChanges(ctx context.Context, scheduleQuery *query.GetScheduledPriceChangesQuery) (*query.GetScheduledPriceChangesQueryResult, error) {
+	if _, err := s.findProduct(ctx, scheduleQuery.ProductId); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.scheduleRepository.FindByProductId(ctx, scheduleQuery.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &query.GetScheduledPriceChangesQueryResult{
+		Result: make([]*common.ScheduledPriceChangeResult, 0, len(changes)),
+	}
+	for _, change := range changes {
+		result.Result = append(result.Result, mapper.NewScheduledPriceChangeResultFromEntity(change))
+	}
+
+	return result, nil
+}
+
+func (s *PricingService) SchedulePriceChange(ctx context.Context, scheduleCommand *command.SchedulePriceChangeCommand) (*command.SchedulePriceChangeCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, scheduleCommand.IdempotencyKey, scheduleCommand, func() (*command.SchedulePriceChangeCommandResult, error) {
+		product, err := s.findProduct(ctx, scheduleCommand.ProductId)
+		if err != nil {
+			return nil, err
+		}
+		if product.Status == entities.ProductArchived {
+			return nil, fmt.Errorf("%w: %s cannot be repriced", entities.ErrProductArchived, product.Name)
+		}
+
+		price, err := entities.NewMoney(scheduleCommand.PriceMinorUnits, scheduleCommand.Currency)
+		if err != nil {
+			return nil, err
+		}
+		change, err := entities.NewScheduledPriceChange(scheduleCommand.ProductId, price, scheduleCommand.EffectiveAt)
+		if err != nil {
+			return nil, err
+		}
+		// Try the change on a copy of the current product, so a price the
+		// product cannot take, e.g. in a currency its variants' price
+		// overrides are not in, is rejected now rather than dropped when it
+		// falls due.
+		candidate := *product
+		if _, err := repriceProduct(&candidate, change); err != nil {
+			return nil, err
+		}
+
+		if err := s.scheduleRepository.Create(ctx, change); err != nil {
+			return nil, err
+		}
+
+		return &command.SchedulePriceChangeCommandResult{
+			Result: mapper.NewScheduledPriceChangeResultFromEntity(change),
+		}, nil
+	})
+}
+
+func (s *PricingService) CancelScheduledPriceChange(ctx context.Context, cancelCommand *command.CancelScheduledPriceChangeCommand) (*command.CancelScheduledPriceChangeCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, cancelCommand.IdempotencyKey, cancelCommand, func() (*command.CancelScheduledPriceChangeCommandResult, error) {
+		if err := s.scheduleRepository.Delete(ctx, cancelCommand.ProductId, cancelCommand.Id); err != nil {
+			return nil, err
+		}
+
+		return &command.CancelScheduledPriceChangeCommandResult{}, nil
+	})
+}
+
+// ApplyDuePriceChanges applies each due change in its own transaction that
+// also deletes it, so a change is applied exactly once even with several
+// workers: the loser of a race finds it deleted and skips it. Changes for
+// products deleted or archived meanwhile are dropped, and so are changes
+// the product rejects, e.g. because a variant gained a price override in
+// another currency since the change was scheduled: retrying them cannot
+// succeed and would hold up the changes behind them. Any other error stops
+// the batch; the change stays scheduled for the next run.
+func (s *PricingService) ApplyDuePriceChanges(ctx context.Context, limit int) (int, error) {
+	changes, err := s.scheduleRepository.FindDue(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, change := range changes {
+		var rejected error
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.scheduleRepository.Delete(ctx, change.ProductId, change.Id); err != nil {
+				return err
+			}
+
+			product, err := s.productRepository.FindById(ctx, change.ProductId)
+			if err != nil || product == nil || product.Status == entities.ProductArchived {
+				return err
+			}
+			validatedProduct, err := repriceProduct(product, change)
+			if isPermanentPriceChangeError(err) {
+				// Commit the delete without the price.
+				rejected = err
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := s.productRepository.Update(ctx, validatedProduct); err != nil {
+				return err
+			}
+
+			applied++
+			return nil
+		})
+		if errors.Is(err, entities.ErrScheduledPriceChangeNotFound) {
+			continue
+		}
+		if err != nil {
+			return applied, err
+		}
+		if rejected != nil {
+			slog.WarnContext(ctx, "dropped scheduled price change the product rejects",
+				slog.String("product_id", change.ProductId.String()),
+				slog.String("price_change_id", change.Id.String()),
+				slog.Any("error", rejected))
+		}
+	}
+
+	return applied, nil
+}
+
+// repriceProduct applies change to product and checks the product's
+// invariants; saving it is up to the caller.
+func repriceProduct(product *entities.Product, change *entities.ScheduledPriceChange) (*entities.ValidatedProduct, error) {
+	if err := product.UpdatePrice(change.Price); err != nil {
+		return nil, err
+	}
+	return entities.NewValidatedProduct(product)
+}
+
+// isPermanentPriceChangeError reports whether the product rejected a price
+// change for reasons a retry does not fix.
+func isPermanentPriceChangeError(err error) bool {
+	return errors.Is(err, entities.ErrValidation) || errors.Is(err, entities.ErrProductArchived)
+}
+
+func (s *PricingService) findProduct(ctx context.Context, productId uuid.UUID) (*entities.Product, error) {
+	product, err := s.productRepository.FindById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	return product, nil
+}
+
+// NewPriceScheduler periodically applies scheduled price changes that are
+// due. A change takes effect at most one interval late.
+func NewPriceScheduler(pricing interfaces.PricingService, interval time.Duration) *PeriodicJob {
+	return NewPeriodicJob("apply scheduled price changes", interval, func(ctx context.Context) (int, error) {
+		return pricing.ApplyDuePriceChanges(ctx, 100)
+	})
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPriceHistoryRepository returns the points of a product that fall in
+// the window, preceded by the last point before it.
+type MockPriceHistoryRepository struct {
+	points map[uuid.UUID][]entities.PricePoint
+}
+
+func (m *MockPriceHistoryRepository) FindByProductId(ctx context.Context, productId uuid.UUID, from, to time.Time) ([]entities.PricePoint, error) {
+	var result []entities.PricePoint
+	for _, point := range m.points[productId] {
+		if point.EffectiveAt.After(to) {
+			break
+		}
+		if !point.EffectiveAt.After(from) && len(result) > 0 {
+			result = result[:0]
+		}
+		result = append(result, point)
+	}
+	return result, nil
+}
+
+type MockScheduledPriceChangeRepository struct {
+	changes []*entities.ScheduledPriceChange
+}
+
+func (m *MockScheduledPriceChangeRepository) Create(ctx context.Context, change *entities.ScheduledPriceChange) error {
+	m.changes = append(m.changes, change)
+	return nil
+}
+
+func (m *MockScheduledPriceChangeRepository) FindByProductId(ctx context.Context, productId uuid.UUID) ([]*entities.ScheduledPriceChange, error) {
+	var changes []*entities.ScheduledPriceChange
+	for _, change := range m.changes {
+		if change.ProductId == productId {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (m *MockScheduledPriceChangeRepository) Delete(ctx context.Context, productId, id uuid.UUID) error {
+	for index, change := range m.changes {
+		if change.ProductId == productId && change.Id == id {
+			m.changes = append(m.changes[:index], m.changes[index+1:]...)
+			return nil
+		}
+	}
+	return entities.ErrScheduledPriceChangeNotFound
+}
+
+func (m *MockScheduledPriceChangeRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.ScheduledPriceChange, error) {
+	var due []*entities.ScheduledPriceChange
+	for _, change := range m.changes {
+		if change.IsDue(now) && len(due) < limit {
+			due = append(due, change)
+		}
+	}
+	return due, nil
+}
+
+func pricePoint(t *testing.T, minor int64, currency entities.Currency, ago time.Duration) entities.PricePoint {
+	t.Helper()
+	price, err := entities.NewMoney(minor, currency)
+	require.NoError(t, err)
+	return entities.PricePoint{Price: price, EffectiveAt: time.Now().Add(-ago)}
+}
+
+func TestPricingService_GetLowestPrice(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	historyRepo := &MockPriceHistoryRepository{points: map[uuid.UUID][]entities.PricePoint{}}
+	scheduleRepo := &MockScheduledPriceChangeRepository{}
+	service := NewPricingService(productRepo, historyRepo, scheduleRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	widget := createPersistedProduct(t, productRepo)
+	day := 24 * time.Hour
+	historyRepo.points[widget.Id] = []entities.PricePoint{
+		pricePoint(t, 500, entities.USD, 60*day),
+		pricePoint(t, 799, entities.USD, 40*day),
+		pricePoint(t, 899, entities.USD, 10*day),
+		pricePoint(t, 999, entities.USD, day),
+	}
+
+	// The price in effect when the window began counts.
+	lowest, err := service.GetLowestPrice(context.Background(), &query.GetLowestPriceQuery{ProductId: widget.Id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(799), lowest.Result.Price.MinorUnits())
+
+	lowest, err = service.GetLowestPrice(context.Background(), &query.GetLowestPriceQuery{ProductId: widget.Id, Window: 5 * day})
+	require.NoError(t, err)
+	assert.Equal(t, int64(899), lowest.Result.Price.MinorUnits())
+
+	_, err = service.GetLowestPrice(context.Background(), &query.GetLowestPriceQuery{ProductId: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+
+	_, err = service.GetLowestPrice(context.Background(), &query.GetLowestPriceQuery{ProductId: widget.Id, Window: 400 * day})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
+
+func TestPricingService_GetLowestPrice_FallsBackToCurrentPrice(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	historyRepo := &MockPriceHistoryRepository{points: map[uuid.UUID][]entities.PricePoint{}}
+	scheduleRepo := &MockScheduledPriceChangeRepository{}
+	service := NewPricingService(productRepo, historyRepo, scheduleRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	widget := createPersistedProduct(t, productRepo)
+	// Prices in another currency are not comparable.
+	historyRepo.points[widget.Id] = []entities.PricePoint{pricePoint(t, 100, entities.EUR, time.Hour)}
+
+	lowest, err := service.GetLowestPrice(context.Background(), &query.GetLowestPriceQuery{ProductId: widget.Id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(999), lowest.Result.Price.MinorUnits())
+	assert.Equal(t, entities.USD, lowest.Result.Price.Currency())
+}
+
+func TestPricingService_GetPriceHistory(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	historyRepo := &MockPriceHistoryRepository{points: map[uuid.UUID][]entities.PricePoint{}}
+	scheduleRepo := &MockScheduledPriceChangeRepository{}
+	service := NewPricingService(productRepo, historyRepo, scheduleRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	widget := createPersistedProduct(t, productRepo)
+	historyRepo.points[widget.Id] = []entities.PricePoint{
+		pricePoint(t, 500, entities.USD, 60*24*time.Hour),
+		pricePoint(t, 999, entities.USD, time.Hour),
+	}
+
+	history, err := service.GetPriceHistory(context.Background(), &query.GetPriceHistoryQuery{ProductId: widget.Id})
+	require.NoError(t, err)
+	require.Len(t, history.Result, 2)
+	assert.Equal(t, int64(500), history.Result[0].Price.MinorUnits())
+	assert.Equal(t, defaultPriceWindow, history.To.Sub(history.From))
+
+	_, err = service.GetPriceHistory(context.Background(), &query.GetPriceHistoryQuery{
+		ProductId: widget.Id,
+		From:      time.Now(),
+		To:        time.Now().Add(-time.Hour),
+	})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
+
+func TestPricingService_ScheduleAndCancel(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	historyRepo := &MockPriceHistoryRepository{points: map[uuid.UUID][]entities.PricePoint{}}
+	scheduleRepo := &MockScheduledPriceChangeRepository{}
+	service := NewPricingService(productRepo, historyRepo, scheduleRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	widget := createPersistedProduct(t, productRepo)
+	ctx := context.Background()
+
+	scheduled, err := service.SchedulePriceChange(ctx, &command.SchedulePriceChangeCommand{
+		ProductId:       widget.Id,
+		PriceMinorUnits: 1299,
+		Currency:        entities.USD,
+		EffectiveAt:     time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1299), scheduled.Result.Price.MinorUnits())
+
+	_, err = service.SchedulePriceChange(ctx, &command.SchedulePriceChangeCommand{
+		ProductId:       widget.Id,
+		PriceMinorUnits: 1299,
+		Currency:        entities.USD,
+		EffectiveAt:     time.Now().Add(-time.Hour),
+	})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+
+	_, err = service.SchedulePriceChange(ctx, &command.SchedulePriceChangeCommand{
+		ProductId:       uuid.New(),
+		PriceMinorUnits: 1299,
+		Currency:        entities.USD,
+		EffectiveAt:     time.Now().Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+
+	listed, err := service.GetScheduledPriceChanges(ctx, &query.GetScheduledPriceChangesQuery{ProductId: widget.Id})
+	require.NoError(t, err)
+	require.Len(t, listed.Result, 1)
+
+	_, err = service.CancelScheduledPriceChange(ctx, &command.CancelScheduledPriceChangeCommand{ProductId: widget.Id, Id: scheduled.Result.Id})
+	require.NoError(t, err)
+	_, err = service.CancelScheduledPriceChange(ctx, &command.CancelScheduledPriceChangeCommand{ProductId: widget.Id, Id: scheduled.Result.Id})
+	assert.ErrorIs(t, err, entities.ErrScheduledPriceChangeNotFound)
+}
+
+func TestPricingService_ApplyDuePriceChanges(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	historyRepo := &MockPriceHistoryRepository{points: map[uuid.UUID][]entities.PricePoint{}}
+	scheduleRepo := &MockScheduledPriceChangeRepository{}
+	service := NewPricingService(productRepo, historyRepo, scheduleRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	widget := createPersistedProduct(t, productRepo)
+	ctx := context.Background()
+
+	due := newScheduledChange(t, widget.Id, 1299)
+	due.EffectiveAt = time.Now().Add(-time.Second)
+	later := newScheduledChange(t, widget.Id, 1499)
+	orphan := newScheduledChange(t, uuid.New(), 100)
+	orphan.EffectiveAt = time.Now().Add(-time.Second)
+	scheduleRepo.changes = []*entities.ScheduledPriceChange{due, later, orphan}
+
+	applied, err := service.ApplyDuePriceChanges(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+
+	product, err := productRepo.FindById(ctx, widget.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1299), product.Price.MinorUnits())
+	assert.Equal(t, 2, product.Version)
+
+	// The due change and the one for the missing product are gone.
+	assert.Equal(t, []*entities.ScheduledPriceChange{later}, scheduleRepo.changes)
+
+	applied, err = service.ApplyDuePriceChanges(ctx, 10)
+	require.NoError(t, err)
+	assert.Zero(t, applied)
+}
+
+func TestPricingService_ArchivedProductsKeepTheirPrice(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	historyRepo := &MockPriceHistoryRepository{points: map[uuid.UUID][]entities.PricePoint{}}
+	scheduleRepo := &MockScheduledPriceChangeRepository{}
+	service := NewPricingService(productRepo, historyRepo, scheduleRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	widget := createPersistedProduct(t, productRepo)
+	ctx := context.Background()
+
+	due := newScheduledChange(t, widget.Id, 1299)
+	due.EffectiveAt = time.Now().Add(-time.Second)
+	scheduleRepo.changes = []*entities.ScheduledPriceChange{due}
+	product, err := productRepo.FindById(ctx, widget.Id)
+	require.NoError(t, err)
+	require.NoError(t, product.Archive())
+
+	_, err = service.SchedulePriceChange(ctx, &command.SchedulePriceChangeCommand{
+		ProductId:       widget.Id,
+		PriceMinorUnits: 1499,
+		Currency:        entities.USD,
+		EffectiveAt:     time.Now().Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, entities.ErrProductArchived)
+
+	applied, err := service.ApplyDuePriceChanges(ctx, 10)
+	require.NoError(t, err)
+	assert.Zero(t, applied)
+	assert.Empty(t, scheduleRepo.changes, "changes for archived products are dropped")
+	assert.Equal(t, int64(999), product.Price.MinorUnits())
+}
+
+func TestPricingService_RejectsPriceChangesTheProductCannotTake(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	historyRepo := &MockPriceHistoryRepository{points: map[uuid.UUID][]entities.PricePoint{}}
+	scheduleRepo := &MockScheduledPriceChangeRepository{}
+	service := NewPricingService(productRepo, historyRepo, scheduleRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	widget := createPersistedProduct(t, productRepo)
+	ctx := context.Background()
+
+	override, err := entities.NewMoney(1099, entities.USD)
+	require.NoError(t, err)
+	product, err := productRepo.FindById(ctx, widget.Id)
+	require.NoError(t, err)
+	require.NoError(t, product.AddVariant(entities.NewProductVariant("WIDGET-XL", map[string]string{"size": "XL"}, &override)))
+
+	// The variant's override is in USD, so the product cannot move to EUR.
+	_, err = service.SchedulePriceChange(ctx, &command.SchedulePriceChangeCommand{
+		ProductId:       widget.Id,
+		PriceMinorUnits: 899,
+		Currency:        entities.EUR,
+		EffectiveAt:     time.Now().Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+	assert.Empty(t, scheduleRepo.changes)
+	assert.Equal(t, entities.USD, product.Price.Currency(), "the check does not touch the product")
+}
+
+func TestPricingService_ApplyDuePriceChanges_DropsRejectedChanges(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	historyRepo := &MockPriceHistoryRepository{points: map[uuid.UUID][]entities.PricePoint{}}
+	scheduleRepo := &MockScheduledPriceChangeRepository{}
+	service := NewPricingService(productRepo, historyRepo, scheduleRepo, &MockTransactor{}, NewMockIdempotencyRepository())
+	widget := createPersistedProduct(t, productRepo)
+	ctx := context.Background()
+
+	// Scheduled before the variant gained its USD override.
+	euroPrice, err := entities.NewMoney(899, entities.EUR)
+	require.NoError(t, err)
+	rejected, err := entities.NewScheduledPriceChange(widget.Id, euroPrice, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	rejected.EffectiveAt = time.Now().Add(-time.Minute)
+	due := newScheduledChange(t, widget.Id, 1299)
+	due.EffectiveAt = time.Now().Add(-time.Second)
+	scheduleRepo.changes = []*entities.ScheduledPriceChange{rejected, due}
+
+	override, err := entities.NewMoney(1099, entities.USD)
+	require.NoError(t, err)
+	product, err := productRepo.FindById(ctx, widget.Id)
+	require.NoError(t, err)
+	require.NoError(t, product.AddVariant(entities.NewProductVariant("WIDGET-XL", map[string]string{"size": "XL"}, &override)))
+
+	applied, err := service.ApplyDuePriceChanges(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied, "the rejected change does not hold up the next one")
+	assert.Empty(t, scheduleRepo.changes, "the rejected change is not retried")
+	assert.Equal(t, int64(1299), product.Price.MinorUnits())
+	assert.Equal(t, entities.USD, product.Price.Currency())
+}
+
+func newScheduledChange(t *testing.T, productId uuid.UUID, minor int64) *entities.ScheduledPriceChange {
+	t.Helper()
+	price, err := entities.NewMoney(minor, entities.USD)
+	require.NoError(t, err)
+	change, err := entities.NewScheduledPriceChange(productId, price, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	return change
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+const (
+	// searchSort is the sort order embedded in search cursors.
+	searchSort = "relevance"
+	// maxSearchTerms bounds the size of the generated tsquery.
+	maxSearchTerms = 10
+)
+
+// SearchProducts returns one page of the published products matching the
+// search, best match first. Like FindAllProducts it fetches one hit more
+// than requested to learn whether a next page exists.
+func (s *ProductService) SearchProducts(ctx context.Context, searchQuery *query.SearchProductsQuery) (*query.SearchProductsQueryResult, error) {
+	terms, err := searchTerms(searchQuery.Q)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := pageSize(searchQuery.Limit)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := decodeCursor(searchQuery.Cursor, searchSort)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := repositories.ProductSearchCriteria{Terms: terms, Limit: limit + 1}
+	normalizedQuery := strings.Join(terms, " ")
+	if cursor != nil {
+		if cursor.Query != normalizedQuery {
+			return nil, fmt.Errorf("%w: cursor was issued for another search", entities.ErrValidation)
+		}
+		criteria.After = &repositories.ProductSearchCursor{Rank: cursor.Rank, Id: cursor.Id}
+	}
+
+	hits, err := s.productRepository.Search(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	var searchResult query.SearchProductsQueryResult
+	if len(hits) > limit {
+		hits = hits[:limit]
+		last := hits[limit-1]
+		searchResult.NextCursor = encodeCursor(pageCursor{
+			Sort:  searchSort,
+			Id:    last.Product.Id,
+			Rank:  last.Rank,
+			Query: normalizedQuery,
+		})
+	}
+
+	products := make([]*entities.Product, 0, len(hits))
+	productResults := make([]*common.ProductResult, 0, len(hits))
+	for _, hit := range hits {
+		productResult := mapper.NewProductResultFromEntity(hit.Product)
+		products = append(products, hit.Product)
+		productResults = append(productResults, productResult)
+		searchResult.Result = append(searchResult.Result, &common.ProductSearchResult{
+			Product:   productResult,
+			Rank:      hit.Rank,
+			Highlight: hit.Highlight,
+		})
+	}
+	if err := s.addEffectivePrices(ctx, products, productResults); err != nil {
+		return nil, err
+	}
+	if err := s.addDisplayPrices(ctx, productResults, searchQuery.DisplayCurrency); err != nil {
+		return nil, err
+	}
+
+	return &searchResult, nil
+}
+
+// searchTerms splits free text into the distinct lowercase words a search
+// matches; anything but letters and digits separates words.
+func searchTerms(text string) ([]string, error) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var terms []string
+	for _, word := range words {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+
+	switch {
+	case len(terms) == 0:
+		return nil, fmt.Errorf("%w: search needs at least one word", entities.ErrValidation)
+	case len(terms) > maxSearchTerms:
+		return nil, fmt.Errorf("%w: search allows at most %d words", entities.ErrValidation, maxSearchTerms)
+	}
+
+	return terms, nil
+}
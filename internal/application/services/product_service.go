@@ -2,9 +2,13 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
 	"github.com/sklinkert/go-ddd/internal/application/interfaces"
 	"github.com/sklinkert/go-ddd/internal/application/mapper"
 	"github.com/sklinkert/go-ddd/internal/application/query"
@@ -13,20 +17,26 @@ import (
 )
 
 type ProductService struct {
-	productRepository repositories.ProductRepository
-	sellerRepository  repositories.SellerRepository
-	idempotencyRepo   repositories.IdempotencyRepository
+	productRepository   repositories.ProductRepository
+	sellerRepository    repositories.SellerRepository
+	promotionRepository repositories.PromotionRepository
+	idempotencyRepo     repositories.IdempotencyRepository
+	converter           interfaces.ConversionService
 }
 
 func NewProductService(
 	productRepository repositories.ProductRepository,
 	sellerRepository repositories.SellerRepository,
+	promotionRepository repositories.PromotionRepository,
 	idempotencyRepo repositories.IdempotencyRepository,
+	converter interfaces.ConversionService,
 ) interfaces.ProductService {
 	return &ProductService{
-		productRepository: productRepository,
-		sellerRepository:  sellerRepository,
-		idempotencyRepo:   idempotencyRepo,
+		productRepository:   productRepository,
+		sellerRepository:    sellerRepository,
+		promotionRepository: promotionRepository,
+		idempotencyRepo:     idempotencyRepo,
+		converter:           converter,
 	}
 }
 
@@ -42,7 +52,16 @@ func (s *ProductService) CreateProduct(ctx context.Context, productCommand *comm
 			return nil, err
 		}
 
-		newProduct := entities.NewProduct(productCommand.Name, price, *validatedSeller)
+		variants := make([]entities.ProductVariant, 0, len(productCommand.Variants))
+		for _, input := range productCommand.Variants {
+			override, err := priceOverride(input, price.Currency())
+			if err != nil {
+				return nil, err
+			}
+			variants = append(variants, entities.NewProductVariant(input.Sku, input.Attributes, override))
+		}
+
+		newProduct := entities.NewProduct(productCommand.Name, price, *validatedSeller, variants...)
 
 		validatedProduct, err := entities.NewValidatedProduct(newProduct)
 		if err != nil {
@@ -53,26 +72,118 @@ func (s *ProductService) CreateProduct(ctx context.Context, productCommand *comm
 			return nil, err
 		}
 
-		return &command.CreateProductCommandResult{
-			Result: mapper.NewProductResultFromValidatedEntity(validatedProduct),
-		}, nil
+		result := mapper.NewProductResultFromValidatedEntity(validatedProduct)
+		if err := s.addEffectivePrices(ctx, []*entities.Product{&validatedProduct.Product}, []*common.ProductResult{result}); err != nil {
+			return nil, err
+		}
+
+		return &command.CreateProductCommandResult{Result: result}, nil
 	})
 }
 
-func (s *ProductService) FindAllProducts(ctx context.Context) (*query.GetAllProductsQueryResult, error) {
-	storedProducts, err := s.productRepository.FindAll(ctx)
+// FindAllProducts returns one page of products. It fetches one row more
+// than requested to learn whether a next page exists without a COUNT.
+func (s *ProductService) FindAllProducts(ctx context.Context, productQuery *query.GetAllProductsQuery) (*query.GetAllProductsQueryResult, error) {
+	criteria, err := productListCriteria(productQuery)
+	if err != nil {
+		return nil, err
+	}
+	limit := criteria.Limit
+	criteria.Limit++
+
+	storedProducts, err := s.productRepository.FindAll(ctx, criteria)
 	if err != nil {
 		return nil, err
 	}
 
 	var queryListResult query.GetAllProductsQueryResult
+	if len(storedProducts) > limit {
+		storedProducts = storedProducts[:limit]
+		last := storedProducts[limit-1]
+		queryListResult.NextCursor = encodeCursor(pageCursor{
+			Sort:            string(criteria.SortBy),
+			Id:              last.Id,
+			CreatedAt:       last.CreatedAt,
+			Name:            last.Name,
+			PriceMinorUnits: last.Price.MinorUnits(),
+		})
+	}
+
 	for _, product := range storedProducts {
 		queryListResult.Result = append(queryListResult.Result, mapper.NewProductResultFromEntity(product))
 	}
+	if err := s.addEffectivePrices(ctx, storedProducts, queryListResult.Result); err != nil {
+		return nil, err
+	}
+	if err := s.addDisplayPrices(ctx, queryListResult.Result, productQuery.DisplayCurrency); err != nil {
+		return nil, err
+	}
 
 	return &queryListResult, nil
 }
 
+func productListCriteria(productQuery *query.GetAllProductsQuery) (repositories.ProductListCriteria, error) {
+	sortBy := repositories.ProductSortField(productQuery.SortBy)
+	switch sortBy {
+	case "":
+		sortBy = repositories.ProductSortByCreatedAt
+	case repositories.ProductSortByCreatedAt, repositories.ProductSortByName, repositories.ProductSortByPrice:
+	default:
+		return repositories.ProductListCriteria{}, fmt.Errorf("%w: unsupported sort %q", entities.ErrValidation, productQuery.SortBy)
+	}
+
+	limit, err := pageSize(productQuery.Limit)
+	if err != nil {
+		return repositories.ProductListCriteria{}, err
+	}
+
+	if productQuery.MinPriceMinorUnits != nil && productQuery.MaxPriceMinorUnits != nil &&
+		*productQuery.MinPriceMinorUnits > *productQuery.MaxPriceMinorUnits {
+		return repositories.ProductListCriteria{}, fmt.Errorf("%w: min price must not exceed max price", entities.ErrValidation)
+	}
+
+	status := productQuery.Status
+	switch status {
+	case "", entities.ProductPublished:
+	case entities.ProductDraft, entities.ProductArchived:
+		if productQuery.SellerId == uuid.Nil {
+			return repositories.ProductListCriteria{}, fmt.Errorf("%w: only a seller's listing includes %s products", entities.ErrValidation, status)
+		}
+	default:
+		return repositories.ProductListCriteria{}, fmt.Errorf("%w: unknown product status %q", entities.ErrValidation, status)
+	}
+	// The public listing holds published products only.
+	if productQuery.SellerId == uuid.Nil {
+		status = entities.ProductPublished
+	}
+
+	criteria := repositories.ProductListCriteria{
+		SellerId:           productQuery.SellerId,
+		Status:             status,
+		Currency:           productQuery.Currency,
+		MinPriceMinorUnits: productQuery.MinPriceMinorUnits,
+		MaxPriceMinorUnits: productQuery.MaxPriceMinorUnits,
+		CategoryId:         productQuery.CategoryId,
+		SortBy:             sortBy,
+		Limit:              limit,
+	}
+
+	cursor, err := decodeCursor(productQuery.Cursor, string(sortBy))
+	if err != nil {
+		return repositories.ProductListCriteria{}, err
+	}
+	if cursor != nil {
+		criteria.After = &repositories.ProductCursor{
+			Id:              cursor.Id,
+			CreatedAt:       cursor.CreatedAt,
+			Name:            cursor.Name,
+			PriceMinorUnits: cursor.PriceMinorUnits,
+		}
+	}
+
+	return criteria, nil
+}
+
 func (s *ProductService) FindProductById(ctx context.Context, productQuery *query.GetProductByIdQuery) (*query.GetProductByIdQueryResult, error) {
 	storedProduct, err := s.productRepository.FindById(ctx, productQuery.Id)
 	if err != nil {
@@ -86,10 +197,89 @@ func (s *ProductService) FindProductById(ctx context.Context, productQuery *quer
 
 	var queryResult query.GetProductByIdQueryResult
 	queryResult.Result = mapper.NewProductResultFromEntity(storedProduct)
+	if err := s.addEffectivePrices(ctx, []*entities.Product{storedProduct}, []*common.ProductResult{queryResult.Result}); err != nil {
+		return nil, err
+	}
+	if err := s.addDisplayPrices(ctx, []*common.ProductResult{queryResult.Result}, productQuery.DisplayCurrency); err != nil {
+		return nil, err
+	}
 
 	return &queryResult, nil
 }
 
+// addEffectivePrices prices each product and each of its variants with the
+// promotions running now; results[i] belongs to products[i], and its
+// variants are in the product's order. The promotions of all sellers on
+// the page are loaded with one query.
+func (s *ProductService) addEffectivePrices(ctx context.Context, products []*entities.Product, results []*common.ProductResult) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	var sellerIds []uuid.UUID
+	for _, product := range products {
+		if !slices.Contains(sellerIds, product.SellerId) {
+			sellerIds = append(sellerIds, product.SellerId)
+		}
+	}
+
+	now := time.Now()
+	promotions, err := s.promotionRepository.FindActive(ctx, sellerIds, now)
+	if err != nil {
+		return err
+	}
+
+	for index, product := range products {
+		price, applied, err := entities.EffectivePrice(product, promotions, now)
+		if err != nil {
+			return err
+		}
+		results[index].EffectivePrice = mapper.NewEffectivePriceResult(price, applied)
+
+		for variantIndex, variant := range product.Variants {
+			price, applied, err := entities.EffectiveVariantPrice(product, variant, promotions, now)
+			if err != nil {
+				return err
+			}
+			results[index].Variants[variantIndex].EffectivePrice = mapper.NewEffectivePriceResult(price, applied)
+		}
+	}
+
+	return nil
+}
+
+// addDisplayPrices converts every price into currency, if one was asked
+// for. All products on a page are converted at the same rate per source
+// currency, so the page is consistent even if a new rate takes effect
+// while it is being built.
+func (s *ProductService) addDisplayPrices(ctx context.Context, products []*common.ProductResult, currency entities.Currency) error {
+	if currency == "" {
+		return nil
+	}
+
+	now := time.Now()
+	rates := make(map[entities.Currency]*entities.ExchangeRate)
+	for _, product := range products {
+		if rate := rates[product.Price.Currency()]; rate != nil {
+			converted, err := rate.Convert(product.Price)
+			if err != nil {
+				return err
+			}
+			product.DisplayPrice = &common.ConversionResult{Amount: converted, Rate: rate}
+			continue
+		}
+
+		conversion, err := s.converter.Convert(ctx, product.Price, currency, now)
+		if err != nil {
+			return err
+		}
+		rates[product.Price.Currency()] = conversion.Rate
+		product.DisplayPrice = conversion
+	}
+
+	return nil
+}
+
 func (s *ProductService) UpdateProduct(ctx context.Context, productCommand *command.UpdateProductCommand) (*command.UpdateProductCommandResult, error) {
 	return withIdempotency(ctx, s.idempotencyRepo, productCommand.IdempotencyKey, productCommand, func() (*command.UpdateProductCommandResult, error) {
 		existingProduct, err := s.productRepository.FindById(ctx, productCommand.Id)
@@ -101,6 +291,10 @@ func (s *ProductService) UpdateProduct(ctx context.Context, productCommand *comm
 			return nil, entities.ErrProductNotFound
 		}
 
+		if err := checkExpectedVersion(productCommand.ExpectedVersion, existingProduct.Version); err != nil {
+			return nil, err
+		}
+
 		if productCommand.SellerId != existingProduct.SellerId {
 			validatedSeller, err := s.findValidatedSeller(ctx, productCommand.SellerId)
 			if err != nil {
@@ -130,16 +324,162 @@ func (s *ProductService) UpdateProduct(ctx context.Context, productCommand *comm
 			return nil, err
 		}
 
-		if _, err := s.productRepository.Update(ctx, validatedProduct); err != nil {
+		updatedProduct, err := s.productRepository.Update(ctx, validatedProduct)
+		if err != nil {
+			return nil, err
+		}
+
+		// The stored product carries the bumped version the client needs
+		// for its next conditional write.
+		result := mapper.NewProductResultFromEntity(updatedProduct)
+		if err := s.addEffectivePrices(ctx, []*entities.Product{updatedProduct}, []*common.ProductResult{result}); err != nil {
 			return nil, err
 		}
 
-		return &command.UpdateProductCommandResult{
-			Result: mapper.NewProductResultFromValidatedEntity(validatedProduct),
-		}, nil
+		return &command.UpdateProductCommandResult{Result: result}, nil
 	})
 }
 
+func (s *ProductService) PublishProduct(ctx context.Context, productCommand *command.PublishProductCommand) (*command.PublishProductCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, productCommand.IdempotencyKey, productCommand, func() (*command.PublishProductCommandResult, error) {
+		result, err := s.modify(ctx, productCommand.Id, productCommand.ExpectedVersion, (*entities.Product).Publish)
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.PublishProductCommandResult{Result: result}, nil
+	})
+}
+
+func (s *ProductService) ArchiveProduct(ctx context.Context, productCommand *command.ArchiveProductCommand) (*command.ArchiveProductCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, productCommand.IdempotencyKey, productCommand, func() (*command.ArchiveProductCommandResult, error) {
+		result, err := s.modify(ctx, productCommand.Id, productCommand.ExpectedVersion, (*entities.Product).Archive)
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.ArchiveProductCommandResult{Result: result}, nil
+	})
+}
+
+// modify loads the product, applies change and stores it with a versioned
+// update.
+func (s *ProductService) modify(ctx context.Context, id uuid.UUID, expectedVersion *int, change func(*entities.Product) error) (*common.ProductResult, error) {
+	existingProduct, err := s.productRepository.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingProduct == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	if err := checkExpectedVersion(expectedVersion, existingProduct.Version); err != nil {
+		return nil, err
+	}
+
+	if err := change(existingProduct); err != nil {
+		return nil, err
+	}
+
+	validatedProduct, err := entities.NewValidatedProduct(existingProduct)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedProduct, err := s.productRepository.Update(ctx, validatedProduct)
+	if err != nil {
+		return nil, err
+	}
+
+	result := mapper.NewProductResultFromEntity(updatedProduct)
+	if err := s.addEffectivePrices(ctx, []*entities.Product{updatedProduct}, []*common.ProductResult{result}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CategorizeProduct replaces the product's categories. The repository checks
+// that they exist as it saves them: a category deleted concurrently cannot
+// be assigned.
+func (s *ProductService) CategorizeProduct(ctx context.Context, productCommand *command.CategorizeProductCommand) (*command.CategorizeProductCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, productCommand.IdempotencyKey, productCommand, func() (*command.CategorizeProductCommandResult, error) {
+		result, err := s.modify(ctx, productCommand.Id, productCommand.ExpectedVersion, func(product *entities.Product) error {
+			return product.Categorize(productCommand.CategoryIds)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.CategorizeProductCommandResult{Result: result}, nil
+	})
+}
+
+// AddProductVariant adds a variant. The repository checks that no other
+// product uses its SKU.
+func (s *ProductService) AddProductVariant(ctx context.Context, productCommand *command.AddProductVariantCommand) (*command.AddProductVariantCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, productCommand.IdempotencyKey, productCommand, func() (*command.AddProductVariantCommandResult, error) {
+		result, err := s.modify(ctx, productCommand.ProductId, productCommand.ExpectedVersion, func(product *entities.Product) error {
+			override, err := priceOverride(productCommand.Variant, product.Price.Currency())
+			if err != nil {
+				return err
+			}
+			return product.AddVariant(entities.NewProductVariant(productCommand.Variant.Sku, productCommand.Variant.Attributes, override))
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.AddProductVariantCommandResult{Result: result}, nil
+	})
+}
+
+func (s *ProductService) UpdateProductVariant(ctx context.Context, productCommand *command.UpdateProductVariantCommand) (*command.UpdateProductVariantCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, productCommand.IdempotencyKey, productCommand, func() (*command.UpdateProductVariantCommandResult, error) {
+		result, err := s.modify(ctx, productCommand.ProductId, productCommand.ExpectedVersion, func(product *entities.Product) error {
+			override, err := priceOverride(productCommand.Variant, product.Price.Currency())
+			if err != nil {
+				return err
+			}
+			return product.UpdateVariant(productCommand.VariantId, productCommand.Variant.Sku, productCommand.Variant.Attributes, override)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.UpdateProductVariantCommandResult{Result: result}, nil
+	})
+}
+
+// RemoveProductVariant removes a variant and its stock. The repository
+// refuses variants whose stock is still reserved.
+func (s *ProductService) RemoveProductVariant(ctx context.Context, productCommand *command.RemoveProductVariantCommand) (*command.RemoveProductVariantCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, productCommand.IdempotencyKey, productCommand, func() (*command.RemoveProductVariantCommandResult, error) {
+		result, err := s.modify(ctx, productCommand.ProductId, productCommand.ExpectedVersion, func(product *entities.Product) error {
+			return product.RemoveVariant(productCommand.VariantId)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.RemoveProductVariantCommandResult{Result: result}, nil
+	})
+}
+
+// priceOverride builds the variant's price override, which is always in the
+// product's currency.
+func priceOverride(input command.ProductVariantInput, currency entities.Currency) (*entities.Money, error) {
+	if input.PriceOverrideMinorUnits == nil {
+		return nil, nil
+	}
+	override, err := entities.NewMoney(*input.PriceOverrideMinorUnits, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
 func (s *ProductService) DeleteProduct(ctx context.Context, productCommand *command.DeleteProductCommand) (*command.DeleteProductCommandResult, error) {
 	return withIdempotency(ctx, s.idempotencyRepo, productCommand.IdempotencyKey, productCommand, func() (*command.DeleteProductCommandResult, error) {
 		existingProduct, err := s.productRepository.FindById(ctx, productCommand.Id)
@@ -151,7 +491,13 @@ func (s *ProductService) DeleteProduct(ctx context.Context, productCommand *comm
 			return nil, entities.ErrProductNotFound
 		}
 
-		if err := s.productRepository.Delete(ctx, productCommand.Id); err != nil {
+		if err := checkExpectedVersion(productCommand.ExpectedVersion, existingProduct.Version); err != nil {
+			return nil, err
+		}
+
+		existingProduct.Delete()
+
+		if err := s.productRepository.Delete(ctx, existingProduct); err != nil {
 			return nil, err
 		}
 
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 
@@ -10,11 +12,18 @@ import (
 	"github.com/sklinkert/go-ddd/internal/application/command"
 	"github.com/sklinkert/go-ddd/internal/application/query"
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // MockProductRepository is a mock implementation of the ProductRepository interface
 type MockProductRepository struct {
 	products []*entities.ValidatedProduct
+	// criteria is what the last FindAll was called with.
+	criteria repositories.ProductListCriteria
+	// searchCriteria is what the last Search was called with.
+	searchCriteria repositories.ProductSearchCriteria
 }
 
 func (m *MockProductRepository) Create(ctx context.Context, product *entities.ValidatedProduct) (*entities.Product, error) {
@@ -22,17 +31,52 @@ func (m *MockProductRepository) Create(ctx context.Context, product *entities.Va
 	return &product.Product, nil
 }
 
-func (m *MockProductRepository) FindAll(ctx context.Context) ([]*entities.Product, error) {
+// FindAll pages in insertion order: After skips up to and including the
+// product with the cursor's Id.
+func (m *MockProductRepository) FindAll(ctx context.Context, criteria repositories.ProductListCriteria) ([]*entities.Product, error) {
+	m.criteria = criteria
 	var products []*entities.Product
+	skipping := criteria.After != nil
 	for _, p := range m.products {
+		if skipping {
+			skipping = p.Id != criteria.After.Id
+			continue
+		}
+		if len(products) == criteria.Limit {
+			break
+		}
 		products = append(products, &p.Product)
 	}
 	return products, nil
 }
 
+// Search matches names containing every term, all at rank 1 in insertion
+// order; After skips up to and including the hit with the cursor's Id.
+func (m *MockProductRepository) Search(ctx context.Context, criteria repositories.ProductSearchCriteria) ([]repositories.ProductSearchHit, error) {
+	m.searchCriteria = criteria
+	var hits []repositories.ProductSearchHit
+	skipping := criteria.After != nil
+	for _, p := range m.products {
+		if skipping {
+			skipping = p.Id != criteria.After.Id
+			continue
+		}
+		name := strings.ToLower(p.Name)
+		if slices.ContainsFunc(criteria.Terms, func(term string) bool { return !strings.Contains(name, term) }) {
+			continue
+		}
+		if len(hits) == criteria.Limit {
+			break
+		}
+		hits = append(hits, repositories.ProductSearchHit{Product: &p.Product, Rank: 1, Highlight: p.Name})
+	}
+	return hits, nil
+}
+
 func (m *MockProductRepository) Update(ctx context.Context, product *entities.ValidatedProduct) (*entities.Product, error) {
 	for index, p := range m.products {
 		if p.Id == product.Id {
+			product.Version++
 			m.products[index] = product
 			return &product.Product, nil
 		}
@@ -40,9 +84,9 @@ func (m *MockProductRepository) Update(ctx context.Context, product *entities.Va
 	return nil, errors.New("product not found for update")
 }
 
-func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockProductRepository) Delete(ctx context.Context, product *entities.Product) error {
 	for index, p := range m.products {
-		if p.Id == id {
+		if p.Id == product.Id {
 			m.products = append(m.products[:index], m.products[index+1:]...)
 			return nil
 		}
@@ -130,7 +174,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
 	idempotencyRepo := NewMockIdempotencyRepository()
-	service := NewProductService(productRepo, sellerRepo, idempotencyRepo)
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, idempotencyRepo, NewConversionService(&MockExchangeRateProvider{}))
 
 	// Create seller
 	seller := createPersistedSeller(t, sellerRepo)
@@ -151,7 +195,7 @@ func TestProductService_GetAllProducts(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
 	idempotencyRepo := NewMockIdempotencyRepository()
-	service := NewProductService(productRepo, sellerRepo, idempotencyRepo)
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, idempotencyRepo, NewConversionService(&MockExchangeRateProvider{}))
 
 	// Create seller
 	seller := createPersistedSeller(t, sellerRepo)
@@ -160,7 +204,7 @@ func TestProductService_GetAllProducts(t *testing.T) {
 	_, _ = service.CreateProduct(context.Background(), getCreateProductCommand("Example1", 10000, seller.Id))
 	_, _ = service.CreateProduct(context.Background(), getCreateProductCommand("Example2", 20000, seller.Id))
 
-	products, err := service.FindAllProducts(context.Background())
+	products, err := service.FindAllProducts(context.Background(), &query.GetAllProductsQuery{})
 	if err != nil {
 		t.Errorf("Unexpected error: %s", err)
 	}
@@ -170,11 +214,257 @@ func TestProductService_GetAllProducts(t *testing.T) {
 	}
 }
 
+func TestProductService_FindAllProducts_Paginates(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+
+	seller := createPersistedSeller(t, sellerRepo)
+	for _, name := range []string{"Example1", "Example2", "Example3"} {
+		_, err := service.CreateProduct(context.Background(), getCreateProductCommand(name, 10000, seller.Id))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	firstPage, err := service.FindAllProducts(context.Background(), &query.GetAllProductsQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(firstPage.Result) != 2 || firstPage.NextCursor == "" {
+		t.Fatalf("Expected 2 products and a next cursor, got %d products and cursor %q", len(firstPage.Result), firstPage.NextCursor)
+	}
+
+	secondPage, err := service.FindAllProducts(context.Background(), &query.GetAllProductsQuery{Limit: 2, Cursor: firstPage.NextCursor})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(secondPage.Result) != 1 || secondPage.Result[0].Name != "Example3" {
+		t.Errorf("Expected only Example3 on the second page, got %v", secondPage.Result)
+	}
+	if secondPage.NextCursor != "" {
+		t.Errorf("Expected no next cursor on the last page, got %q", secondPage.NextCursor)
+	}
+}
+
+func TestProductService_FindAllProducts_InvalidQuery(t *testing.T) {
+	service := NewProductService(&MockProductRepository{}, &MockSellerRepository{}, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+	minPrice, maxPrice := int64(500), int64(100)
+
+	for name, productQuery := range map[string]*query.GetAllProductsQuery{
+		"unknown sort":   {SortBy: "rating"},
+		"negative limit": {Limit: -1},
+		"bad cursor":     {Cursor: "not-a-cursor"},
+		"inverted range": {MinPriceMinorUnits: &minPrice, MaxPriceMinorUnits: &maxPrice},
+	} {
+		_, err := service.FindAllProducts(context.Background(), productQuery)
+		if !errors.Is(err, entities.ErrValidation) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestProductService_FindAllProducts_StatusScope(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	service := NewProductService(productRepo, &MockSellerRepository{}, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+	sellerId := uuid.New()
+
+	for name, tc := range map[string]struct {
+		query    *query.GetAllProductsQuery
+		expected entities.ProductStatus
+	}{
+		"public":        {&query.GetAllProductsQuery{}, entities.ProductPublished},
+		"seller":        {&query.GetAllProductsQuery{SellerId: sellerId}, ""},
+		"seller drafts": {&query.GetAllProductsQuery{SellerId: sellerId, Status: entities.ProductDraft}, entities.ProductDraft},
+	} {
+		_, err := service.FindAllProducts(context.Background(), tc.query)
+		require.NoError(t, err, name)
+		assert.Equal(t, tc.expected, productRepo.criteria.Status, name)
+	}
+
+	_, err := service.FindAllProducts(context.Background(), &query.GetAllProductsQuery{Status: entities.ProductArchived})
+	assert.ErrorIs(t, err, entities.ErrValidation, "the public listing holds published products only")
+	_, err = service.FindAllProducts(context.Background(), &query.GetAllProductsQuery{SellerId: sellerId, Status: "sold_out"})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
+
+func TestProductService_SearchProducts(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+	ctx := context.Background()
+
+	seller := createPersistedSeller(t, sellerRepo)
+	for _, name := range []string{"Oak chair", "Oak table", "Pine chair", "Oak chair cushion"} {
+		_, err := service.CreateProduct(ctx, getCreateProductCommand(name, 10000, seller.Id))
+		require.NoError(t, err)
+	}
+
+	firstPage, err := service.SearchProducts(ctx, &query.SearchProductsQuery{Q: "  OAK, chair! oak", Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oak", "chair"}, productRepo.searchCriteria.Terms, "words are lowercased and deduplicated")
+	assert.Equal(t, 2, productRepo.searchCriteria.Limit)
+	require.Len(t, firstPage.Result, 1)
+	assert.Equal(t, "Oak chair", firstPage.Result[0].Product.Name)
+	assert.Equal(t, "Oak chair", firstPage.Result[0].Highlight)
+	assert.NotNil(t, firstPage.Result[0].Product.EffectivePrice)
+	require.NotEmpty(t, firstPage.NextCursor)
+
+	secondPage, err := service.SearchProducts(ctx, &query.SearchProductsQuery{Q: "oak chair", Limit: 1, Cursor: firstPage.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, secondPage.Result, 1)
+	assert.Equal(t, "Oak chair cushion", secondPage.Result[0].Product.Name)
+	assert.Empty(t, secondPage.NextCursor)
+
+	_, err = service.SearchProducts(ctx, &query.SearchProductsQuery{Q: "pine", Cursor: firstPage.NextCursor})
+	assert.ErrorIs(t, err, entities.ErrValidation, "a cursor only continues its own search")
+}
+
+func TestProductService_SearchProducts_InvalidQuery(t *testing.T) {
+	service := NewProductService(&MockProductRepository{}, &MockSellerRepository{}, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+	listCursor := encodeCursor(pageCursor{Sort: string(repositories.ProductSortByName), Id: uuid.New()})
+
+	for name, searchQuery := range map[string]*query.SearchProductsQuery{
+		"no words":       {Q: " ?! "},
+		"too many words": {Q: "a b c d e f g h i j k"},
+		"negative limit": {Q: "oak", Limit: -1},
+		"bad cursor":     {Q: "oak", Cursor: "not-a-cursor"},
+		"listing cursor": {Q: "oak", Cursor: listCursor},
+	} {
+		_, err := service.SearchProducts(context.Background(), searchQuery)
+		assert.ErrorIs(t, err, entities.ErrValidation, name)
+	}
+}
+
+func TestProductService_PublishAndArchive(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+	ctx := context.Background()
+
+	seller := createPersistedSeller(t, sellerRepo)
+	created, err := service.CreateProduct(ctx, getCreateProductCommand("Example", 10000, seller.Id))
+	require.NoError(t, err)
+	assert.Equal(t, "draft", created.Result.Status)
+
+	stale := 0
+	_, err = service.PublishProduct(ctx, &command.PublishProductCommand{Id: created.Result.Id, ExpectedVersion: &stale})
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	published, err := service.PublishProduct(ctx, &command.PublishProductCommand{Id: created.Result.Id, ExpectedVersion: &created.Result.Version})
+	require.NoError(t, err)
+	assert.Equal(t, "published", published.Result.Status)
+	assert.Equal(t, 2, published.Result.Version)
+
+	_, err = service.PublishProduct(ctx, &command.PublishProductCommand{Id: created.Result.Id})
+	assert.ErrorIs(t, err, entities.ErrInvalidProductTransition)
+
+	archived, err := service.ArchiveProduct(ctx, &command.ArchiveProductCommand{Id: created.Result.Id})
+	require.NoError(t, err)
+	assert.Equal(t, "archived", archived.Result.Status)
+
+	_, err = service.UpdateProduct(ctx, &command.UpdateProductCommand{
+		Id:              created.Result.Id,
+		Name:            "Example",
+		PriceMinorUnits: 9000,
+		Currency:        entities.USD,
+		SellerId:        seller.Id,
+	})
+	assert.ErrorIs(t, err, entities.ErrProductArchived)
+
+	_, err = service.ArchiveProduct(ctx, &command.ArchiveProductCommand{Id: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+}
+
+func TestProductService_CategorizeProduct(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+	ctx := context.Background()
+
+	seller := createPersistedSeller(t, sellerRepo)
+	created, err := service.CreateProduct(ctx, getCreateProductCommand("Example", 10000, seller.Id))
+	require.NoError(t, err)
+	assert.Empty(t, created.Result.CategoryIds)
+
+	chairs, furniture := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	categorized, err := service.CategorizeProduct(ctx, &command.CategorizeProductCommand{
+		Id:              created.Result.Id,
+		CategoryIds:     []uuid.UUID{furniture, chairs},
+		ExpectedVersion: &created.Result.Version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{chairs, furniture}, categorized.Result.CategoryIds)
+	assert.NotNil(t, categorized.Result.EffectivePrice)
+
+	_, err = service.CategorizeProduct(ctx, &command.CategorizeProductCommand{Id: created.Result.Id, CategoryIds: []uuid.UUID{chairs, chairs}})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+	_, err = service.CategorizeProduct(ctx, &command.CategorizeProductCommand{Id: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+
+	_, err = service.FindAllProducts(ctx, &query.GetAllProductsQuery{CategoryId: furniture})
+	require.NoError(t, err)
+	assert.Equal(t, furniture, productRepo.criteria.CategoryId)
+}
+
+func TestProductService_Variants(t *testing.T) {
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+	ctx := context.Background()
+
+	seller := createPersistedSeller(t, sellerRepo)
+	createCommand := getCreateProductCommand("T-shirt", 1000, seller.Id)
+	override := int64(1200)
+	createCommand.Variants = []command.ProductVariantInput{
+		{Sku: "TEE-S", Attributes: map[string]string{"size": "S"}},
+		{Sku: "TEE-L", Attributes: map[string]string{"size": "L"}, PriceOverrideMinorUnits: &override},
+	}
+	created, err := service.CreateProduct(ctx, createCommand)
+	require.NoError(t, err)
+	require.Len(t, created.Result.Variants, 2)
+	assert.Nil(t, created.Result.Variants[0].PriceOverride)
+	assert.Equal(t, int64(1000), created.Result.Variants[0].Price.MinorUnits())
+	assert.Equal(t, int64(1200), created.Result.Variants[1].Price.MinorUnits())
+	assert.Equal(t, entities.USD, created.Result.Variants[1].PriceOverride.Currency(), "overrides use the product's currency")
+
+	added, err := service.AddProductVariant(ctx, &command.AddProductVariantCommand{
+		ProductId:       created.Result.Id,
+		Variant:         command.ProductVariantInput{Sku: "TEE-M", Attributes: map[string]string{"size": "M"}},
+		ExpectedVersion: &created.Result.Version,
+	})
+	require.NoError(t, err)
+	require.Len(t, added.Result.Variants, 3)
+	medium := added.Result.Variants[2]
+
+	_, err = service.AddProductVariant(ctx, &command.AddProductVariantCommand{
+		ProductId: created.Result.Id,
+		Variant:   command.ProductVariantInput{Sku: "TEE-M2", Attributes: map[string]string{"size": "M"}},
+	})
+	assert.ErrorIs(t, err, entities.ErrValidation, "attribute combinations are unique")
+
+	updated, err := service.UpdateProductVariant(ctx, &command.UpdateProductVariantCommand{
+		ProductId: created.Result.Id,
+		VariantId: medium.Id,
+		Variant:   command.ProductVariantInput{Sku: "TEE-M", Attributes: map[string]string{"size": "M"}, PriceOverrideMinorUnits: &override},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1200), updated.Result.Variants[2].Price.MinorUnits())
+	_, err = service.UpdateProductVariant(ctx, &command.UpdateProductVariantCommand{ProductId: created.Result.Id, VariantId: uuid.New(), Variant: command.ProductVariantInput{Sku: "X"}})
+	assert.ErrorIs(t, err, entities.ErrVariantNotFound)
+
+	removed, err := service.RemoveProductVariant(ctx, &command.RemoveProductVariantCommand{ProductId: created.Result.Id, VariantId: medium.Id})
+	require.NoError(t, err)
+	assert.Len(t, removed.Result.Variants, 2)
+	_, err = service.RemoveProductVariant(ctx, &command.RemoveProductVariantCommand{ProductId: uuid.New(), VariantId: medium.Id})
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
+}
+
 func TestProductService_FindProductById(t *testing.T) {
 	productRepo := &MockProductRepository{}
 	sellerRepo := &MockSellerRepository{}
 	idempotencyRepo := NewMockIdempotencyRepository()
-	service := NewProductService(productRepo, sellerRepo, idempotencyRepo)
+	service := NewProductService(productRepo, sellerRepo, &MockPromotionRepository{}, idempotencyRepo, NewConversionService(&MockExchangeRateProvider{}))
 
 	// Create seller
 	seller := createPersistedSeller(t, sellerRepo)
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+type PromotionService struct {
+	promotionRepository repositories.PromotionRepository
+	sellerRepository    repositories.SellerRepository
+	productRepository   repositories.ProductRepository
+	idempotencyRepo     repositories.IdempotencyRepository
+}
+
+func NewPromotionService(
+	promotionRepository repositories.PromotionRepository,
+	sellerRepository repositories.SellerRepository,
+	productRepository repositories.ProductRepository,
+	idempotencyRepo repositories.IdempotencyRepository,
+) interfaces.PromotionService {
+	return &PromotionService{
+		promotionRepository: promotionRepository,
+		sellerRepository:    sellerRepository,
+		productRepository:   productRepository,
+		idempotencyRepo:     idempotencyRepo,
+	}
+}
+
+// CreatePromotion checks that the seller exists and owns every listed
+// product; a promotion on another seller's product would never apply.
+func (s *PromotionService) CreatePromotion(ctx context.Context, promotionCommand *command.CreatePromotionCommand) (*command.CreatePromotionCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, promotionCommand.IdempotencyKey, promotionCommand, func() (*command.CreatePromotionCommandResult, error) {
+		seller, err := s.sellerRepository.FindById(ctx, promotionCommand.SellerId)
+		if err != nil {
+			return nil, err
+		}
+		if seller == nil {
+			return nil, entities.ErrSellerNotFound
+		}
+
+		for _, productId := range promotionCommand.ProductIds {
+			product, err := s.productRepository.FindById(ctx, productId)
+			if err != nil {
+				return nil, err
+			}
+			if product == nil {
+				return nil, fmt.Errorf("%w: product %s", entities.ErrProductNotFound, productId)
+			}
+			if product.SellerId != seller.Id {
+				return nil, fmt.Errorf("%w: product %s does not belong to the seller", entities.ErrValidation, productId)
+			}
+		}
+
+		discount, err := newDiscount(promotionCommand)
+		if err != nil {
+			return nil, err
+		}
+
+		startsAt := promotionCommand.StartsAt
+		if startsAt.IsZero() {
+			startsAt = time.Now()
+		}
+		promotion := entities.NewPromotion(seller.Id, promotionCommand.Name, discount, entities.PromotionTerms{
+			ProductIds: promotionCommand.ProductIds,
+			StartsAt:   startsAt,
+			EndsAt:     promotionCommand.EndsAt,
+			Stackable:  promotionCommand.Stackable,
+			UsageLimit: promotionCommand.UsageLimit,
+		})
+
+		validatedPromotion, err := entities.NewValidatedPromotion(promotion)
+		if err != nil {
+			return nil, err
+		}
+
+		created, err := s.promotionRepository.Create(ctx, validatedPromotion)
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.CreatePromotionCommandResult{
+			Result: mapper.NewPromotionResultFromEntity(created),
+		}, nil
+	})
+}
+
+func newDiscount(promotionCommand *command.CreatePromotionCommand) (entities.Discount, error) {
+	switch promotionCommand.DiscountKind {
+	case entities.DiscountPercentage:
+		return entities.NewPercentageDiscount(promotionCommand.BasisPoints), nil
+	case entities.DiscountFixedAmount:
+		amount, err := entities.NewMoney(promotionCommand.AmountMinorUnits, promotionCommand.Currency)
+		if err != nil {
+			return entities.Discount{}, err
+		}
+		return entities.NewFixedAmountDiscount(amount), nil
+	default:
+		return entities.Discount{}, fmt.Errorf("%w: unknown discount kind %q", entities.ErrValidation, promotionCommand.DiscountKind)
+	}
+}
+
+func (s *PromotionService) EndPromotion(ctx context.Context, promotionCommand *command.EndPromotionCommand) (*command.EndPromotionCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, promotionCommand.IdempotencyKey, promotionCommand, func() (*command.EndPromotionCommandResult, error) {
+		updated, err := s.modify(ctx, promotionCommand.Id, promotionCommand.ExpectedVersion, func(promotion *entities.Promotion) error {
+			return promotion.End(time.Now())
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.EndPromotionCommandResult{Result: mapper.NewPromotionResultFromEntity(updated)}, nil
+	})
+}
+
+// RedeemPromotion counts one use. Concurrent redemptions of the same
+// promotion race on its version: the loser gets ErrVersionConflict and may
+// retry, so the usage limit is never exceeded.
+func (s *PromotionService) RedeemPromotion(ctx context.Context, promotionCommand *command.RedeemPromotionCommand) (*command.RedeemPromotionCommandResult, error) {
+	return withIdempotency(ctx, s.idempotencyRepo, promotionCommand.IdempotencyKey, promotionCommand, func() (*command.RedeemPromotionCommandResult, error) {
+		updated, err := s.modify(ctx, promotionCommand.Id, promotionCommand.ExpectedVersion, func(promotion *entities.Promotion) error {
+			return promotion.Redeem(time.Now())
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &command.RedeemPromotionCommandResult{Result: mapper.NewPromotionResultFromEntity(updated)}, nil
+	})
+}
+
+func (s *PromotionService) modify(ctx context.Context, id uuid.UUID, expectedVersion *int, change func(promotion *entities.Promotion) error) (*entities.Promotion, error) {
+	promotion, err := s.promotionRepository.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if promotion == nil {
+		return nil, entities.ErrPromotionNotFound
+	}
+
+	if err := checkExpectedVersion(expectedVersion, promotion.Version); err != nil {
+		return nil, err
+	}
+
+	if err := change(promotion); err != nil {
+		return nil, err
+	}
+
+	validatedPromotion, err := entities.NewValidatedPromotion(promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.promotionRepository.Update(ctx, validatedPromotion)
+}
+
+func (s *PromotionService) FindPromotionById(ctx context.Context, promotionQuery *query.GetPromotionByIdQuery) (*query.GetPromotionByIdQueryResult, error) {
+	promotion, err := s.promotionRepository.FindById(ctx, promotionQuery.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Not found: let the caller translate this into a 404.
+	if promotion == nil {
+		return nil, nil
+	}
+
+	return &query.GetPromotionByIdQueryResult{Result: mapper.NewPromotionResultFromEntity(promotion)}, nil
+}
+
+func (s *PromotionService) FindPromotionsBySeller(ctx context.Context, promotionQuery *query.GetPromotionsBySellerQuery) (*query.GetPromotionsBySellerQueryResult, error) {
+	promotions, err := s.promotionRepository.FindBySellerId(ctx, promotionQuery.SellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &query.GetPromotionsBySellerQueryResult{}
+	for _, promotion := range promotions {
+		result.Result = append(result.Result, mapper.NewPromotionResultFromEntity(promotion))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPromotionRepository keeps promotions in insertion order and checks
+// versions on update like the real repository.
+type MockPromotionRepository struct {
+	promotions []*entities.Promotion
+}
+
+func (m *MockPromotionRepository) Create(ctx context.Context, promotion *entities.ValidatedPromotion) (*entities.Promotion, error) {
+	stored := promotion.Promotion
+	m.promotions = append(m.promotions, &stored)
+	created := stored
+	return &created, nil
+}
+
+func (m *MockPromotionRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.Promotion, error) {
+	for _, promotion := range m.promotions {
+		if promotion.Id == id {
+			found := *promotion
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockPromotionRepository) FindBySellerId(ctx context.Context, sellerId uuid.UUID) ([]*entities.Promotion, error) {
+	var promotions []*entities.Promotion
+	for _, promotion := range m.promotions {
+		if promotion.SellerId == sellerId {
+			promotions = append(promotions, promotion)
+		}
+	}
+	return promotions, nil
+}
+
+func (m *MockPromotionRepository) FindActive(ctx context.Context, sellerIds []uuid.UUID, at time.Time) ([]*entities.Promotion, error) {
+	var promotions []*entities.Promotion
+	for _, promotion := range m.promotions {
+		for _, sellerId := range sellerIds {
+			if promotion.SellerId == sellerId && promotion.IsActive(at) {
+				found := *promotion
+				promotions = append(promotions, &found)
+			}
+		}
+	}
+	return promotions, nil
+}
+
+func (m *MockPromotionRepository) Redeem(ctx context.Context, id uuid.UUID, at time.Time) (*entities.Promotion, error) {
+	for index, stored := range m.promotions {
+		if stored.Id != id {
+			continue
+		}
+		if !stored.IsActive(at) {
+			return nil, entities.ErrPromotionExhausted
+		}
+		redeemed := *stored
+		redeemed.UsageCount++
+		redeemed.Version++
+		m.promotions[index] = &redeemed
+		result := redeemed
+		return &result, nil
+	}
+	return nil, entities.ErrPromotionNotFound
+}
+
+func (m *MockPromotionRepository) Update(ctx context.Context, promotion *entities.ValidatedPromotion) (*entities.Promotion, error) {
+	for index, stored := range m.promotions {
+		if stored.Id != promotion.Id {
+			continue
+		}
+		if stored.Version != promotion.Version {
+			return nil, entities.ErrVersionConflict
+		}
+		updated := promotion.Promotion
+		updated.Version++
+		m.promotions[index] = &updated
+		result := updated
+		return &result, nil
+	}
+	return nil, entities.ErrPromotionNotFound
+}
+
+func TestPromotionService_CreatePromotion(t *testing.T) {
+	promotionRepo := &MockPromotionRepository{}
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewPromotionService(promotionRepo, sellerRepo, productRepo, NewMockIdempotencyRepository())
+	seller := createPersistedSeller(t, sellerRepo)
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 10000, entities.USD)
+	ctx := context.Background()
+
+	created, err := service.CreatePromotion(ctx, &command.CreatePromotionCommand{
+		SellerId:         seller.Id,
+		Name:             "Five off",
+		DiscountKind:     entities.DiscountFixedAmount,
+		AmountMinorUnits: 500,
+		Currency:         entities.USD,
+		ProductIds:       []uuid.UUID{widget.Id},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created.Result.Amount)
+	assert.Equal(t, int64(500), created.Result.Amount.MinorUnits())
+	assert.WithinDuration(t, time.Now(), created.Result.StartsAt, time.Second, "starts now by default")
+
+	otherSeller := createPersistedSeller(t, sellerRepo)
+	testCases := []struct {
+		name     string
+		cmd      *command.CreatePromotionCommand
+		expected error
+	}{
+		{"unknown seller", &command.CreatePromotionCommand{SellerId: uuid.New(), Name: "Sale", DiscountKind: entities.DiscountPercentage, BasisPoints: 1000}, entities.ErrSellerNotFound},
+		{"unknown product", &command.CreatePromotionCommand{SellerId: seller.Id, Name: "Sale", DiscountKind: entities.DiscountPercentage, BasisPoints: 1000, ProductIds: []uuid.UUID{uuid.New()}}, entities.ErrProductNotFound},
+		{"another seller's product", &command.CreatePromotionCommand{SellerId: otherSeller.Id, Name: "Sale", DiscountKind: entities.DiscountPercentage, BasisPoints: 1000, ProductIds: []uuid.UUID{widget.Id}}, entities.ErrValidation},
+		{"unknown kind", &command.CreatePromotionCommand{SellerId: seller.Id, Name: "Sale", DiscountKind: "bogo"}, entities.ErrValidation},
+		{"invalid percentage", &command.CreatePromotionCommand{SellerId: seller.Id, Name: "Sale", DiscountKind: entities.DiscountPercentage, BasisPoints: 20000}, entities.ErrValidation},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.CreatePromotion(ctx, tc.cmd)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+
+	listed, err := service.FindPromotionsBySeller(ctx, &query.GetPromotionsBySellerQuery{SellerId: seller.Id})
+	require.NoError(t, err)
+	assert.Len(t, listed.Result, 1)
+}
+
+func TestPromotionService_RedeemAndEnd(t *testing.T) {
+	promotionRepo := &MockPromotionRepository{}
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewPromotionService(promotionRepo, sellerRepo, productRepo, NewMockIdempotencyRepository())
+	seller := createPersistedSeller(t, sellerRepo)
+	ctx := context.Background()
+
+	created, err := service.CreatePromotion(ctx, &command.CreatePromotionCommand{
+		SellerId:     seller.Id,
+		Name:         "Launch",
+		DiscountKind: entities.DiscountPercentage,
+		BasisPoints:  1000,
+		StartsAt:     time.Now().Add(-time.Hour),
+		UsageLimit:   1,
+	})
+	require.NoError(t, err)
+
+	stale := 99
+	_, err = service.RedeemPromotion(ctx, &command.RedeemPromotionCommand{Id: created.Result.Id, ExpectedVersion: &stale})
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	redeemed, err := service.RedeemPromotion(ctx, &command.RedeemPromotionCommand{Id: created.Result.Id})
+	require.NoError(t, err)
+	assert.Equal(t, 1, redeemed.Result.UsageCount)
+	assert.Equal(t, 2, redeemed.Result.Version)
+
+	_, err = service.RedeemPromotion(ctx, &command.RedeemPromotionCommand{Id: created.Result.Id})
+	assert.ErrorIs(t, err, entities.ErrPromotionExhausted)
+
+	ended, err := service.EndPromotion(ctx, &command.EndPromotionCommand{Id: created.Result.Id})
+	require.NoError(t, err)
+	require.NotNil(t, ended.Result.EndsAt)
+
+	_, err = service.EndPromotion(ctx, &command.EndPromotionCommand{Id: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrPromotionNotFound)
+
+	found, err := service.FindPromotionById(ctx, &query.GetPromotionByIdQuery{Id: uuid.New()})
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestProductService_EffectivePrice(t *testing.T) {
+	promotionRepo := &MockPromotionRepository{}
+	productRepo := &MockProductRepository{}
+	sellerRepo := &MockSellerRepository{}
+	service := NewPromotionService(promotionRepo, sellerRepo, productRepo, NewMockIdempotencyRepository())
+	seller := createPersistedSeller(t, sellerRepo)
+	widget := createPublishedProduct(t, productRepo, seller, "Widget", 10000, entities.USD)
+	ctx := context.Background()
+	productService := NewProductService(productRepo, sellerRepo, promotionRepo, NewMockIdempotencyRepository(), NewConversionService(&MockExchangeRateProvider{}))
+
+	withoutPromotion, err := productService.FindProductById(ctx, &query.GetProductByIdQuery{Id: widget.Id})
+	require.NoError(t, err)
+	require.NotNil(t, withoutPromotion.Result.EffectivePrice)
+	assert.Equal(t, widget.Price, withoutPromotion.Result.EffectivePrice.Price)
+	assert.Empty(t, withoutPromotion.Result.EffectivePrice.PromotionIds)
+
+	created, err := service.CreatePromotion(ctx, &command.CreatePromotionCommand{
+		SellerId:     seller.Id,
+		Name:         "Spring sale",
+		DiscountKind: entities.DiscountPercentage,
+		BasisPoints:  2500,
+		StartsAt:     time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	listed, err := productService.FindAllProducts(ctx, &query.GetAllProductsQuery{})
+	require.NoError(t, err)
+	require.Len(t, listed.Result, 1)
+	effective := listed.Result[0].EffectivePrice
+	assert.Equal(t, int64(7500), effective.Price.MinorUnits())
+	assert.Equal(t, []uuid.UUID{created.Result.Id}, effective.PromotionIds)
+	assert.Equal(t, int64(10000), listed.Result[0].Price.MinorUnits(), "the list price is unchanged")
+	require.Len(t, listed.Result[0].Variants, 1)
+	variantEffective := listed.Result[0].Variants[0].EffectivePrice
+	require.NotNil(t, variantEffective)
+	assert.Equal(t, int64(7500), variantEffective.Price.MinorUnits())
+	assert.Equal(t, []uuid.UUID{created.Result.Id}, variantEffective.PromotionIds)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/sklinkert/go-ddd/internal/application/command"
 	"github.com/sklinkert/go-ddd/internal/application/interfaces"
@@ -44,14 +45,50 @@ func (s *SellerService) CreateSeller(ctx context.Context, sellerCommand *command
 	})
 }
 
-// FindAllSellers fetches all sellers
-func (s *SellerService) FindAllSellers(ctx context.Context) (*query.GetAllSellersQueryResult, error) {
-	storedSellers, err := s.repo.FindAll(ctx)
+// FindAllSellers fetches one page of sellers
+func (s *SellerService) FindAllSellers(ctx context.Context, sellerQuery *query.GetAllSellersQuery) (*query.GetAllSellersQueryResult, error) {
+	sortBy := repositories.SellerSortField(sellerQuery.SortBy)
+	switch sortBy {
+	case "":
+		sortBy = repositories.SellerSortByCreatedAt
+	case repositories.SellerSortByCreatedAt, repositories.SellerSortByName:
+	default:
+		return nil, fmt.Errorf("%w: unsupported sort %q", entities.ErrValidation, sellerQuery.SortBy)
+	}
+
+	limit, err := pageSize(sellerQuery.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := decodeCursor(sellerQuery.Cursor, string(sortBy))
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra row tells us whether a next page exists.
+	criteria := repositories.SellerListCriteria{SortBy: sortBy, Limit: limit + 1}
+	if cursor != nil {
+		criteria.After = &repositories.SellerCursor{Id: cursor.Id, CreatedAt: cursor.CreatedAt, Name: cursor.Name}
+	}
+
+	storedSellers, err := s.repo.FindAll(ctx, criteria)
 	if err != nil {
 		return nil, err
 	}
 
 	var queryResult query.GetAllSellersQueryResult
+	if len(storedSellers) > limit {
+		storedSellers = storedSellers[:limit]
+		last := storedSellers[limit-1]
+		queryResult.NextCursor = encodeCursor(pageCursor{
+			Sort:      string(sortBy),
+			Id:        last.Id,
+			CreatedAt: last.CreatedAt,
+			Name:      last.Name,
+		})
+	}
+
 	for _, seller := range storedSellers {
 		queryResult.Result = append(queryResult.Result, mapper.NewSellerResultFromEntity(seller))
 	}
@@ -89,6 +126,10 @@ func (s *SellerService) UpdateSeller(ctx context.Context, updateCommand *command
 			return nil, entities.ErrSellerNotFound
 		}
 
+		if err := checkExpectedVersion(updateCommand.ExpectedVersion, seller.Version); err != nil {
+			return nil, err
+		}
+
 		if err := seller.UpdateName(updateCommand.Name); err != nil {
 			return nil, err
 		}
@@ -98,12 +139,13 @@ func (s *SellerService) UpdateSeller(ctx context.Context, updateCommand *command
 			return nil, err
 		}
 
-		if _, err := s.repo.Update(ctx, validatedUpdatedSeller); err != nil {
+		updatedSeller, err := s.repo.Update(ctx, validatedUpdatedSeller)
+		if err != nil {
 			return nil, err
 		}
 
 		return &command.UpdateSellerCommandResult{
-			Result: mapper.NewSellerResultFromValidatedEntity(validatedUpdatedSeller),
+			Result: mapper.NewSellerResultFromEntity(updatedSeller),
 		}, nil
 	})
 }
@@ -119,7 +161,13 @@ func (s *SellerService) DeleteSeller(ctx context.Context, sellerCommand *command
 			return nil, entities.ErrSellerNotFound
 		}
 
-		if err := s.repo.Delete(ctx, sellerCommand.Id); err != nil {
+		if err := checkExpectedVersion(sellerCommand.ExpectedVersion, existingSeller.Version); err != nil {
+			return nil, err
+		}
+
+		existingSeller.Delete()
+
+		if err := s.repo.Delete(ctx, existingSeller); err != nil {
 			return nil, err
 		}
 
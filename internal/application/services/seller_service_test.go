@@ -9,6 +9,7 @@ import (
 	"github.com/sklinkert/go-ddd/internal/application/command"
 	"github.com/sklinkert/go-ddd/internal/application/query"
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
 )
 
 // MockSellerRepository is a mock implementation of the SellerRepository interface
@@ -21,9 +22,19 @@ func (m *MockSellerRepository) Create(ctx context.Context, seller *entities.Vali
 	return &seller.Seller, nil
 }
 
-func (m *MockSellerRepository) FindAll(ctx context.Context) ([]*entities.Seller, error) {
+// FindAll pages in insertion order: After skips up to and including the
+// seller with the cursor's Id.
+func (m *MockSellerRepository) FindAll(ctx context.Context, criteria repositories.SellerListCriteria) ([]*entities.Seller, error) {
 	var sellers []*entities.Seller
+	skipping := criteria.After != nil
 	for _, s := range m.sellers {
+		if skipping {
+			skipping = s.Id != criteria.After.Id
+			continue
+		}
+		if len(sellers) == criteria.Limit {
+			break
+		}
 		sellers = append(sellers, &s.Seller)
 	}
 	return sellers, nil
@@ -38,9 +49,9 @@ func (m *MockSellerRepository) FindById(ctx context.Context, id uuid.UUID) (*ent
 	return nil, nil
 }
 
-func (m *MockSellerRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockSellerRepository) Delete(ctx context.Context, seller *entities.Seller) error {
 	for index, s := range m.sellers {
-		if s.Id == id {
+		if s.Id == seller.Id {
 			m.sellers = append(m.sellers[:index], m.sellers[index+1:]...)
 			return nil
 		}
@@ -51,6 +62,7 @@ func (m *MockSellerRepository) Delete(ctx context.Context, id uuid.UUID) error {
 func (m *MockSellerRepository) Update(ctx context.Context, seller *entities.ValidatedSeller) (*entities.Seller, error) {
 	for index, s := range m.sellers {
 		if s.Id == seller.Id {
+			seller.Version++
 			m.sellers[index] = seller
 			return &seller.Seller, nil
 		}
@@ -82,7 +94,7 @@ func TestSellerService_GetAllSellers(t *testing.T) {
 	_, _ = service.CreateSeller(context.Background(), getCreateSellerCommand("John Doe"))
 	_, _ = service.CreateSeller(context.Background(), getCreateSellerCommand("Jane Doe"))
 
-	sellers, err := service.FindAllSellers(context.Background())
+	sellers, err := service.FindAllSellers(context.Background(), &query.GetAllSellersQuery{})
 	if err != nil {
 		t.Errorf("Unexpected error: %s", err)
 	}
@@ -92,6 +104,39 @@ func TestSellerService_GetAllSellers(t *testing.T) {
 	}
 }
 
+func TestSellerService_FindAllSellers_Paginates(t *testing.T) {
+	repo := &MockSellerRepository{}
+	service := NewSellerService(repo, NewMockIdempotencyRepository())
+
+	for _, name := range []string{"Seller1", "Seller2", "Seller3"} {
+		if _, err := service.CreateSeller(context.Background(), &command.CreateSellerCommand{Name: name}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	firstPage, err := service.FindAllSellers(context.Background(), &query.GetAllSellersQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(firstPage.Result) != 2 || firstPage.NextCursor == "" {
+		t.Fatalf("Expected 2 sellers and a next cursor, got %d sellers and cursor %q", len(firstPage.Result), firstPage.NextCursor)
+	}
+
+	secondPage, err := service.FindAllSellers(context.Background(), &query.GetAllSellersQuery{Limit: 2, Cursor: firstPage.NextCursor})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(secondPage.Result) != 1 || secondPage.NextCursor != "" {
+		t.Errorf("Expected 1 seller and no next cursor, got %d sellers and cursor %q", len(secondPage.Result), secondPage.NextCursor)
+	}
+
+	// A cursor is bound to the sort order it was issued for.
+	_, err = service.FindAllSellers(context.Background(), &query.GetAllSellersQuery{SortBy: "name", Cursor: firstPage.NextCursor})
+	if !errors.Is(err, entities.ErrValidation) {
+		t.Errorf("Expected validation error for mismatched cursor, got %v", err)
+	}
+}
+
 func TestSellerService_GetSellerById(t *testing.T) {
 	repo := &MockSellerRepository{}
 	idempotencyRepo := NewMockIdempotencyRepository()
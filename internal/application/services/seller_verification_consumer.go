@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// SellerVerificationCompletedType is the message type the external KYC
+// system sends when it finishes checking a seller.
+const SellerVerificationCompletedType = "kyc.verification_completed"
+
+type sellerVerificationCompleted struct {
+	SellerId uuid.UUID `json:"seller_id"`
+	// Result is "verified" or "rejected".
+	Result string `json:"result"`
+}
+
+// SellerVerificationConsumer applies KYC results to sellers. The update
+// and its SellerVerificationChanged event commit with the inbox message.
+type SellerVerificationConsumer struct {
+	repo repositories.SellerRepository
+}
+
+func NewSellerVerificationConsumer(repo repositories.SellerRepository) interfaces.InboxConsumer {
+	return &SellerVerificationConsumer{repo: repo}
+}
+
+func (c *SellerVerificationConsumer) MessageType() string {
+	return SellerVerificationCompletedType
+}
+
+func (c *SellerVerificationConsumer) Handle(ctx context.Context, message *entities.InboxMessage) error {
+	var payload sellerVerificationCompleted
+	if err := json.Unmarshal(message.Payload, &payload); err != nil {
+		return fmt.Errorf("%w: invalid %s payload: %v", entities.ErrValidation, message.Type, err)
+	}
+
+	seller, err := c.repo.FindById(ctx, payload.SellerId)
+	if err != nil {
+		return err
+	}
+	if seller == nil {
+		// Sellers are never created after their check, so this is permanent.
+		return fmt.Errorf("%w: %w: %s", entities.ErrValidation, entities.ErrSellerNotFound, payload.SellerId)
+	}
+
+	if err := seller.ApplyVerification(entities.VerificationStatus(payload.Result)); err != nil {
+		return err
+	}
+
+	validatedSeller, err := entities.NewValidatedSeller(seller)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.repo.Update(ctx, validatedSeller)
+	return err
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/mapper"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+const (
+	defaultWebhookDeliveryPageSize = 50
+	maxWebhookDeliveryPageSize     = 500
+)
+
+type WebhookService struct {
+	repo    repositories.WebhookSubscriptionRepository
+	targets repositories.WebhookTargetPolicy
+}
+
+func NewWebhookService(repo repositories.WebhookSubscriptionRepository, targets repositories.WebhookTargetPolicy) interfaces.WebhookService {
+	return &WebhookService{repo: repo, targets: targets}
+}
+
+func (s *WebhookService) CreateWebhookSubscription(ctx context.Context, subscriptionCommand *command.CreateWebhookSubscriptionCommand) (*command.CreateWebhookSubscriptionCommandResult, error) {
+	subscription, err := entities.NewWebhookSubscription(subscriptionCommand.URL, subscriptionCommand.EventTypes, subscriptionCommand.Secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.targets.Check(ctx, subscription.URL); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return &command.CreateWebhookSubscriptionCommandResult{
+		Result: mapper.NewWebhookSubscriptionResultFromEntity(subscription),
+	}, nil
+}
+
+func (s *WebhookService) FindAllWebhookSubscriptions(ctx context.Context) (*query.GetAllWebhookSubscriptionsQueryResult, error) {
+	subscriptions, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.GetAllWebhookSubscriptionsQueryResult{Result: make([]*common.WebhookSubscriptionResult, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		queryResult.Result = append(queryResult.Result, mapper.NewWebhookSubscriptionResultFromEntity(subscription))
+	}
+
+	return &queryResult, nil
+}
+
+func (s *WebhookService) FindWebhookSubscriptionById(ctx context.Context, subscriptionQuery *query.GetWebhookSubscriptionByIdQuery) (*query.GetWebhookSubscriptionByIdQueryResult, error) {
+	subscription, err := s.repo.FindById(ctx, subscriptionQuery.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query.GetWebhookSubscriptionByIdQueryResult{
+		Result: mapper.NewWebhookSubscriptionResultFromEntity(subscription),
+	}, nil
+}
+
+func (s *WebhookService) DeleteWebhookSubscription(ctx context.Context, subscriptionCommand *command.DeleteWebhookSubscriptionCommand) (*command.DeleteWebhookSubscriptionCommandResult, error) {
+	if err := s.repo.Delete(ctx, subscriptionCommand.Id); err != nil {
+		return nil, err
+	}
+	return &command.DeleteWebhookSubscriptionCommandResult{Success: true}, nil
+}
+
+// FindWebhookDeliveries returns the subscription's most recent deliveries,
+// at most maxWebhookDeliveryPageSize of them.
+func (s *WebhookService) FindWebhookDeliveries(ctx context.Context, deliveryQuery *query.GetWebhookDeliveriesQuery) (*query.GetWebhookDeliveriesQueryResult, error) {
+	limit := deliveryQuery.Limit
+	switch {
+	case limit < 0:
+		return nil, fmt.Errorf("%w: limit must not be negative", entities.ErrValidation)
+	case limit == 0:
+		limit = defaultWebhookDeliveryPageSize
+	case limit > maxWebhookDeliveryPageSize:
+		limit = maxWebhookDeliveryPageSize
+	}
+
+	deliveries, err := s.repo.FindDeliveries(ctx, deliveryQuery.SubscriptionId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	queryResult := query.GetWebhookDeliveriesQueryResult{Result: make([]*common.WebhookDeliveryResult, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		queryResult.Result = append(queryResult.Result, mapper.NewWebhookDeliveryResultFromEntity(delivery))
+	}
+
+	return &queryResult, nil
+}
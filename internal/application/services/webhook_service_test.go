@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// MockWebhookSubscriptionRepository keeps subscriptions in memory and
+// records the limit of the last FindDeliveries.
+type MockWebhookSubscriptionRepository struct {
+	subscriptions []*entities.WebhookSubscription
+	deliveries    map[uuid.UUID][]*entities.WebhookDelivery
+	limit         int
+}
+
+func (m *MockWebhookSubscriptionRepository) Create(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	m.subscriptions = append(m.subscriptions, subscription)
+	return nil
+}
+
+func (m *MockWebhookSubscriptionRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	for _, subscription := range m.subscriptions {
+		if subscription.Id == id {
+			return subscription, nil
+		}
+	}
+	return nil, entities.ErrWebhookSubscriptionNotFound
+}
+
+func (m *MockWebhookSubscriptionRepository) FindAll(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	return m.subscriptions, nil
+}
+
+func (m *MockWebhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	for index, subscription := range m.subscriptions {
+		if subscription.Id == id {
+			m.subscriptions = append(m.subscriptions[:index], m.subscriptions[index+1:]...)
+			return nil
+		}
+	}
+	return entities.ErrWebhookSubscriptionNotFound
+}
+
+func (m *MockWebhookSubscriptionRepository) FindDeliveries(ctx context.Context, subscriptionId uuid.UUID, limit int) ([]*entities.WebhookDelivery, error) {
+	if _, err := m.FindById(ctx, subscriptionId); err != nil {
+		return nil, err
+	}
+	m.limit = limit
+	deliveries := m.deliveries[subscriptionId]
+	return deliveries[:min(limit, len(deliveries))], nil
+}
+
+// MockWebhookTargetPolicy rejects URLs that contain one of its forbidden
+// hosts.
+type MockWebhookTargetPolicy struct {
+	forbidden []string
+}
+
+func (m *MockWebhookTargetPolicy) Check(ctx context.Context, rawURL string) error {
+	for _, host := range m.forbidden {
+		if strings.Contains(rawURL, host) {
+			return fmt.Errorf("%w: %s must not be reached", entities.ErrValidation, host)
+		}
+	}
+	return nil
+}
+
+func TestWebhookService_CreateFindDelete(t *testing.T) {
+	repo := &MockWebhookSubscriptionRepository{}
+	service := NewWebhookService(repo, &MockWebhookTargetPolicy{})
+	ctx := context.Background()
+
+	created, err := service.CreateWebhookSubscription(ctx, &command.CreateWebhookSubscriptionCommand{
+		URL:        "https://partner.example/hooks",
+		EventTypes: []string{"product.*"},
+		Secret:     "0123456789abcdef",
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.subscriptions, 1)
+	assert.Equal(t, repo.subscriptions[0].Id, created.Result.Id)
+	assert.Equal(t, "0123456789abcdef", repo.subscriptions[0].Secret)
+
+	found, err := service.FindWebhookSubscriptionById(ctx, &query.GetWebhookSubscriptionByIdQuery{Id: created.Result.Id})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"product.*"}, found.Result.EventTypes)
+
+	listed, err := service.FindAllWebhookSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Len(t, listed.Result, 1)
+
+	_, err = service.DeleteWebhookSubscription(ctx, &command.DeleteWebhookSubscriptionCommand{Id: created.Result.Id})
+	require.NoError(t, err)
+	_, err = service.DeleteWebhookSubscription(ctx, &command.DeleteWebhookSubscriptionCommand{Id: created.Result.Id})
+	assert.ErrorIs(t, err, entities.ErrWebhookSubscriptionNotFound)
+}
+
+func TestWebhookService_CreateRejectsInvalidSubscription(t *testing.T) {
+	repo := &MockWebhookSubscriptionRepository{}
+	service := NewWebhookService(repo, &MockWebhookTargetPolicy{})
+
+	_, err := service.CreateWebhookSubscription(context.Background(), &command.CreateWebhookSubscriptionCommand{
+		URL:        "ftp://partner.example",
+		EventTypes: []string{"*"},
+		Secret:     "0123456789abcdef",
+	})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+	assert.Empty(t, repo.subscriptions)
+}
+
+func TestWebhookService_CreateRejectsForbiddenTarget(t *testing.T) {
+	repo := &MockWebhookSubscriptionRepository{}
+	service := NewWebhookService(repo, &MockWebhookTargetPolicy{forbidden: []string{"169.254.169.254"}})
+
+	_, err := service.CreateWebhookSubscription(context.Background(), &command.CreateWebhookSubscriptionCommand{
+		URL:        "http://169.254.169.254/latest/meta-data",
+		EventTypes: []string{"*"},
+		Secret:     "0123456789abcdef",
+	})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+	assert.Empty(t, repo.subscriptions, "nothing is stored for a target the policy rejects")
+}
+
+func TestWebhookService_FindWebhookDeliveries(t *testing.T) {
+	subscription := &entities.WebhookSubscription{Id: uuid.New()}
+	nextAttemptAt := time.Now().Add(time.Minute)
+	repo := &MockWebhookSubscriptionRepository{
+		subscriptions: []*entities.WebhookSubscription{subscription},
+		deliveries: map[uuid.UUID][]*entities.WebhookDelivery{subscription.Id: {
+			{Id: uuid.New(), Status: entities.WebhookDeliveryPending, NextAttemptAt: nextAttemptAt},
+			{Id: uuid.New(), Status: entities.WebhookDeliveryFailed, NextAttemptAt: nextAttemptAt},
+		}},
+	}
+	service := NewWebhookService(repo, &MockWebhookTargetPolicy{})
+	ctx := context.Background()
+
+	result, err := service.FindWebhookDeliveries(ctx, &query.GetWebhookDeliveriesQuery{SubscriptionId: subscription.Id})
+	require.NoError(t, err)
+	assert.Equal(t, defaultWebhookDeliveryPageSize, repo.limit)
+	require.Len(t, result.Result, 2)
+	assert.Equal(t, "pending", result.Result[0].Status)
+	require.NotNil(t, result.Result[0].NextAttemptAt, "a pending delivery tells when it is retried")
+	assert.Nil(t, result.Result[1].NextAttemptAt)
+
+	_, err = service.FindWebhookDeliveries(ctx, &query.GetWebhookDeliveriesQuery{SubscriptionId: subscription.Id, Limit: 10000})
+	require.NoError(t, err)
+	assert.Equal(t, maxWebhookDeliveryPageSize, repo.limit)
+
+	_, err = service.FindWebhookDeliveries(ctx, &query.GetWebhookDeliveriesQuery{SubscriptionId: subscription.Id, Limit: -1})
+	assert.ErrorIs(t, err, entities.ErrValidation)
+
+	_, err = service.FindWebhookDeliveries(ctx, &query.GetWebhookDeliveriesQuery{SubscriptionId: uuid.New()})
+	assert.ErrorIs(t, err, entities.ErrWebhookSubscriptionNotFound)
+}
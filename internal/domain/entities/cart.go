@@ -0,0 +1,288 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cart is a buyer's selection before checkout; each buyer has at most one.
+// Lines copy the product name and effective price (see EffectiveVariantPrice)
+// when added, so the buyer sees what they chose even if the product or its
+// promotions change later. Checkout compares the copies with the live
+// products and promotions (Revalidate) before an order is placed.
+//
+// All lines share one currency. A cart expires after a period without
+// changes and then counts as empty.
+//
+// Carts record no domain events: they are a buyer's private scratch pad, and
+// checkout announces the result as OrderCreated.
+type Cart struct {
+	BuyerId   uuid.UUID
+	Lines     []CartLine
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+	// Version is incremented on every persisted change; 0 means the cart was
+	// never saved.
+	Version int
+}
+
+// CartLine is one product variant in a cart.
+type CartLine struct {
+	ProductId   uuid.UUID
+	VariantId   uuid.UUID
+	Sku         string
+	ProductName string
+	UnitPrice   Money
+	Quantity    int
+}
+
+// Total is the line's unit price times its quantity.
+func (l CartLine) Total() (Money, error) {
+	return lineTotal(l.ProductName, l.UnitPrice, l.Quantity)
+}
+
+// CartChange describes how Revalidate brought a line up to date.
+type CartChange struct {
+	ProductId   uuid.UUID
+	VariantId   uuid.UUID
+	ProductName string
+	// Removed is set when the product or variant no longer exists, the
+	// product is no longer published or is now sold in another currency;
+	// otherwise the effective price changed from OldPrice to NewPrice.
+	Removed  bool
+	OldPrice Money
+	NewPrice Money
+}
+
+func (c CartChange) String() string {
+	if c.Removed {
+		return fmt.Sprintf("%s is no longer available", c.ProductName)
+	}
+	return fmt.Sprintf("%s now costs %s instead of %s", c.ProductName, c.NewPrice, c.OldPrice)
+}
+
+// NewCart returns the empty cart of buyerId, expiring after ttl.
+func NewCart(buyerId uuid.UUID, ttl time.Duration) *Cart {
+	now := time.Now()
+	return &Cart{
+		BuyerId:   buyerId,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+func (c *Cart) validate() error {
+	if c.BuyerId == uuid.Nil {
+		return fmt.Errorf("%w: buyer id must not be empty", ErrValidation)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(c.Lines))
+	for _, line := range c.Lines {
+		if line.ProductId == uuid.Nil || line.VariantId == uuid.Nil {
+			return fmt.Errorf("%w: product and variant id must not be empty", ErrValidation)
+		}
+		if seen[line.VariantId] {
+			return fmt.Errorf("%w: variant %s appears in more than one line", ErrValidation, line.VariantId)
+		}
+		seen[line.VariantId] = true
+		if line.Quantity <= 0 {
+			return fmt.Errorf("%w: quantity must be greater than 0", ErrValidation)
+		}
+		if line.UnitPrice.Currency() != c.Lines[0].UnitPrice.Currency() {
+			return fmt.Errorf("%w: all cart items must share one currency", ErrValidation)
+		}
+	}
+	if _, err := c.Total(); err != nil {
+		return err
+	}
+	if c.CreatedAt.After(c.UpdatedAt) {
+		return fmt.Errorf("%w: created_at must be before updated_at", ErrValidation)
+	}
+
+	return nil
+}
+
+// Currency is the currency all lines share; empty for an empty cart.
+func (c *Cart) Currency() Currency {
+	if len(c.Lines) == 0 {
+		return ""
+	}
+	return c.Lines[0].UnitPrice.Currency()
+}
+
+// Total is the sum of all lines; the zero Money for an empty cart.
+func (c *Cart) Total() (Money, error) {
+	if len(c.Lines) == 0 {
+		return Money{}, nil
+	}
+
+	sum, err := NewMoney(0, c.Currency())
+	if err != nil {
+		return Money{}, err
+	}
+	for _, line := range c.Lines {
+		total, err := line.Total()
+		if err != nil {
+			return Money{}, err
+		}
+		if sum, err = sum.Add(total); err != nil {
+			return Money{}, err
+		}
+	}
+
+	return sum, nil
+}
+
+// IsExpired reports whether the cart went without changes for too long.
+func (c *Cart) IsExpired(now time.Time) bool {
+	return !c.ExpiresAt.After(now)
+}
+
+// Touch marks the cart as changed and pushes its expiry ttl into the future.
+func (c *Cart) Touch(ttl time.Duration) {
+	c.UpdatedAt = time.Now()
+	c.ExpiresAt = c.UpdatedAt.Add(ttl)
+}
+
+// Empty removes all lines, e.g. after checkout or when the cart expired.
+func (c *Cart) Empty() {
+	c.Lines = nil
+}
+
+// AddItem adds quantity units of a product variant; a nil variantId picks
+// the variant of a single-variant product (see Product.ResolveVariant).
+// Adding a variant that is already in the cart raises its quantity and
+// refreshes the copied name and price. The line is priced at the effective
+// price with promotions at at, as checkout charges it.
+func (c *Cart) AddItem(product *Product, variantId uuid.UUID, quantity int, promotions []*Promotion, at time.Time) error {
+	if quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be greater than 0", ErrValidation)
+	}
+	if !product.IsPublished() {
+		return fmt.Errorf("%w: %s", ErrProductNotPublished, product.Name)
+	}
+	variant, err := product.ResolveVariant(variantId)
+	if err != nil {
+		return err
+	}
+	price, _, err := EffectiveVariantPrice(product, variant, promotions, at)
+	if err != nil {
+		return err
+	}
+	if currency := c.Currency(); currency != "" && price.Currency() != currency {
+		return fmt.Errorf("%w: the cart holds %s items, %s costs %s", ErrValidation, currency, product.Name, price.Currency())
+	}
+
+	for i := range c.Lines {
+		if c.Lines[i].VariantId == variant.Id {
+			c.Lines[i].Sku = variant.Sku
+			c.Lines[i].ProductName = product.Name
+			c.Lines[i].UnitPrice = price
+			c.Lines[i].Quantity += quantity
+			return nil
+		}
+	}
+
+	c.Lines = append(c.Lines, CartLine{
+		ProductId:   product.Id,
+		VariantId:   variant.Id,
+		Sku:         variant.Sku,
+		ProductName: product.Name,
+		UnitPrice:   price,
+		Quantity:    quantity,
+	})
+	return nil
+}
+
+// SetQuantity replaces a line's quantity; 0 removes the line. Lines are
+// found as described at RemoveItem.
+func (c *Cart) SetQuantity(productId, variantId uuid.UUID, quantity int) error {
+	if quantity < 0 {
+		return fmt.Errorf("%w: quantity must not be negative", ErrValidation)
+	}
+	if quantity == 0 {
+		return c.RemoveItem(productId, variantId)
+	}
+
+	index, err := c.lineIndex(productId, variantId)
+	if err != nil {
+		return err
+	}
+	c.Lines[index].Quantity = quantity
+	return nil
+}
+
+// RemoveItem removes the line of a product variant. A nil variantId matches
+// the product's line as long as the cart holds only one variant of it.
+func (c *Cart) RemoveItem(productId, variantId uuid.UUID) error {
+	index, err := c.lineIndex(productId, variantId)
+	if err != nil {
+		return err
+	}
+	c.Lines = append(c.Lines[:index], c.Lines[index+1:]...)
+	return nil
+}
+
+func (c *Cart) lineIndex(productId, variantId uuid.UUID) (int, error) {
+	found := -1
+	for i, line := range c.Lines {
+		if line.ProductId != productId || (variantId != uuid.Nil && line.VariantId != variantId) {
+			continue
+		}
+		if found >= 0 {
+			return -1, fmt.Errorf("%w: the cart holds several variants of %s, choose one", ErrValidation, line.ProductName)
+		}
+		found = i
+	}
+	if found < 0 {
+		return -1, fmt.Errorf("%w: %s", ErrCartItemNotFound, productId)
+	}
+	return found, nil
+}
+
+// Revalidate compares each line with the live product, keyed by product id
+// in current (a missing entry or nil means the product is gone). Lines of
+// gone variants, of gone or unpublished products, and of variants now sold
+// in another currency, are removed; lines whose effective price with
+// promotions at at differs take the new price. It returns what changed.
+func (c *Cart) Revalidate(current map[uuid.UUID]*Product, promotions []*Promotion, at time.Time) ([]CartChange, error) {
+	var changes []CartChange
+	currency := c.Currency()
+	lines := make([]CartLine, 0, len(c.Lines))
+	for _, line := range c.Lines {
+		product := current[line.ProductId]
+		var variant ProductVariant
+		found := false
+		if product != nil {
+			variant, found = product.Variant(line.VariantId)
+		}
+		if !found || !product.IsPublished() || product.VariantPrice(variant).Currency() != currency {
+			changes = append(changes, CartChange{ProductId: line.ProductId, VariantId: line.VariantId, ProductName: line.ProductName, Removed: true})
+			continue
+		}
+		price, _, err := EffectiveVariantPrice(product, variant, promotions, at)
+		if err != nil {
+			return nil, err
+		}
+		if price != line.UnitPrice {
+			changes = append(changes, CartChange{
+				ProductId:   line.ProductId,
+				VariantId:   line.VariantId,
+				ProductName: line.ProductName,
+				OldPrice:    line.UnitPrice,
+				NewPrice:    price,
+			})
+			line.UnitPrice = price
+		}
+		line.ProductName = product.Name
+		line.Sku = variant.Sku
+		lines = append(lines, line)
+	}
+	c.Lines = lines
+
+	return changes, nil
+}
@@ -0,0 +1,185 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCartProduct(t *testing.T, name string, minorUnits int64, currency Currency) *Product {
+	t.Helper()
+	seller, err := NewValidatedSeller(NewSeller("Acme"))
+	require.NoError(t, err)
+	product := NewProduct(name, mustMoney(t, minorUnits, currency), *seller)
+	require.NoError(t, product.Publish())
+	return product
+}
+
+func TestCart_AddItem(t *testing.T) {
+	cart := NewCart(uuid.New(), time.Hour)
+	widget := testCartProduct(t, "Widget", 999, USD)
+
+	require.NoError(t, cart.AddItem(widget, uuid.Nil, 1, nil, time.Now()))
+	widget.Price = mustMoney(t, 1099, USD)
+	require.NoError(t, cart.AddItem(widget, uuid.Nil, 2, nil, time.Now()))
+
+	require.Len(t, cart.Lines, 1, "adding a product twice raises its quantity")
+	assert.Equal(t, 3, cart.Lines[0].Quantity)
+	assert.Equal(t, widget.Price, cart.Lines[0].UnitPrice, "and refreshes its price")
+	total, err := cart.Total()
+	require.NoError(t, err)
+	assert.Equal(t, mustMoney(t, 3297, USD), total)
+
+	assert.ErrorIs(t, cart.AddItem(testCartProduct(t, "Gadget", 500, EUR), uuid.Nil, 1, nil, time.Now()), ErrValidation, "currencies are not mixed")
+	assert.ErrorIs(t, cart.AddItem(widget, uuid.Nil, 0, nil, time.Now()), ErrValidation)
+	draft := testCartProduct(t, "Prototype", 100, USD)
+	draft.Status = ProductDraft
+	assert.ErrorIs(t, cart.AddItem(draft, uuid.Nil, 1, nil, time.Now()), ErrProductNotPublished)
+	assert.Len(t, cart.Lines, 1)
+}
+
+func TestCart_SetQuantityAndRemove(t *testing.T) {
+	cart := NewCart(uuid.New(), time.Hour)
+	widget := testCartProduct(t, "Widget", 999, USD)
+	gadget := testCartProduct(t, "Gadget", 500, USD)
+	require.NoError(t, cart.AddItem(widget, uuid.Nil, 1, nil, time.Now()))
+	require.NoError(t, cart.AddItem(gadget, uuid.Nil, 1, nil, time.Now()))
+
+	require.NoError(t, cart.SetQuantity(widget.Id, uuid.Nil, 4))
+	assert.Equal(t, 4, cart.Lines[0].Quantity)
+	require.NoError(t, cart.SetQuantity(widget.Id, uuid.Nil, 0))
+	require.Len(t, cart.Lines, 1, "quantity 0 removes the line")
+	assert.Equal(t, gadget.Id, cart.Lines[0].ProductId)
+
+	assert.ErrorIs(t, cart.SetQuantity(widget.Id, uuid.Nil, 1), ErrCartItemNotFound)
+	assert.ErrorIs(t, cart.SetQuantity(gadget.Id, uuid.Nil, -1), ErrValidation)
+	require.NoError(t, cart.RemoveItem(gadget.Id, uuid.Nil))
+	assert.ErrorIs(t, cart.RemoveItem(gadget.Id, uuid.Nil), ErrCartItemNotFound)
+	assert.Empty(t, cart.Currency(), "an empty cart accepts any currency again")
+}
+
+func TestCart_Variants(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Acme"))
+	require.NoError(t, err)
+	override := mustMoney(t, 1299, USD)
+	small := NewProductVariant("TEE-S", map[string]string{"size": "S"}, nil)
+	large := NewProductVariant("TEE-L", map[string]string{"size": "L"}, &override)
+	shirt := NewProduct("T-shirt", mustMoney(t, 999, USD), *seller, small, large)
+	require.NoError(t, shirt.Publish())
+	cart := NewCart(uuid.New(), time.Hour)
+
+	assert.ErrorIs(t, cart.AddItem(shirt, uuid.Nil, 1, nil, time.Now()), ErrValidation, "the variant must be chosen")
+	require.NoError(t, cart.AddItem(shirt, small.Id, 1, nil, time.Now()))
+	require.NoError(t, cart.AddItem(shirt, large.Id, 2, nil, time.Now()))
+	require.Len(t, cart.Lines, 2, "each variant gets its own line")
+	assert.Equal(t, "TEE-L", cart.Lines[1].Sku)
+	assert.Equal(t, override, cart.Lines[1].UnitPrice, "the price override applies")
+
+	assert.ErrorIs(t, cart.SetQuantity(shirt.Id, uuid.Nil, 3), ErrValidation, "ambiguous without the variant")
+	require.NoError(t, cart.SetQuantity(shirt.Id, large.Id, 3))
+	assert.Equal(t, 3, cart.Lines[1].Quantity)
+
+	require.NoError(t, shirt.RemoveVariant(small.Id))
+	changes, err := cart.Revalidate(map[uuid.UUID]*Product{shirt.Id: shirt}, nil, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []CartChange{{ProductId: shirt.Id, VariantId: small.Id, ProductName: "T-shirt", Removed: true}}, changes)
+	require.NoError(t, cart.RemoveItem(shirt.Id, uuid.Nil), "one line left, so no variant is needed")
+	assert.Empty(t, cart.Lines)
+}
+
+func TestCart_Revalidate(t *testing.T) {
+	cart := NewCart(uuid.New(), time.Hour)
+	widget := testCartProduct(t, "Widget", 999, USD)
+	gadget := testCartProduct(t, "Gadget", 500, USD)
+	gizmo := testCartProduct(t, "Gizmo", 100, USD)
+	deleted := testCartProduct(t, "Doohickey", 200, USD)
+	for _, product := range []*Product{widget, gadget, gizmo, deleted} {
+		require.NoError(t, cart.AddItem(product, uuid.Nil, 1, nil, time.Now()))
+	}
+
+	repriced := *gadget
+	repriced.Price = mustMoney(t, 650, USD)
+	inEuro := *gizmo
+	inEuro.Price = mustMoney(t, 100, EUR)
+
+	changes, err := cart.Revalidate(map[uuid.UUID]*Product{widget.Id: widget, gadget.Id: &repriced, gizmo.Id: &inEuro}, nil, time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, []CartChange{
+		{ProductId: gadget.Id, VariantId: gadget.Variants[0].Id, ProductName: "Gadget", OldPrice: mustMoney(t, 500, USD), NewPrice: mustMoney(t, 650, USD)},
+		{ProductId: gizmo.Id, VariantId: gizmo.Variants[0].Id, ProductName: "Gizmo", Removed: true},
+		{ProductId: deleted.Id, VariantId: deleted.Variants[0].Id, ProductName: "Doohickey", Removed: true},
+	}, changes)
+	require.Len(t, cart.Lines, 2)
+	assert.Equal(t, mustMoney(t, 650, USD), cart.Lines[1].UnitPrice)
+	assert.Equal(t, "Gadget now costs 6.50 USD instead of 5.00 USD", changes[0].String())
+
+	changes, err = cart.Revalidate(map[uuid.UUID]*Product{widget.Id: widget, gadget.Id: &repriced}, nil, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, changes, "an up-to-date cart does not change")
+}
+
+func TestCart_Promotions(t *testing.T) {
+	cart := NewCart(uuid.New(), time.Hour)
+	widget := testCartProduct(t, "Widget", 1000, USD)
+	endsAt := promotionStart.Add(2 * time.Hour)
+	sale := newTestPromotion(t, widget.SellerId, NewPercentageDiscount(1000), PromotionTerms{EndsAt: &endsAt})
+	during := promotionStart.Add(time.Hour)
+
+	require.NoError(t, cart.AddItem(widget, uuid.Nil, 1, []*Promotion{sale}, during))
+	assert.Equal(t, mustMoney(t, 900, USD), cart.Lines[0].UnitPrice, "lines are priced as checkout charges them")
+
+	changes, err := cart.Revalidate(map[uuid.UUID]*Product{widget.Id: widget}, []*Promotion{sale}, during)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = cart.Revalidate(map[uuid.UUID]*Product{widget.Id: widget}, []*Promotion{sale}, promotionStart.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []CartChange{{ProductId: widget.Id, VariantId: widget.Variants[0].Id, ProductName: "Widget", OldPrice: mustMoney(t, 900, USD), NewPrice: mustMoney(t, 1000, USD)}}, changes, "the sale ended")
+	assert.Equal(t, mustMoney(t, 1000, USD), cart.Lines[0].UnitPrice)
+}
+
+func TestCart_RevalidateRemovesArchivedProducts(t *testing.T) {
+	cart := NewCart(uuid.New(), time.Hour)
+	widget := testCartProduct(t, "Widget", 999, USD)
+	require.NoError(t, cart.AddItem(widget, uuid.Nil, 1, nil, time.Now()))
+
+	archived := *widget
+	require.NoError(t, archived.Archive())
+	changes, err := cart.Revalidate(map[uuid.UUID]*Product{widget.Id: &archived}, nil, time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, []CartChange{{ProductId: widget.Id, VariantId: widget.Variants[0].Id, ProductName: "Widget", Removed: true}}, changes)
+	assert.Empty(t, cart.Lines)
+}
+
+func TestCart_Expiry(t *testing.T) {
+	cart := NewCart(uuid.New(), time.Hour)
+	assert.False(t, cart.IsExpired(time.Now()))
+	assert.True(t, cart.IsExpired(time.Now().Add(2*time.Hour)))
+
+	cart.Touch(3 * time.Hour)
+	assert.False(t, cart.IsExpired(time.Now().Add(2*time.Hour)), "changes push the expiry out")
+}
+
+func TestNewValidatedCart(t *testing.T) {
+	cart := NewCart(uuid.New(), time.Hour)
+	_, err := NewValidatedCart(cart)
+	assert.NoError(t, err, "an empty cart is valid")
+
+	_, err = NewValidatedCart(NewCart(uuid.Nil, time.Hour))
+	assert.ErrorIs(t, err, ErrValidation)
+
+	line := CartLine{ProductId: uuid.New(), VariantId: uuid.New(), ProductName: "Widget", UnitPrice: mustMoney(t, 999, USD), Quantity: 1}
+	cart.Lines = []CartLine{line, line}
+	_, err = NewValidatedCart(cart)
+	assert.ErrorIs(t, err, ErrValidation, "duplicate variant")
+
+	euroLine := CartLine{ProductId: uuid.New(), VariantId: uuid.New(), ProductName: "Gadget", UnitPrice: mustMoney(t, 500, EUR), Quantity: 1}
+	cart.Lines = []CartLine{line, euroLine}
+	_, err = NewValidatedCart(cart)
+	assert.ErrorIs(t, err, ErrValidation, "mixed currencies")
+}
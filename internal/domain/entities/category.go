@@ -0,0 +1,165 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+// Category is a node of the product taxonomy. Categories form a forest:
+// a category without a parent is a root.
+type Category struct {
+	Id        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	ParentId  *uuid.UUID
+	// Path is the materialized path: the ids from the root down to the
+	// category itself, each followed by a slash ("/<root>/<parent>/<id>/").
+	// A category's descendants are the categories whose path starts with
+	// its own. Moving a category changes the paths of all its descendants,
+	// so the repository derives the stored path from the parent's rather
+	// than trusting this one.
+	Path string
+	// Version is incremented on every persisted change and guards against
+	// lost updates (optimistic concurrency).
+	Version int
+
+	domainEvents []events.DomainEvent
+}
+
+// NewCategory creates a category below parent, or a root category if
+// parent is nil.
+func NewCategory(name string, parent *Category) *Category {
+	category := &Category{
+		Id:        uuid.Must(uuid.NewV7()),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Name:      name,
+		Version:   1,
+	}
+	category.placeBelow(parent)
+
+	category.recordEvent(events.NewCategoryCreated(category.Id, name, category.ParentId))
+
+	return category
+}
+
+// CategoryPath is the materialized path of the category id below a parent
+// with the given path; an empty parent path is the root level.
+func CategoryPath(parentPath string, id uuid.UUID) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return parentPath + id.String() + "/"
+}
+
+func (c *Category) recordEvent(event events.DomainEvent) {
+	c.domainEvents = append(c.domainEvents, event)
+}
+
+// PullEvents returns the recorded domain events and clears them. The
+// repository persists them in the same transaction as the aggregate
+// (transactional outbox), so callers pull exactly once per save.
+func (c *Category) PullEvents() []events.DomainEvent {
+	pulled := c.domainEvents
+	c.domainEvents = nil
+	return pulled
+}
+
+func (c *Category) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("%w: name must not be empty", ErrValidation)
+	}
+	if c.ParentId != nil && *c.ParentId == c.Id {
+		return fmt.Errorf("%w: %s cannot be its own parent", ErrCategoryCycle, c.Name)
+	}
+	if !strings.HasSuffix(c.Path, "/"+c.Id.String()+"/") {
+		return fmt.Errorf("%w: path %q does not end with the category id", ErrValidation, c.Path)
+	}
+	if c.CreatedAt.After(c.UpdatedAt) {
+		return fmt.Errorf("%w: created_at must be before updated_at", ErrValidation)
+	}
+
+	return nil
+}
+
+// IsAncestorOf reports whether other is a descendant of the category.
+func (c *Category) IsAncestorOf(other *Category) bool {
+	return other.Id != c.Id && strings.HasPrefix(other.Path, c.Path)
+}
+
+// AncestorIds returns the ids of the category's ancestors, root first.
+func (c *Category) AncestorIds() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, segment := range strings.Split(strings.Trim(c.Path, "/"), "/") {
+		id, err := uuid.Parse(segment)
+		if err != nil || id == c.Id {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Rename renames the category; a no-op rename records no event.
+func (c *Category) Rename(name string) error {
+	oldName := c.Name
+	c.Name = name
+	c.UpdatedAt = time.Now()
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if name != oldName {
+		c.recordEvent(events.NewCategoryRenamed(c.Id, oldName, name))
+	}
+
+	return nil
+}
+
+// MoveTo moves the category with all its descendants below parent, or to
+// the root level if parent is nil. Moving a category below itself or one of
+// its descendants is ErrCategoryCycle; moving it to its current parent
+// records no event.
+func (c *Category) MoveTo(parent *Category) error {
+	if parent != nil && (parent.Id == c.Id || c.IsAncestorOf(parent)) {
+		return fmt.Errorf("%w: %s cannot move below itself", ErrCategoryCycle, c.Name)
+	}
+
+	oldParentId := c.ParentId
+	if (parent == nil && oldParentId == nil) || (parent != nil && oldParentId != nil && *oldParentId == parent.Id) {
+		return nil
+	}
+
+	c.placeBelow(parent)
+	c.UpdatedAt = time.Now()
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	c.recordEvent(events.NewCategoryMoved(c.Id, oldParentId, c.ParentId))
+	return nil
+}
+
+func (c *Category) placeBelow(parent *Category) {
+	if parent == nil {
+		c.ParentId = nil
+		c.Path = CategoryPath("", c.Id)
+		return
+	}
+	parentId := parent.Id
+	c.ParentId = &parentId
+	c.Path = CategoryPath(parent.Path, c.Id)
+}
+
+// Delete records the category's removal. Only empty categories can be
+// deleted; the repository checks that in the same statement that deletes
+// the category, so a concurrent assignment cannot slip in between.
+func (c *Category) Delete() {
+	c.recordEvent(events.NewCategoryDeleted(c.Id))
+}
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCategory_Paths(t *testing.T) {
+	furniture := NewCategory("Furniture", nil)
+	chairs := NewCategory("Chairs", furniture)
+	office := NewCategory("Office chairs", chairs)
+
+	assert.Nil(t, furniture.ParentId)
+	assert.Equal(t, "/"+furniture.Id.String()+"/", furniture.Path)
+	assert.Equal(t, furniture.Id, *chairs.ParentId)
+	assert.Equal(t, furniture.Path+chairs.Id.String()+"/", chairs.Path)
+	assert.Equal(t, []uuid.UUID{furniture.Id, chairs.Id}, office.AncestorIds())
+	assert.Empty(t, furniture.AncestorIds())
+
+	assert.True(t, furniture.IsAncestorOf(office))
+	assert.False(t, office.IsAncestorOf(furniture))
+	assert.False(t, chairs.IsAncestorOf(chairs))
+
+	for _, category := range []*Category{furniture, chairs, office} {
+		_, err := NewValidatedCategory(category)
+		require.NoError(t, err)
+	}
+
+	created, ok := office.PullEvents()[0].(events.CategoryCreated)
+	require.True(t, ok)
+	assert.Equal(t, chairs.Id, *created.ParentId)
+}
+
+func TestCategory_Rename(t *testing.T) {
+	category := NewCategory("Furniture", nil)
+	category.PullEvents()
+
+	require.NoError(t, category.Rename("Furniture"))
+	assert.Empty(t, category.PullEvents())
+
+	require.NoError(t, category.Rename("Home"))
+	renamed, ok := category.PullEvents()[0].(events.CategoryRenamed)
+	require.True(t, ok)
+	assert.Equal(t, "Furniture", renamed.OldName)
+
+	assert.ErrorIs(t, category.Rename(""), ErrValidation)
+}
+
+func TestCategory_MoveTo(t *testing.T) {
+	furniture := NewCategory("Furniture", nil)
+	garden := NewCategory("Garden", nil)
+	chairs := NewCategory("Chairs", furniture)
+	chairs.PullEvents()
+
+	require.NoError(t, chairs.MoveTo(furniture))
+	assert.Empty(t, chairs.PullEvents(), "moving to the current parent is a no-op")
+
+	require.NoError(t, chairs.MoveTo(garden))
+	assert.Equal(t, garden.Id, *chairs.ParentId)
+	assert.Equal(t, garden.Path+chairs.Id.String()+"/", chairs.Path)
+	moved, ok := chairs.PullEvents()[0].(events.CategoryMoved)
+	require.True(t, ok)
+	assert.Equal(t, furniture.Id, *moved.OldParentId)
+	assert.Equal(t, garden.Id, *moved.NewParentId)
+
+	require.NoError(t, chairs.MoveTo(nil))
+	assert.Nil(t, chairs.ParentId)
+	assert.Equal(t, "/"+chairs.Id.String()+"/", chairs.Path)
+	moved, ok = chairs.PullEvents()[0].(events.CategoryMoved)
+	require.True(t, ok)
+	assert.Nil(t, moved.NewParentId)
+}
+
+func TestCategory_MoveTo_RejectsCycles(t *testing.T) {
+	furniture := NewCategory("Furniture", nil)
+	chairs := NewCategory("Chairs", furniture)
+	office := NewCategory("Office chairs", chairs)
+	path := furniture.Path
+
+	assert.ErrorIs(t, furniture.MoveTo(furniture), ErrCategoryCycle)
+	assert.ErrorIs(t, furniture.MoveTo(chairs), ErrCategoryCycle)
+	assert.ErrorIs(t, furniture.MoveTo(office), ErrCategoryCycle)
+	assert.Nil(t, furniture.ParentId)
+	assert.Equal(t, path, furniture.Path)
+
+	// A sibling's subtree is fine.
+	require.NoError(t, office.MoveTo(furniture))
+}
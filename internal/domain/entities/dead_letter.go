@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetter is a stored domain event the outbox relay gave up on after
+// exhausting its retry policy. It stays out of delivery until an operator
+// replays or discards it.
+type DeadLetter struct {
+	Id             uuid.UUID
+	AggregateId    uuid.UUID
+	EventName      string
+	Payload        []byte
+	OccurredAt     time.Time
+	Attempts       int
+	LastError      string
+	DeadLetteredAt time.Time
+}
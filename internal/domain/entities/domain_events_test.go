@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventNames(domainEvents []events.DomainEvent) []string {
+	names := make([]string, 0, len(domainEvents))
+	for _, event := range domainEvents {
+		names = append(names, event.EventName())
+	}
+	return names
+}
+
+func TestProduct_RecordsStateChangeEvents(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Seller"))
+	require.NoError(t, err)
+	otherSeller, err := NewValidatedSeller(NewSeller("Other Seller"))
+	require.NoError(t, err)
+
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+	assert.Equal(t, []string{events.ProductCreatedEventName}, eventNames(product.PullEvents()))
+
+	require.NoError(t, product.UpdateName("Widget v2"))
+	require.NoError(t, product.UpdatePrice(mustMoney(t, 1299, USD)))
+	require.NoError(t, product.AssignSeller(*otherSeller))
+	product.Delete()
+
+	pulled := product.PullEvents()
+	assert.Equal(t, []string{
+		events.ProductRenamedEventName,
+		events.ProductPriceChangedEventName,
+		events.ProductReassignedEventName,
+		events.ProductDeletedEventName,
+	}, eventNames(pulled))
+
+	priceChanged := pulled[1].(events.ProductPriceChanged)
+	assert.Equal(t, events.Money{MinorUnits: 999, Currency: "USD"}, priceChanged.OldPrice)
+	assert.Equal(t, events.Money{MinorUnits: 1299, Currency: "USD"}, priceChanged.NewPrice)
+
+	reassigned := pulled[2].(events.ProductReassigned)
+	assert.Equal(t, seller.Id, reassigned.OldSellerId)
+	assert.Equal(t, otherSeller.Id, reassigned.NewSellerId)
+
+	assert.Empty(t, product.PullEvents())
+}
+
+func TestProduct_NoEventForUnchangedOrInvalidUpdate(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Seller"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+	product.PullEvents()
+
+	require.NoError(t, product.UpdateName("Widget"))
+	require.NoError(t, product.UpdatePrice(mustMoney(t, 999, USD)))
+	require.NoError(t, product.AssignSeller(*seller))
+	assert.Error(t, product.UpdateName(""))
+
+	assert.Empty(t, product.PullEvents())
+}
+
+func TestSeller_RecordsStateChangeEvents(t *testing.T) {
+	seller := NewSeller("Acme")
+	assert.Equal(t, []string{events.SellerCreatedEventName}, eventNames(seller.PullEvents()))
+
+	require.NoError(t, seller.UpdateName("Acme"))
+	require.NoError(t, seller.UpdateName("Acme Corp"))
+	seller.Delete()
+
+	pulled := seller.PullEvents()
+	assert.Equal(t, []string{events.SellerRenamedEventName, events.SellerDeletedEventName}, eventNames(pulled))
+	renamed := pulled[0].(events.SellerRenamed)
+	assert.Equal(t, "Acme", renamed.OldName)
+	assert.Equal(t, "Acme Corp", renamed.NewName)
+}
+
+func TestSeller_ApplyVerification(t *testing.T) {
+	seller := NewSeller("Acme")
+	seller.PullEvents()
+	assert.Equal(t, SellerUnverified, seller.VerificationStatus)
+
+	require.NoError(t, seller.ApplyVerification(SellerVerified))
+	require.NoError(t, seller.ApplyVerification(SellerVerified))
+	require.NoError(t, seller.ApplyVerification(SellerVerificationRejected))
+	assert.ErrorIs(t, seller.ApplyVerification(SellerUnverified), ErrValidation)
+	assert.ErrorIs(t, seller.ApplyVerification("maybe"), ErrValidation)
+
+	assert.Equal(t, SellerVerificationRejected, seller.VerificationStatus)
+	pulled := seller.PullEvents()
+	require.Len(t, pulled, 2, "repeating a result records no event")
+	first := pulled[0].(events.SellerVerificationChanged)
+	assert.Equal(t, "unverified", first.OldStatus)
+	assert.Equal(t, "verified", first.NewStatus)
+}
@@ -1,6 +1,9 @@
 package entities
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Sentinel errors that the interface layer can translate into HTTP status
 // codes (e.g. 404) instead of a generic 500.
@@ -10,4 +13,76 @@ var (
 	// ErrValidation wraps all domain invariant violations; check with
 	// errors.Is to translate into a 400.
 	ErrValidation = errors.New("validation failed")
+	// ErrVersionConflict signals that the aggregate was modified since the
+	// caller read it (optimistic concurrency); translate into a 409 or 412.
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrInsufficientStock signals that fewer units are available than a
+	// reservation or adjustment needs; translate into a 409.
+	ErrInsufficientStock   = errors.New("insufficient stock")
+	ErrReservationNotFound = errors.New("reservation not found")
+	ErrOrderNotFound       = errors.New("order not found")
+	// ErrInvalidOrderTransition signals a status change the order lifecycle
+	// does not allow, e.g. shipping an unpaid order; translate into a 409.
+	ErrInvalidOrderTransition = errors.New("invalid order status transition")
+	// ErrInvalidProductTransition signals a status change the product
+	// lifecycle does not allow, e.g. archiving an archived product;
+	// translate into a 409.
+	ErrInvalidProductTransition = errors.New("invalid product status transition")
+	// ErrProductArchived signals a change an archived product does not
+	// accept, such as a new price; translate into a 409.
+	ErrProductArchived = errors.New("product is archived")
+	// ErrProductNotPublished signals an attempt to buy a draft or archived
+	// product; translate into a 409.
+	ErrProductNotPublished = errors.New("product is not published")
+	ErrCartItemNotFound    = errors.New("cart item not found")
+	// ErrCartChanged signals that checkout found products in the cart that
+	// were repriced or removed since they were added. The cart has been
+	// brought up to date; translate into a 409 so the buyer reviews it.
+	ErrCartChanged = errors.New("cart changed")
+	// ErrCurrencyMismatch signals arithmetic or a comparison between Money
+	// in different currencies. It wraps ErrValidation.
+	ErrCurrencyMismatch = fmt.Errorf("%w: currency mismatch", ErrValidation)
+	// ErrExchangeRateNotFound signals that no rate for a currency pair is in
+	// effect at the requested time; translate into a 422.
+	ErrExchangeRateNotFound         = errors.New("exchange rate not found")
+	ErrScheduledPriceChangeNotFound = errors.New("scheduled price change not found")
+	ErrPromotionNotFound            = errors.New("promotion not found")
+	// ErrPromotionExhausted signals a redemption of a promotion that is not
+	// running or reached its usage limit; translate into a 409.
+	ErrPromotionExhausted = errors.New("promotion exhausted")
+	ErrCategoryNotFound   = errors.New("category not found")
+	// ErrCategoryCycle signals a move of a category below itself or one of
+	// its descendants; translate into a 409.
+	ErrCategoryCycle = errors.New("category cycle")
+	// ErrCategoryNotEmpty signals the deletion of a category that still has
+	// subcategories or products; translate into a 409.
+	ErrCategoryNotEmpty = errors.New("category not empty")
+	ErrVariantNotFound  = errors.New("product variant not found")
+	// ErrSkuTaken signals a variant SKU that another product's variant
+	// already uses; translate into a 409.
+	ErrSkuTaken = errors.New("sku already taken")
+	// ErrVariantReserved signals the removal of a variant whose stock is
+	// still reserved, e.g. by an unpaid order; translate into a 409.
+	ErrVariantReserved = errors.New("product variant has reserved stock")
+	ErrImageNotFound   = errors.New("product image not found")
+	// ErrUnsupportedMediaType signals an upload in a format that is not
+	// accepted, e.g. a PDF sent as a product image; translate into a 415.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	// ErrImageTooLarge signals an upload above the configured size limit;
+	// translate into a 413.
+	ErrImageTooLarge = errors.New("image too large")
+	// ErrBlobNotFound is returned by blob stores for keys they do not hold.
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrDeadLetterNotFound signals that no dead-lettered event has the
+	// given id: it never existed, was replayed or was discarded.
+	ErrDeadLetterNotFound          = errors.New("dead-lettered event not found")
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrOutboxReplayNotFound        = errors.New("replay not found")
+	// ErrOutboxReplayNotResumable signals a resume of a replay that has
+	// completed or is still being run by another process; translate into a
+	// 409.
+	ErrOutboxReplayNotResumable = errors.New("replay is completed or still running")
+	// ErrUnknownReplayTarget signals a replay to a target that names no
+	// configured publisher. It wraps ErrValidation.
+	ErrUnknownReplayTarget = fmt.Errorf("%w: unknown replay target", ErrValidation)
 )
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"time"
+)
+
+var decimalRate = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// ExchangeRate is an immutable value object: one unit of From buys Rate
+// units of To, from EffectiveAt until a later rate for the same pair takes
+// over. Rates are exact decimals, never floats, for the same reason Money
+// is.
+type ExchangeRate struct {
+	from        Currency
+	to          Currency
+	rate        *big.Rat
+	decimal     string
+	effectiveAt time.Time
+}
+
+// NewExchangeRate parses rate as a positive decimal such as "1.0845".
+func NewExchangeRate(from, to Currency, rate string, effectiveAt time.Time) (ExchangeRate, error) {
+	for _, currency := range []Currency{from, to} {
+		if _, ok := supportedCurrencies[currency]; !ok {
+			return ExchangeRate{}, fmt.Errorf("%w: unsupported currency %q", ErrValidation, currency)
+		}
+	}
+	if from == to {
+		return ExchangeRate{}, fmt.Errorf("%w: exchange rate from %s to itself", ErrValidation, from)
+	}
+	if !decimalRate.MatchString(rate) {
+		return ExchangeRate{}, fmt.Errorf("%w: exchange rate %q is not a decimal", ErrValidation, rate)
+	}
+	parsed, _ := new(big.Rat).SetString(rate)
+	if parsed.Sign() <= 0 {
+		return ExchangeRate{}, fmt.Errorf("%w: exchange rate must be positive", ErrValidation)
+	}
+	if effectiveAt.IsZero() {
+		return ExchangeRate{}, fmt.Errorf("%w: exchange rate needs an effective time", ErrValidation)
+	}
+
+	return ExchangeRate{from: from, to: to, rate: parsed, decimal: rate, effectiveAt: effectiveAt}, nil
+}
+
+func (r ExchangeRate) From() Currency {
+	return r.from
+}
+
+func (r ExchangeRate) To() Currency {
+	return r.to
+}
+
+// Rate returns the rate as the decimal it was created from.
+func (r ExchangeRate) Rate() string {
+	return r.decimal
+}
+
+func (r ExchangeRate) EffectiveAt() time.Time {
+	return r.effectiveAt
+}
+
+// Convert returns amount in the To currency, rounded half-to-even to a whole
+// minor unit. The currencies' exponents are applied, so 1000 JPY at 0.0062
+// becomes 620 US cents, not 6 cents.
+func (r ExchangeRate) Convert(amount Money) (Money, error) {
+	if amount.currency != r.from {
+		return Money{}, fmt.Errorf("%w: rate is for %s, amount is in %s", ErrCurrencyMismatch, r.from, amount.currency)
+	}
+
+	scaled := new(big.Rat).Mul(new(big.Rat).SetInt64(amount.minorUnits), r.rate)
+	scaled.Mul(scaled, new(big.Rat).SetFrac(pow10(supportedCurrencies[r.to]), pow10(supportedCurrencies[r.from])))
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if roundsUp(remainder, scaled.Denom(), quotient, RoundHalfEven) {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if !quotient.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s in %s overflows", ErrValidation, amount, r.to)
+	}
+
+	return Money{minorUnits: quotient.Int64(), currency: r.to}, nil
+}
+
+func (r ExchangeRate) String() string {
+	return fmt.Sprintf("1 %s = %s %s", r.from, r.decimal, r.to)
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rateEffectiveAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestNewExchangeRate_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from, to Currency
+		rate     string
+	}{
+		{"unsupported currency", EUR, "XXX", "1"},
+		{"same currency", EUR, EUR, "1"},
+		{"zero", EUR, USD, "0.000"},
+		{"negative", EUR, USD, "-1.08"},
+		{"comma", EUR, USD, "1,08"},
+		{"fraction", EUR, USD, "27/25"},
+		{"exponent", EUR, USD, "1e3"},
+		{"empty", EUR, USD, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewExchangeRate(tc.from, tc.to, tc.rate, rateEffectiveAt)
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+
+	_, err := NewExchangeRate(EUR, USD, "1.08", time.Time{})
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	testCases := []struct {
+		name       string
+		from, to   Currency
+		rate       string
+		minorUnits int64
+		expected   int64
+	}{
+		{"same exponent", EUR, USD, "1.0845", 10000, 10845},
+		{"into zero exponent", USD, JPY, "151.25", 1999, 3023},
+		{"from zero exponent", JPY, USD, "0.0062", 1000, 620},
+		{"into three decimals", USD, KWD, "0.3075", 100, 308},
+		{"half rounds to even", EUR, USD, "1.5", 1, 2},
+		{"half rounds to even, down", EUR, USD, "2.5", 1, 2},
+		{"zero", EUR, USD, "1.0845", 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rate, err := NewExchangeRate(tc.from, tc.to, tc.rate, rateEffectiveAt)
+			require.NoError(t, err)
+
+			converted, err := rate.Convert(mustMoney(t, tc.minorUnits, tc.from))
+			require.NoError(t, err)
+			assert.Equal(t, mustMoney(t, tc.expected, tc.to), converted)
+		})
+	}
+}
+
+func TestExchangeRate_Convert_Errors(t *testing.T) {
+	rate, err := NewExchangeRate(USD, JPY, "151.25", rateEffectiveAt)
+	require.NoError(t, err)
+
+	_, err = rate.Convert(mustMoney(t, 100, EUR))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = rate.Convert(mustMoney(t, math.MaxInt64, USD))
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Contains(t, err.Error(), "overflows")
+}
+
+func TestExchangeRate_String(t *testing.T) {
+	rate, err := NewExchangeRate(EUR, USD, "1.0845", rateEffectiveAt)
+	require.NoError(t, err)
+	assert.Equal(t, "1 EUR = 1.0845 USD", rate.String())
+}
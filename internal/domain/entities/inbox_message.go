@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+// InboxMessage is a message received from an external system. Source and
+// MessageId identify it: the same pair received again is a redelivery.
+type InboxMessage struct {
+	Source     string
+	MessageId  string
+	Type       string
+	Payload    []byte
+	ReceivedAt time.Time
+	// Attempts counts the handling attempts made so far.
+	Attempts int
+}
+
+func NewInboxMessage(source, messageId, messageType string, payload []byte) *InboxMessage {
+	return &InboxMessage{
+		Source:     source,
+		MessageId:  messageId,
+		Type:       messageType,
+		Payload:    payload,
+		ReceivedAt: time.Now(),
+	}
+}
@@ -0,0 +1,234 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+// Inventory is the stock of one product variant: the units on hand and the
+// reservations holding some of them, e.g. for orders awaiting payment. It is
+// an aggregate of its own, keyed by the variant id, so stock changes do not
+// contend with edits to the product.
+//
+// Invariants: OnHand stays between zero and MaxOnHand, and reservations
+// never hold more units than are on hand.
+type Inventory struct {
+	ProductId    uuid.UUID
+	VariantId    uuid.UUID
+	OnHand       int
+	Reservations []Reservation
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// Version is incremented on every persisted change.
+	Version int
+
+	domainEvents []events.DomainEvent
+}
+
+// Reservation holds units of stock until it is committed (sold), released,
+// or expires.
+type Reservation struct {
+	Id        uuid.UUID
+	Quantity  int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// MaxOnHand is the most units an inventory can hold; stock is stored as a
+// 32-bit integer.
+const MaxOnHand = math.MaxInt32
+
+// NewInventory returns the empty inventory every product variant starts
+// with.
+func NewInventory(productId, variantId uuid.UUID) *Inventory {
+	return &Inventory{
+		ProductId: productId,
+		VariantId: variantId,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Version:   1,
+	}
+}
+
+func (i *Inventory) recordEvent(event events.DomainEvent) {
+	i.domainEvents = append(i.domainEvents, event)
+}
+
+// PullEvents returns the recorded domain events and clears them. The
+// repository persists them in the same transaction as the aggregate
+// (transactional outbox), so callers pull exactly once per save.
+func (i *Inventory) PullEvents() []events.DomainEvent {
+	pulled := i.domainEvents
+	i.domainEvents = nil
+	return pulled
+}
+
+func (i *Inventory) validate() error {
+	if i.ProductId == uuid.Nil {
+		return fmt.Errorf("%w: product id must not be empty", ErrValidation)
+	}
+	if i.VariantId == uuid.Nil {
+		return fmt.Errorf("%w: variant id must not be empty", ErrValidation)
+	}
+	if i.OnHand < 0 {
+		return fmt.Errorf("%w: on hand must not be negative", ErrValidation)
+	}
+	if i.OnHand > MaxOnHand {
+		return fmt.Errorf("%w: on hand must not exceed %d", ErrValidation, MaxOnHand)
+	}
+	for _, reservation := range i.Reservations {
+		if reservation.Quantity <= 0 {
+			return fmt.Errorf("%w: reserved quantity must be greater than 0", ErrValidation)
+		}
+	}
+	if i.Reserved() > i.OnHand {
+		return fmt.Errorf("%w: %d units reserved but only %d on hand", ErrValidation, i.Reserved(), i.OnHand)
+	}
+
+	return nil
+}
+
+// Reserved is the number of units held by reservations.
+func (i *Inventory) Reserved() int {
+	reserved := 0
+	for _, reservation := range i.Reservations {
+		reserved += reservation.Quantity
+	}
+	return reserved
+}
+
+// Available is the number of units that can still be reserved.
+func (i *Inventory) Available() int {
+	return i.OnHand - i.Reserved()
+}
+
+// AdjustStock adds (delta > 0) or removes (delta < 0) units on hand, e.g.
+// after a delivery or a stock count. Units held by reservations cannot be
+// removed.
+func (i *Inventory) AdjustStock(delta int, reason string) error {
+	if delta == 0 {
+		return fmt.Errorf("%w: delta must not be 0", ErrValidation)
+	}
+	if i.OnHand+delta < 0 {
+		return fmt.Errorf("%w: only %d units on hand", ErrValidation, i.OnHand)
+	}
+	if delta > MaxOnHand-i.OnHand {
+		return fmt.Errorf("%w: at most %d units can be on hand", ErrValidation, MaxOnHand)
+	}
+	if i.OnHand+delta < i.Reserved() {
+		return fmt.Errorf("%w: %d of %d units on hand are reserved", ErrInsufficientStock, i.Reserved(), i.OnHand)
+	}
+
+	wasAvailable := i.Available()
+	i.OnHand += delta
+	i.UpdatedAt = time.Now()
+	i.recordEvent(events.NewStockAdjusted(i.ProductId, i.VariantId, delta, i.OnHand, reason))
+	i.recordOutOfStock(wasAvailable)
+
+	return nil
+}
+
+// Reserve holds quantity units for ttl. Expired reservations are released
+// first, so their units count as available again.
+func (i *Inventory) Reserve(quantity int, ttl time.Duration) (Reservation, error) {
+	if quantity <= 0 {
+		return Reservation{}, fmt.Errorf("%w: quantity must be greater than 0", ErrValidation)
+	}
+	if ttl <= 0 {
+		return Reservation{}, fmt.Errorf("%w: reservation ttl must be positive", ErrValidation)
+	}
+
+	now := time.Now()
+	i.ReleaseExpired(now)
+	if available := i.Available(); quantity > available {
+		return Reservation{}, fmt.Errorf("%w: %d units requested, %d available", ErrInsufficientStock, quantity, available)
+	}
+
+	wasAvailable := i.Available()
+	reservation := Reservation{
+		Id:        uuid.Must(uuid.NewV7()),
+		Quantity:  quantity,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	i.Reservations = append(i.Reservations, reservation)
+	i.UpdatedAt = now
+	i.recordEvent(events.NewStockReserved(i.ProductId, i.VariantId, reservation.Id, quantity, reservation.ExpiresAt))
+	i.recordOutOfStock(wasAvailable)
+
+	return reservation, nil
+}
+
+// Release gives the reservation's units back, e.g. when an order is
+// cancelled.
+func (i *Inventory) Release(reservationId uuid.UUID) error {
+	i.ReleaseExpired(time.Now())
+	reservation, ok := i.removeReservation(reservationId)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrReservationNotFound, reservationId)
+	}
+
+	i.UpdatedAt = time.Now()
+	i.recordEvent(events.NewStockReleased(i.ProductId, i.VariantId, reservation.Id, reservation.Quantity, events.ReleaseReasonCancelled))
+
+	return nil
+}
+
+// Commit turns a reservation into a sale: its units leave the stock. An
+// expired reservation can no longer be committed.
+func (i *Inventory) Commit(reservationId uuid.UUID) error {
+	i.ReleaseExpired(time.Now())
+	reservation, ok := i.removeReservation(reservationId)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrReservationNotFound, reservationId)
+	}
+
+	i.OnHand -= reservation.Quantity
+	i.UpdatedAt = time.Now()
+	i.recordEvent(events.NewStockCommitted(i.ProductId, i.VariantId, reservation.Id, reservation.Quantity, i.OnHand))
+
+	return nil
+}
+
+// ReleaseExpired releases every reservation that expired at or before now
+// and returns how many it released.
+func (i *Inventory) ReleaseExpired(now time.Time) int {
+	kept := i.Reservations[:0]
+	released := 0
+	for _, reservation := range i.Reservations {
+		if reservation.ExpiresAt.After(now) {
+			kept = append(kept, reservation)
+			continue
+		}
+		released++
+		i.recordEvent(events.NewStockReleased(i.ProductId, i.VariantId, reservation.Id, reservation.Quantity, events.ReleaseReasonExpired))
+	}
+	i.Reservations = kept
+	if released > 0 {
+		i.UpdatedAt = now
+	}
+
+	return released
+}
+
+func (i *Inventory) removeReservation(id uuid.UUID) (Reservation, bool) {
+	for index, reservation := range i.Reservations {
+		if reservation.Id == id {
+			i.Reservations = append(i.Reservations[:index], i.Reservations[index+1:]...)
+			return reservation, true
+		}
+	}
+	return Reservation{}, false
+}
+
+// recordOutOfStock records OutOfStock when a change used up the last
+// available unit.
+func (i *Inventory) recordOutOfStock(wasAvailable int) {
+	if wasAvailable > 0 && i.Available() == 0 {
+		i.recordEvent(events.NewOutOfStock(i.ProductId, i.VariantId, i.OnHand, i.Reserved()))
+	}
+}
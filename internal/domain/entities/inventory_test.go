@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+func stockedInventory(t *testing.T, onHand int) *Inventory {
+	t.Helper()
+	inventory := NewInventory(uuid.New(), uuid.New())
+	require.NoError(t, inventory.AdjustStock(onHand, "initial count"))
+	inventory.PullEvents()
+	return inventory
+}
+
+func TestInventory_AdjustStock(t *testing.T) {
+	inventory := NewInventory(uuid.New(), uuid.New())
+
+	require.NoError(t, inventory.AdjustStock(10, "delivery"))
+	require.NoError(t, inventory.AdjustStock(-4, "damaged"))
+	assert.Equal(t, 6, inventory.OnHand)
+	assert.Equal(t, 6, inventory.Available())
+
+	assert.ErrorIs(t, inventory.AdjustStock(0, ""), ErrValidation)
+	assert.ErrorIs(t, inventory.AdjustStock(-7, ""), ErrValidation, "on hand never drops below zero")
+	assert.ErrorIs(t, inventory.AdjustStock(MaxOnHand-5, ""), ErrValidation, "on hand fits the stored column")
+	assert.ErrorIs(t, inventory.AdjustStock(math.MaxInt, ""), ErrValidation, "no overflow")
+	assert.Equal(t, 6, inventory.OnHand)
+
+	pulled := inventory.PullEvents()
+	require.Len(t, pulled, 2)
+	adjusted := pulled[1].(events.StockAdjusted)
+	assert.Equal(t, -4, adjusted.Delta)
+	assert.Equal(t, 6, adjusted.OnHand)
+	assert.Equal(t, "damaged", adjusted.Reason)
+	assert.Equal(t, inventory.VariantId, adjusted.AggregateId(), "the row lock serializes the variant's events")
+	assert.Equal(t, inventory.ProductId, adjusted.ProductId)
+}
+
+func TestInventory_AdjustStock_CannotRemoveReservedUnits(t *testing.T) {
+	inventory := stockedInventory(t, 5)
+	_, err := inventory.Reserve(3, time.Minute)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, inventory.AdjustStock(-3, "stock count"), ErrInsufficientStock)
+	require.NoError(t, inventory.AdjustStock(-2, "stock count"))
+	assert.Equal(t, 3, inventory.OnHand)
+	assert.Zero(t, inventory.Available())
+	assert.Equal(t, []string{events.StockReservedEventName, events.StockAdjustedEventName, events.OutOfStockEventName},
+		eventNames(inventory.PullEvents()))
+}
+
+func TestInventory_Reserve(t *testing.T) {
+	inventory := stockedInventory(t, 5)
+
+	reservation, err := inventory.Reserve(2, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reservation.Quantity)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), reservation.ExpiresAt, time.Second)
+	assert.Equal(t, 2, inventory.Reserved())
+	assert.Equal(t, 3, inventory.Available())
+	assert.Equal(t, 5, inventory.OnHand, "reserving does not remove units")
+
+	_, err = inventory.Reserve(4, time.Minute)
+	assert.ErrorIs(t, err, ErrInsufficientStock, "no over-reservation")
+	_, err = inventory.Reserve(0, time.Minute)
+	assert.ErrorIs(t, err, ErrValidation)
+	_, err = inventory.Reserve(1, 0)
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = inventory.Reserve(3, time.Minute)
+	require.NoError(t, err)
+	assert.Zero(t, inventory.Available())
+
+	pulled := inventory.PullEvents()
+	assert.Equal(t, []string{events.StockReservedEventName, events.StockReservedEventName, events.OutOfStockEventName}, eventNames(pulled))
+	reserved := pulled[0].(events.StockReserved)
+	assert.Equal(t, reservation.Id, reserved.ReservationId)
+	assert.Equal(t, 2, reserved.Quantity)
+	outOfStock := pulled[2].(events.OutOfStock)
+	assert.Equal(t, 5, outOfStock.OnHand)
+	assert.Equal(t, 5, outOfStock.Reserved)
+}
+
+func TestInventory_ReleaseAndCommit(t *testing.T) {
+	inventory := stockedInventory(t, 5)
+	cancelled, err := inventory.Reserve(2, time.Minute)
+	require.NoError(t, err)
+	sold, err := inventory.Reserve(3, time.Minute)
+	require.NoError(t, err)
+	inventory.PullEvents()
+
+	require.NoError(t, inventory.Release(cancelled.Id))
+	assert.Equal(t, 2, inventory.Available())
+	assert.ErrorIs(t, inventory.Release(cancelled.Id), ErrReservationNotFound)
+
+	require.NoError(t, inventory.Commit(sold.Id))
+	assert.Equal(t, 2, inventory.OnHand)
+	assert.Zero(t, inventory.Reserved())
+	assert.Equal(t, 2, inventory.Available(), "committing does not change availability")
+	assert.ErrorIs(t, inventory.Commit(sold.Id), ErrReservationNotFound)
+
+	pulled := inventory.PullEvents()
+	require.Len(t, pulled, 2)
+	released := pulled[0].(events.StockReleased)
+	assert.Equal(t, events.ReleaseReasonCancelled, released.Reason)
+	assert.Equal(t, 2, released.Quantity)
+	committed := pulled[1].(events.StockCommitted)
+	assert.Equal(t, 3, committed.Quantity)
+	assert.Equal(t, 2, committed.OnHand)
+}
+
+func TestInventory_ExpiredReservationsFreeTheirUnits(t *testing.T) {
+	inventory := stockedInventory(t, 3)
+	expired, err := inventory.Reserve(3, time.Minute)
+	require.NoError(t, err)
+	inventory.Reservations[0].ExpiresAt = time.Now().Add(-time.Second)
+	inventory.PullEvents()
+
+	_, err = inventory.Reserve(2, time.Minute)
+	require.NoError(t, err, "the expired reservation is released first")
+	assert.ErrorIs(t, inventory.Commit(expired.Id), ErrReservationNotFound)
+
+	pulled := inventory.PullEvents()
+	require.NotEmpty(t, pulled)
+	released := pulled[0].(events.StockReleased)
+	assert.Equal(t, expired.Id, released.ReservationId)
+	assert.Equal(t, events.ReleaseReasonExpired, released.Reason)
+
+	assert.Equal(t, 1, inventory.ReleaseExpired(time.Now().Add(time.Hour)))
+	assert.Zero(t, inventory.Reserved())
+}
+
+func TestNewValidatedInventory(t *testing.T) {
+	inventory := stockedInventory(t, 2)
+	validated, err := NewValidatedInventory(inventory)
+	require.NoError(t, err)
+	assert.True(t, validated.IsValid())
+
+	inventory.Reservations = append(inventory.Reservations, Reservation{Id: uuid.New(), Quantity: 3})
+	_, err = NewValidatedInventory(inventory)
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = NewValidatedInventory(NewInventory(uuid.New(), uuid.Nil))
+	assert.ErrorIs(t, err, ErrValidation)
+}
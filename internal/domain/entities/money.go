@@ -3,25 +3,11 @@ package entities
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"slices"
 )
 
-// Currency is the ISO 4217 code of a Money value.
-type Currency string
-
-const (
-	EUR Currency = "EUR"
-	USD Currency = "USD"
-)
-
-// supportedCurrencies maps each supported currency to its ISO 4217
-// minor-unit exponent. Not every currency has two decimals: JPY, KRW,
-// CLP and ISK have 0; BHD, JOD, KWD, OMR and TND have 3. When adding a
-// currency here, use its real exponent so String() stays correct.
-var supportedCurrencies = map[Currency]int{
-	EUR: 2,
-	USD: 2,
-}
-
 // Money is an immutable value object storing an amount in ISO 4217 minor
 // units (cents for EUR/USD, yen for JPY, fils for BHD) to avoid
 // floating-point rounding errors.
@@ -67,6 +53,189 @@ func (m Money) String() string {
 	return fmt.Sprintf("%d.%0*d %s", m.minorUnits/divisor, exponent, m.minorUnits%divisor, m.currency)
 }
 
+// RoundingMode decides where a result that falls between two minor units
+// ends up. Amounts are never negative, so "up" and "away from zero" agree.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest minor unit, halves upwards.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest minor unit, halves to the even
+	// neighbour (banker's rounding).
+	RoundHalfEven
+	// RoundDown truncates.
+	RoundDown
+	// RoundUp rounds any fraction up.
+	RoundUp
+)
+
+// Add returns m + other. Amounts in different currencies are never added
+// up, and a sum beyond int64 is rejected rather than wrapped around.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if m.minorUnits > math.MaxInt64-other.minorUnits {
+		return Money{}, fmt.Errorf("%w: %s + %s overflows", ErrValidation, m, other)
+	}
+
+	return Money{minorUnits: m.minorUnits + other.minorUnits, currency: m.currency}, nil
+}
+
+// Subtract returns m - other. Money is never negative, so subtracting more
+// than m is an error.
+func (m Money) Subtract(other Money) (Money, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if other.minorUnits > m.minorUnits {
+		return Money{}, fmt.Errorf("%w: %s - %s is negative", ErrValidation, m, other)
+	}
+
+	return Money{minorUnits: m.minorUnits - other.minorUnits, currency: m.currency}, nil
+}
+
+// Multiply returns m times a non-negative factor, e.g. a unit price times
+// a quantity.
+func (m Money) Multiply(factor int64) (Money, error) {
+	if factor < 0 {
+		return Money{}, fmt.Errorf("%w: factor must not be negative", ErrValidation)
+	}
+	if factor > 0 && m.minorUnits > math.MaxInt64/factor {
+		return Money{}, fmt.Errorf("%w: %s * %d overflows", ErrValidation, m, factor)
+	}
+
+	return Money{minorUnits: m.minorUnits * factor, currency: m.currency}, nil
+}
+
+// MultiplyRatio returns m * numerator / denominator, rounded to a whole
+// minor unit by mode. The intermediate product is exact, so only the final
+// result has to fit.
+func (m Money) MultiplyRatio(numerator, denominator int64, mode RoundingMode) (Money, error) {
+	if numerator < 0 || denominator <= 0 {
+		return Money{}, fmt.Errorf("%w: ratio %d/%d must not be negative", ErrValidation, numerator, denominator)
+	}
+
+	product := new(big.Int).Mul(big.NewInt(m.minorUnits), big.NewInt(numerator))
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(denominator), new(big.Int))
+	if roundsUp(remainder, big.NewInt(denominator), quotient, mode) {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if !quotient.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s * %d/%d overflows", ErrValidation, m, numerator, denominator)
+	}
+
+	return Money{minorUnits: quotient.Int64(), currency: m.currency}, nil
+}
+
+// Percentage returns basisPoints hundredths of a percent of m (1250 is
+// 12.5%), rounded by mode. Use it for discounts, fees and tax.
+func (m Money) Percentage(basisPoints int64, mode RoundingMode) (Money, error) {
+	return m.MultiplyRatio(basisPoints, 10_000, mode)
+}
+
+func roundsUp(remainder, denominator, quotient *big.Int, mode RoundingMode) bool {
+	if remainder.Sign() == 0 {
+		return false
+	}
+
+	// Compare twice the remainder against the denominator to locate the
+	// half without dividing.
+	half := new(big.Int).Lsh(remainder, 1).Cmp(denominator)
+	switch mode {
+	case RoundUp:
+		return true
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfEven:
+		return half > 0 || half == 0 && quotient.Bit(0) == 1
+	default:
+		return false
+	}
+}
+
+// Compare returns -1, 0 or +1 as m is less than, equal to or greater than
+// other; amounts in different currencies cannot be compared.
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.minorUnits < other.minorUnits:
+		return -1, nil
+	case m.minorUnits > other.minorUnits:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Allocate splits m by ratios without losing a minor unit: each part gets
+// its proportional share rounded down, and the units left over go one each
+// to the parts with the largest remainders (ties to the earlier part). The
+// parts always add up to m.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, fmt.Errorf("%w: nothing to allocate to", ErrValidation)
+	}
+	total := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("%w: ratio must not be negative", ErrValidation)
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, fmt.Errorf("%w: ratios must not all be zero", ErrValidation)
+	}
+
+	parts := make([]Money, len(ratios))
+	remainders := make([]*big.Int, len(ratios))
+	left := m.minorUnits
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(big.NewInt(m.minorUnits), big.NewInt(ratio))
+		quotient, remainder := share.QuoRem(share, total, new(big.Int))
+		parts[i] = Money{minorUnits: quotient.Int64(), currency: m.currency}
+		remainders[i] = remainder
+		left -= quotient.Int64()
+	}
+
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return remainders[b].Cmp(remainders[a])
+	})
+	for _, i := range order[:left] {
+		parts[i].minorUnits++
+	}
+
+	return parts, nil
+}
+
+// Split divides m into n parts that differ by at most one minor unit, the
+// larger parts first.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: cannot split into %d parts", ErrValidation, n)
+	}
+
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+func (m Money) sameCurrency(other Money) error {
+	if m.currency != other.currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	return nil
+}
+
 type moneyJSON struct {
 	MinorUnits int64    `json:"minor_units"`
 	Currency   Currency `json:"currency"`
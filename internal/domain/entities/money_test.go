@@ -2,6 +2,7 @@ package entities
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,7 +33,7 @@ func TestNewMoney_NegativeAmount(t *testing.T) {
 }
 
 func TestNewMoney_UnsupportedCurrency(t *testing.T) {
-	testCases := []Currency{"", "GBP", "usd"}
+	testCases := []Currency{"", "XXX", "XAU", "usd"}
 
 	for _, currency := range testCases {
 		t.Run(string(currency), func(t *testing.T) {
@@ -77,28 +78,20 @@ func TestMoney_String(t *testing.T) {
 	}
 }
 
-// TestMoney_String_ExponentAware pins the formatting contract for
-// non-exponent-2 currencies without adding them to the whitelist: the
-// divisor comes from the exponent table, not a hardcoded 100.
+// TestMoney_String_ExponentAware pins the formatting of non-exponent-2
+// currencies: the divisor comes from the exponent table, not a hardcoded
+// 100.
 func TestMoney_String_ExponentAware(t *testing.T) {
-	const (
-		testJPY Currency = "JPY" // exponent 0
-		testBHD Currency = "BHD" // exponent 3
-	)
-	supportedCurrencies[testJPY] = 0
-	supportedCurrencies[testBHD] = 3
-	defer delete(supportedCurrencies, testJPY)
-	defer delete(supportedCurrencies, testBHD)
-
 	testCases := []struct {
 		minorUnits int64
 		currency   Currency
 		expected   string
 	}{
-		{5000, testJPY, "5000 JPY"},
-		{0, testJPY, "0 JPY"},
-		{12345, testBHD, "12.345 BHD"},
-		{5, testBHD, "0.005 BHD"},
+		{5000, JPY, "5000 JPY"},
+		{0, JPY, "0 JPY"},
+		{12345, BHD, "12.345 BHD"},
+		{5, KWD, "0.005 KWD"},
+		{10000, "CLF", "1.0000 CLF"},
 	}
 
 	for _, tc := range testCases {
@@ -110,6 +103,202 @@ func TestMoney_String_ExponentAware(t *testing.T) {
 	}
 }
 
+func TestCurrency_Exponent(t *testing.T) {
+	testCases := []struct {
+		currency Currency
+		exponent int
+	}{
+		{USD, 2},
+		{GBP, 2},
+		{JPY, 0},
+		{KRW, 0},
+		{BHD, 3},
+		{KWD, 3},
+		{"UYW", 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.currency), func(t *testing.T) {
+			exponent, ok := tc.currency.Exponent()
+			assert.True(t, ok)
+			assert.Equal(t, tc.exponent, exponent)
+		})
+	}
+
+	_, ok := Currency("XXX").Exponent()
+	assert.False(t, ok)
+}
+
+func TestMoney_AddSubtract(t *testing.T) {
+	a := mustMoney(t, 1050, EUR)
+	b := mustMoney(t, 250, EUR)
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	assert.Equal(t, mustMoney(t, 1300, EUR), sum)
+
+	difference, err := a.Subtract(b)
+	require.NoError(t, err)
+	assert.Equal(t, mustMoney(t, 800, EUR), difference)
+
+	_, err = b.Subtract(a)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Contains(t, err.Error(), "negative")
+}
+
+func TestMoney_CurrencyMismatch(t *testing.T) {
+	eur := mustMoney(t, 100, EUR)
+	usd := mustMoney(t, 100, USD)
+
+	_, err := eur.Add(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+	assert.ErrorIs(t, err, ErrValidation)
+
+	_, err = eur.Subtract(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = eur.Compare(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestMoney_Overflow(t *testing.T) {
+	large := mustMoney(t, math.MaxInt64-1, USD)
+
+	_, err := large.Add(mustMoney(t, 2, USD))
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Contains(t, err.Error(), "overflows")
+
+	_, err = large.Multiply(2)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Contains(t, err.Error(), "overflows")
+
+	_, err = large.MultiplyRatio(3, 2, RoundDown)
+	assert.ErrorIs(t, err, ErrValidation)
+
+	// The intermediate product may exceed int64 as long as the result fits.
+	half, err := large.MultiplyRatio(1_000, 2_000, RoundDown)
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64/2), half.MinorUnits())
+}
+
+func TestMoney_Multiply(t *testing.T) {
+	product, err := mustMoney(t, 999, USD).Multiply(3)
+	require.NoError(t, err)
+	assert.Equal(t, mustMoney(t, 2997, USD), product)
+
+	_, err = mustMoney(t, 999, USD).Multiply(-1)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestMoney_Compare(t *testing.T) {
+	a := mustMoney(t, 100, EUR)
+	b := mustMoney(t, 200, EUR)
+
+	for _, tc := range []struct {
+		left, right Money
+		expected    int
+	}{
+		{a, b, -1},
+		{b, a, 1},
+		{a, a, 0},
+	} {
+		result, err := tc.left.Compare(tc.right)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, result)
+	}
+}
+
+func TestMoney_Percentage_RoundingModes(t *testing.T) {
+	testCases := []struct {
+		name        string
+		minorUnits  int64
+		basisPoints int64
+		mode        RoundingMode
+		expected    int64
+	}{
+		// 10% of 1.25 is 0.125
+		{"half up rounds half up", 125, 1000, RoundHalfUp, 13},
+		{"half even rounds half to even", 125, 1000, RoundHalfEven, 12},
+		{"half even rounds odd half up", 135, 1000, RoundHalfEven, 14},
+		{"down truncates", 129, 1000, RoundDown, 12},
+		{"up rounds any fraction", 121, 1000, RoundUp, 13},
+		{"half up below half", 124, 1000, RoundHalfUp, 12},
+		{"exact results are not rounded", 1000, 1250, RoundUp, 125},
+		{"zero percent", 1000, 0, RoundUp, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := mustMoney(t, tc.minorUnits, EUR).Percentage(tc.basisPoints, tc.mode)
+			require.NoError(t, err)
+			assert.Equal(t, mustMoney(t, tc.expected, EUR), result)
+		})
+	}
+
+	_, err := mustMoney(t, 100, EUR).Percentage(-1, RoundDown)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		minorUnits int64
+		ratios     []int64
+		expected   []int64
+	}{
+		{"exact", 100, []int64{1, 1}, []int64{50, 50}},
+		{"remainder to the earlier part on a tie", 100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"remainder to the largest fraction", 7, []int64{3, 7}, []int64{2, 5}},
+		{"70/20/10", 101, []int64{70, 20, 10}, []int64{71, 20, 10}},
+		{"zero ratio gets nothing", 10, []int64{0, 1}, []int64{0, 10}},
+		{"zero amount", 0, []int64{1, 2}, []int64{0, 0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parts, err := mustMoney(t, tc.minorUnits, USD).Allocate(tc.ratios...)
+			require.NoError(t, err)
+
+			var sum int64
+			actual := make([]int64, 0, len(parts))
+			for _, part := range parts {
+				assert.Equal(t, USD, part.Currency())
+				actual = append(actual, part.MinorUnits())
+				sum += part.MinorUnits()
+			}
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.minorUnits, sum)
+		})
+	}
+}
+
+func TestMoney_Allocate_InvalidRatios(t *testing.T) {
+	money := mustMoney(t, 100, USD)
+
+	for _, ratios := range [][]int64{nil, {0, 0}, {1, -1}} {
+		_, err := money.Allocate(ratios...)
+		assert.ErrorIs(t, err, ErrValidation)
+	}
+}
+
+func TestMoney_Split(t *testing.T) {
+	parts, err := mustMoney(t, 1000, JPY).Split(3)
+	require.NoError(t, err)
+	assert.Equal(t, []Money{mustMoney(t, 334, JPY), mustMoney(t, 333, JPY), mustMoney(t, 333, JPY)}, parts)
+
+	_, err = mustMoney(t, 1000, JPY).Split(0)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestMoney_Allocate_LargeAmount(t *testing.T) {
+	parts, err := mustMoney(t, math.MaxInt64, KWD).Allocate(math.MaxInt64, 1)
+	require.NoError(t, err)
+
+	sum, err := parts[0].Add(parts[1])
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), sum.MinorUnits())
+}
+
 func TestMoney_JSONRoundTrip(t *testing.T) {
 	original, err := NewMoney(1234, USD)
 	require.NoError(t, err)
@@ -129,7 +318,7 @@ func TestMoney_UnmarshalJSON_InvalidState(t *testing.T) {
 		json string
 	}{
 		{"negative amount", `{"minor_units":-100,"currency":"USD"}`},
-		{"unsupported currency", `{"minor_units":100,"currency":"GBP"}`},
+		{"unsupported currency", `{"minor_units":100,"currency":"XXX"}`},
 		{"missing currency", `{"minor_units":100}`},
 		{"malformed json", `{"minor_units":`},
 	}
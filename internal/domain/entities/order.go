@@ -0,0 +1,268 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+// OrderStatus is a stage of the order lifecycle:
+//
+//	pending → paid → shipped → delivered
+//	pending → cancelled
+//	paid, shipped, delivered → refunded
+//
+// Cancelled means the order was called off before payment, refunded that
+// the buyer got the paid amounts back.
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"
+	OrderPaid      OrderStatus = "paid"
+	OrderShipped   OrderStatus = "shipped"
+	OrderDelivered OrderStatus = "delivered"
+	OrderCancelled OrderStatus = "cancelled"
+	OrderRefunded  OrderStatus = "refunded"
+)
+
+// orderTransitions lists the statuses each status may move to. Statuses
+// without an entry are final.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending:   {OrderPaid, OrderCancelled},
+	OrderPaid:      {OrderShipped, OrderRefunded},
+	OrderShipped:   {OrderDelivered, OrderRefunded},
+	OrderDelivered: {OrderRefunded},
+}
+
+// Order is a buyer's purchase. Its lines snapshot the product name and
+// price at purchase time, so later edits to the product do not change what
+// was bought. Products are referenced by Id only.
+type Order struct {
+	Id      uuid.UUID
+	BuyerId uuid.UUID
+	Items   []OrderItem
+	Status  OrderStatus
+	// TrackingNumber is set when the order ships; it may stay empty.
+	TrackingNumber string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	// Version is incremented on every persisted change and guards against
+	// lost updates (optimistic concurrency).
+	Version int
+
+	domainEvents []events.DomainEvent
+}
+
+// OrderItem is one line of an order: a product variant, with the SKU it had
+// at purchase time.
+type OrderItem struct {
+	ProductId   uuid.UUID
+	VariantId   uuid.UUID
+	Sku         string
+	ProductName string
+	UnitPrice   Money
+	Quantity    int
+	// ReservationId is the stock reservation holding the line's units until
+	// the order is paid or cancelled.
+	ReservationId uuid.UUID
+}
+
+// Total is the line's unit price times its quantity.
+func (i OrderItem) Total() (Money, error) {
+	return lineTotal(i.ProductName, i.UnitPrice, i.Quantity)
+}
+
+func lineTotal(productName string, unitPrice Money, quantity int) (Money, error) {
+	total, err := unitPrice.Multiply(int64(quantity))
+	if err != nil {
+		return Money{}, fmt.Errorf("total of %s: %w", productName, err)
+	}
+
+	return total, nil
+}
+
+// NewOrder creates a pending order of items for buyerId.
+func NewOrder(buyerId uuid.UUID, items []OrderItem) *Order {
+	order := &Order{
+		Id:        uuid.Must(uuid.NewV7()),
+		BuyerId:   buyerId,
+		Items:     items,
+		Status:    OrderPending,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Version:   1,
+	}
+
+	snapshots := make([]events.OrderItem, 0, len(items))
+	for _, item := range items {
+		snapshots = append(snapshots, events.OrderItem{
+			ProductId:   item.ProductId,
+			VariantId:   item.VariantId,
+			Sku:         item.Sku,
+			ProductName: item.ProductName,
+			UnitPrice:   moneySnapshot(item.UnitPrice),
+			Quantity:    item.Quantity,
+		})
+	}
+	order.recordEvent(events.NewOrderCreated(order.Id, buyerId, snapshots, order.totalSnapshots()))
+
+	return order
+}
+
+func (o *Order) recordEvent(event events.DomainEvent) {
+	o.domainEvents = append(o.domainEvents, event)
+}
+
+// PullEvents returns the recorded domain events and clears them. The
+// repository persists them in the same transaction as the aggregate
+// (transactional outbox), so callers pull exactly once per save.
+func (o *Order) PullEvents() []events.DomainEvent {
+	pulled := o.domainEvents
+	o.domainEvents = nil
+	return pulled
+}
+
+func (o *Order) validate() error {
+	if o.BuyerId == uuid.Nil {
+		return fmt.Errorf("%w: buyer id must not be empty", ErrValidation)
+	}
+	if len(o.Items) == 0 {
+		return fmt.Errorf("%w: an order needs at least one item", ErrValidation)
+	}
+	if _, ok := orderTransitions[o.Status]; !ok && !o.Status.IsFinal() {
+		return fmt.Errorf("%w: unknown order status %q", ErrValidation, o.Status)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(o.Items))
+	for _, item := range o.Items {
+		if item.ProductId == uuid.Nil || item.VariantId == uuid.Nil {
+			return fmt.Errorf("%w: product and variant id must not be empty", ErrValidation)
+		}
+		if seen[item.VariantId] {
+			return fmt.Errorf("%w: variant %s appears in more than one item", ErrValidation, item.VariantId)
+		}
+		seen[item.VariantId] = true
+		if item.ProductName == "" {
+			return fmt.Errorf("%w: product name must not be empty", ErrValidation)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("%w: quantity must be greater than 0", ErrValidation)
+		}
+	}
+	if _, err := o.totals(); err != nil {
+		return err
+	}
+	if o.CreatedAt.After(o.UpdatedAt) {
+		return fmt.Errorf("%w: created_at must be before updated_at", ErrValidation)
+	}
+
+	return nil
+}
+
+// IsFinal reports whether no further transition is possible.
+func (s OrderStatus) IsFinal() bool {
+	return s == OrderCancelled || s == OrderRefunded
+}
+
+// Totals returns one total per currency of the order's lines, ordered by
+// currency code. Lines in different currencies are never added up.
+func (o *Order) Totals() []Money {
+	// Validated orders never overflow, so the error can be ignored.
+	totals, _ := o.totals()
+	return totals
+}
+
+func (o *Order) totals() ([]Money, error) {
+	sums := make(map[Currency]Money)
+	for _, item := range o.Items {
+		total, err := item.Total()
+		if err != nil {
+			return nil, err
+		}
+		if sum, ok := sums[total.Currency()]; ok {
+			if total, err = sum.Add(total); err != nil {
+				return nil, err
+			}
+		}
+		sums[total.Currency()] = total
+	}
+
+	totals := make([]Money, 0, len(sums))
+	for _, sum := range sums {
+		totals = append(totals, sum)
+	}
+	slices.SortFunc(totals, func(a, b Money) int {
+		return strings.Compare(string(a.Currency()), string(b.Currency()))
+	})
+
+	return totals, nil
+}
+
+func (o *Order) totalSnapshots() []events.Money {
+	totals := o.Totals()
+	snapshots := make([]events.Money, 0, len(totals))
+	for _, total := range totals {
+		snapshots = append(snapshots, moneySnapshot(total))
+	}
+	return snapshots
+}
+
+// MarkPaid records the buyer's payment of a pending order.
+func (o *Order) MarkPaid() error {
+	if err := o.transition(OrderPaid); err != nil {
+		return err
+	}
+	o.recordEvent(events.NewOrderPaid(o.Id, o.totalSnapshots()))
+	return nil
+}
+
+// Ship hands a paid order to the carrier.
+func (o *Order) Ship(trackingNumber string) error {
+	if err := o.transition(OrderShipped); err != nil {
+		return err
+	}
+	o.TrackingNumber = trackingNumber
+	o.recordEvent(events.NewOrderShipped(o.Id, trackingNumber))
+	return nil
+}
+
+func (o *Order) Deliver() error {
+	if err := o.transition(OrderDelivered); err != nil {
+		return err
+	}
+	o.recordEvent(events.NewOrderDelivered(o.Id))
+	return nil
+}
+
+// Cancel calls off an order that has not been paid yet. Paid orders are
+// refunded instead.
+func (o *Order) Cancel(reason string) error {
+	if err := o.transition(OrderCancelled); err != nil {
+		return err
+	}
+	o.recordEvent(events.NewOrderCancelled(o.Id, reason))
+	return nil
+}
+
+// Refund returns the paid amounts to the buyer.
+func (o *Order) Refund(reason string) error {
+	if err := o.transition(OrderRefunded); err != nil {
+		return err
+	}
+	o.recordEvent(events.NewOrderRefunded(o.Id, o.totalSnapshots(), reason))
+	return nil
+}
+
+func (o *Order) transition(to OrderStatus) error {
+	if !slices.Contains(orderTransitions[o.Status], to) {
+		return fmt.Errorf("%w: a %s order cannot become %s", ErrInvalidOrderTransition, o.Status, to)
+	}
+
+	o.Status = to
+	o.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+func testOrderItem(t *testing.T, name string, minorUnits int64, currency Currency, quantity int) OrderItem {
+	t.Helper()
+	price, err := NewMoney(minorUnits, currency)
+	require.NoError(t, err)
+	return OrderItem{ProductId: uuid.New(), VariantId: uuid.New(), Sku: "SKU-" + name, ProductName: name, UnitPrice: price, Quantity: quantity, ReservationId: uuid.New()}
+}
+
+func TestNewOrder(t *testing.T) {
+	buyerId := uuid.New()
+	widget := testOrderItem(t, "Widget", 999, USD, 2)
+	order := NewOrder(buyerId, []OrderItem{widget})
+
+	assert.Equal(t, OrderPending, order.Status)
+	assert.Equal(t, 1, order.Version)
+
+	pulled := order.PullEvents()
+	require.Len(t, pulled, 1)
+	created := pulled[0].(events.OrderCreated)
+	assert.Equal(t, order.Id, created.AggregateId())
+	assert.Equal(t, buyerId, created.BuyerId)
+	assert.Equal(t, []events.OrderItem{{ProductId: widget.ProductId, VariantId: widget.VariantId, Sku: "SKU-Widget", ProductName: "Widget", UnitPrice: events.Money{MinorUnits: 999, Currency: "USD"}, Quantity: 2}}, created.Items)
+	assert.Equal(t, []events.Money{{MinorUnits: 1998, Currency: "USD"}}, created.Totals)
+}
+
+func TestOrder_TotalsPerCurrency(t *testing.T) {
+	order := NewOrder(uuid.New(), []OrderItem{
+		testOrderItem(t, "Widget", 999, USD, 2),
+		testOrderItem(t, "Gadget", 500, EUR, 1),
+		testOrderItem(t, "Gizmo", 1, USD, 3),
+	})
+
+	assert.Equal(t, []Money{mustMoney(t, 500, EUR), mustMoney(t, 2001, USD)}, order.Totals(),
+		"amounts in different currencies are never added up")
+}
+
+func TestNewValidatedOrder(t *testing.T) {
+	item := testOrderItem(t, "Widget", 999, USD, 1)
+
+	_, err := NewValidatedOrder(NewOrder(uuid.New(), []OrderItem{item}))
+	assert.NoError(t, err)
+	_, err = NewValidatedOrder(NewOrder(uuid.New(), []OrderItem{testOrderItem(t, "Widget", 0, USD, 1)}))
+	assert.NoError(t, err, "a promotion can make an item free")
+
+	for name, order := range map[string]*Order{
+		"no buyer":          NewOrder(uuid.Nil, []OrderItem{item}),
+		"no items":          NewOrder(uuid.New(), nil),
+		"duplicate variant": NewOrder(uuid.New(), []OrderItem{item, item}),
+		"zero quantity":     NewOrder(uuid.New(), []OrderItem{testOrderItem(t, "Widget", 999, USD, 0)}),
+		"no product name":   NewOrder(uuid.New(), []OrderItem{testOrderItem(t, "", 999, USD, 1)}),
+		"overflowing total": NewOrder(uuid.New(), []OrderItem{testOrderItem(t, "Widget", math.MaxInt64/2, USD, 3)}),
+	} {
+		_, err := NewValidatedOrder(order)
+		assert.ErrorIs(t, err, ErrValidation, name)
+	}
+}
+
+func TestOrder_Lifecycle(t *testing.T) {
+	order := NewOrder(uuid.New(), []OrderItem{testOrderItem(t, "Widget", 999, USD, 1)})
+	order.PullEvents()
+
+	require.NoError(t, order.MarkPaid())
+	require.NoError(t, order.Ship("1Z999"))
+	assert.Equal(t, "1Z999", order.TrackingNumber)
+	require.NoError(t, order.Deliver())
+	require.NoError(t, order.Refund("damaged"))
+	assert.Equal(t, OrderRefunded, order.Status)
+	assert.True(t, order.Status.IsFinal())
+
+	assert.Equal(t, []string{events.OrderPaidEventName, events.OrderShippedEventName, events.OrderDeliveredEventName, events.OrderRefundedEventName},
+		eventNames(order.PullEvents()))
+}
+
+func TestOrder_InvalidTransitions(t *testing.T) {
+	order := NewOrder(uuid.New(), []OrderItem{testOrderItem(t, "Widget", 999, USD, 1)})
+	order.PullEvents()
+
+	assert.ErrorIs(t, order.Ship(""), ErrInvalidOrderTransition, "unpaid orders do not ship")
+	assert.ErrorIs(t, order.Deliver(), ErrInvalidOrderTransition)
+	assert.ErrorIs(t, order.Refund(""), ErrInvalidOrderTransition, "unpaid orders are cancelled, not refunded")
+	assert.Equal(t, OrderPending, order.Status)
+
+	require.NoError(t, order.Cancel("changed my mind"))
+	assert.ErrorIs(t, order.MarkPaid(), ErrInvalidOrderTransition, "cancelled is final")
+	assert.ErrorIs(t, order.Cancel(""), ErrInvalidOrderTransition)
+
+	paid := NewOrder(uuid.New(), []OrderItem{testOrderItem(t, "Widget", 999, USD, 1)})
+	require.NoError(t, paid.MarkPaid())
+	assert.ErrorIs(t, paid.Cancel(""), ErrInvalidOrderTransition, "paid orders are refunded, not cancelled")
+
+	pulled := order.PullEvents()
+	require.Len(t, pulled, 1, "failed transitions record nothing")
+	assert.Equal(t, "changed my mind", pulled[0].(events.OrderCancelled).Reason)
+}
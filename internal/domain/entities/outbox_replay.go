@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxReplayStatus is where a replay stands.
+type OutboxReplayStatus string
+
+const (
+	OutboxReplayPending   OutboxReplayStatus = "pending"
+	OutboxReplayRunning   OutboxReplayStatus = "running"
+	OutboxReplayFailed    OutboxReplayStatus = "failed"
+	OutboxReplayCompleted OutboxReplayStatus = "completed"
+)
+
+// OutboxReplayFilter selects the published events to replay. Zero values
+// match everything; the time window is [From, To) on occurred_at.
+type OutboxReplayFilter struct {
+	AggregateId uuid.UUID
+	EventName   string
+	From        time.Time
+	To          time.Time
+}
+
+func (f OutboxReplayFilter) Validate() error {
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return fmt.Errorf("%w: from must be before to", ErrValidation)
+	}
+	return nil
+}
+
+// OutboxReplay re-emits published domain events to one named publisher
+// (its target), e.g. to rebuild a consumer that lost its state. It
+// checkpoints as it goes, so a failed run can be resumed.
+type OutboxReplay struct {
+	Id     uuid.UUID
+	Target string
+	Filter OutboxReplayFilter
+	Status OutboxReplayStatus
+	// Total is the number of matching events published before the replay
+	// was created; Replayed counts how many of them were re-emitted.
+	Total              int64
+	Replayed           int64
+	LastSequenceNumber int64
+	LastError          string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	CompletedAt        *time.Time
+}
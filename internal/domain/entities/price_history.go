@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PricePoint is a price a product had and when it took effect. It stays in
+// effect until the next point.
+type PricePoint struct {
+	Price       Money
+	EffectiveAt time.Time
+}
+
+// LowestPrice returns the lowest price in currency among points. points
+// must be a product's price history for a window, including the price that
+// was already in effect when the window began, as the repository returns
+// it. Prices in other currencies are skipped: they cannot be compared
+// without a rate. ok is false if no point is in currency.
+func LowestPrice(points []PricePoint, currency Currency) (lowest PricePoint, ok bool) {
+	for _, point := range points {
+		if point.Price.Currency() != currency {
+			continue
+		}
+		if !ok || point.Price.MinorUnits() < lowest.Price.MinorUnits() {
+			lowest, ok = point, true
+		}
+	}
+
+	return lowest, ok
+}
+
+// ScheduledPriceChange is a price a product takes on at EffectiveAt. A
+// worker applies it through Product.UpdatePrice, so the change is
+// versioned, published and recorded in the price history like any other.
+type ScheduledPriceChange struct {
+	Id          uuid.UUID
+	ProductId   uuid.UUID
+	Price       Money
+	EffectiveAt time.Time
+	CreatedAt   time.Time
+}
+
+// NewScheduledPriceChange schedules price for productId. effectiveAt must
+// lie in the future; a change that should apply now is a product update.
+func NewScheduledPriceChange(productId uuid.UUID, price Money, effectiveAt time.Time) (*ScheduledPriceChange, error) {
+	now := time.Now()
+	if productId == uuid.Nil {
+		return nil, fmt.Errorf("%w: product id must not be empty", ErrValidation)
+	}
+	if price.MinorUnits() == 0 {
+		return nil, fmt.Errorf("%w: price must be greater than 0", ErrValidation)
+	}
+	if !effectiveAt.After(now) {
+		return nil, fmt.Errorf("%w: effective_at must be in the future", ErrValidation)
+	}
+
+	return &ScheduledPriceChange{
+		Id:          uuid.Must(uuid.NewV7()),
+		ProductId:   productId,
+		Price:       price,
+		EffectiveAt: effectiveAt,
+		CreatedAt:   now,
+	}, nil
+}
+
+// IsDue reports whether the change should have been applied by now.
+func (c *ScheduledPriceChange) IsDue(now time.Time) bool {
+	return !c.EffectiveAt.After(now)
+}
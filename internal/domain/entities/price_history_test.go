@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLowestPrice(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := []PricePoint{
+		{Price: mustMoney(t, 999, USD), EffectiveAt: start},
+		{Price: mustMoney(t, 100, EUR), EffectiveAt: start.Add(time.Hour)},
+		{Price: mustMoney(t, 799, USD), EffectiveAt: start.Add(2 * time.Hour)},
+		{Price: mustMoney(t, 799, USD), EffectiveAt: start.Add(3 * time.Hour)},
+		{Price: mustMoney(t, 899, USD), EffectiveAt: start.Add(4 * time.Hour)},
+	}
+
+	// Prices in other currencies are skipped; ties keep the earliest point.
+	lowest, ok := LowestPrice(points, USD)
+	require.True(t, ok)
+	assert.Equal(t, int64(799), lowest.Price.MinorUnits())
+	assert.Equal(t, start.Add(2*time.Hour), lowest.EffectiveAt)
+
+	_, ok = LowestPrice(points, GBP)
+	assert.False(t, ok)
+	_, ok = LowestPrice(nil, USD)
+	assert.False(t, ok)
+}
+
+func TestNewScheduledPriceChange(t *testing.T) {
+	productId := uuid.New()
+	future := time.Now().Add(time.Hour)
+
+	change, err := NewScheduledPriceChange(productId, mustMoney(t, 1299, USD), future)
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, change.Id)
+	assert.False(t, change.IsDue(time.Now()))
+	assert.True(t, change.IsDue(future))
+
+	testCases := []struct {
+		name        string
+		productId   uuid.UUID
+		price       Money
+		effectiveAt time.Time
+	}{
+		{"nil product", uuid.Nil, mustMoney(t, 1299, USD), future},
+		{"zero price", productId, mustMoney(t, 0, USD), future},
+		{"in the past", productId, mustMoney(t, 1299, USD), time.Now().Add(-time.Minute)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewScheduledPriceChange(tc.productId, tc.price, tc.effectiveAt)
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// EffectivePrice is the price of product at at after the best applicable
+// promotion or combination of promotions, and the promotions it applied.
+// It prices product.Price, which is what every variant without a price
+// override costs; EffectiveVariantPrice prices one variant. It is a domain
+// service: the rule spans Product and Promotion and belongs to neither.
+//
+// Promotions that do not cover the product, are not running at at, have
+// no redemptions left or discount a fixed amount in another currency are
+// ignored. Of the rest, each promotion that is not stackable is tried on
+// its own, and all stackable ones together: percentages first, compounding
+// in start order, then fixed amounts. The lowest price wins; on a tie, the
+// candidate tried first. The price never drops below zero.
+func EffectivePrice(product *Product, promotions []*Promotion, at time.Time) (Money, []*Promotion, error) {
+	return discountedPrice(product, product.Price, promotions, at)
+}
+
+// EffectiveVariantPrice is EffectivePrice for variant of product, starting
+// from product.VariantPrice(variant).
+func EffectiveVariantPrice(product *Product, variant ProductVariant, promotions []*Promotion, at time.Time) (Money, []*Promotion, error) {
+	return discountedPrice(product, product.VariantPrice(variant), promotions, at)
+}
+
+func discountedPrice(product *Product, listPrice Money, promotions []*Promotion, at time.Time) (Money, []*Promotion, error) {
+	var exclusive, stackable []*Promotion
+	for _, promotion := range promotions {
+		if !promotion.AppliesTo(product) || !promotion.IsActive(at) {
+			continue
+		}
+		if promotion.Discount.Kind == DiscountFixedAmount && promotion.Discount.Amount.Currency() != listPrice.Currency() {
+			continue
+		}
+		if promotion.Stackable {
+			stackable = append(stackable, promotion)
+		} else {
+			exclusive = append(exclusive, promotion)
+		}
+	}
+
+	slices.SortStableFunc(stackable, func(a, b *Promotion) int {
+		aFixed, bFixed := a.Discount.Kind == DiscountFixedAmount, b.Discount.Kind == DiscountFixedAmount
+		if aFixed != bFixed {
+			if aFixed {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(a.StartsAt.Compare(b.StartsAt), cmp.Compare(a.Id.String(), b.Id.String()))
+	})
+
+	candidates := make([][]*Promotion, 0, len(exclusive)+1)
+	if len(stackable) > 0 {
+		candidates = append(candidates, stackable)
+	}
+	for _, promotion := range exclusive {
+		candidates = append(candidates, []*Promotion{promotion})
+	}
+
+	best, applied := listPrice, []*Promotion(nil)
+	for _, candidate := range candidates {
+		price := listPrice
+		for _, promotion := range candidate {
+			var err error
+			if price, err = promotion.Discount.Apply(price); err != nil {
+				return Money{}, nil, err
+			}
+		}
+		if price.MinorUnits() < best.MinorUnits() {
+			best, applied = price, candidate
+		}
+	}
+
+	return best, applied, nil
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectivePrice(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Acme"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 10000, USD), *seller)
+	at := promotionStart.Add(time.Hour)
+
+	tenPercent := newTestPromotion(t, seller.Id, NewPercentageDiscount(1000), PromotionTerms{Stackable: true})
+	fiveOff := newTestPromotion(t, seller.Id, NewFixedAmountDiscount(mustMoney(t, 500, USD)), PromotionTerms{Stackable: true})
+	twentyPercent := newTestPromotion(t, seller.Id, NewPercentageDiscount(2000), PromotionTerms{})
+	fiftyPercent := newTestPromotion(t, seller.Id, NewPercentageDiscount(5000), PromotionTerms{})
+	euros := newTestPromotion(t, seller.Id, NewFixedAmountDiscount(mustMoney(t, 9000, EUR)), PromotionTerms{})
+	otherSeller := newTestPromotion(t, uuid.New(), NewPercentageDiscount(9000), PromotionTerms{})
+	otherProduct := newTestPromotion(t, seller.Id, NewPercentageDiscount(9000), PromotionTerms{ProductIds: []uuid.UUID{uuid.New()}})
+	exhausted := newTestPromotion(t, seller.Id, NewPercentageDiscount(9000), PromotionTerms{UsageLimit: 1})
+	require.NoError(t, exhausted.Redeem(at))
+	upcoming := newTestPromotion(t, seller.Id, NewPercentageDiscount(9000), PromotionTerms{StartsAt: at.Add(time.Hour)})
+
+	ignored := []*Promotion{euros, otherSeller, otherProduct, exhausted, upcoming}
+
+	testCases := []struct {
+		name       string
+		promotions []*Promotion
+		expected   int64
+		applied    []*Promotion
+	}{
+		{"none", nil, 10000, nil},
+		{"only inapplicable ones", ignored, 10000, nil},
+		// Percentages apply before fixed amounts whatever the order given:
+		// 10000 - 10% - 500, not (10000 - 500) - 10%.
+		{"stacked", []*Promotion{fiveOff, tenPercent}, 8500, []*Promotion{tenPercent, fiveOff}},
+		{"exclusive beats the stack", []*Promotion{tenPercent, fiveOff, twentyPercent}, 8000, []*Promotion{twentyPercent}},
+		{"stack beats the exclusive", []*Promotion{tenPercent, fiveOff, newTestPromotion(t, seller.Id, NewPercentageDiscount(1200), PromotionTerms{})}, 8500, []*Promotion{tenPercent, fiveOff}},
+		{"best exclusive", append([]*Promotion{twentyPercent, fiftyPercent}, ignored...), 5000, []*Promotion{fiftyPercent}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, applied, err := EffectivePrice(product, tc.promotions, at)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, price.MinorUnits())
+			assert.Equal(t, USD, price.Currency())
+			assert.Equal(t, tc.applied, applied)
+		})
+	}
+}
+
+func TestEffectiveVariantPrice(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Acme"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 10000, USD), *seller)
+	override := mustMoney(t, 4000, USD)
+	require.NoError(t, product.AddVariant(NewProductVariant("WIDGET-S", map[string]string{"size": "S"}, &override)))
+	small := product.Variants[len(product.Variants)-1]
+	at := promotionStart.Add(time.Hour)
+
+	tenPercent := newTestPromotion(t, seller.Id, NewPercentageDiscount(1000), PromotionTerms{})
+	// 5.00 off beats 10% on the override but not on the product's price.
+	fiveOff := newTestPromotion(t, seller.Id, NewFixedAmountDiscount(mustMoney(t, 500, USD)), PromotionTerms{})
+	promotions := []*Promotion{tenPercent, fiveOff}
+
+	price, applied, err := EffectiveVariantPrice(product, small, promotions, at)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3500), price.MinorUnits())
+	assert.Equal(t, []*Promotion{fiveOff}, applied)
+
+	price, applied, err = EffectiveVariantPrice(product, product.Variants[0], promotions, at)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9000), price.MinorUnits())
+	assert.Equal(t, []*Promotion{tenPercent}, applied)
+}
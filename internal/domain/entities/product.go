@@ -1,13 +1,38 @@
 package entities
 
 import (
+	"bytes"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sklinkert/go-ddd/internal/domain/events"
 )
 
+// ProductStatus is a stage of the product lifecycle:
+//
+//	draft → published ⇄ archived
+//	draft → archived
+//
+// Only published products are listed publicly and can be bought; archived
+// ones are retired listings the seller keeps without deleting them.
+type ProductStatus string
+
+const (
+	ProductDraft     ProductStatus = "draft"
+	ProductPublished ProductStatus = "published"
+	ProductArchived  ProductStatus = "archived"
+)
+
+// productTransitions lists the statuses each status may move to.
+var productTransitions = map[ProductStatus][]ProductStatus{
+	ProductDraft:     {ProductPublished, ProductArchived},
+	ProductPublished: {ProductArchived},
+	ProductArchived:  {ProductPublished},
+}
+
 type Product struct {
 	Id        uuid.UUID
 	CreatedAt time.Time
@@ -15,6 +40,19 @@ type Product struct {
 	Name      string
 	Price     Money
 	SellerId  uuid.UUID
+	Status    ProductStatus
+	// CategoryIds references the categories the product is listed in,
+	// sorted. Categories are a separate aggregate; the repository checks
+	// that they exist when the product is saved.
+	CategoryIds []uuid.UUID
+	// Variants are the sellable versions of the product; there is always at
+	// least one. See ProductVariant.
+	Variants []ProductVariant
+	// Images are the product's pictures in display order. See ProductImage.
+	Images []ProductImage
+	// Version is incremented on every persisted change and guards against
+	// lost updates (optimistic concurrency).
+	Version int
 
 	domainEvents []events.DomainEvent
 }
@@ -29,6 +67,23 @@ func (p *Product) validate() error {
 	if p.SellerId == uuid.Nil {
 		return fmt.Errorf("%w: seller id must not be empty", ErrValidation)
 	}
+	if _, ok := productTransitions[p.Status]; !ok {
+		return fmt.Errorf("%w: unknown product status %q", ErrValidation, p.Status)
+	}
+	for index, categoryId := range p.CategoryIds {
+		if categoryId == uuid.Nil {
+			return fmt.Errorf("%w: category id must not be empty", ErrValidation)
+		}
+		if slices.Contains(p.CategoryIds[:index], categoryId) {
+			return fmt.Errorf("%w: category %s is listed twice", ErrValidation, categoryId)
+		}
+	}
+	if err := p.validateVariants(); err != nil {
+		return err
+	}
+	if err := p.validateImages(); err != nil {
+		return err
+	}
 	if p.CreatedAt.After(p.UpdatedAt) {
 		return fmt.Errorf("%w: created_at must be before updated_at", ErrValidation)
 	}
@@ -36,10 +91,90 @@ func (p *Product) validate() error {
 	return nil
 }
 
+func (p *Product) validateVariants() error {
+	if len(p.Variants) == 0 {
+		return fmt.Errorf("%w: a product needs at least one variant", ErrValidation)
+	}
+
+	skus := make(map[string]bool, len(p.Variants))
+	combinations := make(map[string]string, len(p.Variants))
+	for index, variant := range p.Variants {
+		if variant.Id == uuid.Nil {
+			return fmt.Errorf("%w: variant id must not be empty", ErrValidation)
+		}
+		if slices.ContainsFunc(p.Variants[:index], func(other ProductVariant) bool { return other.Id == variant.Id }) {
+			return fmt.Errorf("%w: variant %s is listed twice", ErrValidation, variant.Id)
+		}
+		if strings.TrimSpace(variant.Sku) == "" {
+			return fmt.Errorf("%w: sku must not be empty", ErrValidation)
+		}
+		if skus[variant.Sku] {
+			return fmt.Errorf("%w: sku %s is used by more than one variant", ErrValidation, variant.Sku)
+		}
+		skus[variant.Sku] = true
+		for name, value := range variant.Attributes {
+			if name == "" || value == "" {
+				return fmt.Errorf("%w: variant attributes need a name and a value", ErrValidation)
+			}
+		}
+		key := variant.attributeKey()
+		if sku, taken := combinations[key]; taken {
+			return fmt.Errorf("%w: variants %s and %s have the same attributes", ErrValidation, sku, variant.Sku)
+		}
+		combinations[key] = variant.Sku
+		if override := variant.PriceOverride; override != nil {
+			if override.MinorUnits() <= 0 {
+				return fmt.Errorf("%w: price override of %s must be greater than 0", ErrValidation, variant.Sku)
+			}
+			if override.Currency() != p.Price.Currency() {
+				return fmt.Errorf("%w: price override of %s must be in %s", ErrValidation, variant.Sku, p.Price.Currency())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Product) validateImages() error {
+	if len(p.Images) > MaxProductImages {
+		return fmt.Errorf("%w: a product has at most %d images", ErrValidation, MaxProductImages)
+	}
+
+	primaries := 0
+	for index, image := range p.Images {
+		if image.Id == uuid.Nil {
+			return fmt.Errorf("%w: image id must not be empty", ErrValidation)
+		}
+		if slices.ContainsFunc(p.Images[:index], func(other ProductImage) bool { return other.Id == image.Id }) {
+			return fmt.Errorf("%w: image %s is listed twice", ErrValidation, image.Id)
+		}
+		if image.BlobKey == "" {
+			return fmt.Errorf("%w: image blob key must not be empty", ErrValidation)
+		}
+		if !IsImageContentType(image.ContentType) {
+			return fmt.Errorf("%w: image %s has unsupported content type %q", ErrValidation, image.Id, image.ContentType)
+		}
+		if image.SizeBytes <= 0 {
+			return fmt.Errorf("%w: image %s must not be empty", ErrValidation, image.Id)
+		}
+		if image.Primary {
+			primaries++
+		}
+	}
+	if len(p.Images) > 0 && primaries != 1 {
+		return fmt.Errorf("%w: a product with images needs exactly one primary image", ErrValidation)
+	}
+
+	return nil
+}
+
 // NewProduct requires a ValidatedSeller so a product can only ever be
 // created against a seller that passed validation. The product stores just
 // the seller's Id: sellers are a separate aggregate and must not be embedded.
-func NewProduct(name string, price Money, seller ValidatedSeller) *Product {
+// New products are drafts until they are published. Without variants the
+// product gets a single default variant without attributes, whose SKU is
+// the product Id.
+func NewProduct(name string, price Money, seller ValidatedSeller, variants ...ProductVariant) *Product {
 	product := &Product{
 		Id:        uuid.Must(uuid.NewV7()),
 		CreatedAt: time.Now(),
@@ -47,9 +182,19 @@ func NewProduct(name string, price Money, seller ValidatedSeller) *Product {
 		Name:      name,
 		Price:     price,
 		SellerId:  seller.Id,
+		Status:    ProductDraft,
+		Variants:  slices.Clone(variants),
+		Version:   1,
+	}
+	if len(product.Variants) == 0 {
+		product.Variants = []ProductVariant{NewProductVariant(product.Id.String(), nil, nil)}
 	}
 
-	product.recordEvent(events.NewProductCreated(product.Id, name, price.MinorUnits(), string(price.Currency()), seller.Id))
+	snapshots := make([]events.ProductVariant, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		snapshots = append(snapshots, variant.snapshot())
+	}
+	product.recordEvent(events.NewProductCreated(product.Id, name, price.MinorUnits(), string(price.Currency()), seller.Id, string(product.Status), snapshots))
 
 	return product
 }
@@ -67,24 +212,381 @@ func (p *Product) PullEvents() []events.DomainEvent {
 	return pulled
 }
 
+// UpdateName renames the product. Events are only recorded for valid,
+// actual changes, so a no-op update publishes nothing.
 func (p *Product) UpdateName(name string) error {
+	oldName := p.Name
 	p.Name = name
 	p.UpdatedAt = time.Now()
 
-	return p.validate()
+	if err := p.validate(); err != nil {
+		return err
+	}
+	if name != oldName {
+		p.recordEvent(events.NewProductRenamed(p.Id, oldName, name))
+	}
+
+	return nil
 }
 
+// UpdatePrice reprices the product. Archived products keep their last
+// price: repricing them is ErrProductArchived.
 func (p *Product) UpdatePrice(price Money) error {
+	if p.Status == ProductArchived && price != p.Price {
+		return fmt.Errorf("%w: %s cannot be repriced", ErrProductArchived, p.Name)
+	}
+
+	oldPrice := p.Price
 	p.Price = price
 	p.UpdatedAt = time.Now()
 
-	return p.validate()
+	if err := p.validate(); err != nil {
+		return err
+	}
+	if price != oldPrice {
+		p.recordEvent(events.NewProductPriceChanged(p.Id, moneySnapshot(oldPrice), moneySnapshot(price)))
+	}
+
+	return nil
 }
 
 // AssignSeller moves the product to a different (validated) seller.
 func (p *Product) AssignSeller(seller ValidatedSeller) error {
+	oldSellerId := p.SellerId
 	p.SellerId = seller.Id
 	p.UpdatedAt = time.Now()
 
-	return p.validate()
+	if err := p.validate(); err != nil {
+		return err
+	}
+	if seller.Id != oldSellerId {
+		p.recordEvent(events.NewProductReassigned(p.Id, oldSellerId, seller.Id))
+	}
+
+	return nil
+}
+
+// Categorize replaces the categories the product is listed in. The order
+// of categoryIds does not matter; setting the current categories again
+// records no event.
+func (p *Product) Categorize(categoryIds []uuid.UUID) error {
+	sorted := slices.Clone(categoryIds)
+	slices.SortFunc(sorted, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+
+	oldCategoryIds := p.CategoryIds
+	p.CategoryIds = sorted
+	p.UpdatedAt = time.Now()
+
+	if err := p.validate(); err != nil {
+		return err
+	}
+	if !slices.Equal(sorted, oldCategoryIds) {
+		p.recordEvent(events.NewProductCategorized(p.Id, p.SellerId, sorted))
+	}
+
+	return nil
+}
+
+// Variant returns the variant with the given Id; ok is false when the
+// product has no such variant.
+func (p *Product) Variant(variantId uuid.UUID) (variant ProductVariant, ok bool) {
+	index := slices.IndexFunc(p.Variants, func(v ProductVariant) bool { return v.Id == variantId })
+	if index < 0 {
+		return ProductVariant{}, false
+	}
+	return p.Variants[index], true
+}
+
+// ResolveVariant returns the variant a buyer or stock clerk refers to. A
+// nil variantId is accepted for products with a single variant, so clients
+// that ignore variants keep working; with several variants it is
+// ErrValidation.
+func (p *Product) ResolveVariant(variantId uuid.UUID) (ProductVariant, error) {
+	if variantId == uuid.Nil {
+		if len(p.Variants) != 1 {
+			return ProductVariant{}, fmt.Errorf("%w: %s has %d variants, choose one", ErrValidation, p.Name, len(p.Variants))
+		}
+		return p.Variants[0], nil
+	}
+
+	variant, ok := p.Variant(variantId)
+	if !ok {
+		return ProductVariant{}, fmt.Errorf("%w: %s of %s", ErrVariantNotFound, variantId, p.Name)
+	}
+	return variant, nil
+}
+
+// VariantPrice is what the variant costs: its price override, or else the
+// product's price.
+func (p *Product) VariantPrice(variant ProductVariant) Money {
+	if variant.PriceOverride != nil {
+		return *variant.PriceOverride
+	}
+	return p.Price
+}
+
+// AddVariant adds a variant. Its SKU must also be unused by other products;
+// the repository checks that when the product is saved. A rejected variant
+// leaves the product unchanged.
+func (p *Product) AddVariant(variant ProductVariant) error {
+	variant.Attributes = cloneAttributes(variant.Attributes)
+	if err := p.replaceVariants(append(slices.Clone(p.Variants), variant)); err != nil {
+		return err
+	}
+	p.recordEvent(events.NewProductVariantAdded(p.Id, p.SellerId, variant.snapshot()))
+
+	return nil
+}
+
+// UpdateVariant replaces the variant's SKU, attributes and price override;
+// a rejected change leaves the product unchanged. Like the product's price,
+// the price overrides of an archived product are frozen.
+func (p *Product) UpdateVariant(variantId uuid.UUID, sku string, attributes map[string]string, priceOverride *Money) error {
+	index := slices.IndexFunc(p.Variants, func(v ProductVariant) bool { return v.Id == variantId })
+	if index < 0 {
+		return fmt.Errorf("%w: %s of %s", ErrVariantNotFound, variantId, p.Name)
+	}
+
+	old := p.Variants[index]
+	if p.Status == ProductArchived && !equalOverrides(old.PriceOverride, priceOverride) {
+		return fmt.Errorf("%w: %s cannot be repriced", ErrProductArchived, p.Name)
+	}
+
+	updated := ProductVariant{Id: variantId, Sku: sku, Attributes: cloneAttributes(attributes), PriceOverride: priceOverride}
+	variants := slices.Clone(p.Variants)
+	variants[index] = updated
+	if err := p.replaceVariants(variants); err != nil {
+		return err
+	}
+	if updated.Sku != old.Sku || updated.attributeKey() != old.attributeKey() || !equalOverrides(old.PriceOverride, priceOverride) {
+		p.recordEvent(events.NewProductVariantChanged(p.Id, p.SellerId, updated.snapshot()))
+	}
+
+	return nil
+}
+
+// RemoveVariant removes a variant; the last one cannot be removed. The
+// variant's stock goes with it.
+func (p *Product) RemoveVariant(variantId uuid.UUID) error {
+	index := slices.IndexFunc(p.Variants, func(v ProductVariant) bool { return v.Id == variantId })
+	if index < 0 {
+		return fmt.Errorf("%w: %s of %s", ErrVariantNotFound, variantId, p.Name)
+	}
+	if len(p.Variants) == 1 {
+		return fmt.Errorf("%w: a product needs at least one variant", ErrValidation)
+	}
+
+	removed := p.Variants[index]
+	p.Variants = slices.Delete(slices.Clone(p.Variants), index, index+1)
+	p.UpdatedAt = time.Now()
+	p.recordEvent(events.NewProductVariantRemoved(p.Id, p.SellerId, removed.Id, removed.Sku))
+
+	return nil
+}
+
+// replaceVariants swaps in variants if the product stays valid with them.
+func (p *Product) replaceVariants(variants []ProductVariant) error {
+	oldVariants := p.Variants
+	p.Variants = variants
+	if err := p.validate(); err != nil {
+		p.Variants = oldVariants
+		return err
+	}
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Image returns the image with the given Id; ok is false when the product
+// has no such image.
+func (p *Product) Image(imageId uuid.UUID) (image ProductImage, ok bool) {
+	index := slices.IndexFunc(p.Images, func(i ProductImage) bool { return i.Id == imageId })
+	if index < 0 {
+		return ProductImage{}, false
+	}
+	return p.Images[index], true
+}
+
+// PrimaryImage returns the image shown in listings; ok is false when the
+// product has no images.
+func (p *Product) PrimaryImage() (image ProductImage, ok bool) {
+	index := slices.IndexFunc(p.Images, func(i ProductImage) bool { return i.Primary })
+	if index < 0 {
+		return ProductImage{}, false
+	}
+	return p.Images[index], true
+}
+
+// AddImage appends an image. The first image of a product always becomes
+// its primary image; later ones only when primary is set.
+func (p *Product) AddImage(image ProductImage, primary bool) error {
+	images := slices.Clone(p.Images)
+	primary = primary || len(images) == 0
+	if primary {
+		for index := range images {
+			images[index].Primary = false
+		}
+	}
+	image.Primary = primary
+	if err := p.replaceImages(append(images, image)); err != nil {
+		return err
+	}
+
+	p.recordEvent(events.NewProductImageAdded(p.Id, p.SellerId, image.snapshot(), p.gallery()))
+	return nil
+}
+
+// RemoveImage removes an image. When it was the primary image, the next
+// image in display order takes its place. The repository schedules the
+// image's blob for deletion.
+func (p *Product) RemoveImage(imageId uuid.UUID) error {
+	index := slices.IndexFunc(p.Images, func(i ProductImage) bool { return i.Id == imageId })
+	if index < 0 {
+		return fmt.Errorf("%w: %s of %s", ErrImageNotFound, imageId, p.Name)
+	}
+
+	removed := p.Images[index]
+	images := slices.Delete(slices.Clone(p.Images), index, index+1)
+	if removed.Primary && len(images) > 0 {
+		images[0].Primary = true
+	}
+	if err := p.replaceImages(images); err != nil {
+		return err
+	}
+
+	p.recordEvent(events.NewProductImageRemoved(p.Id, p.SellerId, removed.Id, removed.BlobKey, p.gallery()))
+	return nil
+}
+
+// ReorderImages puts the images in the given order, which must name every
+// image of the product exactly once.
+func (p *Product) ReorderImages(imageIds []uuid.UUID) error {
+	if len(imageIds) != len(p.Images) {
+		return fmt.Errorf("%w: the new order must name all %d images", ErrValidation, len(p.Images))
+	}
+
+	images := make([]ProductImage, 0, len(imageIds))
+	for _, imageId := range imageIds {
+		image, ok := p.Image(imageId)
+		if !ok {
+			return fmt.Errorf("%w: %s of %s", ErrImageNotFound, imageId, p.Name)
+		}
+		images = append(images, image)
+	}
+	if slices.Equal(imageIds, p.imageIds()) {
+		return nil
+	}
+	if err := p.replaceImages(images); err != nil {
+		return err
+	}
+
+	p.recordEvent(events.NewProductImagesArranged(p.Id, p.SellerId, p.gallery()))
+	return nil
+}
+
+// SetPrimaryImage makes the image the one shown in listings.
+func (p *Product) SetPrimaryImage(imageId uuid.UUID) error {
+	image, ok := p.Image(imageId)
+	if !ok {
+		return fmt.Errorf("%w: %s of %s", ErrImageNotFound, imageId, p.Name)
+	}
+	if image.Primary {
+		return nil
+	}
+
+	images := slices.Clone(p.Images)
+	for index := range images {
+		images[index].Primary = images[index].Id == imageId
+	}
+	if err := p.replaceImages(images); err != nil {
+		return err
+	}
+
+	p.recordEvent(events.NewProductImagesArranged(p.Id, p.SellerId, p.gallery()))
+	return nil
+}
+
+// replaceImages swaps in images if the product stays valid with them.
+func (p *Product) replaceImages(images []ProductImage) error {
+	oldImages := p.Images
+	p.Images = images
+	if err := p.validate(); err != nil {
+		p.Images = oldImages
+		return err
+	}
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Product) imageIds() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(p.Images))
+	for _, image := range p.Images {
+		ids = append(ids, image.Id)
+	}
+	return ids
+}
+
+func (p *Product) gallery() events.ProductGallery {
+	gallery := events.ProductGallery{ImageIds: p.imageIds()}
+	if primary, ok := p.PrimaryImage(); ok {
+		gallery.PrimaryImageId = primary.Id
+	}
+	return gallery
+}
+
+func equalOverrides(a, b *Money) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// IsPublished reports whether the product is listed publicly and can be
+// bought.
+func (p *Product) IsPublished() bool {
+	return p.Status == ProductPublished
+}
+
+// Publish lists the product. Only a product that passes validation can be
+// published.
+func (p *Product) Publish() error {
+	if err := p.transition(ProductPublished); err != nil {
+		return err
+	}
+	p.recordEvent(events.NewProductPublished(p.Id, p.SellerId))
+	return nil
+}
+
+// Archive retires the product: it is no longer listed or sold, and keeps
+// its price, but stays with the seller and can be published again.
+func (p *Product) Archive() error {
+	if err := p.transition(ProductArchived); err != nil {
+		return err
+	}
+	p.recordEvent(events.NewProductArchived(p.Id, p.SellerId))
+	return nil
+}
+
+func (p *Product) transition(to ProductStatus) error {
+	if !slices.Contains(productTransitions[p.Status], to) {
+		return fmt.Errorf("%w: a %s product cannot become %s", ErrInvalidProductTransition, p.Status, to)
+	}
+
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	p.Status = to
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Delete records the product's removal. The repository performs the soft
+// delete, schedules the blobs of the product's images for deletion and
+// stores the event in the same transaction.
+func (p *Product) Delete() {
+	p.recordEvent(events.NewProductDeleted(p.Id, p.SellerId))
+}
+
+func moneySnapshot(m Money) events.Money {
+	return events.Money{MinorUnits: m.MinorUnits(), Currency: string(m.Currency())}
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/events"
 )
 
 func mustMoney(t *testing.T, minorUnits int64, currency Currency) Money {
@@ -113,6 +115,8 @@ func TestProduct_Validate_CreatedAtAfterUpdatedAt(t *testing.T) {
 		Name:      "Test Product",
 		Price:     mustMoney(t, 9999, USD),
 		SellerId:  validatedSeller.Id,
+		Status:    ProductDraft,
+		Variants:  []ProductVariant{NewProductVariant("TEST-1", nil, nil)},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now().Add(-1 * time.Hour), // UpdatedAt before CreatedAt
 	}
@@ -147,6 +151,8 @@ func TestProduct_Validate_AllEdgeCases(t *testing.T) {
 				Name:      tc.productName,
 				Price:     mustMoney(t, tc.priceMinorUnits, USD),
 				SellerId:  tc.sellerId,
+				Status:    ProductDraft,
+				Variants:  []ProductVariant{NewProductVariant("TEST-1", nil, nil)},
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			}
@@ -161,3 +167,230 @@ func TestProduct_Validate_AllEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestProduct_Lifecycle(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Test Seller"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+	product.PullEvents()
+
+	assert.Equal(t, ProductDraft, product.Status)
+	assert.False(t, product.IsPublished())
+
+	require.NoError(t, product.Publish())
+	assert.True(t, product.IsPublished())
+	require.NoError(t, product.Archive())
+	assert.Equal(t, ProductArchived, product.Status)
+	require.NoError(t, product.Publish(), "archived products can be listed again")
+
+	assert.Equal(t, []string{events.ProductPublishedEventName, events.ProductArchivedEventName, events.ProductPublishedEventName},
+		eventNames(product.PullEvents()))
+}
+
+func TestProduct_InvalidTransitions(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Test Seller"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+	require.NoError(t, product.Publish())
+	product.PullEvents()
+
+	assert.ErrorIs(t, product.Publish(), ErrInvalidProductTransition)
+	require.NoError(t, product.Archive())
+	assert.ErrorIs(t, product.Archive(), ErrInvalidProductTransition)
+
+	// A product that fails validation stays a draft.
+	invalid := &Product{Name: "", Price: mustMoney(t, 999, USD), SellerId: seller.Id, Status: ProductDraft}
+	assert.ErrorIs(t, invalid.Publish(), ErrValidation)
+	assert.Equal(t, ProductDraft, invalid.Status)
+
+	pulled := product.PullEvents()
+	require.Len(t, pulled, 1, "failed transitions record nothing")
+	assert.Equal(t, events.ProductArchivedEventName, pulled[0].EventName())
+}
+
+func TestProduct_ArchivedCannotBeRepriced(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Test Seller"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+	require.NoError(t, product.Archive())
+
+	assert.ErrorIs(t, product.UpdatePrice(mustMoney(t, 1299, USD)), ErrProductArchived)
+	assert.Equal(t, int64(999), product.Price.MinorUnits())
+
+	// Resubmitting the current price, as a full update does, is allowed.
+	require.NoError(t, product.UpdatePrice(mustMoney(t, 999, USD)))
+	require.NoError(t, product.UpdateName("Widget (retired)"))
+}
+
+func TestProduct_Categorize(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Test Seller"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+	product.PullEvents()
+	first, second := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+
+	require.NoError(t, product.Categorize([]uuid.UUID{second, first}))
+	assert.Equal(t, []uuid.UUID{first, second}, product.CategoryIds, "kept sorted")
+	categorized, ok := product.PullEvents()[0].(events.ProductCategorized)
+	require.True(t, ok)
+	assert.Equal(t, []uuid.UUID{first, second}, categorized.CategoryIds)
+
+	require.NoError(t, product.Categorize([]uuid.UUID{first, second}))
+	assert.Empty(t, product.PullEvents(), "the same categories in another order are no change")
+
+	assert.ErrorIs(t, product.Categorize([]uuid.UUID{first, first}), ErrValidation)
+	assert.ErrorIs(t, product.Categorize([]uuid.UUID{uuid.Nil}), ErrValidation)
+
+	require.NoError(t, product.Categorize(nil))
+	assert.Empty(t, product.CategoryIds)
+	assert.Len(t, product.PullEvents(), 1)
+}
+
+func TestNewProduct_DefaultVariant(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Test Seller"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+
+	require.Len(t, product.Variants, 1)
+	assert.Equal(t, product.Id.String(), product.Variants[0].Sku)
+	assert.Empty(t, product.Variants[0].Attributes)
+	created, ok := product.PullEvents()[0].(events.ProductCreated)
+	require.True(t, ok)
+	require.Len(t, created.Variants, 1)
+	assert.Equal(t, product.Variants[0].Id, created.Variants[0].Id)
+
+	variant, err := product.ResolveVariant(uuid.Nil)
+	require.NoError(t, err, "a single variant is picked without its id")
+	assert.Equal(t, product.Variants[0], variant)
+	assert.Equal(t, product.Price, product.VariantPrice(variant))
+}
+
+func TestProduct_Variants(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Test Seller"))
+	require.NoError(t, err)
+	override := mustMoney(t, 1299, USD)
+	small := NewProductVariant("TEE-S", map[string]string{"size": "S"}, nil)
+	large := NewProductVariant("TEE-L", map[string]string{"size": "L"}, &override)
+	product := NewProduct("T-shirt", mustMoney(t, 999, USD), *seller, small, large)
+	_, err = NewValidatedProduct(product)
+	require.NoError(t, err)
+	product.PullEvents()
+
+	assert.Equal(t, mustMoney(t, 1299, USD), product.VariantPrice(large))
+	_, err = product.ResolveVariant(uuid.Nil)
+	assert.ErrorIs(t, err, ErrValidation, "several variants need an explicit choice")
+	_, err = product.ResolveVariant(uuid.New())
+	assert.ErrorIs(t, err, ErrVariantNotFound)
+
+	medium := NewProductVariant("TEE-M", map[string]string{"size": "M"}, nil)
+	require.NoError(t, product.AddVariant(medium))
+	added, ok := product.PullEvents()[0].(events.ProductVariantAdded)
+	require.True(t, ok)
+	assert.Equal(t, "TEE-M", added.Variant.Sku)
+
+	euro := mustMoney(t, 999, EUR)
+	for name, variant := range map[string]ProductVariant{
+		"duplicate sku":        NewProductVariant("TEE-S", map[string]string{"size": "XL"}, nil),
+		"same attributes":      NewProductVariant("TEE-S2", map[string]string{"size": "S"}, nil),
+		"empty sku":            NewProductVariant(" ", map[string]string{"size": "XL"}, nil),
+		"empty attribute":      NewProductVariant("TEE-XL", map[string]string{"size": ""}, nil),
+		"override in currency": NewProductVariant("TEE-XL", map[string]string{"size": "XL"}, &euro),
+	} {
+		assert.ErrorIs(t, product.AddVariant(variant), ErrValidation, name)
+	}
+	assert.Len(t, product.Variants, 3, "rejected variants are not added")
+
+	require.NoError(t, product.UpdateVariant(medium.Id, "TEE-M", map[string]string{"size": "M", "colour": "red"}, nil))
+	changed, ok := product.PullEvents()[0].(events.ProductVariantChanged)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"size": "M", "colour": "red"}, changed.Variant.Attributes)
+	require.NoError(t, product.UpdateVariant(medium.Id, "TEE-M", map[string]string{"colour": "red", "size": "M"}, nil))
+	assert.Empty(t, product.PullEvents(), "the same variant is no change")
+	assert.ErrorIs(t, product.UpdateVariant(medium.Id, "TEE-L", nil, nil), ErrValidation)
+	assert.ErrorIs(t, product.UpdateVariant(uuid.New(), "TEE-X", nil, nil), ErrVariantNotFound)
+
+	require.NoError(t, product.RemoveVariant(small.Id))
+	require.NoError(t, product.RemoveVariant(medium.Id))
+	removed, ok := product.PullEvents()[1].(events.ProductVariantRemoved)
+	require.True(t, ok)
+	assert.Equal(t, "TEE-M", removed.Sku)
+	assert.ErrorIs(t, product.RemoveVariant(large.Id), ErrValidation, "the last variant stays")
+	assert.ErrorIs(t, product.RemoveVariant(small.Id), ErrVariantNotFound)
+}
+
+func TestProduct_ArchivedVariantsCannotBeRepriced(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Test Seller"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+	require.NoError(t, product.Archive())
+	variant := product.Variants[0]
+	override := mustMoney(t, 1299, USD)
+
+	assert.ErrorIs(t, product.UpdateVariant(variant.Id, variant.Sku, nil, &override), ErrProductArchived)
+	require.NoError(t, product.UpdateVariant(variant.Id, "WIDGET-1", nil, nil), "the SKU can still change")
+}
+
+func TestProduct_Images(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Test Seller"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+	product.PullEvents()
+
+	_, err = NewProductImage(product.Id, "application/pdf", 10)
+	assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+	_, err = NewProductImage(product.Id, "image/png", 0)
+	assert.ErrorIs(t, err, ErrValidation)
+
+	front, err := NewProductImage(product.Id, "image/png", 10)
+	require.NoError(t, err)
+	assert.Equal(t, "products/"+product.Id.String()+"/images/"+front.Id.String()+".png", front.BlobKey)
+	back, err := NewProductImage(product.Id, "image/jpeg", 20)
+	require.NoError(t, err)
+	side, err := NewProductImage(product.Id, "image/webp", 30)
+	require.NoError(t, err)
+
+	require.NoError(t, product.AddImage(front, false))
+	require.NoError(t, product.AddImage(back, false))
+	primary, ok := product.PrimaryImage()
+	require.True(t, ok)
+	assert.Equal(t, front.Id, primary.Id, "the first image becomes primary")
+	require.NoError(t, product.AddImage(side, true))
+	primary, _ = product.PrimaryImage()
+	assert.Equal(t, side.Id, primary.Id)
+	added, ok := product.PullEvents()[2].(events.ProductImageAdded)
+	require.True(t, ok)
+	assert.Equal(t, side.BlobKey, added.Image.BlobKey)
+	assert.Equal(t, []uuid.UUID{front.Id, back.Id, side.Id}, added.ImageIds)
+	assert.Equal(t, side.Id, added.PrimaryImageId)
+
+	assert.ErrorIs(t, product.ReorderImages([]uuid.UUID{front.Id, back.Id}), ErrValidation, "every image must be named")
+	assert.ErrorIs(t, product.ReorderImages([]uuid.UUID{front.Id, back.Id, uuid.New()}), ErrImageNotFound)
+	assert.ErrorIs(t, product.ReorderImages([]uuid.UUID{front.Id, back.Id, back.Id}), ErrValidation)
+	require.NoError(t, product.ReorderImages([]uuid.UUID{side.Id, front.Id, back.Id}))
+	require.NoError(t, product.ReorderImages([]uuid.UUID{side.Id, front.Id, back.Id}))
+	require.Len(t, product.PullEvents(), 1, "the same order is no change")
+
+	require.NoError(t, product.SetPrimaryImage(back.Id))
+	require.NoError(t, product.SetPrimaryImage(back.Id))
+	arranged, ok := product.PullEvents()[0].(events.ProductImagesArranged)
+	require.True(t, ok)
+	assert.Equal(t, back.Id, arranged.PrimaryImageId)
+	assert.ErrorIs(t, product.SetPrimaryImage(uuid.New()), ErrImageNotFound)
+
+	require.NoError(t, product.RemoveImage(back.Id))
+	removed, ok := product.PullEvents()[0].(events.ProductImageRemoved)
+	require.True(t, ok)
+	assert.Equal(t, back.BlobKey, removed.BlobKey)
+	assert.Equal(t, side.Id, removed.PrimaryImageId, "the next image in order becomes primary")
+	assert.ErrorIs(t, product.RemoveImage(back.Id), ErrImageNotFound)
+
+	for len(product.Images) < MaxProductImages {
+		image, err := NewProductImage(product.Id, "image/gif", 1)
+		require.NoError(t, err)
+		require.NoError(t, product.AddImage(image, false))
+	}
+	extra, err := NewProductImage(product.Id, "image/gif", 1)
+	require.NoError(t, err)
+	assert.ErrorIs(t, product.AddImage(extra, false), ErrValidation)
+	assert.Len(t, product.Images, MaxProductImages)
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+// MaxProductImages bounds the number of images of one product.
+const MaxProductImages = 10
+
+// imageExtensions lists the accepted image formats by content type, with
+// the file extension their blobs are stored under.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ProductImage is a picture of the product. The image data lives in a blob
+// store under BlobKey; the product keeps the metadata, the order of its
+// images and which of them is primary.
+type ProductImage struct {
+	Id          uuid.UUID
+	BlobKey     string
+	ContentType string
+	SizeBytes   int64
+	// Primary marks the image shown in listings. A product with images has
+	// exactly one primary image.
+	Primary   bool
+	CreatedAt time.Time
+}
+
+// NewProductImage describes an image uploaded for the product. Only JPEG,
+// PNG, GIF and WebP are accepted; other formats are
+// ErrUnsupportedMediaType.
+func NewProductImage(productId uuid.UUID, contentType string, sizeBytes int64) (ProductImage, error) {
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return ProductImage{}, fmt.Errorf("%w: %q is not a JPEG, PNG, GIF or WebP image", ErrUnsupportedMediaType, contentType)
+	}
+	if sizeBytes <= 0 {
+		return ProductImage{}, fmt.Errorf("%w: image must not be empty", ErrValidation)
+	}
+
+	id := uuid.Must(uuid.NewV7())
+	return ProductImage{
+		Id:          id,
+		BlobKey:     "products/" + productId.String() + "/images/" + id.String() + extension,
+		ContentType: contentType,
+		SizeBytes:   sizeBytes,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// IsImageContentType reports whether contentType is an accepted image
+// format.
+func IsImageContentType(contentType string) bool {
+	_, ok := imageExtensions[contentType]
+	return ok
+}
+
+func (i ProductImage) snapshot() events.ProductImage {
+	return events.ProductImage{
+		Id:          i.Id,
+		BlobKey:     i.BlobKey,
+		ContentType: i.ContentType,
+		SizeBytes:   i.SizeBytes,
+	}
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+// ProductVariant is one sellable version of a product, e.g. the T-shirt in
+// size M and colour red. Variants live inside the product aggregate: the
+// product guards their invariants (unique SKUs and attribute combinations,
+// at least one variant). Stock is kept per variant.
+type ProductVariant struct {
+	Id uuid.UUID
+	// Sku is the seller's stock keeping unit; it is unique across all
+	// products.
+	Sku string
+	// Attributes distinguish the variant from its siblings, e.g.
+	// {"size": "M", "colour": "red"}. The single variant of a product
+	// without options has none.
+	Attributes map[string]string
+	// PriceOverride replaces the product's price for this variant; nil
+	// means the variant sells at the product's price.
+	PriceOverride *Money
+}
+
+// NewProductVariant returns a variant with a new Id. The product validates
+// it when the variant is added.
+func NewProductVariant(sku string, attributes map[string]string, priceOverride *Money) ProductVariant {
+	return ProductVariant{
+		Id:            uuid.Must(uuid.NewV7()),
+		Sku:           sku,
+		Attributes:    cloneAttributes(attributes),
+		PriceOverride: priceOverride,
+	}
+}
+
+// attributeKey is a canonical form of the attributes, so two variants with
+// the same combination map to the same key regardless of map order.
+func (v ProductVariant) attributeKey() string {
+	var key strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(v.Attributes)) {
+		fmt.Fprintf(&key, "%q=%q;", name, v.Attributes[name])
+	}
+	return key.String()
+}
+
+func (v ProductVariant) snapshot() events.ProductVariant {
+	snapshot := events.ProductVariant{
+		Id:         v.Id,
+		Sku:        v.Sku,
+		Attributes: cloneAttributes(v.Attributes),
+	}
+	if v.PriceOverride != nil {
+		override := moneySnapshot(*v.PriceOverride)
+		snapshot.PriceOverride = &override
+	}
+	return snapshot
+}
+
+func cloneAttributes(attributes map[string]string) map[string]string {
+	if attributes == nil {
+		return map[string]string{}
+	}
+	return maps.Clone(attributes)
+}
@@ -0,0 +1,242 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+type DiscountKind string
+
+const (
+	DiscountPercentage  DiscountKind = "percentage"
+	DiscountFixedAmount DiscountKind = "fixed_amount"
+)
+
+// Discount is what a promotion takes off a price: BasisPoints hundredths
+// of a percent for a percentage discount (1250 is 12.5%), or Amount for a
+// fixed-amount one.
+type Discount struct {
+	Kind        DiscountKind
+	BasisPoints int64
+	Amount      Money
+}
+
+func NewPercentageDiscount(basisPoints int64) Discount {
+	return Discount{Kind: DiscountPercentage, BasisPoints: basisPoints}
+}
+
+func NewFixedAmountDiscount(amount Money) Discount {
+	return Discount{Kind: DiscountFixedAmount, Amount: amount}
+}
+
+func (d Discount) validate() error {
+	switch d.Kind {
+	case DiscountPercentage:
+		if d.BasisPoints <= 0 || d.BasisPoints > 10000 {
+			return fmt.Errorf("%w: a percentage discount must be between 1 and 10000 basis points", ErrValidation)
+		}
+	case DiscountFixedAmount:
+		if d.Amount.MinorUnits() == 0 {
+			return fmt.Errorf("%w: a fixed-amount discount must be greater than 0", ErrValidation)
+		}
+	default:
+		return fmt.Errorf("%w: unknown discount kind %q", ErrValidation, d.Kind)
+	}
+
+	return nil
+}
+
+// Apply returns price less the discount, never below zero. Percentage
+// discounts are rounded half up to the minor unit; a fixed amount in
+// another currency than price is ErrCurrencyMismatch.
+func (d Discount) Apply(price Money) (Money, error) {
+	var discount Money
+	switch d.Kind {
+	case DiscountPercentage:
+		var err error
+		if discount, err = price.Percentage(d.BasisPoints, RoundHalfUp); err != nil {
+			return Money{}, err
+		}
+	case DiscountFixedAmount:
+		discount = d.Amount
+	default:
+		return Money{}, fmt.Errorf("%w: unknown discount kind %q", ErrValidation, d.Kind)
+	}
+
+	cmp, err := discount.Compare(price)
+	if err != nil {
+		return Money{}, err
+	}
+	if cmp >= 0 {
+		return NewMoney(0, price.Currency())
+	}
+
+	return price.Subtract(discount)
+}
+
+func (d Discount) snapshot() events.Discount {
+	snapshot := events.Discount{Kind: string(d.Kind), BasisPoints: d.BasisPoints}
+	if d.Kind == DiscountFixedAmount {
+		amount := moneySnapshot(d.Amount)
+		snapshot.Amount = &amount
+	}
+	return snapshot
+}
+
+// PromotionTerms are the conditions under which a promotion applies.
+type PromotionTerms struct {
+	// ProductIds limits the promotion to these products of the seller;
+	// empty applies it to all of them.
+	ProductIds []uuid.UUID
+	StartsAt   time.Time
+	// EndsAt is exclusive; nil runs the promotion until it is ended.
+	EndsAt *time.Time
+	// Stackable promotions combine with each other; a promotion that is not
+	// stackable only ever applies alone.
+	Stackable bool
+	// UsageLimit caps the redemptions; 0 means unlimited.
+	UsageLimit int
+}
+
+// Promotion is a seller's discount on some or all of their products for a
+// period of time.
+type Promotion struct {
+	Id       uuid.UUID
+	SellerId uuid.UUID
+	Name     string
+	Discount Discount
+	PromotionTerms
+	UsageCount int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// Version is incremented on every persisted change and guards against
+	// lost updates (optimistic concurrency).
+	Version int
+
+	domainEvents []events.DomainEvent
+}
+
+func NewPromotion(sellerId uuid.UUID, name string, discount Discount, terms PromotionTerms) *Promotion {
+	promotion := &Promotion{
+		Id:             uuid.Must(uuid.NewV7()),
+		SellerId:       sellerId,
+		Name:           name,
+		Discount:       discount,
+		PromotionTerms: terms,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+		Version:        1,
+	}
+
+	promotion.recordEvent(events.NewPromotionCreated(promotion.Id, sellerId, name, discount.snapshot(),
+		terms.ProductIds, terms.StartsAt, terms.EndsAt, terms.Stackable, terms.UsageLimit))
+
+	return promotion
+}
+
+func (p *Promotion) recordEvent(event events.DomainEvent) {
+	p.domainEvents = append(p.domainEvents, event)
+}
+
+// PullEvents returns the recorded domain events and clears them. The
+// repository persists them in the same transaction as the aggregate
+// (transactional outbox), so callers pull exactly once per save.
+func (p *Promotion) PullEvents() []events.DomainEvent {
+	pulled := p.domainEvents
+	p.domainEvents = nil
+	return pulled
+}
+
+func (p *Promotion) validate() error {
+	if p.SellerId == uuid.Nil {
+		return fmt.Errorf("%w: seller id must not be empty", ErrValidation)
+	}
+	if p.Name == "" {
+		return fmt.Errorf("%w: name must not be empty", ErrValidation)
+	}
+	if err := p.Discount.validate(); err != nil {
+		return err
+	}
+	if p.StartsAt.IsZero() {
+		return fmt.Errorf("%w: starts_at must be set", ErrValidation)
+	}
+	if p.EndsAt != nil && p.EndsAt.Before(p.StartsAt) {
+		return fmt.Errorf("%w: ends_at must not be before starts_at", ErrValidation)
+	}
+	if p.UsageLimit < 0 {
+		return fmt.Errorf("%w: usage limit must not be negative", ErrValidation)
+	}
+	if p.UsageCount < 0 || (p.UsageLimit > 0 && p.UsageCount > p.UsageLimit) {
+		return fmt.Errorf("%w: usage count must be between 0 and the usage limit", ErrValidation)
+	}
+	for index, productId := range p.ProductIds {
+		if productId == uuid.Nil {
+			return fmt.Errorf("%w: product id must not be empty", ErrValidation)
+		}
+		if slices.Contains(p.ProductIds[:index], productId) {
+			return fmt.Errorf("%w: product %s is listed twice", ErrValidation, productId)
+		}
+	}
+	if p.CreatedAt.After(p.UpdatedAt) {
+		return fmt.Errorf("%w: created_at must be before updated_at", ErrValidation)
+	}
+
+	return nil
+}
+
+// IsActive reports whether the promotion runs at at and has redemptions
+// left.
+func (p *Promotion) IsActive(at time.Time) bool {
+	if at.Before(p.StartsAt) || (p.EndsAt != nil && !at.Before(*p.EndsAt)) {
+		return false
+	}
+	return p.UsageLimit == 0 || p.UsageCount < p.UsageLimit
+}
+
+// AppliesTo reports whether the promotion covers product, regardless of
+// time.
+func (p *Promotion) AppliesTo(product *Product) bool {
+	if product.SellerId != p.SellerId {
+		return false
+	}
+	return len(p.ProductIds) == 0 || slices.Contains(p.ProductIds, product.Id)
+}
+
+// End stops the promotion at at. Ending a promotion that already ended, or
+// will end earlier, changes nothing.
+func (p *Promotion) End(at time.Time) error {
+	if p.EndsAt != nil && !p.EndsAt.After(at) {
+		return nil
+	}
+	// A promotion ended before it starts never runs.
+	endsAt := at
+	if endsAt.Before(p.StartsAt) {
+		endsAt = p.StartsAt
+	}
+	p.EndsAt = &endsAt
+	p.UpdatedAt = time.Now()
+
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	p.recordEvent(events.NewPromotionEnded(p.Id, endsAt))
+	return nil
+}
+
+// Redeem counts one use of the promotion at at. A promotion that is not
+// running or has no redemptions left is ErrPromotionExhausted.
+func (p *Promotion) Redeem(at time.Time) error {
+	if !p.IsActive(at) {
+		return ErrPromotionExhausted
+	}
+	p.UsageCount++
+	p.UpdatedAt = time.Now()
+
+	p.recordEvent(events.NewPromotionRedeemed(p.Id, p.UsageCount, p.UsageLimit))
+	return nil
+}
@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var promotionStart = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestPromotion(t *testing.T, sellerId uuid.UUID, discount Discount, terms PromotionTerms) *Promotion {
+	t.Helper()
+	if terms.StartsAt.IsZero() {
+		terms.StartsAt = promotionStart
+	}
+	promotion := NewPromotion(sellerId, "Spring sale", discount, terms)
+	_, err := NewValidatedPromotion(promotion)
+	require.NoError(t, err)
+	return promotion
+}
+
+func TestDiscount_Apply(t *testing.T) {
+	testCases := []struct {
+		name     string
+		discount Discount
+		price    Money
+		expected int64
+	}{
+		{"percentage", NewPercentageDiscount(1000), mustMoney(t, 999, USD), 899},
+		{"percentage rounds the discount half up", NewPercentageDiscount(5000), mustMoney(t, 5, USD), 2},
+		{"whole price", NewPercentageDiscount(10000), mustMoney(t, 999, USD), 0},
+		{"fixed amount", NewFixedAmountDiscount(mustMoney(t, 200, USD)), mustMoney(t, 999, USD), 799},
+		{"fixed amount above the price", NewFixedAmountDiscount(mustMoney(t, 2000, USD)), mustMoney(t, 999, USD), 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, err := tc.discount.Apply(tc.price)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, price.MinorUnits())
+			assert.Equal(t, tc.price.Currency(), price.Currency())
+		})
+	}
+
+	_, err := NewFixedAmountDiscount(mustMoney(t, 200, EUR)).Apply(mustMoney(t, 999, USD))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestNewValidatedPromotion_Invalid(t *testing.T) {
+	sellerId := uuid.New()
+	productId := uuid.New()
+	before := promotionStart.Add(-time.Hour)
+
+	testCases := []struct {
+		name      string
+		sellerId  uuid.UUID
+		promoName string
+		discount  Discount
+		terms     PromotionTerms
+	}{
+		{"no seller", uuid.Nil, "Sale", NewPercentageDiscount(1000), PromotionTerms{StartsAt: promotionStart}},
+		{"no name", sellerId, "", NewPercentageDiscount(1000), PromotionTerms{StartsAt: promotionStart}},
+		{"zero percentage", sellerId, "Sale", NewPercentageDiscount(0), PromotionTerms{StartsAt: promotionStart}},
+		{"over 100 percent", sellerId, "Sale", NewPercentageDiscount(10001), PromotionTerms{StartsAt: promotionStart}},
+		{"zero amount", sellerId, "Sale", NewFixedAmountDiscount(mustMoney(t, 0, USD)), PromotionTerms{StartsAt: promotionStart}},
+		{"unknown kind", sellerId, "Sale", Discount{Kind: "bogo"}, PromotionTerms{StartsAt: promotionStart}},
+		{"no start", sellerId, "Sale", NewPercentageDiscount(1000), PromotionTerms{}},
+		{"ends before it starts", sellerId, "Sale", NewPercentageDiscount(1000), PromotionTerms{StartsAt: promotionStart, EndsAt: &before}},
+		{"negative usage limit", sellerId, "Sale", NewPercentageDiscount(1000), PromotionTerms{StartsAt: promotionStart, UsageLimit: -1}},
+		{"duplicate product", sellerId, "Sale", NewPercentageDiscount(1000), PromotionTerms{StartsAt: promotionStart, ProductIds: []uuid.UUID{productId, productId}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewValidatedPromotion(NewPromotion(tc.sellerId, tc.promoName, tc.discount, tc.terms))
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
+
+func TestPromotion_IsActiveAndAppliesTo(t *testing.T) {
+	seller, err := NewValidatedSeller(NewSeller("Acme"))
+	require.NoError(t, err)
+	product := NewProduct("Widget", mustMoney(t, 999, USD), *seller)
+	other := NewProduct("Gadget", mustMoney(t, 999, USD), *seller)
+	endsAt := promotionStart.Add(24 * time.Hour)
+
+	promotion := newTestPromotion(t, seller.Id, NewPercentageDiscount(1000), PromotionTerms{
+		ProductIds: []uuid.UUID{product.Id},
+		EndsAt:     &endsAt,
+	})
+
+	assert.False(t, promotion.IsActive(promotionStart.Add(-time.Second)))
+	assert.True(t, promotion.IsActive(promotionStart))
+	assert.False(t, promotion.IsActive(endsAt))
+	assert.True(t, promotion.AppliesTo(product))
+	assert.False(t, promotion.AppliesTo(other))
+
+	storewide := newTestPromotion(t, seller.Id, NewPercentageDiscount(1000), PromotionTerms{})
+	assert.True(t, storewide.AppliesTo(other))
+	assert.False(t, newTestPromotion(t, uuid.New(), NewPercentageDiscount(1000), PromotionTerms{}).AppliesTo(product))
+}
+
+func TestPromotion_Redeem(t *testing.T) {
+	promotion := newTestPromotion(t, uuid.New(), NewPercentageDiscount(1000), PromotionTerms{UsageLimit: 2})
+	at := promotionStart.Add(time.Hour)
+
+	require.NoError(t, promotion.Redeem(at))
+	require.NoError(t, promotion.Redeem(at))
+	assert.Equal(t, 2, promotion.UsageCount)
+	assert.False(t, promotion.IsActive(at))
+	assert.ErrorIs(t, promotion.Redeem(at), ErrPromotionExhausted)
+
+	notStarted := newTestPromotion(t, uuid.New(), NewPercentageDiscount(1000), PromotionTerms{})
+	assert.ErrorIs(t, notStarted.Redeem(promotionStart.Add(-time.Hour)), ErrPromotionExhausted)
+}
+
+func TestPromotion_End(t *testing.T) {
+	promotion := newTestPromotion(t, uuid.New(), NewPercentageDiscount(1000), PromotionTerms{})
+	endsAt := promotionStart.Add(time.Hour)
+
+	require.NoError(t, promotion.End(endsAt))
+	require.NotNil(t, promotion.EndsAt)
+	assert.Equal(t, endsAt, *promotion.EndsAt)
+	assert.False(t, promotion.IsActive(endsAt))
+
+	// Ending later does not extend it.
+	require.NoError(t, promotion.End(endsAt.Add(time.Hour)))
+	assert.Equal(t, endsAt, *promotion.EndsAt)
+
+	// Ending before the start leaves an empty window.
+	upcoming := newTestPromotion(t, uuid.New(), NewPercentageDiscount(1000), PromotionTerms{})
+	require.NoError(t, upcoming.End(promotionStart.Add(-time.Hour)))
+	assert.Equal(t, promotionStart, *upcoming.EndsAt)
+	assert.False(t, upcoming.IsActive(promotionStart))
+}
+
+func TestPromotion_RecordsEvents(t *testing.T) {
+	promotion := newTestPromotion(t, uuid.New(), NewFixedAmountDiscount(mustMoney(t, 100, USD)), PromotionTerms{})
+	pulled := promotion.PullEvents()
+	require.Equal(t, []string{events.PromotionCreatedEventName}, eventNames(pulled))
+	created := pulled[0].(events.PromotionCreated)
+	assert.Equal(t, "fixed_amount", created.Discount.Kind)
+	require.NotNil(t, created.Discount.Amount)
+	assert.Equal(t, int64(100), created.Discount.Amount.MinorUnits)
+
+	require.NoError(t, promotion.Redeem(promotionStart))
+	require.NoError(t, promotion.End(promotionStart.Add(time.Hour)))
+	assert.Equal(t, []string{events.PromotionRedeemedEventName, events.PromotionEndedEventName}, eventNames(promotion.PullEvents()))
+	assert.Empty(t, promotion.PullEvents())
+}
@@ -5,22 +5,58 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+)
+
+// VerificationStatus is the outcome of the seller's identity (KYC) check,
+// which an external system performs.
+type VerificationStatus string
+
+const (
+	SellerUnverified           VerificationStatus = "unverified"
+	SellerVerified             VerificationStatus = "verified"
+	SellerVerificationRejected VerificationStatus = "rejected"
 )
 
 type Seller struct {
-	Id        uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Name      string
+	Id                 uuid.UUID
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Name               string
+	VerificationStatus VerificationStatus
+	// Version is incremented on every persisted change and guards against
+	// lost updates (optimistic concurrency).
+	Version int
+
+	domainEvents []events.DomainEvent
 }
 
 func NewSeller(name string) *Seller {
-	return &Seller{
-		Id:        uuid.Must(uuid.NewV7()),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-		Name:      name,
+	seller := &Seller{
+		Id:                 uuid.Must(uuid.NewV7()),
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+		Name:               name,
+		VerificationStatus: SellerUnverified,
+		Version:            1,
 	}
+
+	seller.recordEvent(events.NewSellerCreated(seller.Id, name))
+
+	return seller
+}
+
+func (s *Seller) recordEvent(event events.DomainEvent) {
+	s.domainEvents = append(s.domainEvents, event)
+}
+
+// PullEvents returns the recorded domain events and clears them. The
+// repository persists them in the same transaction as the aggregate
+// (transactional outbox), so callers pull exactly once per save.
+func (s *Seller) PullEvents() []events.DomainEvent {
+	pulled := s.domainEvents
+	s.domainEvents = nil
+	return pulled
 }
 
 func (s *Seller) validate() error {
@@ -34,9 +70,45 @@ func (s *Seller) validate() error {
 	return nil
 }
 
+// UpdateName renames the seller; a no-op rename records no event.
 func (s *Seller) UpdateName(name string) error {
+	oldName := s.Name
 	s.Name = name
 	s.UpdatedAt = time.Now()
 
-	return s.validate()
+	if err := s.validate(); err != nil {
+		return err
+	}
+	if name != oldName {
+		s.recordEvent(events.NewSellerRenamed(s.Id, oldName, name))
+	}
+
+	return nil
+}
+
+// ApplyVerification records the result of an identity check. Checks can be
+// repeated (e.g. after a seller updates their documents), so any result may
+// follow any other; repeating the current one records no event.
+func (s *Seller) ApplyVerification(status VerificationStatus) error {
+	switch status {
+	case SellerVerified, SellerVerificationRejected:
+	default:
+		return fmt.Errorf("%w: unknown verification result %q", ErrValidation, status)
+	}
+	if status == s.VerificationStatus {
+		return nil
+	}
+
+	oldStatus := s.VerificationStatus
+	s.VerificationStatus = status
+	s.UpdatedAt = time.Now()
+	s.recordEvent(events.NewSellerVerificationChanged(s.Id, string(oldStatus), string(status)))
+
+	return nil
+}
+
+// Delete records the seller's removal. The repository performs the soft
+// delete and stores the event in the same transaction.
+func (s *Seller) Delete() {
+	s.recordEvent(events.NewSellerDeleted(s.Id))
 }
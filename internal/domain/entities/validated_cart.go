@@ -0,0 +1,21 @@
+package entities
+
+type ValidatedCart struct {
+	Cart
+	isValidated bool
+}
+
+func (vc *ValidatedCart) IsValid() bool {
+	return vc.isValidated
+}
+
+func NewValidatedCart(cart *Cart) (*ValidatedCart, error) {
+	if err := cart.validate(); err != nil {
+		return nil, err
+	}
+
+	return &ValidatedCart{
+		Cart:        *cart,
+		isValidated: true,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package entities
+
+type ValidatedCategory struct {
+	Category
+	isValidated bool
+}
+
+func (vc *ValidatedCategory) IsValid() bool {
+	return vc.isValidated
+}
+
+func NewValidatedCategory(category *Category) (*ValidatedCategory, error) {
+	if err := category.validate(); err != nil {
+		return nil, err
+	}
+
+	return &ValidatedCategory{
+		Category:    *category,
+		isValidated: true,
+	}, nil
+}
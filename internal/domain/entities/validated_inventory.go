@@ -0,0 +1,21 @@
+package entities
+
+type ValidatedInventory struct {
+	Inventory
+	isValidated bool
+}
+
+func (vi *ValidatedInventory) IsValid() bool {
+	return vi.isValidated
+}
+
+func NewValidatedInventory(inventory *Inventory) (*ValidatedInventory, error) {
+	if err := inventory.validate(); err != nil {
+		return nil, err
+	}
+
+	return &ValidatedInventory{
+		Inventory:   *inventory,
+		isValidated: true,
+	}, nil
+}
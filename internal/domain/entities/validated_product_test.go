@@ -14,28 +14,35 @@ func TestProductValidation(t *testing.T) {
 	sellerId := uuid.Must(uuid.NewV7())
 
 	// Test valid product
-	validProduct := &Product{Name: "Valid Product", Price: price, SellerId: sellerId}
+	variants := []ProductVariant{NewProductVariant("VALID-1", nil, nil)}
+	validProduct := &Product{Name: "Valid Product", Price: price, SellerId: sellerId, Status: ProductDraft, Variants: variants}
 	if err := validProduct.validate(); err != nil {
 		t.Errorf("Expected product to be valid, but got error: %s", err)
 	}
 
 	// Test product with empty name
-	invalidProduct1 := &Product{Name: "", Price: price, SellerId: sellerId}
+	invalidProduct1 := &Product{Name: "", Price: price, SellerId: sellerId, Status: ProductDraft}
 	if err := invalidProduct1.validate(); err == nil {
 		t.Error("Expected product with empty name to be invalid, but got no error")
 	}
 
 	// Test product with zero price
-	invalidProduct2 := &Product{Name: "Product", Price: Money{}, SellerId: sellerId}
+	invalidProduct2 := &Product{Name: "Product", Price: Money{}, SellerId: sellerId, Status: ProductDraft}
 	if err := invalidProduct2.validate(); err == nil {
 		t.Error("Expected product with zero price to be invalid, but got no error")
 	}
 
 	// Test product without seller
-	invalidProduct3 := &Product{Name: "Product", Price: price}
+	invalidProduct3 := &Product{Name: "Product", Price: price, Status: ProductDraft}
 	if err := invalidProduct3.validate(); err == nil {
 		t.Error("Expected product without seller id to be invalid, but got no error")
 	}
+
+	// Test product without variants
+	invalidProduct4 := &Product{Name: "Product", Price: price, SellerId: sellerId, Status: ProductDraft}
+	if err := invalidProduct4.validate(); err == nil {
+		t.Error("Expected product without variants to be invalid, but got no error")
+	}
 }
 
 func TestNewValidatedProduct(t *testing.T) {
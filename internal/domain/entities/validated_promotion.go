@@ -0,0 +1,21 @@
+package entities
+
+type ValidatedPromotion struct {
+	Promotion
+	isValidated bool
+}
+
+func (vp *ValidatedPromotion) IsValid() bool {
+	return vp.isValidated
+}
+
+func NewValidatedPromotion(promotion *Promotion) (*ValidatedPromotion, error) {
+	if err := promotion.validate(); err != nil {
+		return nil, err
+	}
+
+	return &ValidatedPromotion{
+		Promotion:   *promotion,
+		isValidated: true,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// minWebhookSecretLength keeps secrets out of brute-force range for
+// HMAC-SHA256.
+const minWebhookSecretLength = 16
+
+// webhookEventTypePattern matches an event name ("product.created") or a
+// prefix wildcard ("product.*"); "*" alone is handled separately.
+var webhookEventTypePattern = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_]+)*(\.\*)?$`)
+
+// WebhookSubscription registers URL to receive the domain events matching
+// EventTypes, signed with Secret.
+type WebhookSubscription struct {
+	Id         uuid.UUID
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  time.Time
+}
+
+func NewWebhookSubscription(rawURL string, eventTypes []string, secret string) (*WebhookSubscription, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http(s) URL", ErrValidation)
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("%w: at least one event type is required", ErrValidation)
+	}
+	for _, eventType := range eventTypes {
+		if eventType != "*" && !webhookEventTypePattern.MatchString(eventType) {
+			return nil, fmt.Errorf("%w: event type %q must be an event name, a prefix like \"product.*\", or \"*\"", ErrValidation, eventType)
+		}
+	}
+
+	if len(secret) < minWebhookSecretLength {
+		return nil, fmt.Errorf("%w: secret must be at least %d characters", ErrValidation, minWebhookSecretLength)
+	}
+
+	return &WebhookSubscription{
+		Id:         uuid.Must(uuid.NewV7()),
+		URL:        rawURL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// WebhookDeliveryStatus is derived from a delivery's timestamps.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery tracks one event for one subscription.
+type WebhookDelivery struct {
+	Id             uuid.UUID
+	SubscriptionId uuid.UUID
+	EventId        uuid.UUID
+	EventName      string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	// LastStatusCode is 0 if the last attempt got no response.
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	const secret = "0123456789abcdef"
+
+	subscription, err := NewWebhookSubscription("https://partner.example/hooks", []string{"product.created", "seller.*", "*"}, secret)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"product.created", "seller.*", "*"}, subscription.EventTypes)
+	_, err = NewWebhookSubscription("http://localhost:9000/hooks", []string{"product.price_changed"}, secret)
+	assert.NoError(t, err)
+
+	cases := map[string]struct {
+		url        string
+		eventTypes []string
+		secret     string
+	}{
+		"relative url":      {"/hooks", []string{"*"}, secret},
+		"unsupported proto": {"ftp://partner.example", []string{"*"}, secret},
+		"no event types":    {"https://partner.example", nil, secret},
+		"bad wildcard":      {"https://partner.example", []string{"product*"}, secret},
+		"uppercase name":    {"https://partner.example", []string{"Product.Created"}, secret},
+		"short secret":      {"https://partner.example", []string{"*"}, "short"},
+	}
+	for name, tc := range cases {
+		_, err := NewWebhookSubscription(tc.url, tc.eventTypes, tc.secret)
+		assert.ErrorIs(t, err, ErrValidation, name)
+	}
+}
@@ -0,0 +1,71 @@
+package events
+
+import "github.com/google/uuid"
+
+const (
+	CategoryCreatedEventName = "category.created"
+	CategoryRenamedEventName = "category.renamed"
+	CategoryMovedEventName   = "category.moved"
+	CategoryDeletedEventName = "category.deleted"
+)
+
+type CategoryCreated struct {
+	BaseEvent
+	Name string `json:"name"`
+	// ParentId is nil for a root category.
+	ParentId *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+func NewCategoryCreated(categoryId uuid.UUID, name string, parentId *uuid.UUID) CategoryCreated {
+	return CategoryCreated{
+		BaseEvent: NewBaseEvent(categoryId),
+		Name:      name,
+		ParentId:  parentId,
+	}
+}
+
+func (e CategoryCreated) EventName() string { return CategoryCreatedEventName }
+
+type CategoryRenamed struct {
+	BaseEvent
+	OldName string `json:"old_name"`
+	NewName string `json:"new_name"`
+}
+
+func NewCategoryRenamed(categoryId uuid.UUID, oldName, newName string) CategoryRenamed {
+	return CategoryRenamed{
+		BaseEvent: NewBaseEvent(categoryId),
+		OldName:   oldName,
+		NewName:   newName,
+	}
+}
+
+func (e CategoryRenamed) EventName() string { return CategoryRenamedEventName }
+
+// CategoryMoved is raised when a category gets a new parent; its
+// descendants move along with it. A nil parent id is the root level.
+type CategoryMoved struct {
+	BaseEvent
+	OldParentId *uuid.UUID `json:"old_parent_id,omitempty"`
+	NewParentId *uuid.UUID `json:"new_parent_id,omitempty"`
+}
+
+func NewCategoryMoved(categoryId uuid.UUID, oldParentId, newParentId *uuid.UUID) CategoryMoved {
+	return CategoryMoved{
+		BaseEvent:   NewBaseEvent(categoryId),
+		OldParentId: oldParentId,
+		NewParentId: newParentId,
+	}
+}
+
+func (e CategoryMoved) EventName() string { return CategoryMovedEventName }
+
+type CategoryDeleted struct {
+	BaseEvent
+}
+
+func NewCategoryDeleted(categoryId uuid.UUID) CategoryDeleted {
+	return CategoryDeleted{BaseEvent: NewBaseEvent(categoryId)}
+}
+
+func (e CategoryDeleted) EventName() string { return CategoryDeletedEventName }
@@ -14,14 +14,22 @@ type DomainEvent interface {
 	OccurredAt() time.Time
 	// AggregateId identifies the aggregate the event belongs to.
 	AggregateId() uuid.UUID
+	// SchemaVersion is the version of the event's serialized data. Bump it
+	// (by overriding BaseEvent's) on incompatible changes to the fields.
+	SchemaVersion() int
 }
 
 // BaseEvent carries the fields shared by all domain events. Embed it and
 // implement EventName on the concrete event.
+//
+// Its fields are envelope metadata, not event data: they are stored in and
+// published from dedicated outbox columns, so they stay out of the JSON.
+// Concrete events tag every field with an explicit snake_case name; those
+// names are a public contract with consumers.
 type BaseEvent struct {
-	Id          uuid.UUID
-	Aggregate   uuid.UUID
-	OccurredAtT time.Time
+	Id          uuid.UUID `json:"-"`
+	Aggregate   uuid.UUID `json:"-"`
+	OccurredAtT time.Time `json:"-"`
 }
 
 func NewBaseEvent(aggregateId uuid.UUID) BaseEvent {
@@ -35,3 +43,4 @@ func NewBaseEvent(aggregateId uuid.UUID) BaseEvent {
 func (e BaseEvent) EventId() uuid.UUID     { return e.Id }
 func (e BaseEvent) AggregateId() uuid.UUID { return e.Aggregate }
 func (e BaseEvent) OccurredAt() time.Time  { return e.OccurredAtT }
+func (e BaseEvent) SchemaVersion() int     { return 1 }
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -12,13 +13,17 @@ func TestNewProductCreated(t *testing.T) {
 	productId := uuid.New()
 	sellerId := uuid.New()
 
-	event := NewProductCreated(productId, "Widget", 999, "USD", sellerId)
+	variants := []ProductVariant{{Id: uuid.New(), Sku: "WIDGET-RED", Attributes: map[string]string{"color": "red"}}}
+
+	event := NewProductCreated(productId, "Widget", 999, "USD", sellerId, "draft", variants)
 
 	assert.Equal(t, "product.created", event.EventName())
 	assert.Equal(t, productId, event.AggregateId())
 	assert.Equal(t, sellerId, event.SellerId)
 	assert.Equal(t, int64(999), event.PriceMinorUnits)
 	assert.Equal(t, "USD", event.Currency)
+	assert.Equal(t, "draft", event.Status)
+	assert.Equal(t, variants, event.Variants)
 	assert.NotEqual(t, uuid.Nil, event.EventId())
 	assert.WithinDuration(t, time.Now(), event.OccurredAt(), time.Second)
 }
@@ -31,3 +36,202 @@ func TestBaseEvent_UniqueIds(t *testing.T) {
 
 	assert.NotEqual(t, first.EventId(), second.EventId())
 }
+
+func TestProductEvents_Names(t *testing.T) {
+	productId := uuid.New()
+	oldSellerId, newSellerId := uuid.New(), uuid.New()
+
+	renamed := NewProductRenamed(productId, "Old", "New")
+	assert.Equal(t, "product.renamed", renamed.EventName())
+	assert.Equal(t, productId, renamed.AggregateId())
+	assert.Equal(t, "Old", renamed.OldName)
+	assert.Equal(t, "New", renamed.NewName)
+
+	priceChanged := NewProductPriceChanged(productId, Money{MinorUnits: 999, Currency: "USD"}, Money{MinorUnits: 1299, Currency: "USD"})
+	assert.Equal(t, "product.price_changed", priceChanged.EventName())
+	assert.Equal(t, int64(999), priceChanged.OldPrice.MinorUnits)
+	assert.Equal(t, int64(1299), priceChanged.NewPrice.MinorUnits)
+
+	reassigned := NewProductReassigned(productId, oldSellerId, newSellerId)
+	assert.Equal(t, "product.reassigned", reassigned.EventName())
+	assert.Equal(t, oldSellerId, reassigned.OldSellerId)
+	assert.Equal(t, newSellerId, reassigned.NewSellerId)
+
+	deleted := NewProductDeleted(productId, oldSellerId)
+	assert.Equal(t, "product.deleted", deleted.EventName())
+	assert.Equal(t, productId, deleted.AggregateId())
+
+	published := NewProductPublished(productId, oldSellerId)
+	assert.Equal(t, "product.published", published.EventName())
+	assert.Equal(t, oldSellerId, published.SellerId)
+
+	archived := NewProductArchived(productId, oldSellerId)
+	assert.Equal(t, "product.archived", archived.EventName())
+	assert.Equal(t, productId, archived.AggregateId())
+}
+
+func TestSellerEvents_Names(t *testing.T) {
+	sellerId := uuid.New()
+
+	created := NewSellerCreated(sellerId, "Acme")
+	assert.Equal(t, "seller.created", created.EventName())
+	assert.Equal(t, sellerId, created.AggregateId())
+	assert.Equal(t, "Acme", created.Name)
+
+	renamed := NewSellerRenamed(sellerId, "Acme", "Acme Corp")
+	assert.Equal(t, "seller.renamed", renamed.EventName())
+	assert.Equal(t, "Acme Corp", renamed.NewName)
+
+	deleted := NewSellerDeleted(sellerId)
+	assert.Equal(t, "seller.deleted", deleted.EventName())
+	assert.Equal(t, sellerId, deleted.AggregateId())
+
+	verified := NewSellerVerificationChanged(sellerId, "unverified", "verified")
+	assert.Equal(t, "seller.verification_changed", verified.EventName())
+	assert.Equal(t, "verified", verified.NewStatus)
+}
+
+func TestInventoryEvents_Names(t *testing.T) {
+	productId, variantId, reservationId := uuid.New(), uuid.New(), uuid.New()
+
+	for event, name := range map[DomainEvent]string{
+		NewStockAdjusted(productId, variantId, 5, 5, "delivery"):             "inventory.stock_adjusted",
+		NewStockReserved(productId, variantId, reservationId, 2, time.Now()): "inventory.stock_reserved",
+		NewStockReleased(productId, variantId, reservationId, 2, "expired"):  "inventory.stock_released",
+		NewStockCommitted(productId, variantId, reservationId, 2, 3):         "inventory.stock_committed",
+		NewOutOfStock(productId, variantId, 2, 2):                            "inventory.out_of_stock",
+	} {
+		assert.Equal(t, name, event.EventName())
+		assert.Equal(t, variantId, event.AggregateId(), name)
+	}
+}
+
+func TestOrderEvents_Names(t *testing.T) {
+	orderId := uuid.New()
+
+	for _, tc := range []struct {
+		event DomainEvent
+		name  string
+	}{
+		{NewOrderCreated(orderId, uuid.New(), nil, nil), "order.created"},
+		{NewOrderPaid(orderId, nil), "order.paid"},
+		{NewOrderShipped(orderId, "1Z999"), "order.shipped"},
+		{NewOrderDelivered(orderId), "order.delivered"},
+		{NewOrderCancelled(orderId, ""), "order.cancelled"},
+		{NewOrderRefunded(orderId, nil, ""), "order.refunded"},
+	} {
+		assert.Equal(t, tc.name, tc.event.EventName())
+		assert.Equal(t, orderId, tc.event.AggregateId(), tc.name)
+	}
+}
+
+func TestEvents_SerializeDataOnlyWithSnakeCaseFields(t *testing.T) {
+	productId, sellerId, reservationId := uuid.New(), uuid.New(), uuid.New()
+	orderId, buyerId, variantId := uuid.New(), uuid.New(), uuid.New()
+	price := Money{MinorUnits: 999, Currency: "USD"}
+	item := OrderItem{ProductId: productId, VariantId: variantId, Sku: "WIDGET-RED", ProductName: "Widget", UnitPrice: price, Quantity: 2}
+	variant := ProductVariant{Id: variantId, Sku: "WIDGET-RED", Attributes: map[string]string{"color": "red"}, PriceOverride: &price}
+	variantJSON := `{"id":"` + variantId.String() + `","sku":"WIDGET-RED","attributes":{"color":"red"},"price_override":{"minor_units":999,"currency":"USD"}}`
+	v := `"variant_id":"` + variantId.String() + `",`
+	pv := `"product_id":"` + productId.String() + `",` + v
+	expiresAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[DomainEvent]string{
+		NewProductPublished(productId, sellerId):                               `{"seller_id":"` + sellerId.String() + `"}`,
+		NewProductArchived(productId, sellerId):                                `{"seller_id":"` + sellerId.String() + `"}`,
+		NewProductRenamed(productId, "Old", "New"):                             `{"old_name":"Old","new_name":"New"}`,
+		NewProductPriceChanged(productId, price, price):                        `{"old_price":{"minor_units":999,"currency":"USD"},"new_price":{"minor_units":999,"currency":"USD"}}`,
+		NewProductReassigned(productId, sellerId, sellerId):                    `{"old_seller_id":"` + sellerId.String() + `","new_seller_id":"` + sellerId.String() + `"}`,
+		NewProductDeleted(productId, sellerId):                                 `{"seller_id":"` + sellerId.String() + `"}`,
+		NewSellerCreated(sellerId, "Acme"):                                     `{"name":"Acme"}`,
+		NewSellerRenamed(sellerId, "Acme", "Acme Corp"):                        `{"old_name":"Acme","new_name":"Acme Corp"}`,
+		NewSellerDeleted(sellerId):                                             `{}`,
+		NewSellerVerificationChanged(sellerId, "unverified", "verified"):       `{"old_status":"unverified","new_status":"verified"}`,
+		NewStockAdjusted(productId, variantId, -2, 3, "stock count"):           `{` + pv + `"delta":-2,"on_hand":3,"reason":"stock count"}`,
+		NewStockReserved(productId, variantId, reservationId, 2, expiresAt):    `{` + pv + `"reservation_id":"` + reservationId.String() + `","quantity":2,"expires_at":"2026-01-01T12:00:00Z"}`,
+		NewStockReleased(productId, variantId, reservationId, 2, "cancelled"):  `{` + pv + `"reservation_id":"` + reservationId.String() + `","quantity":2,"reason":"cancelled"}`,
+		NewStockCommitted(productId, variantId, reservationId, 2, 1):           `{` + pv + `"reservation_id":"` + reservationId.String() + `","quantity":2,"on_hand":1}`,
+		NewOutOfStock(productId, variantId, 2, 2):                              `{` + pv + `"on_hand":2,"reserved":2}`,
+		NewProductVariantRemoved(productId, sellerId, variantId, "WIDGET-RED"): `{"seller_id":"` + sellerId.String() + `",` + v + `"sku":"WIDGET-RED"}`,
+	}
+
+	assertSerialized := func(event DomainEvent, expected string) {
+		data, err := json.Marshal(event)
+		assert.NoError(t, err)
+		assert.JSONEq(t, expected, string(data), event.EventName())
+		assert.Equal(t, 1, event.SchemaVersion(), event.EventName())
+	}
+	for event, expected := range cases {
+		assertSerialized(event, expected)
+	}
+
+	// Events holding slices are not comparable, so they cannot be map keys.
+	for _, tc := range []struct {
+		event    DomainEvent
+		expected string
+	}{
+		{NewProductCreated(productId, "Widget", 999, "USD", sellerId, "draft", []ProductVariant{variant}), `{"name":"Widget","price_minor_units":999,"currency":"USD","seller_id":"` + sellerId.String() +
+			`","status":"draft","variants":[` + variantJSON + `]}`},
+		{NewProductVariantAdded(productId, sellerId, variant), `{"seller_id":"` + sellerId.String() + `","variant":` + variantJSON + `}`},
+		{NewProductVariantChanged(productId, sellerId, ProductVariant{Id: variantId, Sku: "WIDGET-RED", Attributes: map[string]string{}}),
+			`{"seller_id":"` + sellerId.String() + `","variant":{"id":"` + variantId.String() + `","sku":"WIDGET-RED","attributes":{}}}`},
+		{NewOrderCreated(orderId, buyerId, []OrderItem{item}, []Money{{MinorUnits: 1998, Currency: "USD"}}), `{"buyer_id":"` + buyerId.String() + `","items":[{"product_id":"` + productId.String() +
+			`",` + v + `"sku":"WIDGET-RED","product_name":"Widget","unit_price":{"minor_units":999,"currency":"USD"},"quantity":2}],"totals":[{"minor_units":1998,"currency":"USD"}]}`},
+		{NewOrderPaid(orderId, []Money{price}), `{"totals":[{"minor_units":999,"currency":"USD"}]}`},
+		{NewOrderShipped(orderId, "1Z999"), `{"tracking_number":"1Z999"}`},
+		{NewOrderDelivered(orderId), `{}`},
+		{NewOrderCancelled(orderId, "changed my mind"), `{"reason":"changed my mind"}`},
+		{NewOrderRefunded(orderId, []Money{price}, "damaged"), `{"totals":[{"minor_units":999,"currency":"USD"}],"reason":"damaged"}`},
+	} {
+		assertSerialized(tc.event, tc.expected)
+	}
+}
+
+func TestCategoryEvents_Names(t *testing.T) {
+	categoryId, parentId := uuid.New(), uuid.New()
+
+	created := NewCategoryCreated(categoryId, "Chairs", &parentId)
+	assert.Equal(t, "category.created", created.EventName())
+	assert.Equal(t, categoryId, created.AggregateId())
+	assert.Equal(t, parentId, *created.ParentId)
+
+	assert.Equal(t, "category.renamed", NewCategoryRenamed(categoryId, "Chairs", "Seating").EventName())
+	assert.Equal(t, "category.moved", NewCategoryMoved(categoryId, &parentId, nil).EventName())
+	assert.Equal(t, "category.deleted", NewCategoryDeleted(categoryId).EventName())
+	assert.Equal(t, "product.categorized", NewProductCategorized(uuid.New(), uuid.New(), []uuid.UUID{categoryId}).EventName())
+}
+
+func TestProductVariantEvents_Names(t *testing.T) {
+	productId, sellerId := uuid.New(), uuid.New()
+	variant := ProductVariant{Id: uuid.New(), Sku: "WIDGET-RED"}
+
+	for _, tc := range []struct {
+		event DomainEvent
+		name  string
+	}{
+		{NewProductVariantAdded(productId, sellerId, variant), "product.variant_added"},
+		{NewProductVariantChanged(productId, sellerId, variant), "product.variant_changed"},
+		{NewProductVariantRemoved(productId, sellerId, variant.Id, variant.Sku), "product.variant_removed"},
+	} {
+		assert.Equal(t, tc.name, tc.event.EventName())
+		assert.Equal(t, productId, tc.event.AggregateId(), tc.name)
+	}
+}
+
+func TestProductImageEvents_Names(t *testing.T) {
+	productId, sellerId := uuid.New(), uuid.New()
+	image := ProductImage{Id: uuid.New(), BlobKey: "products/x/images/y.png", ContentType: "image/png", SizeBytes: 12}
+	gallery := ProductGallery{ImageIds: []uuid.UUID{image.Id}, PrimaryImageId: image.Id}
+
+	for _, tc := range []struct {
+		event DomainEvent
+		name  string
+	}{
+		{NewProductImageAdded(productId, sellerId, image, gallery), "product.image_added"},
+		{NewProductImageRemoved(productId, sellerId, image.Id, image.BlobKey, ProductGallery{}), "product.image_removed"},
+		{NewProductImagesArranged(productId, sellerId, gallery), "product.images_arranged"},
+	} {
+		assert.Equal(t, tc.name, tc.event.EventName())
+		assert.Equal(t, productId, tc.event.AggregateId(), tc.name)
+	}
+}
@@ -0,0 +1,135 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Inventory events belong to the inventory of one product variant; their
+// aggregate id is the variant id, as changes to one variant's stock are
+// serialized by its inventory row lock, and product_id names the product.
+const (
+	StockAdjustedEventName  = "inventory.stock_adjusted"
+	StockReservedEventName  = "inventory.stock_reserved"
+	StockReleasedEventName  = "inventory.stock_released"
+	StockCommittedEventName = "inventory.stock_committed"
+	OutOfStockEventName     = "inventory.out_of_stock"
+)
+
+// Reasons a reservation is released.
+const (
+	ReleaseReasonCancelled = "cancelled"
+	ReleaseReasonExpired   = "expired"
+)
+
+type StockAdjusted struct {
+	BaseEvent
+	ProductId uuid.UUID `json:"product_id"`
+	VariantId uuid.UUID `json:"variant_id"`
+	Delta     int       `json:"delta"`
+	OnHand    int       `json:"on_hand"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+func NewStockAdjusted(productId, variantId uuid.UUID, delta, onHand int, reason string) StockAdjusted {
+	return StockAdjusted{
+		BaseEvent: NewBaseEvent(variantId),
+		ProductId: productId,
+		VariantId: variantId,
+		Delta:     delta,
+		OnHand:    onHand,
+		Reason:    reason,
+	}
+}
+
+func (e StockAdjusted) EventName() string { return StockAdjustedEventName }
+
+type StockReserved struct {
+	BaseEvent
+	ProductId     uuid.UUID `json:"product_id"`
+	VariantId     uuid.UUID `json:"variant_id"`
+	ReservationId uuid.UUID `json:"reservation_id"`
+	Quantity      int       `json:"quantity"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+func NewStockReserved(productId, variantId, reservationId uuid.UUID, quantity int, expiresAt time.Time) StockReserved {
+	return StockReserved{
+		BaseEvent:     NewBaseEvent(variantId),
+		ProductId:     productId,
+		VariantId:     variantId,
+		ReservationId: reservationId,
+		Quantity:      quantity,
+		ExpiresAt:     expiresAt,
+	}
+}
+
+func (e StockReserved) EventName() string { return StockReservedEventName }
+
+type StockReleased struct {
+	BaseEvent
+	ProductId     uuid.UUID `json:"product_id"`
+	VariantId     uuid.UUID `json:"variant_id"`
+	ReservationId uuid.UUID `json:"reservation_id"`
+	Quantity      int       `json:"quantity"`
+	Reason        string    `json:"reason"`
+}
+
+func NewStockReleased(productId, variantId, reservationId uuid.UUID, quantity int, reason string) StockReleased {
+	return StockReleased{
+		BaseEvent:     NewBaseEvent(variantId),
+		ProductId:     productId,
+		VariantId:     variantId,
+		ReservationId: reservationId,
+		Quantity:      quantity,
+		Reason:        reason,
+	}
+}
+
+func (e StockReleased) EventName() string { return StockReleasedEventName }
+
+// StockCommitted means reserved units were sold and left the stock.
+type StockCommitted struct {
+	BaseEvent
+	ProductId     uuid.UUID `json:"product_id"`
+	VariantId     uuid.UUID `json:"variant_id"`
+	ReservationId uuid.UUID `json:"reservation_id"`
+	Quantity      int       `json:"quantity"`
+	OnHand        int       `json:"on_hand"`
+}
+
+func NewStockCommitted(productId, variantId, reservationId uuid.UUID, quantity, onHand int) StockCommitted {
+	return StockCommitted{
+		BaseEvent:     NewBaseEvent(variantId),
+		ProductId:     productId,
+		VariantId:     variantId,
+		ReservationId: reservationId,
+		Quantity:      quantity,
+		OnHand:        onHand,
+	}
+}
+
+func (e StockCommitted) EventName() string { return StockCommittedEventName }
+
+// OutOfStock is recorded when the last available unit is reserved or
+// adjusted away; units may still be on hand, held by reservations.
+type OutOfStock struct {
+	BaseEvent
+	ProductId uuid.UUID `json:"product_id"`
+	VariantId uuid.UUID `json:"variant_id"`
+	OnHand    int       `json:"on_hand"`
+	Reserved  int       `json:"reserved"`
+}
+
+func NewOutOfStock(productId, variantId uuid.UUID, onHand, reserved int) OutOfStock {
+	return OutOfStock{
+		BaseEvent: NewBaseEvent(variantId),
+		ProductId: productId,
+		VariantId: variantId,
+		OnHand:    onHand,
+		Reserved:  reserved,
+	}
+}
+
+func (e OutOfStock) EventName() string { return OutOfStockEventName }
@@ -0,0 +1,98 @@
+package events
+
+import "github.com/google/uuid"
+
+const (
+	OrderCreatedEventName   = "order.created"
+	OrderPaidEventName      = "order.paid"
+	OrderShippedEventName   = "order.shipped"
+	OrderDeliveredEventName = "order.delivered"
+	OrderCancelledEventName = "order.cancelled"
+	OrderRefundedEventName  = "order.refunded"
+)
+
+// OrderItem is the event-side snapshot of an order line.
+type OrderItem struct {
+	ProductId   uuid.UUID `json:"product_id"`
+	VariantId   uuid.UUID `json:"variant_id"`
+	Sku         string    `json:"sku"`
+	ProductName string    `json:"product_name"`
+	UnitPrice   Money     `json:"unit_price"`
+	Quantity    int       `json:"quantity"`
+}
+
+type OrderCreated struct {
+	BaseEvent
+	BuyerId uuid.UUID   `json:"buyer_id"`
+	Items   []OrderItem `json:"items"`
+	// Totals holds one amount per currency of the order's lines.
+	Totals []Money `json:"totals"`
+}
+
+func NewOrderCreated(orderId, buyerId uuid.UUID, items []OrderItem, totals []Money) OrderCreated {
+	return OrderCreated{
+		BaseEvent: NewBaseEvent(orderId),
+		BuyerId:   buyerId,
+		Items:     items,
+		Totals:    totals,
+	}
+}
+
+func (e OrderCreated) EventName() string { return OrderCreatedEventName }
+
+type OrderPaid struct {
+	BaseEvent
+	Totals []Money `json:"totals"`
+}
+
+func NewOrderPaid(orderId uuid.UUID, totals []Money) OrderPaid {
+	return OrderPaid{BaseEvent: NewBaseEvent(orderId), Totals: totals}
+}
+
+func (e OrderPaid) EventName() string { return OrderPaidEventName }
+
+type OrderShipped struct {
+	BaseEvent
+	TrackingNumber string `json:"tracking_number,omitempty"`
+}
+
+func NewOrderShipped(orderId uuid.UUID, trackingNumber string) OrderShipped {
+	return OrderShipped{BaseEvent: NewBaseEvent(orderId), TrackingNumber: trackingNumber}
+}
+
+func (e OrderShipped) EventName() string { return OrderShippedEventName }
+
+type OrderDelivered struct {
+	BaseEvent
+}
+
+func NewOrderDelivered(orderId uuid.UUID) OrderDelivered {
+	return OrderDelivered{BaseEvent: NewBaseEvent(orderId)}
+}
+
+func (e OrderDelivered) EventName() string { return OrderDeliveredEventName }
+
+// OrderCancelled means the order was called off before payment.
+type OrderCancelled struct {
+	BaseEvent
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewOrderCancelled(orderId uuid.UUID, reason string) OrderCancelled {
+	return OrderCancelled{BaseEvent: NewBaseEvent(orderId), Reason: reason}
+}
+
+func (e OrderCancelled) EventName() string { return OrderCancelledEventName }
+
+// OrderRefunded means the buyer got the paid amounts back.
+type OrderRefunded struct {
+	BaseEvent
+	Totals []Money `json:"totals"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+func NewOrderRefunded(orderId uuid.UUID, totals []Money, reason string) OrderRefunded {
+	return OrderRefunded{BaseEvent: NewBaseEvent(orderId), Totals: totals, Reason: reason}
+}
+
+func (e OrderRefunded) EventName() string { return OrderRefundedEventName }
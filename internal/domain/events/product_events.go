@@ -2,24 +2,295 @@ package events
 
 import "github.com/google/uuid"
 
-const ProductCreatedEventName = "product.created"
+const (
+	ProductCreatedEventName        = "product.created"
+	ProductRenamedEventName        = "product.renamed"
+	ProductPriceChangedEventName   = "product.price_changed"
+	ProductReassignedEventName     = "product.reassigned"
+	ProductDeletedEventName        = "product.deleted"
+	ProductPublishedEventName      = "product.published"
+	ProductArchivedEventName       = "product.archived"
+	ProductCategorizedEventName    = "product.categorized"
+	ProductVariantAddedEventName   = "product.variant_added"
+	ProductVariantChangedEventName = "product.variant_changed"
+	ProductVariantRemovedEventName = "product.variant_removed"
+	ProductImageAddedEventName     = "product.image_added"
+	ProductImageRemovedEventName   = "product.image_removed"
+	ProductImagesArrangedEventName = "product.images_arranged"
+)
+
+// Money is the event-side snapshot of a price. Events only carry primitive
+// values so they stay serializable and independent of the entities package.
+type Money struct {
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency"`
+}
+
+// ProductVariant is the event-side snapshot of a product variant.
+type ProductVariant struct {
+	Id         uuid.UUID         `json:"id"`
+	Sku        string            `json:"sku"`
+	Attributes map[string]string `json:"attributes"`
+	// PriceOverride is omitted when the variant sells at the product's
+	// price.
+	PriceOverride *Money `json:"price_override,omitempty"`
+}
+
+// ProductImage is the event-side snapshot of a product image.
+type ProductImage struct {
+	Id          uuid.UUID `json:"id"`
+	BlobKey     string    `json:"blob_key"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+}
+
+// ProductGallery is the order of a product's images after a change and
+// which of them is primary; PrimaryImageId is uuid.Nil without images.
+type ProductGallery struct {
+	ImageIds       []uuid.UUID `json:"image_ids"`
+	PrimaryImageId uuid.UUID   `json:"primary_image_id"`
+}
 
 type ProductCreated struct {
 	BaseEvent
-	Name            string
-	PriceMinorUnits int64
-	Currency        string
-	SellerId        uuid.UUID
+	Name            string           `json:"name"`
+	PriceMinorUnits int64            `json:"price_minor_units"`
+	Currency        string           `json:"currency"`
+	SellerId        uuid.UUID        `json:"seller_id"`
+	Status          string           `json:"status"`
+	Variants        []ProductVariant `json:"variants"`
 }
 
-func NewProductCreated(productId uuid.UUID, name string, priceMinorUnits int64, currency string, sellerId uuid.UUID) ProductCreated {
+func NewProductCreated(productId uuid.UUID, name string, priceMinorUnits int64, currency string, sellerId uuid.UUID, status string, variants []ProductVariant) ProductCreated {
 	return ProductCreated{
 		BaseEvent:       NewBaseEvent(productId),
 		Name:            name,
 		PriceMinorUnits: priceMinorUnits,
 		Currency:        currency,
 		SellerId:        sellerId,
+		Status:          status,
+		Variants:        variants,
 	}
 }
 
 func (e ProductCreated) EventName() string { return ProductCreatedEventName }
+
+type ProductRenamed struct {
+	BaseEvent
+	OldName string `json:"old_name"`
+	NewName string `json:"new_name"`
+}
+
+func NewProductRenamed(productId uuid.UUID, oldName, newName string) ProductRenamed {
+	return ProductRenamed{
+		BaseEvent: NewBaseEvent(productId),
+		OldName:   oldName,
+		NewName:   newName,
+	}
+}
+
+func (e ProductRenamed) EventName() string { return ProductRenamedEventName }
+
+type ProductPriceChanged struct {
+	BaseEvent
+	OldPrice Money `json:"old_price"`
+	NewPrice Money `json:"new_price"`
+}
+
+func NewProductPriceChanged(productId uuid.UUID, oldPrice, newPrice Money) ProductPriceChanged {
+	return ProductPriceChanged{
+		BaseEvent: NewBaseEvent(productId),
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+	}
+}
+
+func (e ProductPriceChanged) EventName() string { return ProductPriceChangedEventName }
+
+// ProductReassigned is raised when a product moves to a different seller.
+type ProductReassigned struct {
+	BaseEvent
+	OldSellerId uuid.UUID `json:"old_seller_id"`
+	NewSellerId uuid.UUID `json:"new_seller_id"`
+}
+
+func NewProductReassigned(productId, oldSellerId, newSellerId uuid.UUID) ProductReassigned {
+	return ProductReassigned{
+		BaseEvent:   NewBaseEvent(productId),
+		OldSellerId: oldSellerId,
+		NewSellerId: newSellerId,
+	}
+}
+
+func (e ProductReassigned) EventName() string { return ProductReassignedEventName }
+
+type ProductDeleted struct {
+	BaseEvent
+	SellerId uuid.UUID `json:"seller_id"`
+}
+
+func NewProductDeleted(productId, sellerId uuid.UUID) ProductDeleted {
+	return ProductDeleted{
+		BaseEvent: NewBaseEvent(productId),
+		SellerId:  sellerId,
+	}
+}
+
+func (e ProductDeleted) EventName() string { return ProductDeletedEventName }
+
+// ProductPublished is raised when a product becomes publicly listed.
+type ProductPublished struct {
+	BaseEvent
+	SellerId uuid.UUID `json:"seller_id"`
+}
+
+func NewProductPublished(productId, sellerId uuid.UUID) ProductPublished {
+	return ProductPublished{
+		BaseEvent: NewBaseEvent(productId),
+		SellerId:  sellerId,
+	}
+}
+
+func (e ProductPublished) EventName() string { return ProductPublishedEventName }
+
+// ProductArchived is raised when a product is retired from the listings.
+type ProductArchived struct {
+	BaseEvent
+	SellerId uuid.UUID `json:"seller_id"`
+}
+
+func NewProductArchived(productId, sellerId uuid.UUID) ProductArchived {
+	return ProductArchived{
+		BaseEvent: NewBaseEvent(productId),
+		SellerId:  sellerId,
+	}
+}
+
+func (e ProductArchived) EventName() string { return ProductArchivedEventName }
+
+// ProductCategorized is raised when a product's categories change. It
+// carries the complete new set, so consumers do not need the old one.
+type ProductCategorized struct {
+	BaseEvent
+	SellerId    uuid.UUID   `json:"seller_id"`
+	CategoryIds []uuid.UUID `json:"category_ids"`
+}
+
+func NewProductCategorized(productId, sellerId uuid.UUID, categoryIds []uuid.UUID) ProductCategorized {
+	return ProductCategorized{
+		BaseEvent:   NewBaseEvent(productId),
+		SellerId:    sellerId,
+		CategoryIds: categoryIds,
+	}
+}
+
+func (e ProductCategorized) EventName() string { return ProductCategorizedEventName }
+
+type ProductVariantAdded struct {
+	BaseEvent
+	SellerId uuid.UUID      `json:"seller_id"`
+	Variant  ProductVariant `json:"variant"`
+}
+
+func NewProductVariantAdded(productId, sellerId uuid.UUID, variant ProductVariant) ProductVariantAdded {
+	return ProductVariantAdded{
+		BaseEvent: NewBaseEvent(productId),
+		SellerId:  sellerId,
+		Variant:   variant,
+	}
+}
+
+func (e ProductVariantAdded) EventName() string { return ProductVariantAddedEventName }
+
+// ProductVariantChanged carries the variant's complete new state: its SKU,
+// attributes or price override changed.
+type ProductVariantChanged struct {
+	BaseEvent
+	SellerId uuid.UUID      `json:"seller_id"`
+	Variant  ProductVariant `json:"variant"`
+}
+
+func NewProductVariantChanged(productId, sellerId uuid.UUID, variant ProductVariant) ProductVariantChanged {
+	return ProductVariantChanged{
+		BaseEvent: NewBaseEvent(productId),
+		SellerId:  sellerId,
+		Variant:   variant,
+	}
+}
+
+func (e ProductVariantChanged) EventName() string { return ProductVariantChangedEventName }
+
+type ProductVariantRemoved struct {
+	BaseEvent
+	SellerId  uuid.UUID `json:"seller_id"`
+	VariantId uuid.UUID `json:"variant_id"`
+	Sku       string    `json:"sku"`
+}
+
+func NewProductVariantRemoved(productId, sellerId, variantId uuid.UUID, sku string) ProductVariantRemoved {
+	return ProductVariantRemoved{
+		BaseEvent: NewBaseEvent(productId),
+		SellerId:  sellerId,
+		VariantId: variantId,
+		Sku:       sku,
+	}
+}
+
+func (e ProductVariantRemoved) EventName() string { return ProductVariantRemovedEventName }
+
+type ProductImageAdded struct {
+	BaseEvent
+	ProductGallery
+	SellerId uuid.UUID    `json:"seller_id"`
+	Image    ProductImage `json:"image"`
+}
+
+func NewProductImageAdded(productId, sellerId uuid.UUID, image ProductImage, gallery ProductGallery) ProductImageAdded {
+	return ProductImageAdded{
+		BaseEvent:      NewBaseEvent(productId),
+		ProductGallery: gallery,
+		SellerId:       sellerId,
+		Image:          image,
+	}
+}
+
+func (e ProductImageAdded) EventName() string { return ProductImageAddedEventName }
+
+// ProductImageRemoved names the removed image's blob, which is deleted
+// once the removal is committed.
+type ProductImageRemoved struct {
+	BaseEvent
+	ProductGallery
+	SellerId uuid.UUID `json:"seller_id"`
+	ImageId  uuid.UUID `json:"image_id"`
+	BlobKey  string    `json:"blob_key"`
+}
+
+func NewProductImageRemoved(productId, sellerId, imageId uuid.UUID, blobKey string, gallery ProductGallery) ProductImageRemoved {
+	return ProductImageRemoved{
+		BaseEvent:      NewBaseEvent(productId),
+		ProductGallery: gallery,
+		SellerId:       sellerId,
+		ImageId:        imageId,
+		BlobKey:        blobKey,
+	}
+}
+
+func (e ProductImageRemoved) EventName() string { return ProductImageRemovedEventName }
+
+// ProductImagesArranged records a new image order or primary image.
+type ProductImagesArranged struct {
+	BaseEvent
+	ProductGallery
+	SellerId uuid.UUID `json:"seller_id"`
+}
+
+func NewProductImagesArranged(productId, sellerId uuid.UUID, gallery ProductGallery) ProductImagesArranged {
+	return ProductImagesArranged{
+		BaseEvent:      NewBaseEvent(productId),
+		ProductGallery: gallery,
+		SellerId:       sellerId,
+	}
+}
+
+func (e ProductImagesArranged) EventName() string { return ProductImagesArrangedEventName }
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PromotionCreatedEventName  = "promotion.created"
+	PromotionEndedEventName    = "promotion.ended"
+	PromotionRedeemedEventName = "promotion.redeemed"
+)
+
+// Discount is the event-side snapshot of a promotion's discount: either
+// BasisPoints or Amount is set, as Kind says.
+type Discount struct {
+	Kind        string `json:"kind"`
+	BasisPoints int64  `json:"basis_points,omitempty"`
+	Amount      *Money `json:"amount,omitempty"`
+}
+
+type PromotionCreated struct {
+	BaseEvent
+	SellerId   uuid.UUID   `json:"seller_id"`
+	Name       string      `json:"name"`
+	Discount   Discount    `json:"discount"`
+	ProductIds []uuid.UUID `json:"product_ids"`
+	StartsAt   time.Time   `json:"starts_at"`
+	EndsAt     *time.Time  `json:"ends_at,omitempty"`
+	Stackable  bool        `json:"stackable"`
+	UsageLimit int         `json:"usage_limit"`
+}
+
+func NewPromotionCreated(promotionId, sellerId uuid.UUID, name string, discount Discount, productIds []uuid.UUID, startsAt time.Time, endsAt *time.Time, stackable bool, usageLimit int) PromotionCreated {
+	return PromotionCreated{
+		BaseEvent:  NewBaseEvent(promotionId),
+		SellerId:   sellerId,
+		Name:       name,
+		Discount:   discount,
+		ProductIds: productIds,
+		StartsAt:   startsAt,
+		EndsAt:     endsAt,
+		Stackable:  stackable,
+		UsageLimit: usageLimit,
+	}
+}
+
+func (e PromotionCreated) EventName() string { return PromotionCreatedEventName }
+
+// PromotionEnded is raised when a seller ends a promotion before its
+// scheduled end.
+type PromotionEnded struct {
+	BaseEvent
+	EndsAt time.Time `json:"ends_at"`
+}
+
+func NewPromotionEnded(promotionId uuid.UUID, endsAt time.Time) PromotionEnded {
+	return PromotionEnded{
+		BaseEvent: NewBaseEvent(promotionId),
+		EndsAt:    endsAt,
+	}
+}
+
+func (e PromotionEnded) EventName() string { return PromotionEndedEventName }
+
+type PromotionRedeemed struct {
+	BaseEvent
+	UsageCount int `json:"usage_count"`
+	// UsageLimit is 0 for promotions without a limit.
+	UsageLimit int `json:"usage_limit"`
+}
+
+func NewPromotionRedeemed(promotionId uuid.UUID, usageCount, usageLimit int) PromotionRedeemed {
+	return PromotionRedeemed{
+		BaseEvent:  NewBaseEvent(promotionId),
+		UsageCount: usageCount,
+		UsageLimit: usageLimit,
+	}
+}
+
+func (e PromotionRedeemed) EventName() string { return PromotionRedeemedEventName }
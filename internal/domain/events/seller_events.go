@@ -0,0 +1,66 @@
+package events
+
+import "github.com/google/uuid"
+
+const (
+	SellerCreatedEventName             = "seller.created"
+	SellerRenamedEventName             = "seller.renamed"
+	SellerVerificationChangedEventName = "seller.verification_changed"
+	SellerDeletedEventName             = "seller.deleted"
+)
+
+type SellerCreated struct {
+	BaseEvent
+	Name string `json:"name"`
+}
+
+func NewSellerCreated(sellerId uuid.UUID, name string) SellerCreated {
+	return SellerCreated{
+		BaseEvent: NewBaseEvent(sellerId),
+		Name:      name,
+	}
+}
+
+func (e SellerCreated) EventName() string { return SellerCreatedEventName }
+
+type SellerRenamed struct {
+	BaseEvent
+	OldName string `json:"old_name"`
+	NewName string `json:"new_name"`
+}
+
+func NewSellerRenamed(sellerId uuid.UUID, oldName, newName string) SellerRenamed {
+	return SellerRenamed{
+		BaseEvent: NewBaseEvent(sellerId),
+		OldName:   oldName,
+		NewName:   newName,
+	}
+}
+
+func (e SellerRenamed) EventName() string { return SellerRenamedEventName }
+
+type SellerDeleted struct {
+	BaseEvent
+}
+
+func NewSellerDeleted(sellerId uuid.UUID) SellerDeleted {
+	return SellerDeleted{BaseEvent: NewBaseEvent(sellerId)}
+}
+
+func (e SellerDeleted) EventName() string { return SellerDeletedEventName }
+
+type SellerVerificationChanged struct {
+	BaseEvent
+	OldStatus string `json:"old_status"`
+	NewStatus string `json:"new_status"`
+}
+
+func NewSellerVerificationChanged(sellerId uuid.UUID, oldStatus, newStatus string) SellerVerificationChanged {
+	return SellerVerificationChanged{
+		BaseEvent: NewBaseEvent(sellerId),
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+	}
+}
+
+func (e SellerVerificationChanged) EventName() string { return SellerVerificationChangedEventName }
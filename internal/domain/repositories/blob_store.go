@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore keeps binary data such as product images under string keys.
+type BlobStore interface {
+	// Put stores size bytes read from body under key, replacing what was
+	// stored there before.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens the blob stored under key; the caller closes it. Keys that
+	// hold no blob are entities.ErrBlobNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob; deleting a key that holds no blob is a no-op.
+	Delete(ctx context.Context, key string) error
+}
+
+// BlobDeletionRepository lists the blobs whose owners were removed. Blobs
+// are scheduled in the transaction that removes their owner, so they are
+// only deleted from the BlobStore once the removal has committed.
+type BlobDeletionRepository interface {
+	// FindDue returns up to limit scheduled blob keys, oldest first.
+	FindDue(ctx context.Context, limit int) ([]string, error)
+	// Remove unschedules the key once its blob has been deleted.
+	Remove(ctx context.Context, key string) error
+}
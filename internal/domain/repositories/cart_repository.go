@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type CartRepository interface {
+	// FindByBuyerId returns nil when the buyer has no stored cart. Expired
+	// carts are returned as stored; the caller decides what expiry means.
+	FindByBuyerId(ctx context.Context, buyerId uuid.UUID) (*entities.Cart, error)
+	// Save inserts a cart with Version 0 or updates a stored one while its
+	// version still equals the aggregate's Version; otherwise it fails with
+	// ErrVersionConflict.
+	Save(ctx context.Context, cart *entities.ValidatedCart) (*entities.Cart, error)
+	// Delete removes the cart under the same version check as Save.
+	Delete(ctx context.Context, cart *entities.Cart) error
+	// DeleteExpired removes up to limit expired carts and returns how many
+	// it removed.
+	DeleteExpired(ctx context.Context, limit int) (int, error)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type CategoryRepository interface {
+	// Create fails with ErrCategoryNotFound if the parent is gone.
+	Create(ctx context.Context, category *entities.ValidatedCategory) (*entities.Category, error)
+	FindById(ctx context.Context, id uuid.UUID) (*entities.Category, error)
+	// FindChildren returns the direct subcategories of parentId, or the
+	// root categories for uuid.Nil, ordered by name.
+	FindChildren(ctx context.Context, parentId uuid.UUID) ([]*entities.Category, error)
+	// Update and Delete only apply while the stored version still equals the
+	// aggregate's Version; otherwise they fail with ErrVersionConflict. A
+	// moved category takes its descendants along; a move that became a cycle
+	// through a concurrent move fails with ErrCategoryCycle.
+	Update(ctx context.Context, category *entities.ValidatedCategory) (*entities.Category, error)
+	// Delete fails with ErrCategoryNotEmpty while the category has
+	// subcategories or products.
+	Delete(ctx context.Context, category *entities.Category) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// DeadLetterRepository gives operators access to the dead-lettered outbox
+// events. Replay and Discard fail with entities.ErrDeadLetterNotFound for
+// an id that is not dead-lettered.
+type DeadLetterRepository interface {
+	// FindAll returns up to limit dead letters, oldest first.
+	FindAll(ctx context.Context, limit int) ([]*entities.DeadLetter, error)
+	// Replay hands the event back to the relay with a fresh attempt budget.
+	// It is still delivered before the later events of its aggregate.
+	Replay(ctx context.Context, id uuid.UUID) error
+	// Discard deletes the event; it will never be published. Later events
+	// of the same aggregate, held back by it, become deliverable again.
+	Discard(ctx context.Context, id uuid.UUID) error
+}
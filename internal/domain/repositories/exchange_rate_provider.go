@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// ExchangeRateProvider answers which rate converts from into to at a point
+// in time. Implementations return entities.ErrExchangeRateNotFound when no
+// rate for the pair was in effect yet.
+type ExchangeRateProvider interface {
+	Rate(ctx context.Context, from, to entities.Currency, at time.Time) (entities.ExchangeRate, error)
+}
+
+// ExchangeRateRepository keeps every rate it is given; a new rate for a pair
+// takes over from its EffectiveAt without replacing the older ones, so
+// conversions in the past stay reproducible.
+type ExchangeRateRepository interface {
+	ExchangeRateProvider
+	// Save stores rate, replacing a rate for the same pair and EffectiveAt.
+	Save(ctx context.Context, rate entities.ExchangeRate) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type InboxRepository interface {
+	// Save stores a received message. It returns false when the message was
+	// stored before, i.e. for a redelivery.
+	Save(ctx context.Context, message *entities.InboxMessage) (bool, error)
+	// ClaimNext locks the oldest message that is due for handling until the
+	// transaction in ctx ends, skipping messages locked by others. It returns
+	// nil when there is nothing to do; it must run inside a Transactor.
+	ClaimNext(ctx context.Context) (*entities.InboxMessage, error)
+	MarkProcessed(ctx context.Context, message *entities.InboxMessage) error
+	// RecordFailure schedules another attempt after retryIn, or parks the
+	// message for good when giveUp is set.
+	RecordFailure(ctx context.Context, message *entities.InboxMessage, cause error, retryIn time.Duration, giveUp bool) error
+}
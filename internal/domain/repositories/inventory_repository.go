@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// InventoryRepository stores one inventory per product variant.
+type InventoryRepository interface {
+	// FindByVariantId returns nil when the variant never had stock.
+	FindByVariantId(ctx context.Context, variantId uuid.UUID) (*entities.Inventory, error)
+	// Lock loads the variant's inventory, creating an empty one if needed,
+	// and locks it until the transaction in ctx ends; concurrent writers
+	// wait. It must run inside a Transactor. A variant that does not exist
+	// is ErrVariantNotFound.
+	Lock(ctx context.Context, variantId uuid.UUID) (*entities.Inventory, error)
+	// Save writes a locked inventory and its recorded events.
+	Save(ctx context.Context, inventory *entities.ValidatedInventory) (*entities.Inventory, error)
+	// FindVariantIdsWithExpiredReservations returns up to limit variants
+	// that hold at least one expired reservation.
+	FindVariantIdsWithExpiredReservations(ctx context.Context, limit int) ([]uuid.UUID, error)
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type OrderRepository interface {
+	Create(ctx context.Context, order *entities.ValidatedOrder) (*entities.Order, error)
+	FindById(ctx context.Context, id uuid.UUID) (*entities.Order, error)
+	// FindAll returns one keyset page of orders, newest first.
+	FindAll(ctx context.Context, criteria OrderListCriteria) ([]*entities.Order, error)
+	// Update only applies while the stored version still equals the
+	// aggregate's Version; otherwise it fails with ErrVersionConflict.
+	Update(ctx context.Context, order *entities.ValidatedOrder) (*entities.Order, error)
+}
+
+type OrderListCriteria struct {
+	// BuyerId restricts the page to one buyer's orders; uuid.Nil lists all.
+	BuyerId uuid.UUID
+	// After is the position of the last order on the previous page; nil
+	// starts at the first page.
+	After *OrderCursor
+	Limit int
+}
+
+type OrderCursor struct {
+	Id        uuid.UUID
+	CreatedAt time.Time
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// OutboxReplayRepository records replays of published events and runs
+// them. FindById and Resume fail with entities.ErrOutboxReplayNotFound for
+// an unknown replay.
+type OutboxReplayRepository interface {
+	// Create records a pending replay of the events matching filter to
+	// target. It fails with entities.ErrUnknownReplayTarget if no publisher
+	// is configured under that name.
+	Create(ctx context.Context, filter entities.OutboxReplayFilter, target string) (*entities.OutboxReplay, error)
+	FindById(ctx context.Context, id uuid.UUID) (*entities.OutboxReplay, error)
+	// FindAll returns up to limit replays, newest first.
+	FindAll(ctx context.Context, limit int) ([]*entities.OutboxReplay, error)
+	// Resume claims a pending, failed or abandoned replay and continues it
+	// in the background from its last checkpoint. It fails with
+	// entities.ErrOutboxReplayNotResumable if the replay completed or is
+	// still running elsewhere.
+	Resume(ctx context.Context, id uuid.UUID) (*entities.OutboxReplay, error)
+}
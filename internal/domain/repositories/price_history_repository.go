@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// PriceHistoryRepository reads the prices a product had. The history is
+// written by ProductRepository in the same transaction as the price change.
+type PriceHistoryRepository interface {
+	// FindByProductId returns the prices in effect at any time in [from,
+	// to], oldest first: the one already in effect at from, if any, and
+	// every change up to to.
+	FindByProductId(ctx context.Context, productId uuid.UUID, from, to time.Time) ([]entities.PricePoint, error)
+}
+
+type ScheduledPriceChangeRepository interface {
+	Create(ctx context.Context, change *entities.ScheduledPriceChange) error
+	// FindByProductId returns the product's pending changes, earliest first.
+	FindByProductId(ctx context.Context, productId uuid.UUID) ([]*entities.ScheduledPriceChange, error)
+	// Delete removes a pending change of productId. It fails with
+	// ErrScheduledPriceChangeNotFound if the change was applied or deleted
+	// meanwhile, so two workers cannot both apply it.
+	Delete(ctx context.Context, productId, id uuid.UUID) error
+	// FindDue returns up to limit changes that are due at now, earliest
+	// first.
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.ScheduledPriceChange, error)
+}
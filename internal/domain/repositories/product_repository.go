@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
@@ -10,7 +11,80 @@ import (
 type ProductRepository interface {
 	Create(ctx context.Context, product *entities.ValidatedProduct) (*entities.Product, error)
 	FindById(ctx context.Context, id uuid.UUID) (*entities.Product, error)
-	FindAll(ctx context.Context) ([]*entities.Product, error)
+	// FindAll returns one keyset page of products matching the criteria,
+	// ordered by criteria.SortBy with the id as tiebreaker.
+	FindAll(ctx context.Context, criteria ProductListCriteria) ([]*entities.Product, error)
+	// Search returns one keyset page of the published products matching
+	// every search term, best match first with the id as tiebreaker.
+	Search(ctx context.Context, criteria ProductSearchCriteria) ([]ProductSearchHit, error)
+	// Update and Delete only apply while the stored version still equals the
+	// aggregate's Version; otherwise they fail with ErrVersionConflict.
 	Update(ctx context.Context, product *entities.ValidatedProduct) (*entities.Product, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, product *entities.Product) error
+}
+
+// ProductSortField selects the listing order. Newest products come first
+// for created_at; name and price sort ascending.
+type ProductSortField string
+
+const (
+	ProductSortByCreatedAt ProductSortField = "created_at"
+	ProductSortByName      ProductSortField = "name"
+	ProductSortByPrice     ProductSortField = "price"
+)
+
+// ProductListCriteria filters and pages a product listing. Zero values mean
+// "no restriction".
+type ProductListCriteria struct {
+	SellerId           uuid.UUID
+	Status             entities.ProductStatus
+	Currency           entities.Currency
+	MinPriceMinorUnits *int64
+	MaxPriceMinorUnits *int64
+	// CategoryId restricts the listing to the category and all its
+	// descendants.
+	CategoryId uuid.UUID
+
+	SortBy ProductSortField
+	// After is the sort key of the last product on the previous page; nil
+	// starts at the first page.
+	After *ProductCursor
+	Limit int
+}
+
+// ProductCursor is the keyset position of a product. Only Id and the field
+// matching the sort order are compared.
+type ProductCursor struct {
+	Id              uuid.UUID
+	CreatedAt       time.Time
+	Name            string
+	PriceMinorUnits int64
+}
+
+// ProductSearchCriteria pages a full-text search. Each term is a word of
+// letters and digits; it matches any word it is a prefix of after
+// stemming, in the product name or in the name of one of the product's
+// categories or their ancestors.
+type ProductSearchCriteria struct {
+	Terms []string
+	// After is the position of the last hit on the previous page; nil starts
+	// at the first page.
+	After *ProductSearchCursor
+	Limit int
+}
+
+// ProductSearchCursor is the keyset position of a search hit.
+type ProductSearchCursor struct {
+	Rank float32
+	Id   uuid.UUID
+}
+
+// ProductSearchHit is a product matching a search. Rank grows with the
+// relevance of the match; name matches weigh more than category matches.
+// Highlight is the HTML-escaped product name with every matching word
+// wrapped in <mark> and </mark>.
+type ProductSearchHit struct {
+	Product   *entities.Product
+	Rank      float32
+	Highlight string
 }
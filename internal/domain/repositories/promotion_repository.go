@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *entities.ValidatedPromotion) (*entities.Promotion, error)
+	FindById(ctx context.Context, id uuid.UUID) (*entities.Promotion, error)
+	// FindBySellerId returns all of a seller's promotions, newest first.
+	FindBySellerId(ctx context.Context, sellerId uuid.UUID) ([]*entities.Promotion, error)
+	// FindActive returns the promotions of the given sellers that run at at
+	// and have redemptions left, for pricing a page of products at once.
+	FindActive(ctx context.Context, sellerIds []uuid.UUID, at time.Time) ([]*entities.Promotion, error)
+	// Redeem counts one use of the promotion at at in a single conditional
+	// write, without a version check, so concurrent redemptions of the same
+	// promotion do not conflict. It fails with ErrPromotionExhausted if the
+	// promotion is not running at at or has no redemptions left.
+	Redeem(ctx context.Context, id uuid.UUID, at time.Time) (*entities.Promotion, error)
+	// Update only applies while the stored version still equals the
+	// aggregate's Version; otherwise it fails with ErrVersionConflict.
+	Update(ctx context.Context, promotion *entities.ValidatedPromotion) (*entities.Promotion, error)
+}
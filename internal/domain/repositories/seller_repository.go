@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
@@ -10,7 +11,36 @@ import (
 type SellerRepository interface {
 	Create(ctx context.Context, seller *entities.ValidatedSeller) (*entities.Seller, error)
 	FindById(ctx context.Context, id uuid.UUID) (*entities.Seller, error)
-	FindAll(ctx context.Context) ([]*entities.Seller, error)
+	// FindAll returns one keyset page of sellers, ordered by
+	// criteria.SortBy with the id as tiebreaker.
+	FindAll(ctx context.Context, criteria SellerListCriteria) ([]*entities.Seller, error)
+	// Update and Delete only apply while the stored version still equals the
+	// aggregate's Version; otherwise they fail with ErrVersionConflict.
 	Update(ctx context.Context, seller *entities.ValidatedSeller) (*entities.Seller, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, seller *entities.Seller) error
+}
+
+// SellerSortField selects the listing order. Newest sellers come first for
+// created_at; name sorts ascending.
+type SellerSortField string
+
+const (
+	SellerSortByCreatedAt SellerSortField = "created_at"
+	SellerSortByName      SellerSortField = "name"
+)
+
+type SellerListCriteria struct {
+	SortBy SellerSortField
+	// After is the sort key of the last seller on the previous page; nil
+	// starts at the first page.
+	After *SellerCursor
+	Limit int
+}
+
+// SellerCursor is the keyset position of a seller. Only Id and the field
+// matching the sort order are compared.
+type SellerCursor struct {
+	Id        uuid.UUID
+	CreatedAt time.Time
+	Name      string
 }
@@ -0,0 +1,10 @@
+package repositories
+
+import "context"
+
+// Transactor runs a unit of work in one database transaction. Repository
+// calls made with the context passed to fn take part in it, so their writes
+// commit or roll back together.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// WebhookSubscriptionRepository stores webhook subscriptions and exposes
+// their delivery history. FindById, Delete and FindDeliveries fail with
+// entities.ErrWebhookSubscriptionNotFound for an unknown subscription.
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entities.WebhookSubscription) error
+	FindById(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error)
+	FindAll(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	// Delete removes the subscription together with its pending deliveries.
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindDeliveries returns up to limit of the subscription's most recent
+	// deliveries, newest first.
+	FindDeliveries(ctx context.Context, subscriptionId uuid.UUID, limit int) ([]*entities.WebhookDelivery, error)
+}
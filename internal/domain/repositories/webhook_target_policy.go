@@ -0,0 +1,12 @@
+package repositories
+
+import "context"
+
+// WebhookTargetPolicy decides which URLs webhooks may be sent to, so a
+// subscription cannot make the server call into its own network.
+type WebhookTargetPolicy interface {
+	// Check fails with entities.ErrValidation if the host of rawURL, an
+	// absolute http(s) URL, cannot be resolved or resolves to an address
+	// webhooks must not reach.
+	Check(ctx context.Context, rawURL string) error
+}
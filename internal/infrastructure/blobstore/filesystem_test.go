@@ -0,0 +1,50 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func TestFilesystemStore(t *testing.T) {
+	store, err := NewFilesystemStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	key := "products/42/images/1.png"
+
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, entities.ErrBlobNotFound)
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("png data"), 8, "image/png"))
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("new data"), 8, "image/png"), "a put replaces the blob")
+	body, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	assert.Equal(t, "new data", string(data))
+
+	assert.Error(t, store.Put(ctx, "short.png", strings.NewReader("abc"), 8, "image/png"), "a short body is not stored")
+	_, err = store.Get(ctx, "short.png")
+	assert.ErrorIs(t, err, entities.ErrBlobNotFound)
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key), "deleting a missing blob is a no-op")
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, entities.ErrBlobNotFound)
+}
+
+func TestFilesystemStore_RejectsKeysOutsideRoot(t *testing.T) {
+	store, err := NewFilesystemStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", ".", "../escape.png", "/etc/passwd", "a/../../b", "a//b"} {
+		assert.Error(t, store.Put(context.Background(), key, strings.NewReader("x"), 1, "image/png"), key)
+	}
+}
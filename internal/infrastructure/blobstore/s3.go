@@ -0,0 +1,270 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// unsignedPayload lets uploads stream without hashing the body first; the
+// request itself is still signed.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Config locates a bucket of an S3-compatible object store, e.g. AWS S3
+// or MinIO.
+type S3Config struct {
+	// Endpoint is the store's base URL, e.g. https://s3.eu-central-1.amazonaws.com
+	// or http://localhost:9000.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyId     string
+	SecretAccessKey string
+}
+
+// S3Store talks to the object store's REST API directly, using path-style
+// URLs ({endpoint}/{bucket}/{key}) and AWS Signature Version 4, which every
+// S3-compatible store accepts.
+type S3Store struct {
+	endpoint *url.URL
+	bucket   string
+	signer   signer
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3Store sends its requests through client, or http.DefaultClient when
+// client is nil.
+func NewS3Store(config S3Config, client *http.Client) (repositories.BlobStore, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("s3 endpoint: %w", err)
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("s3 endpoint %q needs a scheme and a host", config.Endpoint)
+	}
+	if config.Bucket == "" || config.Region == "" {
+		return nil, fmt.Errorf("s3 store needs a bucket and a region")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &S3Store{
+		endpoint: endpoint,
+		bucket:   config.Bucket,
+		signer: signer{
+			accessKeyId:     config.AccessKeyId,
+			secretAccessKey: config.SecretAccessKey,
+			region:          config.Region,
+			service:         "s3",
+		},
+		client: client,
+		now:    time.Now,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := s.request(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		return unexpectedResponse(req, resp)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", entities.ErrBlobNotFound, key)
+	case resp.StatusCode/100 != 2:
+		defer func() { _ = resp.Body.Close() }()
+		return nil, unexpectedResponse(req, resp)
+	}
+
+	return resp.Body, nil
+}
+
+// Delete treats a 404 like success: S3 itself answers deletes of missing
+// keys with 204, but not every compatible store does.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return unexpectedResponse(req, resp)
+	}
+	return nil
+}
+
+func (s *S3Store) request(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" {
+		return nil, fmt.Errorf("invalid blob key %q", key)
+	}
+	target := s.endpoint.String() + "/" + uriEncode(s.bucket, false) + "/" + uriEncode(key, true)
+	return http.NewRequestWithContext(ctx, method, target, body)
+}
+
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	s.signer.sign(req, unsignedPayload, s.now())
+	return s.client.Do(req)
+}
+
+func unexpectedResponse(req *http.Request, resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(message)))
+}
+
+// signer signs requests with AWS Signature Version 4:
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html
+type signer struct {
+	accessKeyId     string
+	secretAccessKey string
+	region          string
+	service         string
+}
+
+// sign sets the X-Amz-Date and Authorization headers. It signs the host and
+// every X-Amz-* header, so those must be set before.
+func (s signer) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := []string{"host"}
+	for name := range req.Header {
+		if name := strings.ToLower(name); strings.HasPrefix(name, "x-amz-") {
+			signedHeaders = append(signedHeaders, name)
+		}
+	}
+	slices.Sort(signedHeaders)
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyId, s.scope(amzDate), strings.Join(signedHeaders, ";"), s.signature(req, payloadHash, signedHeaders, amzDate)))
+}
+
+// signature computes the request's signature over signedHeaders, which
+// must be lower case and sorted.
+func (s signer) signature(req *http.Request, payloadHash string, signedHeaders []string, amzDate string) string {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	var headers strings.Builder
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	path := req.URL.Path
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(path, true),
+		canonicalQuery(req.URL.Query()),
+		headers.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		s.scope(amzDate),
+		hashHex(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), amzDate[:8])
+	for _, part := range []string{s.region, s.service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (s signer) scope(amzDate string) string {
+	return amzDate[:8] + "/" + s.region + "/" + s.service + "/aws4_request"
+}
+
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name, false)+"="+uriEncode(value, false))
+		}
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters and,
+// when keepSlash is set, '/', as Signature Version 4 requires.
+func uriEncode(value string, keepSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/' && keepSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func hashHex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,143 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// TestSigner_AWSTestSuite checks the signer against the get-vanilla case of
+// the AWS Signature Version 4 test suite.
+func TestSigner_AWSTestSuite(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	s := signer{
+		accessKeyId:     "AKIDEXAMPLE",
+		secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		region:          "us-east-1",
+		service:         "service",
+	}
+
+	s.sign(req, hashHex(""), time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+// fakeS3 is a local stand-in for an S3-compatible store: it keeps objects
+// in memory and rejects requests whose signature does not verify.
+type fakeS3 struct {
+	signer  signer
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.verify(r) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil || int64(len(data)) != r.ContentLength {
+			http.Error(w, "IncompleteBody", http.StatusBadRequest)
+			return
+		}
+		f.objects[r.URL.Path] = data
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[r.URL.Path])
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) verify(r *http.Request) bool {
+	authorization := strings.TrimPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	var signedHeaders, signature string
+	for _, part := range strings.Split(authorization, ", ") {
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case "SignedHeaders":
+			signedHeaders = value
+		case "Signature":
+			signature = value
+		}
+	}
+	expected := f.signer.signature(r, r.Header.Get("X-Amz-Content-Sha256"), strings.Split(signedHeaders, ";"), r.Header.Get("X-Amz-Date"))
+	return signature != "" && signature == expected
+}
+
+func TestS3Store(t *testing.T) {
+	fake := &fakeS3{
+		signer:  signer{accessKeyId: "minio", secretAccessKey: "minio-secret", region: "eu-central-1", service: "s3"},
+		objects: make(map[string][]byte),
+		types:   make(map[string]string),
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:        server.URL,
+		Region:          "eu-central-1",
+		Bucket:          "media",
+		AccessKeyId:     "minio",
+		SecretAccessKey: "minio-secret",
+	}, server.Client())
+	require.NoError(t, err)
+	ctx := context.Background()
+	key := "products/42/images/front view+1.png"
+
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, entities.ErrBlobNotFound)
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("png data"), 8, "image/png"))
+	assert.Equal(t, "image/png", fake.types["/media/"+key], "keys are sent path-style and escaped")
+	body, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	assert.Equal(t, "png data", string(data))
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, entities.ErrBlobNotFound)
+
+	wrongSecret, err := NewS3Store(S3Config{Endpoint: server.URL, Region: "eu-central-1", Bucket: "media", AccessKeyId: "minio", SecretAccessKey: "guess"}, server.Client())
+	require.NoError(t, err)
+	err = wrongSecret.Put(ctx, key, strings.NewReader("png data"), 8, "image/png")
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestNewS3Store_InvalidConfig(t *testing.T) {
+	_, err := NewS3Store(S3Config{Endpoint: "localhost:9000", Region: "us-east-1", Bucket: "media"}, nil)
+	assert.Error(t, err)
+	_, err = NewS3Store(S3Config{Endpoint: "http://localhost:9000", Region: "us-east-1"}, nil)
+	assert.Error(t, err)
+}
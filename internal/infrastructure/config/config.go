@@ -1,6 +1,12 @@
 package config
 
-import "os"
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type Config struct {
 	// DatabaseURL may be a libpq keyword DSN or a postgres:// URL; pgx
@@ -8,6 +14,98 @@ type Config struct {
 	DatabaseURL string
 	// Port is the HTTP listen port without colon.
 	Port string
+	// OutboxPollInterval is the relay's fallback poll; new events are normally
+	// dispatched right away via LISTEN/NOTIFY.
+	OutboxPollInterval time.Duration
+	// OutboxMaxAttempts is how many failed publishes an outbox event gets
+	// before it is dead-lettered.
+	OutboxMaxAttempts int
+	// OutboxRetryBaseDelay is the backoff after the first failed publish; it
+	// doubles per attempt up to OutboxRetryMaxDelay.
+	OutboxRetryBaseDelay time.Duration
+	OutboxRetryMaxDelay  time.Duration
+	// OutboxRetentionDays is how long published events stay in
+	// outbox_events, and finished webhook deliveries in webhook_deliveries,
+	// before the retention worker removes them; 0 disables it.
+	OutboxRetentionDays int
+	// OutboxRetentionMode is "archive" (move to outbox_events_archive) or
+	// "delete".
+	OutboxRetentionMode     string
+	OutboxRetentionInterval time.Duration
+	// EventSource is the CloudEvents "source" of published events.
+	EventSource string
+	// OutboxPublisher selects where the relay publishes: "log", "http",
+	// "nats" or "kafka". Empty means "http" if CloudEventsURL is set and
+	// "log" otherwise.
+	OutboxPublisher string
+	// CloudEventsURL is the HTTP sink the "http" publisher POSTs CloudEvents
+	// to.
+	CloudEventsURL string
+	// CloudEventsMode is "structured" or "binary", for every publisher.
+	CloudEventsMode string
+	// NATSURL, NATSStream and NATSSubjectPrefix configure the "nats"
+	// publisher; the stream is created if it does not exist.
+	NATSURL           string
+	NATSStream        string
+	NATSSubjectPrefix string
+	// KafkaBrokers and KafkaTopic configure the "kafka" publisher.
+	KafkaBrokers []string
+	KafkaTopic   string
+	// WebhookPollInterval is how often the webhook dispatcher looks for due
+	// retries; new deliveries are sent right away.
+	WebhookPollInterval time.Duration
+	// WebhookMaxAttempts and the retry delays apply per delivery. Partner
+	// endpoints can be down for a while, so they back off further than the
+	// outbox does.
+	WebhookMaxAttempts    int
+	WebhookRetryBaseDelay time.Duration
+	WebhookRetryMaxDelay  time.Duration
+	// WebhookAllowedHosts are host names, IP addresses or CIDR ranges that
+	// webhooks may target even though they are loopback, link-local or
+	// private, e.g. a partner inside the same network or a local test
+	// endpoint.
+	WebhookAllowedHosts []string
+	// InboxPollInterval is how often the inbox dispatcher looks for due
+	// retries; new messages are handled right away.
+	InboxPollInterval time.Duration
+	// InboxMaxAttempts and the retry delays apply per received message.
+	InboxMaxAttempts    int
+	InboxRetryBaseDelay time.Duration
+	InboxRetryMaxDelay  time.Duration
+	// ReservationExpiryInterval is how often expired stock reservations are
+	// released. Availability excludes them right away; the sweep emits
+	// their StockReleased events.
+	ReservationExpiryInterval time.Duration
+	// OrderReservationTTL is how long a pending order holds its stock;
+	// paying later fails if the units were released meanwhile.
+	OrderReservationTTL time.Duration
+	// CartTTL is how long a cart lives without changes; CartExpiryInterval
+	// is how often expired carts are deleted.
+	CartTTL            time.Duration
+	CartExpiryInterval time.Duration
+	// PriceScheduleInterval is how often due scheduled price changes are
+	// applied, i.e. how late one may take effect.
+	PriceScheduleInterval time.Duration
+	// ExchangeRatesFile, if set, serves exchange rates from this JSON file
+	// instead of the exchange_rates table, e.g. for offline use.
+	ExchangeRatesFile string
+
+	// BlobStore selects where product images are kept: "filesystem" (the
+	// default) below BlobStoreDir, or "s3" for any S3-compatible store.
+	BlobStore    string
+	BlobStoreDir string
+	// S3Endpoint is the store's base URL, e.g. http://localhost:9000 for
+	// MinIO; buckets are addressed path-style.
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyId     string
+	S3SecretAccessKey string
+	// MaxImageBytes is the largest image upload accepted.
+	MaxImageBytes int
+	// BlobCleanupInterval is how often the blobs of removed images and
+	// deleted products are deleted.
+	BlobCleanupInterval time.Duration
 }
 
 // Load reads configuration from the environment. Defaults live here — next
@@ -16,6 +114,58 @@ func Load() Config {
 	return Config{
 		DatabaseURL: getEnv("DATABASE_URL", "host=localhost user=marketplace password=marketplace dbname=marketplace port=5432 sslmode=disable"),
 		Port:        getEnv("PORT", "8080"),
+
+		OutboxPollInterval:   getEnvDuration("OUTBOX_POLL_INTERVAL", 5*time.Second),
+		OutboxMaxAttempts:    getEnvInt("OUTBOX_MAX_ATTEMPTS", 10),
+		OutboxRetryBaseDelay: getEnvDuration("OUTBOX_RETRY_BASE_DELAY", time.Second),
+		OutboxRetryMaxDelay:  getEnvDuration("OUTBOX_RETRY_MAX_DELAY", 10*time.Minute),
+
+		OutboxRetentionDays:     getEnvInt("OUTBOX_RETENTION_DAYS", 7),
+		OutboxRetentionMode:     getEnv("OUTBOX_RETENTION_MODE", "archive"),
+		OutboxRetentionInterval: getEnvDuration("OUTBOX_RETENTION_INTERVAL", time.Hour),
+
+		EventSource:     getEnv("EVENT_SOURCE", "/go-ddd/marketplace"),
+		OutboxPublisher: getEnv("OUTBOX_PUBLISHER", ""),
+		CloudEventsURL:  getEnv("CLOUDEVENTS_URL", ""),
+		CloudEventsMode: getEnv("CLOUDEVENTS_MODE", "structured"),
+
+		NATSURL:           getEnv("NATS_URL", "nats://localhost:4222"),
+		NATSStream:        getEnv("NATS_STREAM", "MARKETPLACE_EVENTS"),
+		NATSSubjectPrefix: getEnv("NATS_SUBJECT_PREFIX", "marketplace.events"),
+
+		KafkaBrokers: getEnvList("KAFKA_BROKERS", []string{"localhost:9092"}),
+		KafkaTopic:   getEnv("KAFKA_TOPIC", "marketplace.events"),
+
+		WebhookPollInterval:   getEnvDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+		WebhookMaxAttempts:    getEnvInt("WEBHOOK_MAX_ATTEMPTS", 15),
+		WebhookRetryBaseDelay: getEnvDuration("WEBHOOK_RETRY_BASE_DELAY", 10*time.Second),
+		WebhookRetryMaxDelay:  getEnvDuration("WEBHOOK_RETRY_MAX_DELAY", time.Hour),
+		WebhookAllowedHosts:   getEnvList("WEBHOOK_ALLOWED_HOSTS", nil),
+
+		InboxPollInterval:   getEnvDuration("INBOX_POLL_INTERVAL", 5*time.Second),
+		InboxMaxAttempts:    getEnvInt("INBOX_MAX_ATTEMPTS", 10),
+		InboxRetryBaseDelay: getEnvDuration("INBOX_RETRY_BASE_DELAY", time.Second),
+		InboxRetryMaxDelay:  getEnvDuration("INBOX_RETRY_MAX_DELAY", 10*time.Minute),
+
+		ReservationExpiryInterval: getEnvDuration("RESERVATION_EXPIRY_INTERVAL", time.Minute),
+		OrderReservationTTL:       getEnvDuration("ORDER_RESERVATION_TTL", 15*time.Minute),
+
+		CartTTL:            getEnvDuration("CART_TTL", 7*24*time.Hour),
+		CartExpiryInterval: getEnvDuration("CART_EXPIRY_INTERVAL", time.Hour),
+
+		PriceScheduleInterval: getEnvDuration("PRICE_SCHEDULE_INTERVAL", time.Minute),
+
+		ExchangeRatesFile: getEnv("EXCHANGE_RATES_FILE", ""),
+
+		BlobStore:           getEnv("BLOB_STORE", "filesystem"),
+		BlobStoreDir:        getEnv("BLOB_STORE_DIR", "data/blobs"),
+		S3Endpoint:          getEnv("S3_ENDPOINT", ""),
+		S3Region:            getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:            getEnv("S3_BUCKET", ""),
+		S3AccessKeyId:       getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:   getEnv("S3_SECRET_ACCESS_KEY", ""),
+		MaxImageBytes:       getEnvInt("MEDIA_MAX_IMAGE_BYTES", 5<<20),
+		BlobCleanupInterval: getEnvDuration("BLOB_CLEANUP_INTERVAL", time.Minute),
 	}
 }
 
@@ -25,3 +175,49 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// getEnvList splits a comma-separated value, e.g. "kafka-1:9092,kafka-2:9092".
+func getEnvList(key string, fallback []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvInt falls back (with a warning) on values that do not parse, so a
+// typo in a tuning knob does not keep the service from starting.
+func getEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		slog.Warn("ignoring invalid integer in environment", slog.String("key", key), slog.String("value", value))
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvDuration accepts Go duration syntax, e.g. "500ms" or "2m". Every
+// duration setting is an interval, delay or TTL, so zero and negative
+// values fall back like unparsable ones; a ticker would panic on them.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		slog.Warn("ignoring invalid duration in environment", slog.String("key", key), slog.String("value", value))
+		return fallback
+	}
+	return parsed
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEnvDuration(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"":      time.Minute,
+		"90s":   90 * time.Second,
+		"soon":  time.Minute,
+		"0s":    time.Minute,
+		"-5m":   time.Minute,
+		"500ms": 500 * time.Millisecond,
+	} {
+		t.Setenv("TEST_INTERVAL", value)
+		assert.Equal(t, expected, getEnvDuration("TEST_INTERVAL", time.Minute), value)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
 	"github.com/sklinkert/go-ddd/internal/testhelpers"
 )
 
@@ -51,7 +52,7 @@ func TestNewQueries(t *testing.T) {
 
 	// Verify queries object is functional by running a simple query
 	ctx := context.Background()
-	_, err := queries.GetAllProducts(ctx)
+	_, err := queries.ListProductsByCreatedAt(ctx, db.ListProductsByCreatedAtParams{Limit: 10})
 	assert.NoError(t, err) // Should not error even if empty
 }
 
@@ -1,8 +1,11 @@
 package postgres
 
 import (
+	"html"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -18,3 +21,71 @@ func timeFromTimestamptz(ts pgtype.Timestamptz) time.Time {
 	}
 	return time.Time{}
 }
+
+// nullableUUID maps uuid.Nil to SQL NULL, for optional filter arguments.
+func nullableUUID(id uuid.UUID) pgtype.UUID {
+	if id == uuid.Nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+// nullableText maps the empty string to SQL NULL.
+func nullableText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func nullableInt8(i *int64) pgtype.Int8 {
+	if i == nil {
+		return pgtype.Int8{}
+	}
+	return pgtype.Int8{Int64: *i, Valid: true}
+}
+
+// nullableTimestamptz maps nil to SQL NULL.
+func nullableTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return timestamptzFromTime(*t)
+}
+
+// nullableTime maps the zero time to SQL NULL.
+func nullableTime(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: !t.IsZero()}
+}
+
+const (
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+)
+
+// prefixTsQuery builds a to_tsquery expression that requires every term,
+// each as a quoted prefix so that to_tsquery stems it but reads no
+// operators from it.
+func prefixTsQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		term = strings.ReplaceAll(term, `\`, `\\`)
+		quoted[i] = "'" + strings.ReplaceAll(term, "'", "''") + "':*"
+	}
+	return strings.Join(quoted, " & ")
+}
+
+// escapeHeadline HTML-escapes a ts_headline result except for the
+// highlight markers it inserted.
+func escapeHeadline(headline string) string {
+	var escaped strings.Builder
+	for i, part := range strings.Split(headline, highlightStart) {
+		if i > 0 {
+			escaped.WriteString(highlightStart)
+		}
+		for j, piece := range strings.Split(part, highlightStop) {
+			if j > 0 {
+				escaped.WriteString(highlightStop)
+			}
+			escaped.WriteString(html.EscapeString(piece))
+		}
+	}
+	return escaped.String()
+}
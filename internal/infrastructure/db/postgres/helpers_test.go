@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 )
@@ -59,3 +60,43 @@ func TestTimestamptz_RoundTrip(t *testing.T) {
 	resultTime := timeFromTimestamptz(pgTime)
 	assert.True(t, originalTime.Equal(resultTime))
 }
+
+func TestNullableUUID(t *testing.T) {
+	assert.False(t, nullableUUID(uuid.Nil).Valid)
+
+	id := uuid.New()
+	value := nullableUUID(id)
+	assert.True(t, value.Valid)
+	assert.Equal(t, [16]byte(id), value.Bytes)
+}
+
+func TestNullableText(t *testing.T) {
+	assert.False(t, nullableText("").Valid)
+	assert.Equal(t, pgtype.Text{String: "EUR", Valid: true}, nullableText("EUR"))
+}
+
+func TestNullableInt8(t *testing.T) {
+	assert.False(t, nullableInt8(nil).Valid)
+
+	amount := int64(0)
+	assert.Equal(t, pgtype.Int8{Int64: 0, Valid: true}, nullableInt8(&amount))
+}
+
+func TestNullableTimestamptz(t *testing.T) {
+	assert.False(t, nullableTimestamptz(nil).Valid)
+
+	now := time.Now()
+	value := nullableTimestamptz(&now)
+	assert.True(t, value.Valid)
+	assert.True(t, value.Time.Equal(now))
+}
+
+func TestPrefixTsQuery(t *testing.T) {
+	assert.Equal(t, "'oak':* & 'chair':*", prefixTsQuery([]string{"oak", "chair"}))
+	assert.Equal(t, `'it''s':* & 'a\\b':*`, prefixTsQuery([]string{"it's", `a\b`}))
+}
+
+func TestEscapeHeadline(t *testing.T) {
+	assert.Equal(t, "<mark>Oak</mark> &lt;Chair&gt; &amp; <mark>oaks</mark>", escapeHeadline("<mark>Oak</mark> <Chair> & <mark>oaks</mark>"))
+	assert.Equal(t, "Plain", escapeHeadline("Plain"))
+}
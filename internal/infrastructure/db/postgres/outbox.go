@@ -19,11 +19,12 @@ func insertOutboxEvents(ctx context.Context, queries *db.Queries, domainEvents [
 		}
 
 		if err := queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
-			ID:          event.EventId(),
-			AggregateID: event.AggregateId(),
-			EventName:   event.EventName(),
-			Payload:     payload,
-			OccurredAt:  timestamptzFromTime(event.OccurredAt()),
+			ID:            event.EventId(),
+			AggregateID:   event.AggregateId(),
+			EventName:     event.EventName(),
+			SchemaVersion: int32(event.SchemaVersion()),
+			Payload:       payload,
+			OccurredAt:    timestamptzFromTime(event.OccurredAt()),
 		}); err != nil {
 			return err
 		}
@@ -31,11 +31,12 @@ func TestMigration000004_RewritesPendingOutboxPayloads(t *testing.T) {
 	legacyPayload := `{"Id":"0198c0de-0000-7000-8000-000000000001","Aggregate":"0198c0de-0000-7000-8000-000000000002","OccurredAtT":"2026-07-01T00:00:00Z","Name":"Legacy Product","PriceCents":4999,"Currency":"EUR","SellerId":"0198c0de-0000-7000-8000-000000000003"}`
 	eventId := uuid.Must(uuid.NewV7())
 	require.NoError(t, testDB.Queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
-		ID:          eventId,
-		AggregateID: uuid.Must(uuid.NewV7()),
-		EventName:   "product.created",
-		Payload:     []byte(legacyPayload),
-		OccurredAt:  timestamptzFromTime(time.Now()),
+		ID:            eventId,
+		AggregateID:   uuid.Must(uuid.NewV7()),
+		EventName:     "product.created",
+		SchemaVersion: 1,
+		Payload:       []byte(legacyPayload),
+		OccurredAt:    timestamptzFromTime(time.Now()),
 	}))
 
 	migration, err := os.ReadFile(filepath.Join(testhelpers.ProjectRoot(t), "migrations", "000004_price_minor_units.up.sql"))
@@ -58,6 +59,49 @@ func TestMigration000004_RewritesPendingOutboxPayloads(t *testing.T) {
 	assert.Equal(t, `"Legacy Product"`, string(payload["Name"]))
 }
 
+// The CloudEvents migration strips envelope fields from stored payloads and
+// renames the rest to the snake_case data contract, including nested Money.
+func TestMigration000011_RewritesPayloadsToSnakeCaseData(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	ctx := context.Background()
+
+	legacy := map[string]string{
+		"product.price_changed": `{"Id":"0198c0de-0000-7000-8000-000000000001","Aggregate":"0198c0de-0000-7000-8000-000000000002","OccurredAtT":"2026-07-01T00:00:00Z","OldPrice":{"MinorUnits":999,"Currency":"EUR"},"NewPrice":{"MinorUnits":1299,"Currency":"EUR"}}`,
+		"seller.deleted":        `{"Id":"0198c0de-0000-7000-8000-000000000003","Aggregate":"0198c0de-0000-7000-8000-000000000004","OccurredAtT":"2026-07-01T00:00:00Z"}`,
+		"product.created":       `{"name":"Already Migrated","price_minor_units":1,"currency":"EUR"}`,
+	}
+	for eventName, payload := range legacy {
+		require.NoError(t, testDB.Queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateID:   uuid.Must(uuid.NewV7()),
+			EventName:     eventName,
+			SchemaVersion: 1,
+			Payload:       []byte(payload),
+			OccurredAt:    timestamptzFromTime(time.Now()),
+		}))
+	}
+
+	migration, err := os.ReadFile(filepath.Join(testhelpers.ProjectRoot(t), "migrations", "000011_outbox_cloudevents.up.sql"))
+	require.NoError(t, err)
+	_, err = testDB.Pool.Exec(ctx, extractOutboxUpdate(t, string(migration)))
+	require.NoError(t, err)
+
+	events, err := testDB.Queries.GetUnpublishedOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, len(legacy))
+
+	expected := map[string]string{
+		"product.price_changed": `{"old_price":{"minor_units":999,"currency":"EUR"},"new_price":{"minor_units":1299,"currency":"EUR"}}`,
+		"seller.deleted":        `{}`,
+		"product.created":       legacy["product.created"],
+	}
+	for _, event := range events {
+		assert.JSONEq(t, expected[event.EventName], string(event.Payload), event.EventName)
+	}
+}
+
 // extractOutboxUpdate pulls the UPDATE statement out of the migration file
 // so the test always runs what the migration actually ships.
 func extractOutboxUpdate(t *testing.T, migration string) string {
@@ -5,13 +5,39 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
 	"github.com/sklinkert/go-ddd/internal/testhelpers"
 )
 
+// unpublishedEventsFor returns the pending outbox rows of one aggregate, in
+// occurrence order.
+func unpublishedEventsFor(t *testing.T, testDB *testhelpers.PostgresTestContainer, aggregateId uuid.UUID) []db.GetUnpublishedOutboxEventsRow {
+	t.Helper()
+	all, err := testDB.Queries.GetUnpublishedOutboxEvents(context.Background(), 100)
+	require.NoError(t, err)
+
+	var events []db.GetUnpublishedOutboxEventsRow
+	for _, event := range all {
+		if event.AggregateID == aggregateId {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func eventNames(events []db.GetUnpublishedOutboxEventsRow) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, event.EventName)
+	}
+	return names
+}
+
 func TestSqlcProductRepository_Create_WritesOutboxEvent(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
@@ -27,8 +53,7 @@ func TestSqlcProductRepository_Create_WritesOutboxEvent(t *testing.T) {
 	require.NoError(t, err)
 
 	// The ProductCreated event must be committed together with the product.
-	events, err := testDB.Queries.GetUnpublishedOutboxEvents(context.Background(), 10)
-	require.NoError(t, err)
+	events := unpublishedEventsFor(t, testDB, product.Id)
 	require.Len(t, events, 1)
 
 	event := events[0]
@@ -37,9 +62,9 @@ func TestSqlcProductRepository_Create_WritesOutboxEvent(t *testing.T) {
 	assert.False(t, event.PublishedAt.Valid)
 
 	var payload struct {
-		Name            string `json:"Name"`
-		PriceMinorUnits int64  `json:"PriceMinorUnits"`
-		Currency        string `json:"Currency"`
+		Name            string `json:"name"`
+		PriceMinorUnits int64  `json:"price_minor_units"`
+		Currency        string `json:"currency"`
 	}
 	require.NoError(t, json.Unmarshal(event.Payload, &payload))
 	assert.Equal(t, "Outbox Product", payload.Name)
@@ -48,7 +73,109 @@ func TestSqlcProductRepository_Create_WritesOutboxEvent(t *testing.T) {
 
 	// Marking published removes it from the unpublished set (relay behavior).
 	require.NoError(t, testDB.Queries.MarkOutboxEventPublished(context.Background(), event.ID))
-	remaining, err := testDB.Queries.GetUnpublishedOutboxEvents(context.Background(), 10)
+	assert.Empty(t, unpublishedEventsFor(t, testDB, product.Id))
+}
+
+func TestSqlcProductRepository_UpdateAndDelete_WriteOutboxEvents(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcProductRepository(testDB.Pool)
+	validatedSeller := createTestSeller(t, testDB, "Outbox Seller")
+	otherSeller := createTestSeller(t, testDB, "Other Seller")
+
+	product := entities.NewProduct("Outbox Product", mustMoney(t, 1299, entities.EUR), *validatedSeller)
+	validatedProduct, err := entities.NewValidatedProduct(product)
+	require.NoError(t, err)
+	created, err := repo.Create(context.Background(), validatedProduct)
+	require.NoError(t, err)
+
+	require.NoError(t, created.UpdateName("Renamed Product"))
+	require.NoError(t, created.UpdatePrice(mustMoney(t, 1499, entities.EUR)))
+	require.NoError(t, created.AssignSeller(*otherSeller))
+	validatedUpdate, err := entities.NewValidatedProduct(created)
+	require.NoError(t, err)
+	updated, err := repo.Update(context.Background(), validatedUpdate)
+	require.NoError(t, err)
+
+	updated.Delete()
+	require.NoError(t, repo.Delete(context.Background(), updated))
+
+	// Events recorded within the same microsecond may tie on occurred_at,
+	// so only the set is asserted here.
+	events := unpublishedEventsFor(t, testDB, product.Id)
+	assert.ElementsMatch(t, []string{
+		"product.created",
+		"product.renamed",
+		"product.price_changed",
+		"product.reassigned",
+		"product.deleted",
+	}, eventNames(events))
+
+	var priceChanged struct {
+		OldPrice struct {
+			MinorUnits int64 `json:"minor_units"`
+		} `json:"old_price"`
+		NewPrice struct {
+			MinorUnits int64 `json:"minor_units"`
+		} `json:"new_price"`
+	}
+	for _, event := range events {
+		if event.EventName == "product.price_changed" {
+			require.NoError(t, json.Unmarshal(event.Payload, &priceChanged))
+		}
+	}
+	assert.Equal(t, int64(1299), priceChanged.OldPrice.MinorUnits)
+	assert.Equal(t, int64(1499), priceChanged.NewPrice.MinorUnits)
+}
+
+func TestSqlcProductRepository_Update_ConflictWritesNoEvents(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcProductRepository(testDB.Pool)
+	validatedSeller := createTestSeller(t, testDB, "Outbox Seller")
+
+	product := entities.NewProduct("Outbox Product", mustMoney(t, 1299, entities.EUR), *validatedSeller)
+	validatedProduct, err := entities.NewValidatedProduct(product)
+	require.NoError(t, err)
+	created, err := repo.Create(context.Background(), validatedProduct)
+	require.NoError(t, err)
+
+	// A stale version rolls back the whole transaction, events included.
+	created.Version++
+	require.NoError(t, created.UpdateName("Lost Update"))
+	validatedUpdate, err := entities.NewValidatedProduct(created)
 	require.NoError(t, err)
-	assert.Empty(t, remaining)
+	_, err = repo.Update(context.Background(), validatedUpdate)
+	require.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	assert.Equal(t, []string{"product.created"}, eventNames(unpublishedEventsFor(t, testDB, product.Id)))
+}
+
+func TestSqlcSellerRepository_WritesOutboxEvents(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcSellerRepository(testDB.Pool)
+
+	validatedSeller, err := entities.NewValidatedSeller(entities.NewSeller("Outbox Seller"))
+	require.NoError(t, err)
+	created, err := repo.Create(context.Background(), validatedSeller)
+	require.NoError(t, err)
+
+	require.NoError(t, created.UpdateName("Renamed Seller"))
+	validatedUpdate, err := entities.NewValidatedSeller(created)
+	require.NoError(t, err)
+	updated, err := repo.Update(context.Background(), validatedUpdate)
+	require.NoError(t, err)
+
+	updated.Delete()
+	require.NoError(t, repo.Delete(context.Background(), updated))
+
+	assert.ElementsMatch(t, []string{
+		"seller.created",
+		"seller.renamed",
+		"seller.deleted",
+	}, eventNames(unpublishedEventsFor(t, testDB, validatedSeller.Id)))
 }
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+type SqlcBlobDeletionRepository struct {
+	queries *db.Queries
+}
+
+func NewSqlcBlobDeletionRepository(queries *db.Queries) repositories.BlobDeletionRepository {
+	return &SqlcBlobDeletionRepository{queries: queries}
+}
+
+func (r *SqlcBlobDeletionRepository) FindDue(ctx context.Context, limit int) ([]string, error) {
+	return r.queries.ListDueBlobDeletions(ctx, int32(limit))
+}
+
+func (r *SqlcBlobDeletionRepository) Remove(ctx context.Context, key string) error {
+	return r.queries.DeleteBlobDeletion(ctx, key)
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+type SqlcCartRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewSqlcCartRepository(pool *pgxpool.Pool) repositories.CartRepository {
+	return &SqlcCartRepository{pool: pool, queries: db.New(pool)}
+}
+
+func (repo *SqlcCartRepository) FindByBuyerId(ctx context.Context, buyerId uuid.UUID) (*entities.Cart, error) {
+	cart, err := findCart(ctx, queriesFor(ctx, repo.queries), buyerId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return cart, err
+}
+
+// Save writes the cart and replaces its lines in one transaction. Carts are
+// small, so rewriting all lines is simpler than diffing them.
+func (repo *SqlcCartRepository) Save(ctx context.Context, cart *entities.ValidatedCart) (*entities.Cart, error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	rows, err := qtx.UpsertCart(ctx, db.UpsertCartParams{
+		BuyerID:   cart.BuyerId,
+		CreatedAt: timestamptzFromTime(cart.CreatedAt),
+		UpdatedAt: timestamptzFromTime(cart.UpdatedAt),
+		ExpiresAt: timestamptzFromTime(cart.ExpiresAt),
+		Version:   int32(cart.Version),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, entities.ErrVersionConflict
+	}
+
+	if err := qtx.DeleteCartLines(ctx, cart.BuyerId); err != nil {
+		return nil, err
+	}
+	for position, line := range cart.Lines {
+		if err := qtx.InsertCartLine(ctx, db.InsertCartLineParams{
+			BuyerID:             cart.BuyerId,
+			Position:            int32(position),
+			ProductID:           line.ProductId,
+			VariantID:           line.VariantId,
+			Sku:                 line.Sku,
+			ProductName:         line.ProductName,
+			UnitPriceMinorUnits: line.UnitPrice.MinorUnits(),
+			Currency:            string(line.UnitPrice.Currency()),
+			Quantity:            int32(line.Quantity),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	saved, err := findCart(ctx, qtx, cart.BuyerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (repo *SqlcCartRepository) Delete(ctx context.Context, cart *entities.Cart) error {
+	rows, err := queriesFor(ctx, repo.queries).DeleteCart(ctx, db.DeleteCartParams{
+		BuyerID: cart.BuyerId,
+		Version: int32(cart.Version),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (repo *SqlcCartRepository) DeleteExpired(ctx context.Context, limit int) (int, error) {
+	rows, err := queriesFor(ctx, repo.queries).DeleteExpiredCarts(ctx, int32(limit))
+	return int(rows), err
+}
+
+func findCart(ctx context.Context, queries *db.Queries, buyerId uuid.UUID) (*entities.Cart, error) {
+	dbCart, err := queries.GetCart(ctx, buyerId)
+	if err != nil {
+		return nil, err
+	}
+	dbLines, err := queries.ListCartLines(ctx, buyerId)
+	if err != nil {
+		return nil, err
+	}
+
+	cart := &entities.Cart{
+		BuyerId:   dbCart.BuyerID,
+		CreatedAt: timeFromTimestamptz(dbCart.CreatedAt),
+		UpdatedAt: timeFromTimestamptz(dbCart.UpdatedAt),
+		ExpiresAt: timeFromTimestamptz(dbCart.ExpiresAt),
+		Version:   int(dbCart.Version),
+	}
+	for _, dbLine := range dbLines {
+		unitPrice, err := entities.NewMoney(dbLine.UnitPriceMinorUnits, entities.Currency(dbLine.Currency))
+		if err != nil {
+			return nil, err
+		}
+		cart.Lines = append(cart.Lines, entities.CartLine{
+			ProductId:   dbLine.ProductID,
+			VariantId:   dbLine.VariantID,
+			Sku:         dbLine.Sku,
+			ProductName: dbLine.ProductName,
+			UnitPrice:   unitPrice,
+			Quantity:    int(dbLine.Quantity),
+		})
+	}
+
+	return cart, nil
+}
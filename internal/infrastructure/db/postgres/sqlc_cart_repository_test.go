@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func saveTestCart(t *testing.T, repo *SqlcCartRepository, cart *entities.Cart) *entities.Cart {
+	t.Helper()
+	validated, err := entities.NewValidatedCart(cart)
+	require.NoError(t, err)
+	saved, err := repo.Save(context.Background(), validated)
+	require.NoError(t, err)
+	return saved
+}
+
+func TestSqlcCartRepository_SaveAndFind(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcCartRepository(testDB.Pool).(*SqlcCartRepository)
+	ctx := context.Background()
+	product := createTestProduct(t, testDB)
+	buyerId := uuid.New()
+
+	missing, err := repo.FindByBuyerId(ctx, buyerId)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// Only published products go into carts; the stored status does not
+	// matter to the cart repository.
+	require.NoError(t, product.Publish())
+	cart := entities.NewCart(buyerId, time.Hour)
+	require.NoError(t, cart.AddItem(&product.Product, uuid.Nil, 2, nil, time.Now()))
+	saved := saveTestCart(t, repo, cart)
+	assert.Equal(t, 1, saved.Version)
+	require.Len(t, saved.Lines, 1)
+	assert.Equal(t, product.Price, saved.Lines[0].UnitPrice)
+	assert.Equal(t, 2, saved.Lines[0].Quantity)
+	assert.Equal(t, product.Variants[0].Id, saved.Lines[0].VariantId)
+	assert.Equal(t, product.Variants[0].Sku, saved.Lines[0].Sku)
+
+	require.NoError(t, saved.SetQuantity(product.Id, uuid.Nil, 5))
+	updated := saveTestCart(t, repo, saved)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, 5, updated.Lines[0].Quantity)
+
+	stale, err := entities.NewValidatedCart(saved)
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, stale)
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	fresh, err := entities.NewValidatedCart(entities.NewCart(buyerId, time.Hour))
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, fresh)
+	assert.ErrorIs(t, err, entities.ErrVersionConflict, "a second new cart for the same buyer loses")
+}
+
+func TestSqlcCartRepository_Delete(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcCartRepository(testDB.Pool).(*SqlcCartRepository)
+	ctx := context.Background()
+	saved := saveTestCart(t, repo, entities.NewCart(uuid.New(), time.Hour))
+
+	stale := *saved
+	stale.Version = 7
+	assert.ErrorIs(t, repo.Delete(ctx, &stale), entities.ErrVersionConflict)
+	require.NoError(t, repo.Delete(ctx, saved))
+
+	found, err := repo.FindByBuyerId(ctx, saved.BuyerId)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestSqlcCartRepository_DeleteExpired(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcCartRepository(testDB.Pool).(*SqlcCartRepository)
+	ctx := context.Background()
+	expired := saveTestCart(t, repo, entities.NewCart(uuid.New(), -time.Minute))
+	live := saveTestCart(t, repo, entities.NewCart(uuid.New(), time.Hour))
+
+	deleted, err := repo.DeleteExpired(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	found, err := repo.FindByBuyerId(ctx, expired.BuyerId)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	found, err = repo.FindByBuyerId(ctx, live.BuyerId)
+	require.NoError(t, err)
+	assert.NotNil(t, found)
+}
@@ -0,0 +1,306 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+type SqlcCategoryRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewSqlcCategoryRepository(pool *pgxpool.Pool) repositories.CategoryRepository {
+	return &SqlcCategoryRepository{pool: pool, queries: db.New(pool)}
+}
+
+// Create persists the category and its recorded domain events in one
+// transaction. The stored path is derived from the parent's current path
+// with the parent locked, so a concurrent move of the parent cannot leave
+// the new category with a stale one.
+func (repo *SqlcCategoryRepository) Create(ctx context.Context, category *entities.ValidatedCategory) (*entities.Category, error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	path := entities.CategoryPath("", category.Id)
+	if category.ParentId != nil {
+		paths, err := lockCategoryPaths(ctx, qtx, *category.ParentId)
+		if err != nil {
+			return nil, err
+		}
+		parentPath, ok := paths[*category.ParentId]
+		if !ok {
+			return nil, entities.ErrCategoryNotFound
+		}
+		path = entities.CategoryPath(parentPath, category.Id)
+	}
+
+	if err := qtx.CreateCategory(ctx, db.CreateCategoryParams{
+		ID:        category.Id,
+		Name:      category.Name,
+		ParentID:  parentIdParam(category.ParentId),
+		Path:      path,
+		CreatedAt: timestamptzFromTime(category.CreatedAt),
+		UpdatedAt: timestamptzFromTime(category.UpdatedAt),
+		Version:   int32(category.Version),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, category.PullEvents()); err != nil {
+		return nil, err
+	}
+
+	row, err := qtx.GetCategoryById(ctx, category.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return categoryFromRow(row), nil
+}
+
+func (repo *SqlcCategoryRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	row, err := queriesFor(ctx, repo.queries).GetCategoryById(ctx, id)
+	if err != nil {
+		// A missing row is not an error: return (nil, nil) so callers can
+		// translate it into a 404 instead of a 500.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return categoryFromRow(row), nil
+}
+
+func (repo *SqlcCategoryRepository) FindChildren(ctx context.Context, parentId uuid.UUID) ([]*entities.Category, error) {
+	rows, err := queriesFor(ctx, repo.queries).ListCategoriesByParent(ctx, nullableUUID(parentId))
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]*entities.Category, 0, len(rows))
+	for _, row := range rows {
+		categories = append(categories, categoryFromRow(row))
+	}
+
+	return categories, nil
+}
+
+// Update writes the category and its recorded events in one transaction.
+// A move locks the category and its new parent in id order, checks the
+// move against the stored paths — a concurrent move may have put the
+// parent below the category in the meantime — and rewrites the paths of
+// the whole subtree. The search data of the products in the subtree is
+// refreshed along with it.
+func (repo *SqlcCategoryRepository) Update(ctx context.Context, category *entities.ValidatedCategory) (*entities.Category, error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	domainEvents := category.PullEvents()
+	moved := slices.ContainsFunc(domainEvents, func(event events.DomainEvent) bool {
+		_, ok := event.(events.CategoryMoved)
+		return ok
+	})
+
+	var oldPath, newPath string
+	if moved {
+		ids := []uuid.UUID{category.Id}
+		if category.ParentId != nil {
+			ids = append(ids, *category.ParentId)
+		}
+		paths, err := lockCategoryPaths(ctx, qtx, ids...)
+		if err != nil {
+			return nil, err
+		}
+
+		var ok bool
+		if oldPath, ok = paths[category.Id]; !ok {
+			return nil, entities.ErrCategoryNotFound
+		}
+		newPath = entities.CategoryPath("", category.Id)
+		if category.ParentId != nil {
+			parentPath, ok := paths[*category.ParentId]
+			if !ok {
+				return nil, entities.ErrCategoryNotFound
+			}
+			if strings.HasPrefix(parentPath, oldPath) {
+				return nil, fmt.Errorf("%w: %s cannot move below itself", entities.ErrCategoryCycle, category.Name)
+			}
+			newPath = entities.CategoryPath(parentPath, category.Id)
+		}
+	}
+
+	rows, err := qtx.UpdateCategory(ctx, db.UpdateCategoryParams{
+		ID:        category.Id,
+		Name:      category.Name,
+		ParentID:  parentIdParam(category.ParentId),
+		UpdatedAt: timestamptzFromTime(category.UpdatedAt),
+		Version:   int32(category.Version),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, missedCategoryWrite(ctx, qtx, category.Id)
+	}
+
+	if moved {
+		if err := qtx.MoveCategorySubtree(ctx, db.MoveCategorySubtreeParams{
+			NewPath: newPath,
+			OldPath: oldPath,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, domainEvents); err != nil {
+		return nil, err
+	}
+
+	row, err := qtx.GetCategoryById(ctx, category.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The products below the category carry its name, and after a move
+	// those of its new ancestors, in their search vectors.
+	if err := qtx.RefreshCategorizedProductNames(ctx, row.Path); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return categoryFromRow(row), nil
+}
+
+// Delete removes the category and stores its recorded events (e.g.
+// CategoryDeleted) in the same transaction. The emptiness check is part of
+// the delete statement; the foreign keys of subcategories and product
+// assignments back it up. Deleting a category that no longer exists is a
+// no-op and publishes nothing.
+func (repo *SqlcCategoryRepository) Delete(ctx context.Context, category *entities.Category) error {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	rows, err := qtx.DeleteCategory(ctx, db.DeleteCategoryParams{
+		ID:      category.Id,
+		Version: int32(category.Version),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		err := missedCategoryWrite(ctx, qtx, category.Id)
+		if errors.Is(err, entities.ErrCategoryNotFound) {
+			return nil
+		}
+		if !errors.Is(err, entities.ErrVersionConflict) {
+			return err
+		}
+
+		hasContents, err := qtx.CategoryHasContents(ctx, category.Id)
+		if err != nil {
+			return err
+		}
+		if hasContents {
+			return entities.ErrCategoryNotEmpty
+		}
+		return entities.ErrVersionConflict
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, category.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// lockCategoryPaths locks the categories for the rest of the transaction and
+// returns their stored paths by id; missing categories are left out.
+func lockCategoryPaths(ctx context.Context, queries *db.Queries, ids ...uuid.UUID) (map[uuid.UUID]string, error) {
+	rows, err := queries.LockCategoryPaths(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[uuid.UUID]string, len(rows))
+	for _, row := range rows {
+		paths[row.ID] = row.Path
+	}
+
+	return paths, nil
+}
+
+// missedCategoryWrite explains a versioned write that matched no row: either
+// the category is gone or someone else bumped the version.
+func missedCategoryWrite(ctx context.Context, queries *db.Queries, id uuid.UUID) error {
+	exists, err := queries.CategoryExists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return entities.ErrCategoryNotFound
+	}
+
+	return entities.ErrVersionConflict
+}
+
+// parentIdParam maps a root category's nil parent to SQL NULL.
+func parentIdParam(parentId *uuid.UUID) pgtype.UUID {
+	if parentId == nil {
+		return pgtype.UUID{}
+	}
+	return nullableUUID(*parentId)
+}
+
+func categoryFromRow(row db.Category) *entities.Category {
+	category := &entities.Category{
+		Id:        row.ID,
+		Name:      row.Name,
+		Path:      row.Path,
+		CreatedAt: timeFromTimestamptz(row.CreatedAt),
+		UpdatedAt: timeFromTimestamptz(row.UpdatedAt),
+		Version:   int(row.Version),
+	}
+	if row.ParentID.Valid {
+		parentId := uuid.UUID(row.ParentID.Bytes)
+		category.ParentId = &parentId
+	}
+
+	return category
+}
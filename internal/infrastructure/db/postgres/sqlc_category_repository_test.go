@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func createTestCategory(t *testing.T, repo *SqlcCategoryRepository, name string, parent *entities.Category) *entities.Category {
+	t.Helper()
+	validated, err := entities.NewValidatedCategory(entities.NewCategory(name, parent))
+	require.NoError(t, err)
+	created, err := repo.Create(context.Background(), validated)
+	require.NoError(t, err)
+	return created
+}
+
+func TestSqlcCategoryRepository_CreateAndFind(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcCategoryRepository(testDB.Pool).(*SqlcCategoryRepository)
+	ctx := context.Background()
+
+	furniture := createTestCategory(t, repo, "Furniture", nil)
+	garden := createTestCategory(t, repo, "Garden", nil)
+	chairs := createTestCategory(t, repo, "Chairs", furniture)
+	tables := createTestCategory(t, repo, "Tables", furniture)
+
+	found, err := repo.FindById(ctx, chairs.Id)
+	require.NoError(t, err)
+	require.NotNil(t, found.ParentId)
+	assert.Equal(t, furniture.Id, *found.ParentId)
+	assert.Equal(t, furniture.Path+chairs.Id.String()+"/", found.Path)
+
+	missing, err := repo.FindById(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	roots, err := repo.FindChildren(ctx, uuid.Nil)
+	require.NoError(t, err)
+	require.Len(t, roots, 2)
+	assert.Equal(t, []uuid.UUID{furniture.Id, garden.Id}, []uuid.UUID{roots[0].Id, roots[1].Id})
+
+	children, err := repo.FindChildren(ctx, furniture.Id)
+	require.NoError(t, err)
+	require.Len(t, children, 2)
+	assert.Equal(t, []uuid.UUID{chairs.Id, tables.Id}, []uuid.UUID{children[0].Id, children[1].Id}, "by name")
+
+	orphan, err := entities.NewValidatedCategory(entities.NewCategory("Orphan", entities.NewCategory("Unsaved", nil)))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, orphan)
+	assert.ErrorIs(t, err, entities.ErrCategoryNotFound)
+}
+
+func TestSqlcCategoryRepository_MoveRewritesSubtree(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcCategoryRepository(testDB.Pool).(*SqlcCategoryRepository)
+	ctx := context.Background()
+
+	furniture := createTestCategory(t, repo, "Furniture", nil)
+	office := createTestCategory(t, repo, "Office", nil)
+	chairs := createTestCategory(t, repo, "Chairs", furniture)
+	swivel := createTestCategory(t, repo, "Swivel chairs", chairs)
+
+	require.NoError(t, chairs.MoveTo(office))
+	validated, err := entities.NewValidatedCategory(chairs)
+	require.NoError(t, err)
+	moved, err := repo.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.Equal(t, office.Path+chairs.Id.String()+"/", moved.Path)
+	assert.Equal(t, 2, moved.Version)
+
+	descendant, err := repo.FindById(ctx, swivel.Id)
+	require.NoError(t, err)
+	assert.Equal(t, moved.Path+swivel.Id.String()+"/", descendant.Path)
+	assert.Equal(t, []uuid.UUID{office.Id, chairs.Id}, descendant.AncestorIds())
+	assert.Equal(t, 1, descendant.Version, "descendants keep their version")
+
+	// swivel was loaded before chairs moved below office, so the domain
+	// cannot tell that moving office below it is a cycle; the stored paths
+	// can.
+	staleOffice := *office
+	require.NoError(t, office.MoveTo(swivel))
+	validated, err = entities.NewValidatedCategory(office)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, validated)
+	assert.ErrorIs(t, err, entities.ErrCategoryCycle)
+
+	require.NoError(t, staleOffice.Rename("Office furniture"))
+	validated, err = entities.NewValidatedCategory(&staleOffice)
+	require.NoError(t, err)
+	renamed, err := repo.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.Equal(t, "/"+office.Id.String()+"/", renamed.Path, "a rename keeps the stored path")
+}
+
+func TestSqlcCategoryRepository_Delete(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcCategoryRepository(testDB.Pool).(*SqlcCategoryRepository)
+	productRepo := NewSqlcProductRepository(testDB.Pool)
+	ctx := context.Background()
+
+	furniture := createTestCategory(t, repo, "Furniture", nil)
+	chairs := createTestCategory(t, repo, "Chairs", furniture)
+
+	assert.ErrorIs(t, repo.Delete(ctx, furniture), entities.ErrCategoryNotEmpty, "has a subcategory")
+
+	product := createTestProduct(t, testDB)
+	require.NoError(t, product.Categorize([]uuid.UUID{chairs.Id}))
+	validatedProduct, err := entities.NewValidatedProduct(&product.Product)
+	require.NoError(t, err)
+	categorized, err := productRepo.Update(ctx, validatedProduct)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{chairs.Id}, categorized.CategoryIds)
+
+	assert.ErrorIs(t, repo.Delete(ctx, chairs), entities.ErrCategoryNotEmpty, "has a product")
+
+	categorized.Delete()
+	require.NoError(t, productRepo.Delete(ctx, categorized))
+
+	stale := *chairs
+	stale.Version = 0
+	assert.ErrorIs(t, repo.Delete(ctx, &stale), entities.ErrVersionConflict)
+
+	require.NoError(t, repo.Delete(ctx, chairs))
+	require.NoError(t, repo.Delete(ctx, furniture))
+	require.NoError(t, repo.Delete(ctx, furniture), "deleting twice is a no-op")
+
+	found, err := repo.FindById(ctx, furniture.Id)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
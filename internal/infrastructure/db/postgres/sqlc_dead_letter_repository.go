@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+// SqlcDeadLetterRepository lets operators inspect dead-lettered events and
+// decide per event: replay it (e.g. after fixing the consumer) or discard
+// it.
+type SqlcDeadLetterRepository struct {
+	queries *db.Queries
+}
+
+func NewSqlcDeadLetterRepository(queries *db.Queries) repositories.DeadLetterRepository {
+	return &SqlcDeadLetterRepository{queries: queries}
+}
+
+func (r *SqlcDeadLetterRepository) FindAll(ctx context.Context, limit int) ([]*entities.DeadLetter, error) {
+	rows, err := queriesFor(ctx, r.queries).ListDeadLetteredOutboxEvents(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetters := make([]*entities.DeadLetter, 0, len(rows))
+	for _, row := range rows {
+		deadLetters = append(deadLetters, &entities.DeadLetter{
+			Id:             row.ID,
+			AggregateId:    row.AggregateID,
+			EventName:      row.EventName,
+			Payload:        row.Payload,
+			OccurredAt:     timeFromTimestamptz(row.OccurredAt),
+			Attempts:       int(row.Attempts),
+			LastError:      row.LastError.String,
+			DeadLetteredAt: timeFromTimestamptz(row.DeadLetteredAt),
+		})
+	}
+
+	return deadLetters, nil
+}
+
+func (r *SqlcDeadLetterRepository) Replay(ctx context.Context, id uuid.UUID) error {
+	rows, err := queriesFor(ctx, r.queries).ReplayDeadLetteredOutboxEvent(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrDeadLetterNotFound
+	}
+
+	return nil
+}
+
+func (r *SqlcDeadLetterRepository) Discard(ctx context.Context, id uuid.UUID) error {
+	rows, err := queriesFor(ctx, r.queries).DiscardDeadLetteredOutboxEvent(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrDeadLetterNotFound
+	}
+
+	return nil
+}
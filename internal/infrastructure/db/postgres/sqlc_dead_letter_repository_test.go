@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+// insertDeadLetter stores an outbox event the relay gave up on.
+func insertDeadLetter(t *testing.T, testDB *testhelpers.PostgresTestContainer) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+	id := uuid.Must(uuid.NewV7())
+	require.NoError(t, testDB.Queries.InsertOutboxEvent(ctx, db.InsertOutboxEventParams{
+		ID:            id,
+		AggregateID:   uuid.New(),
+		EventName:     "test.event",
+		SchemaVersion: 1,
+		Payload:       []byte(`{}`),
+		OccurredAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}))
+	_, err := testDB.Pool.Exec(ctx,
+		`UPDATE outbox_events SET attempts = 2, last_error = 'broker unavailable', dead_lettered_at = NOW() WHERE id = $1`, id)
+	require.NoError(t, err)
+	return id
+}
+
+func TestSqlcDeadLetterRepository_ReplayAndDiscard(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	first, second := insertDeadLetter(t, testDB), insertDeadLetter(t, testDB)
+	repo := NewSqlcDeadLetterRepository(testDB.Queries)
+
+	listed, err := repo.FindAll(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, first, listed[0].Id, "oldest first")
+	assert.Equal(t, 2, listed[0].Attempts)
+	assert.Equal(t, "broker unavailable", listed[0].LastError)
+
+	require.NoError(t, repo.Replay(ctx, first))
+	require.NoError(t, repo.Discard(ctx, second))
+	assert.ErrorIs(t, repo.Replay(ctx, second), entities.ErrDeadLetterNotFound)
+	assert.ErrorIs(t, repo.Discard(ctx, uuid.New()), entities.ErrDeadLetterNotFound)
+
+	listed, err = repo.FindAll(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	// The replayed event is pending again, with a fresh attempt budget.
+	var attempts int
+	require.NoError(t, testDB.Pool.QueryRow(ctx,
+		`SELECT attempts FROM outbox_events WHERE id = $1 AND published_at IS NULL`, first).Scan(&attempts))
+	assert.Zero(t, attempts)
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+type SqlcExchangeRateRepository struct {
+	queries *db.Queries
+}
+
+func NewSqlcExchangeRateRepository(pool *pgxpool.Pool) repositories.ExchangeRateRepository {
+	return &SqlcExchangeRateRepository{queries: db.New(pool)}
+}
+
+func (repo *SqlcExchangeRateRepository) Rate(ctx context.Context, from, to entities.Currency, at time.Time) (entities.ExchangeRate, error) {
+	row, err := queriesFor(ctx, repo.queries).GetExchangeRate(ctx, db.GetExchangeRateParams{
+		BaseCurrency:  string(from),
+		QuoteCurrency: string(to),
+		EffectiveAt:   timestamptzFromTime(at),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.ExchangeRate{}, fmt.Errorf("%w: %s to %s", entities.ErrExchangeRateNotFound, from, to)
+	}
+	if err != nil {
+		return entities.ExchangeRate{}, err
+	}
+
+	// NUMERIC renders as a plain decimal, which is what NewExchangeRate
+	// parses.
+	rate, err := row.Rate.Value()
+	if err != nil {
+		return entities.ExchangeRate{}, err
+	}
+
+	return entities.NewExchangeRate(
+		entities.Currency(row.BaseCurrency),
+		entities.Currency(row.QuoteCurrency),
+		rate.(string),
+		timeFromTimestamptz(row.EffectiveAt),
+	)
+}
+
+func (repo *SqlcExchangeRateRepository) Save(ctx context.Context, rate entities.ExchangeRate) error {
+	var numeric pgtype.Numeric
+	if err := numeric.Scan(rate.Rate()); err != nil {
+		return err
+	}
+
+	return queriesFor(ctx, repo.queries).UpsertExchangeRate(ctx, db.UpsertExchangeRateParams{
+		BaseCurrency:  string(rate.From()),
+		QuoteCurrency: string(rate.To()),
+		Rate:          numeric,
+		EffectiveAt:   timestamptzFromTime(rate.EffectiveAt()),
+	})
+}
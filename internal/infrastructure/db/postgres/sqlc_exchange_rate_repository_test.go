@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func TestSqlcExchangeRateRepository_EffectiveDated(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcExchangeRateRepository(testDB.Pool)
+	ctx := context.Background()
+	january := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, rate := range []struct {
+		decimal     string
+		effectiveAt time.Time
+	}{
+		{"1.0845", january},
+		{"1.10", february},
+	} {
+		exchangeRate, err := entities.NewExchangeRate(entities.EUR, entities.USD, rate.decimal, rate.effectiveAt)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, exchangeRate))
+	}
+
+	_, err := repo.Rate(ctx, entities.EUR, entities.USD, january.Add(-time.Second))
+	assert.ErrorIs(t, err, entities.ErrExchangeRateNotFound)
+
+	rate, err := repo.Rate(ctx, entities.EUR, entities.USD, january.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "1.0845", rate.Rate())
+	assert.True(t, rate.EffectiveAt().Equal(january))
+
+	rate, err = repo.Rate(ctx, entities.EUR, entities.USD, february)
+	require.NoError(t, err)
+	assert.Equal(t, "1.10", rate.Rate())
+
+	// Rates are per direction; USD to EUR was never stored.
+	_, err = repo.Rate(ctx, entities.USD, entities.EUR, february)
+	assert.ErrorIs(t, err, entities.ErrExchangeRateNotFound)
+}
+
+func TestSqlcExchangeRateRepository_SaveReplacesSameEffectiveAt(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcExchangeRateRepository(testDB.Pool)
+	ctx := context.Background()
+	effectiveAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, decimal := range []string{"150", "151.25"} {
+		rate, err := entities.NewExchangeRate(entities.USD, entities.JPY, decimal, effectiveAt)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, rate))
+	}
+
+	rate, err := repo.Rate(ctx, entities.USD, entities.JPY, effectiveAt)
+	require.NoError(t, err)
+	assert.Equal(t, "151.25", rate.Rate())
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+// SqlcInboxRepository joins the transaction of a Transactor, so a claimed
+// message stays locked while its handler runs and is marked processed
+// together with the handler's writes.
+type SqlcInboxRepository struct {
+	queries *db.Queries
+}
+
+func NewSqlcInboxRepository(queries *db.Queries) repositories.InboxRepository {
+	return &SqlcInboxRepository{queries: queries}
+}
+
+func (r *SqlcInboxRepository) Save(ctx context.Context, message *entities.InboxMessage) (bool, error) {
+	rows, err := queriesFor(ctx, r.queries).InsertInboxMessage(ctx, db.InsertInboxMessageParams{
+		Source:      message.Source,
+		MessageID:   message.MessageId,
+		MessageType: message.Type,
+		Payload:     message.Payload,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *SqlcInboxRepository) ClaimNext(ctx context.Context) (*entities.InboxMessage, error) {
+	dbMessage, err := queriesFor(ctx, r.queries).ClaimInboxMessage(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entities.InboxMessage{
+		Source:     dbMessage.Source,
+		MessageId:  dbMessage.MessageID,
+		Type:       dbMessage.MessageType,
+		Payload:    dbMessage.Payload,
+		ReceivedAt: timeFromTimestamptz(dbMessage.ReceivedAt),
+		Attempts:   int(dbMessage.Attempts),
+	}, nil
+}
+
+func (r *SqlcInboxRepository) MarkProcessed(ctx context.Context, message *entities.InboxMessage) error {
+	return queriesFor(ctx, r.queries).MarkInboxMessageProcessed(ctx, db.MarkInboxMessageProcessedParams{
+		Source:    message.Source,
+		MessageID: message.MessageId,
+	})
+}
+
+func (r *SqlcInboxRepository) RecordFailure(ctx context.Context, message *entities.InboxMessage, cause error, retryIn time.Duration, giveUp bool) error {
+	return queriesFor(ctx, r.queries).RecordInboxMessageFailure(ctx, db.RecordInboxMessageFailureParams{
+		LastError: cause.Error(),
+		BackoffMs: retryIn.Milliseconds(),
+		GiveUp:    giveUp,
+		Source:    message.Source,
+		MessageID: message.MessageId,
+	})
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func TestSqlcInboxRepository_SaveIsIdempotent(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcInboxRepository(testDB.Queries)
+	ctx := context.Background()
+
+	stored, err := repo.Save(ctx, entities.NewInboxMessage("kyc", "m-1", "kyc.test", []byte(`{"a":1}`)))
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	stored, err = repo.Save(ctx, entities.NewInboxMessage("kyc", "m-1", "kyc.test", []byte(`{"a":2}`)))
+	require.NoError(t, err)
+	assert.False(t, stored, "a redelivery is not stored again")
+
+	stored, err = repo.Save(ctx, entities.NewInboxMessage("crm", "m-1", "crm.test", []byte(`{}`)))
+	require.NoError(t, err)
+	assert.True(t, stored, "message ids are scoped to their source")
+}
+
+func TestSqlcInboxRepository_ClaimProcessAndRetry(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcInboxRepository(testDB.Queries)
+	transactor := NewTransactor(testDB.Pool)
+	ctx := context.Background()
+
+	for _, id := range []string{"m-1", "m-2"} {
+		_, err := repo.Save(ctx, entities.NewInboxMessage("kyc", id, "kyc.test", []byte(`{}`)))
+		require.NoError(t, err)
+	}
+
+	// While one transaction holds m-1, another claims m-2.
+	err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		first, err := repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, first)
+		assert.Equal(t, "m-1", first.MessageId)
+		assert.JSONEq(t, `{}`, string(first.Payload))
+
+		return transactor.WithinTransaction(context.Background(), func(otherCtx context.Context) error {
+			second, err := repo.ClaimNext(otherCtx)
+			require.NoError(t, err)
+			require.NotNil(t, second)
+			assert.Equal(t, "m-2", second.MessageId)
+			return repo.RecordFailure(otherCtx, second, errors.New("boom"), time.Hour, false)
+		})
+	})
+	require.NoError(t, err)
+
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		message, err := repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, message, "m-1 was released unprocessed")
+		assert.Equal(t, "m-1", message.MessageId)
+		return repo.MarkProcessed(ctx, message)
+	})
+	require.NoError(t, err)
+
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		message, err := repo.ClaimNext(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, message, "m-1 is processed and m-2 waits for its retry")
+		return nil
+	})
+	require.NoError(t, err)
+
+	var attempts int
+	var lastError string
+	require.NoError(t, testDB.Pool.QueryRow(ctx,
+		`SELECT attempts, last_error FROM inbox_messages WHERE message_id = 'm-2'`).Scan(&attempts, &lastError))
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, "boom", lastError)
+}
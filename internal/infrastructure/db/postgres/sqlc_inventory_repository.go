@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+// SqlcInventoryRepository serializes writers per variant with a row lock
+// instead of version checks: a reservation of the last unit must wait for
+// the competing one, not fail and retry.
+type SqlcInventoryRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewSqlcInventoryRepository(pool *pgxpool.Pool) repositories.InventoryRepository {
+	return &SqlcInventoryRepository{pool: pool, queries: db.New(pool)}
+}
+
+func (repo *SqlcInventoryRepository) FindByVariantId(ctx context.Context, variantId uuid.UUID) (*entities.Inventory, error) {
+	queries := queriesFor(ctx, repo.queries)
+	dbInventory, err := queries.GetInventory(ctx, variantId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return inventoryFromRows(ctx, queries, dbInventory)
+}
+
+func (repo *SqlcInventoryRepository) Lock(ctx context.Context, variantId uuid.UUID) (*entities.Inventory, error) {
+	queries := queriesFor(ctx, repo.queries)
+	if _, err := queries.EnsureInventory(ctx, variantId); err != nil {
+		return nil, err
+	}
+	dbInventory, err := queries.LockInventory(ctx, variantId)
+	if err != nil {
+		// EnsureInventory creates the row of every existing variant.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrVariantNotFound
+		}
+		return nil, err
+	}
+
+	return inventoryFromRows(ctx, queries, dbInventory)
+}
+
+// Save writes the stock level, brings the stored reservations in line with
+// the aggregate's and stores the recorded events, in one transaction.
+func (repo *SqlcInventoryRepository) Save(ctx context.Context, inventory *entities.ValidatedInventory) (*entities.Inventory, error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	if err := qtx.UpdateInventory(ctx, db.UpdateInventoryParams{
+		VariantID: inventory.VariantId,
+		OnHand:    int32(inventory.OnHand),
+		UpdatedAt: timestamptzFromTime(inventory.UpdatedAt),
+	}); err != nil {
+		return nil, err
+	}
+
+	stored, err := qtx.ListStockReservations(ctx, inventory.VariantId)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[uuid.UUID]bool, len(inventory.Reservations))
+	for _, reservation := range inventory.Reservations {
+		current[reservation.Id] = true
+	}
+	known := make(map[uuid.UUID]bool, len(stored))
+	for _, reservation := range stored {
+		known[reservation.ID] = true
+		if !current[reservation.ID] {
+			if err := qtx.DeleteStockReservation(ctx, reservation.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, reservation := range inventory.Reservations {
+		if known[reservation.Id] {
+			continue
+		}
+		if err := qtx.InsertStockReservation(ctx, db.InsertStockReservationParams{
+			ID:        reservation.Id,
+			VariantID: inventory.VariantId,
+			Quantity:  int32(reservation.Quantity),
+			ExpiresAt: timestamptzFromTime(reservation.ExpiresAt),
+			CreatedAt: timestamptzFromTime(reservation.CreatedAt),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, inventory.PullEvents()); err != nil {
+		return nil, err
+	}
+
+	dbInventory, err := qtx.GetInventory(ctx, inventory.VariantId)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := inventoryFromRows(ctx, qtx, dbInventory)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (repo *SqlcInventoryRepository) FindVariantIdsWithExpiredReservations(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	return queriesFor(ctx, repo.queries).ListVariantsWithExpiredReservations(ctx, int32(limit))
+}
+
+func inventoryFromRows(ctx context.Context, queries *db.Queries, dbInventory db.Inventory) (*entities.Inventory, error) {
+	dbReservations, err := queries.ListStockReservations(ctx, dbInventory.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &entities.Inventory{
+		ProductId: dbInventory.ProductID,
+		VariantId: dbInventory.VariantID,
+		OnHand:    int(dbInventory.OnHand),
+		CreatedAt: timeFromTimestamptz(dbInventory.CreatedAt),
+		UpdatedAt: timeFromTimestamptz(dbInventory.UpdatedAt),
+		Version:   int(dbInventory.Version),
+	}
+	for _, reservation := range dbReservations {
+		inventory.Reservations = append(inventory.Reservations, entities.Reservation{
+			Id:        reservation.ID,
+			Quantity:  int(reservation.Quantity),
+			ExpiresAt: timeFromTimestamptz(reservation.ExpiresAt),
+			CreatedAt: timeFromTimestamptz(reservation.CreatedAt),
+		})
+	}
+
+	return inventory, nil
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func createTestProduct(t *testing.T, testDB *testhelpers.PostgresTestContainer) *entities.ValidatedProduct {
+	t.Helper()
+	seller := createTestSeller(t, testDB, "Test Seller")
+	product, err := entities.NewValidatedProduct(entities.NewProduct("Widget", mustMoney(t, 999, entities.USD), *seller))
+	require.NoError(t, err)
+	_, err = NewSqlcProductRepository(testDB.Pool).Create(context.Background(), product)
+	require.NoError(t, err)
+	return product
+}
+
+func TestSqlcInventoryRepository_SaveAndFind(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcInventoryRepository(testDB.Pool)
+	transactor := NewTransactor(testDB.Pool)
+	product := createTestProduct(t, testDB)
+	variantId := product.Variants[0].Id
+	ctx := context.Background()
+
+	found, err := repo.FindByVariantId(ctx, variantId)
+	require.NoError(t, err)
+	assert.Nil(t, found, "variants start without an inventory row")
+
+	var reservation entities.Reservation
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		inventory, err := repo.Lock(ctx, variantId)
+		require.NoError(t, err)
+		require.NoError(t, inventory.AdjustStock(5, "delivery"))
+		reservation, err = inventory.Reserve(2, time.Minute)
+		require.NoError(t, err)
+
+		validated, err := entities.NewValidatedInventory(inventory)
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, validated)
+		return err
+	})
+	require.NoError(t, err)
+
+	found, err = repo.FindByVariantId(ctx, variantId)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, 5, found.OnHand)
+	assert.Equal(t, 2, found.Version)
+	require.Len(t, found.Reservations, 1)
+	assert.Equal(t, reservation.Id, found.Reservations[0].Id)
+	assert.WithinDuration(t, reservation.ExpiresAt, found.Reservations[0].ExpiresAt, time.Millisecond)
+
+	var stored int
+	require.NoError(t, testDB.Pool.QueryRow(ctx,
+		`SELECT count(*) FROM outbox_events WHERE aggregate_id = $1`, variantId).Scan(&stored))
+	assert.Equal(t, 2, stored, "StockAdjusted and StockReserved are stored with the change")
+
+	require.NoError(t, found.Commit(reservation.Id))
+	validated, err := entities.NewValidatedInventory(found)
+	require.NoError(t, err)
+	saved, err := repo.Save(ctx, validated)
+	require.NoError(t, err)
+	assert.Equal(t, 3, saved.OnHand)
+	assert.Empty(t, saved.Reservations, "removed reservations are deleted")
+}
+
+func TestSqlcInventoryRepository_LockSerializesWriters(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcInventoryRepository(testDB.Pool)
+	transactor := NewTransactor(testDB.Pool)
+	product := createTestProduct(t, testDB)
+	variantId := product.Variants[0].Id
+	ctx := context.Background()
+
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := repo.Lock(ctx, variantId); err != nil {
+				return err
+			}
+			close(locked)
+			<-release
+			return nil
+		})
+	}()
+	<-locked
+
+	lockCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	err := transactor.WithinTransaction(lockCtx, func(ctx context.Context) error {
+		_, err := repo.Lock(ctx, variantId)
+		return err
+	})
+	assert.Error(t, err, "a second writer waits for the lock")
+
+	close(release)
+	require.NoError(t, <-done)
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := repo.Lock(ctx, variantId)
+		return err
+	})
+	assert.NoError(t, err)
+}
+
+func TestSqlcInventoryRepository_LockUnknownVariant(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	_, err := NewSqlcInventoryRepository(testDB.Pool).Lock(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, entities.ErrVariantNotFound)
+}
+
+func TestSqlcInventoryRepository_FindVariantIdsWithExpiredReservations(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcInventoryRepository(testDB.Pool)
+	product := createTestProduct(t, testDB)
+	variantId := product.Variants[0].Id
+	ctx := context.Background()
+
+	inventory, err := repo.Lock(ctx, variantId)
+	require.NoError(t, err)
+	require.NoError(t, inventory.AdjustStock(3, ""))
+	_, err = inventory.Reserve(1, time.Minute)
+	require.NoError(t, err)
+	_, err = inventory.Reserve(1, time.Minute)
+	require.NoError(t, err)
+	inventory.Reservations[0].ExpiresAt = time.Now().Add(-time.Minute)
+	inventory.Reservations[1].ExpiresAt = time.Now().Add(-time.Minute)
+	validated, err := entities.NewValidatedInventory(inventory)
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, validated)
+	require.NoError(t, err)
+
+	variantIds, err := repo.FindVariantIdsWithExpiredReservations(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{variantId}, variantIds, "each variant is listed once")
+}
@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+type SqlcOrderRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewSqlcOrderRepository(pool *pgxpool.Pool) repositories.OrderRepository {
+	return &SqlcOrderRepository{pool: pool, queries: db.New(pool)}
+}
+
+// Create persists the order, its items and its recorded domain events in
+// one transaction (transactional outbox).
+func (repo *SqlcOrderRepository) Create(ctx context.Context, order *entities.ValidatedOrder) (*entities.Order, error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	if err := qtx.CreateOrder(ctx, db.CreateOrderParams{
+		ID:             order.Id,
+		BuyerID:        order.BuyerId,
+		Status:         string(order.Status),
+		TrackingNumber: order.TrackingNumber,
+		CreatedAt:      timestamptzFromTime(order.CreatedAt),
+		UpdatedAt:      timestamptzFromTime(order.UpdatedAt),
+		Version:        int32(order.Version),
+	}); err != nil {
+		return nil, err
+	}
+
+	for position, item := range order.Items {
+		if err := qtx.InsertOrderItem(ctx, db.InsertOrderItemParams{
+			OrderID:             order.Id,
+			Position:            int32(position),
+			ProductID:           item.ProductId,
+			VariantID:           item.VariantId,
+			Sku:                 item.Sku,
+			ProductName:         item.ProductName,
+			UnitPriceMinorUnits: item.UnitPrice.MinorUnits(),
+			Currency:            string(item.UnitPrice.Currency()),
+			Quantity:            int32(item.Quantity),
+			ReservationID:       nullableUUID(item.ReservationId),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, order.PullEvents()); err != nil {
+		return nil, err
+	}
+
+	created, err := findOrder(ctx, qtx, order.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (repo *SqlcOrderRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	order, err := findOrder(ctx, queriesFor(ctx, repo.queries), id)
+	if errors.Is(err, entities.ErrOrderNotFound) {
+		// A missing row is not an error: return (nil, nil) so callers can
+		// translate it into a 404 instead of a 500.
+		return nil, nil
+	}
+
+	return order, err
+}
+
+func (repo *SqlcOrderRepository) FindAll(ctx context.Context, criteria repositories.OrderListCriteria) ([]*entities.Order, error) {
+	after := criteria.After
+	if after == nil {
+		after = &repositories.OrderCursor{}
+	}
+
+	queries := queriesFor(ctx, repo.queries)
+	dbOrders, err := queries.ListOrders(ctx, db.ListOrdersParams{
+		BuyerID:        nullableUUID(criteria.BuyerId),
+		AfterID:        nullableUUID(after.Id),
+		AfterCreatedAt: timestamptzFromTime(after.CreatedAt),
+		Limit:          int32(criteria.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ordersFromRows(ctx, queries, dbOrders)
+}
+
+// Update writes the order's status and its recorded events in one
+// transaction.
+func (repo *SqlcOrderRepository) Update(ctx context.Context, order *entities.ValidatedOrder) (*entities.Order, error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	rows, err := qtx.UpdateOrder(ctx, db.UpdateOrderParams{
+		ID:             order.Id,
+		Status:         string(order.Status),
+		TrackingNumber: order.TrackingNumber,
+		UpdatedAt:      timestamptzFromTime(order.UpdatedAt),
+		Version:        int32(order.Version),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		exists, err := qtx.OrderExists(ctx, order.Id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, entities.ErrOrderNotFound
+		}
+		return nil, entities.ErrVersionConflict
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, order.PullEvents()); err != nil {
+		return nil, err
+	}
+
+	updated, err := findOrder(ctx, qtx, order.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func findOrder(ctx context.Context, queries *db.Queries, id uuid.UUID) (*entities.Order, error) {
+	dbOrder, err := queries.GetOrderById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	orders, err := ordersFromRows(ctx, queries, []db.Order{dbOrder})
+	if err != nil {
+		return nil, err
+	}
+
+	return orders[0], nil
+}
+
+// ordersFromRows loads the items of all orders with one query.
+func ordersFromRows(ctx context.Context, queries *db.Queries, dbOrders []db.Order) ([]*entities.Order, error) {
+	orderIds := make([]uuid.UUID, 0, len(dbOrders))
+	for _, dbOrder := range dbOrders {
+		orderIds = append(orderIds, dbOrder.ID)
+	}
+	dbItems, err := queries.ListOrderItems(ctx, orderIds)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[uuid.UUID][]entities.OrderItem, len(dbOrders))
+	for _, dbItem := range dbItems {
+		unitPrice, err := entities.NewMoney(dbItem.UnitPriceMinorUnits, entities.Currency(dbItem.Currency))
+		if err != nil {
+			return nil, err
+		}
+		var reservationId uuid.UUID
+		if dbItem.ReservationID.Valid {
+			reservationId = dbItem.ReservationID.Bytes
+		}
+		items[dbItem.OrderID] = append(items[dbItem.OrderID], entities.OrderItem{
+			ProductId:     dbItem.ProductID,
+			VariantId:     dbItem.VariantID,
+			Sku:           dbItem.Sku,
+			ProductName:   dbItem.ProductName,
+			UnitPrice:     unitPrice,
+			Quantity:      int(dbItem.Quantity),
+			ReservationId: reservationId,
+		})
+	}
+
+	orders := make([]*entities.Order, 0, len(dbOrders))
+	for _, dbOrder := range dbOrders {
+		orders = append(orders, &entities.Order{
+			Id:             dbOrder.ID,
+			BuyerId:        dbOrder.BuyerID,
+			Items:          items[dbOrder.ID],
+			Status:         entities.OrderStatus(dbOrder.Status),
+			TrackingNumber: dbOrder.TrackingNumber,
+			CreatedAt:      timeFromTimestamptz(dbOrder.CreatedAt),
+			UpdatedAt:      timeFromTimestamptz(dbOrder.UpdatedAt),
+			Version:        int(dbOrder.Version),
+		})
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func newTestOrder(t *testing.T, buyerId uuid.UUID) *entities.ValidatedOrder {
+	t.Helper()
+	order, err := entities.NewValidatedOrder(entities.NewOrder(buyerId, []entities.OrderItem{
+		{ProductId: uuid.New(), VariantId: uuid.New(), Sku: "WIDGET", ProductName: "Widget", UnitPrice: mustMoney(t, 999, entities.USD), Quantity: 2, ReservationId: uuid.New()},
+		{ProductId: uuid.New(), VariantId: uuid.New(), Sku: "GADGET", ProductName: "Gadget", UnitPrice: mustMoney(t, 2500, entities.EUR), Quantity: 1, ReservationId: uuid.New()},
+	}))
+	require.NoError(t, err)
+	return order
+}
+
+func TestSqlcOrderRepository_CreateAndFind(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcOrderRepository(testDB.Pool)
+	ctx := context.Background()
+	order := newTestOrder(t, uuid.New())
+
+	created, err := repo.Create(ctx, order)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderPending, created.Status)
+	require.Len(t, created.Items, 2)
+	assert.Equal(t, "Widget", created.Items[0].ProductName, "items keep their order")
+	assert.Equal(t, order.Items[0].UnitPrice, created.Items[0].UnitPrice)
+	assert.Equal(t, order.Items[1].ReservationId, created.Items[1].ReservationId)
+	assert.Equal(t, order.Items[1].VariantId, created.Items[1].VariantId)
+	assert.Equal(t, "GADGET", created.Items[1].Sku)
+
+	found, err := repo.FindById(ctx, order.Id)
+	require.NoError(t, err)
+	assert.Equal(t, created.Items, found.Items)
+
+	missing, err := repo.FindById(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestSqlcOrderRepository_Update(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcOrderRepository(testDB.Pool)
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newTestOrder(t, uuid.New()))
+	require.NoError(t, err)
+
+	require.NoError(t, created.MarkPaid())
+	require.NoError(t, created.Ship("1Z999"))
+	validated, err := entities.NewValidatedOrder(created)
+	require.NoError(t, err)
+	updated, err := repo.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OrderShipped, updated.Status)
+	assert.Equal(t, "1Z999", updated.TrackingNumber)
+	assert.Equal(t, 2, updated.Version)
+
+	_, err = repo.Update(ctx, validated)
+	assert.ErrorIs(t, err, entities.ErrVersionConflict, "the stored version moved on")
+
+	unknown := newTestOrder(t, uuid.New())
+	_, err = repo.Update(ctx, unknown)
+	assert.ErrorIs(t, err, entities.ErrOrderNotFound)
+}
+
+func TestSqlcOrderRepository_FindAll(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcOrderRepository(testDB.Pool)
+	ctx := context.Background()
+	buyerId := uuid.New()
+	first, err := repo.Create(ctx, newTestOrder(t, buyerId))
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, newTestOrder(t, buyerId))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newTestOrder(t, uuid.New()))
+	require.NoError(t, err)
+
+	page, err := repo.FindAll(ctx, repositories.OrderListCriteria{BuyerId: buyerId, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, second.Id, page[0].Id, "newest first")
+	assert.Len(t, page[0].Items, 2)
+
+	page, err = repo.FindAll(ctx, repositories.OrderListCriteria{
+		BuyerId: buyerId,
+		After:   &repositories.OrderCursor{Id: second.Id, CreatedAt: second.CreatedAt},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, first.Id, page[0].Id)
+
+	all, err := repo.FindAll(ctx, repositories.OrderListCriteria{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+}
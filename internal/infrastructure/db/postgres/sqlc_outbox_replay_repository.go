@@ -0,0 +1,304 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+)
+
+// replayLease bounds how long a replay stays claimed without a checkpoint;
+// it must comfortably exceed publishing one batch.
+const replayLease = time.Minute
+
+// SqlcOutboxReplayRepository records replays and runs them: it re-emits
+// published events from the outbox and its archive to one of a set of
+// named publishers ("targets"), e.g. to rebuild a consumer that
+// lost its state. It never touches the events' publication state, so the
+// relay is unaffected. Events go out in sequence_number order, which keeps
+// each aggregate's events in order; consumers deduplicate on the event id
+// as always.
+//
+// A replay checkpoints after every batch. If it fails, or the process
+// running it dies, Resume continues after the last checkpoint; at most one
+// batch is re-sent.
+type SqlcOutboxReplayRepository struct {
+	queries   *db.Queries
+	targets   map[string]outbox.Publisher
+	batchSize int32
+}
+
+func NewSqlcOutboxReplayRepository(queries *db.Queries, targets map[string]outbox.Publisher) *SqlcOutboxReplayRepository {
+	return &SqlcOutboxReplayRepository{queries: queries, targets: targets, batchSize: 500}
+}
+
+// Targets lists the configured target names, sorted.
+func (r *SqlcOutboxReplayRepository) Targets() []string {
+	targets := make([]string, 0, len(r.targets))
+	for name := range r.targets {
+		targets = append(targets, name)
+	}
+	slices.Sort(targets)
+	return targets
+}
+
+// Create records a pending replay of the events matching filter. It counts
+// them up front, so progress can be reported against a fixed total.
+func (r *SqlcOutboxReplayRepository) Create(ctx context.Context, filter entities.OutboxReplayFilter, target string) (*entities.OutboxReplay, error) {
+	if _, ok := r.targets[target]; !ok {
+		return nil, fmt.Errorf("%w %q (want one of %v)", entities.ErrUnknownReplayTarget, target, r.Targets())
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	publishedBefore := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	total, err := queriesFor(ctx, r.queries).CountReplayableOutboxEvents(ctx, db.CountReplayableOutboxEventsParams{
+		PublishedBefore: publishedBefore,
+		AggregateID:     nullableUUID(filter.AggregateId),
+		EventName:       nullableText(filter.EventName),
+		OccurredFrom:    nullableTime(filter.From),
+		OccurredTo:      nullableTime(filter.To),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := queriesFor(ctx, r.queries).CreateOutboxReplay(ctx, db.CreateOutboxReplayParams{
+		ID:              uuid.Must(uuid.NewV7()),
+		Target:          target,
+		AggregateID:     nullableUUID(filter.AggregateId),
+		EventName:       nullableText(filter.EventName),
+		OccurredFrom:    nullableTime(filter.From),
+		OccurredTo:      nullableTime(filter.To),
+		PublishedBefore: publishedBefore,
+		Total:           total,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return replayFromRow(row), nil
+}
+
+func (r *SqlcOutboxReplayRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.OutboxReplay, error) {
+	row, err := queriesFor(ctx, r.queries).GetOutboxReplay(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entities.ErrOutboxReplayNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return replayFromRow(row), nil
+}
+
+// FindAll returns up to limit replays, newest first.
+func (r *SqlcOutboxReplayRepository) FindAll(ctx context.Context, limit int) ([]*entities.OutboxReplay, error) {
+	rows, err := queriesFor(ctx, r.queries).ListOutboxReplays(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	replays := make([]*entities.OutboxReplay, 0, len(rows))
+	for _, row := range rows {
+		replays = append(replays, replayFromRow(row))
+	}
+
+	return replays, nil
+}
+
+// Run claims the replay and replays it to the end, calling progress after
+// every checkpoint. It returns the replay's final state; a publish failure
+// leaves it failed (and resumable) and is returned as error. The replay is
+// nil if it could not be claimed.
+func (r *SqlcOutboxReplayRepository) Run(ctx context.Context, id uuid.UUID, progress func(*entities.OutboxReplay)) (*entities.OutboxReplay, error) {
+	row, err := r.claim(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.run(ctx, row, progress)
+}
+
+// Resume claims a pending, failed or abandoned replay and continues it in
+// the background from its last checkpoint. The run outlives the caller's
+// ctx (e.g. an HTTP request); if the process stops first, the replay can be
+// resumed once its lease expires.
+func (r *SqlcOutboxReplayRepository) Resume(ctx context.Context, id uuid.UUID) (*entities.OutboxReplay, error) {
+	row, err := r.claim(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		_, _ = r.run(context.WithoutCancel(ctx), row, nil)
+	}()
+
+	return replayFromRow(row), nil
+}
+
+func (r *SqlcOutboxReplayRepository) claim(ctx context.Context, id uuid.UUID) (db.OutboxReplay, error) {
+	row, err := queriesFor(ctx, r.queries).ClaimOutboxReplay(ctx, db.ClaimOutboxReplayParams{
+		ID:      id,
+		LeaseMs: replayLease.Milliseconds(),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, err := r.FindById(ctx, id); err != nil {
+			return db.OutboxReplay{}, err
+		}
+		return db.OutboxReplay{}, entities.ErrOutboxReplayNotResumable
+	}
+	if err != nil {
+		return db.OutboxReplay{}, err
+	}
+	if _, ok := r.targets[row.Target]; !ok {
+		err := fmt.Errorf("%w %q (want one of %v)", entities.ErrUnknownReplayTarget, row.Target, r.Targets())
+		r.finish(ctx, row.ID, entities.OutboxReplayFailed, err)
+		return db.OutboxReplay{}, err
+	}
+
+	return row, nil
+}
+
+// run publishes batch after batch, starting after the checkpoint in row.
+func (r *SqlcOutboxReplayRepository) run(ctx context.Context, row db.OutboxReplay, progress func(*entities.OutboxReplay)) (*entities.OutboxReplay, error) {
+	publisher := r.targets[row.Target]
+	replay := replayFromRow(row)
+	replay.Status = entities.OutboxReplayRunning
+	slog.InfoContext(ctx, "outbox replay started",
+		slog.String("replay_id", replay.Id.String()), slog.String("target", replay.Target),
+		slog.Int64("total", replay.Total), slog.Int64("replayed", replay.Replayed))
+
+	for {
+		events, err := queriesFor(ctx, r.queries).ListReplayableOutboxEvents(ctx, db.ListReplayableOutboxEventsParams{
+			AfterSequenceNumber: replay.LastSequenceNumber,
+			PublishedBefore:     row.PublishedBefore,
+			AggregateID:         row.AggregateID,
+			EventName:           row.EventName,
+			OccurredFrom:        row.OccurredFrom,
+			OccurredTo:          row.OccurredTo,
+			BatchSize:           r.batchSize,
+		})
+		if err != nil {
+			return r.fail(ctx, replay, err)
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		for _, event := range events {
+			if err := publisher.Publish(ctx, replayEventFromRow(event)); err != nil {
+				return r.fail(ctx, replay, fmt.Errorf("publish event %s: %w", event.ID, err))
+			}
+			replay.Replayed++
+			replay.LastSequenceNumber = event.SequenceNumber
+		}
+
+		if err := r.checkpoint(ctx, replay); err != nil {
+			return r.fail(ctx, replay, err)
+		}
+		replay.UpdatedAt = time.Now()
+		if progress != nil {
+			progress(replay)
+		}
+	}
+
+	r.finish(ctx, replay.Id, entities.OutboxReplayCompleted, nil)
+	replay.Status = entities.OutboxReplayCompleted
+	completedAt := time.Now()
+	replay.CompletedAt = &completedAt
+	slog.InfoContext(ctx, "outbox replay completed",
+		slog.String("replay_id", replay.Id.String()), slog.Int64("replayed", replay.Replayed))
+
+	return replay, nil
+}
+
+func (r *SqlcOutboxReplayRepository) checkpoint(ctx context.Context, replay *entities.OutboxReplay) error {
+	return queriesFor(ctx, r.queries).SaveOutboxReplayCheckpoint(ctx, db.SaveOutboxReplayCheckpointParams{
+		Replayed:           replay.Replayed,
+		LastSequenceNumber: replay.LastSequenceNumber,
+		LeaseMs:            replayLease.Milliseconds(),
+		ID:                 replay.Id,
+	})
+}
+
+// fail checkpoints what was replayed so far and marks the replay failed.
+func (r *SqlcOutboxReplayRepository) fail(ctx context.Context, replay *entities.OutboxReplay, cause error) (*entities.OutboxReplay, error) {
+	slog.ErrorContext(ctx, "outbox replay failed",
+		slog.String("replay_id", replay.Id.String()), slog.Int64("replayed", replay.Replayed), slog.Any("error", cause))
+
+	// The run's ctx may be what failed; the bookkeeping must still happen.
+	ctx = context.WithoutCancel(ctx)
+	if err := r.checkpoint(ctx, replay); err != nil {
+		slog.WarnContext(ctx, "failed to checkpoint outbox replay",
+			slog.String("replay_id", replay.Id.String()), slog.Any("error", err))
+	}
+	r.finish(ctx, replay.Id, entities.OutboxReplayFailed, cause)
+
+	replay.Status = entities.OutboxReplayFailed
+	replay.LastError = cause.Error()
+	return replay, cause
+}
+
+func (r *SqlcOutboxReplayRepository) finish(ctx context.Context, id uuid.UUID, status entities.OutboxReplayStatus, cause error) {
+	var lastError pgtype.Text
+	if cause != nil {
+		lastError = pgtype.Text{String: cause.Error(), Valid: true}
+	}
+	if err := queriesFor(ctx, r.queries).FinishOutboxReplay(ctx, db.FinishOutboxReplayParams{
+		Status:    string(status),
+		LastError: lastError,
+		ID:        id,
+	}); err != nil {
+		slog.WarnContext(ctx, "failed to record outbox replay status",
+			slog.String("replay_id", id.String()), slog.String("status", string(status)), slog.Any("error", err))
+	}
+}
+
+func replayFromRow(row db.OutboxReplay) *entities.OutboxReplay {
+	replay := &entities.OutboxReplay{
+		Id:     row.ID,
+		Target: row.Target,
+		Filter: entities.OutboxReplayFilter{
+			EventName: row.EventName.String,
+			From:      row.OccurredFrom.Time,
+			To:        row.OccurredTo.Time,
+		},
+		Status:             entities.OutboxReplayStatus(row.Status),
+		Total:              row.Total,
+		Replayed:           row.Replayed,
+		LastSequenceNumber: row.LastSequenceNumber,
+		LastError:          row.LastError.String,
+		CreatedAt:          row.CreatedAt.Time,
+		UpdatedAt:          row.UpdatedAt.Time,
+	}
+	if row.AggregateID.Valid {
+		replay.Filter.AggregateId = row.AggregateID.Bytes
+	}
+	if row.CompletedAt.Valid {
+		replay.CompletedAt = &row.CompletedAt.Time
+	}
+	return replay
+}
+
+func replayEventFromRow(row db.ListReplayableOutboxEventsRow) outbox.Event {
+	return outbox.Event{
+		Id:            row.ID,
+		AggregateId:   row.AggregateID,
+		Name:          row.EventName,
+		SchemaVersion: int(row.SchemaVersion),
+		Data:          row.Payload,
+		OccurredAt:    row.OccurredAt.Time,
+	}
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+// recordingPublisher records the payloads it publishes, failing on failOn.
+type recordingPublisher struct {
+	published map[string]int
+	order     []string
+	failOn    string
+}
+
+func newRecordingPublisher() *recordingPublisher {
+	return &recordingPublisher{published: map[string]int{}}
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event outbox.Event) error {
+	if string(event.Data) == p.failOn {
+		return errors.New("broker unavailable")
+	}
+	p.published[string(event.Data)]++
+	p.order = append(p.order, string(event.Data))
+	return nil
+}
+
+func insertTestEvents(t *testing.T, queries *db.Queries, count int) []string {
+	t.Helper()
+	payloads := make([]string, 0, count)
+	for range count {
+		payloads = append(payloads, insertTestEvent(t, queries, uuid.New()))
+	}
+	return payloads
+}
+
+// insertTestEvent stores an unpublished event and returns its payload.
+func insertTestEvent(t *testing.T, queries *db.Queries, aggregateId uuid.UUID) string {
+	t.Helper()
+	id := uuid.Must(uuid.NewV7())
+	payload := fmt.Sprintf(`{"aggregate": %q, "id": %q}`, aggregateId, id)
+	require.NoError(t, queries.InsertOutboxEvent(context.Background(), db.InsertOutboxEventParams{
+		ID:            id,
+		AggregateID:   aggregateId,
+		EventName:     "test.event",
+		SchemaVersion: 1,
+		Payload:       []byte(payload),
+		OccurredAt:    timestamptzFromTime(time.Now()),
+	}))
+	return payload
+}
+
+// markOutboxEventsPublished does what the relay would.
+func markOutboxEventsPublished(t *testing.T, testDB *testhelpers.PostgresTestContainer) {
+	t.Helper()
+	_, err := testDB.Pool.Exec(context.Background(), `UPDATE outbox_events SET published_at = NOW() WHERE published_at IS NULL`)
+	require.NoError(t, err)
+}
+
+func countUnpublished(t *testing.T, queries *db.Queries) int {
+	t.Helper()
+	events, err := queries.GetUnpublishedOutboxEvents(context.Background(), 10_000)
+	require.NoError(t, err)
+	return len(events)
+}
+
+func TestSqlcOutboxReplayRepository_ReplaysPublishedEventsFromOutboxAndArchive(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	aggregate, other := uuid.New(), uuid.New()
+	archived := []string{insertTestEvent(t, testDB.Queries, aggregate), insertTestEvent(t, testDB.Queries, aggregate)}
+	markOutboxEventsPublished(t, testDB)
+	_, err := testDB.Queries.ArchivePublishedOutboxEvents(ctx, db.ArchivePublishedOutboxEventsParams{
+		PublishedBefore: timestamptzFromTime(time.Now()),
+		BatchSize:       100,
+	})
+	require.NoError(t, err)
+
+	recent := insertTestEvent(t, testDB.Queries, aggregate)
+	insertTestEvent(t, testDB.Queries, other)
+	markOutboxEventsPublished(t, testDB)
+	pending := insertTestEvent(t, testDB.Queries, aggregate)
+
+	target := newRecordingPublisher()
+	replays := NewSqlcOutboxReplayRepository(testDB.Queries, map[string]outbox.Publisher{"test": target})
+	replays.batchSize = 2
+
+	created, err := replays.Create(ctx, entities.OutboxReplayFilter{AggregateId: aggregate}, "test")
+	require.NoError(t, err)
+	assert.Equal(t, entities.OutboxReplayPending, created.Status)
+	assert.Equal(t, int64(3), created.Total)
+
+	var progress []int64
+	replay, err := replays.Run(ctx, created.Id, func(r *entities.OutboxReplay) { progress = append(progress, r.Replayed) })
+	require.NoError(t, err)
+
+	assert.Equal(t, entities.OutboxReplayCompleted, replay.Status)
+	assert.Equal(t, int64(3), replay.Replayed)
+	assert.Equal(t, []int64{2, 3}, progress)
+	assert.Equal(t, append(archived, recent), target.order, "archived and live events, in order")
+	assert.Zero(t, target.published[pending], "unpublished events are left to the relay")
+	assert.Equal(t, 1, countUnpublished(t, testDB.Queries), "publication state is untouched")
+
+	stored, err := replays.FindById(ctx, created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OutboxReplayCompleted, stored.Status)
+	assert.Equal(t, int64(3), stored.Replayed)
+	assert.NotNil(t, stored.CompletedAt)
+
+	_, err = replays.Run(ctx, created.Id, nil)
+	assert.ErrorIs(t, err, entities.ErrOutboxReplayNotResumable, "completed replays do not run again")
+}
+
+func TestSqlcOutboxReplayRepository_FiltersByEventNameAndTimeWindow(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	payloads := insertTestEvents(t, testDB.Queries, 4)
+	markOutboxEventsPublished(t, testDB)
+	_, err := testDB.Pool.Exec(ctx, `
+		UPDATE outbox_events
+		SET event_name = CASE WHEN sequence_number % 2 = 0 THEN 'product.created' ELSE 'seller.created' END,
+		    occurred_at = '2026-01-01T00:00:00Z'::timestamptz + (sequence_number - (SELECT min(sequence_number) FROM outbox_events)) * INTERVAL '1 day'`)
+	require.NoError(t, err)
+
+	target := newRecordingPublisher()
+	replays := NewSqlcOutboxReplayRepository(testDB.Queries, map[string]outbox.Publisher{"test": target})
+
+	window := entities.OutboxReplayFilter{
+		From: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC),
+	}
+	created, err := replays.Create(ctx, window, "test")
+	require.NoError(t, err)
+	_, err = replays.Run(ctx, created.Id, nil)
+	require.NoError(t, err)
+	assert.Equal(t, payloads[1:3], target.order)
+
+	target.order = nil
+	created, err = replays.Create(ctx, entities.OutboxReplayFilter{EventName: "product.created"}, "test")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), created.Total)
+	_, err = replays.Run(ctx, created.Id, nil)
+	require.NoError(t, err)
+	assert.Len(t, target.order, 2)
+
+	_, err = replays.Create(ctx, entities.OutboxReplayFilter{From: window.To, To: window.From}, "test")
+	assert.ErrorIs(t, err, entities.ErrValidation)
+	_, err = replays.Create(ctx, entities.OutboxReplayFilter{}, "carrier-pigeon")
+	assert.ErrorIs(t, err, entities.ErrUnknownReplayTarget)
+}
+
+func TestSqlcOutboxReplayRepository_ResumesFromCheckpointAfterFailure(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	payloads := insertTestEvents(t, testDB.Queries, 5)
+	markOutboxEventsPublished(t, testDB)
+
+	target := newRecordingPublisher()
+	target.failOn = payloads[3]
+	replays := NewSqlcOutboxReplayRepository(testDB.Queries, map[string]outbox.Publisher{"test": target})
+	replays.batchSize = 2
+
+	created, err := replays.Create(ctx, entities.OutboxReplayFilter{}, "test")
+	require.NoError(t, err)
+	replay, err := replays.Run(ctx, created.Id, nil)
+	require.Error(t, err)
+	assert.Equal(t, entities.OutboxReplayFailed, replay.Status)
+
+	stored, err := replays.FindById(ctx, created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OutboxReplayFailed, stored.Status)
+	assert.Equal(t, int64(3), stored.Replayed, "checkpoint covers the events sent before the failure")
+	assert.Contains(t, stored.LastError, "broker unavailable")
+
+	target.failOn = ""
+	replay, err = replays.Run(ctx, created.Id, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), replay.Replayed)
+	assert.Equal(t, payloads, target.order, "no event is sent twice or skipped")
+}
+
+func TestSqlcOutboxReplayRepository_OneRunnerAtATime(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	replays := NewSqlcOutboxReplayRepository(testDB.Queries, map[string]outbox.Publisher{"test": newRecordingPublisher()})
+	created, err := replays.Create(ctx, entities.OutboxReplayFilter{}, "test")
+	require.NoError(t, err)
+
+	_, err = replays.claim(ctx, created.Id)
+	require.NoError(t, err)
+	_, err = replays.Resume(ctx, created.Id)
+	assert.ErrorIs(t, err, entities.ErrOutboxReplayNotResumable, "the first runner still holds the lease")
+
+	// A runner that died stops renewing its lease; then anyone may resume.
+	_, err = testDB.Pool.Exec(ctx, `UPDATE outbox_replays SET claimed_until = NOW() - INTERVAL '1 second'`)
+	require.NoError(t, err)
+	replay, err := replays.Run(ctx, created.Id, nil)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OutboxReplayCompleted, replay.Status)
+
+	_, err = replays.FindById(ctx, uuid.New())
+	assert.ErrorIs(t, err, entities.ErrOutboxReplayNotFound)
+	_, err = replays.Resume(ctx, uuid.New())
+	assert.ErrorIs(t, err, entities.ErrOutboxReplayNotFound)
+}
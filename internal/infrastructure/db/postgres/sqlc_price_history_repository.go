@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+type SqlcPriceHistoryRepository struct {
+	queries *db.Queries
+}
+
+func NewSqlcPriceHistoryRepository(pool *pgxpool.Pool) repositories.PriceHistoryRepository {
+	return &SqlcPriceHistoryRepository{queries: db.New(pool)}
+}
+
+func (repo *SqlcPriceHistoryRepository) FindByProductId(ctx context.Context, productId uuid.UUID, from, to time.Time) ([]entities.PricePoint, error) {
+	rows, err := queriesFor(ctx, repo.queries).ListProductPriceHistory(ctx, db.ListProductPriceHistoryParams{
+		ProductID: productId,
+		From:      timestamptzFromTime(from),
+		To:        timestamptzFromTime(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]entities.PricePoint, 0, len(rows))
+	for _, row := range rows {
+		price, err := entities.NewMoney(row.PriceMinorUnits, entities.Currency(row.Currency))
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, entities.PricePoint{Price: price, EffectiveAt: timeFromTimestamptz(row.EffectiveAt)})
+	}
+
+	return points, nil
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func TestSqlcPriceHistoryRepository_RecordsEveryPriceChange(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	productRepo := NewSqlcProductRepository(testDB.Pool)
+	repo := NewSqlcPriceHistoryRepository(testDB.Pool)
+	ctx := context.Background()
+	product := createTestProduct(t, testDB)
+
+	stored, err := productRepo.FindById(ctx, product.Id)
+	require.NoError(t, err)
+	for _, minorUnits := range []int64{799, 1299} {
+		require.NoError(t, stored.UpdatePrice(mustMoney(t, minorUnits, entities.USD)))
+		validated, err := entities.NewValidatedProduct(stored)
+		require.NoError(t, err)
+		stored, err = productRepo.Update(ctx, validated)
+		require.NoError(t, err)
+	}
+
+	// A rename is not a price change.
+	require.NoError(t, stored.UpdateName("Renamed"))
+	validated, err := entities.NewValidatedProduct(stored)
+	require.NoError(t, err)
+	_, err = productRepo.Update(ctx, validated)
+	require.NoError(t, err)
+
+	points, err := repo.FindByProductId(ctx, product.Id, time.Now().Add(-time.Hour), time.Now())
+	require.NoError(t, err)
+	require.Len(t, points, 3)
+	assert.Equal(t, int64(999), points[0].Price.MinorUnits())
+	assert.Equal(t, int64(799), points[1].Price.MinorUnits())
+	assert.Equal(t, int64(1299), points[2].Price.MinorUnits())
+
+	// A window that starts after the last change still includes the price
+	// in effect at its start.
+	points, err = repo.FindByProductId(ctx, product.Id, time.Now(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(1299), points[0].Price.MinorUnits())
+
+	points, err = repo.FindByProductId(ctx, product.Id, time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, points)
+}
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -10,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
 	"github.com/sklinkert/go-ddd/internal/domain/repositories"
 	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
 )
@@ -28,7 +31,7 @@ func NewSqlcProductRepository(pool *pgxpool.Pool) repositories.ProductRepository
 // The read-after-write happens inside the same transaction, so a transient
 // failure cannot surface after the commit already succeeded.
 func (repo *SqlcProductRepository) Create(ctx context.Context, product *entities.ValidatedProduct) (*entities.Product, error) {
-	tx, err := repo.pool.Begin(ctx)
+	tx, err := begin(ctx, repo.pool)
 	if err != nil {
 		return nil, err
 	}
@@ -42,13 +45,15 @@ func (repo *SqlcProductRepository) Create(ctx context.Context, product *entities
 		PriceMinorUnits: product.Price.MinorUnits(),
 		Currency:        string(product.Price.Currency()),
 		SellerID:        product.SellerId,
+		Status:          string(product.Status),
 		CreatedAt:       timestamptzFromTime(product.CreatedAt),
 		UpdatedAt:       timestamptzFromTime(product.UpdatedAt),
+		Version:         int32(product.Version),
 	}); err != nil {
 		return nil, err
 	}
 
-	if err := insertOutboxEvents(ctx, qtx, product.PullEvents()); err != nil {
+	if err := insertProductEvents(ctx, qtx, product.PullEvents()); err != nil {
 		return nil, err
 	}
 
@@ -57,10 +62,13 @@ func (repo *SqlcProductRepository) Create(ctx context.Context, product *entities
 		return nil, err
 	}
 
-	created, err := productFromRow(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.CreatedAt, row.UpdatedAt)
+	created, err := productFromRow(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.Status, row.CategoryIds, row.CreatedAt, row.UpdatedAt, row.Version)
 	if err != nil {
 		return nil, err
 	}
+	if err := attachDetails(ctx, qtx, created); err != nil {
+		return nil, err
+	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
@@ -70,7 +78,8 @@ func (repo *SqlcProductRepository) Create(ctx context.Context, product *entities
 }
 
 func (repo *SqlcProductRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
-	row, err := repo.queries.GetProductById(ctx, id)
+	queries := queriesFor(ctx, repo.queries)
+	row, err := queries.GetProductById(ctx, id)
 	if err != nil {
 		// A missing row is not an error: return (nil, nil) so callers can
 		// translate it into a 404 instead of a 500.
@@ -80,63 +89,568 @@ func (repo *SqlcProductRepository) FindById(ctx context.Context, id uuid.UUID) (
 		return nil, err
 	}
 
-	return productFromRow(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.CreatedAt, row.UpdatedAt)
+	product, err := productFromRow(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.Status, row.CategoryIds, row.CreatedAt, row.UpdatedAt, row.Version)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachDetails(ctx, queries, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
 }
 
-func (repo *SqlcProductRepository) FindAll(ctx context.Context) ([]*entities.Product, error) {
-	rows, err := repo.queries.GetAllProducts(ctx)
+// FindAll pages with keyset (seek) pagination: each sort order has its own
+// query so Postgres can walk the matching index instead of sorting and
+// skipping OFFSET rows.
+func (repo *SqlcProductRepository) FindAll(ctx context.Context, criteria repositories.ProductListCriteria) ([]*entities.Product, error) {
+	var (
+		sellerId = nullableUUID(criteria.SellerId)
+		status   = nullableText(string(criteria.Status))
+		category = nullableUUID(criteria.CategoryId)
+		currency = nullableText(string(criteria.Currency))
+		minPrice = nullableInt8(criteria.MinPriceMinorUnits)
+		maxPrice = nullableInt8(criteria.MaxPriceMinorUnits)
+		after    = criteria.After
+		limit    = int32(criteria.Limit)
+	)
+	if after == nil {
+		after = &repositories.ProductCursor{}
+	}
+
+	var products []*entities.Product
+	collect := func(id uuid.UUID, name string, priceMinorUnits int64, currency string, sellerId uuid.UUID, status string, categoryIds []uuid.UUID, createdAt, updatedAt pgtype.Timestamptz, version int32) error {
+		product, err := productFromRow(id, name, priceMinorUnits, currency, sellerId, status, categoryIds, createdAt, updatedAt, version)
+		if err != nil {
+			return err
+		}
+		products = append(products, product)
+		return nil
+	}
+
+	switch criteria.SortBy {
+	case repositories.ProductSortByName:
+		rows, err := queriesFor(ctx, repo.queries).ListProductsByName(ctx, db.ListProductsByNameParams{
+			SellerID:           sellerId,
+			Status:             status,
+			CategoryID:         category,
+			Currency:           currency,
+			MinPriceMinorUnits: minPrice,
+			MaxPriceMinorUnits: maxPrice,
+			AfterID:            nullableUUID(after.Id),
+			AfterName:          pgtype.Text{String: after.Name, Valid: true},
+			Limit:              limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if err := collect(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.Status, row.CategoryIds, row.CreatedAt, row.UpdatedAt, row.Version); err != nil {
+				return nil, err
+			}
+		}
+	case repositories.ProductSortByPrice:
+		rows, err := queriesFor(ctx, repo.queries).ListProductsByPrice(ctx, db.ListProductsByPriceParams{
+			SellerID:             sellerId,
+			Status:               status,
+			CategoryID:           category,
+			Currency:             currency,
+			MinPriceMinorUnits:   minPrice,
+			MaxPriceMinorUnits:   maxPrice,
+			AfterID:              nullableUUID(after.Id),
+			AfterPriceMinorUnits: pgtype.Int8{Int64: after.PriceMinorUnits, Valid: true},
+			Limit:                limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if err := collect(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.Status, row.CategoryIds, row.CreatedAt, row.UpdatedAt, row.Version); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		rows, err := queriesFor(ctx, repo.queries).ListProductsByCreatedAt(ctx, db.ListProductsByCreatedAtParams{
+			SellerID:           sellerId,
+			Status:             status,
+			CategoryID:         category,
+			Currency:           currency,
+			MinPriceMinorUnits: minPrice,
+			MaxPriceMinorUnits: maxPrice,
+			AfterID:            nullableUUID(after.Id),
+			AfterCreatedAt:     timestamptzFromTime(after.CreatedAt),
+			Limit:              limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if err := collect(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.Status, row.CategoryIds, row.CreatedAt, row.UpdatedAt, row.Version); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := attachDetails(ctx, queriesFor(ctx, repo.queries), products...); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// Search matches the terms as stemmed prefixes against the GIN-indexed
+// search vector and pages by (rank, id).
+func (repo *SqlcProductRepository) Search(ctx context.Context, criteria repositories.ProductSearchCriteria) ([]repositories.ProductSearchHit, error) {
+	params := db.SearchProductsParams{
+		Query: prefixTsQuery(criteria.Terms),
+		Limit: int32(criteria.Limit),
+	}
+	if criteria.After != nil {
+		params.AfterID = nullableUUID(criteria.After.Id)
+		params.AfterRank = pgtype.Float4{Float32: criteria.After.Rank, Valid: true}
+	}
+
+	rows, err := queriesFor(ctx, repo.queries).SearchProducts(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	products := make([]*entities.Product, len(rows))
-	for i, row := range rows {
-		product, err := productFromRow(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.CreatedAt, row.UpdatedAt)
+	hits := make([]repositories.ProductSearchHit, 0, len(rows))
+	products := make([]*entities.Product, 0, len(rows))
+	for _, row := range rows {
+		product, err := productFromRow(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.Status, row.CategoryIds, row.CreatedAt, row.UpdatedAt, row.Version)
 		if err != nil {
 			return nil, err
 		}
-		products[i] = product
+		products = append(products, product)
+		hits = append(hits, repositories.ProductSearchHit{
+			Product:   product,
+			Rank:      row.Rank,
+			Highlight: escapeHeadline(row.Headline),
+		})
 	}
 
-	return products, nil
+	if err := attachDetails(ctx, queriesFor(ctx, repo.queries), products...); err != nil {
+		return nil, err
+	}
+
+	return hits, nil
 }
 
+// Update writes the product and its recorded events in one transaction, the
+// same way Create does.
 func (repo *SqlcProductRepository) Update(ctx context.Context, product *entities.ValidatedProduct) (*entities.Product, error) {
-	rows, err := repo.queries.UpdateProduct(ctx, db.UpdateProductParams{
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	rows, err := qtx.UpdateProduct(ctx, db.UpdateProductParams{
 		ID:              product.Id,
 		Name:            product.Name,
 		PriceMinorUnits: product.Price.MinorUnits(),
 		Currency:        string(product.Price.Currency()),
 		SellerID:        product.SellerId,
+		Status:          string(product.Status),
 		UpdatedAt:       timestamptzFromTime(product.UpdatedAt),
+		Version:         int32(product.Version),
 	})
 	if err != nil {
 		return nil, err
 	}
 	if rows == 0 {
-		// Nothing matched: the product does not exist (or is soft-deleted).
-		return nil, entities.ErrProductNotFound
+		return nil, missedProductWrite(ctx, qtx, product.Id)
 	}
 
-	return repo.FindById(ctx, product.Id)
+	if err := insertProductEvents(ctx, qtx, product.PullEvents()); err != nil {
+		return nil, err
+	}
+
+	row, err := qtx.GetProductById(ctx, product.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := productFromRow(row.ID, row.Name, row.PriceMinorUnits, row.Currency, row.SellerID, row.Status, row.CategoryIds, row.CreatedAt, row.UpdatedAt, row.Version)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachDetails(ctx, qtx, updated); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
-func (repo *SqlcProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return repo.queries.DeleteProduct(ctx, id)
+// Delete soft-deletes the product and stores its recorded events (e.g.
+// ProductDeleted) in the same transaction. Deleting a product that no
+// longer exists is a no-op and publishes nothing; deleting a stale version
+// is a conflict.
+func (repo *SqlcProductRepository) Delete(ctx context.Context, product *entities.Product) error {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	rows, err := qtx.DeleteProduct(ctx, db.DeleteProductParams{
+		ID:      product.Id,
+		Version: int32(product.Version),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		if err := missedProductWrite(ctx, qtx, product.Id); !errors.Is(err, entities.ErrProductNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	// A deleted product no longer keeps its categories from being deleted.
+	if err := qtx.DeleteProductCategories(ctx, product.Id); err != nil {
+		return err
+	}
+	// Its images go with it; their blobs are deleted once this commits.
+	if err := qtx.ScheduleProductImageDeletions(ctx, product.Id); err != nil {
+		return err
+	}
+	if err := qtx.DeleteProductImages(ctx, product.Id); err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, product.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertProductEvents stores the events in the outbox and applies those
+// whose state is kept in tables of its own: every price they set is recorded
+// in the price history, every category change in product_categories, every
+// variant change in product_variants and every image change in
+// product_images, so none can miss a change that was committed.
+func insertProductEvents(ctx context.Context, queries *db.Queries, domainEvents []events.DomainEvent) error {
+	for _, event := range domainEvents {
+		var err error
+		switch event := event.(type) {
+		case events.ProductCreated:
+			err = insertProductPrice(ctx, queries, event, events.Money{MinorUnits: event.PriceMinorUnits, Currency: event.Currency})
+			for _, variant := range event.Variants {
+				if err == nil {
+					err = insertProductVariant(ctx, queries, event.AggregateId(), variant)
+				}
+			}
+		case events.ProductPriceChanged:
+			err = insertProductPrice(ctx, queries, event, event.NewPrice)
+		case events.ProductCategorized:
+			err = replaceProductCategories(ctx, queries, event.AggregateId(), event.CategoryIds)
+		case events.ProductVariantAdded:
+			err = insertProductVariant(ctx, queries, event.AggregateId(), event.Variant)
+		case events.ProductVariantChanged:
+			err = updateProductVariant(ctx, queries, event.Variant)
+		case events.ProductVariantRemoved:
+			err = deleteProductVariant(ctx, queries, event.VariantId)
+		case events.ProductImageAdded:
+			err = insertProductImage(ctx, queries, event)
+		case events.ProductImageRemoved:
+			err = deleteProductImage(ctx, queries, event)
+		case events.ProductImagesArranged:
+			err = arrangeProductImages(ctx, queries, event.AggregateId(), event.ProductGallery)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return insertOutboxEvents(ctx, queries, domainEvents)
+}
+
+func insertProductPrice(ctx context.Context, queries *db.Queries, event events.DomainEvent, price events.Money) error {
+	return queries.InsertProductPrice(ctx, db.InsertProductPriceParams{
+		ProductID:       event.AggregateId(),
+		PriceMinorUnits: price.MinorUnits,
+		Currency:        price.Currency,
+		EffectiveAt:     timestamptzFromTime(event.OccurredAt()),
+	})
+}
+
+// replaceProductCategories stores the product's complete set of categories.
+// Categories that do not exist (any more) are ErrCategoryNotFound.
+func replaceProductCategories(ctx context.Context, queries *db.Queries, productId uuid.UUID, categoryIds []uuid.UUID) error {
+	if err := queries.DeleteProductCategories(ctx, productId); err != nil {
+		return err
+	}
+
+	if len(categoryIds) > 0 {
+		rows, err := queries.InsertProductCategories(ctx, db.InsertProductCategoriesParams{
+			ProductID:   productId,
+			CategoryIds: categoryIds,
+		})
+		if err != nil {
+			return err
+		}
+		if rows != int64(len(categoryIds)) {
+			return entities.ErrCategoryNotFound
+		}
+	}
+
+	return queries.RefreshProductCategoryNames(ctx, productId)
+}
+
+func insertProductVariant(ctx context.Context, queries *db.Queries, productId uuid.UUID, variant events.ProductVariant) error {
+	if err := ensureSkuAvailable(ctx, queries, variant); err != nil {
+		return err
+	}
+	attributes, overrideMinorUnits, overrideCurrency, err := variantColumns(variant)
+	if err != nil {
+		return err
+	}
+
+	return queries.InsertProductVariant(ctx, db.InsertProductVariantParams{
+		ID:                      variant.Id,
+		ProductID:               productId,
+		Sku:                     variant.Sku,
+		Attributes:              attributes,
+		PriceOverrideMinorUnits: overrideMinorUnits,
+		PriceOverrideCurrency:   overrideCurrency,
+	})
+}
+
+func updateProductVariant(ctx context.Context, queries *db.Queries, variant events.ProductVariant) error {
+	if err := ensureSkuAvailable(ctx, queries, variant); err != nil {
+		return err
+	}
+	attributes, overrideMinorUnits, overrideCurrency, err := variantColumns(variant)
+	if err != nil {
+		return err
+	}
+
+	return queries.UpdateProductVariant(ctx, db.UpdateProductVariantParams{
+		ID:                      variant.Id,
+		Sku:                     variant.Sku,
+		Attributes:              attributes,
+		PriceOverrideMinorUnits: overrideMinorUnits,
+		PriceOverrideCurrency:   overrideCurrency,
+	})
+}
+
+// deleteProductVariant removes the variant together with its stock. Stock
+// that is still reserved would leave the reserving orders unable to commit
+// or release it, so such variants are ErrVariantReserved.
+func deleteProductVariant(ctx context.Context, queries *db.Queries, variantId uuid.UUID) error {
+	reserved, err := queries.VariantHasReservations(ctx, variantId)
+	if err != nil {
+		return err
+	}
+	if reserved {
+		return entities.ErrVariantReserved
+	}
+
+	return queries.DeleteProductVariant(ctx, variantId)
+}
+
+// ensureSkuAvailable checks the SKU against the variants of all other
+// products; the product itself already guarantees unique SKUs among its
+// own variants.
+func ensureSkuAvailable(ctx context.Context, queries *db.Queries, variant events.ProductVariant) error {
+	taken, err := queries.SkuTaken(ctx, db.SkuTakenParams{Sku: variant.Sku, ID: variant.Id})
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("%w: %s", entities.ErrSkuTaken, variant.Sku)
+	}
+
+	return nil
+}
+
+func variantColumns(variant events.ProductVariant) ([]byte, pgtype.Int8, pgtype.Text, error) {
+	attributes, err := json.Marshal(variant.Attributes)
+	if err != nil {
+		return nil, pgtype.Int8{}, pgtype.Text{}, err
+	}
+	if variant.PriceOverride == nil {
+		return attributes, pgtype.Int8{}, pgtype.Text{}, nil
+	}
+
+	return attributes,
+		pgtype.Int8{Int64: variant.PriceOverride.MinorUnits, Valid: true},
+		pgtype.Text{String: variant.PriceOverride.Currency, Valid: true},
+		nil
+}
+
+func insertProductImage(ctx context.Context, queries *db.Queries, event events.ProductImageAdded) error {
+	if err := queries.InsertProductImage(ctx, db.InsertProductImageParams{
+		ID:          event.Image.Id,
+		ProductID:   event.AggregateId(),
+		BlobKey:     event.Image.BlobKey,
+		ContentType: event.Image.ContentType,
+		SizeBytes:   event.Image.SizeBytes,
+		CreatedAt:   timestamptzFromTime(event.OccurredAt()),
+	}); err != nil {
+		return err
+	}
+
+	return arrangeProductImages(ctx, queries, event.AggregateId(), event.ProductGallery)
+}
+
+// deleteProductImage removes the image row and schedules its blob for
+// deletion; the blob itself is only deleted after the removal committed.
+func deleteProductImage(ctx context.Context, queries *db.Queries, event events.ProductImageRemoved) error {
+	if err := queries.DeleteProductImage(ctx, event.ImageId); err != nil {
+		return err
+	}
+	if err := queries.ScheduleBlobDeletion(ctx, event.BlobKey); err != nil {
+		return err
+	}
+
+	return arrangeProductImages(ctx, queries, event.AggregateId(), event.ProductGallery)
+}
+
+func arrangeProductImages(ctx context.Context, queries *db.Queries, productId uuid.UUID, gallery events.ProductGallery) error {
+	if len(gallery.ImageIds) == 0 {
+		return nil
+	}
+
+	return queries.ArrangeProductImages(ctx, db.ArrangeProductImagesParams{
+		PrimaryImageID: gallery.PrimaryImageId,
+		ImageIds:       gallery.ImageIds,
+		ProductID:      productId,
+	})
+}
+
+// attachDetails loads what belongs to the products besides their own row.
+func attachDetails(ctx context.Context, queries *db.Queries, products ...*entities.Product) error {
+	if err := attachVariants(ctx, queries, products...); err != nil {
+		return err
+	}
+
+	return attachImages(ctx, queries, products...)
+}
+
+// attachVariants loads the variants of all products in one query.
+func attachVariants(ctx context.Context, queries *db.Queries, products ...*entities.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	productIds := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		productIds = append(productIds, product.Id)
+	}
+	dbVariants, err := queries.ListProductVariants(ctx, productIds)
+	if err != nil {
+		return err
+	}
+
+	variants := make(map[uuid.UUID][]entities.ProductVariant, len(products))
+	for _, dbVariant := range dbVariants {
+		variant, err := variantFromRow(dbVariant)
+		if err != nil {
+			return err
+		}
+		variants[dbVariant.ProductID] = append(variants[dbVariant.ProductID], variant)
+	}
+	for _, product := range products {
+		product.Variants = variants[product.Id]
+	}
+
+	return nil
+}
+
+// attachImages loads the images of all products in one query, in display
+// order.
+func attachImages(ctx context.Context, queries *db.Queries, products ...*entities.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	productIds := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		productIds = append(productIds, product.Id)
+	}
+	dbImages, err := queries.ListProductImages(ctx, productIds)
+	if err != nil {
+		return err
+	}
+
+	images := make(map[uuid.UUID][]entities.ProductImage, len(products))
+	for _, dbImage := range dbImages {
+		images[dbImage.ProductID] = append(images[dbImage.ProductID], entities.ProductImage{
+			Id:          dbImage.ID,
+			BlobKey:     dbImage.BlobKey,
+			ContentType: dbImage.ContentType,
+			SizeBytes:   dbImage.SizeBytes,
+			Primary:     dbImage.IsPrimary,
+			CreatedAt:   timeFromTimestamptz(dbImage.CreatedAt),
+		})
+	}
+	for _, product := range products {
+		product.Images = images[product.Id]
+	}
+
+	return nil
+}
+
+func variantFromRow(dbVariant db.ProductVariant) (entities.ProductVariant, error) {
+	variant := entities.ProductVariant{
+		Id:  dbVariant.ID,
+		Sku: dbVariant.Sku,
+	}
+	if err := json.Unmarshal(dbVariant.Attributes, &variant.Attributes); err != nil {
+		return entities.ProductVariant{}, err
+	}
+	if dbVariant.PriceOverrideMinorUnits.Valid {
+		override, err := entities.NewMoney(dbVariant.PriceOverrideMinorUnits.Int64, entities.Currency(dbVariant.PriceOverrideCurrency.String))
+		if err != nil {
+			return entities.ProductVariant{}, err
+		}
+		variant.PriceOverride = &override
+	}
+
+	return variant, nil
+}
+
+// missedProductWrite explains a versioned write that matched no row: either
+// the product is gone (or soft-deleted) or someone else bumped the version.
+func missedProductWrite(ctx context.Context, queries *db.Queries, id uuid.UUID) error {
+	exists, err := queries.ProductExists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return entities.ErrProductNotFound
+	}
+
+	return entities.ErrVersionConflict
 }
 
-func productFromRow(id uuid.UUID, name string, priceMinorUnits int64, currency string, sellerId uuid.UUID, createdAt, updatedAt pgtype.Timestamptz) (*entities.Product, error) {
+func productFromRow(id uuid.UUID, name string, priceMinorUnits int64, currency string, sellerId uuid.UUID, status string, categoryIds []uuid.UUID, createdAt, updatedAt pgtype.Timestamptz, version int32) (*entities.Product, error) {
 	price, err := entities.NewMoney(priceMinorUnits, entities.Currency(currency))
 	if err != nil {
 		return nil, err
 	}
 
 	return &entities.Product{
-		Id:        id,
-		Name:      name,
-		Price:     price,
-		SellerId:  sellerId,
-		CreatedAt: timeFromTimestamptz(createdAt),
-		UpdatedAt: timeFromTimestamptz(updatedAt),
+		Id:          id,
+		Name:        name,
+		Price:       price,
+		SellerId:    sellerId,
+		Status:      entities.ProductStatus(status),
+		CategoryIds: categoryIds,
+		CreatedAt:   timeFromTimestamptz(createdAt),
+		UpdatedAt:   timeFromTimestamptz(updatedAt),
+		Version:     int(version),
 	}, nil
 }
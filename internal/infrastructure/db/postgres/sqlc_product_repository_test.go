@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
 	"github.com/sklinkert/go-ddd/internal/testhelpers"
 )
 
@@ -22,7 +23,7 @@ func mustMoney(t *testing.T, minorUnits int64, currency entities.Currency) entit
 
 func createTestSeller(t *testing.T, testDB *testhelpers.PostgresTestContainer, name string) *entities.ValidatedSeller {
 	t.Helper()
-	sellerRepo := NewSqlcSellerRepository(testDB.Queries)
+	sellerRepo := NewSqlcSellerRepository(testDB.Pool)
 
 	seller := entities.NewSeller(name)
 	validatedSeller, err := entities.NewValidatedSeller(seller)
@@ -125,7 +126,7 @@ func TestSqlcProductRepository_FindAll(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test finding all products
-	products, err := repo.FindAll(context.Background())
+	products, err := repo.FindAll(context.Background(), repositories.ProductListCriteria{Limit: 10})
 
 	// Assertions
 	require.NoError(t, err)
@@ -156,13 +157,212 @@ func TestSqlcProductRepository_FindAll_Empty(t *testing.T) {
 	repo := NewSqlcProductRepository(testDB.Pool)
 
 	// Test finding all when no products exist
-	products, err := repo.FindAll(context.Background())
+	products, err := repo.FindAll(context.Background(), repositories.ProductListCriteria{Limit: 10})
 
 	// Assertions
 	require.NoError(t, err)
 	assert.Empty(t, products)
 }
 
+func TestSqlcProductRepository_FindAll_KeysetPagesBySort(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcProductRepository(testDB.Pool)
+	validatedSeller := createTestSeller(t, testDB, "Test Seller")
+
+	// Equal prices force the id tiebreaker to keep pages disjoint.
+	for _, name := range []string{"Delta", "Alpha", "Charlie", "Bravo", "Echo"} {
+		product := entities.NewProduct(name, mustMoney(t, 500, entities.EUR), *validatedSeller)
+		validatedProduct, err := entities.NewValidatedProduct(product)
+		require.NoError(t, err)
+		_, err = repo.Create(context.Background(), validatedProduct)
+		require.NoError(t, err)
+	}
+
+	for _, sortBy := range []repositories.ProductSortField{
+		repositories.ProductSortByCreatedAt,
+		repositories.ProductSortByName,
+		repositories.ProductSortByPrice,
+	} {
+		t.Run(string(sortBy), func(t *testing.T) {
+			var seen []*entities.Product
+			criteria := repositories.ProductListCriteria{SortBy: sortBy, Limit: 2}
+			for {
+				page, err := repo.FindAll(context.Background(), criteria)
+				require.NoError(t, err)
+				seen = append(seen, page...)
+				if len(page) < criteria.Limit {
+					break
+				}
+				last := page[len(page)-1]
+				criteria.After = &repositories.ProductCursor{
+					Id:              last.Id,
+					CreatedAt:       last.CreatedAt,
+					Name:            last.Name,
+					PriceMinorUnits: last.Price.MinorUnits(),
+				}
+			}
+
+			require.Len(t, seen, 5)
+			ids := map[uuid.UUID]bool{}
+			for _, p := range seen {
+				ids[p.Id] = true
+			}
+			assert.Len(t, ids, 5, "pages must not overlap")
+			if sortBy == repositories.ProductSortByName {
+				assert.Equal(t, "Alpha", seen[0].Name)
+				assert.Equal(t, "Echo", seen[4].Name)
+			}
+			if sortBy == repositories.ProductSortByCreatedAt {
+				assert.Equal(t, "Echo", seen[0].Name, "newest first")
+			}
+		})
+	}
+}
+
+func TestSqlcProductRepository_FindAll_Filters(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcProductRepository(testDB.Pool)
+	seller1 := createTestSeller(t, testDB, "Seller 1")
+	seller2 := createTestSeller(t, testDB, "Seller 2")
+	categoryRepo := NewSqlcCategoryRepository(testDB.Pool).(*SqlcCategoryRepository)
+	furniture := createTestCategory(t, categoryRepo, "Furniture", nil)
+	chairs := createTestCategory(t, categoryRepo, "Chairs", furniture)
+	garden := createTestCategory(t, categoryRepo, "Garden", nil)
+
+	create := func(name string, price entities.Money, seller *entities.ValidatedSeller, publish bool, categoryIds ...uuid.UUID) {
+		product := entities.NewProduct(name, price, *seller)
+		if publish {
+			require.NoError(t, product.Publish())
+		}
+		require.NoError(t, product.Categorize(categoryIds))
+		validatedProduct, err := entities.NewValidatedProduct(product)
+		require.NoError(t, err)
+		_, err = repo.Create(context.Background(), validatedProduct)
+		require.NoError(t, err)
+	}
+	create("Cheap EUR", mustMoney(t, 100, entities.EUR), seller1, true, chairs.Id)
+	create("Pricey EUR", mustMoney(t, 10000, entities.EUR), seller1, false, furniture.Id, garden.Id)
+	create("Mid USD", mustMoney(t, 1000, entities.USD), seller2, true)
+
+	minPrice, maxPrice := int64(500), int64(5000)
+	tests := []struct {
+		name     string
+		criteria repositories.ProductListCriteria
+		expected []string
+	}{
+		{"seller", repositories.ProductListCriteria{SellerId: seller2.Id}, []string{"Mid USD"}},
+		{"currency", repositories.ProductListCriteria{Currency: entities.EUR}, []string{"Cheap EUR", "Pricey EUR"}},
+		{"min price", repositories.ProductListCriteria{MinPriceMinorUnits: &minPrice}, []string{"Mid USD", "Pricey EUR"}},
+		{"price range", repositories.ProductListCriteria{MinPriceMinorUnits: &minPrice, MaxPriceMinorUnits: &maxPrice}, []string{"Mid USD"}},
+		{"published", repositories.ProductListCriteria{Status: entities.ProductPublished}, []string{"Cheap EUR", "Mid USD"}},
+		{"seller drafts", repositories.ProductListCriteria{SellerId: seller1.Id, Status: entities.ProductDraft}, []string{"Pricey EUR"}},
+		{"category with descendants", repositories.ProductListCriteria{CategoryId: furniture.Id}, []string{"Cheap EUR", "Pricey EUR"}},
+		{"leaf category", repositories.ProductListCriteria{CategoryId: chairs.Id}, []string{"Cheap EUR"}},
+		{"category and status", repositories.ProductListCriteria{CategoryId: garden.Id, Status: entities.ProductPublished}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.criteria.SortBy = repositories.ProductSortByName
+			tt.criteria.Limit = 10
+
+			products, err := repo.FindAll(context.Background(), tt.criteria)
+			require.NoError(t, err)
+
+			var names []string
+			for _, p := range products {
+				names = append(names, p.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestSqlcProductRepository_Search(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcProductRepository(testDB.Pool)
+	ctx := context.Background()
+	seller := createTestSeller(t, testDB, "Seller")
+	categoryRepo := NewSqlcCategoryRepository(testDB.Pool).(*SqlcCategoryRepository)
+	furniture := createTestCategory(t, categoryRepo, "Furniture", nil)
+	chairs := createTestCategory(t, categoryRepo, "Chairs", furniture)
+
+	create := func(name string, publish bool, categoryIds ...uuid.UUID) *entities.Product {
+		product := entities.NewProduct(name, mustMoney(t, 1000, entities.EUR), *seller)
+		if publish {
+			require.NoError(t, product.Publish())
+		}
+		require.NoError(t, product.Categorize(categoryIds))
+		validatedProduct, err := entities.NewValidatedProduct(product)
+		require.NoError(t, err)
+		created, err := repo.Create(ctx, validatedProduct)
+		require.NoError(t, err)
+		return created
+	}
+	oakChair := create("Oak & Chair", true, chairs.Id)
+	rocker := create("Rocker", true, chairs.Id)
+	create("Pine Chair", false, chairs.Id)
+	create("Garden Hose", true)
+
+	search := func(terms ...string) []string {
+		t.Helper()
+		hits, err := repo.Search(ctx, repositories.ProductSearchCriteria{Terms: terms, Limit: 10})
+		require.NoError(t, err)
+		var names []string
+		for _, hit := range hits {
+			names = append(names, hit.Product.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"Oak & Chair", "Rocker"}, search("chairs"),
+		"stemmed, name matches rank above category matches, drafts are left out")
+	assert.ElementsMatch(t, []string{"Oak & Chair", "Rocker"}, search("furn"),
+		"prefixes match and ancestor categories count")
+	assert.Equal(t, []string{"Oak & Chair"}, search("oak", "chair"))
+	assert.Empty(t, search("oak", "hose"))
+
+	firstPage, err := repo.Search(ctx, repositories.ProductSearchCriteria{Terms: []string{"chair"}, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, firstPage, 1)
+	assert.Equal(t, oakChair.Id, firstPage[0].Product.Id)
+	assert.Equal(t, "Oak &amp; <mark>Chair</mark>", firstPage[0].Highlight)
+	assert.Equal(t, []uuid.UUID{chairs.Id}, firstPage[0].Product.CategoryIds)
+
+	secondPage, err := repo.Search(ctx, repositories.ProductSearchCriteria{
+		Terms: []string{"chair"},
+		After: &repositories.ProductSearchCursor{Rank: firstPage[0].Rank, Id: firstPage[0].Product.Id},
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+	assert.Equal(t, rocker.Id, secondPage[0].Product.Id)
+	assert.Less(t, secondPage[0].Rank, firstPage[0].Rank)
+
+	require.NoError(t, chairs.Rename("Seating"))
+	validatedCategory, err := entities.NewValidatedCategory(chairs)
+	require.NoError(t, err)
+	_, err = categoryRepo.Update(ctx, validatedCategory)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Oak & Chair", "Rocker"}, search("seat"),
+		"a renamed category is searchable under its new name")
+
+	reloaded, err := repo.FindById(ctx, rocker.Id)
+	require.NoError(t, err)
+	require.NoError(t, reloaded.Categorize(nil))
+	validatedProduct, err := entities.NewValidatedProduct(reloaded)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, validatedProduct)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Oak & Chair"}, search("seat"), "uncategorized products lose the category terms")
+}
+
 func TestSqlcProductRepository_Update(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
@@ -183,8 +383,11 @@ func TestSqlcProductRepository_Update(t *testing.T) {
 		Name:      "Updated Product",
 		Price:     mustMoney(t, 7500, entities.USD),
 		SellerId:  createdProduct.SellerId,
+		Status:    entities.ProductPublished,
+		Variants:  createdProduct.Variants,
 		CreatedAt: createdProduct.CreatedAt,
 		UpdatedAt: time.Now(),
+		Version:   createdProduct.Version,
 	}
 
 	validatedUpdatedProduct, err := entities.NewValidatedProduct(updatedProduct)
@@ -197,8 +400,54 @@ func TestSqlcProductRepository_Update(t *testing.T) {
 	require.NotNil(t, result)
 	assert.Equal(t, "Updated Product", result.Name)
 	assert.Equal(t, mustMoney(t, 7500, entities.USD), result.Price)
+	assert.Equal(t, entities.ProductPublished, result.Status)
 	assert.Equal(t, createdProduct.Id, result.Id)
 	assert.True(t, result.UpdatedAt.After(createdProduct.UpdatedAt))
+	assert.Equal(t, createdProduct.Version+1, result.Version)
+}
+
+func TestSqlcProductRepository_Update_StaleVersion(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcProductRepository(testDB.Pool)
+	validatedSeller := createTestSeller(t, testDB, "Test Seller")
+
+	product := entities.NewProduct("Test Product", mustMoney(t, 9999, entities.USD), *validatedSeller)
+	validatedProduct, err := entities.NewValidatedProduct(product)
+	require.NoError(t, err)
+
+	createdProduct, err := repo.Create(context.Background(), validatedProduct)
+	require.NoError(t, err)
+	assert.Equal(t, 1, createdProduct.Version)
+
+	// Two writers read the same version; only the first one may win.
+	first, err := repo.FindById(context.Background(), createdProduct.Id)
+	require.NoError(t, err)
+	second, err := repo.FindById(context.Background(), createdProduct.Id)
+	require.NoError(t, err)
+
+	require.NoError(t, first.UpdateName("First Writer"))
+	validatedFirst, err := entities.NewValidatedProduct(first)
+	require.NoError(t, err)
+	_, err = repo.Update(context.Background(), validatedFirst)
+	require.NoError(t, err)
+
+	require.NoError(t, second.UpdateName("Second Writer"))
+	validatedSecond, err := entities.NewValidatedProduct(second)
+	require.NoError(t, err)
+	_, err = repo.Update(context.Background(), validatedSecond)
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	// A stale delete is rejected as well.
+	err = repo.Delete(context.Background(), second)
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	stored, err := repo.FindById(context.Background(), createdProduct.Id)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "First Writer", stored.Name)
+	assert.Equal(t, 2, stored.Version)
 }
 
 func TestSqlcProductRepository_Update_NotFound(t *testing.T) {
@@ -213,6 +462,8 @@ func TestSqlcProductRepository_Update_NotFound(t *testing.T) {
 		Name:      "Non-existent Product",
 		Price:     mustMoney(t, 10000, entities.USD),
 		SellerId:  uuid.New(),
+		Status:    entities.ProductDraft,
+		Variants:  []entities.ProductVariant{entities.NewProductVariant("SKU-1", nil, nil)},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -223,10 +474,125 @@ func TestSqlcProductRepository_Update_NotFound(t *testing.T) {
 	result, err := repo.Update(context.Background(), validatedNonExistentProduct)
 
 	// Assertions
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, entities.ErrProductNotFound)
 	assert.Nil(t, result)
 }
 
+func TestSqlcProductRepository_Variants(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcProductRepository(testDB.Pool)
+	inventories := NewSqlcInventoryRepository(testDB.Pool)
+	validatedSeller := createTestSeller(t, testDB, "Test Seller")
+	ctx := context.Background()
+
+	override := mustMoney(t, 1299, entities.USD)
+	small := entities.NewProductVariant("TEE-S", map[string]string{"size": "S"}, nil)
+	large := entities.NewProductVariant("TEE-L", map[string]string{"size": "L"}, &override)
+	product, err := entities.NewValidatedProduct(entities.NewProduct("T-shirt", mustMoney(t, 999, entities.USD), *validatedSeller, small, large))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, product)
+	require.NoError(t, err)
+
+	found, err := repo.FindById(ctx, product.Id)
+	require.NoError(t, err)
+	require.Len(t, found.Variants, 2)
+	assert.Equal(t, small, found.Variants[0])
+	assert.Equal(t, large, found.Variants[1], "attributes and overrides round-trip")
+
+	// SKUs are unique across products.
+	other, err := entities.NewValidatedProduct(entities.NewProduct("Hoodie", mustMoney(t, 4999, entities.USD), *validatedSeller,
+		entities.NewProductVariant("TEE-S", nil, nil)))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, other)
+	assert.ErrorIs(t, err, entities.ErrSkuTaken)
+
+	medium := entities.NewProductVariant("TEE-M", map[string]string{"size": "M"}, nil)
+	require.NoError(t, found.AddVariant(medium))
+	require.NoError(t, found.UpdateVariant(small.Id, "TEE-XS", map[string]string{"size": "XS"}, nil))
+	validated, err := entities.NewValidatedProduct(found)
+	require.NoError(t, err)
+	updated, err := repo.Update(ctx, validated)
+	require.NoError(t, err)
+	require.Len(t, updated.Variants, 3)
+	assert.Equal(t, "TEE-XS", updated.Variants[0].Sku)
+
+	// A variant with reserved stock stays until the reservation ends.
+	inventory, err := inventories.Lock(ctx, medium.Id)
+	require.NoError(t, err)
+	require.NoError(t, inventory.AdjustStock(1, ""))
+	_, err = inventory.Reserve(1, time.Minute)
+	require.NoError(t, err)
+	validatedInventory, err := entities.NewValidatedInventory(inventory)
+	require.NoError(t, err)
+	_, err = inventories.Save(ctx, validatedInventory)
+	require.NoError(t, err)
+
+	require.NoError(t, updated.RemoveVariant(medium.Id))
+	validated, err = entities.NewValidatedProduct(updated)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, validated)
+	assert.ErrorIs(t, err, entities.ErrVariantReserved)
+
+	updated, err = repo.FindById(ctx, product.Id)
+	require.NoError(t, err)
+	require.NoError(t, updated.RemoveVariant(large.Id))
+	validated, err = entities.NewValidatedProduct(updated)
+	require.NoError(t, err)
+	updated, err = repo.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.Len(t, updated.Variants, 2)
+}
+
+func TestSqlcProductRepository_Images(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcProductRepository(testDB.Pool)
+	deletions := NewSqlcBlobDeletionRepository(testDB.Queries)
+	validatedSeller := createTestSeller(t, testDB, "Test Seller")
+	ctx := context.Background()
+
+	product := entities.NewProduct("Widget", mustMoney(t, 999, entities.USD), *validatedSeller)
+	front, err := entities.NewProductImage(product.Id, "image/png", 10)
+	require.NoError(t, err)
+	back, err := entities.NewProductImage(product.Id, "image/jpeg", 20)
+	require.NoError(t, err)
+	require.NoError(t, product.AddImage(front, false))
+	require.NoError(t, product.AddImage(back, true))
+	validated, err := entities.NewValidatedProduct(product)
+	require.NoError(t, err)
+	created, err := repo.Create(ctx, validated)
+	require.NoError(t, err)
+	require.Len(t, created.Images, 2)
+	assert.Equal(t, front.BlobKey, created.Images[0].BlobKey)
+	assert.Equal(t, back.Id, created.Images[1].Id)
+	assert.True(t, created.Images[1].Primary)
+	assert.False(t, created.Images[0].Primary)
+
+	require.NoError(t, created.ReorderImages([]uuid.UUID{back.Id, front.Id}))
+	require.NoError(t, created.RemoveImage(back.Id))
+	validated, err = entities.NewValidatedProduct(created)
+	require.NoError(t, err)
+	updated, err := repo.Update(ctx, validated)
+	require.NoError(t, err)
+	require.Len(t, updated.Images, 1)
+	assert.Equal(t, front.Id, updated.Images[0].Id)
+	assert.True(t, updated.Images[0].Primary, "the remaining image takes over as primary")
+
+	due, err := deletions.FindDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{back.BlobKey}, due, "a removed image's blob is scheduled for deletion")
+	require.NoError(t, deletions.Remove(ctx, back.BlobKey))
+
+	// Deleting the product schedules the blobs of all its images.
+	require.NoError(t, repo.Delete(ctx, updated))
+	due, err = deletions.FindDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{front.BlobKey}, due)
+}
+
 func TestSqlcProductRepository_Delete(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
@@ -242,7 +608,7 @@ func TestSqlcProductRepository_Delete(t *testing.T) {
 	require.NoError(t, err)
 
 	// Delete the product
-	err = repo.Delete(context.Background(), createdProduct.Id)
+	err = repo.Delete(context.Background(), createdProduct)
 	require.NoError(t, err)
 
 	// Verify product is deleted
@@ -258,11 +624,11 @@ func TestSqlcProductRepository_Delete_NotFound(t *testing.T) {
 	repo := NewSqlcProductRepository(testDB.Pool)
 
 	// Try to delete non-existent product
-	nonExistentId := uuid.New()
-	err := repo.Delete(context.Background(), nonExistentId)
+	nonExistentProduct := &entities.Product{Id: uuid.New(), Version: 1}
+	err := repo.Delete(context.Background(), nonExistentProduct)
 
-	// Note: PostgreSQL DELETE doesn't fail if the row doesn't exist
-	// So this should not return an error
+	// Deleting a missing product is a no-op, so this should not return an
+	// error
 	assert.NoError(t, err)
 }
 
@@ -278,6 +644,8 @@ func TestSqlcProductRepository_Create_WithInvalidSeller(t *testing.T) {
 		Name:      "Test Product",
 		Price:     mustMoney(t, 9999, entities.USD),
 		SellerId:  uuid.New(),
+		Status:    entities.ProductDraft,
+		Variants:  []entities.ProductVariant{entities.NewProductVariant("SKU-1", nil, nil)},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
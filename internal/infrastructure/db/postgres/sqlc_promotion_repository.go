@@ -0,0 +1,276 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/events"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+type SqlcPromotionRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewSqlcPromotionRepository(pool *pgxpool.Pool) repositories.PromotionRepository {
+	return &SqlcPromotionRepository{pool: pool, queries: db.New(pool)}
+}
+
+// Create persists the promotion and its recorded domain events in one
+// transaction (transactional outbox).
+func (repo *SqlcPromotionRepository) Create(ctx context.Context, promotion *entities.ValidatedPromotion) (*entities.Promotion, error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	params := db.CreatePromotionParams{
+		ID:           promotion.Id,
+		SellerID:     promotion.SellerId,
+		Name:         promotion.Name,
+		DiscountKind: string(promotion.Discount.Kind),
+		// A nil slice would be stored as NULL.
+		ProductIds: append([]uuid.UUID{}, promotion.ProductIds...),
+		StartsAt:   timestamptzFromTime(promotion.StartsAt),
+		EndsAt:     nullableTimestamptz(promotion.EndsAt),
+		Stackable:  promotion.Stackable,
+		UsageLimit: int32(promotion.UsageLimit),
+		UsageCount: int32(promotion.UsageCount),
+		CreatedAt:  timestamptzFromTime(promotion.CreatedAt),
+		UpdatedAt:  timestamptzFromTime(promotion.UpdatedAt),
+		Version:    int32(promotion.Version),
+	}
+	switch promotion.Discount.Kind {
+	case entities.DiscountPercentage:
+		params.BasisPoints = pgtype.Int8{Int64: promotion.Discount.BasisPoints, Valid: true}
+	case entities.DiscountFixedAmount:
+		params.AmountMinorUnits = pgtype.Int8{Int64: promotion.Discount.Amount.MinorUnits(), Valid: true}
+		params.Currency = nullableText(string(promotion.Discount.Amount.Currency()))
+	}
+	if err := qtx.CreatePromotion(ctx, params); err != nil {
+		return nil, err
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, promotion.PullEvents()); err != nil {
+		return nil, err
+	}
+
+	created, err := findPromotion(ctx, qtx, promotion.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (repo *SqlcPromotionRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.Promotion, error) {
+	promotion, err := findPromotion(ctx, queriesFor(ctx, repo.queries), id)
+	if errors.Is(err, entities.ErrPromotionNotFound) {
+		// A missing row is not an error: return (nil, nil) so callers can
+		// translate it into a 404 instead of a 500.
+		return nil, nil
+	}
+
+	return promotion, err
+}
+
+func (repo *SqlcPromotionRepository) FindBySellerId(ctx context.Context, sellerId uuid.UUID) ([]*entities.Promotion, error) {
+	rows, err := queriesFor(ctx, repo.queries).ListPromotionsBySeller(ctx, sellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return promotionsFromRows(rows)
+}
+
+func (repo *SqlcPromotionRepository) FindActive(ctx context.Context, sellerIds []uuid.UUID, at time.Time) ([]*entities.Promotion, error) {
+	if len(sellerIds) == 0 {
+		return nil, nil
+	}
+
+	rows, err := queriesFor(ctx, repo.queries).ListActivePromotions(ctx, db.ListActivePromotionsParams{
+		SellerIds: sellerIds,
+		At:        timestamptzFromTime(at),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return promotionsFromRows(rows)
+}
+
+// Update writes the promotion's end and usage count and its recorded
+// events in one transaction.
+func (repo *SqlcPromotionRepository) Update(ctx context.Context, promotion *entities.ValidatedPromotion) (*entities.Promotion, error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	rows, err := qtx.UpdatePromotion(ctx, db.UpdatePromotionParams{
+		ID:         promotion.Id,
+		EndsAt:     nullableTimestamptz(promotion.EndsAt),
+		UsageCount: int32(promotion.UsageCount),
+		UpdatedAt:  timestamptzFromTime(promotion.UpdatedAt),
+		Version:    int32(promotion.Version),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		exists, err := qtx.PromotionExists(ctx, promotion.Id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, entities.ErrPromotionNotFound
+		}
+		return nil, entities.ErrVersionConflict
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, promotion.PullEvents()); err != nil {
+		return nil, err
+	}
+
+	updated, err := findPromotion(ctx, qtx, promotion.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Redeem counts one use in a single conditional UPDATE and stores the
+// PromotionRedeemed event with the resulting count, in one transaction. The
+// row lock orders concurrent redemptions; none of them conflicts.
+func (repo *SqlcPromotionRepository) Redeem(ctx context.Context, id uuid.UUID, at time.Time) (*entities.Promotion, error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	row, err := qtx.RedeemPromotion(ctx, db.RedeemPromotionParams{ID: id, At: timestamptzFromTime(at)})
+	if errors.Is(err, pgx.ErrNoRows) {
+		exists, err := qtx.PromotionExists(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, entities.ErrPromotionNotFound
+		}
+		return nil, entities.ErrPromotionExhausted
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	redeemed, err := promotionFromRow(row)
+	if err != nil {
+		return nil, err
+	}
+
+	redemption := events.NewPromotionRedeemed(redeemed.Id, redeemed.UsageCount, redeemed.UsageLimit)
+	if err := insertOutboxEvents(ctx, qtx, []events.DomainEvent{redemption}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return redeemed, nil
+}
+
+func findPromotion(ctx context.Context, queries *db.Queries, id uuid.UUID) (*entities.Promotion, error) {
+	row, err := queries.GetPromotionById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrPromotionNotFound
+		}
+		return nil, err
+	}
+
+	return promotionFromRow(row)
+}
+
+func promotionsFromRows(rows []db.Promotion) ([]*entities.Promotion, error) {
+	promotions := make([]*entities.Promotion, 0, len(rows))
+	for _, row := range rows {
+		promotion, err := promotionFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	return promotions, nil
+}
+
+func promotionFromRow(row db.Promotion) (*entities.Promotion, error) {
+	var discount entities.Discount
+	switch entities.DiscountKind(row.DiscountKind) {
+	case entities.DiscountPercentage:
+		discount = entities.NewPercentageDiscount(row.BasisPoints.Int64)
+	case entities.DiscountFixedAmount:
+		amount, err := entities.NewMoney(row.AmountMinorUnits.Int64, entities.Currency(row.Currency.String))
+		if err != nil {
+			return nil, err
+		}
+		discount = entities.NewFixedAmountDiscount(amount)
+	default:
+		discount = entities.Discount{Kind: entities.DiscountKind(row.DiscountKind)}
+	}
+
+	var endsAt *time.Time
+	if row.EndsAt.Valid {
+		endsAt = &row.EndsAt.Time
+	}
+	var productIds []uuid.UUID
+	if len(row.ProductIds) > 0 {
+		productIds = row.ProductIds
+	}
+
+	return &entities.Promotion{
+		Id:       row.ID,
+		SellerId: row.SellerID,
+		Name:     row.Name,
+		Discount: discount,
+		PromotionTerms: entities.PromotionTerms{
+			ProductIds: productIds,
+			StartsAt:   timeFromTimestamptz(row.StartsAt),
+			EndsAt:     endsAt,
+			Stackable:  row.Stackable,
+			UsageLimit: int(row.UsageLimit),
+		},
+		UsageCount: int(row.UsageCount),
+		CreatedAt:  timeFromTimestamptz(row.CreatedAt),
+		UpdatedAt:  timeFromTimestamptz(row.UpdatedAt),
+		Version:    int(row.Version),
+	}, nil
+}
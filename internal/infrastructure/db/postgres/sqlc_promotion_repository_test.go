@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/services"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func newTestPromotion(t *testing.T, sellerId uuid.UUID, discount entities.Discount, terms entities.PromotionTerms) *entities.ValidatedPromotion {
+	t.Helper()
+	promotion, err := entities.NewValidatedPromotion(entities.NewPromotion(sellerId, "Spring sale", discount, terms))
+	require.NoError(t, err)
+	return promotion
+}
+
+func TestSqlcPromotionRepository_CreateAndFind(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	seller := createTestSeller(t, testDB, "Acme")
+	repo := NewSqlcPromotionRepository(testDB.Pool)
+	ctx := context.Background()
+	startsAt := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	endsAt := startsAt.Add(48 * time.Hour)
+	productId := uuid.New()
+
+	percentage, err := repo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1500), entities.PromotionTerms{
+		ProductIds: []uuid.UUID{productId},
+		StartsAt:   startsAt,
+		EndsAt:     &endsAt,
+		Stackable:  true,
+		UsageLimit: 10,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, entities.NewPercentageDiscount(1500), percentage.Discount)
+	assert.Equal(t, []uuid.UUID{productId}, percentage.ProductIds)
+	require.NotNil(t, percentage.EndsAt)
+	assert.True(t, endsAt.Equal(*percentage.EndsAt))
+	assert.True(t, percentage.Stackable)
+	assert.Equal(t, 10, percentage.UsageLimit)
+
+	fixed, err := repo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewFixedAmountDiscount(mustMoney(t, 500, entities.EUR)), entities.PromotionTerms{
+		StartsAt: startsAt,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, mustMoney(t, 500, entities.EUR), fixed.Discount.Amount)
+	assert.Empty(t, fixed.ProductIds)
+	assert.Nil(t, fixed.EndsAt)
+
+	found, err := repo.FindById(ctx, fixed.Id)
+	require.NoError(t, err)
+	assert.Equal(t, fixed.Discount, found.Discount)
+
+	missing, err := repo.FindById(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	bySeller, err := repo.FindBySellerId(ctx, seller.Id)
+	require.NoError(t, err)
+	require.Len(t, bySeller, 2)
+	assert.Equal(t, fixed.Id, bySeller[0].Id, "newest first")
+}
+
+func TestSqlcPromotionRepository_FindActive(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	seller := createTestSeller(t, testDB, "Acme")
+	other := createTestSeller(t, testDB, "Other")
+	repo := NewSqlcPromotionRepository(testDB.Pool)
+	ctx := context.Background()
+	now := time.Now()
+	ended := now.Add(-time.Minute)
+
+	running, err := repo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: now.Add(-time.Hour)}))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: now.Add(time.Hour)}))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: now.Add(-time.Hour), EndsAt: &ended}))
+	require.NoError(t, err)
+	otherRunning, err := repo.Create(ctx, newTestPromotion(t, other.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: now.Add(-time.Hour)}))
+	require.NoError(t, err)
+
+	exhausted, err := repo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: now.Add(-time.Hour), UsageLimit: 1}))
+	require.NoError(t, err)
+	require.NoError(t, exhausted.Redeem(now))
+	validated, err := entities.NewValidatedPromotion(exhausted)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, validated)
+	require.NoError(t, err)
+
+	active, err := repo.FindActive(ctx, []uuid.UUID{seller.Id}, now)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, running.Id, active[0].Id)
+
+	active, err = repo.FindActive(ctx, []uuid.UUID{seller.Id, other.Id}, now)
+	require.NoError(t, err)
+	assert.Len(t, active, 2)
+	assert.Contains(t, []uuid.UUID{active[0].Id, active[1].Id}, otherRunning.Id)
+
+	active, err = repo.FindActive(ctx, nil, now)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+}
+
+func TestSqlcPromotionRepository_Update(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	seller := createTestSeller(t, testDB, "Acme")
+	repo := NewSqlcPromotionRepository(testDB.Pool)
+	ctx := context.Background()
+	created, err := repo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: time.Now().Add(-time.Hour)}))
+	require.NoError(t, err)
+
+	require.NoError(t, created.Redeem(time.Now()))
+	require.NoError(t, created.End(time.Now()))
+	validated, err := entities.NewValidatedPromotion(created)
+	require.NoError(t, err)
+	updated, err := repo.Update(ctx, validated)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated.UsageCount)
+	assert.NotNil(t, updated.EndsAt)
+	assert.Equal(t, 2, updated.Version)
+
+	_, err = repo.Update(ctx, validated)
+	assert.ErrorIs(t, err, entities.ErrVersionConflict, "the stored version moved on")
+
+	unknown := newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: time.Now()})
+	_, err = repo.Update(ctx, unknown)
+	assert.ErrorIs(t, err, entities.ErrPromotionNotFound)
+}
+
+func TestSqlcPromotionRepository_Redeem(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	seller := createTestSeller(t, testDB, "Acme")
+	repo := NewSqlcPromotionRepository(testDB.Pool)
+	ctx := context.Background()
+	now := time.Now()
+	limited, err := repo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: now.Add(-time.Hour), UsageLimit: 1}))
+	require.NoError(t, err)
+	upcoming, err := repo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: now.Add(time.Hour)}))
+	require.NoError(t, err)
+
+	redeemed, err := repo.Redeem(ctx, limited.Id, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, redeemed.UsageCount)
+	assert.Equal(t, 2, redeemed.Version)
+
+	var stored int
+	require.NoError(t, testDB.Pool.QueryRow(ctx,
+		`SELECT count(*) FROM outbox_events WHERE aggregate_id = $1 AND event_name = 'promotion.redeemed'`, limited.Id).Scan(&stored))
+	assert.Equal(t, 1, stored, "the redemption event is stored with the count")
+
+	_, err = repo.Redeem(ctx, limited.Id, now)
+	assert.ErrorIs(t, err, entities.ErrPromotionExhausted)
+	_, err = repo.Redeem(ctx, upcoming.Id, now)
+	assert.ErrorIs(t, err, entities.ErrPromotionExhausted, "not running yet")
+	_, err = repo.Redeem(ctx, uuid.New(), now)
+	assert.ErrorIs(t, err, entities.ErrPromotionNotFound)
+}
+
+// TestSqlcPromotionRepository_ConcurrentCheckouts runs checkouts of the same
+// promotion side by side through the real OrderService.
+func TestSqlcPromotionRepository_ConcurrentCheckouts(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	ctx := context.Background()
+	seller := createTestSeller(t, testDB, "Acme")
+	productRepo := NewSqlcProductRepository(testDB.Pool)
+	promotionRepo := NewSqlcPromotionRepository(testDB.Pool)
+	transactor := NewTransactor(testDB.Pool)
+	idempotencyRepo := NewSqlcIdempotencyRepository(testDB.Queries)
+	inventoryService := services.NewInventoryService(NewSqlcInventoryRepository(testDB.Pool), productRepo, transactor, idempotencyRepo)
+	orderService := services.NewOrderService(NewSqlcOrderRepository(testDB.Pool), productRepo, promotionRepo, inventoryService, transactor, idempotencyRepo, time.Minute)
+
+	draft := entities.NewProduct("Widget", mustMoney(t, 1000, entities.USD), *seller)
+	require.NoError(t, draft.Publish())
+	product, err := entities.NewValidatedProduct(draft)
+	require.NoError(t, err)
+	_, err = productRepo.Create(ctx, product)
+	require.NoError(t, err)
+	_, err = inventoryService.AdjustStock(ctx, &command.AdjustStockCommand{ProductId: product.Id, Delta: 100})
+	require.NoError(t, err)
+
+	// checkoutConcurrently returns the unit price of each order that went
+	// through and the errors of the others.
+	checkoutConcurrently := func(checkouts int) ([]int64, []error) {
+		var mu sync.Mutex
+		var prices []int64
+		var errs []error
+		var wg sync.WaitGroup
+		for range checkouts {
+			wg.Go(func() {
+				created, err := orderService.CreateOrder(ctx, &command.CreateOrderCommand{
+					BuyerId: uuid.New(),
+					Items:   []command.CreateOrderItem{{ProductId: product.Id, Quantity: 1}},
+				})
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs = append(errs, err)
+					return
+				}
+				prices = append(prices, created.Result.Items[0].UnitPrice.MinorUnits())
+			})
+		}
+		wg.Wait()
+		return prices, errs
+	}
+
+	unlimited, err := promotionRepo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(1000), entities.PromotionTerms{StartsAt: time.Now().Add(-time.Hour)}))
+	require.NoError(t, err)
+
+	prices, errs := checkoutConcurrently(10)
+	assert.Empty(t, errs, "redemptions of an unlimited promotion never conflict")
+	assert.Len(t, prices, 10)
+	for _, price := range prices {
+		assert.Equal(t, int64(900), price)
+	}
+	found, err := promotionRepo.FindById(ctx, unlimited.Id)
+	require.NoError(t, err)
+	assert.Equal(t, 10, found.UsageCount)
+
+	ended := time.Now()
+	require.NoError(t, found.End(ended))
+	validated, err := entities.NewValidatedPromotion(found)
+	require.NoError(t, err)
+	_, err = promotionRepo.Update(ctx, validated)
+	require.NoError(t, err)
+	limited, err := promotionRepo.Create(ctx, newTestPromotion(t, seller.Id, entities.NewPercentageDiscount(2000), entities.PromotionTerms{StartsAt: ended.Add(-time.Hour), UsageLimit: 3}))
+	require.NoError(t, err)
+
+	// Checkouts that priced with the promotion after its last redemption was
+	// taken fail; those that came later pay the list price.
+	prices, errs = checkoutConcurrently(10)
+	for _, err := range errs {
+		assert.ErrorIs(t, err, entities.ErrPromotionExhausted)
+	}
+	assert.Equal(t, 3, countOf(prices, 800), "exactly the usage limit is sold at the discount")
+	found, err = promotionRepo.FindById(ctx, limited.Id)
+	require.NoError(t, err)
+	assert.Equal(t, 3, found.UsageCount)
+}
+
+func countOf(values []int64, value int64) int {
+	count := 0
+	for _, v := range values {
+		if v == value {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+type SqlcScheduledPriceChangeRepository struct {
+	queries *db.Queries
+}
+
+func NewSqlcScheduledPriceChangeRepository(pool *pgxpool.Pool) repositories.ScheduledPriceChangeRepository {
+	return &SqlcScheduledPriceChangeRepository{queries: db.New(pool)}
+}
+
+func (repo *SqlcScheduledPriceChangeRepository) Create(ctx context.Context, change *entities.ScheduledPriceChange) error {
+	return queriesFor(ctx, repo.queries).InsertScheduledPriceChange(ctx, db.InsertScheduledPriceChangeParams{
+		ID:              change.Id,
+		ProductID:       change.ProductId,
+		PriceMinorUnits: change.Price.MinorUnits(),
+		Currency:        string(change.Price.Currency()),
+		EffectiveAt:     timestamptzFromTime(change.EffectiveAt),
+		CreatedAt:       timestamptzFromTime(change.CreatedAt),
+	})
+}
+
+func (repo *SqlcScheduledPriceChangeRepository) FindByProductId(ctx context.Context, productId uuid.UUID) ([]*entities.ScheduledPriceChange, error) {
+	rows, err := queriesFor(ctx, repo.queries).ListScheduledPriceChanges(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledPriceChangesFromRows(rows)
+}
+
+func (repo *SqlcScheduledPriceChangeRepository) Delete(ctx context.Context, productId, id uuid.UUID) error {
+	rows, err := queriesFor(ctx, repo.queries).DeleteScheduledPriceChange(ctx, db.DeleteScheduledPriceChangeParams{
+		ID:        id,
+		ProductID: productId,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrScheduledPriceChangeNotFound
+	}
+
+	return nil
+}
+
+func (repo *SqlcScheduledPriceChangeRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.ScheduledPriceChange, error) {
+	rows, err := queriesFor(ctx, repo.queries).ListDueScheduledPriceChanges(ctx, db.ListDueScheduledPriceChangesParams{
+		EffectiveAt: timestamptzFromTime(now),
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledPriceChangesFromRows(rows)
+}
+
+func scheduledPriceChangesFromRows(rows []db.ScheduledPriceChange) ([]*entities.ScheduledPriceChange, error) {
+	changes := make([]*entities.ScheduledPriceChange, 0, len(rows))
+	for _, row := range rows {
+		price, err := entities.NewMoney(row.PriceMinorUnits, entities.Currency(row.Currency))
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &entities.ScheduledPriceChange{
+			Id:          row.ID,
+			ProductId:   row.ProductID,
+			Price:       price,
+			EffectiveAt: timeFromTimestamptz(row.EffectiveAt),
+			CreatedAt:   timeFromTimestamptz(row.CreatedAt),
+		})
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func TestSqlcScheduledPriceChangeRepository(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcScheduledPriceChangeRepository(testDB.Pool)
+	ctx := context.Background()
+	product := createTestProduct(t, testDB)
+
+	later, err := entities.NewScheduledPriceChange(product.Id, mustMoney(t, 899, entities.USD), time.Now().Add(48*time.Hour))
+	require.NoError(t, err)
+	sooner, err := entities.NewScheduledPriceChange(product.Id, mustMoney(t, 799, entities.USD), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, later))
+	require.NoError(t, repo.Create(ctx, sooner))
+
+	changes, err := repo.FindByProductId(ctx, product.Id)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, sooner.Id, changes[0].Id)
+	assert.Equal(t, sooner.Price, changes[0].Price)
+
+	due, err := repo.FindDue(ctx, time.Now().Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, sooner.Id, due[0].Id)
+
+	require.NoError(t, repo.Delete(ctx, product.Id, sooner.Id))
+	assert.ErrorIs(t, repo.Delete(ctx, product.Id, sooner.Id), entities.ErrScheduledPriceChangeNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, uuid.New(), later.Id), entities.ErrScheduledPriceChangeNotFound)
+
+	due, err = repo.FindDue(ctx, time.Now().Add(2*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+}
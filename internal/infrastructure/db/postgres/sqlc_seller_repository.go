@@ -6,6 +6,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
 	"github.com/sklinkert/go-ddd/internal/domain/repositories"
@@ -13,29 +15,50 @@ import (
 )
 
 type SqlcSellerRepository struct {
+	pool    *pgxpool.Pool
 	queries *db.Queries
 }
 
-func NewSqlcSellerRepository(queries *db.Queries) repositories.SellerRepository {
-	return &SqlcSellerRepository{queries: queries}
+func NewSqlcSellerRepository(pool *pgxpool.Pool) repositories.SellerRepository {
+	return &SqlcSellerRepository{pool: pool, queries: db.New(pool)}
 }
 
+// Create persists the seller and its recorded domain events in one
+// transaction (transactional outbox).
 func (repo *SqlcSellerRepository) Create(ctx context.Context, seller *entities.ValidatedSeller) (*entities.Seller, error) {
-	createdSeller, err := repo.queries.CreateSeller(ctx, db.CreateSellerParams{
-		ID:        seller.Id,
-		Name:      seller.Name,
-		CreatedAt: timestamptzFromTime(seller.CreatedAt),
-		UpdatedAt: timestamptzFromTime(seller.UpdatedAt),
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	createdSeller, err := qtx.CreateSeller(ctx, db.CreateSellerParams{
+		ID:                 seller.Id,
+		Name:               seller.Name,
+		VerificationStatus: string(seller.VerificationStatus),
+		CreatedAt:          timestamptzFromTime(seller.CreatedAt),
+		UpdatedAt:          timestamptzFromTime(seller.UpdatedAt),
+		Version:            int32(seller.Version),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return repo.FindById(ctx, createdSeller.ID)
+	if err := insertOutboxEvents(ctx, qtx, seller.PullEvents()); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return sellerFromRow(createdSeller.ID, createdSeller.Name, createdSeller.VerificationStatus, createdSeller.CreatedAt, createdSeller.UpdatedAt, createdSeller.Version), nil
 }
 
 func (repo *SqlcSellerRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.Seller, error) {
-	dbSeller, err := repo.queries.GetSellerById(ctx, id)
+	dbSeller, err := queriesFor(ctx, repo.queries).GetSellerById(ctx, id)
 	if err != nil {
 		// A missing row is not an error: return (nil, nil) so callers can
 		// translate it into a 404 instead of a 500.
@@ -48,57 +71,142 @@ func (repo *SqlcSellerRepository) FindById(ctx context.Context, id uuid.UUID) (*
 	return fromSqlcSellerRow(&dbSeller), nil
 }
 
-func (repo *SqlcSellerRepository) FindAll(ctx context.Context) ([]*entities.Seller, error) {
-	dbSellers, err := repo.queries.GetAllSellers(ctx)
-	if err != nil {
-		return nil, err
+func (repo *SqlcSellerRepository) FindAll(ctx context.Context, criteria repositories.SellerListCriteria) ([]*entities.Seller, error) {
+	after := criteria.After
+	if after == nil {
+		after = &repositories.SellerCursor{}
 	}
 
-	sellers := make([]*entities.Seller, len(dbSellers))
-	for i, dbSeller := range dbSellers {
-		sellers[i] = fromSqlcSellerAllRow(&dbSeller)
+	var sellers []*entities.Seller
+	switch criteria.SortBy {
+	case repositories.SellerSortByName:
+		dbSellers, err := queriesFor(ctx, repo.queries).ListSellersByName(ctx, db.ListSellersByNameParams{
+			AfterID:   nullableUUID(after.Id),
+			AfterName: pgtype.Text{String: after.Name, Valid: true},
+			Limit:     int32(criteria.Limit),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, dbSeller := range dbSellers {
+			sellers = append(sellers, sellerFromRow(dbSeller.ID, dbSeller.Name, dbSeller.VerificationStatus, dbSeller.CreatedAt, dbSeller.UpdatedAt, dbSeller.Version))
+		}
+	default:
+		dbSellers, err := queriesFor(ctx, repo.queries).ListSellersByCreatedAt(ctx, db.ListSellersByCreatedAtParams{
+			AfterID:        nullableUUID(after.Id),
+			AfterCreatedAt: timestamptzFromTime(after.CreatedAt),
+			Limit:          int32(criteria.Limit),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, dbSeller := range dbSellers {
+			sellers = append(sellers, sellerFromRow(dbSeller.ID, dbSeller.Name, dbSeller.VerificationStatus, dbSeller.CreatedAt, dbSeller.UpdatedAt, dbSeller.Version))
+		}
 	}
 
 	return sellers, nil
 }
 
+// Update writes the seller and its recorded events in one transaction.
 func (repo *SqlcSellerRepository) Update(ctx context.Context, seller *entities.ValidatedSeller) (*entities.Seller, error) {
-	rows, err := repo.queries.UpdateSeller(ctx, db.UpdateSellerParams{
-		ID:        seller.Id,
-		Name:      seller.Name,
-		UpdatedAt: timestamptzFromTime(seller.UpdatedAt),
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	rows, err := qtx.UpdateSeller(ctx, db.UpdateSellerParams{
+		ID:                 seller.Id,
+		Name:               seller.Name,
+		VerificationStatus: string(seller.VerificationStatus),
+		UpdatedAt:          timestamptzFromTime(seller.UpdatedAt),
+		Version:            int32(seller.Version),
 	})
 	if err != nil {
 		return nil, err
 	}
 	if rows == 0 {
-		// Nothing matched: the seller does not exist (or is soft-deleted).
-		return nil, entities.ErrSellerNotFound
+		return nil, missedSellerWrite(ctx, qtx, seller.Id)
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, seller.PullEvents()); err != nil {
+		return nil, err
 	}
 
-	return repo.FindById(ctx, seller.Id)
+	dbSeller, err := qtx.GetSellerById(ctx, seller.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return fromSqlcSellerRow(&dbSeller), nil
+}
+
+// Delete soft-deletes the seller and stores its recorded events (e.g.
+// SellerDeleted) in the same transaction. Deleting a seller that no longer
+// exists is a no-op and publishes nothing; deleting a stale version is a
+// conflict.
+func (repo *SqlcSellerRepository) Delete(ctx context.Context, seller *entities.Seller) error {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := repo.queries.WithTx(tx)
+
+	rows, err := qtx.DeleteSeller(ctx, db.DeleteSellerParams{
+		ID:      seller.Id,
+		Version: int32(seller.Version),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		if err := missedSellerWrite(ctx, qtx, seller.Id); !errors.Is(err, entities.ErrSellerNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	if err := insertOutboxEvents(ctx, qtx, seller.PullEvents()); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
-func (repo *SqlcSellerRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return repo.queries.DeleteSeller(ctx, id)
+// missedSellerWrite explains a versioned write that matched no row: either
+// the seller is gone (or soft-deleted) or someone else bumped the version.
+func missedSellerWrite(ctx context.Context, queries *db.Queries, id uuid.UUID) error {
+	exists, err := queries.SellerExists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return entities.ErrSellerNotFound
+	}
+
+	return entities.ErrVersionConflict
 }
 
 func fromSqlcSellerRow(dbSeller *db.GetSellerByIdRow) *entities.Seller {
-	seller := &entities.Seller{
-		Name:      dbSeller.Name,
-		CreatedAt: timeFromTimestamptz(dbSeller.CreatedAt),
-		UpdatedAt: timeFromTimestamptz(dbSeller.UpdatedAt),
-	}
-	seller.Id = dbSeller.ID
-	return seller
+	return sellerFromRow(dbSeller.ID, dbSeller.Name, dbSeller.VerificationStatus, dbSeller.CreatedAt, dbSeller.UpdatedAt, dbSeller.Version)
 }
 
-func fromSqlcSellerAllRow(dbSeller *db.GetAllSellersRow) *entities.Seller {
-	seller := &entities.Seller{
-		Name:      dbSeller.Name,
-		CreatedAt: timeFromTimestamptz(dbSeller.CreatedAt),
-		UpdatedAt: timeFromTimestamptz(dbSeller.UpdatedAt),
+func sellerFromRow(id uuid.UUID, name, verificationStatus string, createdAt, updatedAt pgtype.Timestamptz, version int32) *entities.Seller {
+	return &entities.Seller{
+		Id:                 id,
+		Name:               name,
+		VerificationStatus: entities.VerificationStatus(verificationStatus),
+		CreatedAt:          timeFromTimestamptz(createdAt),
+		UpdatedAt:          timeFromTimestamptz(updatedAt),
+		Version:            int(version),
 	}
-	seller.Id = dbSeller.ID
-	return seller
 }
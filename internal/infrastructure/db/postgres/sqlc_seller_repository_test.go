@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
 	"github.com/sklinkert/go-ddd/internal/testhelpers"
 )
 
@@ -17,7 +18,7 @@ func TestSqlcSellerRepository_Create(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Create a seller
 	seller := entities.NewSeller("Test Seller")
@@ -39,7 +40,7 @@ func TestSqlcSellerRepository_FindById(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Create test data
 	seller := entities.NewSeller("Test Seller")
@@ -65,7 +66,7 @@ func TestSqlcSellerRepository_FindById_NotFound(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Test finding non-existent seller
 	nonExistentId := uuid.New()
@@ -80,7 +81,7 @@ func TestSqlcSellerRepository_FindAll(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Create multiple sellers
 	seller1 := entities.NewSeller("Seller One")
@@ -98,7 +99,7 @@ func TestSqlcSellerRepository_FindAll(t *testing.T) {
 	require.NoError(t, err)
 
 	// Test finding all sellers
-	sellers, err := repo.FindAll(context.Background())
+	sellers, err := repo.FindAll(context.Background(), repositories.SellerListCriteria{Limit: 10})
 
 	// Assertions
 	require.NoError(t, err)
@@ -124,21 +125,49 @@ func TestSqlcSellerRepository_FindAll_Empty(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Test finding all when no sellers exist
-	sellers, err := repo.FindAll(context.Background())
+	sellers, err := repo.FindAll(context.Background(), repositories.SellerListCriteria{Limit: 10})
 
 	// Assertions
 	require.NoError(t, err)
 	assert.Empty(t, sellers)
 }
 
+func TestSqlcSellerRepository_FindAll_KeysetPagesByName(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcSellerRepository(testDB.Pool)
+	for _, name := range []string{"Charlie", "Alpha", "Bravo"} {
+		validatedSeller, err := entities.NewValidatedSeller(entities.NewSeller(name))
+		require.NoError(t, err)
+		_, err = repo.Create(context.Background(), validatedSeller)
+		require.NoError(t, err)
+	}
+
+	firstPage, err := repo.FindAll(context.Background(), repositories.SellerListCriteria{SortBy: repositories.SellerSortByName, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, firstPage, 2)
+	assert.Equal(t, "Alpha", firstPage[0].Name)
+	assert.Equal(t, "Bravo", firstPage[1].Name)
+
+	secondPage, err := repo.FindAll(context.Background(), repositories.SellerListCriteria{
+		SortBy: repositories.SellerSortByName,
+		After:  &repositories.SellerCursor{Id: firstPage[1].Id, Name: firstPage[1].Name},
+		Limit:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+	assert.Equal(t, "Charlie", secondPage[0].Name)
+}
+
 func TestSqlcSellerRepository_Update(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Create test data
 	seller := entities.NewSeller("Original Seller")
@@ -154,6 +183,7 @@ func TestSqlcSellerRepository_Update(t *testing.T) {
 		Name:      "Updated Seller",
 		CreatedAt: createdSeller.CreatedAt,
 		UpdatedAt: time.Now(),
+		Version:   createdSeller.Version,
 	}
 
 	validatedUpdatedSeller, err := entities.NewValidatedSeller(updatedSeller)
@@ -167,13 +197,73 @@ func TestSqlcSellerRepository_Update(t *testing.T) {
 	assert.Equal(t, "Updated Seller", result.Name)
 	assert.Equal(t, createdSeller.Id, result.Id)
 	assert.True(t, result.UpdatedAt.After(createdSeller.UpdatedAt))
+	assert.Equal(t, createdSeller.Version+1, result.Version)
+}
+
+func TestSqlcSellerRepository_Update_VerificationStatus(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcSellerRepository(testDB.Pool)
+	ctx := context.Background()
+
+	validatedSeller, err := entities.NewValidatedSeller(entities.NewSeller("Acme"))
+	require.NoError(t, err)
+	created, err := repo.Create(ctx, validatedSeller)
+	require.NoError(t, err)
+	assert.Equal(t, entities.SellerUnverified, created.VerificationStatus)
+
+	require.NoError(t, created.ApplyVerification(entities.SellerVerified))
+	validatedSeller, err = entities.NewValidatedSeller(created)
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, validatedSeller)
+	require.NoError(t, err)
+
+	found, err := repo.FindById(ctx, created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, entities.SellerVerified, found.VerificationStatus)
+}
+
+func TestSqlcSellerRepository_Update_StaleVersion(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	repo := NewSqlcSellerRepository(testDB.Pool)
+
+	validatedSeller, err := entities.NewValidatedSeller(entities.NewSeller("Test Seller"))
+	require.NoError(t, err)
+	createdSeller, err := repo.Create(context.Background(), validatedSeller)
+	require.NoError(t, err)
+
+	// Bump the stored version behind the stale copy's back.
+	current, err := repo.FindById(context.Background(), createdSeller.Id)
+	require.NoError(t, err)
+	require.NoError(t, current.UpdateName("Renamed"))
+	validatedCurrent, err := entities.NewValidatedSeller(current)
+	require.NoError(t, err)
+	_, err = repo.Update(context.Background(), validatedCurrent)
+	require.NoError(t, err)
+
+	require.NoError(t, createdSeller.UpdateName("Stale Rename"))
+	validatedStale, err := entities.NewValidatedSeller(createdSeller)
+	require.NoError(t, err)
+	_, err = repo.Update(context.Background(), validatedStale)
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	err = repo.Delete(context.Background(), createdSeller)
+	assert.ErrorIs(t, err, entities.ErrVersionConflict)
+
+	stored, err := repo.FindById(context.Background(), createdSeller.Id)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "Renamed", stored.Name)
 }
 
 func TestSqlcSellerRepository_Update_NotFound(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Create a seller with non-existent ID
 	nonExistentSeller := &entities.Seller{
@@ -189,7 +279,7 @@ func TestSqlcSellerRepository_Update_NotFound(t *testing.T) {
 	result, err := repo.Update(context.Background(), validatedNonExistentSeller)
 
 	// Assertions
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, entities.ErrSellerNotFound)
 	assert.Nil(t, result)
 }
 
@@ -197,7 +287,7 @@ func TestSqlcSellerRepository_Delete(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Create test data
 	seller := entities.NewSeller("Test Seller")
@@ -208,7 +298,7 @@ func TestSqlcSellerRepository_Delete(t *testing.T) {
 	require.NoError(t, err)
 
 	// Delete the seller
-	err = repo.Delete(context.Background(), createdSeller.Id)
+	err = repo.Delete(context.Background(), createdSeller)
 	require.NoError(t, err)
 
 	// Verify seller is deleted
@@ -221,14 +311,14 @@ func TestSqlcSellerRepository_Delete_NotFound(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Try to delete non-existent seller
-	nonExistentId := uuid.New()
-	err := repo.Delete(context.Background(), nonExistentId)
+	nonExistentSeller := &entities.Seller{Id: uuid.New(), Version: 1}
+	err := repo.Delete(context.Background(), nonExistentSeller)
 
-	// Note: PostgreSQL DELETE doesn't fail if the row doesn't exist
-	// So this should not return an error
+	// Deleting a missing seller is a no-op, so this should not return an
+	// error
 	assert.NoError(t, err)
 }
 
@@ -236,7 +326,7 @@ func TestSqlcSellerRepository_Delete_WithExistingProducts(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	sellerRepo := NewSqlcSellerRepository(testDB.Queries)
+	sellerRepo := NewSqlcSellerRepository(testDB.Pool)
 	productRepo := NewSqlcProductRepository(testDB.Pool)
 
 	// Create a seller
@@ -256,7 +346,7 @@ func TestSqlcSellerRepository_Delete_WithExistingProducts(t *testing.T) {
 	require.NoError(t, err)
 
 	// Try to delete the seller - should succeed with soft delete
-	err = sellerRepo.Delete(context.Background(), createdSeller.Id)
+	err = sellerRepo.Delete(context.Background(), createdSeller)
 	assert.NoError(t, err) // Soft delete should succeed even with related products
 
 	// Verify the seller is soft deleted (not returned in queries)
@@ -286,7 +376,7 @@ func TestSqlcSellerRepository_Create_LongName(t *testing.T) {
 	testDB := testhelpers.SetupTestDB(t)
 	defer testDB.Close(t)
 
-	repo := NewSqlcSellerRepository(testDB.Queries)
+	repo := NewSqlcSellerRepository(testDB.Pool)
 
 	// Create a seller with very long name
 	longName := make([]byte, 1000)
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+// SqlcWebhookSubscriptionRepository stores webhook subscriptions and
+// exposes their delivery history.
+type SqlcWebhookSubscriptionRepository struct {
+	queries *db.Queries
+}
+
+func NewSqlcWebhookSubscriptionRepository(queries *db.Queries) repositories.WebhookSubscriptionRepository {
+	return &SqlcWebhookSubscriptionRepository{queries: queries}
+}
+
+func (r *SqlcWebhookSubscriptionRepository) Create(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	row, err := queriesFor(ctx, r.queries).CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+		ID:         subscription.Id,
+		Url:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Secret:     subscription.Secret,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The database clock decides the creation time, as for every other row.
+	subscription.CreatedAt = row.CreatedAt.Time
+	return nil
+}
+
+func (r *SqlcWebhookSubscriptionRepository) FindById(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	row, err := queriesFor(ctx, r.queries).GetWebhookSubscription(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entities.ErrWebhookSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookSubscriptionFromRow(row), nil
+}
+
+func (r *SqlcWebhookSubscriptionRepository) FindAll(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	rows, err := queriesFor(ctx, r.queries).ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*entities.WebhookSubscription, 0, len(rows))
+	for _, row := range rows {
+		subscriptions = append(subscriptions, webhookSubscriptionFromRow(row))
+	}
+	return subscriptions, nil
+}
+
+// Delete removes the subscription together with its pending deliveries.
+func (r *SqlcWebhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	rows, err := queriesFor(ctx, r.queries).DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrWebhookSubscriptionNotFound
+	}
+	return nil
+}
+
+// FindDeliveries returns the subscription's most recent deliveries, newest
+// first.
+func (r *SqlcWebhookSubscriptionRepository) FindDeliveries(ctx context.Context, subscriptionId uuid.UUID, limit int) ([]*entities.WebhookDelivery, error) {
+	if _, err := r.FindById(ctx, subscriptionId); err != nil {
+		return nil, err
+	}
+
+	rows, err := queriesFor(ctx, r.queries).ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		SubscriptionID: subscriptionId,
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*entities.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, webhookDeliveryFromRow(row))
+	}
+	return deliveries, nil
+}
+
+func webhookSubscriptionFromRow(row db.WebhookSubscription) *entities.WebhookSubscription {
+	return &entities.WebhookSubscription{
+		Id:         row.ID,
+		URL:        row.Url,
+		EventTypes: row.EventTypes,
+		Secret:     row.Secret,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+}
+
+func webhookDeliveryFromRow(row db.WebhookDelivery) *entities.WebhookDelivery {
+	delivery := &entities.WebhookDelivery{
+		Id:             row.ID,
+		SubscriptionId: row.SubscriptionID,
+		EventId:        row.EventID,
+		EventName:      row.EventName,
+		Status:         entities.WebhookDeliveryPending,
+		Attempts:       int(row.Attempts),
+		LastStatusCode: int(row.LastStatusCode.Int32),
+		LastError:      row.LastError.String,
+		NextAttemptAt:  row.NextAttemptAt.Time,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+	switch {
+	case row.DeliveredAt.Valid:
+		delivery.Status = entities.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &row.DeliveredAt.Time
+	case row.FailedAt.Valid:
+		delivery.Status = entities.WebhookDeliveryFailed
+	}
+	return delivery
+}
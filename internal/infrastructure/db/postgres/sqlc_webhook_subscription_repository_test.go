@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func TestSqlcWebhookSubscriptionRepository_CreateFindDelete(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	repo := NewSqlcWebhookSubscriptionRepository(testDB.Queries)
+	subscription, err := entities.NewWebhookSubscription("https://partner.example/hooks", []string{"product.created"}, "0123456789abcdef")
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, subscription))
+	assert.False(t, subscription.CreatedAt.IsZero())
+
+	found, err := repo.FindById(ctx, subscription.Id)
+	require.NoError(t, err)
+	assert.Equal(t, subscription.URL, found.URL)
+	assert.Equal(t, []string{"product.created"}, found.EventTypes)
+
+	listed, err := repo.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	deliveries, err := repo.FindDeliveries(ctx, subscription.Id, 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+
+	require.NoError(t, repo.Delete(ctx, subscription.Id))
+	_, err = repo.FindById(ctx, subscription.Id)
+	assert.ErrorIs(t, err, entities.ErrWebhookSubscriptionNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, uuid.New()), entities.ErrWebhookSubscriptionNotFound)
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+type txKey struct{}
+
+// Transactor runs a function in one transaction that the repositories of
+// this package join: they pick it up from the context instead of opening
+// their own.
+type Transactor struct {
+	pool *pgxpool.Pool
+}
+
+func NewTransactor(pool *pgxpool.Pool) repositories.Transactor {
+	return &Transactor{pool: pool}
+}
+
+// WithinTransaction commits if fn returns nil and rolls back otherwise.
+// Nested calls run in a savepoint, so an inner failure can be rolled back
+// without losing the outer transaction.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := begin(ctx, t.pool)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// begin starts a transaction, or a savepoint inside the one ctx carries.
+// Repositories use it for their multi-statement writes, so those stay atomic
+// on their own and become part of the caller's transaction under a
+// Transactor.
+func begin(ctx context.Context, pool *pgxpool.Pool) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+	return pool.Begin(ctx)
+}
+
+// queriesFor binds queries to the transaction ctx carries, if any, so reads
+// inside a Transactor see (and lock against) its uncommitted writes.
+func queriesFor(ctx context.Context, queries *db.Queries) *db.Queries {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return queries.WithTx(tx)
+	}
+	return queries
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func TestTransactor_RepositoriesJoinTheTransaction(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	transactor := NewTransactor(testDB.Pool)
+	repo := NewSqlcSellerRepository(testDB.Pool)
+	ctx := context.Background()
+
+	seller, err := entities.NewValidatedSeller(entities.NewSeller("Acme"))
+	require.NoError(t, err)
+
+	failure := errors.New("abort")
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := repo.Create(ctx, seller); err != nil {
+			return err
+		}
+		found, err := repo.FindById(ctx, seller.Id)
+		require.NoError(t, err)
+		assert.NotNil(t, found, "reads see the transaction's own writes")
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	found, err := repo.FindById(ctx, seller.Id)
+	require.NoError(t, err)
+	assert.Nil(t, found, "the seller and its events were rolled back together")
+	var events int
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `SELECT count(*) FROM outbox_events`).Scan(&events))
+	assert.Zero(t, events)
+}
+
+func TestTransactor_NestedCallsUseSavepoints(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	transactor := NewTransactor(testDB.Pool)
+	repo := NewSqlcSellerRepository(testDB.Pool)
+	ctx := context.Background()
+
+	kept, err := entities.NewValidatedSeller(entities.NewSeller("Kept"))
+	require.NoError(t, err)
+	discarded, err := entities.NewValidatedSeller(entities.NewSeller("Discarded"))
+	require.NoError(t, err)
+
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := repo.Create(ctx, kept); err != nil {
+			return err
+		}
+		inner := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, err := repo.Create(ctx, discarded); err != nil {
+				return err
+			}
+			return errors.New("abort inner")
+		})
+		assert.Error(t, inner)
+		return nil
+	})
+	require.NoError(t, err)
+
+	found, err := repo.FindById(ctx, kept.Id)
+	require.NoError(t, err)
+	assert.NotNil(t, found)
+	found, err = repo.FindById(ctx, discarded.Id)
+	require.NoError(t, err)
+	assert.Nil(t, found, "only the savepoint was rolled back")
+}
@@ -9,6 +9,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type BlobDeletion struct {
+	BlobKey     string             `db:"blob_key" json:"blob_key"`
+	ScheduledAt pgtype.Timestamptz `db:"scheduled_at" json:"scheduled_at"`
+}
+
+type Cart struct {
+	BuyerID   uuid.UUID          `db:"buyer_id" json:"buyer_id"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	Version   int32              `db:"version" json:"version"`
+}
+
+type CartLine struct {
+	BuyerID             uuid.UUID `db:"buyer_id" json:"buyer_id"`
+	Position            int32     `db:"position" json:"position"`
+	ProductID           uuid.UUID `db:"product_id" json:"product_id"`
+	ProductName         string    `db:"product_name" json:"product_name"`
+	UnitPriceMinorUnits int64     `db:"unit_price_minor_units" json:"unit_price_minor_units"`
+	Currency            string    `db:"currency" json:"currency"`
+	Quantity            int32     `db:"quantity" json:"quantity"`
+	VariantID           uuid.UUID `db:"variant_id" json:"variant_id"`
+	Sku                 string    `db:"sku" json:"sku"`
+}
+
+type Category struct {
+	ID        uuid.UUID          `db:"id" json:"id"`
+	Name      string             `db:"name" json:"name"`
+	ParentID  pgtype.UUID        `db:"parent_id" json:"parent_id"`
+	Path      string             `db:"path" json:"path"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version   int32              `db:"version" json:"version"`
+}
+
+type ExchangeRate struct {
+	BaseCurrency  string             `db:"base_currency" json:"base_currency"`
+	QuoteCurrency string             `db:"quote_currency" json:"quote_currency"`
+	Rate          pgtype.Numeric     `db:"rate" json:"rate"`
+	EffectiveAt   pgtype.Timestamptz `db:"effective_at" json:"effective_at"`
+}
+
 type IdempotencyRecord struct {
 	ID         uuid.UUID          `db:"id" json:"id"`
 	Key        string             `db:"key" json:"key"`
@@ -18,13 +60,97 @@ type IdempotencyRecord struct {
 	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
+type InboxMessage struct {
+	Source        string             `db:"source" json:"source"`
+	MessageID     string             `db:"message_id" json:"message_id"`
+	MessageType   string             `db:"message_type" json:"message_type"`
+	Payload       []byte             `db:"payload" json:"payload"`
+	ReceivedAt    pgtype.Timestamptz `db:"received_at" json:"received_at"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	LastError     pgtype.Text        `db:"last_error" json:"last_error"`
+	NextAttemptAt pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	ProcessedAt   pgtype.Timestamptz `db:"processed_at" json:"processed_at"`
+	FailedAt      pgtype.Timestamptz `db:"failed_at" json:"failed_at"`
+}
+
+type Inventory struct {
+	ProductID uuid.UUID          `db:"product_id" json:"product_id"`
+	OnHand    int32              `db:"on_hand" json:"on_hand"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version   int32              `db:"version" json:"version"`
+	VariantID uuid.UUID          `db:"variant_id" json:"variant_id"`
+}
+
+type Order struct {
+	ID             uuid.UUID          `db:"id" json:"id"`
+	BuyerID        uuid.UUID          `db:"buyer_id" json:"buyer_id"`
+	Status         string             `db:"status" json:"status"`
+	TrackingNumber string             `db:"tracking_number" json:"tracking_number"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version        int32              `db:"version" json:"version"`
+}
+
+type OrderItem struct {
+	OrderID             uuid.UUID   `db:"order_id" json:"order_id"`
+	Position            int32       `db:"position" json:"position"`
+	ProductID           uuid.UUID   `db:"product_id" json:"product_id"`
+	ProductName         string      `db:"product_name" json:"product_name"`
+	UnitPriceMinorUnits int64       `db:"unit_price_minor_units" json:"unit_price_minor_units"`
+	Currency            string      `db:"currency" json:"currency"`
+	Quantity            int32       `db:"quantity" json:"quantity"`
+	ReservationID       pgtype.UUID `db:"reservation_id" json:"reservation_id"`
+	VariantID           uuid.UUID   `db:"variant_id" json:"variant_id"`
+	Sku                 string      `db:"sku" json:"sku"`
+}
+
 type OutboxEvent struct {
-	ID          uuid.UUID          `db:"id" json:"id"`
-	AggregateID uuid.UUID          `db:"aggregate_id" json:"aggregate_id"`
-	EventName   string             `db:"event_name" json:"event_name"`
-	Payload     []byte             `db:"payload" json:"payload"`
-	OccurredAt  pgtype.Timestamptz `db:"occurred_at" json:"occurred_at"`
-	PublishedAt pgtype.Timestamptz `db:"published_at" json:"published_at"`
+	ID             uuid.UUID          `db:"id" json:"id"`
+	AggregateID    uuid.UUID          `db:"aggregate_id" json:"aggregate_id"`
+	EventName      string             `db:"event_name" json:"event_name"`
+	Payload        []byte             `db:"payload" json:"payload"`
+	OccurredAt     pgtype.Timestamptz `db:"occurred_at" json:"occurred_at"`
+	PublishedAt    pgtype.Timestamptz `db:"published_at" json:"published_at"`
+	ClaimedBy      pgtype.Text        `db:"claimed_by" json:"claimed_by"`
+	ClaimedUntil   pgtype.Timestamptz `db:"claimed_until" json:"claimed_until"`
+	Attempts       int32              `db:"attempts" json:"attempts"`
+	LastError      pgtype.Text        `db:"last_error" json:"last_error"`
+	NextAttemptAt  pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	DeadLetteredAt pgtype.Timestamptz `db:"dead_lettered_at" json:"dead_lettered_at"`
+	SequenceNumber int64              `db:"sequence_number" json:"sequence_number"`
+	SchemaVersion  int32              `db:"schema_version" json:"schema_version"`
+}
+
+type OutboxEventsArchive struct {
+	ID             uuid.UUID          `db:"id" json:"id"`
+	AggregateID    uuid.UUID          `db:"aggregate_id" json:"aggregate_id"`
+	EventName      string             `db:"event_name" json:"event_name"`
+	SchemaVersion  int32              `db:"schema_version" json:"schema_version"`
+	Payload        []byte             `db:"payload" json:"payload"`
+	OccurredAt     pgtype.Timestamptz `db:"occurred_at" json:"occurred_at"`
+	PublishedAt    pgtype.Timestamptz `db:"published_at" json:"published_at"`
+	SequenceNumber int64              `db:"sequence_number" json:"sequence_number"`
+	ArchivedAt     pgtype.Timestamptz `db:"archived_at" json:"archived_at"`
+}
+
+type OutboxReplay struct {
+	ID                 uuid.UUID          `db:"id" json:"id"`
+	Target             string             `db:"target" json:"target"`
+	AggregateID        pgtype.UUID        `db:"aggregate_id" json:"aggregate_id"`
+	EventName          pgtype.Text        `db:"event_name" json:"event_name"`
+	OccurredFrom       pgtype.Timestamptz `db:"occurred_from" json:"occurred_from"`
+	OccurredTo         pgtype.Timestamptz `db:"occurred_to" json:"occurred_to"`
+	PublishedBefore    pgtype.Timestamptz `db:"published_before" json:"published_before"`
+	Status             string             `db:"status" json:"status"`
+	Total              int64              `db:"total" json:"total"`
+	Replayed           int64              `db:"replayed" json:"replayed"`
+	LastSequenceNumber int64              `db:"last_sequence_number" json:"last_sequence_number"`
+	LastError          pgtype.Text        `db:"last_error" json:"last_error"`
+	ClaimedUntil       pgtype.Timestamptz `db:"claimed_until" json:"claimed_until"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	CompletedAt        pgtype.Timestamptz `db:"completed_at" json:"completed_at"`
 }
 
 type Product struct {
@@ -36,12 +162,111 @@ type Product struct {
 	DeletedAt       pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
 	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
 	Currency        string             `db:"currency" json:"currency"`
+	Version         int32              `db:"version" json:"version"`
+	Status          string             `db:"status" json:"status"`
+	CategoryNames   string             `db:"category_names" json:"category_names"`
+	SearchVector    interface{}        `db:"search_vector" json:"search_vector"`
+}
+
+type ProductCategory struct {
+	ProductID  uuid.UUID `db:"product_id" json:"product_id"`
+	CategoryID uuid.UUID `db:"category_id" json:"category_id"`
+}
+
+type ProductImage struct {
+	ID          uuid.UUID          `db:"id" json:"id"`
+	ProductID   uuid.UUID          `db:"product_id" json:"product_id"`
+	BlobKey     string             `db:"blob_key" json:"blob_key"`
+	ContentType string             `db:"content_type" json:"content_type"`
+	SizeBytes   int64              `db:"size_bytes" json:"size_bytes"`
+	Position    int32              `db:"position" json:"position"`
+	IsPrimary   bool               `db:"is_primary" json:"is_primary"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type ProductPriceHistory struct {
+	ProductID       uuid.UUID          `db:"product_id" json:"product_id"`
+	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
+	Currency        string             `db:"currency" json:"currency"`
+	EffectiveAt     pgtype.Timestamptz `db:"effective_at" json:"effective_at"`
+}
+
+type ProductVariant struct {
+	ID                      uuid.UUID   `db:"id" json:"id"`
+	ProductID               uuid.UUID   `db:"product_id" json:"product_id"`
+	Sku                     string      `db:"sku" json:"sku"`
+	Attributes              []byte      `db:"attributes" json:"attributes"`
+	PriceOverrideMinorUnits pgtype.Int8 `db:"price_override_minor_units" json:"price_override_minor_units"`
+	PriceOverrideCurrency   pgtype.Text `db:"price_override_currency" json:"price_override_currency"`
+}
+
+type Promotion struct {
+	ID               uuid.UUID          `db:"id" json:"id"`
+	SellerID         uuid.UUID          `db:"seller_id" json:"seller_id"`
+	Name             string             `db:"name" json:"name"`
+	DiscountKind     string             `db:"discount_kind" json:"discount_kind"`
+	BasisPoints      pgtype.Int8        `db:"basis_points" json:"basis_points"`
+	AmountMinorUnits pgtype.Int8        `db:"amount_minor_units" json:"amount_minor_units"`
+	Currency         pgtype.Text        `db:"currency" json:"currency"`
+	ProductIds       []uuid.UUID        `db:"product_ids" json:"product_ids"`
+	StartsAt         pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt           pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	Stackable        bool               `db:"stackable" json:"stackable"`
+	UsageLimit       int32              `db:"usage_limit" json:"usage_limit"`
+	UsageCount       int32              `db:"usage_count" json:"usage_count"`
+	CreatedAt        pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version          int32              `db:"version" json:"version"`
+}
+
+type ScheduledPriceChange struct {
+	ID              uuid.UUID          `db:"id" json:"id"`
+	ProductID       uuid.UUID          `db:"product_id" json:"product_id"`
+	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
+	Currency        string             `db:"currency" json:"currency"`
+	EffectiveAt     pgtype.Timestamptz `db:"effective_at" json:"effective_at"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
 
 type Seller struct {
+	ID                 uuid.UUID          `db:"id" json:"id"`
+	Name               string             `db:"name" json:"name"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	DeletedAt          pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	Version            int32              `db:"version" json:"version"`
+	VerificationStatus string             `db:"verification_status" json:"verification_status"`
+}
+
+type StockReservation struct {
 	ID        uuid.UUID          `db:"id" json:"id"`
-	Name      string             `db:"name" json:"name"`
+	Quantity  int32              `db:"quantity" json:"quantity"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
 	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
-	DeletedAt pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	VariantID uuid.UUID          `db:"variant_id" json:"variant_id"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID          `db:"id" json:"id"`
+	SubscriptionID uuid.UUID          `db:"subscription_id" json:"subscription_id"`
+	EventID        uuid.UUID          `db:"event_id" json:"event_id"`
+	EventName      string             `db:"event_name" json:"event_name"`
+	Payload        []byte             `db:"payload" json:"payload"`
+	Attempts       int32              `db:"attempts" json:"attempts"`
+	LastStatusCode pgtype.Int4        `db:"last_status_code" json:"last_status_code"`
+	LastError      pgtype.Text        `db:"last_error" json:"last_error"`
+	NextAttemptAt  pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	ClaimedBy      pgtype.Text        `db:"claimed_by" json:"claimed_by"`
+	ClaimedUntil   pgtype.Timestamptz `db:"claimed_until" json:"claimed_until"`
+	DeliveredAt    pgtype.Timestamptz `db:"delivered_at" json:"delivered_at"`
+	FailedAt       pgtype.Timestamptz `db:"failed_at" json:"failed_at"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type WebhookSubscription struct {
+	ID         uuid.UUID          `db:"id" json:"id"`
+	Url        string             `db:"url" json:"url"`
+	EventTypes []string           `db:"event_types" json:"event_types"`
+	Secret     string             `db:"secret" json:"secret"`
+	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
@@ -12,6 +12,152 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archivePublishedOutboxEvents = `-- name: ArchivePublishedOutboxEvents :execrows
+WITH moved AS (
+    DELETE FROM outbox_events
+    WHERE id IN (
+        SELECT id
+        FROM outbox_events
+        WHERE published_at < $1::timestamptz
+        ORDER BY published_at
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, aggregate_id, event_name, schema_version, payload, occurred_at, published_at, sequence_number
+)
+INSERT INTO outbox_events_archive (id, aggregate_id, event_name, schema_version, payload, occurred_at, published_at, sequence_number)
+SELECT id, aggregate_id, event_name, schema_version, payload, occurred_at, published_at, sequence_number
+FROM moved
+`
+
+type ArchivePublishedOutboxEventsParams struct {
+	PublishedBefore pgtype.Timestamptz `db:"published_before" json:"published_before"`
+	BatchSize       int32              `db:"batch_size" json:"batch_size"`
+}
+
+// Moves up to batch_size events published before published_before into
+// outbox_events_archive, in one statement so a row is never in both tables
+// or in neither. Only published rows qualify, which the relay never touches
+// again; SKIP LOCKED keeps concurrent workers on disjoint rows.
+func (q *Queries) ArchivePublishedOutboxEvents(ctx context.Context, arg ArchivePublishedOutboxEventsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, archivePublishedOutboxEvents, arg.PublishedBefore, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+UPDATE outbox_events
+SET claimed_by = $1::text,
+    claimed_until = NOW() + $2::bigint * INTERVAL '1 millisecond'
+WHERE id IN (
+    SELECT e.id
+    FROM outbox_events e
+    WHERE e.published_at IS NULL
+      AND e.dead_lettered_at IS NULL
+      AND (e.next_attempt_at IS NULL OR e.next_attempt_at <= NOW())
+      AND (e.claimed_until IS NULL OR e.claimed_until < NOW())
+      AND NOT EXISTS (
+          SELECT 1
+          FROM outbox_events earlier
+          WHERE earlier.aggregate_id = e.aggregate_id
+            AND earlier.published_at IS NULL
+            AND earlier.sequence_number < e.sequence_number
+      )
+    ORDER BY e.sequence_number
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, aggregate_id, event_name, payload, occurred_at, published_at, claimed_by, claimed_until, attempts, last_error, next_attempt_at, dead_lettered_at, sequence_number, schema_version
+`
+
+type ClaimOutboxEventsParams struct {
+	RelayID   string `db:"relay_id" json:"relay_id"`
+	LeaseMs   int64  `db:"lease_ms" json:"lease_ms"`
+	BatchSize int32  `db:"batch_size" json:"batch_size"`
+}
+
+// Leases up to batch_size unpublished events to one relay. SKIP LOCKED makes
+// concurrent relays claim disjoint batches instead of waiting on each other;
+// rows with a live lease, a pending backoff or a dead letter are skipped.
+// Only the oldest pending event of each aggregate is eligible, so at most one
+// event per aggregate is in flight and a failing (or dead-lettered) event
+// holds back the later events of its aggregate until it is published,
+// replayed or discarded.
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error) {
+	rows, err := q.db.Query(ctx, claimOutboxEvents, arg.RelayID, arg.LeaseMs, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OutboxEvent{}
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateID,
+			&i.EventName,
+			&i.Payload,
+			&i.OccurredAt,
+			&i.PublishedAt,
+			&i.ClaimedBy,
+			&i.ClaimedUntil,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.DeadLetteredAt,
+			&i.SequenceNumber,
+			&i.SchemaVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deletePublishedOutboxEvents = `-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE id IN (
+    SELECT id
+    FROM outbox_events
+    WHERE published_at < $1::timestamptz
+    ORDER BY published_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+`
+
+type DeletePublishedOutboxEventsParams struct {
+	PublishedBefore pgtype.Timestamptz `db:"published_before" json:"published_before"`
+	BatchSize       int32              `db:"batch_size" json:"batch_size"`
+}
+
+// Like ArchivePublishedOutboxEvents, for deployments that keep no archive.
+func (q *Queries) DeletePublishedOutboxEvents(ctx context.Context, arg DeletePublishedOutboxEventsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePublishedOutboxEvents, arg.PublishedBefore, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const discardDeadLetteredOutboxEvent = `-- name: DiscardDeadLetteredOutboxEvent :execrows
+DELETE FROM outbox_events WHERE id = $1 AND dead_lettered_at IS NOT NULL
+`
+
+func (q *Queries) DiscardDeadLetteredOutboxEvent(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, discardDeadLetteredOutboxEvent, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getUnpublishedOutboxEvents = `-- name: GetUnpublishedOutboxEvents :many
 SELECT id, aggregate_id, event_name, payload, occurred_at, published_at
 FROM outbox_events
@@ -20,15 +166,24 @@ ORDER BY occurred_at
 LIMIT $1
 `
 
-func (q *Queries) GetUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error) {
+type GetUnpublishedOutboxEventsRow struct {
+	ID          uuid.UUID          `db:"id" json:"id"`
+	AggregateID uuid.UUID          `db:"aggregate_id" json:"aggregate_id"`
+	EventName   string             `db:"event_name" json:"event_name"`
+	Payload     []byte             `db:"payload" json:"payload"`
+	OccurredAt  pgtype.Timestamptz `db:"occurred_at" json:"occurred_at"`
+	PublishedAt pgtype.Timestamptz `db:"published_at" json:"published_at"`
+}
+
+func (q *Queries) GetUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]GetUnpublishedOutboxEventsRow, error) {
 	rows, err := q.db.Query(ctx, getUnpublishedOutboxEvents, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []OutboxEvent{}
+	items := []GetUnpublishedOutboxEventsRow{}
 	for rows.Next() {
-		var i OutboxEvent
+		var i GetUnpublishedOutboxEventsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.AggregateID,
@@ -48,16 +203,17 @@ func (q *Queries) GetUnpublishedOutboxEvents(ctx context.Context, limit int32) (
 }
 
 const insertOutboxEvent = `-- name: InsertOutboxEvent :exec
-INSERT INTO outbox_events (id, aggregate_id, event_name, payload, occurred_at)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO outbox_events (id, aggregate_id, event_name, schema_version, payload, occurred_at)
+VALUES ($1, $2, $3, $4, $5, $6)
 `
 
 type InsertOutboxEventParams struct {
-	ID          uuid.UUID          `db:"id" json:"id"`
-	AggregateID uuid.UUID          `db:"aggregate_id" json:"aggregate_id"`
-	EventName   string             `db:"event_name" json:"event_name"`
-	Payload     []byte             `db:"payload" json:"payload"`
-	OccurredAt  pgtype.Timestamptz `db:"occurred_at" json:"occurred_at"`
+	ID            uuid.UUID          `db:"id" json:"id"`
+	AggregateID   uuid.UUID          `db:"aggregate_id" json:"aggregate_id"`
+	EventName     string             `db:"event_name" json:"event_name"`
+	SchemaVersion int32              `db:"schema_version" json:"schema_version"`
+	Payload       []byte             `db:"payload" json:"payload"`
+	OccurredAt    pgtype.Timestamptz `db:"occurred_at" json:"occurred_at"`
 }
 
 func (q *Queries) InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventParams) error {
@@ -65,17 +221,127 @@ func (q *Queries) InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventPa
 		arg.ID,
 		arg.AggregateID,
 		arg.EventName,
+		arg.SchemaVersion,
 		arg.Payload,
 		arg.OccurredAt,
 	)
 	return err
 }
 
+const listDeadLetteredOutboxEvents = `-- name: ListDeadLetteredOutboxEvents :many
+SELECT *
+FROM outbox_events
+WHERE dead_lettered_at IS NOT NULL
+ORDER BY dead_lettered_at, id
+LIMIT $1
+`
+
+func (q *Queries) ListDeadLetteredOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error) {
+	rows, err := q.db.Query(ctx, listDeadLetteredOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OutboxEvent{}
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateID,
+			&i.EventName,
+			&i.Payload,
+			&i.OccurredAt,
+			&i.PublishedAt,
+			&i.ClaimedBy,
+			&i.ClaimedUntil,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.DeadLetteredAt,
+			&i.SequenceNumber,
+			&i.SchemaVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const markOutboxEventPublished = `-- name: MarkOutboxEventPublished :exec
-UPDATE outbox_events SET published_at = NOW() WHERE id = $1
+UPDATE outbox_events SET published_at = NOW(), claimed_by = NULL, claimed_until = NULL WHERE id = $1
 `
 
 func (q *Queries) MarkOutboxEventPublished(ctx context.Context, id uuid.UUID) error {
 	_, err := q.db.Exec(ctx, markOutboxEventPublished, id)
 	return err
 }
+
+const recordOutboxEventFailure = `-- name: RecordOutboxEventFailure :exec
+UPDATE outbox_events
+SET attempts = attempts + 1,
+    last_error = $1::text,
+    next_attempt_at = NOW() + $2::bigint * INTERVAL '1 millisecond',
+    dead_lettered_at = CASE WHEN $3::boolean THEN NOW() END,
+    claimed_by = NULL,
+    claimed_until = NULL
+WHERE id = $4 AND claimed_by = $5::text
+`
+
+type RecordOutboxEventFailureParams struct {
+	LastError  string    `db:"last_error" json:"last_error"`
+	BackoffMs  int64     `db:"backoff_ms" json:"backoff_ms"`
+	DeadLetter bool      `db:"dead_letter" json:"dead_letter"`
+	ID         uuid.UUID `db:"id" json:"id"`
+	RelayID    string    `db:"relay_id" json:"relay_id"`
+}
+
+// Counts a failed publish, schedules the next attempt and releases the
+// lease. dead_letter parks the event for good once attempts are exhausted.
+func (q *Queries) RecordOutboxEventFailure(ctx context.Context, arg RecordOutboxEventFailureParams) error {
+	_, err := q.db.Exec(ctx, recordOutboxEventFailure,
+		arg.LastError,
+		arg.BackoffMs,
+		arg.DeadLetter,
+		arg.ID,
+		arg.RelayID,
+	)
+	return err
+}
+
+const releaseOutboxEventClaim = `-- name: ReleaseOutboxEventClaim :exec
+UPDATE outbox_events
+SET claimed_by = NULL, claimed_until = NULL
+WHERE id = $1 AND claimed_by = $2::text AND published_at IS NULL
+`
+
+type ReleaseOutboxEventClaimParams struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	RelayID string    `db:"relay_id" json:"relay_id"`
+}
+
+// Hands an unpublished event back before the lease runs out, e.g. after a
+// failed publish. Only the relay holding the lease can release it.
+func (q *Queries) ReleaseOutboxEventClaim(ctx context.Context, arg ReleaseOutboxEventClaimParams) error {
+	_, err := q.db.Exec(ctx, releaseOutboxEventClaim, arg.ID, arg.RelayID)
+	return err
+}
+
+const replayDeadLetteredOutboxEvent = `-- name: ReplayDeadLetteredOutboxEvent :execrows
+UPDATE outbox_events
+SET dead_lettered_at = NULL, attempts = 0, next_attempt_at = NULL
+WHERE id = $1 AND dead_lettered_at IS NOT NULL
+`
+
+// Puts a dead letter back into the relay's queue with a fresh attempt
+// budget. last_error is kept for reference until the next failure.
+func (q *Queries) ReplayDeadLetteredOutboxEvent(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, replayDeadLetteredOutboxEvent, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
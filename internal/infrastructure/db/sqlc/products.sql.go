@@ -12,10 +12,29 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const arrangeProductImages = `-- name: ArrangeProductImages :exec
+UPDATE product_images i
+SET position = arranged.position, is_primary = (i.id = $1::uuid)
+FROM unnest($2::uuid[]) WITH ORDINALITY AS arranged(id, position)
+WHERE i.id = arranged.id AND i.product_id = $3::uuid
+`
+
+type ArrangeProductImagesParams struct {
+	PrimaryImageID uuid.UUID   `db:"primary_image_id" json:"primary_image_id"`
+	ImageIds       []uuid.UUID `db:"image_ids" json:"image_ids"`
+	ProductID      uuid.UUID   `db:"product_id" json:"product_id"`
+}
+
+// Stores the display order of the product's images and which is primary.
+func (q *Queries) ArrangeProductImages(ctx context.Context, arg ArrangeProductImagesParams) error {
+	_, err := q.db.Exec(ctx, arrangeProductImages, arg.PrimaryImageID, arg.ImageIds, arg.ProductID)
+	return err
+}
+
 const createProduct = `-- name: CreateProduct :one
-INSERT INTO products (id, name, price_minor_units, currency, seller_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, name, seller_id, created_at, updated_at, deleted_at, price_minor_units, currency
+INSERT INTO products (id, name, price_minor_units, currency, seller_id, status, created_at, updated_at, version)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, name, seller_id, created_at, updated_at, deleted_at, price_minor_units, currency, version, status, category_names, search_vector
 `
 
 type CreateProductParams struct {
@@ -24,8 +43,10 @@ type CreateProductParams struct {
 	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
 	Currency        string             `db:"currency" json:"currency"`
 	SellerID        uuid.UUID          `db:"seller_id" json:"seller_id"`
+	Status          string             `db:"status" json:"status"`
 	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version         int32              `db:"version" json:"version"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -35,8 +56,10 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.PriceMinorUnits,
 		arg.Currency,
 		arg.SellerID,
+		arg.Status,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Version,
 	)
 	var i Product
 	err := row.Scan(
@@ -48,54 +71,329 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.DeletedAt,
 		&i.PriceMinorUnits,
 		&i.Currency,
+		&i.Version,
+		&i.Status,
+		&i.CategoryNames,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
-const deleteProduct = `-- name: DeleteProduct :exec
-UPDATE products SET deleted_at = NOW() WHERE id = $1
+const deleteProduct = `-- name: DeleteProduct :execrows
+UPDATE products
+SET deleted_at = NOW(), version = version + 1
+WHERE id = $1 AND version = $2 AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteProduct(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteProduct, id)
+type DeleteProductParams struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	Version int32     `db:"version" json:"version"`
+}
+
+func (q *Queries) DeleteProduct(ctx context.Context, arg DeleteProductParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProduct, arg.ID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProductCategories = `-- name: DeleteProductCategories :exec
+DELETE FROM product_categories WHERE product_id = $1
+`
+
+func (q *Queries) DeleteProductCategories(ctx context.Context, productID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteProductCategories, productID)
 	return err
 }
 
-const getAllProducts = `-- name: GetAllProducts :many
-SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.created_at, p.updated_at
+const deleteProductImage = `-- name: DeleteProductImage :exec
+DELETE FROM product_images WHERE id = $1
+`
+
+func (q *Queries) DeleteProductImage(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteProductImage, id)
+	return err
+}
+
+const deleteProductImages = `-- name: DeleteProductImages :exec
+DELETE FROM product_images WHERE product_id = $1
+`
+
+func (q *Queries) DeleteProductImages(ctx context.Context, productID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteProductImages, productID)
+	return err
+}
+
+const deleteProductVariant = `-- name: DeleteProductVariant :exec
+DELETE FROM product_variants WHERE id = $1
+`
+
+func (q *Queries) DeleteProductVariant(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteProductVariant, id)
+	return err
+}
+
+const getProductById = `-- name: GetProductById :one
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids
+FROM products p
+JOIN sellers s ON p.seller_id = s.id
+WHERE p.id = $1 AND p.deleted_at IS NULL AND s.deleted_at IS NULL
+`
+
+type GetProductByIdRow struct {
+	ID              uuid.UUID          `db:"id" json:"id"`
+	Name            string             `db:"name" json:"name"`
+	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
+	Currency        string             `db:"currency" json:"currency"`
+	SellerID        uuid.UUID          `db:"seller_id" json:"seller_id"`
+	Status          string             `db:"status" json:"status"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version         int32              `db:"version" json:"version"`
+	CategoryIds     []uuid.UUID        `db:"category_ids" json:"category_ids"`
+}
+
+func (q *Queries) GetProductById(ctx context.Context, id uuid.UUID) (GetProductByIdRow, error) {
+	row := q.db.QueryRow(ctx, getProductById, id)
+	var i GetProductByIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.PriceMinorUnits,
+		&i.Currency,
+		&i.SellerID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.CategoryIds,
+	)
+	return i, err
+}
+
+const insertProductCategories = `-- name: InsertProductCategories :execrows
+INSERT INTO product_categories (product_id, category_id)
+SELECT $1::uuid, c.id
+FROM categories c
+WHERE c.id = ANY($2::uuid[])
+`
+
+type InsertProductCategoriesParams struct {
+	ProductID   uuid.UUID   `db:"product_id" json:"product_id"`
+	CategoryIds []uuid.UUID `db:"category_ids" json:"category_ids"`
+}
+
+// Inserts only the categories that exist; fewer rows than ids means some
+// category is missing.
+func (q *Queries) InsertProductCategories(ctx context.Context, arg InsertProductCategoriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertProductCategories, arg.ProductID, arg.CategoryIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertProductImage = `-- name: InsertProductImage :exec
+INSERT INTO product_images (id, product_id, blob_key, content_type, size_bytes, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertProductImageParams struct {
+	ID          uuid.UUID          `db:"id" json:"id"`
+	ProductID   uuid.UUID          `db:"product_id" json:"product_id"`
+	BlobKey     string             `db:"blob_key" json:"blob_key"`
+	ContentType string             `db:"content_type" json:"content_type"`
+	SizeBytes   int64              `db:"size_bytes" json:"size_bytes"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+func (q *Queries) InsertProductImage(ctx context.Context, arg InsertProductImageParams) error {
+	_, err := q.db.Exec(ctx, insertProductImage,
+		arg.ID,
+		arg.ProductID,
+		arg.BlobKey,
+		arg.ContentType,
+		arg.SizeBytes,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const insertProductVariant = `-- name: InsertProductVariant :exec
+INSERT INTO product_variants (id, product_id, sku, attributes, price_override_minor_units, price_override_currency)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertProductVariantParams struct {
+	ID                      uuid.UUID   `db:"id" json:"id"`
+	ProductID               uuid.UUID   `db:"product_id" json:"product_id"`
+	Sku                     string      `db:"sku" json:"sku"`
+	Attributes              []byte      `db:"attributes" json:"attributes"`
+	PriceOverrideMinorUnits pgtype.Int8 `db:"price_override_minor_units" json:"price_override_minor_units"`
+	PriceOverrideCurrency   pgtype.Text `db:"price_override_currency" json:"price_override_currency"`
+}
+
+func (q *Queries) InsertProductVariant(ctx context.Context, arg InsertProductVariantParams) error {
+	_, err := q.db.Exec(ctx, insertProductVariant,
+		arg.ID,
+		arg.ProductID,
+		arg.Sku,
+		arg.Attributes,
+		arg.PriceOverrideMinorUnits,
+		arg.PriceOverrideCurrency,
+	)
+	return err
+}
+
+const listProductImages = `-- name: ListProductImages :many
+SELECT id, product_id, blob_key, content_type, size_bytes, position, is_primary, created_at
+FROM product_images
+WHERE product_id = ANY($1::uuid[])
+ORDER BY product_id, position, id
+`
+
+// Loads the images of a whole page of products in one query.
+func (q *Queries) ListProductImages(ctx context.Context, productIds []uuid.UUID) ([]ProductImage, error) {
+	rows, err := q.db.Query(ctx, listProductImages, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductImage{}
+	for rows.Next() {
+		var i ProductImage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.BlobKey,
+			&i.ContentType,
+			&i.SizeBytes,
+			&i.Position,
+			&i.IsPrimary,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductVariants = `-- name: ListProductVariants :many
+SELECT id, product_id, sku, attributes, price_override_minor_units, price_override_currency
+FROM product_variants
+WHERE product_id = ANY($1::uuid[])
+ORDER BY product_id, id
+`
+
+// Loads the variants of a whole page of products in one query.
+func (q *Queries) ListProductVariants(ctx context.Context, productIds []uuid.UUID) ([]ProductVariant, error) {
+	rows, err := q.db.Query(ctx, listProductVariants, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductVariant{}
+	for rows.Next() {
+		var i ProductVariant
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Sku,
+			&i.Attributes,
+			&i.PriceOverrideMinorUnits,
+			&i.PriceOverrideCurrency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsByCreatedAt = `-- name: ListProductsByCreatedAt :many
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids
 FROM products p
 JOIN sellers s ON p.seller_id = s.id
 WHERE p.deleted_at IS NULL AND s.deleted_at IS NULL
-ORDER BY p.created_at DESC
+  AND ($1::uuid IS NULL OR p.seller_id = $1::uuid)
+  AND ($2::text IS NULL OR p.status = $2::text)
+  AND ($3::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM product_categories pc JOIN categories c ON c.id = pc.category_id
+        WHERE pc.product_id = p.id
+          AND c.path LIKE (SELECT root.path FROM categories root WHERE root.id = $3::uuid) || '%'))
+  AND ($4::text IS NULL OR p.currency = $4::text)
+  AND ($5::bigint IS NULL OR p.price_minor_units >= $5::bigint)
+  AND ($6::bigint IS NULL OR p.price_minor_units <= $6::bigint)
+  AND ($7::uuid IS NULL OR (p.created_at, p.id) < ($8::timestamptz, $7::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT $9
 `
 
-type GetAllProductsRow struct {
+type ListProductsByCreatedAtParams struct {
+	SellerID           pgtype.UUID        `db:"seller_id" json:"seller_id"`
+	Status             pgtype.Text        `db:"status" json:"status"`
+	CategoryID         pgtype.UUID        `db:"category_id" json:"category_id"`
+	Currency           pgtype.Text        `db:"currency" json:"currency"`
+	MinPriceMinorUnits pgtype.Int8        `db:"min_price_minor_units" json:"min_price_minor_units"`
+	MaxPriceMinorUnits pgtype.Int8        `db:"max_price_minor_units" json:"max_price_minor_units"`
+	AfterID            pgtype.UUID        `db:"after_id" json:"after_id"`
+	AfterCreatedAt     pgtype.Timestamptz `db:"after_created_at" json:"after_created_at"`
+	Limit              int32              `db:"limit" json:"limit"`
+}
+
+type ListProductsByCreatedAtRow struct {
 	ID              uuid.UUID          `db:"id" json:"id"`
 	Name            string             `db:"name" json:"name"`
 	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
 	Currency        string             `db:"currency" json:"currency"`
 	SellerID        uuid.UUID          `db:"seller_id" json:"seller_id"`
+	Status          string             `db:"status" json:"status"`
 	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version         int32              `db:"version" json:"version"`
+	CategoryIds     []uuid.UUID        `db:"category_ids" json:"category_ids"`
 }
 
-func (q *Queries) GetAllProducts(ctx context.Context) ([]GetAllProductsRow, error) {
-	rows, err := q.db.Query(ctx, getAllProducts)
+func (q *Queries) ListProductsByCreatedAt(ctx context.Context, arg ListProductsByCreatedAtParams) ([]ListProductsByCreatedAtRow, error) {
+	rows, err := q.db.Query(ctx, listProductsByCreatedAt,
+		arg.SellerID,
+		arg.Status,
+		arg.CategoryID,
+		arg.Currency,
+		arg.MinPriceMinorUnits,
+		arg.MaxPriceMinorUnits,
+		arg.AfterID,
+		arg.AfterCreatedAt,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetAllProductsRow{}
+	items := []ListProductsByCreatedAtRow{}
 	for rows.Next() {
-		var i GetAllProductsRow
+		var i ListProductsByCreatedAtRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
 			&i.PriceMinorUnits,
 			&i.Currency,
 			&i.SellerID,
+			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.CategoryIds,
 		); err != nil {
 			return nil, err
 		}
@@ -107,42 +405,319 @@ func (q *Queries) GetAllProducts(ctx context.Context) ([]GetAllProductsRow, erro
 	return items, nil
 }
 
-const getProductById = `-- name: GetProductById :one
-SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.created_at, p.updated_at
+const listProductsByName = `-- name: ListProductsByName :many
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids
 FROM products p
 JOIN sellers s ON p.seller_id = s.id
-WHERE p.id = $1 AND p.deleted_at IS NULL AND s.deleted_at IS NULL
+WHERE p.deleted_at IS NULL AND s.deleted_at IS NULL
+  AND ($1::uuid IS NULL OR p.seller_id = $1::uuid)
+  AND ($2::text IS NULL OR p.status = $2::text)
+  AND ($3::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM product_categories pc JOIN categories c ON c.id = pc.category_id
+        WHERE pc.product_id = p.id
+          AND c.path LIKE (SELECT root.path FROM categories root WHERE root.id = $3::uuid) || '%'))
+  AND ($4::text IS NULL OR p.currency = $4::text)
+  AND ($5::bigint IS NULL OR p.price_minor_units >= $5::bigint)
+  AND ($6::bigint IS NULL OR p.price_minor_units <= $6::bigint)
+  AND ($7::uuid IS NULL OR (p.name, p.id) > ($8::text, $7::uuid))
+ORDER BY p.name, p.id
+LIMIT $9
 `
 
-type GetProductByIdRow struct {
+type ListProductsByNameParams struct {
+	SellerID           pgtype.UUID `db:"seller_id" json:"seller_id"`
+	Status             pgtype.Text `db:"status" json:"status"`
+	CategoryID         pgtype.UUID `db:"category_id" json:"category_id"`
+	Currency           pgtype.Text `db:"currency" json:"currency"`
+	MinPriceMinorUnits pgtype.Int8 `db:"min_price_minor_units" json:"min_price_minor_units"`
+	MaxPriceMinorUnits pgtype.Int8 `db:"max_price_minor_units" json:"max_price_minor_units"`
+	AfterID            pgtype.UUID `db:"after_id" json:"after_id"`
+	AfterName          pgtype.Text `db:"after_name" json:"after_name"`
+	Limit              int32       `db:"limit" json:"limit"`
+}
+
+type ListProductsByNameRow struct {
 	ID              uuid.UUID          `db:"id" json:"id"`
 	Name            string             `db:"name" json:"name"`
 	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
 	Currency        string             `db:"currency" json:"currency"`
 	SellerID        uuid.UUID          `db:"seller_id" json:"seller_id"`
+	Status          string             `db:"status" json:"status"`
 	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version         int32              `db:"version" json:"version"`
+	CategoryIds     []uuid.UUID        `db:"category_ids" json:"category_ids"`
 }
 
-func (q *Queries) GetProductById(ctx context.Context, id uuid.UUID) (GetProductByIdRow, error) {
-	row := q.db.QueryRow(ctx, getProductById, id)
-	var i GetProductByIdRow
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.PriceMinorUnits,
-		&i.Currency,
-		&i.SellerID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+func (q *Queries) ListProductsByName(ctx context.Context, arg ListProductsByNameParams) ([]ListProductsByNameRow, error) {
+	rows, err := q.db.Query(ctx, listProductsByName,
+		arg.SellerID,
+		arg.Status,
+		arg.CategoryID,
+		arg.Currency,
+		arg.MinPriceMinorUnits,
+		arg.MaxPriceMinorUnits,
+		arg.AfterID,
+		arg.AfterName,
+		arg.Limit,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductsByNameRow{}
+	for rows.Next() {
+		var i ListProductsByNameRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.PriceMinorUnits,
+			&i.Currency,
+			&i.SellerID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.CategoryIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsByPrice = `-- name: ListProductsByPrice :many
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids
+FROM products p
+JOIN sellers s ON p.seller_id = s.id
+WHERE p.deleted_at IS NULL AND s.deleted_at IS NULL
+  AND ($1::uuid IS NULL OR p.seller_id = $1::uuid)
+  AND ($2::text IS NULL OR p.status = $2::text)
+  AND ($3::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM product_categories pc JOIN categories c ON c.id = pc.category_id
+        WHERE pc.product_id = p.id
+          AND c.path LIKE (SELECT root.path FROM categories root WHERE root.id = $3::uuid) || '%'))
+  AND ($4::text IS NULL OR p.currency = $4::text)
+  AND ($5::bigint IS NULL OR p.price_minor_units >= $5::bigint)
+  AND ($6::bigint IS NULL OR p.price_minor_units <= $6::bigint)
+  AND ($7::uuid IS NULL OR (p.price_minor_units, p.id) > ($8::bigint, $7::uuid))
+ORDER BY p.price_minor_units, p.id
+LIMIT $9
+`
+
+type ListProductsByPriceParams struct {
+	SellerID             pgtype.UUID `db:"seller_id" json:"seller_id"`
+	Status               pgtype.Text `db:"status" json:"status"`
+	CategoryID           pgtype.UUID `db:"category_id" json:"category_id"`
+	Currency             pgtype.Text `db:"currency" json:"currency"`
+	MinPriceMinorUnits   pgtype.Int8 `db:"min_price_minor_units" json:"min_price_minor_units"`
+	MaxPriceMinorUnits   pgtype.Int8 `db:"max_price_minor_units" json:"max_price_minor_units"`
+	AfterID              pgtype.UUID `db:"after_id" json:"after_id"`
+	AfterPriceMinorUnits pgtype.Int8 `db:"after_price_minor_units" json:"after_price_minor_units"`
+	Limit                int32       `db:"limit" json:"limit"`
+}
+
+type ListProductsByPriceRow struct {
+	ID              uuid.UUID          `db:"id" json:"id"`
+	Name            string             `db:"name" json:"name"`
+	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
+	Currency        string             `db:"currency" json:"currency"`
+	SellerID        uuid.UUID          `db:"seller_id" json:"seller_id"`
+	Status          string             `db:"status" json:"status"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version         int32              `db:"version" json:"version"`
+	CategoryIds     []uuid.UUID        `db:"category_ids" json:"category_ids"`
+}
+
+func (q *Queries) ListProductsByPrice(ctx context.Context, arg ListProductsByPriceParams) ([]ListProductsByPriceRow, error) {
+	rows, err := q.db.Query(ctx, listProductsByPrice,
+		arg.SellerID,
+		arg.Status,
+		arg.CategoryID,
+		arg.Currency,
+		arg.MinPriceMinorUnits,
+		arg.MaxPriceMinorUnits,
+		arg.AfterID,
+		arg.AfterPriceMinorUnits,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductsByPriceRow{}
+	for rows.Next() {
+		var i ListProductsByPriceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.PriceMinorUnits,
+			&i.Currency,
+			&i.SellerID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.CategoryIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const productExists = `-- name: ProductExists :one
+SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)
+`
+
+func (q *Queries) ProductExists(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, productExists, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const refreshProductCategoryNames = `-- name: RefreshProductCategoryNames :exec
+UPDATE products p
+SET category_names = coalesce((
+        SELECT string_agg(DISTINCT ancestor.name, ' ')
+        FROM product_categories pc
+        JOIN categories c ON c.id = pc.category_id
+        JOIN categories ancestor ON c.path LIKE ancestor.path || '%'
+        WHERE pc.product_id = p.id), '')
+WHERE p.id = $1
+`
+
+// Recomputes category_names, the category part of the search vector, from
+// the product's categories and all their ancestors.
+func (q *Queries) RefreshProductCategoryNames(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, refreshProductCategoryNames, id)
+	return err
+}
+
+const scheduleProductImageDeletions = `-- name: ScheduleProductImageDeletions :exec
+INSERT INTO blob_deletions (blob_key)
+SELECT blob_key FROM product_images WHERE product_id = $1
+ON CONFLICT (blob_key) DO NOTHING
+`
+
+func (q *Queries) ScheduleProductImageDeletions(ctx context.Context, productID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, scheduleProductImageDeletions, productID)
+	return err
+}
+
+const searchProducts = `-- name: SearchProducts :many
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids,
+       ts_rank(p.search_vector, query)::real AS rank,
+       ts_headline('english', p.name, query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::text AS headline
+FROM products p
+JOIN sellers s ON p.seller_id = s.id
+CROSS JOIN to_tsquery('english', $1::text) query
+WHERE p.deleted_at IS NULL AND s.deleted_at IS NULL AND p.status = 'published'
+  AND p.search_vector @@ query
+  AND ($2::uuid IS NULL
+       OR ts_rank(p.search_vector, query) < $3::real
+       OR (ts_rank(p.search_vector, query) = $3::real AND p.id > $2::uuid))
+ORDER BY rank DESC, p.id
+LIMIT $4
+`
+
+type SearchProductsParams struct {
+	Query     string        `db:"query" json:"query"`
+	AfterID   pgtype.UUID   `db:"after_id" json:"after_id"`
+	AfterRank pgtype.Float4 `db:"after_rank" json:"after_rank"`
+	Limit     int32         `db:"limit" json:"limit"`
+}
+
+type SearchProductsRow struct {
+	ID              uuid.UUID          `db:"id" json:"id"`
+	Name            string             `db:"name" json:"name"`
+	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
+	Currency        string             `db:"currency" json:"currency"`
+	SellerID        uuid.UUID          `db:"seller_id" json:"seller_id"`
+	Status          string             `db:"status" json:"status"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version         int32              `db:"version" json:"version"`
+	CategoryIds     []uuid.UUID        `db:"category_ids" json:"category_ids"`
+	Rank            float32            `db:"rank" json:"rank"`
+	Headline        string             `db:"headline" json:"headline"`
+}
+
+// Ranks the published products whose search vector matches the tsquery,
+// best match first. The headline is the product name with every matching
+// word wrapped in <mark> and </mark>.
+func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error) {
+	rows, err := q.db.Query(ctx, searchProducts,
+		arg.Query,
+		arg.AfterID,
+		arg.AfterRank,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchProductsRow{}
+	for rows.Next() {
+		var i SearchProductsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.PriceMinorUnits,
+			&i.Currency,
+			&i.SellerID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.CategoryIds,
+			&i.Rank,
+			&i.Headline,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const skuTaken = `-- name: SkuTaken :one
+SELECT EXISTS(SELECT 1 FROM product_variants WHERE sku = $1 AND id <> $2)
+`
+
+type SkuTakenParams struct {
+	Sku string    `db:"sku" json:"sku"`
+	ID  uuid.UUID `db:"id" json:"id"`
+}
+
+// SKUs of soft-deleted products stay taken.
+func (q *Queries) SkuTaken(ctx context.Context, arg SkuTakenParams) (bool, error) {
+	row := q.db.QueryRow(ctx, skuTaken, arg.Sku, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
 }
 
 const updateProduct = `-- name: UpdateProduct :execrows
 UPDATE products
-SET name = $2, price_minor_units = $3, currency = $4, seller_id = $5, updated_at = $6
-WHERE id = $1 AND deleted_at IS NULL
+SET name = $2, price_minor_units = $3, currency = $4, seller_id = $5, status = $6, updated_at = $7, version = version + 1
+WHERE id = $1 AND version = $8 AND deleted_at IS NULL
 `
 
 type UpdateProductParams struct {
@@ -151,9 +726,13 @@ type UpdateProductParams struct {
 	PriceMinorUnits int64              `db:"price_minor_units" json:"price_minor_units"`
 	Currency        string             `db:"currency" json:"currency"`
 	SellerID        uuid.UUID          `db:"seller_id" json:"seller_id"`
+	Status          string             `db:"status" json:"status"`
 	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version         int32              `db:"version" json:"version"`
 }
 
+// Applies only while the row still has the version the caller read; zero
+// rows means the product is gone or was modified concurrently.
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (int64, error) {
 	result, err := q.db.Exec(ctx, updateProduct,
 		arg.ID,
@@ -161,10 +740,37 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (i
 		arg.PriceMinorUnits,
 		arg.Currency,
 		arg.SellerID,
+		arg.Status,
 		arg.UpdatedAt,
+		arg.Version,
 	)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected(), nil
 }
+
+const updateProductVariant = `-- name: UpdateProductVariant :exec
+UPDATE product_variants
+SET sku = $2, attributes = $3, price_override_minor_units = $4, price_override_currency = $5
+WHERE id = $1
+`
+
+type UpdateProductVariantParams struct {
+	ID                      uuid.UUID   `db:"id" json:"id"`
+	Sku                     string      `db:"sku" json:"sku"`
+	Attributes              []byte      `db:"attributes" json:"attributes"`
+	PriceOverrideMinorUnits pgtype.Int8 `db:"price_override_minor_units" json:"price_override_minor_units"`
+	PriceOverrideCurrency   pgtype.Text `db:"price_override_currency" json:"price_override_currency"`
+}
+
+func (q *Queries) UpdateProductVariant(ctx context.Context, arg UpdateProductVariantParams) error {
+	_, err := q.db.Exec(ctx, updateProductVariant,
+		arg.ID,
+		arg.Sku,
+		arg.Attributes,
+		arg.PriceOverrideMinorUnits,
+		arg.PriceOverrideCurrency,
+	)
+	return err
+}
@@ -8,27 +8,215 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	// Moves up to batch_size events published before published_before into
+	// outbox_events_archive, in one statement so a row is never in both tables
+	// or in neither. Only published rows qualify, which the relay never touches
+	// again; SKIP LOCKED keeps concurrent workers on disjoint rows.
+	ArchivePublishedOutboxEvents(ctx context.Context, arg ArchivePublishedOutboxEventsParams) (int64, error)
+	// Stores the display order of the product's images and which is primary.
+	ArrangeProductImages(ctx context.Context, arg ArrangeProductImagesParams) error
+	CategoryExists(ctx context.Context, id uuid.UUID) (bool, error)
+	CategoryHasContents(ctx context.Context, id uuid.UUID) (bool, error)
+	// Locks the oldest due message for the rest of the caller's transaction;
+	// concurrent dispatchers skip it and take the next one.
+	ClaimInboxMessage(ctx context.Context) (InboxMessage, error)
+	// Leases up to batch_size unpublished events to one relay. SKIP LOCKED makes
+	// concurrent relays claim disjoint batches instead of waiting on each other;
+	// rows with a live lease, a pending backoff or a dead letter are skipped.
+	// Only the oldest pending event of each aggregate is eligible, so at most one
+	// event per aggregate is in flight and a failing (or dead-lettered) event
+	// holds back the later events of its aggregate until it is published,
+	// replayed or discarded.
+	ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error)
+	// Takes the lease on a replay that is not completed and not being run by
+	// someone else (or whose runner stopped renewing its lease).
+	ClaimOutboxReplay(ctx context.Context, arg ClaimOutboxReplayParams) (OutboxReplay, error)
+	// Leases due deliveries to one dispatcher, the same way ClaimOutboxEvents
+	// leases outbox events.
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Published events matching a replay's filters; NULL filters match all.
+	CountReplayableOutboxEvents(ctx context.Context, arg CountReplayableOutboxEventsParams) (int64, error)
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) error
+	CreateOrder(ctx context.Context, arg CreateOrderParams) error
+	CreateOutboxReplay(ctx context.Context, arg CreateOutboxReplayParams) (OutboxReplay, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreatePromotion(ctx context.Context, arg CreatePromotionParams) error
 	CreateSeller(ctx context.Context, arg CreateSellerParams) (Seller, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	DeleteBlobDeletion(ctx context.Context, blobKey string) error
+	DeleteCart(ctx context.Context, arg DeleteCartParams) (int64, error)
+	DeleteCartLines(ctx context.Context, buyerID uuid.UUID) error
+	// Deletes only an empty category: no subcategories and no products.
+	DeleteCategory(ctx context.Context, arg DeleteCategoryParams) (int64, error)
+	DeleteExpiredCarts(ctx context.Context, limit int32) (int64, error)
+	// Deletes up to batch_size deliveries that were delivered, or failed for
+	// good, before finished_before. Pending deliveries are never touched, and
+	// SKIP LOCKED keeps concurrent retention workers on disjoint rows.
+	DeleteFinishedWebhookDeliveries(ctx context.Context, arg DeleteFinishedWebhookDeliveriesParams) (int64, error)
 	DeleteIdempotencyRecord(ctx context.Context, key string) error
-	DeleteProduct(ctx context.Context, id uuid.UUID) error
-	DeleteSeller(ctx context.Context, id uuid.UUID) error
-	GetAllProducts(ctx context.Context) ([]GetAllProductsRow, error)
-	GetAllSellers(ctx context.Context) ([]GetAllSellersRow, error)
+	DeleteProduct(ctx context.Context, arg DeleteProductParams) (int64, error)
+	DeleteProductCategories(ctx context.Context, productID uuid.UUID) error
+	DeleteProductImage(ctx context.Context, id uuid.UUID) error
+	DeleteProductImages(ctx context.Context, productID uuid.UUID) error
+	DeleteProductVariant(ctx context.Context, id uuid.UUID) error
+	// Like ArchivePublishedOutboxEvents, for deployments that keep no archive.
+	DeletePublishedOutboxEvents(ctx context.Context, arg DeletePublishedOutboxEventsParams) (int64, error)
+	DeleteScheduledPriceChange(ctx context.Context, arg DeleteScheduledPriceChangeParams) (int64, error)
+	DeleteSeller(ctx context.Context, arg DeleteSellerParams) (int64, error)
+	DeleteStockReservation(ctx context.Context, id uuid.UUID) error
+	// Pending deliveries go with it (ON DELETE CASCADE).
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) (int64, error)
+	DiscardDeadLetteredOutboxEvent(ctx context.Context, id uuid.UUID) (int64, error)
+	// Creates a delivery for every subscription whose filter matches the event.
+	// Idempotent per (subscription, event), so a republished outbox event does
+	// not notify a subscriber twice.
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error)
+	// Variants start without an inventory row; the first writer creates it.
+	// Zero rows means the variant does not exist, or its row already does.
+	EnsureInventory(ctx context.Context, id uuid.UUID) (int64, error)
+	// Ends a run as 'completed' or 'failed' and gives up the lease.
+	FinishOutboxReplay(ctx context.Context, arg FinishOutboxReplayParams) error
+	GetCart(ctx context.Context, buyerID uuid.UUID) (Cart, error)
+	GetCategoryById(ctx context.Context, id uuid.UUID) (Category, error)
+	// Returns the latest rate for the pair that took effect at or before $3.
+	GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (ExchangeRate, error)
 	GetIdempotencyRecordByKey(ctx context.Context, key string) (IdempotencyRecord, error)
+	GetInventory(ctx context.Context, variantID uuid.UUID) (Inventory, error)
+	GetOrderById(ctx context.Context, id uuid.UUID) (Order, error)
+	GetOutboxReplay(ctx context.Context, id uuid.UUID) (OutboxReplay, error)
 	GetProductById(ctx context.Context, id uuid.UUID) (GetProductByIdRow, error)
+	GetPromotionById(ctx context.Context, id uuid.UUID) (Promotion, error)
 	GetSellerById(ctx context.Context, id uuid.UUID) (GetSellerByIdRow, error)
-	GetUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	GetUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]GetUnpublishedOutboxEventsRow, error)
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error)
+	InsertCartLine(ctx context.Context, arg InsertCartLineParams) error
+	// Stores a received message. Zero rows means the message was received
+	// before, i.e. this is a redelivery.
+	InsertInboxMessage(ctx context.Context, arg InsertInboxMessageParams) (int64, error)
+	InsertOrderItem(ctx context.Context, arg InsertOrderItemParams) error
 	InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventParams) error
+	// Inserts only the categories that exist; fewer rows than ids means some
+	// category is missing.
+	InsertProductCategories(ctx context.Context, arg InsertProductCategoriesParams) (int64, error)
+	InsertProductImage(ctx context.Context, arg InsertProductImageParams) error
+	// Two changes within the same microsecond keep the later one.
+	InsertProductPrice(ctx context.Context, arg InsertProductPriceParams) error
+	InsertProductVariant(ctx context.Context, arg InsertProductVariantParams) error
+	InsertScheduledPriceChange(ctx context.Context, arg InsertScheduledPriceChangeParams) error
+	InsertStockReservation(ctx context.Context, arg InsertStockReservationParams) error
+	// Promotions of the given sellers that run at the given time and have
+	// redemptions left.
+	ListActivePromotions(ctx context.Context, arg ListActivePromotionsParams) ([]Promotion, error)
+	ListCartLines(ctx context.Context, buyerID uuid.UUID) ([]CartLine, error)
+	// A null parent_id lists the root categories.
+	ListCategoriesByParent(ctx context.Context, parentID pgtype.UUID) ([]Category, error)
+	ListDeadLetteredOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	ListDueBlobDeletions(ctx context.Context, limit int32) ([]string, error)
+	ListDueScheduledPriceChanges(ctx context.Context, arg ListDueScheduledPriceChangesParams) ([]ScheduledPriceChange, error)
+	// Loads the items of a whole page of orders in one query.
+	ListOrderItems(ctx context.Context, orderIds []uuid.UUID) ([]OrderItem, error)
+	ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order, error)
+	ListOutboxReplays(ctx context.Context, limit int32) ([]OutboxReplay, error)
+	// Loads the images of a whole page of products in one query.
+	ListProductImages(ctx context.Context, productIds []uuid.UUID) ([]ProductImage, error)
+	// Returns the price already in effect at "from" and every change up to
+	// "to", oldest first.
+	ListProductPriceHistory(ctx context.Context, arg ListProductPriceHistoryParams) ([]ProductPriceHistory, error)
+	// Loads the variants of a whole page of products in one query.
+	ListProductVariants(ctx context.Context, productIds []uuid.UUID) ([]ProductVariant, error)
+	ListProductsByCreatedAt(ctx context.Context, arg ListProductsByCreatedAtParams) ([]ListProductsByCreatedAtRow, error)
+	ListProductsByName(ctx context.Context, arg ListProductsByNameParams) ([]ListProductsByNameRow, error)
+	ListProductsByPrice(ctx context.Context, arg ListProductsByPriceParams) ([]ListProductsByPriceRow, error)
+	ListPromotionsBySeller(ctx context.Context, sellerID uuid.UUID) ([]Promotion, error)
+	// The next batch_size published events after after_sequence_number, from
+	// both the outbox and its archive. The retention worker moves rows in one
+	// statement, so this statement's snapshot sees each row exactly once.
+	ListReplayableOutboxEvents(ctx context.Context, arg ListReplayableOutboxEventsParams) ([]ListReplayableOutboxEventsRow, error)
+	ListScheduledPriceChanges(ctx context.Context, productID uuid.UUID) ([]ScheduledPriceChange, error)
+	ListSellersByCreatedAt(ctx context.Context, arg ListSellersByCreatedAtParams) ([]ListSellersByCreatedAtRow, error)
+	ListSellersByName(ctx context.Context, arg ListSellersByNameParams) ([]ListSellersByNameRow, error)
+	ListStockReservations(ctx context.Context, variantID uuid.UUID) ([]StockReservation, error)
+	ListVariantsWithExpiredReservations(ctx context.Context, limit int32) ([]uuid.UUID, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	// Locks the categories in id order, so concurrent moves cannot deadlock,
+	// and returns their current paths.
+	LockCategoryPaths(ctx context.Context, ids []uuid.UUID) ([]LockCategoryPathsRow, error)
+	LockInventory(ctx context.Context, variantID uuid.UUID) (Inventory, error)
+	MarkInboxMessageProcessed(ctx context.Context, arg MarkInboxMessageProcessedParams) error
 	MarkOutboxEventPublished(ctx context.Context, id uuid.UUID) error
+	MarkWebhookDelivered(ctx context.Context, arg MarkWebhookDeliveredParams) error
+	// Replaces the old_path prefix of a category and all its descendants.
+	MoveCategorySubtree(ctx context.Context, arg MoveCategorySubtreeParams) error
+	OrderExists(ctx context.Context, id uuid.UUID) (bool, error)
+	ProductExists(ctx context.Context, id uuid.UUID) (bool, error)
+	PromotionExists(ctx context.Context, id uuid.UUID) (bool, error)
+	// Counts a failed attempt and schedules the next one; give_up parks the
+	// message for good.
+	RecordInboxMessageFailure(ctx context.Context, arg RecordInboxMessageFailureParams) error
+	// Counts a failed publish, schedules the next attempt and releases the
+	// lease. dead_letter parks the event for good once attempts are exhausted.
+	RecordOutboxEventFailure(ctx context.Context, arg RecordOutboxEventFailureParams) error
+	// Counts a failed attempt and schedules the next one; give_up marks the
+	// delivery as failed for good. status_code is NULL when no response came.
+	RecordWebhookDeliveryFailure(ctx context.Context, arg RecordWebhookDeliveryFailureParams) error
+	// Counts one use without a version check, so concurrent redemptions do not
+	// conflict; the condition keeps the count within the usage limit. No row
+	// means the promotion is unknown, not running at the given time or used up.
+	RedeemPromotion(ctx context.Context, arg RedeemPromotionParams) (Promotion, error)
+	// Recomputes products.category_names for the products in the subtree under
+	// path, after a category in it was renamed or moved.
+	RefreshCategorizedProductNames(ctx context.Context, path string) error
+	// Recomputes category_names, the category part of the search vector, from
+	// the product's categories and all their ancestors.
+	RefreshProductCategoryNames(ctx context.Context, id uuid.UUID) error
+	// Hands an unpublished event back before the lease runs out, e.g. after a
+	// failed publish. Only the relay holding the lease can release it.
+	ReleaseOutboxEventClaim(ctx context.Context, arg ReleaseOutboxEventClaimParams) error
+	// Puts a dead letter back into the relay's queue with a fresh attempt
+	// budget. last_error is kept for reference until the next failure.
+	ReplayDeadLetteredOutboxEvent(ctx context.Context, id uuid.UUID) (int64, error)
 	// Atomically claims the key. Zero rows means another request already holds it.
 	ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (int64, error)
+	// Records progress and renews the lease.
+	SaveOutboxReplayCheckpoint(ctx context.Context, arg SaveOutboxReplayCheckpointParams) error
+	ScheduleBlobDeletion(ctx context.Context, blobKey string) error
+	ScheduleProductImageDeletions(ctx context.Context, productID uuid.UUID) error
+	// Ranks the published products whose search vector matches the tsquery,
+	// best match first. The headline is the product name with every matching
+	// word wrapped in <mark> and </mark>.
+	SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error)
+	SellerExists(ctx context.Context, id uuid.UUID) (bool, error)
 	SetIdempotencyResponse(ctx context.Context, arg SetIdempotencyResponseParams) error
+	// SKUs of soft-deleted products stay taken.
+	SkuTaken(ctx context.Context, arg SkuTakenParams) (bool, error)
+	// Applies only while the row still has the version the caller read; zero
+	// rows means the category is gone or was modified concurrently.
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (int64, error)
+	UpdateInventory(ctx context.Context, arg UpdateInventoryParams) error
+	// Items never change after creation; only the status moves on. Applies
+	// only while the row still has the version the caller read.
+	UpdateOrder(ctx context.Context, arg UpdateOrderParams) (int64, error)
+	// Applies only while the row still has the version the caller read; zero
+	// rows means the product is gone or was modified concurrently.
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (int64, error)
+	UpdateProductVariant(ctx context.Context, arg UpdateProductVariantParams) error
+	// Only the end and the usage count change after creation. Applies only
+	// while the row still has the version the caller read.
+	UpdatePromotion(ctx context.Context, arg UpdatePromotionParams) (int64, error)
+	// Applies only while the row still has the version the caller read; zero
+	// rows means the seller is gone or was modified concurrently.
 	UpdateSeller(ctx context.Context, arg UpdateSellerParams) (int64, error)
+	// Inserts a new cart or updates a stored one while its version still
+	// matches. A cart that was never saved passes version 0, which no stored
+	// row has, so two concurrent first writes cannot both succeed.
+	UpsertCart(ctx context.Context, arg UpsertCartParams) (int64, error)
+	UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) error
+	VariantHasReservations(ctx context.Context, variantID uuid.UUID) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -13,24 +13,28 @@ import (
 )
 
 const createSeller = `-- name: CreateSeller :one
-INSERT INTO sellers (id, name, created_at, updated_at)
-VALUES ($1, $2, $3, $4)
-RETURNING id, name, created_at, updated_at, deleted_at
+INSERT INTO sellers (id, name, verification_status, created_at, updated_at, version)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, created_at, updated_at, deleted_at, version, verification_status
 `
 
 type CreateSellerParams struct {
-	ID        uuid.UUID          `db:"id" json:"id"`
-	Name      string             `db:"name" json:"name"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID                 uuid.UUID          `db:"id" json:"id"`
+	Name               string             `db:"name" json:"name"`
+	VerificationStatus string             `db:"verification_status" json:"verification_status"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version            int32              `db:"version" json:"version"`
 }
 
 func (q *Queries) CreateSeller(ctx context.Context, arg CreateSellerParams) (Seller, error) {
 	row := q.db.QueryRow(ctx, createSeller,
 		arg.ID,
 		arg.Name,
+		arg.VerificationStatus,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Version,
 	)
 	var i Seller
 	err := row.Scan(
@@ -39,47 +43,100 @@ func (q *Queries) CreateSeller(ctx context.Context, arg CreateSellerParams) (Sel
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Version,
+		&i.VerificationStatus,
 	)
 	return i, err
 }
 
-const deleteSeller = `-- name: DeleteSeller :exec
-UPDATE sellers SET deleted_at = NOW() WHERE id = $1
+const deleteSeller = `-- name: DeleteSeller :execrows
+UPDATE sellers
+SET deleted_at = NOW(), version = version + 1
+WHERE id = $1 AND version = $2 AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteSeller(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteSeller, id)
-	return err
+type DeleteSellerParams struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	Version int32     `db:"version" json:"version"`
+}
+
+func (q *Queries) DeleteSeller(ctx context.Context, arg DeleteSellerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSeller, arg.ID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const getAllSellers = `-- name: GetAllSellers :many
-SELECT id, name, created_at, updated_at
+const getSellerById = `-- name: GetSellerById :one
+SELECT id, name, verification_status, created_at, updated_at, version
+FROM sellers
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type GetSellerByIdRow struct {
+	ID                 uuid.UUID          `db:"id" json:"id"`
+	Name               string             `db:"name" json:"name"`
+	VerificationStatus string             `db:"verification_status" json:"verification_status"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version            int32              `db:"version" json:"version"`
+}
+
+func (q *Queries) GetSellerById(ctx context.Context, id uuid.UUID) (GetSellerByIdRow, error) {
+	row := q.db.QueryRow(ctx, getSellerById, id)
+	var i GetSellerByIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.VerificationStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const listSellersByCreatedAt = `-- name: ListSellersByCreatedAt :many
+SELECT id, name, verification_status, created_at, updated_at, version
 FROM sellers
 WHERE deleted_at IS NULL
-ORDER BY created_at DESC
+  AND ($1::uuid IS NULL OR (created_at, id) < ($2::timestamptz, $1::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT $3
 `
 
-type GetAllSellersRow struct {
-	ID        uuid.UUID          `db:"id" json:"id"`
-	Name      string             `db:"name" json:"name"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+type ListSellersByCreatedAtParams struct {
+	AfterID        pgtype.UUID        `db:"after_id" json:"after_id"`
+	AfterCreatedAt pgtype.Timestamptz `db:"after_created_at" json:"after_created_at"`
+	Limit          int32              `db:"limit" json:"limit"`
+}
+
+type ListSellersByCreatedAtRow struct {
+	ID                 uuid.UUID          `db:"id" json:"id"`
+	Name               string             `db:"name" json:"name"`
+	VerificationStatus string             `db:"verification_status" json:"verification_status"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version            int32              `db:"version" json:"version"`
 }
 
-func (q *Queries) GetAllSellers(ctx context.Context) ([]GetAllSellersRow, error) {
-	rows, err := q.db.Query(ctx, getAllSellers)
+func (q *Queries) ListSellersByCreatedAt(ctx context.Context, arg ListSellersByCreatedAtParams) ([]ListSellersByCreatedAtRow, error) {
+	rows, err := q.db.Query(ctx, listSellersByCreatedAt, arg.AfterID, arg.AfterCreatedAt, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetAllSellersRow{}
+	items := []ListSellersByCreatedAtRow{}
 	for rows.Next() {
-		var i GetAllSellersRow
+		var i ListSellersByCreatedAtRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
+			&i.VerificationStatus,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -91,45 +148,92 @@ func (q *Queries) GetAllSellers(ctx context.Context) ([]GetAllSellersRow, error)
 	return items, nil
 }
 
-const getSellerById = `-- name: GetSellerById :one
-SELECT id, name, created_at, updated_at
+const listSellersByName = `-- name: ListSellersByName :many
+SELECT id, name, verification_status, created_at, updated_at, version
 FROM sellers
-WHERE id = $1 AND deleted_at IS NULL
+WHERE deleted_at IS NULL
+  AND ($1::uuid IS NULL OR (name, id) > ($2::text, $1::uuid))
+ORDER BY name, id
+LIMIT $3
 `
 
-type GetSellerByIdRow struct {
-	ID        uuid.UUID          `db:"id" json:"id"`
-	Name      string             `db:"name" json:"name"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+type ListSellersByNameParams struct {
+	AfterID   pgtype.UUID `db:"after_id" json:"after_id"`
+	AfterName pgtype.Text `db:"after_name" json:"after_name"`
+	Limit     int32       `db:"limit" json:"limit"`
 }
 
-func (q *Queries) GetSellerById(ctx context.Context, id uuid.UUID) (GetSellerByIdRow, error) {
-	row := q.db.QueryRow(ctx, getSellerById, id)
-	var i GetSellerByIdRow
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type ListSellersByNameRow struct {
+	ID                 uuid.UUID          `db:"id" json:"id"`
+	Name               string             `db:"name" json:"name"`
+	VerificationStatus string             `db:"verification_status" json:"verification_status"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version            int32              `db:"version" json:"version"`
+}
+
+func (q *Queries) ListSellersByName(ctx context.Context, arg ListSellersByNameParams) ([]ListSellersByNameRow, error) {
+	rows, err := q.db.Query(ctx, listSellersByName, arg.AfterID, arg.AfterName, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSellersByNameRow{}
+	for rows.Next() {
+		var i ListSellersByNameRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.VerificationStatus,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sellerExists = `-- name: SellerExists :one
+SELECT EXISTS(SELECT 1 FROM sellers WHERE id = $1 AND deleted_at IS NULL)
+`
+
+func (q *Queries) SellerExists(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, sellerExists, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
 }
 
 const updateSeller = `-- name: UpdateSeller :execrows
 UPDATE sellers
-SET name = $2, updated_at = $3
-WHERE id = $1 AND deleted_at IS NULL
+SET name = $2, verification_status = $3, updated_at = $4, version = version + 1
+WHERE id = $1 AND version = $5 AND deleted_at IS NULL
 `
 
 type UpdateSellerParams struct {
-	ID        uuid.UUID          `db:"id" json:"id"`
-	Name      string             `db:"name" json:"name"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID                 uuid.UUID          `db:"id" json:"id"`
+	Name               string             `db:"name" json:"name"`
+	VerificationStatus string             `db:"verification_status" json:"verification_status"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Version            int32              `db:"version" json:"version"`
 }
 
+// Applies only while the row still has the version the caller read; zero
+// rows means the seller is gone or was modified concurrently.
 func (q *Queries) UpdateSeller(ctx context.Context, arg UpdateSellerParams) (int64, error) {
-	result, err := q.db.Exec(ctx, updateSeller, arg.ID, arg.Name, arg.UpdatedAt)
+	result, err := q.db.Exec(ctx, updateSeller,
+		arg.ID,
+		arg.Name,
+		arg.VerificationStatus,
+		arg.UpdatedAt,
+		arg.Version,
+	)
 	if err != nil {
 		return 0, err
 	}
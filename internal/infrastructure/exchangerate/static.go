@@ -0,0 +1,98 @@
+// Package exchangerate holds exchange-rate providers that do not need the
+// database.
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// StaticProvider serves rates read once from a JSON file, for tests and
+// offline use:
+//
+//	{"rates": [
+//	  {"from": "EUR", "to": "USD", "rate": "1.0845", "effective_at": "2026-01-01T00:00:00Z"}
+//	]}
+//
+// Like the Postgres table, rates are effective-dated and per direction.
+type StaticProvider struct {
+	// rates holds each pair's rates, oldest first.
+	rates map[pair][]entities.ExchangeRate
+}
+
+type pair struct {
+	from, to entities.Currency
+}
+
+type staticFile struct {
+	Rates []struct {
+		From        entities.Currency `json:"from"`
+		To          entities.Currency `json:"to"`
+		Rate        string            `json:"rate"`
+		EffectiveAt time.Time         `json:"effective_at"`
+	} `json:"rates"`
+}
+
+// NewStaticProvider reads path and fails on the first invalid rate, so a
+// typo surfaces at startup rather than at the first conversion.
+func NewStaticProvider(path string) (repositories.ExchangeRateProvider, error) {
+	rates, err := ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &StaticProvider{rates: make(map[pair][]entities.ExchangeRate)}
+	for _, rate := range rates {
+		key := pair{from: rate.From(), to: rate.To()}
+		provider.rates[key] = append(provider.rates[key], rate)
+	}
+	for _, rates := range provider.rates {
+		slices.SortStableFunc(rates, func(a, b entities.ExchangeRate) int {
+			return a.EffectiveAt().Compare(b.EffectiveAt())
+		})
+	}
+
+	return provider, nil
+}
+
+// ReadFile parses a rates file in the format StaticProvider serves, e.g.
+// to load it into the exchange_rates table. It fails on the first invalid
+// rate.
+func ReadFile(path string) ([]entities.ExchangeRate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file staticFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	rates := make([]entities.ExchangeRate, 0, len(file.Rates))
+	for i, raw := range file.Rates {
+		rate, err := entities.NewExchangeRate(raw.From, raw.To, raw.Rate, raw.EffectiveAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: rate %d: %w", path, i, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+func (p *StaticProvider) Rate(_ context.Context, from, to entities.Currency, at time.Time) (entities.ExchangeRate, error) {
+	rates := p.rates[pair{from: from, to: to}]
+	for i := len(rates) - 1; i >= 0; i-- {
+		if !rates[i].EffectiveAt().After(at) {
+			return rates[i], nil
+		}
+	}
+
+	return entities.ExchangeRate{}, fmt.Errorf("%w: %s to %s", entities.ErrExchangeRateNotFound, from, to)
+}
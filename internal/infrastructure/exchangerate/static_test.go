@@ -0,0 +1,52 @@
+package exchangerate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func TestStaticProvider_EffectiveDated(t *testing.T) {
+	provider, err := NewStaticProvider("testdata/rates.json")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = provider.Rate(ctx, entities.EUR, entities.USD, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, entities.ErrExchangeRateNotFound)
+
+	rate, err := provider.Rate(ctx, entities.EUR, entities.USD, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "1.0845", rate.Rate())
+
+	rate, err = provider.Rate(ctx, entities.EUR, entities.USD, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "1.10", rate.Rate())
+
+	_, err = provider.Rate(ctx, entities.USD, entities.EUR, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, entities.ErrExchangeRateNotFound)
+}
+
+func TestNewStaticProvider_InvalidFile(t *testing.T) {
+	_, err := NewStaticProvider("testdata/invalid_rate.json")
+	assert.ErrorIs(t, err, entities.ErrValidation)
+
+	_, err = NewStaticProvider("testdata/missing.json")
+	assert.Error(t, err)
+}
+
+func TestReadFile(t *testing.T) {
+	rates, err := ReadFile("testdata/rates.json")
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+	assert.Equal(t, entities.EUR, rates[0].From())
+	assert.Equal(t, entities.USD, rates[0].To())
+	assert.Equal(t, "1.10", rates[0].Rate(), "in file order")
+
+	_, err = ReadFile("testdata/invalid_rate.json")
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
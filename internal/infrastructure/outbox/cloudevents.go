@@ -0,0 +1,151 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	// eventTypePrefix namespaces event types in reverse-DNS style, as the
+	// CloudEvents spec recommends: product.created becomes
+	// io.github.sklinkert.goddd.product.created.v1.
+	eventTypePrefix       = "io.github.sklinkert.goddd."
+	structuredContentType = "application/cloudevents+json"
+	eventDataContentType  = "application/json"
+)
+
+// CloudEvent is the wire format of published domain events: a CloudEvents
+// 1.0 envelope (https://github.com/cloudevents/spec) around the event data.
+// The subject is the aggregate id; the schema version is part of the type,
+// so consumers can route incompatible versions apart.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	Id              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// NewCloudEvent wraps an outbox event. source identifies this service, e.g.
+// "/go-ddd/marketplace"; together with the event id it must be unique.
+func NewCloudEvent(source string, event Event) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		Id:              event.Id.String(),
+		Source:          source,
+		Type:            fmt.Sprintf("%s%s.v%d", eventTypePrefix, event.Name, max(event.SchemaVersion, 1)),
+		Subject:         event.AggregateId.String(),
+		Time:            event.OccurredAt.UTC(),
+		DataContentType: eventDataContentType,
+		Data:            event.Data,
+	}
+}
+
+// Attributes returns the context attributes carried outside the payload in
+// binary mode, keyed by their CloudEvents names. Transports add their own
+// prefix (ce- for HTTP, ce_ for Kafka); datacontenttype maps to the
+// transport's content type instead.
+func (ce CloudEvent) Attributes() map[string]string {
+	attributes := map[string]string{
+		"specversion": ce.SpecVersion,
+		"id":          ce.Id,
+		"source":      ce.Source,
+		"type":        ce.Type,
+		"time":        ce.Time.Format(time.RFC3339Nano),
+	}
+	if ce.Subject != "" {
+		attributes["subject"] = ce.Subject
+	}
+	return attributes
+}
+
+// Encode maps the event onto a transport message: a body plus headers,
+// including "content-type". In binary mode every attribute becomes a header
+// named headerPrefix+attribute; the protocol bindings use "ce-" (HTTP,
+// NATS) and "ce_" (Kafka).
+func (ce CloudEvent) Encode(mode ContentMode, headerPrefix string) ([]byte, map[string]string, error) {
+	if mode == BinaryMode {
+		headers := map[string]string{"content-type": ce.DataContentType}
+		for name, value := range ce.Attributes() {
+			headers[headerPrefix+name] = value
+		}
+		return ce.Data, headers, nil
+	}
+
+	body, err := json.Marshal(ce)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, map[string]string{"content-type": structuredContentType}, nil
+}
+
+// ContentMode selects how a CloudEvent is mapped onto a transport message.
+type ContentMode string
+
+const (
+	// StructuredMode sends the whole envelope as one JSON document.
+	StructuredMode ContentMode = "structured"
+	// BinaryMode sends the data as the body and the attributes as headers.
+	BinaryMode ContentMode = "binary"
+)
+
+func ParseContentMode(value string) (ContentMode, error) {
+	switch mode := ContentMode(value); mode {
+	case StructuredMode, BinaryMode:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown CloudEvents content mode %q (want %q or %q)", value, StructuredMode, BinaryMode)
+	}
+}
+
+// HTTPPublisher POSTs events as CloudEvents to a single sink URL, following
+// the CloudEvents HTTP protocol binding, so any CloudEvents SDK or
+// Knative-style broker can receive them. Any 2xx response counts as
+// delivered.
+type HTTPPublisher struct {
+	client *http.Client
+	url    string
+	source string
+	mode   ContentMode
+}
+
+func NewHTTPPublisher(client *http.Client, url, source string, mode ContentMode) *HTTPPublisher {
+	return &HTTPPublisher{client: client, url: url, source: source, mode: mode}
+}
+
+func (p *HTTPPublisher) Publish(ctx context.Context, event Event) error {
+	body, headers, err := NewCloudEvent(p.source, event).Encode(p.mode, "ce-")
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("cloudevents sink responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent() Event {
+	return Event{
+		Id:            uuid.Must(uuid.NewV7()),
+		AggregateId:   uuid.New(),
+		Name:          "product.created",
+		SchemaVersion: 1,
+		Data:          []byte(`{"name":"Widget","price_minor_units":999}`),
+		OccurredAt:    time.Date(2026, 7, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+	}
+}
+
+func TestNewCloudEvent(t *testing.T) {
+	event := testEvent()
+
+	ce := NewCloudEvent("/go-ddd/marketplace", event)
+
+	assert.Equal(t, "1.0", ce.SpecVersion)
+	assert.Equal(t, event.Id.String(), ce.Id)
+	assert.Equal(t, "/go-ddd/marketplace", ce.Source)
+	assert.Equal(t, "io.github.sklinkert.goddd.product.created.v1", ce.Type)
+	assert.Equal(t, event.AggregateId.String(), ce.Subject)
+	assert.Equal(t, time.UTC, ce.Time.Location())
+	assert.True(t, ce.Time.Equal(event.OccurredAt))
+
+	structured, err := json.Marshal(ce)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"specversion": "1.0",
+		"id": "`+event.Id.String()+`",
+		"source": "/go-ddd/marketplace",
+		"type": "io.github.sklinkert.goddd.product.created.v1",
+		"subject": "`+event.AggregateId.String()+`",
+		"time": "2026-07-01T10:00:00Z",
+		"datacontenttype": "application/json",
+		"data": {"name": "Widget", "price_minor_units": 999}
+	}`, string(structured))
+}
+
+func TestParseContentMode(t *testing.T) {
+	mode, err := ParseContentMode("binary")
+	require.NoError(t, err)
+	assert.Equal(t, BinaryMode, mode)
+
+	_, err = ParseContentMode("batched")
+	assert.Error(t, err)
+}
+
+// capturedRequest is what the test sink received.
+type capturedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newSink(t *testing.T, status int) (*httptest.Server, <-chan capturedRequest) {
+	t.Helper()
+	requests := make(chan capturedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- capturedRequest{header: r.Header.Clone(), body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestHTTPPublisher_StructuredMode(t *testing.T) {
+	server, requests := newSink(t, http.StatusAccepted)
+	publisher := NewHTTPPublisher(server.Client(), server.URL, "/go-ddd/marketplace", StructuredMode)
+	event := testEvent()
+
+	require.NoError(t, publisher.Publish(context.Background(), event))
+
+	req := <-requests
+	assert.Equal(t, "application/cloudevents+json", req.header.Get("Content-Type"))
+	assert.Empty(t, req.header.Get("ce-id"))
+	var ce CloudEvent
+	require.NoError(t, json.Unmarshal(req.body, &ce))
+	assert.Equal(t, event.Id.String(), ce.Id)
+	assert.JSONEq(t, string(event.Data), string(ce.Data))
+}
+
+func TestHTTPPublisher_BinaryMode(t *testing.T) {
+	server, requests := newSink(t, http.StatusOK)
+	publisher := NewHTTPPublisher(server.Client(), server.URL, "/go-ddd/marketplace", BinaryMode)
+	event := testEvent()
+
+	require.NoError(t, publisher.Publish(context.Background(), event))
+
+	req := <-requests
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "1.0", req.header.Get("ce-specversion"))
+	assert.Equal(t, event.Id.String(), req.header.Get("ce-id"))
+	assert.Equal(t, "/go-ddd/marketplace", req.header.Get("ce-source"))
+	assert.Equal(t, "io.github.sklinkert.goddd.product.created.v1", req.header.Get("ce-type"))
+	assert.Equal(t, event.AggregateId.String(), req.header.Get("ce-subject"))
+	assert.Equal(t, "2026-07-01T10:00:00Z", req.header.Get("ce-time"))
+	assert.JSONEq(t, string(event.Data), string(req.body))
+}
+
+func TestHTTPPublisher_NonSuccessStatusFails(t *testing.T) {
+	server, _ := newSink(t, http.StatusServiceUnavailable)
+	publisher := NewHTTPPublisher(server.Client(), server.URL, "/go-ddd/marketplace", StructuredMode)
+
+	err := publisher.Publish(context.Background(), testEvent())
+	assert.ErrorContains(t, err, "503")
+}
@@ -0,0 +1,73 @@
+// Package kafka publishes outbox events to Kafka.
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// EventIdHeader carries the event id in both content modes, so consumers
+// can deduplicate without decoding the body.
+const EventIdHeader = "event_id"
+
+type Config struct {
+	Brokers []string
+	Topic   string
+	// Source is the CloudEvents source of published events.
+	Source string
+	Mode   outbox.ContentMode
+}
+
+// Publisher produces each event to one topic as a CloudEvent. The record
+// key is the aggregate id, so the default partitioner keeps an aggregate's
+// events on one partition, in order; the event id is the dedup key, sent as
+// the event_id header. The producer is idempotent, which already removes
+// duplicates from its own retries.
+type Publisher struct {
+	client *kgo.Client
+	topic  string
+	source string
+	mode   outbox.ContentMode
+}
+
+func New(cfg Config) (*Publisher, error) {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(cfg.Brokers...),
+		kgo.DefaultProduceTopic(cfg.Topic),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create kafka client: %w", err)
+	}
+
+	return &Publisher{client: client, topic: cfg.Topic, source: cfg.Source, mode: cfg.Mode}, nil
+}
+
+// Publish returns once all in-sync replicas have the record.
+func (p *Publisher) Publish(ctx context.Context, event outbox.Event) error {
+	body, headers, err := outbox.NewCloudEvent(p.source, event).Encode(p.mode, "ce_")
+	if err != nil {
+		return err
+	}
+
+	record := &kgo.Record{
+		Topic: p.topic,
+		Key:   []byte(event.AggregateId.String()),
+		Value: body,
+		Headers: []kgo.RecordHeader{
+			{Key: EventIdHeader, Value: []byte(event.Id.String())},
+		},
+	}
+	for name, value := range headers {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: name, Value: []byte(value)})
+	}
+
+	return p.client.ProduceSync(ctx, record).FirstErr()
+}
+
+func (p *Publisher) Close() {
+	p.client.Close()
+}
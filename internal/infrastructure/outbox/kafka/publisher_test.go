@@ -0,0 +1,131 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const testTopic = "marketplace.events"
+
+func startCluster(t *testing.T) []string {
+	t.Helper()
+
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(4, testTopic))
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+
+	return cluster.ListenAddrs()
+}
+
+func consume(t *testing.T, brokers []string, n int) []*kgo.Record {
+	t.Helper()
+
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(brokers...),
+		kgo.ConsumeTopics(testTopic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var records []*kgo.Record
+	for len(records) < n {
+		fetches := client.PollFetches(ctx)
+		require.NoError(t, ctx.Err(), "got %d of %d records", len(records), n)
+		records = append(records, fetches.Records()...)
+	}
+	return records
+}
+
+func header(record *kgo.Record, key string) string {
+	for _, h := range record.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func testEvent(aggregateId uuid.UUID) outbox.Event {
+	return outbox.Event{
+		Id:            uuid.New(),
+		AggregateId:   aggregateId,
+		Name:          "product.updated",
+		SchemaVersion: 1,
+		Data:          []byte(`{"name":"Desk"}`),
+		OccurredAt:    time.Now(),
+	}
+}
+
+func TestPublisher_KeysRecordsByAggregate(t *testing.T) {
+	brokers := startCluster(t)
+	publisher, err := New(Config{Brokers: brokers, Topic: testTopic, Source: "/go-ddd/test", Mode: outbox.BinaryMode})
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	ctx := context.Background()
+	aggregates := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	var published []outbox.Event
+	for round := 0; round < 3; round++ {
+		for _, aggregateId := range aggregates {
+			event := testEvent(aggregateId)
+			require.NoError(t, publisher.Publish(ctx, event))
+			published = append(published, event)
+		}
+	}
+
+	records := consume(t, brokers, len(published))
+
+	partitions := map[string]int32{}
+	order := map[string][]string{}
+	for _, record := range records {
+		key := string(record.Key)
+		if partition, ok := partitions[key]; ok {
+			assert.Equal(t, partition, record.Partition, "aggregate %s spans partitions", key)
+		}
+		partitions[key] = record.Partition
+		order[key] = append(order[key], header(record, EventIdHeader))
+
+		assert.Equal(t, header(record, EventIdHeader), header(record, "ce_id"))
+		assert.Equal(t, key, header(record, "ce_subject"))
+		assert.Equal(t, "io.github.sklinkert.goddd.product.updated.v1", header(record, "ce_type"))
+		assert.JSONEq(t, `{"name":"Desk"}`, string(record.Value))
+	}
+	assert.Len(t, partitions, len(aggregates))
+
+	for _, aggregateId := range aggregates {
+		var want []string
+		for _, event := range published {
+			if event.AggregateId == aggregateId {
+				want = append(want, event.Id.String())
+			}
+		}
+		assert.Equal(t, want, order[aggregateId.String()], "events of one aggregate out of order")
+	}
+}
+
+func TestPublisher_StructuredMode(t *testing.T) {
+	brokers := startCluster(t)
+	publisher, err := New(Config{Brokers: brokers, Topic: testTopic, Source: "/go-ddd/test", Mode: outbox.StructuredMode})
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	event := testEvent(uuid.New())
+	require.NoError(t, publisher.Publish(context.Background(), event))
+
+	record := consume(t, brokers, 1)[0]
+	assert.Equal(t, "application/cloudevents+json", header(record, "content-type"))
+	assert.Equal(t, event.Id.String(), header(record, EventIdHeader))
+	assert.Contains(t, string(record.Value), `"id":"`+event.Id.String()+`"`)
+}
@@ -0,0 +1,77 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// notifyChannel is the channel the outbox_events_notify trigger (migration
+// 000010) signals on.
+const notifyChannel = "outbox_events"
+
+const (
+	minListenRetry = time.Second
+	maxListenRetry = 30 * time.Second
+)
+
+// listen holds a dedicated connection LISTENing on notifyChannel and signals
+// wake for every notification. It reconnects with backoff until ctx is
+// cancelled; while it is down the relay still polls, just with more latency.
+func (r *Relay) listen(ctx context.Context, wake chan<- struct{}) {
+	retry := minListenRetry
+	for {
+		connected, err := r.listenOnce(ctx, wake)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			retry = minListenRetry
+		}
+		slog.WarnContext(ctx, "outbox listener disconnected; falling back to polling",
+			slog.Any("error", err), slog.Duration("retry_in", retry))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+		retry = min(retry*2, maxListenRetry)
+	}
+}
+
+// listenOnce runs one LISTEN session and reports whether it got as far as
+// listening. The connection is opened outside the pool: it is held for the
+// relay's lifetime and must not starve request handlers.
+func (r *Relay) listenOnce(ctx context.Context, wake chan<- struct{}) (bool, error) {
+	conn, err := pgx.ConnectConfig(ctx, r.pool.Config().ConnConfig.Copy())
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{notifyChannel}.Sanitize()); err != nil {
+		return false, err
+	}
+	// Events committed while nobody was listening sent their notifications
+	// into the void.
+	wakeUp(wake)
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return true, err
+		}
+		wakeUp(wake)
+	}
+}
+
+// wakeUp wakes the relay without blocking; a pending wake-up already covers
+// any number of new events.
+func wakeUp(wake chan<- struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,99 @@
+// Package natsjs publishes outbox events to NATS JetStream.
+package natsjs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+)
+
+// duplicateWindow is how long JetStream remembers message ids. It only has
+// to cover the relay's redelivery after a lost ack, i.e. a lease or two.
+const duplicateWindow = 2 * time.Minute
+
+type Config struct {
+	URL string
+	// Stream is created (or updated) to capture SubjectPrefix.>.
+	Stream        string
+	SubjectPrefix string
+	// Source is the CloudEvents source of published events.
+	Source string
+	Mode   outbox.ContentMode
+}
+
+// Publisher sends each event to <prefix>.<event name>.<aggregate id>, e.g.
+// marketplace.events.product.created.0198c0de-..., as a CloudEvent. The
+// event id is the Nats-Msg-Id, so JetStream drops the duplicates the
+// at-least-once relay may produce. The aggregate id is the last subject
+// token: consumers filter per aggregate, and a stream subject transform
+// like {{partition(n,4)}} partitions by aggregate while keeping each
+// aggregate's events in order.
+type Publisher struct {
+	conn          *nats.Conn
+	js            jetstream.JetStream
+	subjectPrefix string
+	source        string
+	mode          outbox.ContentMode
+}
+
+// New connects and makes sure the stream exists.
+func New(ctx context.Context, cfg Config) (*Publisher, error) {
+	conn, err := nats.Connect(cfg.URL, nats.Name("go-ddd outbox relay"))
+	if err != nil {
+		return nil, fmt.Errorf("connect to nats: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       cfg.Stream,
+		Subjects:   []string{cfg.SubjectPrefix + ".>"},
+		Duplicates: duplicateWindow,
+	}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("ensure stream %s: %w", cfg.Stream, err)
+	}
+
+	return &Publisher{
+		conn:          conn,
+		js:            js,
+		subjectPrefix: cfg.SubjectPrefix,
+		source:        cfg.Source,
+		mode:          cfg.Mode,
+	}, nil
+}
+
+// Publish returns once the stream has stored the message.
+func (p *Publisher) Publish(ctx context.Context, event outbox.Event) error {
+	body, headers, err := outbox.NewCloudEvent(p.source, event).Encode(p.mode, "ce-")
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(p.subject(event))
+	msg.Data = body
+	for name, value := range headers {
+		msg.Header.Set(name, value)
+	}
+	msg.Header.Set(jetstream.MsgIDHeader, event.Id.String())
+
+	_, err = p.js.PublishMsg(ctx, msg)
+	return err
+}
+
+func (p *Publisher) subject(event outbox.Event) string {
+	return fmt.Sprintf("%s.%s.%s", p.subjectPrefix, event.Name, event.AggregateId)
+}
+
+// Close flushes and closes the connection.
+func (p *Publisher) Close() {
+	_ = p.conn.Drain()
+}
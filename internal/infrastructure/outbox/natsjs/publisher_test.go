@@ -0,0 +1,97 @@
+package natsjs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startServer(t *testing.T) string {
+	t.Helper()
+
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+	require.True(t, srv.ReadyForConnections(5*time.Second), "nats server did not start")
+
+	return srv.ClientURL()
+}
+
+func newTestPublisher(t *testing.T, mode outbox.ContentMode) *Publisher {
+	t.Helper()
+
+	publisher, err := New(context.Background(), Config{
+		URL:           startServer(t),
+		Stream:        "MARKETPLACE_EVENTS",
+		SubjectPrefix: "marketplace.events",
+		Source:        "/go-ddd/test",
+		Mode:          mode,
+	})
+	require.NoError(t, err)
+	t.Cleanup(publisher.Close)
+
+	return publisher
+}
+
+func testEvent() outbox.Event {
+	return outbox.Event{
+		Id:            uuid.New(),
+		AggregateId:   uuid.New(),
+		Name:          "product.created",
+		SchemaVersion: 1,
+		Data:          []byte(`{"name":"Desk"}`),
+		OccurredAt:    time.Now(),
+	}
+}
+
+func TestPublisher_PublishesToAggregateSubject(t *testing.T) {
+	ctx := context.Background()
+	publisher := newTestPublisher(t, outbox.BinaryMode)
+	event := testEvent()
+
+	require.NoError(t, publisher.Publish(ctx, event))
+
+	stream, err := publisher.js.Stream(ctx, "MARKETPLACE_EVENTS")
+	require.NoError(t, err)
+	msg, err := stream.GetLastMsgForSubject(ctx, "marketplace.events.>")
+	require.NoError(t, err)
+
+	assert.Equal(t, "marketplace.events.product.created."+event.AggregateId.String(), msg.Subject)
+	assert.JSONEq(t, `{"name":"Desk"}`, string(msg.Data))
+	assert.Equal(t, event.Id.String(), msg.Header.Get(jetstream.MsgIDHeader))
+	assert.Equal(t, event.Id.String(), msg.Header.Get("ce-id"))
+	assert.Equal(t, "io.github.sklinkert.goddd.product.created.v1", msg.Header.Get("ce-type"))
+	assert.Equal(t, event.AggregateId.String(), msg.Header.Get("ce-subject"))
+}
+
+func TestPublisher_DeduplicatesByEventId(t *testing.T) {
+	ctx := context.Background()
+	publisher := newTestPublisher(t, outbox.StructuredMode)
+	event := testEvent()
+
+	require.NoError(t, publisher.Publish(ctx, event))
+	require.NoError(t, publisher.Publish(ctx, event), "a redelivery must not fail")
+	require.NoError(t, publisher.Publish(ctx, testEvent()))
+
+	stream, err := publisher.js.Stream(ctx, "MARKETPLACE_EVENTS")
+	require.NoError(t, err)
+	info, err := stream.Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), info.State.Msgs)
+}
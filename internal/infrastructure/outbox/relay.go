@@ -1,81 +1,250 @@
 package outbox
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"os"
+	"slices"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
 )
 
-// Publisher forwards an event payload to the outside world (message broker,
+// defaultLease bounds how long a claimed batch stays reserved for one relay.
+// It must comfortably exceed the time needed to publish a whole batch;
+// otherwise another relay may claim the same events again (still
+// at-least-once, but duplicated work).
+const defaultLease = 30 * time.Second
+
+// Event is an outbox row on its way out. Data is the event's JSON in the
+// versioned wire format (see events.DomainEvent.SchemaVersion); the other
+// fields are envelope metadata. NewCloudEvent wraps it for the wire.
+type Event struct {
+	Id            uuid.UUID
+	AggregateId   uuid.UUID
+	Name          string
+	SchemaVersion int
+	Data          []byte
+	OccurredAt    time.Time
+}
+
+func eventFromRow(row db.OutboxEvent) Event {
+	return Event{
+		Id:            row.ID,
+		AggregateId:   row.AggregateID,
+		Name:          row.EventName,
+		SchemaVersion: int(row.SchemaVersion),
+		Data:          row.Payload,
+		OccurredAt:    row.OccurredAt.Time,
+	}
+}
+
+// Publisher forwards an event to the outside world (message broker,
 // webhook, ...). Implementations must be safe to call repeatedly with the
-// same event: the outbox guarantees at-least-once, not exactly-once.
+// same event: the outbox guarantees at-least-once, not exactly-once. The
+// event id is the consumers' dedup key.
 type Publisher interface {
-	Publish(ctx context.Context, eventName string, payload []byte) error
+	Publish(ctx context.Context, event Event) error
 }
 
 // SlogPublisher logs events instead of sending them anywhere. Swap it for a
 // Kafka/NATS/SQS publisher in a real deployment.
 type SlogPublisher struct{}
 
-func (SlogPublisher) Publish(ctx context.Context, eventName string, payload []byte) error {
+func (SlogPublisher) Publish(ctx context.Context, event Event) error {
 	slog.InfoContext(ctx, "publishing domain event",
-		slog.String("event", eventName), slog.String("payload", string(payload)))
+		slog.String("event", event.Name), slog.String("event_id", event.Id.String()),
+		slog.String("aggregate_id", event.AggregateId.String()), slog.String("data", string(event.Data)))
 	return nil
 }
 
-// Relay polls the outbox table and publishes unpublished events. Run exactly
-// one instance (or guard with FOR UPDATE SKIP LOCKED when scaling out).
+// FanOut publishes every event to all of its publishers. If any of them
+// fails the relay retries the event for all of them, so each must tolerate
+// duplicates (they must anyway).
+type FanOut []Publisher
+
+func (f FanOut) Publish(ctx context.Context, event Event) error {
+	var errs []error
+	for _, publisher := range f {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Relay publishes unpublished events from the outbox table. It wakes up as
+// soon as new events commit (LISTEN/NOTIFY) and also polls every interval,
+// which picks up due retries and anything a dropped notification missed.
+// Any number of instances may run side by side: a relay leases each batch
+// with FOR UPDATE SKIP LOCKED, so concurrent relays claim disjoint batches,
+// and a batch left behind by a crashed relay is claimed again once its lease
+// expires. Failed events are retried with backoff per the RetryPolicy and
+// dead-lettered once it is exhausted.
+//
+// Delivery is ordered per aggregate and parallel across aggregates: a batch
+// holds at most one event per aggregate_id, and the next event of that
+// aggregate becomes claimable only once the previous one is published.
 type Relay struct {
+	pool      *pgxpool.Pool
 	queries   *db.Queries
 	publisher Publisher
 	interval  time.Duration
 	batchSize int32
+	lease     time.Duration
+	retry     RetryPolicy
+	// id identifies this relay's leases in outbox_events.claimed_by.
+	id string
 }
 
-func NewRelay(queries *db.Queries, publisher Publisher, interval time.Duration) *Relay {
+func NewRelay(pool *pgxpool.Pool, publisher Publisher, interval time.Duration, retry RetryPolicy) *Relay {
 	return &Relay{
-		queries:   queries,
+		pool:      pool,
+		queries:   db.New(pool),
 		publisher: publisher,
 		interval:  interval,
 		batchSize: 100,
+		lease:     defaultLease,
+		retry:     retry,
+		id:        newRelayId(),
 	}
 }
 
+// newRelayId combines the hostname (the pod name on Kubernetes) with a
+// random suffix, so leases are attributable but never shared between two
+// relays on the same host.
+func newRelayId() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "relay"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8])
+}
+
 // Start blocks until ctx is cancelled.
 func (r *Relay) Start(ctx context.Context) {
+	wake := make(chan struct{}, 1)
+	go r.listen(ctx, wake)
+
 	ticker := time.NewTicker(r.interval)
 	defer ticker.Stop()
 
+	r.drain(ctx)
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-wake:
+			r.drain(ctx)
 		case <-ticker.C:
-			if err := r.relayBatch(ctx); err != nil {
-				slog.ErrorContext(ctx, "outbox relay batch failed", slog.Any("error", err))
-			}
+			r.drain(ctx)
+		}
+	}
+}
+
+// drain relays batches until one publishes nothing. A batch carries only the
+// head event of each aggregate, so a burst of changes to one aggregate needs
+// several batches; waiting a tick between them would throttle it needlessly.
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		published, err := r.relayBatch(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "outbox relay batch failed", slog.Any("error", err))
+			return
+		}
+		if published == 0 {
+			return
 		}
 	}
 }
 
-func (r *Relay) relayBatch(ctx context.Context) error {
-	events, err := r.queries.GetUnpublishedOutboxEvents(ctx, r.batchSize)
+// relayBatch claims and publishes one batch and reports how many events it
+// published.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	events, err := r.queries.ClaimOutboxEvents(ctx, db.ClaimOutboxEventsParams{
+		RelayID:   r.id,
+		LeaseMs:   r.lease.Milliseconds(),
+		BatchSize: r.batchSize,
+	})
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	for _, event := range events {
-		if err := r.publisher.Publish(ctx, event.EventName, event.Payload); err != nil {
-			// Stop the batch; unpublished events are retried next tick.
-			return err
+	// UPDATE ... RETURNING does not preserve the claim order.
+	slices.SortFunc(events, func(a, b db.OutboxEvent) int {
+		return cmp.Compare(a.SequenceNumber, b.SequenceNumber)
+	})
+
+	published := 0
+	for i, event := range events {
+		if err := r.publisher.Publish(ctx, eventFromRow(event)); err != nil {
+			if ctx.Err() != nil {
+				// Shutting down is not the event's fault: hand the rest
+				// back without counting an attempt.
+				r.release(ctx, events[i:])
+				return published, ctx.Err()
+			}
+			// Count the failure and carry on, so one poison event does not
+			// hold up the rest of the batch.
+			if err := r.recordFailure(ctx, event, err); err != nil {
+				r.release(ctx, events[i+1:])
+				return published, err
+			}
+			continue
 		}
 
 		if err := r.queries.MarkOutboxEventPublished(ctx, event.ID); err != nil {
-			return err
+			r.release(ctx, events[i+1:])
+			return published, err
 		}
+		published++
 	}
 
-	return nil
+	return published, nil
+}
+
+// recordFailure schedules the event's next attempt, or dead-letters it once
+// the retry policy is exhausted.
+func (r *Relay) recordFailure(ctx context.Context, event db.OutboxEvent, publishErr error) error {
+	attempts := int(event.Attempts) + 1
+	deadLetter := r.retry.Exhausted(attempts)
+
+	if deadLetter {
+		slog.ErrorContext(ctx, "outbox event dead-lettered",
+			slog.String("event_id", event.ID.String()), slog.String("event", event.EventName),
+			slog.Int("attempts", attempts), slog.Any("error", publishErr))
+	} else {
+		slog.WarnContext(ctx, "outbox event publish failed; will retry",
+			slog.String("event_id", event.ID.String()), slog.String("event", event.EventName),
+			slog.Int("attempts", attempts), slog.Any("error", publishErr))
+	}
+
+	return r.queries.RecordOutboxEventFailure(ctx, db.RecordOutboxEventFailureParams{
+		LastError:  publishErr.Error(),
+		BackoffMs:  r.retry.Backoff(attempts).Milliseconds(),
+		DeadLetter: deadLetter,
+		ID:         event.ID,
+		RelayID:    r.id,
+	})
+}
+
+// release gives up the leases of events this relay did not publish. Failing
+// to release is harmless: the lease simply runs out.
+func (r *Relay) release(ctx context.Context, events []db.OutboxEvent) {
+	// Release even when ctx was cancelled (e.g. shutdown mid-batch).
+	ctx = context.WithoutCancel(ctx)
+	for _, event := range events {
+		if err := r.queries.ReleaseOutboxEventClaim(ctx, db.ReleaseOutboxEventClaimParams{
+			ID:      event.ID,
+			RelayID: r.id,
+		}); err != nil {
+			slog.WarnContext(ctx, "failed to release outbox lease",
+				slog.String("event_id", event.ID.String()), slog.Any("error", err))
+		}
+	}
 }
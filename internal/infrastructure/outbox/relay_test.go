@@ -0,0 +1,334 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+// recordingPublisher counts how often each event payload was published and
+// remembers the order.
+type recordingPublisher struct {
+	mu        sync.Mutex
+	published map[string]int
+	order     []string
+	failOn    string
+}
+
+func newRecordingPublisher() *recordingPublisher {
+	return &recordingPublisher{published: map[string]int{}}
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event Event) error {
+	payload := event.Data
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if string(payload) == p.failOn {
+		return errors.New("broker unavailable")
+	}
+	p.published[string(payload)]++
+	p.order = append(p.order, string(payload))
+	return nil
+}
+
+// failingPublisher rejects every event.
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, event Event) error {
+	return errors.New("broker unavailable")
+}
+
+// insertTestEvents inserts count events, each for its own aggregate.
+func insertTestEvents(t *testing.T, queries *db.Queries, count int) []string {
+	t.Helper()
+	payloads := make([]string, 0, count)
+	for range count {
+		payloads = append(payloads, insertTestEvent(t, queries, uuid.New()))
+	}
+	return payloads
+}
+
+func insertTestEvent(t *testing.T, queries *db.Queries, aggregateId uuid.UUID) string {
+	t.Helper()
+	id := uuid.Must(uuid.NewV7())
+	payload := fmt.Sprintf(`{"aggregate": %q, "id": %q}`, aggregateId, id)
+	require.NoError(t, queries.InsertOutboxEvent(context.Background(), db.InsertOutboxEventParams{
+		ID:            id,
+		AggregateID:   aggregateId,
+		EventName:     "test.event",
+		SchemaVersion: 1,
+		Payload:       []byte(payload),
+		OccurredAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}))
+	return payload
+}
+
+// runBatch relays one batch and returns the number of published events.
+func runBatch(t *testing.T, relay *Relay) int {
+	t.Helper()
+	published, err := relay.relayBatch(context.Background())
+	require.NoError(t, err)
+	return published
+}
+
+func countUnpublished(t *testing.T, queries *db.Queries) int {
+	t.Helper()
+	events, err := queries.GetUnpublishedOutboxEvents(context.Background(), 10_000)
+	require.NoError(t, err)
+	return len(events)
+}
+
+func TestRelay_ConcurrentRelaysPublishEachEventOnce(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	payloads := insertTestEvents(t, testDB.Queries, 500)
+	publisher := newRecordingPublisher()
+
+	// Several relays with small batches race for the same rows, like
+	// replicas of cmd/marketplace would. Together they run more batches
+	// than needed, so the tail end also exercises empty claims.
+	var wg sync.WaitGroup
+	for range 4 {
+		relay := NewRelay(testDB.Pool, publisher, time.Second, DefaultRetryPolicy())
+		relay.batchSize = 25
+		wg.Go(func() {
+			for range 10 {
+				_, err := relay.relayBatch(context.Background())
+				assert.NoError(t, err)
+			}
+		})
+	}
+	wg.Wait()
+	assert.Zero(t, countUnpublished(t, testDB.Queries))
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	require.Len(t, publisher.published, len(payloads))
+	for _, payload := range payloads {
+		assert.Equal(t, 1, publisher.published[payload], "event published more than once: %s", payload)
+	}
+}
+
+func TestRelay_SkipsEventsLeasedByAnotherRelay(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	insertTestEvents(t, testDB.Queries, 3)
+
+	// A relay that claimed the batch and then crashed.
+	claimed, err := testDB.Queries.ClaimOutboxEvents(ctx, db.ClaimOutboxEventsParams{
+		RelayID:   "crashed-relay",
+		LeaseMs:   (300 * time.Millisecond).Milliseconds(),
+		BatchSize: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, claimed, 3)
+
+	publisher := newRecordingPublisher()
+	relay := NewRelay(testDB.Pool, publisher, time.Second, DefaultRetryPolicy())
+
+	runBatch(t, relay)
+	assert.Empty(t, publisher.published, "live leases must be skipped")
+
+	// Once the lease expires the orphaned batch is picked up.
+	time.Sleep(400 * time.Millisecond)
+	runBatch(t, relay)
+	assert.Len(t, publisher.published, 3)
+	assert.Zero(t, countUnpublished(t, testDB.Queries))
+}
+
+func TestRelay_PublishFailureSchedulesRetryAndContinuesBatch(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	payloads := insertTestEvents(t, testDB.Queries, 3)
+
+	failing := newRecordingPublisher()
+	failing.failOn = payloads[1]
+	relay := NewRelay(testDB.Pool, failing, time.Second, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour})
+	runBatch(t, relay)
+
+	// The poison event does not hold up the events behind it.
+	assert.Equal(t, 1, failing.published[payloads[0]])
+	assert.Equal(t, 1, failing.published[payloads[2]])
+
+	events, err := testDB.Queries.GetUnpublishedOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	failed := events[0]
+
+	var attempts int32
+	var lastError pgtype.Text
+	var nextAttemptAt, claimedUntil pgtype.Timestamptz
+	require.NoError(t, testDB.Pool.QueryRow(ctx,
+		`SELECT attempts, last_error, next_attempt_at, claimed_until FROM outbox_events WHERE id = $1`, failed.ID).
+		Scan(&attempts, &lastError, &nextAttemptAt, &claimedUntil))
+	assert.EqualValues(t, 1, attempts)
+	assert.Equal(t, "broker unavailable", lastError.String)
+	assert.True(t, nextAttemptAt.Time.After(time.Now()), "retry is scheduled in the future")
+	assert.False(t, claimedUntil.Valid, "lease is released")
+
+	// Backing off: no relay picks the event up before next_attempt_at.
+	other := newRecordingPublisher()
+	runBatch(t, NewRelay(testDB.Pool, other, time.Second, DefaultRetryPolicy()))
+	assert.Empty(t, other.published)
+}
+
+func TestRelay_DeadLettersEventAfterMaxAttempts(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	payloads := insertTestEvents(t, testDB.Queries, 1)
+
+	failing := newRecordingPublisher()
+	failing.failOn = payloads[0]
+	relay := NewRelay(testDB.Pool, failing, time.Second, RetryPolicy{MaxAttempts: 2})
+	runBatch(t, relay)
+	runBatch(t, relay)
+
+	listed, err := testDB.Queries.ListDeadLetteredOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, int32(2), listed[0].Attempts)
+	assert.Equal(t, "broker unavailable", listed[0].LastError.String)
+	assert.Equal(t, 1, countUnpublished(t, testDB.Queries), "dead letters stay unpublished")
+
+	// Dead letters are not claimed again.
+	assert.Zero(t, runBatch(t, relay))
+	listed, err = testDB.Queries.ListDeadLetteredOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), listed[0].Attempts)
+}
+
+func TestRelay_DeliversEventsInOrderPerAggregate(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	// Interleave the events of three aggregates.
+	aggregates := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	expected := map[uuid.UUID][]string{}
+	for range 20 {
+		for _, aggregateId := range aggregates {
+			expected[aggregateId] = append(expected[aggregateId], insertTestEvent(t, testDB.Queries, aggregateId))
+		}
+	}
+
+	// A claim holds only the head event of each aggregate.
+	probe, err := testDB.Queries.ClaimOutboxEvents(context.Background(), db.ClaimOutboxEventsParams{
+		RelayID: "probe", LeaseMs: 1, BatchSize: 100,
+	})
+	require.NoError(t, err)
+	assert.Len(t, probe, len(aggregates))
+	time.Sleep(10 * time.Millisecond)
+
+	publisher := newRecordingPublisher()
+	var wg sync.WaitGroup
+	for range 4 {
+		relay := NewRelay(testDB.Pool, publisher, time.Second, DefaultRetryPolicy())
+		relay.batchSize = 2
+		wg.Go(func() {
+			for range 100 {
+				_, err := relay.relayBatch(context.Background())
+				assert.NoError(t, err)
+			}
+		})
+	}
+	wg.Wait()
+	require.Zero(t, countUnpublished(t, testDB.Queries))
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	for _, aggregateId := range aggregates {
+		var delivered []string
+		for _, payload := range publisher.order {
+			if slices.Contains(expected[aggregateId], payload) {
+				delivered = append(delivered, payload)
+			}
+		}
+		assert.Equal(t, expected[aggregateId], delivered, "aggregate %s delivered out of order", aggregateId)
+	}
+}
+
+func TestRelay_FailingEventHoldsBackItsAggregateOnly(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	blocked, other := uuid.New(), uuid.New()
+	head := insertTestEvent(t, testDB.Queries, blocked)
+	next := insertTestEvent(t, testDB.Queries, blocked)
+	unrelated := insertTestEvent(t, testDB.Queries, other)
+
+	publisher := newRecordingPublisher()
+	publisher.failOn = head
+	relay := NewRelay(testDB.Pool, publisher, time.Second, RetryPolicy{MaxAttempts: 2})
+
+	// Other aggregates keep flowing; the failing head blocks its successor
+	// while it is retried and after it is dead-lettered.
+	assert.Equal(t, 1, runBatch(t, relay))
+	assert.Equal(t, 1, publisher.published[unrelated])
+	assert.Zero(t, runBatch(t, relay))
+	assert.Zero(t, runBatch(t, relay))
+	assert.Zero(t, publisher.published[next])
+
+	listed, err := testDB.Queries.ListDeadLetteredOutboxEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	// Discarding the head releases the rest of the aggregate.
+	discarded, err := testDB.Queries.DiscardDeadLetteredOutboxEvent(ctx, listed[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), discarded)
+	assert.Equal(t, 1, runBatch(t, relay))
+	assert.Equal(t, 1, publisher.published[next])
+}
+
+func TestRelay_WakesOnNotificationWithoutWaitingForPoll(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	publisher := newRecordingPublisher()
+	relay := NewRelay(testDB.Pool, publisher, time.Hour, DefaultRetryPolicy())
+	done := make(chan struct{})
+	go func() {
+		relay.Start(ctx)
+		close(done)
+	}()
+
+	published := func(payload string) func() bool {
+		return func() bool {
+			publisher.mu.Lock()
+			defer publisher.mu.Unlock()
+			return publisher.published[payload] == 1
+		}
+	}
+
+	// With an hour-long poll only the notification can deliver these.
+	payload := insertTestEvent(t, testDB.Queries, uuid.New())
+	assert.Eventually(t, published(payload), 5*time.Second, 10*time.Millisecond)
+
+	payload = insertTestEvent(t, testDB.Queries, uuid.New())
+	assert.Eventually(t, published(payload), 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+}
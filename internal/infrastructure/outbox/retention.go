@@ -0,0 +1,135 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+)
+
+// RetentionMode is what happens to published events once they expire.
+type RetentionMode string
+
+const (
+	// ArchiveMode moves them to outbox_events_archive.
+	ArchiveMode RetentionMode = "archive"
+	// DeleteMode drops them.
+	DeleteMode RetentionMode = "delete"
+)
+
+// ParseRetentionMode accepts "archive" and "delete".
+func ParseRetentionMode(s string) (RetentionMode, error) {
+	switch mode := RetentionMode(s); mode {
+	case ArchiveMode, DeleteMode:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown outbox retention mode %q (want archive or delete)", s)
+	}
+}
+
+// Retention keeps outbox_events small by archiving or deleting events that
+// were published more than retain ago. It runs safely next to any number of
+// relays (and other retention workers): it only touches published rows,
+// which relays never claim again, and works in short batches with SKIP
+// LOCKED so it never holds many locks or waits on anyone.
+//
+// It prunes webhook_deliveries the same way: deliveries that were delivered
+// or failed for good more than retain ago are deleted whatever the mode, as
+// their payload is the event itself. Pending deliveries are kept.
+type Retention struct {
+	queries   *db.Queries
+	retain    time.Duration
+	interval  time.Duration
+	mode      RetentionMode
+	batchSize int32
+}
+
+func NewRetention(queries *db.Queries, retain, interval time.Duration, mode RetentionMode) *Retention {
+	return &Retention{
+		queries:   queries,
+		retain:    retain,
+		interval:  interval,
+		mode:      mode,
+		batchSize: 1000,
+	}
+}
+
+// Start runs a pass right away and then every interval, until ctx is
+// cancelled.
+func (r *Retention) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := r.Run(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "outbox retention failed", slog.Any("error", err), slog.Int64("removed", removed))
+		} else if removed > 0 {
+			slog.InfoContext(ctx, "outbox retention removed published events",
+				slog.Int64("removed", removed), slog.String("mode", string(r.mode)))
+		}
+
+		deleted, err := r.RunWebhookDeliveries(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "webhook delivery retention failed", slog.Any("error", err), slog.Int64("removed", deleted))
+		} else if deleted > 0 {
+			slog.InfoContext(ctx, "outbox retention removed finished webhook deliveries", slog.Int64("removed", deleted))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run removes every event published before now-retain, batch by batch, and
+// reports how many it removed (also when it fails halfway).
+func (r *Retention) Run(ctx context.Context) (int64, error) {
+	return r.drain(ctx, r.removeBatch)
+}
+
+// RunWebhookDeliveries deletes every webhook delivery that finished before
+// now-retain, like Run.
+func (r *Retention) RunWebhookDeliveries(ctx context.Context) (int64, error) {
+	return r.drain(ctx, func(ctx context.Context, before pgtype.Timestamptz) (int64, error) {
+		return r.queries.DeleteFinishedWebhookDeliveries(ctx, db.DeleteFinishedWebhookDeliveriesParams{
+			FinishedBefore: before,
+			BatchSize:      r.batchSize,
+		})
+	})
+}
+
+func (r *Retention) drain(ctx context.Context, removeBatch func(ctx context.Context, before pgtype.Timestamptz) (int64, error)) (int64, error) {
+	before := pgtype.Timestamptz{Time: time.Now().Add(-r.retain), Valid: true}
+
+	var total int64
+	for ctx.Err() == nil {
+		removed, err := removeBatch(ctx, before)
+		total += removed
+		if err != nil {
+			return total, err
+		}
+		if removed < int64(r.batchSize) {
+			break
+		}
+	}
+	return total, ctx.Err()
+}
+
+func (r *Retention) removeBatch(ctx context.Context, before pgtype.Timestamptz) (int64, error) {
+	if r.mode == DeleteMode {
+		return r.queries.DeletePublishedOutboxEvents(ctx, db.DeletePublishedOutboxEventsParams{
+			PublishedBefore: before,
+			BatchSize:       r.batchSize,
+		})
+	}
+	return r.queries.ArchivePublishedOutboxEvents(ctx, db.ArchivePublishedOutboxEventsParams{
+		PublishedBefore: before,
+		BatchSize:       r.batchSize,
+	})
+}
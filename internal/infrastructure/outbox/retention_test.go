@@ -0,0 +1,171 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+func TestParseRetentionMode(t *testing.T) {
+	mode, err := ParseRetentionMode("delete")
+	require.NoError(t, err)
+	assert.Equal(t, DeleteMode, mode)
+
+	_, err = ParseRetentionMode("truncate")
+	assert.Error(t, err)
+}
+
+// ageRetentionTestEvents publishes expired+fresh events and backdates the
+// publication of the first expired ones by 30 days. It also leaves an old dead letter and an
+// old pending event, which retention must never touch.
+func ageRetentionTestEvents(t *testing.T, testDB *testhelpers.PostgresTestContainer, expired, fresh int) {
+	t.Helper()
+	ctx := context.Background()
+
+	insertTestEvents(t, testDB.Queries, expired+fresh)
+	runBatch(t, NewRelay(testDB.Pool, newRecordingPublisher(), time.Second, DefaultRetryPolicy()))
+	_, err := testDB.Pool.Exec(ctx, `
+		UPDATE outbox_events SET published_at = NOW() - INTERVAL '30 days'
+		WHERE id IN (SELECT id FROM outbox_events ORDER BY sequence_number LIMIT $1)`, expired)
+	require.NoError(t, err)
+
+	insertTestEvents(t, testDB.Queries, 1)
+	runBatch(t, NewRelay(testDB.Pool, failingPublisher{}, time.Second, RetryPolicy{MaxAttempts: 1}))
+	insertTestEvent(t, testDB.Queries, uuid.New())
+	_, err = testDB.Pool.Exec(ctx, `UPDATE outbox_events SET occurred_at = NOW() - INTERVAL '30 days' WHERE published_at IS NULL`)
+	require.NoError(t, err)
+}
+
+func countRows(t *testing.T, testDB *testhelpers.PostgresTestContainer, table string) int {
+	t.Helper()
+	var count int
+	require.NoError(t, testDB.Pool.QueryRow(context.Background(), "SELECT count(*) FROM "+table).Scan(&count))
+	return count
+}
+
+func TestRetention_ArchivesExpiredPublishedEventsInBatches(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	ageRetentionTestEvents(t, testDB, 5, 3)
+
+	retention := NewRetention(testDB.Queries, 7*24*time.Hour, time.Hour, ArchiveMode)
+	retention.batchSize = 2
+	removed, err := retention.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5), removed)
+	assert.Equal(t, 5, countRows(t, testDB, "outbox_events_archive"))
+	assert.Equal(t, 5, countRows(t, testDB, "outbox_events"), "fresh, pending and dead-lettered events stay")
+	assert.Equal(t, 2, countUnpublished(t, testDB.Queries))
+
+	var archivedPublishedAt int
+	require.NoError(t, testDB.Pool.QueryRow(context.Background(),
+		`SELECT count(*) FROM outbox_events_archive WHERE published_at < NOW() - INTERVAL '29 days'`).Scan(&archivedPublishedAt))
+	assert.Equal(t, 5, archivedPublishedAt)
+
+	removed, err = retention.Run(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
+
+func TestRetention_DeleteModeKeepsNoArchive(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+
+	ageRetentionTestEvents(t, testDB, 2, 1)
+
+	removed, err := NewRetention(testDB.Queries, 7*24*time.Hour, time.Hour, DeleteMode).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), removed)
+	assert.Zero(t, countRows(t, testDB, "outbox_events_archive"))
+	assert.Equal(t, 3, countRows(t, testDB, "outbox_events"))
+}
+
+func TestRetention_RunsAlongsideRelay(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ageRetentionTestEvents(t, testDB, 50, 0)
+	payloads := insertTestEvents(t, testDB.Queries, 200)
+
+	publisher := newRecordingPublisher()
+	relay := NewRelay(testDB.Pool, publisher, time.Second, DefaultRetryPolicy())
+	relay.batchSize = 10
+	retention := NewRetention(testDB.Queries, 0, time.Hour, ArchiveMode)
+	retention.batchSize = 5
+
+	// With zero retention every published event is fair game the moment it
+	// is marked, so the worker races the relay row for row.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ctx.Err() == nil {
+			_, err := retention.Run(ctx)
+			if ctx.Err() == nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	for runBatch(t, relay) > 0 {
+	}
+	cancel()
+	<-done
+
+	_, err := retention.Run(context.Background())
+	require.NoError(t, err)
+
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	for _, payload := range payloads {
+		assert.Equal(t, 1, publisher.published[payload], "event lost or duplicated: %s", payload)
+	}
+	// The old pending event was published (and archived) along the way.
+	assert.Equal(t, 251, countRows(t, testDB, "outbox_events_archive"))
+	assert.Equal(t, 1, countRows(t, testDB, "outbox_events"), "only the dead letter stays")
+}
+
+func TestRetention_DeletesFinishedWebhookDeliveries(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	subscriptionId := uuid.New()
+	_, err := testDB.Pool.Exec(ctx,
+		`INSERT INTO webhook_subscriptions (id, url, event_types, secret) VALUES ($1, 'https://partner.example/hooks', '{*}', '0123456789abcdef')`,
+		subscriptionId)
+	require.NoError(t, err)
+	for _, finished := range []string{
+		"delivered_at = NOW() - INTERVAL '30 days'",
+		"failed_at = NOW() - INTERVAL '30 days'",
+		"delivered_at = NOW() - INTERVAL '30 days'",
+		"delivered_at = NOW()",
+		"created_at = NOW() - INTERVAL '30 days'", // still pending
+	} {
+		id := uuid.New()
+		_, err := testDB.Pool.Exec(ctx,
+			`INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_name, payload) VALUES ($1, $2, $3, 'product.created', '{}')`,
+			id, subscriptionId, uuid.New())
+		require.NoError(t, err)
+		_, err = testDB.Pool.Exec(ctx, `UPDATE webhook_deliveries SET `+finished+` WHERE id = $1`, id)
+		require.NoError(t, err)
+	}
+
+	retention := NewRetention(testDB.Queries, 7*24*time.Hour, time.Hour, ArchiveMode)
+	retention.batchSize = 2
+	removed, err := retention.RunWebhookDeliveries(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), removed)
+	assert.Equal(t, 2, countRows(t, testDB, "webhook_deliveries"), "fresh and pending deliveries stay")
+	assert.Zero(t, countRows(t, testDB, "outbox_events_archive"), "deliveries are never archived")
+}
@@ -0,0 +1,50 @@
+package outbox
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls how often a failing event is retried before it is
+// dead-lettered, and how long the relay waits between attempts.
+type RetryPolicy struct {
+	// MaxAttempts is the number of failed publishes after which the event is
+	// dead-lettered. Values below 1 are treated as 1.
+	MaxAttempts int
+	// BaseDelay is the wait after the first failure; it doubles per attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential growth.
+	MaxDelay time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 10,
+		BaseDelay:   time.Second,
+		MaxDelay:    10 * time.Minute,
+	}
+}
+
+// Exhausted reports whether the failure that just happened was the last
+// attempt allowed. attempts counts failures including the current one.
+func (p RetryPolicy) Exhausted(attempts int) bool {
+	return attempts >= max(p.MaxAttempts, 1)
+}
+
+// Backoff returns the wait before the next attempt after the given number
+// of failures: BaseDelay * 2^(attempts-1), capped at MaxDelay, with "equal
+// jitter" (half fixed, half random) so events that failed together, e.g.
+// during a broker outage, do not all retry in the same instant.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxDelay)
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
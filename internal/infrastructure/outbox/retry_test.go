@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_BackoffGrowsExponentiallyWithinBounds(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+	tests := []struct {
+		attempts int
+		ceiling  time.Duration
+	}{
+		{attempts: 1, ceiling: time.Second},
+		{attempts: 2, ceiling: 2 * time.Second},
+		{attempts: 3, ceiling: 4 * time.Second},
+		{attempts: 5, ceiling: 16 * time.Second},
+		{attempts: 6, ceiling: 30 * time.Second},
+		{attempts: 60, ceiling: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		for range 50 {
+			delay := policy.Backoff(tt.attempts)
+			assert.GreaterOrEqual(t, delay, tt.ceiling/2, "attempt %d", tt.attempts)
+			assert.LessOrEqual(t, delay, tt.ceiling, "attempt %d", tt.attempts)
+		}
+	}
+}
+
+func TestRetryPolicy_ZeroDelay(t *testing.T) {
+	assert.Zero(t, RetryPolicy{MaxAttempts: 3}.Backoff(2))
+}
+
+func TestRetryPolicy_Exhausted(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3}
+	assert.False(t, policy.Exhausted(2))
+	assert.True(t, policy.Exhausted(3))
+	assert.True(t, RetryPolicy{}.Exhausted(1), "a non-positive limit still allows one attempt")
+}
@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+)
+
+const (
+	// defaultLease must exceed the HTTP client timeout, since a batch is
+	// delivered concurrently.
+	defaultLease = 30 * time.Second
+	contentType  = "application/cloudevents+json"
+	userAgent    = "go-ddd-webhooks/1.0"
+)
+
+// Dispatcher sends pending webhook deliveries: signed POSTs with the
+// CloudEvent as body. Every delivery is retried on its own with backoff per
+// the RetryPolicy and marked failed once it is exhausted; any 2xx response
+// counts as delivered. Like the outbox relay, several dispatchers can run
+// side by side because deliveries are leased with SKIP LOCKED.
+type Dispatcher struct {
+	queries   *db.Queries
+	client    *http.Client
+	interval  time.Duration
+	batchSize int32
+	lease     time.Duration
+	retry     outbox.RetryPolicy
+	id        string
+	wake      chan struct{}
+}
+
+func NewDispatcher(queries *db.Queries, client *http.Client, interval time.Duration, retry outbox.RetryPolicy) *Dispatcher {
+	return &Dispatcher{
+		queries:   queries,
+		client:    client,
+		interval:  interval,
+		batchSize: 20,
+		lease:     defaultLease,
+		retry:     retry,
+		id:        newDispatcherId(),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+func newDispatcherId() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "webhooks"
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8])
+}
+
+// Wake makes a running dispatcher look for due deliveries right away.
+func (d *Dispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start blocks until ctx is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+			d.drain(ctx)
+		case <-ticker.C:
+			d.drain(ctx)
+		}
+	}
+}
+
+func (d *Dispatcher) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := d.dispatchBatch(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "webhook dispatch batch failed", slog.Any("error", err))
+			return
+		}
+		if claimed == 0 {
+			return
+		}
+	}
+}
+
+// dispatchBatch claims due deliveries, sends them concurrently and reports
+// how many it claimed.
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	deliveries, err := d.queries.ClaimWebhookDeliveries(ctx, db.ClaimWebhookDeliveriesParams{
+		DispatcherID: d.id,
+		LeaseMs:      d.lease.Milliseconds(),
+		BatchSize:    d.batchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Go(func() {
+			if err := d.deliver(ctx, delivery); err != nil {
+				slog.ErrorContext(ctx, "failed to record webhook delivery outcome",
+					slog.String("delivery_id", delivery.ID.String()), slog.Any("error", err))
+			}
+		})
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver sends one delivery and records the outcome. The returned error is
+// about recording, not about the endpoint.
+func (d *Dispatcher) deliver(ctx context.Context, delivery db.WebhookDelivery) error {
+	subscription, err := d.queries.GetWebhookSubscription(ctx, delivery.SubscriptionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Deleted meanwhile; its deliveries went with it.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	statusCode, sendErr := d.send(ctx, subscription, delivery)
+	if sendErr == nil {
+		return d.queries.MarkWebhookDelivered(ctx, db.MarkWebhookDeliveredParams{
+			StatusCode:   int32(statusCode),
+			ID:           delivery.ID,
+			DispatcherID: d.id,
+		})
+	}
+	if ctx.Err() != nil {
+		// Shutting down; the lease runs out and another attempt follows.
+		return nil
+	}
+
+	attempts := int(delivery.Attempts) + 1
+	giveUp := d.retry.Exhausted(attempts)
+	slog.WarnContext(ctx, "webhook delivery failed",
+		slog.String("delivery_id", delivery.ID.String()), slog.String("subscription_id", subscription.ID.String()),
+		slog.String("event", delivery.EventName), slog.Int("attempts", attempts), slog.Bool("gave_up", giveUp),
+		slog.Any("error", sendErr))
+
+	return d.queries.RecordWebhookDeliveryFailure(ctx, db.RecordWebhookDeliveryFailureParams{
+		StatusCode:   pgtype.Int4{Int32: int32(statusCode), Valid: statusCode != 0},
+		LastError:    sendErr.Error(),
+		BackoffMs:    d.retry.Backoff(attempts).Milliseconds(),
+		GiveUp:       giveUp,
+		ID:           delivery.ID,
+		DispatcherID: d.id,
+	})
+}
+
+// send POSTs the signed payload and returns the response status, or 0 if
+// there was no response.
+func (d *Dispatcher) send(ctx context.Context, subscription db.WebhookSubscription, delivery db.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(IdHeader, delivery.EventID.String())
+	req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, now, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
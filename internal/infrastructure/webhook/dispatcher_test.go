@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/db/postgres"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+	"github.com/sklinkert/go-ddd/internal/testhelpers"
+)
+
+const testSecret = "0123456789abcdef"
+
+// endpoint is a partner endpoint that records what it receives.
+type endpoint struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newEndpoint(t *testing.T, status int) *endpoint {
+	t.Helper()
+	e := &endpoint{status: status}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.requests = append(e.requests, r)
+		e.bodies = append(e.bodies, body)
+		w.WriteHeader(e.status)
+	}))
+	t.Cleanup(e.Close)
+	return e
+}
+
+func (e *endpoint) received() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.requests)
+}
+
+func testEvent(name string) outbox.Event {
+	return outbox.Event{
+		Id:            uuid.Must(uuid.NewV7()),
+		AggregateId:   uuid.New(),
+		Name:          name,
+		SchemaVersion: 1,
+		Data:          []byte(`{"name":"Widget"}`),
+		OccurredAt:    time.Now(),
+	}
+}
+
+func subscribe(t *testing.T, subscriptions repositories.WebhookSubscriptionRepository, url string, eventTypes []string) *entities.WebhookSubscription {
+	t.Helper()
+	subscription, err := entities.NewWebhookSubscription(url, eventTypes, testSecret)
+	require.NoError(t, err)
+	require.NoError(t, subscriptions.Create(context.Background(), subscription))
+	return subscription
+}
+
+func dispatch(t *testing.T, dispatcher *Dispatcher) int {
+	t.Helper()
+	claimed, err := dispatcher.dispatchBatch(context.Background())
+	require.NoError(t, err)
+	return claimed
+}
+
+func TestWebhooks_DeliversSignedEventToMatchingSubscriptions(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	products, sellers := newEndpoint(t, http.StatusOK), newEndpoint(t, http.StatusOK)
+	subscriptions := postgres.NewSqlcWebhookSubscriptionRepository(testDB.Queries)
+	productSubscription := subscribe(t, subscriptions, products.URL, []string{"product.*"})
+	subscribe(t, subscriptions, sellers.URL, []string{"seller.created"})
+
+	dispatcher := NewDispatcher(testDB.Queries, http.DefaultClient, time.Second, outbox.DefaultRetryPolicy())
+	publisher := NewPublisher(testDB.Queries, "/go-ddd/marketplace", dispatcher)
+	event := testEvent("product.created")
+	require.NoError(t, publisher.Publish(ctx, event))
+	// The outbox is at-least-once; a republished event is not delivered twice.
+	require.NoError(t, publisher.Publish(ctx, event))
+
+	assert.Equal(t, 1, dispatch(t, dispatcher))
+	assert.Zero(t, dispatch(t, dispatcher))
+	require.Equal(t, 1, products.received())
+	assert.Zero(t, sellers.received())
+
+	req, body := products.requests[0], products.bodies[0]
+	assert.Equal(t, "application/cloudevents+json", req.Header.Get("Content-Type"))
+	assert.Equal(t, event.Id.String(), req.Header.Get(IdHeader))
+	assert.NoError(t, Verify(testSecret, req.Header.Get(TimestampHeader), req.Header.Get(SignatureHeader), body, time.Minute, time.Now()))
+
+	var ce outbox.CloudEvent
+	require.NoError(t, json.Unmarshal(body, &ce))
+	assert.Equal(t, event.Id.String(), ce.Id)
+	assert.Equal(t, "io.github.sklinkert.goddd.product.created.v1", ce.Type)
+	assert.JSONEq(t, string(event.Data), string(ce.Data))
+
+	deliveries, err := subscriptions.FindDeliveries(ctx, productSubscription.Id, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entities.WebhookDeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusOK, deliveries[0].LastStatusCode)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+}
+
+func TestWebhooks_RetriesEachSubscriptionIndependently(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	broken, healthy := newEndpoint(t, http.StatusInternalServerError), newEndpoint(t, http.StatusNoContent)
+	subscriptions := postgres.NewSqlcWebhookSubscriptionRepository(testDB.Queries)
+	brokenSubscription := subscribe(t, subscriptions, broken.URL, []string{"*"})
+	healthySubscription := subscribe(t, subscriptions, healthy.URL, []string{"*"})
+
+	dispatcher := NewDispatcher(testDB.Queries, http.DefaultClient, time.Second, outbox.RetryPolicy{MaxAttempts: 2})
+	require.NoError(t, NewPublisher(testDB.Queries, "/go-ddd/marketplace", nil).Publish(ctx, testEvent("seller.created")))
+
+	assert.Equal(t, 2, dispatch(t, dispatcher))
+	assert.Equal(t, 1, dispatch(t, dispatcher), "only the failed delivery is retried")
+	assert.Zero(t, dispatch(t, dispatcher), "no retries once the policy is exhausted")
+	assert.Equal(t, 2, broken.received())
+	assert.Equal(t, 1, healthy.received())
+
+	deliveries, err := subscriptions.FindDeliveries(ctx, brokenSubscription.Id, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entities.WebhookDeliveryFailed, deliveries[0].Status)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].LastStatusCode)
+	assert.Contains(t, deliveries[0].LastError, "500")
+
+	deliveries, err = subscriptions.FindDeliveries(ctx, healthySubscription.Id, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entities.WebhookDeliveryDelivered, deliveries[0].Status)
+}
+
+func TestWebhooks_UnreachableEndpointBacksOff(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	gone := newEndpoint(t, http.StatusOK)
+	gone.Close()
+	subscriptions := postgres.NewSqlcWebhookSubscriptionRepository(testDB.Queries)
+	subscription := subscribe(t, subscriptions, gone.URL, []string{"product.created"})
+
+	dispatcher := NewDispatcher(testDB.Queries, http.DefaultClient, time.Second, outbox.DefaultRetryPolicy())
+	require.NoError(t, NewPublisher(testDB.Queries, "/go-ddd/marketplace", nil).Publish(ctx, testEvent("product.created")))
+
+	assert.Equal(t, 1, dispatch(t, dispatcher))
+	assert.Zero(t, dispatch(t, dispatcher), "the retry waits for its backoff")
+
+	deliveries, err := subscriptions.FindDeliveries(ctx, subscription.Id, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entities.WebhookDeliveryPending, deliveries[0].Status)
+	assert.Zero(t, deliveries[0].LastStatusCode)
+	assert.True(t, deliveries[0].NextAttemptAt.After(time.Now()))
+}
+
+func TestWebhooks_DeletedSubscriptionDropsPendingDeliveries(t *testing.T) {
+	testDB := testhelpers.SetupTestDB(t)
+	defer testDB.Close(t)
+	ctx := context.Background()
+
+	subscriptions := postgres.NewSqlcWebhookSubscriptionRepository(testDB.Queries)
+	subscription := subscribe(t, subscriptions, "https://partner.example/hooks", []string{"*"})
+	require.NoError(t, NewPublisher(testDB.Queries, "/go-ddd/marketplace", nil).Publish(ctx, testEvent("product.created")))
+
+	listed, err := subscriptions.FindAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, []string{"*"}, listed[0].EventTypes)
+
+	require.NoError(t, subscriptions.Delete(ctx, subscription.Id))
+	assert.ErrorIs(t, subscriptions.Delete(ctx, subscription.Id), entities.ErrWebhookSubscriptionNotFound)
+	_, err = subscriptions.FindDeliveries(ctx, subscription.Id, 10)
+	assert.ErrorIs(t, err, entities.ErrWebhookSubscriptionNotFound)
+
+	dispatcher := NewDispatcher(testDB.Queries, http.DefaultClient, time.Second, outbox.DefaultRetryPolicy())
+	assert.Zero(t, dispatch(t, dispatcher))
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	db "github.com/sklinkert/go-ddd/internal/infrastructure/db/sqlc"
+	"github.com/sklinkert/go-ddd/internal/infrastructure/outbox"
+)
+
+// Publisher is the outbox.Publisher for webhooks. It does not call anyone
+// itself: it fans the event out into one delivery per matching subscription
+// and leaves sending to the Dispatcher. That way a slow or broken partner
+// endpoint only retries its own delivery instead of holding up the outbox.
+type Publisher struct {
+	queries    *db.Queries
+	source     string
+	dispatcher *Dispatcher
+}
+
+// NewPublisher wraps events as structured CloudEvents from source. If
+// dispatcher is not nil it is woken up for new deliveries.
+func NewPublisher(queries *db.Queries, source string, dispatcher *Dispatcher) *Publisher {
+	return &Publisher{queries: queries, source: source, dispatcher: dispatcher}
+}
+
+func (p *Publisher) Publish(ctx context.Context, event outbox.Event) error {
+	body, err := json.Marshal(outbox.NewCloudEvent(p.source, event))
+	if err != nil {
+		return err
+	}
+
+	enqueued, err := p.queries.EnqueueWebhookDeliveries(ctx, db.EnqueueWebhookDeliveriesParams{
+		EventID:   event.Id,
+		EventName: event.Name,
+		Payload:   body,
+	})
+	if err != nil {
+		return err
+	}
+
+	if enqueued > 0 && p.dispatcher != nil {
+		p.dispatcher.Wake()
+	}
+	return nil
+}
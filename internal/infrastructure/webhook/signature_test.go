@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret := "0123456789abcdef"
+	body := []byte(`{"id":"1"}`)
+	now := time.Unix(1_780_000_000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	signature := Sign(secret, now, body)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+
+	assert.NoError(t, Verify(secret, timestamp, signature, body, 5*time.Minute, now.Add(time.Minute)))
+	assert.ErrorIs(t, Verify("another-secret-123", timestamp, signature, body, 5*time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, timestamp, signature, []byte(`{"id":"2"}`), 5*time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, "not-a-number", signature, body, 5*time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, timestamp, signature, body, 5*time.Minute, now.Add(10*time.Minute)), ErrStaleTimestamp)
+
+	// The timestamp is signed: moving it forward breaks the signature.
+	later := strconv.FormatInt(now.Unix()+60, 10)
+	assert.ErrorIs(t, Verify(secret, later, signature, body, 5*time.Minute, now), ErrInvalidSignature)
+}
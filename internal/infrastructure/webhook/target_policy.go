@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/domain/repositories"
+)
+
+// errForbiddenTarget is returned by the dialer of NewHTTPClient for
+// addresses the TargetPolicy rejects.
+var errForbiddenTarget = errors.New("webhook target address is not allowed")
+
+// TargetPolicy keeps webhooks away from the server's own network: hosts
+// that resolve to loopback, link-local, private or unspecified addresses
+// are rejected unless allowlisted. Allowlist entries are host names, IP
+// addresses or CIDR ranges, e.g. for a partner inside the same VPC.
+type TargetPolicy struct {
+	hosts    []string
+	prefixes []netip.Prefix
+	resolver *net.Resolver
+}
+
+// NewTargetPolicy fails on allowlist entries that look like an IP address
+// or CIDR range but do not parse.
+func NewTargetPolicy(allowlist []string) (*TargetPolicy, error) {
+	policy := &TargetPolicy{resolver: net.DefaultResolver}
+	for _, entry := range allowlist {
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			policy.prefixes = append(policy.prefixes, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			policy.prefixes = append(policy.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		if strings.ContainsAny(entry, "/:") {
+			return nil, fmt.Errorf("webhook allowlist entry %q is neither a host name, an IP address nor a CIDR range", entry)
+		}
+		policy.hosts = append(policy.hosts, strings.ToLower(entry))
+	}
+	return policy, nil
+}
+
+var _ repositories.WebhookTargetPolicy = (*TargetPolicy)(nil)
+
+func (p *TargetPolicy) Check(ctx context.Context, rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", entities.ErrValidation)
+	}
+	host := target.Hostname()
+	if p.allowsHost(host) {
+		return nil
+	}
+
+	addrs, err := p.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: url host %s cannot be resolved", entities.ErrValidation, host)
+	}
+	for _, addr := range addrs {
+		if !p.allowsAddr(addr) {
+			return fmt.Errorf("%w: url host %s resolves to %s, which webhooks must not reach", entities.ErrValidation, host, addr.Unmap())
+		}
+	}
+	return nil
+}
+
+// NewHTTPClient returns the client to deliver webhooks with. It checks
+// every address it connects to against the policy again, as DNS may
+// answer differently than when the subscription was created, and it does
+// not follow redirects: a 3xx counts as a failed delivery. Proxies from
+// the environment are not used, so the check sees the partner's address.
+func (p *TargetPolicy) NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: p.control}
+	allowlisted := &net.Dialer{Timeout: timeout}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if host, _, err := net.SplitHostPort(address); err == nil && p.allowsHost(host) {
+			return allowlisted.DialContext(ctx, network, address)
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// control runs after name resolution, right before each connection.
+func (p *TargetPolicy) control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !p.allowsAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errForbiddenTarget, addrPort.Addr().Unmap())
+	}
+	return nil
+}
+
+func (p *TargetPolicy) allowsHost(host string) bool {
+	return slices.Contains(p.hosts, strings.ToLower(host))
+}
+
+func (p *TargetPolicy) allowsAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return !addr.IsLoopback() && !addr.IsLinkLocalUnicast() && !addr.IsLinkLocalMulticast() &&
+		!addr.IsPrivate() && !addr.IsUnspecified()
+}
@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+func TestTargetPolicy_Check(t *testing.T) {
+	policy, err := NewTargetPolicy([]string{"10.1.0.0/16", "hooks.internal"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for _, rawURL := range []string{
+		"http://127.0.0.1:8080/hooks",
+		"http://[::1]/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://192.168.1.10/hooks",
+		"http://10.2.0.1/hooks",
+		"http://0.0.0.0/hooks",
+		"http://[::ffff:127.0.0.1]/hooks",
+	} {
+		assert.ErrorIs(t, policy.Check(ctx, rawURL), entities.ErrValidation, rawURL)
+	}
+
+	assert.NoError(t, policy.Check(ctx, "https://93.184.216.34/hooks"))
+	assert.NoError(t, policy.Check(ctx, "http://10.1.2.3/hooks"), "allowlisted range")
+	assert.NoError(t, policy.Check(ctx, "http://HOOKS.internal/hooks"), "allowlisted host, not resolved")
+
+	_, err = NewTargetPolicy([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestTargetPolicy_HTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/hooks", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	strict, err := NewTargetPolicy(nil)
+	require.NoError(t, err)
+	_, err = strict.NewHTTPClient(time.Second).Get(server.URL + "/hooks")
+	assert.ErrorIs(t, err, errForbiddenTarget, "a loopback address is refused when connecting")
+
+	allowed, err := NewTargetPolicy([]string{"127.0.0.1"})
+	require.NoError(t, err)
+	client := allowed.NewHTTPClient(time.Second)
+	response, err := client.Get(server.URL + "/hooks")
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+	response, err = client.Get(server.URL + "/moved")
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusFound, response.StatusCode, "redirects are not followed")
+}
@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+type CartController struct {
+	service interfaces.CartService
+}
+
+// NewCartController registers the buyer's cart. Carts are addressed by
+// buyer id; there is at most one per buyer. Item routes take an optional
+// ?variant_id= when the cart holds several variants of the product.
+func NewCartController(e *echo.Echo, service interfaces.CartService) *CartController {
+	controller := &CartController{service: service}
+
+	e.GET("/api/v1/carts/:buyer_id", controller.GetCartController)
+	e.POST("/api/v1/carts/:buyer_id/items", controller.AddCartItemController)
+	e.PUT("/api/v1/carts/:buyer_id/items/:product_id", controller.UpdateCartItemController)
+	e.DELETE("/api/v1/carts/:buyer_id/items/:product_id", controller.RemoveCartItemController)
+	e.POST("/api/v1/carts/:buyer_id/checkout", controller.CheckoutCartController)
+
+	return controller
+}
+
+func (cc *CartController) GetCartController(c echo.Context) error {
+	buyerId, err := uuid.Parse(c.Param("buyer_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid buyer Id format",
+		})
+	}
+
+	cart, err := cc.service.GetCart(c.Request().Context(), &query.GetCartQuery{BuyerId: buyerId})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch cart",
+		})
+	}
+
+	return cc.writeCart(c, cart.Result)
+}
+
+func (cc *CartController) AddCartItemController(c echo.Context) error {
+	buyerId, err := uuid.Parse(c.Param("buyer_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid buyer Id format",
+		})
+	}
+
+	var addCartItemRequest request.AddCartItemRequest
+	if err := c.Bind(&addCartItemRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	cartCommand, err := addCartItemRequest.ToAddCartItemCommand(buyerId)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	cartCommand.IdempotencyKey = idempotencyKey(c, cartCommand.IdempotencyKey)
+	if cartCommand.ExpectedVersion, err = expectedVersion(c); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := cc.service.AddCartItem(c.Request().Context(), cartCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to add cart item")
+	}
+
+	return cc.writeCart(c, result.Result)
+}
+
+func (cc *CartController) UpdateCartItemController(c echo.Context) error {
+	buyerId, err := uuid.Parse(c.Param("buyer_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid buyer Id format",
+		})
+	}
+	productId, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+	variantId, err := variantIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	var updateCartItemRequest request.UpdateCartItemRequest
+	if err := c.Bind(&updateCartItemRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	cartCommand := updateCartItemRequest.ToUpdateCartItemCommand(buyerId, productId, variantId)
+	cartCommand.IdempotencyKey = idempotencyKey(c, cartCommand.IdempotencyKey)
+	if cartCommand.ExpectedVersion, err = expectedVersion(c); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := cc.service.UpdateCartItem(c.Request().Context(), cartCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to update cart item")
+	}
+
+	return cc.writeCart(c, result.Result)
+}
+
+func (cc *CartController) RemoveCartItemController(c echo.Context) error {
+	buyerId, err := uuid.Parse(c.Param("buyer_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid buyer Id format",
+		})
+	}
+	productId, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+	variantId, err := variantIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := cc.service.RemoveCartItem(c.Request().Context(), &command.RemoveCartItemCommand{
+		IdempotencyKey:  idempotencyKey(c, ""),
+		BuyerId:         buyerId,
+		ProductId:       productId,
+		VariantId:       variantId,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to remove cart item")
+	}
+
+	return cc.writeCart(c, result.Result)
+}
+
+// CheckoutCartController answers 201 with the new order, or 409 if prices
+// or products changed; the cart then holds the current state for review.
+func (cc *CartController) CheckoutCartController(c echo.Context) error {
+	buyerId, err := uuid.Parse(c.Param("buyer_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid buyer Id format",
+		})
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := cc.service.CheckoutCart(c.Request().Context(), &command.CheckoutCartCommand{
+		IdempotencyKey:  idempotencyKey(c, ""),
+		BuyerId:         buyerId,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to check out cart")
+	}
+
+	response := mapper.ToOrderResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (cc *CartController) writeCart(c echo.Context, cart *common.CartResult) error {
+	response := mapper.ToCartResponse(cart)
+	// A cart that was never saved has no version to send back in If-Match.
+	if response.Version > 0 {
+		setETag(c, response.Version)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+type CategoryController struct {
+	service        interfaces.CategoryService
+	productService interfaces.ProductService
+}
+
+// NewCategoryController registers the category endpoints. Listing the
+// products of a category goes through the product service so it accepts
+// the same filters, sorting and paging as GET /api/v1/products.
+func NewCategoryController(e *echo.Echo, service interfaces.CategoryService, productService interfaces.ProductService) *CategoryController {
+	controller := &CategoryController{
+		service:        service,
+		productService: productService,
+	}
+
+	e.POST("/api/v1/categories", controller.CreateCategoryController)
+	e.GET("/api/v1/categories", controller.GetCategoriesController)
+	e.GET("/api/v1/categories/:id", controller.GetCategoryByIdController)
+	e.PUT("/api/v1/categories/:id", controller.UpdateCategoryController)
+	e.DELETE("/api/v1/categories/:id", controller.DeleteCategoryController)
+	e.GET("/api/v1/categories/:id/products", controller.GetCategoryProductsController)
+
+	return controller
+}
+
+func (cc *CategoryController) CreateCategoryController(c echo.Context) error {
+	var createCategoryRequest request.CreateCategoryRequest
+	if err := c.Bind(&createCategoryRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	categoryCommand, err := createCategoryRequest.ToCreateCategoryCommand()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	categoryCommand.IdempotencyKey = idempotencyKey(c, categoryCommand.IdempotencyKey)
+
+	result, err := cc.service.CreateCategory(c.Request().Context(), categoryCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to create category")
+	}
+
+	response := mapper.ToCategoryResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (cc *CategoryController) GetCategoriesController(c echo.Context) error {
+	var listCategoriesRequest request.ListCategoriesRequest
+	if err := c.Bind(&listCategoriesRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse query parameters",
+		})
+	}
+
+	categoriesQuery, err := listCategoriesRequest.ToGetCategoriesQuery()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	categories, err := cc.service.FindCategories(c.Request().Context(), categoriesQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch categories")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToCategoryListResponse(categories.Result))
+}
+
+func (cc *CategoryController) GetCategoryByIdController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid category Id format",
+		})
+	}
+
+	category, err := cc.service.FindCategoryById(c.Request().Context(), &query.GetCategoryByIdQuery{Id: id})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch category",
+		})
+	}
+
+	if category == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Category not found",
+		})
+	}
+
+	response := mapper.ToCategoryResponse(category.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (cc *CategoryController) UpdateCategoryController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid category Id format",
+		})
+	}
+
+	var updateCategoryRequest request.UpdateCategoryRequest
+	if err := c.Bind(&updateCategoryRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	categoryCommand, err := updateCategoryRequest.ToUpdateCategoryCommand(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	categoryCommand.IdempotencyKey = idempotencyKey(c, categoryCommand.IdempotencyKey)
+
+	categoryCommand.ExpectedVersion, err = expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := cc.service.UpdateCategory(c.Request().Context(), categoryCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to update category")
+	}
+
+	response := mapper.ToCategoryResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (cc *CategoryController) DeleteCategoryController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid category Id format",
+		})
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	_, err = cc.service.DeleteCategory(c.Request().Context(), &command.DeleteCategoryCommand{
+		IdempotencyKey:  idempotencyKey(c, ""),
+		Id:              id,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to delete category")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetCategoryProductsController lists the products in the category and all
+// of its descendants.
+func (cc *CategoryController) GetCategoryProductsController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid category Id format",
+		})
+	}
+
+	category, err := cc.service.FindCategoryById(c.Request().Context(), &query.GetCategoryByIdQuery{Id: id})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch category",
+		})
+	}
+
+	if category == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Category not found",
+		})
+	}
+
+	var listProductsRequest request.ListProductsRequest
+	if err := c.Bind(&listProductsRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse query parameters",
+		})
+	}
+	listProductsRequest.CategoryId = ""
+
+	productQuery, err := listProductsRequest.ToGetAllProductsQuery()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid seller Id format",
+		})
+	}
+	productQuery.CategoryId = id
+
+	products, err := cc.productService.FindAllProducts(c.Request().Context(), productQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch products")
+	}
+
+	response := mapper.ToProductListResponse(products.Result)
+	response.NextCursor = products.NextCursor
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToCartResponse(cart *common.CartResult) *response.CartResponse {
+	items := make([]*response.CartItemResponse, 0, len(cart.Lines))
+	for _, line := range cart.Lines {
+		items = append(items, &response.CartItemResponse{
+			ProductId:           line.ProductId.String(),
+			VariantId:           line.VariantId.String(),
+			Sku:                 line.Sku,
+			ProductName:         line.ProductName,
+			UnitPriceMinorUnits: line.UnitPrice.MinorUnits(),
+			Currency:            string(line.UnitPrice.Currency()),
+			Quantity:            line.Quantity,
+			TotalMinorUnits:     line.Total.MinorUnits(),
+		})
+	}
+
+	cartResponse := &response.CartResponse{
+		BuyerId:   cart.BuyerId.String(),
+		Items:     items,
+		UpdatedAt: cart.UpdatedAt,
+		ExpiresAt: cart.ExpiresAt,
+		Version:   cart.Version,
+	}
+	if !cart.Total.IsZero() {
+		cartResponse.Total = &response.MoneyResponse{MinorUnits: cart.Total.MinorUnits(), Currency: string(cart.Total.Currency())}
+	}
+
+	return cartResponse
+}
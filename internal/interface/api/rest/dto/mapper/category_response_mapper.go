@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToCategoryResponse(category *common.CategoryResult) *response.CategoryResponse {
+	ancestorIds := make([]string, 0, len(category.AncestorIds))
+	for _, ancestorId := range category.AncestorIds {
+		ancestorIds = append(ancestorIds, ancestorId.String())
+	}
+
+	categoryResponse := &response.CategoryResponse{
+		Id:          category.Id.String(),
+		Name:        category.Name,
+		AncestorIds: ancestorIds,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+		Version:     category.Version,
+	}
+	if category.ParentId != nil {
+		parentId := category.ParentId.String()
+		categoryResponse.ParentId = &parentId
+	}
+
+	return categoryResponse
+}
+
+func ToCategoryListResponse(categories []*common.CategoryResult) *response.ListCategoriesResponse {
+	responseList := make([]*response.CategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		responseList = append(responseList, ToCategoryResponse(category))
+	}
+	return &response.ListCategoriesResponse{Categories: responseList}
+}
@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToDeadLetterResponse(deadLetter *common.DeadLetterResult) *response.DeadLetterResponse {
+	return &response.DeadLetterResponse{
+		Id:             deadLetter.Id.String(),
+		AggregateId:    deadLetter.AggregateId.String(),
+		EventName:      deadLetter.EventName,
+		Payload:        deadLetter.Payload,
+		OccurredAt:     deadLetter.OccurredAt,
+		Attempts:       deadLetter.Attempts,
+		LastError:      deadLetter.LastError,
+		DeadLetteredAt: deadLetter.DeadLetteredAt,
+	}
+}
+
+func ToDeadLetterListResponse(deadLetters []*common.DeadLetterResult) *response.ListDeadLettersResponse {
+	responseList := make([]*response.DeadLetterResponse, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		responseList = append(responseList, ToDeadLetterResponse(deadLetter))
+	}
+	return &response.ListDeadLettersResponse{DeadLetters: responseList}
+}
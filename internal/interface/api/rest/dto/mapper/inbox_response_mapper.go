@@ -0,0 +1,13 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToReceiveInboxMessageResponse(receiveCommand *command.ReceiveInboxMessageCommand, result *command.ReceiveInboxMessageCommandResult) *response.ReceiveInboxMessageResponse {
+	return &response.ReceiveInboxMessageResponse{
+		Id:        receiveCommand.MessageId,
+		Duplicate: result.Duplicate,
+	}
+}
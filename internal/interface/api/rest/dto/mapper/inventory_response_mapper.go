@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToInventoryResponse(inventory *common.InventoryResult) *response.InventoryResponse {
+	reservations := make([]*response.ReservationResponse, 0, len(inventory.Reservations))
+	for _, reservation := range inventory.Reservations {
+		reservations = append(reservations, &response.ReservationResponse{
+			Id:        reservation.Id.String(),
+			Quantity:  reservation.Quantity,
+			ExpiresAt: reservation.ExpiresAt,
+		})
+	}
+
+	return &response.InventoryResponse{
+		ProductId:    inventory.ProductId.String(),
+		VariantId:    inventory.VariantId.String(),
+		OnHand:       inventory.OnHand,
+		Reserved:     inventory.Reserved,
+		Available:    inventory.Available,
+		Reservations: reservations,
+		UpdatedAt:    inventory.UpdatedAt,
+		Version:      inventory.Version,
+	}
+}
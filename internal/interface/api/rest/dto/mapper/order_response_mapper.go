@@ -0,0 +1,47 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToOrderResponse(order *common.OrderResult) *response.OrderResponse {
+	items := make([]*response.OrderItemResponse, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, &response.OrderItemResponse{
+			ProductId:           item.ProductId.String(),
+			VariantId:           item.VariantId.String(),
+			Sku:                 item.Sku,
+			ProductName:         item.ProductName,
+			UnitPriceMinorUnits: item.UnitPrice.MinorUnits(),
+			Currency:            string(item.UnitPrice.Currency()),
+			Quantity:            item.Quantity,
+			TotalMinorUnits:     item.Total.MinorUnits(),
+		})
+	}
+
+	totals := make([]response.MoneyResponse, 0, len(order.Totals))
+	for _, total := range order.Totals {
+		totals = append(totals, response.MoneyResponse{MinorUnits: total.MinorUnits(), Currency: string(total.Currency())})
+	}
+
+	return &response.OrderResponse{
+		Id:             order.Id.String(),
+		BuyerId:        order.BuyerId.String(),
+		Status:         order.Status,
+		Items:          items,
+		Totals:         totals,
+		TrackingNumber: order.TrackingNumber,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+		Version:        order.Version,
+	}
+}
+
+func ToOrderListResponse(orders []*common.OrderResult) *response.ListOrdersResponse {
+	responseList := make([]*response.OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		responseList = append(responseList, ToOrderResponse(order))
+	}
+	return &response.ListOrdersResponse{Orders: responseList}
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToOutboxReplayResponse(replay *common.OutboxReplayResult) *response.OutboxReplayResponse {
+	replayResponse := &response.OutboxReplayResponse{
+		Id:          replay.Id.String(),
+		Target:      replay.Target,
+		EventName:   replay.EventName,
+		From:        replay.From,
+		To:          replay.To,
+		Status:      replay.Status,
+		Total:       replay.Total,
+		Replayed:    replay.Replayed,
+		LastError:   replay.LastError,
+		CreatedAt:   replay.CreatedAt,
+		UpdatedAt:   replay.UpdatedAt,
+		CompletedAt: replay.CompletedAt,
+	}
+	if replay.AggregateId != nil {
+		replayResponse.AggregateId = replay.AggregateId.String()
+	}
+	return replayResponse
+}
+
+func ToOutboxReplayListResponse(replays []*common.OutboxReplayResult) *response.ListOutboxReplaysResponse {
+	responseList := make([]*response.OutboxReplayResponse, 0, len(replays))
+	for _, replay := range replays {
+		responseList = append(responseList, ToOutboxReplayResponse(replay))
+	}
+	return &response.ListOutboxReplaysResponse{Replays: responseList}
+}
@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToPriceHistoryResponse(productId uuid.UUID, history *query.GetPriceHistoryQueryResult) *response.PriceHistoryResponse {
+	prices := make([]*response.PricePointResponse, 0, len(history.Result))
+	for _, point := range history.Result {
+		pricePoint := toPricePointResponse(point)
+		prices = append(prices, &pricePoint)
+	}
+
+	return &response.PriceHistoryResponse{
+		ProductId: productId.String(),
+		From:      history.From,
+		To:        history.To,
+		Prices:    prices,
+	}
+}
+
+func ToLowestPriceResponse(productId uuid.UUID, lowest *query.GetLowestPriceQueryResult) *response.LowestPriceResponse {
+	return &response.LowestPriceResponse{
+		ProductId:          productId.String(),
+		From:               lowest.From,
+		To:                 lowest.To,
+		PricePointResponse: toPricePointResponse(lowest.Result),
+	}
+}
+
+func toPricePointResponse(point *common.PricePointResult) response.PricePointResponse {
+	return response.PricePointResponse{
+		PriceMinorUnits: point.Price.MinorUnits(),
+		Currency:        string(point.Price.Currency()),
+		EffectiveAt:     point.EffectiveAt,
+	}
+}
+
+func ToScheduledPriceChangeResponse(change *common.ScheduledPriceChangeResult) *response.ScheduledPriceChangeResponse {
+	return &response.ScheduledPriceChangeResponse{
+		Id:              change.Id.String(),
+		ProductId:       change.ProductId.String(),
+		PriceMinorUnits: change.Price.MinorUnits(),
+		Currency:        string(change.Price.Currency()),
+		EffectiveAt:     change.EffectiveAt,
+		CreatedAt:       change.CreatedAt,
+	}
+}
+
+func ToListScheduledPriceChangesResponse(changes []*common.ScheduledPriceChangeResult) *response.ListScheduledPriceChangesResponse {
+	scheduledPrices := make([]*response.ScheduledPriceChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		scheduledPrices = append(scheduledPrices, ToScheduledPriceChangeResponse(change))
+	}
+
+	return &response.ListScheduledPriceChangesResponse{ScheduledPrices: scheduledPrices}
+}
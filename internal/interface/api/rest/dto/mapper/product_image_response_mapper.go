@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToProductImagesResponse(images *common.ProductImagesResult) *response.ProductImagesResponse {
+	return &response.ProductImagesResponse{
+		ProductId: images.ProductId.String(),
+		Images:    toProductImageResponses(images.ProductId, images.Images),
+		Version:   images.Version,
+	}
+}
+
+func ToUploadProductImageResponse(result *command.UploadProductImageCommandResult) *response.UploadProductImageResponse {
+	return &response.UploadProductImageResponse{
+		Image:                 ToProductImageResponse(result.Result.ProductId, result.Image),
+		ProductImagesResponse: *ToProductImagesResponse(result.Result),
+	}
+}
+
+func ToProductImageResponse(productId uuid.UUID, image *common.ProductImageResult) *response.ProductImageResponse {
+	return &response.ProductImageResponse{
+		Id:          image.Id.String(),
+		Url:         "/api/v1/products/" + productId.String() + "/images/" + image.Id.String(),
+		ContentType: image.ContentType,
+		SizeBytes:   image.SizeBytes,
+		Position:    image.Position,
+		Primary:     image.Primary,
+		CreatedAt:   image.CreatedAt,
+	}
+}
+
+func toProductImageResponses(productId uuid.UUID, images []common.ProductImageResult) []*response.ProductImageResponse {
+	responses := make([]*response.ProductImageResponse, 0, len(images))
+	for index := range images {
+		responses = append(responses, ToProductImageResponse(productId, &images[index]))
+	}
+	return responses
+}
@@ -6,14 +6,81 @@ import (
 )
 
 func ToProductResponse(product *common.ProductResult) *response.ProductResponse {
-	return &response.ProductResponse{
+	categoryIds := make([]string, 0, len(product.CategoryIds))
+	for _, categoryId := range product.CategoryIds {
+		categoryIds = append(categoryIds, categoryId.String())
+	}
+
+	productResponse := &response.ProductResponse{
 		Id:              product.Id.String(),
 		Name:            product.Name,
 		PriceMinorUnits: product.Price.MinorUnits(),
 		Currency:        string(product.Price.Currency()),
 		SellerId:        product.SellerId.String(),
+		Status:          product.Status,
+		CategoryIds:     categoryIds,
+		Variants:        toProductVariantResponses(product.Variants),
+		Images:          toProductImageResponses(product.Id, product.Images),
 		CreatedAt:       product.CreatedAt,
 		UpdatedAt:       product.UpdatedAt,
+		Version:         product.Version,
+	}
+	if product.EffectivePrice != nil {
+		productResponse.EffectivePrice = toEffectivePriceResponse(product.EffectivePrice)
+	}
+	if product.DisplayPrice != nil {
+		productResponse.DisplayPrice = toDisplayPriceResponse(product.DisplayPrice)
+	}
+
+	return productResponse
+}
+
+func toProductVariantResponses(variants []common.ProductVariantResult) []*response.ProductVariantResponse {
+	responses := make([]*response.ProductVariantResponse, 0, len(variants))
+	for _, variant := range variants {
+		variantResponse := &response.ProductVariantResponse{
+			Id:              variant.Id.String(),
+			Sku:             variant.Sku,
+			Attributes:      variant.Attributes,
+			PriceMinorUnits: variant.Price.MinorUnits(),
+			Currency:        string(variant.Price.Currency()),
+		}
+		if variant.PriceOverride != nil {
+			override := variant.PriceOverride.MinorUnits()
+			variantResponse.PriceOverrideMinorUnits = &override
+		}
+		if variant.EffectivePrice != nil {
+			variantResponse.EffectivePrice = toEffectivePriceResponse(variant.EffectivePrice)
+		}
+		responses = append(responses, variantResponse)
+	}
+	return responses
+}
+
+func toDisplayPriceResponse(conversion *common.ConversionResult) *response.DisplayPriceResponse {
+	displayPrice := &response.DisplayPriceResponse{
+		MinorUnits: conversion.Amount.MinorUnits(),
+		Currency:   string(conversion.Amount.Currency()),
+	}
+	if conversion.Rate != nil {
+		effectiveAt := conversion.Rate.EffectiveAt()
+		displayPrice.ExchangeRate = conversion.Rate.Rate()
+		displayPrice.RateEffectiveAt = &effectiveAt
+	}
+
+	return displayPrice
+}
+
+func toEffectivePriceResponse(effectivePrice *common.EffectivePriceResult) *response.EffectivePriceResponse {
+	promotionIds := make([]string, 0, len(effectivePrice.PromotionIds))
+	for _, promotionId := range effectivePrice.PromotionIds {
+		promotionIds = append(promotionIds, promotionId.String())
+	}
+
+	return &response.EffectivePriceResponse{
+		MinorUnits:   effectivePrice.Price.MinorUnits(),
+		Currency:     string(effectivePrice.Price.Currency()),
+		PromotionIds: promotionIds,
 	}
 }
 
@@ -24,3 +91,15 @@ func ToProductListResponse(products []*common.ProductResult) *response.ListProdu
 	}
 	return &response.ListProductsResponse{Products: responseList}
 }
+
+func ToSearchProductsResponse(results []*common.ProductSearchResult) *response.SearchProductsResponse {
+	responseList := make([]*response.ProductSearchResultResponse, 0, len(results))
+	for _, result := range results {
+		responseList = append(responseList, &response.ProductSearchResultResponse{
+			Product:   ToProductResponse(result.Product),
+			Rank:      result.Rank,
+			Highlight: result.Highlight,
+		})
+	}
+	return &response.SearchProductsResponse{Results: responseList}
+}
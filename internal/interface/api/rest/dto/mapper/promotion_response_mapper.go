@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToPromotionResponse(promotion *common.PromotionResult) *response.PromotionResponse {
+	productIds := make([]string, 0, len(promotion.ProductIds))
+	for _, productId := range promotion.ProductIds {
+		productIds = append(productIds, productId.String())
+	}
+
+	promotionResponse := &response.PromotionResponse{
+		Id:           promotion.Id.String(),
+		SellerId:     promotion.SellerId.String(),
+		Name:         promotion.Name,
+		DiscountKind: promotion.DiscountKind,
+		BasisPoints:  promotion.BasisPoints,
+		ProductIds:   productIds,
+		StartsAt:     promotion.StartsAt,
+		EndsAt:       promotion.EndsAt,
+		Stackable:    promotion.Stackable,
+		UsageLimit:   promotion.UsageLimit,
+		UsageCount:   promotion.UsageCount,
+		CreatedAt:    promotion.CreatedAt,
+		UpdatedAt:    promotion.UpdatedAt,
+		Version:      promotion.Version,
+	}
+	if promotion.Amount != nil {
+		promotionResponse.AmountMinorUnits = promotion.Amount.MinorUnits()
+		promotionResponse.Currency = string(promotion.Amount.Currency())
+	}
+
+	return promotionResponse
+}
+
+func ToPromotionListResponse(promotions []*common.PromotionResult) *response.ListPromotionsResponse {
+	responseList := make([]*response.PromotionResponse, 0, len(promotions))
+	for _, promotion := range promotions {
+		responseList = append(responseList, ToPromotionResponse(promotion))
+	}
+	return &response.ListPromotionsResponse{Promotions: responseList}
+}
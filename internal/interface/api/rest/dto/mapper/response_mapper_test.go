@@ -58,6 +58,7 @@ func TestToProductResponse(t *testing.T) {
 		Name:      "Widget",
 		Price:     mustMoney(t, 999, entities.USD),
 		SellerId:  sellerId,
+		Status:    "published",
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -69,7 +70,164 @@ func TestToProductResponse(t *testing.T) {
 	assert.Equal(t, int64(999), resp.PriceMinorUnits)
 	assert.Equal(t, "USD", resp.Currency)
 	assert.Equal(t, sellerId.String(), resp.SellerId)
+	assert.Equal(t, "published", resp.Status)
 	assert.Equal(t, now, resp.CreatedAt)
+	assert.Equal(t, []string{}, resp.CategoryIds)
+}
+
+func TestToProductResponse_Variants(t *testing.T) {
+	override := mustMoney(t, 1299, entities.USD)
+	small, large := uuid.New(), uuid.New()
+	result := &common.ProductResult{
+		Id:       uuid.New(),
+		Name:     "T-shirt",
+		Price:    mustMoney(t, 999, entities.USD),
+		SellerId: uuid.New(),
+		Variants: []common.ProductVariantResult{
+			{Id: small, Sku: "TEE-S", Attributes: map[string]string{"size": "S"}, Price: mustMoney(t, 999, entities.USD)},
+			{Id: large, Sku: "TEE-L", Attributes: map[string]string{"size": "L"}, PriceOverride: &override, Price: override},
+		},
+	}
+
+	resp := ToProductResponse(result)
+
+	require.Len(t, resp.Variants, 2)
+	assert.Equal(t, small.String(), resp.Variants[0].Id)
+	assert.Equal(t, "TEE-S", resp.Variants[0].Sku)
+	assert.Nil(t, resp.Variants[0].PriceOverrideMinorUnits, "no override sells at the product's price")
+	assert.Equal(t, int64(999), resp.Variants[0].PriceMinorUnits)
+	assert.Equal(t, map[string]string{"size": "L"}, resp.Variants[1].Attributes)
+	require.NotNil(t, resp.Variants[1].PriceOverrideMinorUnits)
+	assert.Equal(t, int64(1299), *resp.Variants[1].PriceOverrideMinorUnits)
+	assert.Equal(t, int64(1299), resp.Variants[1].PriceMinorUnits)
+	assert.Equal(t, "USD", resp.Variants[1].Currency)
+}
+
+func TestToCategoryResponse(t *testing.T) {
+	rootId, parentId := uuid.New(), uuid.New()
+	result := &common.CategoryResult{
+		Id:          uuid.New(),
+		Name:        "Office chairs",
+		ParentId:    &parentId,
+		AncestorIds: []uuid.UUID{rootId, parentId},
+		Version:     2,
+	}
+
+	resp := ToCategoryResponse(result)
+
+	assert.Equal(t, result.Id.String(), resp.Id)
+	assert.Equal(t, parentId.String(), *resp.ParentId)
+	assert.Equal(t, []string{rootId.String(), parentId.String()}, resp.AncestorIds)
+	assert.Equal(t, 2, resp.Version)
+
+	root := ToCategoryResponse(&common.CategoryResult{Id: rootId, Name: "Furniture"})
+	assert.Nil(t, root.ParentId)
+	assert.Equal(t, []string{}, root.AncestorIds)
+}
+
+func TestToProductResponse_DisplayPrice(t *testing.T) {
+	effectiveAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rate, err := entities.NewExchangeRate(entities.USD, entities.EUR, "0.92", effectiveAt)
+	require.NoError(t, err)
+	result := &common.ProductResult{
+		Id:       uuid.New(),
+		Price:    mustMoney(t, 1000, entities.USD),
+		SellerId: uuid.New(),
+		DisplayPrice: &common.ConversionResult{
+			Amount: mustMoney(t, 920, entities.EUR),
+			Rate:   &rate,
+		},
+	}
+
+	resp := ToProductResponse(result)
+
+	require.NotNil(t, resp.DisplayPrice)
+	assert.Equal(t, int64(920), resp.DisplayPrice.MinorUnits)
+	assert.Equal(t, "EUR", resp.DisplayPrice.Currency)
+	assert.Equal(t, "0.92", resp.DisplayPrice.ExchangeRate)
+	assert.Equal(t, effectiveAt, *resp.DisplayPrice.RateEffectiveAt)
+
+	// No conversion needed: no rate to report.
+	result.DisplayPrice = &common.ConversionResult{Amount: result.Price}
+	resp = ToProductResponse(result)
+	assert.Empty(t, resp.DisplayPrice.ExchangeRate)
+	assert.Nil(t, resp.DisplayPrice.RateEffectiveAt)
+
+	result.DisplayPrice = nil
+	assert.Nil(t, ToProductResponse(result).DisplayPrice)
+}
+
+func TestToProductResponse_EffectivePrice(t *testing.T) {
+	promotionId := uuid.New()
+	result := &common.ProductResult{
+		Id:       uuid.New(),
+		Price:    mustMoney(t, 1000, entities.USD),
+		SellerId: uuid.New(),
+		EffectivePrice: &common.EffectivePriceResult{
+			Price:        mustMoney(t, 800, entities.USD),
+			PromotionIds: []uuid.UUID{promotionId},
+		},
+		Variants: []common.ProductVariantResult{{
+			Id:    uuid.New(),
+			Sku:   "WIDGET",
+			Price: mustMoney(t, 1000, entities.USD),
+			EffectivePrice: &common.EffectivePriceResult{
+				Price:        mustMoney(t, 800, entities.USD),
+				PromotionIds: []uuid.UUID{promotionId},
+			},
+		}},
+	}
+
+	resp := ToProductResponse(result)
+
+	require.NotNil(t, resp.EffectivePrice)
+	assert.Equal(t, int64(800), resp.EffectivePrice.MinorUnits)
+	assert.Equal(t, "USD", resp.EffectivePrice.Currency)
+	assert.Equal(t, []string{promotionId.String()}, resp.EffectivePrice.PromotionIds)
+	require.NotNil(t, resp.Variants[0].EffectivePrice)
+	assert.Equal(t, int64(800), resp.Variants[0].EffectivePrice.MinorUnits)
+	assert.Equal(t, []string{promotionId.String()}, resp.Variants[0].EffectivePrice.PromotionIds)
+
+	// No promotion applies: an empty list rather than null.
+	result.EffectivePrice = &common.EffectivePriceResult{Price: result.Price}
+	assert.Equal(t, []string{}, ToProductResponse(result).EffectivePrice.PromotionIds)
+
+	result.EffectivePrice = nil
+	assert.Nil(t, ToProductResponse(result).EffectivePrice)
+}
+
+func TestToPromotionResponse(t *testing.T) {
+	amount := mustMoney(t, 500, entities.USD)
+	productId := uuid.New()
+	result := &common.PromotionResult{
+		Id:           uuid.New(),
+		SellerId:     uuid.New(),
+		Name:         "Spring sale",
+		DiscountKind: string(entities.DiscountFixedAmount),
+		Amount:       &amount,
+		ProductIds:   []uuid.UUID{productId},
+		UsageLimit:   10,
+		Version:      2,
+	}
+
+	resp := ToPromotionResponse(result)
+
+	assert.Equal(t, "fixed_amount", resp.DiscountKind)
+	assert.Equal(t, int64(500), resp.AmountMinorUnits)
+	assert.Equal(t, "USD", resp.Currency)
+	assert.Zero(t, resp.BasisPoints)
+	assert.Equal(t, []string{productId.String()}, resp.ProductIds)
+	assert.Equal(t, 10, resp.UsageLimit)
+	assert.Equal(t, 2, resp.Version)
+
+	// Percentage discounts carry no amount.
+	result.DiscountKind = string(entities.DiscountPercentage)
+	result.BasisPoints = 1500
+	result.Amount = nil
+	resp = ToPromotionResponse(result)
+	assert.Equal(t, int64(1500), resp.BasisPoints)
+	assert.Zero(t, resp.AmountMinorUnits)
+	assert.Empty(t, resp.Currency)
 }
 
 func TestToProductResponse_JsonShape(t *testing.T) {
@@ -7,10 +7,12 @@ import (
 
 func ToSellerResponse(product *common.SellerResult) *response.SellerResponse {
 	return &response.SellerResponse{
-		Id:        product.Id.String(),
-		Name:      product.Name,
-		CreatedAt: product.CreatedAt,
-		UpdatedAt: product.UpdatedAt,
+		Id:                 product.Id.String(),
+		Name:               product.Name,
+		VerificationStatus: product.VerificationStatus,
+		CreatedAt:          product.CreatedAt,
+		UpdatedAt:          product.UpdatedAt,
+		Version:            product.Version,
 	}
 }
 
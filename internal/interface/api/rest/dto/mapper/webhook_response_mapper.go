@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+)
+
+func ToWebhookSubscriptionResponse(subscription *common.WebhookSubscriptionResult) *response.WebhookSubscriptionResponse {
+	return &response.WebhookSubscriptionResponse{
+		Id:         subscription.Id.String(),
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func ToWebhookSubscriptionListResponse(subscriptions []*common.WebhookSubscriptionResult) *response.ListWebhookSubscriptionsResponse {
+	responseList := make([]*response.WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responseList = append(responseList, ToWebhookSubscriptionResponse(subscription))
+	}
+	return &response.ListWebhookSubscriptionsResponse{Subscriptions: responseList}
+}
+
+func ToWebhookDeliveryResponse(delivery *common.WebhookDeliveryResult) *response.WebhookDeliveryResponse {
+	return &response.WebhookDeliveryResponse{
+		Id:             delivery.Id.String(),
+		EventId:        delivery.EventId.String(),
+		EventName:      delivery.EventName,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+func ToWebhookDeliveryListResponse(deliveries []*common.WebhookDeliveryResult) *response.ListWebhookDeliveriesResponse {
+	responseList := make([]*response.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responseList = append(responseList, ToWebhookDeliveryResponse(delivery))
+	}
+	return &response.ListWebhookDeliveriesResponse{Deliveries: responseList}
+}
@@ -0,0 +1,29 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+)
+
+type AdjustStockRequest struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	// VariantId may be omitted for products with a single variant.
+	VariantId string `json:"variant_id"`
+	Delta     int    `json:"delta"`
+	Reason    string `json:"reason"`
+}
+
+func (req *AdjustStockRequest) ToAdjustStockCommand(productId uuid.UUID) (*command.AdjustStockCommand, error) {
+	variantId, err := parseVariantId(req.VariantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.AdjustStockCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		ProductId:      productId,
+		VariantId:      variantId,
+		Delta:          req.Delta,
+		Reason:         req.Reason,
+	}, nil
+}
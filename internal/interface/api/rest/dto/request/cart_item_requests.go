@@ -0,0 +1,53 @@
+package request
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+)
+
+type AddCartItemRequest struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	ProductId      string `json:"product_id"`
+	// VariantId may be omitted for products with a single variant.
+	VariantId string `json:"variant_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+func (req *AddCartItemRequest) ToAddCartItemCommand(buyerId uuid.UUID) (*command.AddCartItemCommand, error) {
+	productId, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, errors.New("invalid product Id format")
+	}
+	variantId, err := parseVariantId(req.VariantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.AddCartItemCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		BuyerId:        buyerId,
+		ProductId:      productId,
+		VariantId:      variantId,
+		Quantity:       req.Quantity,
+	}, nil
+}
+
+type UpdateCartItemRequest struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	// Quantity replaces the line's quantity; 0 removes the line.
+	Quantity int `json:"quantity"`
+}
+
+// ToUpdateCartItemCommand builds the command. The product and variant come
+// from the URL rather than the body.
+func (req *UpdateCartItemRequest) ToUpdateCartItemCommand(buyerId, productId, variantId uuid.UUID) *command.UpdateCartItemCommand {
+	return &command.UpdateCartItemCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		BuyerId:        buyerId,
+		ProductId:      productId,
+		VariantId:      variantId,
+		Quantity:       req.Quantity,
+	}
+}
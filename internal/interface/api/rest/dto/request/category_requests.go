@@ -0,0 +1,108 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// CreateCategoryRequest creates a root category when parent_id is omitted.
+type CreateCategoryRequest struct {
+	IdempotencyKey string  `json:"idempotency_key"`
+	Name           string  `json:"name"`
+	ParentId       *string `json:"parent_id"`
+}
+
+func (req *CreateCategoryRequest) ToCreateCategoryCommand() (*command.CreateCategoryCommand, error) {
+	parentId, err := parseParentId(req.ParentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.CreateCategoryCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		Name:           req.Name,
+		ParentId:       parentId,
+	}, nil
+}
+
+// UpdateCategoryRequest replaces the category's name and parent; omitting
+// parent_id moves the category to the root level.
+type UpdateCategoryRequest struct {
+	IdempotencyKey string  `json:"idempotency_key"`
+	Name           string  `json:"name"`
+	ParentId       *string `json:"parent_id"`
+}
+
+// ToUpdateCategoryCommand builds the command. The category Id comes from
+// the URL path rather than the body.
+func (req *UpdateCategoryRequest) ToUpdateCategoryCommand(id uuid.UUID) (*command.UpdateCategoryCommand, error) {
+	parentId, err := parseParentId(req.ParentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &command.UpdateCategoryCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		Id:             id,
+		Name:           req.Name,
+		ParentId:       parentId,
+	}, nil
+}
+
+// ListCategoriesRequest binds the query string of GET /api/v1/categories.
+// Without parent_id the root categories are listed.
+type ListCategoriesRequest struct {
+	ParentId string `query:"parent_id"`
+}
+
+func (req *ListCategoriesRequest) ToGetCategoriesQuery() (*query.GetCategoriesQuery, error) {
+	var parentId uuid.UUID
+	if req.ParentId != "" {
+		parsed, err := uuid.Parse(req.ParentId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parent_id must be a valid category Id", entities.ErrValidation)
+		}
+		parentId = parsed
+	}
+
+	return &query.GetCategoriesQuery{ParentId: parentId}, nil
+}
+
+// CategorizeProductRequest replaces the product's categories with
+// category_ids; an empty list removes it from all categories.
+type CategorizeProductRequest struct {
+	IdempotencyKey string   `json:"idempotency_key"`
+	CategoryIds    []string `json:"category_ids"`
+}
+
+func (req *CategorizeProductRequest) ToCategorizeProductCommand(id uuid.UUID) (*command.CategorizeProductCommand, error) {
+	categoryIds := make([]uuid.UUID, 0, len(req.CategoryIds))
+	for _, rawId := range req.CategoryIds {
+		categoryId, err := uuid.Parse(rawId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid category Id format", entities.ErrValidation)
+		}
+		categoryIds = append(categoryIds, categoryId)
+	}
+
+	return &command.CategorizeProductCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		Id:             id,
+		CategoryIds:    categoryIds,
+	}, nil
+}
+
+func parseParentId(raw *string) (*uuid.UUID, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+	parentId, err := uuid.Parse(*raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parent_id must be a valid category Id", entities.ErrValidation)
+	}
+	return &parentId, nil
+}
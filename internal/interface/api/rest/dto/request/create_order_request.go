@@ -0,0 +1,47 @@
+package request
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+)
+
+type CreateOrderRequest struct {
+	IdempotencyKey string                   `json:"idempotency_key"`
+	BuyerId        string                   `json:"buyer_id"`
+	Items          []CreateOrderItemRequest `json:"items"`
+}
+
+type CreateOrderItemRequest struct {
+	ProductId string `json:"product_id"`
+	// VariantId may be omitted for products with a single variant.
+	VariantId string `json:"variant_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+func (req *CreateOrderRequest) ToCreateOrderCommand() (*command.CreateOrderCommand, error) {
+	buyerId, err := uuid.Parse(req.BuyerId)
+	if err != nil {
+		return nil, errors.New("invalid buyer Id format")
+	}
+
+	items := make([]command.CreateOrderItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		productId, err := uuid.Parse(item.ProductId)
+		if err != nil {
+			return nil, errors.New("invalid product Id format")
+		}
+		variantId, err := parseVariantId(item.VariantId)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, command.CreateOrderItem{ProductId: productId, VariantId: variantId, Quantity: item.Quantity})
+	}
+
+	return &command.CreateOrderCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		BuyerId:        buyerId,
+		Items:          items,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package request
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+)
+
+// CreateOutboxReplayRequest starts a replay of published events to Target.
+// Omitted filters match every event; From and To bound occurred_at as
+// [from, to).
+type CreateOutboxReplayRequest struct {
+	Target      string     `json:"target"`
+	AggregateId string     `json:"aggregate_id"`
+	EventName   string     `json:"event_name"`
+	From        *time.Time `json:"from"`
+	To          *time.Time `json:"to"`
+}
+
+func (req *CreateOutboxReplayRequest) ToStartOutboxReplayCommand() (*command.StartOutboxReplayCommand, error) {
+	replayCommand := &command.StartOutboxReplayCommand{
+		Target:    req.Target,
+		EventName: req.EventName,
+	}
+	if req.AggregateId != "" {
+		aggregateId, err := uuid.Parse(req.AggregateId)
+		if err != nil {
+			return nil, err
+		}
+		replayCommand.AggregateId = aggregateId
+	}
+	if req.From != nil {
+		replayCommand.From = *req.From
+	}
+	if req.To != nil {
+		replayCommand.To = *req.To
+	}
+
+	return replayCommand, nil
+}
@@ -12,6 +12,9 @@ type CreateProductRequest struct {
 	PriceMinorUnits int64  `json:"price_minor_units"`
 	Currency        string `json:"currency"`
 	SellerId        string `json:"seller_id"`
+	// Variants is optional; without it the product gets a single default
+	// variant.
+	Variants []ProductVariantRequest `json:"variants"`
 }
 
 func (req *CreateProductRequest) ToCreateProductCommand() (*command.CreateProductCommand, error) {
@@ -26,5 +29,6 @@ func (req *CreateProductRequest) ToCreateProductCommand() (*command.CreateProduc
 		PriceMinorUnits: req.PriceMinorUnits,
 		Currency:        entities.Currency(req.Currency),
 		SellerId:        sellerId,
+		Variants:        toProductVariantInputs(req.Variants),
 	}, nil
 }
@@ -0,0 +1,59 @@
+package request
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// CreatePromotionRequest takes basis_points for a percentage discount
+// (1250 is 12.5%) and amount_minor_units and currency for a fixed-amount
+// one.
+type CreatePromotionRequest struct {
+	IdempotencyKey   string     `json:"idempotency_key"`
+	SellerId         string     `json:"seller_id"`
+	Name             string     `json:"name"`
+	DiscountKind     string     `json:"discount_kind"`
+	BasisPoints      int64      `json:"basis_points"`
+	AmountMinorUnits int64      `json:"amount_minor_units"`
+	Currency         string     `json:"currency"`
+	ProductIds       []string   `json:"product_ids"`
+	StartsAt         time.Time  `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	Stackable        bool       `json:"stackable"`
+	UsageLimit       int        `json:"usage_limit"`
+}
+
+func (req *CreatePromotionRequest) ToCreatePromotionCommand() (*command.CreatePromotionCommand, error) {
+	sellerId, err := uuid.Parse(req.SellerId)
+	if err != nil {
+		return nil, errors.New("invalid seller Id format")
+	}
+
+	productIds := make([]uuid.UUID, 0, len(req.ProductIds))
+	for _, rawId := range req.ProductIds {
+		productId, err := uuid.Parse(rawId)
+		if err != nil {
+			return nil, errors.New("invalid product Id format")
+		}
+		productIds = append(productIds, productId)
+	}
+
+	return &command.CreatePromotionCommand{
+		IdempotencyKey:   req.IdempotencyKey,
+		SellerId:         sellerId,
+		Name:             req.Name,
+		DiscountKind:     entities.DiscountKind(req.DiscountKind),
+		BasisPoints:      req.BasisPoints,
+		AmountMinorUnits: req.AmountMinorUnits,
+		Currency:         entities.Currency(req.Currency),
+		ProductIds:       productIds,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		Stackable:        req.Stackable,
+		UsageLimit:       req.UsageLimit,
+	}, nil
+}
@@ -0,0 +1,17 @@
+package request
+
+import "github.com/sklinkert/go-ddd/internal/application/command"
+
+type CreateWebhookSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+func (req *CreateWebhookSubscriptionRequest) ToCreateWebhookSubscriptionCommand() *command.CreateWebhookSubscriptionCommand {
+	return &command.CreateWebhookSubscriptionCommand{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	}
+}
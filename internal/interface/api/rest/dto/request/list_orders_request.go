@@ -0,0 +1,30 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+// ListOrdersRequest binds the query string of GET /api/v1/orders.
+type ListOrdersRequest struct {
+	Cursor  string `query:"cursor"`
+	Limit   int    `query:"limit"`
+	BuyerId string `query:"buyer_id"`
+}
+
+func (req *ListOrdersRequest) ToGetAllOrdersQuery() (*query.GetAllOrdersQuery, error) {
+	var buyerId uuid.UUID
+	if req.BuyerId != "" {
+		parsed, err := uuid.Parse(req.BuyerId)
+		if err != nil {
+			return nil, err
+		}
+		buyerId = parsed
+	}
+
+	return &query.GetAllOrdersQuery{
+		Cursor:  req.Cursor,
+		Limit:   req.Limit,
+		BuyerId: buyerId,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// ListProductsRequest binds the query string of GET /api/v1/products.
+type ListProductsRequest struct {
+	Cursor             string `query:"cursor"`
+	Limit              int    `query:"limit"`
+	Sort               string `query:"sort"`
+	SellerId           string `query:"seller_id"`
+	Status             string `query:"status"`
+	Currency           string `query:"currency"`
+	MinPriceMinorUnits *int64 `query:"min_price_minor_units"`
+	MaxPriceMinorUnits *int64 `query:"max_price_minor_units"`
+	DisplayCurrency    string `query:"display_currency"`
+	CategoryId         string `query:"category_id"`
+}
+
+func (req *ListProductsRequest) ToGetAllProductsQuery() (*query.GetAllProductsQuery, error) {
+	var sellerId uuid.UUID
+	if req.SellerId != "" {
+		parsed, err := uuid.Parse(req.SellerId)
+		if err != nil {
+			return nil, err
+		}
+		sellerId = parsed
+	}
+
+	var categoryId uuid.UUID
+	if req.CategoryId != "" {
+		parsed, err := uuid.Parse(req.CategoryId)
+		if err != nil {
+			return nil, err
+		}
+		categoryId = parsed
+	}
+
+	return &query.GetAllProductsQuery{
+		Cursor:             req.Cursor,
+		Limit:              req.Limit,
+		SortBy:             req.Sort,
+		SellerId:           sellerId,
+		Status:             entities.ProductStatus(req.Status),
+		Currency:           entities.Currency(req.Currency),
+		MinPriceMinorUnits: req.MinPriceMinorUnits,
+		MaxPriceMinorUnits: req.MaxPriceMinorUnits,
+		DisplayCurrency:    entities.Currency(req.DisplayCurrency),
+		CategoryId:         categoryId,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package request
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+)
+
+// ListPromotionsRequest binds the query string of GET /api/v1/promotions.
+type ListPromotionsRequest struct {
+	SellerId string `query:"seller_id"`
+}
+
+func (req *ListPromotionsRequest) ToGetPromotionsBySellerQuery() (*query.GetPromotionsBySellerQuery, error) {
+	sellerId, err := uuid.Parse(req.SellerId)
+	if err != nil {
+		return nil, errors.New("seller_id must be a valid seller Id")
+	}
+
+	return &query.GetPromotionsBySellerQuery{SellerId: sellerId}, nil
+}
@@ -0,0 +1,18 @@
+package request
+
+import "github.com/sklinkert/go-ddd/internal/application/query"
+
+// ListSellersRequest binds the query string of GET /api/v1/sellers.
+type ListSellersRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+	Sort   string `query:"sort"`
+}
+
+func (req *ListSellersRequest) ToGetAllSellersQuery() *query.GetAllSellersQuery {
+	return &query.GetAllSellersQuery{
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+		SortBy: req.Sort,
+	}
+}
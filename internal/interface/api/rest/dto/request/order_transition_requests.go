@@ -0,0 +1,47 @@
+package request
+
+import (
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+)
+
+// ShipOrderRequest, CancelOrderRequest and RefundOrderRequest are optional
+// bodies of the order status endpoints.
+type ShipOrderRequest struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	TrackingNumber string `json:"tracking_number"`
+}
+
+func (req *ShipOrderRequest) ToShipOrderCommand(id uuid.UUID) *command.ShipOrderCommand {
+	return &command.ShipOrderCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		Id:             id,
+		TrackingNumber: req.TrackingNumber,
+	}
+}
+
+type CancelOrderRequest struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	Reason         string `json:"reason"`
+}
+
+func (req *CancelOrderRequest) ToCancelOrderCommand(id uuid.UUID) *command.CancelOrderCommand {
+	return &command.CancelOrderCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		Id:             id,
+		Reason:         req.Reason,
+	}
+}
+
+type RefundOrderRequest struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	Reason         string `json:"reason"`
+}
+
+func (req *RefundOrderRequest) ToRefundOrderCommand(id uuid.UUID) *command.RefundOrderCommand {
+	return &command.RefundOrderCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		Id:             id,
+		Reason:         req.Reason,
+	}
+}
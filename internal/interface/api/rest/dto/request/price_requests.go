@@ -0,0 +1,77 @@
+package request
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// PriceHistoryRequest binds the query string of
+// GET /api/v1/products/:id/price-history. Both bounds are RFC 3339 and
+// optional.
+type PriceHistoryRequest struct {
+	From string `query:"from"`
+	To   string `query:"to"`
+}
+
+func (req *PriceHistoryRequest) ToGetPriceHistoryQuery(productId uuid.UUID) (*query.GetPriceHistoryQuery, error) {
+	historyQuery := &query.GetPriceHistoryQuery{ProductId: productId}
+	var err error
+	if historyQuery.From, err = parseOptionalTime("from", req.From); err != nil {
+		return nil, err
+	}
+	if historyQuery.To, err = parseOptionalTime("to", req.To); err != nil {
+		return nil, err
+	}
+
+	return historyQuery, nil
+}
+
+func parseOptionalTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be an RFC 3339 timestamp", entities.ErrValidation, name)
+	}
+	return parsed, nil
+}
+
+// LowestPriceRequest binds the query string of
+// GET /api/v1/products/:id/lowest-price.
+type LowestPriceRequest struct {
+	Days int `query:"days"`
+}
+
+func (req *LowestPriceRequest) ToGetLowestPriceQuery(productId uuid.UUID) (*query.GetLowestPriceQuery, error) {
+	if req.Days < 0 {
+		return nil, fmt.Errorf("%w: days must not be negative", entities.ErrValidation)
+	}
+
+	return &query.GetLowestPriceQuery{
+		ProductId: productId,
+		Window:    time.Duration(req.Days) * 24 * time.Hour,
+	}, nil
+}
+
+type SchedulePriceChangeRequest struct {
+	IdempotencyKey  string    `json:"idempotency_key"`
+	PriceMinorUnits int64     `json:"price_minor_units"`
+	Currency        string    `json:"currency"`
+	EffectiveAt     time.Time `json:"effective_at"`
+}
+
+func (req *SchedulePriceChangeRequest) ToSchedulePriceChangeCommand(productId uuid.UUID) *command.SchedulePriceChangeCommand {
+	return &command.SchedulePriceChangeCommand{
+		IdempotencyKey:  req.IdempotencyKey,
+		ProductId:       productId,
+		PriceMinorUnits: req.PriceMinorUnits,
+		Currency:        entities.Currency(req.Currency),
+		EffectiveAt:     req.EffectiveAt,
+	}
+}
@@ -0,0 +1,33 @@
+package request
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+)
+
+// ReorderProductImagesRequest is the body of PUT
+// /api/v1/products/:id/images/order; image_ids names every image of the
+// product once, in the new display order.
+type ReorderProductImagesRequest struct {
+	IdempotencyKey string   `json:"idempotency_key"`
+	ImageIds       []string `json:"image_ids"`
+}
+
+func (req *ReorderProductImagesRequest) ToReorderProductImagesCommand(productId uuid.UUID) (*command.ReorderProductImagesCommand, error) {
+	imageIds := make([]uuid.UUID, 0, len(req.ImageIds))
+	for _, raw := range req.ImageIds {
+		imageId, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, errors.New("invalid image Id format")
+		}
+		imageIds = append(imageIds, imageId)
+	}
+
+	return &command.ReorderProductImagesCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		ProductId:      productId,
+		ImageIds:       imageIds,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package request
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+)
+
+// ProductVariantRequest is the body of POST /api/v1/products/:id/variants
+// and one entry of a new product's variants. price_override_minor_units is
+// in the product's currency; omitting it sells the variant at the
+// product's price.
+type ProductVariantRequest struct {
+	IdempotencyKey          string            `json:"idempotency_key"`
+	Sku                     string            `json:"sku"`
+	Attributes              map[string]string `json:"attributes"`
+	PriceOverrideMinorUnits *int64            `json:"price_override_minor_units"`
+}
+
+func (req *ProductVariantRequest) toProductVariantInput() command.ProductVariantInput {
+	return command.ProductVariantInput{
+		Sku:                     req.Sku,
+		Attributes:              req.Attributes,
+		PriceOverrideMinorUnits: req.PriceOverrideMinorUnits,
+	}
+}
+
+func (req *ProductVariantRequest) ToAddProductVariantCommand(productId uuid.UUID) *command.AddProductVariantCommand {
+	return &command.AddProductVariantCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		ProductId:      productId,
+		Variant:        req.toProductVariantInput(),
+	}
+}
+
+// ToUpdateProductVariantCommand builds the command for PUT
+// /api/v1/products/:id/variants/:variant_id; the body replaces the
+// variant's SKU, attributes and price override.
+func (req *ProductVariantRequest) ToUpdateProductVariantCommand(productId, variantId uuid.UUID) *command.UpdateProductVariantCommand {
+	return &command.UpdateProductVariantCommand{
+		IdempotencyKey: req.IdempotencyKey,
+		ProductId:      productId,
+		VariantId:      variantId,
+		Variant:        req.toProductVariantInput(),
+	}
+}
+
+func toProductVariantInputs(variants []ProductVariantRequest) []command.ProductVariantInput {
+	if len(variants) == 0 {
+		return nil
+	}
+	inputs := make([]command.ProductVariantInput, 0, len(variants))
+	for _, variant := range variants {
+		inputs = append(inputs, variant.toProductVariantInput())
+	}
+	return inputs
+}
+
+// parseVariantId parses an optional variant Id; an empty string is
+// uuid.Nil, which selects the product's only variant.
+func parseVariantId(raw string) (uuid.UUID, error) {
+	if raw == "" {
+		return uuid.Nil, nil
+	}
+	variantId, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid variant Id format")
+	}
+	return variantId, nil
+}
@@ -0,0 +1,31 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/sklinkert/go-ddd/internal/application/command"
+)
+
+// ReceiveInboxMessageRequest is a CloudEvent in structured mode. The event
+// id is the message id, scoped to its source; data is stored as is.
+type ReceiveInboxMessageRequest struct {
+	SpecVersion string          `json:"specversion"`
+	Id          string          `json:"id"`
+	Source      string          `json:"source"`
+	Type        string          `json:"type"`
+	Data        json.RawMessage `json:"data"`
+}
+
+func (req *ReceiveInboxMessageRequest) ToReceiveInboxMessageCommand() (*command.ReceiveInboxMessageCommand, error) {
+	if req.SpecVersion != "1.0" {
+		return nil, errors.New("specversion must be 1.0")
+	}
+
+	return &command.ReceiveInboxMessageCommand{
+		Source:    req.Source,
+		MessageId: req.Id,
+		Type:      req.Type,
+		Payload:   req.Data,
+	}, nil
+}
@@ -3,8 +3,10 @@ package request
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/sklinkert/go-ddd/internal/application/command"
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,6 +30,40 @@ func TestCreateProductRequest_ToCreateProductCommand(t *testing.T) {
 	assert.Equal(t, int64(999), cmd.PriceMinorUnits)
 	assert.Equal(t, entities.USD, cmd.Currency)
 	assert.Equal(t, sellerId, cmd.SellerId)
+	assert.Nil(t, cmd.Variants, "without variants the product gets a default one")
+}
+
+func TestCreateProductRequest_Variants(t *testing.T) {
+	sellerId := uuid.New()
+	body := `{"name":"T-shirt","price_minor_units":999,"currency":"USD","seller_id":"` + sellerId.String() + `",
+		"variants":[{"sku":"TEE-S","attributes":{"size":"S"}},{"sku":"TEE-L","attributes":{"size":"L"},"price_override_minor_units":1299}]}`
+	var req CreateProductRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &req))
+
+	cmd, err := req.ToCreateProductCommand()
+
+	require.NoError(t, err)
+	override := int64(1299)
+	assert.Equal(t, []command.ProductVariantInput{
+		{Sku: "TEE-S", Attributes: map[string]string{"size": "S"}},
+		{Sku: "TEE-L", Attributes: map[string]string{"size": "L"}, PriceOverrideMinorUnits: &override},
+	}, cmd.Variants)
+}
+
+func TestProductVariantRequest_ToCommands(t *testing.T) {
+	productId := uuid.New()
+	variantId := uuid.New()
+	var req ProductVariantRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"idempotency_key":"key-1","sku":"TEE-M","attributes":{"size":"M"}}`), &req))
+
+	add := req.ToAddProductVariantCommand(productId)
+	assert.Equal(t, "key-1", add.IdempotencyKey)
+	assert.Equal(t, productId, add.ProductId)
+	assert.Equal(t, command.ProductVariantInput{Sku: "TEE-M", Attributes: map[string]string{"size": "M"}}, add.Variant)
+
+	update := req.ToUpdateProductVariantCommand(productId, variantId)
+	assert.Equal(t, variantId, update.VariantId)
+	assert.Equal(t, add.Variant, update.Variant)
 }
 
 func TestCreateProductRequest_ToCreateProductCommand_InvalidSellerId(t *testing.T) {
@@ -116,3 +152,257 @@ func TestUpdateSellerRequest_JsonTags(t *testing.T) {
 	assert.Equal(t, id, req.Id)
 	assert.Equal(t, "Acme v2", req.Name)
 }
+
+func TestListProductsRequest_ToGetAllProductsQuery(t *testing.T) {
+	sellerId := uuid.New()
+	minPrice := int64(100)
+	req := &ListProductsRequest{
+		Cursor:             "abc",
+		Limit:              25,
+		Sort:               "price",
+		SellerId:           sellerId.String(),
+		Status:             "archived",
+		Currency:           "EUR",
+		MinPriceMinorUnits: &minPrice,
+	}
+
+	productQuery, err := req.ToGetAllProductsQuery()
+
+	require.NoError(t, err)
+	assert.Equal(t, "abc", productQuery.Cursor)
+	assert.Equal(t, 25, productQuery.Limit)
+	assert.Equal(t, "price", productQuery.SortBy)
+	assert.Equal(t, sellerId, productQuery.SellerId)
+	assert.Equal(t, entities.ProductArchived, productQuery.Status)
+	assert.Equal(t, entities.EUR, productQuery.Currency)
+	assert.Equal(t, &minPrice, productQuery.MinPriceMinorUnits)
+	assert.Nil(t, productQuery.MaxPriceMinorUnits)
+}
+
+func TestListProductsRequest_ToGetAllProductsQuery_NoFilters(t *testing.T) {
+	productQuery, err := (&ListProductsRequest{}).ToGetAllProductsQuery()
+
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, productQuery.SellerId)
+	assert.Empty(t, productQuery.Currency)
+}
+
+func TestListProductsRequest_ToGetAllProductsQuery_InvalidSellerId(t *testing.T) {
+	productQuery, err := (&ListProductsRequest{SellerId: "nope"}).ToGetAllProductsQuery()
+
+	assert.Error(t, err)
+	assert.Nil(t, productQuery)
+}
+
+func TestListProductsRequest_ToGetAllProductsQuery_Category(t *testing.T) {
+	categoryId := uuid.New()
+	productQuery, err := (&ListProductsRequest{CategoryId: categoryId.String()}).ToGetAllProductsQuery()
+
+	require.NoError(t, err)
+	assert.Equal(t, categoryId, productQuery.CategoryId)
+
+	_, err = (&ListProductsRequest{CategoryId: "nope"}).ToGetAllProductsQuery()
+	assert.Error(t, err)
+}
+
+func TestCreateCategoryRequest_ToCreateCategoryCommand(t *testing.T) {
+	parentId := uuid.New()
+	var req CreateCategoryRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"idempotency_key":"key-1","name":"Chairs","parent_id":"`+parentId.String()+`"}`), &req))
+
+	cmd, err := req.ToCreateCategoryCommand()
+
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", cmd.IdempotencyKey)
+	assert.Equal(t, "Chairs", cmd.Name)
+	assert.Equal(t, parentId, *cmd.ParentId)
+
+	cmd, err = (&CreateCategoryRequest{Name: "Furniture"}).ToCreateCategoryCommand()
+	require.NoError(t, err)
+	assert.Nil(t, cmd.ParentId, "no parent_id creates a root category")
+
+	invalid := "nope"
+	_, err = (&CreateCategoryRequest{Name: "Chairs", ParentId: &invalid}).ToCreateCategoryCommand()
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
+
+func TestCategorizeProductRequest_ToCategorizeProductCommand(t *testing.T) {
+	productId, categoryId := uuid.New(), uuid.New()
+
+	cmd, err := (&CategorizeProductRequest{CategoryIds: []string{categoryId.String()}}).ToCategorizeProductCommand(productId)
+
+	require.NoError(t, err)
+	assert.Equal(t, productId, cmd.Id)
+	assert.Equal(t, []uuid.UUID{categoryId}, cmd.CategoryIds)
+
+	_, err = (&CategorizeProductRequest{CategoryIds: []string{"nope"}}).ToCategorizeProductCommand(productId)
+	assert.ErrorIs(t, err, entities.ErrValidation)
+}
+
+func TestListSellersRequest_ToGetAllSellersQuery(t *testing.T) {
+	sellerQuery := (&ListSellersRequest{Cursor: "abc", Limit: 5, Sort: "name"}).ToGetAllSellersQuery()
+
+	assert.Equal(t, "abc", sellerQuery.Cursor)
+	assert.Equal(t, 5, sellerQuery.Limit)
+	assert.Equal(t, "name", sellerQuery.SortBy)
+}
+
+func TestCreateOutboxReplayRequest_ToStartOutboxReplayCommand(t *testing.T) {
+	aggregateId := uuid.New()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := &CreateOutboxReplayRequest{Target: "kafka", AggregateId: aggregateId.String(), EventName: "product.created", From: &from}
+
+	replayCommand, err := req.ToStartOutboxReplayCommand()
+
+	require.NoError(t, err)
+	assert.Equal(t, "kafka", replayCommand.Target)
+	assert.Equal(t, aggregateId, replayCommand.AggregateId)
+	assert.Equal(t, "product.created", replayCommand.EventName)
+	assert.Equal(t, from, replayCommand.From)
+	assert.True(t, replayCommand.To.IsZero())
+}
+
+func TestCreateOutboxReplayRequest_ToStartOutboxReplayCommand_InvalidAggregateId(t *testing.T) {
+	_, err := (&CreateOutboxReplayRequest{AggregateId: "nope"}).ToStartOutboxReplayCommand()
+
+	assert.Error(t, err)
+}
+
+func TestReceiveInboxMessageRequest_ToReceiveInboxMessageCommand(t *testing.T) {
+	req := &ReceiveInboxMessageRequest{
+		SpecVersion: "1.0",
+		Id:          "m-1",
+		Source:      "kyc",
+		Type:        "kyc.verification_completed",
+		Data:        json.RawMessage(`{"result":"verified"}`),
+	}
+
+	cmd, err := req.ToReceiveInboxMessageCommand()
+	require.NoError(t, err)
+	assert.Equal(t, "m-1", cmd.MessageId)
+	assert.Equal(t, "kyc", cmd.Source)
+	assert.Equal(t, "kyc.verification_completed", cmd.Type)
+	assert.JSONEq(t, `{"result":"verified"}`, string(cmd.Payload))
+
+	req.SpecVersion = "0.3"
+	_, err = req.ToReceiveInboxMessageCommand()
+	assert.Error(t, err)
+}
+
+func TestAdjustStockRequest_ToAdjustStockCommand(t *testing.T) {
+	productId := uuid.New()
+	var req AdjustStockRequest
+	variantId := uuid.New()
+	require.NoError(t, json.Unmarshal([]byte(`{"idempotency_key":"key-1","variant_id":"`+variantId.String()+`","delta":-3,"reason":"damaged"}`), &req))
+
+	cmd, err := req.ToAdjustStockCommand(productId)
+
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", cmd.IdempotencyKey)
+	assert.Equal(t, productId, cmd.ProductId)
+	assert.Equal(t, variantId, cmd.VariantId)
+	assert.Equal(t, -3, cmd.Delta)
+	assert.Equal(t, "damaged", cmd.Reason)
+
+	cmd, err = (&AdjustStockRequest{Delta: 1}).ToAdjustStockCommand(productId)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, cmd.VariantId, "the variant may be omitted")
+	_, err = (&AdjustStockRequest{VariantId: "nope"}).ToAdjustStockCommand(productId)
+	assert.Error(t, err)
+}
+
+func TestCreateOrderRequest_ToCreateOrderCommand(t *testing.T) {
+	buyerId := uuid.New()
+	productId := uuid.New()
+	var req CreateOrderRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"idempotency_key":"key-1","buyer_id":"`+buyerId.String()+
+		`","items":[{"product_id":"`+productId.String()+`","quantity":2}]}`), &req))
+
+	cmd, err := req.ToCreateOrderCommand()
+
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", cmd.IdempotencyKey)
+	assert.Equal(t, buyerId, cmd.BuyerId)
+	assert.Equal(t, []command.CreateOrderItem{{ProductId: productId, Quantity: 2}}, cmd.Items)
+}
+
+func TestCreateOrderRequest_ToCreateOrderCommand_InvalidIds(t *testing.T) {
+	_, err := (&CreateOrderRequest{BuyerId: "nope"}).ToCreateOrderCommand()
+	assert.Error(t, err)
+
+	_, err = (&CreateOrderRequest{
+		BuyerId: uuid.NewString(),
+		Items:   []CreateOrderItemRequest{{ProductId: "nope", Quantity: 1}},
+	}).ToCreateOrderCommand()
+	assert.Error(t, err)
+
+	_, err = (&CreateOrderRequest{
+		BuyerId: uuid.NewString(),
+		Items:   []CreateOrderItemRequest{{ProductId: uuid.NewString(), VariantId: "nope", Quantity: 1}},
+	}).ToCreateOrderCommand()
+	assert.Error(t, err)
+}
+
+func TestListOrdersRequest_ToGetAllOrdersQuery(t *testing.T) {
+	buyerId := uuid.New()
+
+	q, err := (&ListOrdersRequest{Cursor: "abc", Limit: 10, BuyerId: buyerId.String()}).ToGetAllOrdersQuery()
+
+	require.NoError(t, err)
+	assert.Equal(t, "abc", q.Cursor)
+	assert.Equal(t, 10, q.Limit)
+	assert.Equal(t, buyerId, q.BuyerId)
+
+	_, err = (&ListOrdersRequest{BuyerId: "nope"}).ToGetAllOrdersQuery()
+	assert.Error(t, err)
+}
+
+func TestAddCartItemRequest_ToAddCartItemCommand(t *testing.T) {
+	buyerId := uuid.New()
+	productId := uuid.New()
+	var req AddCartItemRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"idempotency_key":"key-1","product_id":"`+productId.String()+`","quantity":2}`), &req))
+
+	cmd, err := req.ToAddCartItemCommand(buyerId)
+
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", cmd.IdempotencyKey)
+	assert.Equal(t, buyerId, cmd.BuyerId)
+	assert.Equal(t, productId, cmd.ProductId)
+	assert.Equal(t, 2, cmd.Quantity)
+
+	assert.Equal(t, uuid.Nil, cmd.VariantId)
+
+	_, err = (&AddCartItemRequest{ProductId: "nope"}).ToAddCartItemCommand(buyerId)
+	assert.Error(t, err)
+	_, err = (&AddCartItemRequest{ProductId: productId.String(), VariantId: "nope"}).ToAddCartItemCommand(buyerId)
+	assert.Error(t, err)
+}
+
+func TestUpdateCartItemRequest_ToUpdateCartItemCommand(t *testing.T) {
+	buyerId := uuid.New()
+	productId := uuid.New()
+	variantId := uuid.New()
+
+	cmd := (&UpdateCartItemRequest{IdempotencyKey: "key-1", Quantity: 3}).ToUpdateCartItemCommand(buyerId, productId, variantId)
+
+	assert.Equal(t, "key-1", cmd.IdempotencyKey)
+	assert.Equal(t, buyerId, cmd.BuyerId)
+	assert.Equal(t, productId, cmd.ProductId)
+	assert.Equal(t, variantId, cmd.VariantId)
+	assert.Equal(t, 3, cmd.Quantity)
+}
+
+func TestReorderProductImagesRequest_ToReorderProductImagesCommand(t *testing.T) {
+	productId, front, back := uuid.New(), uuid.New(), uuid.New()
+
+	cmd, err := (&ReorderProductImagesRequest{IdempotencyKey: "key-1", ImageIds: []string{back.String(), front.String()}}).ToReorderProductImagesCommand(productId)
+
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", cmd.IdempotencyKey)
+	assert.Equal(t, productId, cmd.ProductId)
+	assert.Equal(t, []uuid.UUID{back, front}, cmd.ImageIds)
+
+	_, err = (&ReorderProductImagesRequest{ImageIds: []string{"nope"}}).ToReorderProductImagesCommand(productId)
+	assert.Error(t, err)
+}
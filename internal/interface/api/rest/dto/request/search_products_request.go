@@ -0,0 +1,23 @@
+package request
+
+import (
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+)
+
+// SearchProductsRequest binds the query string of GET /api/v1/products/search.
+type SearchProductsRequest struct {
+	Q               string `query:"q"`
+	Cursor          string `query:"cursor"`
+	Limit           int    `query:"limit"`
+	DisplayCurrency string `query:"display_currency"`
+}
+
+func (req *SearchProductsRequest) ToSearchProductsQuery() *query.SearchProductsQuery {
+	return &query.SearchProductsQuery{
+		Q:               req.Q,
+		Cursor:          req.Cursor,
+		Limit:           req.Limit,
+		DisplayCurrency: entities.Currency(req.DisplayCurrency),
+	}
+}
@@ -0,0 +1,25 @@
+package response
+
+import "time"
+
+type CartResponse struct {
+	BuyerId string              `json:"buyer_id"`
+	Items   []*CartItemResponse `json:"items"`
+	// Total is omitted for an empty cart.
+	Total     *MoneyResponse `json:"total,omitempty"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	// Version is 0 until the cart is first changed.
+	Version int `json:"version"`
+}
+
+type CartItemResponse struct {
+	ProductId           string `json:"product_id"`
+	VariantId           string `json:"variant_id"`
+	Sku                 string `json:"sku"`
+	ProductName         string `json:"product_name"`
+	UnitPriceMinorUnits int64  `json:"unit_price_minor_units"`
+	Currency            string `json:"currency"`
+	Quantity            int    `json:"quantity"`
+	TotalMinorUnits     int64  `json:"total_minor_units"`
+}
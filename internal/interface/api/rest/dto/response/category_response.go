@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+type CategoryResponse struct {
+	Id       string  `json:"id"`
+	Name     string  `json:"name"`
+	ParentId *string `json:"parent_id,omitempty"`
+	// AncestorIds lists the category's ancestors, root first.
+	AncestorIds []string  `json:"ancestor_ids"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Version     int       `json:"version"`
+}
+
+type ListCategoriesResponse struct {
+	Categories []*CategoryResponse `json:"categories"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type DeadLetterResponse struct {
+	Id             string          `json:"id"`
+	AggregateId    string          `json:"aggregate_id"`
+	EventName      string          `json:"event_name"`
+	Payload        json.RawMessage `json:"payload"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	Attempts       int             `json:"attempts"`
+	LastError      string          `json:"last_error"`
+	DeadLetteredAt time.Time       `json:"dead_lettered_at"`
+}
+
+type ListDeadLettersResponse struct {
+	DeadLetters []*DeadLetterResponse `json:"dead_letters"`
+}
@@ -0,0 +1,6 @@
+package response
+
+type ReceiveInboxMessageResponse struct {
+	Id        string `json:"id"`
+	Duplicate bool   `json:"duplicate"`
+}
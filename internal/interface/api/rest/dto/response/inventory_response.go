@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type InventoryResponse struct {
+	ProductId    string                 `json:"product_id"`
+	VariantId    string                 `json:"variant_id"`
+	OnHand       int                    `json:"on_hand"`
+	Reserved     int                    `json:"reserved"`
+	Available    int                    `json:"available"`
+	Reservations []*ReservationResponse `json:"reservations"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+	Version      int                    `json:"version"`
+}
+
+type ReservationResponse struct {
+	Id        string    `json:"id"`
+	Quantity  int       `json:"quantity"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,39 @@
+package response
+
+import "time"
+
+type OrderResponse struct {
+	Id      string               `json:"id"`
+	BuyerId string               `json:"buyer_id"`
+	Status  string               `json:"status"`
+	Items   []*OrderItemResponse `json:"items"`
+	// Totals holds one amount per currency of the order's items.
+	Totals         []MoneyResponse `json:"totals"`
+	TrackingNumber string          `json:"tracking_number,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	Version        int             `json:"version"`
+}
+
+type OrderItemResponse struct {
+	ProductId           string `json:"product_id"`
+	VariantId           string `json:"variant_id"`
+	Sku                 string `json:"sku"`
+	ProductName         string `json:"product_name"`
+	UnitPriceMinorUnits int64  `json:"unit_price_minor_units"`
+	Currency            string `json:"currency"`
+	Quantity            int    `json:"quantity"`
+	TotalMinorUnits     int64  `json:"total_minor_units"`
+}
+
+type MoneyResponse struct {
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency"`
+}
+
+type ListOrdersResponse struct {
+	Orders []*OrderResponse `json:"orders"`
+	// NextCursor is passed as ?cursor= to fetch the next page; omitted on
+	// the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
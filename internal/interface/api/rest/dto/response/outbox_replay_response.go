@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+type OutboxReplayResponse struct {
+	Id          string     `json:"id"`
+	Target      string     `json:"target"`
+	AggregateId string     `json:"aggregate_id,omitempty"`
+	EventName   string     `json:"event_name,omitempty"`
+	From        *time.Time `json:"from,omitempty"`
+	To          *time.Time `json:"to,omitempty"`
+	Status      string     `json:"status"`
+	Total       int64      `json:"total"`
+	Replayed    int64      `json:"replayed"`
+	LastError   string     `json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+type ListOutboxReplaysResponse struct {
+	Replays []*OutboxReplayResponse `json:"replays"`
+}
@@ -0,0 +1,37 @@
+package response
+
+import "time"
+
+type PricePointResponse struct {
+	PriceMinorUnits int64     `json:"price_minor_units"`
+	Currency        string    `json:"currency"`
+	EffectiveAt     time.Time `json:"effective_at"`
+}
+
+type PriceHistoryResponse struct {
+	ProductId string    `json:"product_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	// Prices starts with the price already in effect at From, oldest first.
+	Prices []*PricePointResponse `json:"prices"`
+}
+
+type LowestPriceResponse struct {
+	ProductId string    `json:"product_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	PricePointResponse
+}
+
+type ScheduledPriceChangeResponse struct {
+	Id              string    `json:"id"`
+	ProductId       string    `json:"product_id"`
+	PriceMinorUnits int64     `json:"price_minor_units"`
+	Currency        string    `json:"currency"`
+	EffectiveAt     time.Time `json:"effective_at"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ListScheduledPriceChangesResponse struct {
+	ScheduledPrices []*ScheduledPriceChangeResponse `json:"scheduled_prices"`
+}
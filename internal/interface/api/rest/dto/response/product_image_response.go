@@ -0,0 +1,30 @@
+package response
+
+import "time"
+
+// ProductImageResponse describes one product image. Url serves the image
+// data.
+type ProductImageResponse struct {
+	Id          string    `json:"id"`
+	Url         string    `json:"url"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	Position    int       `json:"position"`
+	Primary     bool      `json:"primary"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ProductImagesResponse lists a product's images in display order. Version
+// is the product's version, to send in If-Match on the next change.
+type ProductImagesResponse struct {
+	ProductId string                  `json:"product_id"`
+	Images    []*ProductImageResponse `json:"images"`
+	Version   int                     `json:"version"`
+}
+
+// UploadProductImageResponse is the uploaded image together with all of the
+// product's images.
+type UploadProductImageResponse struct {
+	Image *ProductImageResponse `json:"image"`
+	ProductImagesResponse
+}
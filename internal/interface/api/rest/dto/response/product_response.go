@@ -3,15 +3,81 @@ package response
 import "time"
 
 type ProductResponse struct {
-	Id              string    `json:"id"`
-	Name            string    `json:"name"`
-	PriceMinorUnits int64     `json:"price_minor_units"`
-	Currency        string    `json:"currency"`
-	SellerId        string    `json:"seller_id"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	Id              string                    `json:"id"`
+	Name            string                    `json:"name"`
+	PriceMinorUnits int64                     `json:"price_minor_units"`
+	Currency        string                    `json:"currency"`
+	SellerId        string                    `json:"seller_id"`
+	Status          string                    `json:"status"`
+	CategoryIds     []string                  `json:"category_ids"`
+	Variants        []*ProductVariantResponse `json:"variants"`
+	Images          []*ProductImageResponse   `json:"images"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+	Version         int                       `json:"version"`
+	// EffectivePrice is the price after the seller's running promotions.
+	EffectivePrice *EffectivePriceResponse `json:"effective_price,omitempty"`
+	// DisplayPrice is only set when a display_currency was requested.
+	DisplayPrice *DisplayPriceResponse `json:"display_price,omitempty"`
+}
+
+// ProductVariantResponse is one sellable variant. Price is what the variant
+// costs: price_override when set, else the product's price.
+type ProductVariantResponse struct {
+	Id         string            `json:"id"`
+	Sku        string            `json:"sku"`
+	Attributes map[string]string `json:"attributes"`
+	// PriceOverrideMinorUnits is omitted when the variant sells at the
+	// product's price.
+	PriceOverrideMinorUnits *int64 `json:"price_override_minor_units,omitempty"`
+	PriceMinorUnits         int64  `json:"price_minor_units"`
+	Currency                string `json:"currency"`
+	// EffectivePrice is the variant's price after the seller's running
+	// promotions; checkout charges it.
+	EffectivePrice *EffectivePriceResponse `json:"effective_price,omitempty"`
+}
+
+// DisplayPriceResponse is a price converted for display. It is informative
+// only: orders are charged in the product's own currency.
+type DisplayPriceResponse struct {
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency"`
+	// ExchangeRate is how many units of Currency one unit of the product's
+	// currency buys, as a decimal string; it and RateEffectiveAt are
+	// omitted when the price needed no conversion.
+	ExchangeRate    string     `json:"exchange_rate,omitempty"`
+	RateEffectiveAt *time.Time `json:"rate_effective_at,omitempty"`
+}
+
+// EffectivePriceResponse is what the product costs after promotions. It
+// equals the list price, with no promotion_ids, when none applies.
+type EffectivePriceResponse struct {
+	MinorUnits   int64    `json:"minor_units"`
+	Currency     string   `json:"currency"`
+	PromotionIds []string `json:"promotion_ids"`
 }
 
 type ListProductsResponse struct {
 	Products []*ProductResponse `json:"products"`
+	// NextCursor is passed as ?cursor= to fetch the next page; omitted on
+	// the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ProductSearchResultResponse is one product found by a search.
+type ProductSearchResultResponse struct {
+	Product *ProductResponse `json:"product"`
+	// Rank grows with the relevance of the match.
+	Rank float32 `json:"rank"`
+	// Highlight is the HTML-escaped product name with every matching word
+	// wrapped in <mark> and </mark>.
+	Highlight string `json:"highlight"`
+}
+
+type SearchProductsResponse struct {
+	// Results holds the best matches first.
+	Results []*ProductSearchResultResponse `json:"results"`
+	// NextCursor is passed as ?cursor= along with the same q to fetch the
+	// next page; omitted on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
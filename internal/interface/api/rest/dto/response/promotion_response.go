@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+type PromotionResponse struct {
+	Id           string `json:"id"`
+	SellerId     string `json:"seller_id"`
+	Name         string `json:"name"`
+	DiscountKind string `json:"discount_kind"`
+	// BasisPoints is set for percentage discounts, AmountMinorUnits and
+	// Currency for fixed-amount ones.
+	BasisPoints      int64      `json:"basis_points,omitempty"`
+	AmountMinorUnits int64      `json:"amount_minor_units,omitempty"`
+	Currency         string     `json:"currency,omitempty"`
+	ProductIds       []string   `json:"product_ids"`
+	StartsAt         time.Time  `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at,omitempty"`
+	Stackable        bool       `json:"stackable"`
+	UsageLimit       int        `json:"usage_limit"`
+	UsageCount       int        `json:"usage_count"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	Version          int        `json:"version"`
+}
+
+type ListPromotionsResponse struct {
+	Promotions []*PromotionResponse `json:"promotions"`
+}
@@ -3,12 +3,17 @@ package response
 import "time"
 
 type SellerResponse struct {
-	Id        string    `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id                 string    `json:"id"`
+	Name               string    `json:"name"`
+	VerificationStatus string    `json:"verification_status"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	Version            int       `json:"version"`
 }
 
 type ListSellersResponse struct {
 	Sellers []*SellerResponse `json:"sellers"`
+	// NextCursor is passed as ?cursor= to fetch the next page; omitted on
+	// the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
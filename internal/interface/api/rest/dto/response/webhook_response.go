@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+// WebhookSubscriptionResponse never includes the secret.
+type WebhookSubscriptionResponse struct {
+	Id         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ListWebhookSubscriptionsResponse struct {
+	Subscriptions []*WebhookSubscriptionResponse `json:"subscriptions"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id             string     `json:"id"`
+	EventId        string     `json:"event_id"`
+	EventName      string     `json:"event_name"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []*WebhookDeliveryResponse `json:"deliveries"`
+}
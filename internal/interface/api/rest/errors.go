@@ -10,16 +10,41 @@ import (
 )
 
 // writeCommandError maps well-known service errors to HTTP status codes so
-// clients get 404/409 instead of a generic 500.
+// clients get 404/409 instead of a generic 500. A version conflict is a 412
+// when the client asked for a conditional write via If-Match and a 409 when
+// a concurrent writer won the race on an unconditional one.
 func writeCommandError(c echo.Context, err error, fallback string) error {
 	switch {
-	case errors.Is(err, entities.ErrProductNotFound), errors.Is(err, entities.ErrSellerNotFound):
+	case errors.Is(err, entities.ErrProductNotFound), errors.Is(err, entities.ErrSellerNotFound),
+		errors.Is(err, entities.ErrReservationNotFound), errors.Is(err, entities.ErrOrderNotFound),
+		errors.Is(err, entities.ErrCartItemNotFound), errors.Is(err, entities.ErrScheduledPriceChangeNotFound),
+		errors.Is(err, entities.ErrPromotionNotFound), errors.Is(err, entities.ErrCategoryNotFound),
+		errors.Is(err, entities.ErrVariantNotFound), errors.Is(err, entities.ErrImageNotFound),
+		errors.Is(err, entities.ErrBlobNotFound), errors.Is(err, entities.ErrDeadLetterNotFound),
+		errors.Is(err, entities.ErrWebhookSubscriptionNotFound), errors.Is(err, entities.ErrOutboxReplayNotFound):
 		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, entities.ErrUnsupportedMediaType):
+		return c.JSON(http.StatusUnsupportedMediaType, map[string]string{"error": err.Error()})
+	case errors.Is(err, entities.ErrImageTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": err.Error()})
 	case errors.Is(err, entities.ErrValidation):
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, entities.ErrVersionConflict):
+		status := http.StatusConflict
+		if c.Request().Header.Get(ifMatchHeader) != "" {
+			status = http.StatusPreconditionFailed
+		}
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	case errors.Is(err, entities.ErrInsufficientStock), errors.Is(err, entities.ErrInvalidOrderTransition),
+		errors.Is(err, entities.ErrCartChanged), errors.Is(err, entities.ErrPromotionExhausted),
+		errors.Is(err, entities.ErrInvalidProductTransition), errors.Is(err, entities.ErrProductArchived),
+		errors.Is(err, entities.ErrProductNotPublished), errors.Is(err, entities.ErrCategoryCycle),
+		errors.Is(err, entities.ErrCategoryNotEmpty), errors.Is(err, entities.ErrSkuTaken),
+		errors.Is(err, entities.ErrVariantReserved), errors.Is(err, entities.ErrOutboxReplayNotResumable):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
 	case errors.Is(err, services.ErrRequestInFlight):
 		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
-	case errors.Is(err, services.ErrIdempotencyKeyReuse):
+	case errors.Is(err, services.ErrIdempotencyKeyReuse), errors.Is(err, entities.ErrExchangeRateNotFound):
 		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
 	default:
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": fallback})
@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	etagHeader    = "ETag"
+	ifMatchHeader = "If-Match"
+)
+
+// errInvalidIfMatch is returned for an If-Match header that is not a single
+// entity tag issued by this API.
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+// setETag exposes the aggregate version as a strong entity tag so clients
+// can send it back in If-Match on their next write.
+func setETag(c echo.Context, version int) {
+	c.Response().Header().Set(etagHeader, strconv.Quote(strconv.Itoa(version)))
+}
+
+// expectedVersion turns the If-Match header into the version a conditional
+// command expects. No header (or "*", which only asks for the resource to
+// exist) yields nil, i.e. an unconditional write. Weak tags are accepted
+// because the version identifies the representation exactly either way.
+func expectedVersion(c echo.Context) (*int, error) {
+	header := strings.TrimSpace(c.Request().Header.Get(ifMatchHeader))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil || !strings.HasPrefix(tag, `"`) {
+		return nil, errInvalidIfMatch
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return nil, errInvalidIfMatch
+	}
+
+	return &version, nil
+}
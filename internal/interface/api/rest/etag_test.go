@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIfMatchContext(t *testing.T, header string) echo.Context {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPut, "/", nil)
+	if header != "" {
+		req.Header.Set(ifMatchHeader, header)
+	}
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestExpectedVersion(t *testing.T) {
+	tests := []struct {
+		header string
+		want   *int
+	}{
+		{header: "", want: nil},
+		{header: "*", want: nil},
+		{header: `"3"`, want: new(3)},
+		{header: `W/"12"`, want: new(12)},
+	}
+	for _, tt := range tests {
+		version, err := expectedVersion(newIfMatchContext(t, tt.header))
+		require.NoError(t, err, tt.header)
+		assert.Equal(t, tt.want, version, tt.header)
+	}
+}
+
+func TestExpectedVersion_Invalid(t *testing.T) {
+	for _, header := range []string{`3`, `"abc"`, `"0"`, `"1", "2"`, `W/3`} {
+		_, err := expectedVersion(newIfMatchContext(t, header))
+		assert.ErrorIs(t, err, errInvalidIfMatch, header)
+	}
+}
+
+func TestSetETag(t *testing.T) {
+	c := newIfMatchContext(t, "")
+	setETag(c, 4)
+	assert.Equal(t, `"4"`, c.Response().Header().Get(etagHeader))
+}
@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+type InboxController struct {
+	service interfaces.InboxService
+}
+
+// NewInboxController registers the ingestion endpoint for external systems
+// such as the KYC provider. Like the admin endpoints, expose it only behind
+// the gateway's authentication for those systems.
+func NewInboxController(e *echo.Echo, service interfaces.InboxService) *InboxController {
+	controller := &InboxController{service: service}
+
+	e.POST("/api/v1/inbox", controller.ReceiveMessageController)
+
+	return controller
+}
+
+// ReceiveMessageController answers 202 once the message is stored, and for
+// redeliveries too, so senders can retry until they see a 2xx.
+func (ic *InboxController) ReceiveMessageController(c echo.Context) error {
+	// Decoded directly: echo's binder does not accept the
+	// application/cloudevents+json content type.
+	var receiveRequest request.ReceiveInboxMessageRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&receiveRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	receiveCommand, err := receiveRequest.ToReceiveInboxMessageCommand()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := ic.service.Receive(c.Request().Context(), receiveCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to receive message")
+	}
+
+	return c.JSON(http.StatusAccepted, mapper.ToReceiveInboxMessageResponse(receiveCommand, result))
+}
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+type InventoryController struct {
+	service interfaces.InventoryService
+}
+
+func NewInventoryController(e *echo.Echo, service interfaces.InventoryService) *InventoryController {
+	controller := &InventoryController{service: service}
+
+	e.GET("/api/v1/products/:id/inventory", controller.GetInventoryController)
+	e.POST("/api/v1/products/:id/inventory/adjustments", controller.AdjustStockController)
+
+	return controller
+}
+
+// GetInventoryController returns the stock of one variant, chosen with
+// ?variant_id=; it may be omitted for products with a single variant.
+func (ic *InventoryController) GetInventoryController(c echo.Context) error {
+	productId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	variantId, err := variantIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	inventory, err := ic.service.GetInventory(c.Request().Context(), &query.GetInventoryQuery{ProductId: productId, VariantId: variantId})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch inventory")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToInventoryResponse(inventory.Result))
+}
+
+// AdjustStockController adds or removes units on hand. Units held by
+// reservations cannot be removed (409).
+func (ic *InventoryController) AdjustStockController(c echo.Context) error {
+	productId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	var adjustStockRequest request.AdjustStockRequest
+	if err := c.Bind(&adjustStockRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	adjustCommand, err := adjustStockRequest.ToAdjustStockCommand(productId)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	adjustCommand.IdempotencyKey = idempotencyKey(c, adjustCommand.IdempotencyKey)
+
+	result, err := ic.service.AdjustStock(c.Request().Context(), adjustCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to adjust stock")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToInventoryResponse(result.Result))
+}
@@ -0,0 +1,282 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+// multipartOverhead is what an upload may add on top of the image itself:
+// boundaries, part headers and the other form fields.
+const multipartOverhead = 64 << 10
+
+type MediaController struct {
+	service       interfaces.MediaService
+	maxImageBytes int64
+}
+
+func NewMediaController(e *echo.Echo, service interfaces.MediaService, maxImageBytes int64) *MediaController {
+	controller := &MediaController{
+		service:       service,
+		maxImageBytes: maxImageBytes,
+	}
+
+	e.GET("/api/v1/products/:id/images", controller.GetProductImagesController)
+	e.POST("/api/v1/products/:id/images", controller.UploadProductImageController)
+	e.PUT("/api/v1/products/:id/images/order", controller.ReorderProductImagesController)
+	e.GET("/api/v1/products/:id/images/:image_id", controller.GetProductImageController)
+	e.DELETE("/api/v1/products/:id/images/:image_id", controller.RemoveProductImageController)
+	e.POST("/api/v1/products/:id/images/:image_id/primary", controller.SetPrimaryProductImageController)
+
+	return controller
+}
+
+func (mc *MediaController) GetProductImagesController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	result, err := mc.service.GetProductImages(c.Request().Context(), &query.GetProductImagesQuery{ProductId: id})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch product images")
+	}
+
+	response := mapper.ToProductImagesResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetProductImageController streams the image data. An image never changes
+// once uploaded, so clients may cache it for good.
+func (mc *MediaController) GetProductImageController(c echo.Context) error {
+	id, imageId, err := productImageIds(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := mc.service.OpenProductImage(c.Request().Context(), &query.GetProductImageQuery{ProductId: id, ImageId: imageId})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch product image")
+	}
+	defer func() { _ = result.Body.Close() }()
+
+	c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(result.Image.SizeBytes, 10))
+	c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+
+	return c.Stream(http.StatusOK, result.Image.ContentType, result.Body)
+}
+
+// UploadProductImageController takes a multipart/form-data body with the
+// file in the "image" field and an optional "primary" field. It answers 201
+// with the new image and the product's images, 413 for files above the size
+// limit and 415 for anything but a JPEG, PNG, GIF or WebP image.
+func (mc *MediaController) UploadProductImageController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, mc.maxImageBytes+multipartOverhead)
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{
+				"error": "Image exceeds the size limit",
+			})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Missing image file",
+		})
+	}
+
+	primary := false
+	if raw := c.FormValue("primary"); raw != "" {
+		if primary, err = strconv.ParseBool(raw); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid primary value",
+			})
+		}
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to read image file",
+		})
+	}
+	defer func() { _ = file.Close() }()
+	// One byte past the limit is enough for the service to reject it.
+	data, err := io.ReadAll(io.LimitReader(file, mc.maxImageBytes+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to read image file",
+		})
+	}
+
+	// Clients that cannot tell the type send application/octet-stream;
+	// the service then goes by the data alone.
+	contentType := fileHeader.Header.Get(echo.HeaderContentType)
+	if contentType == echo.MIMEOctetStream {
+		contentType = ""
+	}
+
+	result, err := mc.service.UploadProductImage(req.Context(), &command.UploadProductImageCommand{
+		IdempotencyKey:  idempotencyKey(c, c.FormValue("idempotency_key")),
+		ProductId:       id,
+		Data:            data,
+		ContentType:     contentType,
+		Primary:         primary,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to upload product image")
+	}
+
+	response := mapper.ToUploadProductImageResponse(result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// RemoveProductImageController answers 200 with the remaining images. The
+// image data is deleted in the background once the removal is committed.
+func (mc *MediaController) RemoveProductImageController(c echo.Context) error {
+	id, imageId, err := productImageIds(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := mc.service.RemoveProductImage(c.Request().Context(), &command.RemoveProductImageCommand{
+		IdempotencyKey:  idempotencyKey(c, ""),
+		ProductId:       id,
+		ImageId:         imageId,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to remove product image")
+	}
+
+	response := mapper.ToProductImagesResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (mc *MediaController) ReorderProductImagesController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	var reorderRequest request.ReorderProductImagesRequest
+	if err := c.Bind(&reorderRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	reorderCommand, err := reorderRequest.ToReorderProductImagesCommand(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	reorderCommand.IdempotencyKey = idempotencyKey(c, reorderCommand.IdempotencyKey)
+
+	reorderCommand.ExpectedVersion, err = expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := mc.service.ReorderProductImages(c.Request().Context(), reorderCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to reorder product images")
+	}
+
+	response := mapper.ToProductImagesResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (mc *MediaController) SetPrimaryProductImageController(c echo.Context) error {
+	id, imageId, err := productImageIds(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := mc.service.SetPrimaryProductImage(c.Request().Context(), &command.SetPrimaryProductImageCommand{
+		IdempotencyKey:  idempotencyKey(c, ""),
+		ProductId:       id,
+		ImageId:         imageId,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to set primary product image")
+	}
+
+	response := mapper.ToProductImagesResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func productImageIds(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid product Id format")
+	}
+	imageId, err := uuid.Parse(c.Param("image_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid image Id format")
+	}
+	return id, imageId, nil
+}
@@ -0,0 +1,222 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+type OrderController struct {
+	service interfaces.OrderService
+}
+
+// NewOrderController registers checkout and one endpoint per status
+// change. Status changes accept If-Match like product updates do.
+func NewOrderController(e *echo.Echo, service interfaces.OrderService) *OrderController {
+	controller := &OrderController{service: service}
+
+	e.POST("/api/v1/orders", controller.CreateOrderController)
+	e.GET("/api/v1/orders", controller.GetAllOrdersController)
+	e.GET("/api/v1/orders/:id", controller.GetOrderByIdController)
+	e.POST("/api/v1/orders/:id/pay", controller.PayOrderController)
+	e.POST("/api/v1/orders/:id/ship", controller.ShipOrderController)
+	e.POST("/api/v1/orders/:id/deliver", controller.DeliverOrderController)
+	e.POST("/api/v1/orders/:id/cancel", controller.CancelOrderController)
+	e.POST("/api/v1/orders/:id/refund", controller.RefundOrderController)
+
+	return controller
+}
+
+func (oc *OrderController) CreateOrderController(c echo.Context) error {
+	var createOrderRequest request.CreateOrderRequest
+	if err := c.Bind(&createOrderRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	orderCommand, err := createOrderRequest.ToCreateOrderCommand()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	orderCommand.IdempotencyKey = idempotencyKey(c, orderCommand.IdempotencyKey)
+
+	result, err := oc.service.CreateOrder(c.Request().Context(), orderCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to create order")
+	}
+
+	return oc.writeOrder(c, http.StatusCreated, result.Result)
+}
+
+func (oc *OrderController) GetAllOrdersController(c echo.Context) error {
+	var listOrdersRequest request.ListOrdersRequest
+	if err := c.Bind(&listOrdersRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse query parameters",
+		})
+	}
+
+	ordersQuery, err := listOrdersRequest.ToGetAllOrdersQuery()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid buyer Id format",
+		})
+	}
+
+	orders, err := oc.service.FindAllOrders(c.Request().Context(), ordersQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch orders")
+	}
+
+	response := mapper.ToOrderListResponse(orders.Result)
+	response.NextCursor = orders.NextCursor
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (oc *OrderController) GetOrderByIdController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid order Id format",
+		})
+	}
+
+	order, err := oc.service.FindOrderById(c.Request().Context(), &query.GetOrderByIdQuery{Id: id})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch order",
+		})
+	}
+
+	if order == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Order not found",
+		})
+	}
+
+	return oc.writeOrder(c, http.StatusOK, order.Result)
+}
+
+func (oc *OrderController) PayOrderController(c echo.Context) error {
+	return oc.transition(c, nil, func(id uuid.UUID, version *int) (*common.OrderResult, error) {
+		result, err := oc.service.PayOrder(c.Request().Context(), &command.PayOrderCommand{
+			IdempotencyKey:  idempotencyKey(c, ""),
+			Id:              id,
+			ExpectedVersion: version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Result, nil
+	})
+}
+
+func (oc *OrderController) ShipOrderController(c echo.Context) error {
+	var shipOrderRequest request.ShipOrderRequest
+	return oc.transition(c, &shipOrderRequest, func(id uuid.UUID, version *int) (*common.OrderResult, error) {
+		orderCommand := shipOrderRequest.ToShipOrderCommand(id)
+		orderCommand.IdempotencyKey = idempotencyKey(c, orderCommand.IdempotencyKey)
+		orderCommand.ExpectedVersion = version
+
+		result, err := oc.service.ShipOrder(c.Request().Context(), orderCommand)
+		if err != nil {
+			return nil, err
+		}
+		return result.Result, nil
+	})
+}
+
+func (oc *OrderController) DeliverOrderController(c echo.Context) error {
+	return oc.transition(c, nil, func(id uuid.UUID, version *int) (*common.OrderResult, error) {
+		result, err := oc.service.DeliverOrder(c.Request().Context(), &command.DeliverOrderCommand{
+			IdempotencyKey:  idempotencyKey(c, ""),
+			Id:              id,
+			ExpectedVersion: version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Result, nil
+	})
+}
+
+func (oc *OrderController) CancelOrderController(c echo.Context) error {
+	var cancelOrderRequest request.CancelOrderRequest
+	return oc.transition(c, &cancelOrderRequest, func(id uuid.UUID, version *int) (*common.OrderResult, error) {
+		orderCommand := cancelOrderRequest.ToCancelOrderCommand(id)
+		orderCommand.IdempotencyKey = idempotencyKey(c, orderCommand.IdempotencyKey)
+		orderCommand.ExpectedVersion = version
+
+		result, err := oc.service.CancelOrder(c.Request().Context(), orderCommand)
+		if err != nil {
+			return nil, err
+		}
+		return result.Result, nil
+	})
+}
+
+func (oc *OrderController) RefundOrderController(c echo.Context) error {
+	var refundOrderRequest request.RefundOrderRequest
+	return oc.transition(c, &refundOrderRequest, func(id uuid.UUID, version *int) (*common.OrderResult, error) {
+		orderCommand := refundOrderRequest.ToRefundOrderCommand(id)
+		orderCommand.IdempotencyKey = idempotencyKey(c, orderCommand.IdempotencyKey)
+		orderCommand.ExpectedVersion = version
+
+		result, err := oc.service.RefundOrder(c.Request().Context(), orderCommand)
+		if err != nil {
+			return nil, err
+		}
+		return result.Result, nil
+	})
+}
+
+// transition parses the order id, the If-Match header and the optional
+// body (nil for endpoints without one), then runs the status change.
+func (oc *OrderController) transition(c echo.Context, body any, run func(id uuid.UUID, version *int) (*common.OrderResult, error)) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid order Id format",
+		})
+	}
+
+	if body != nil {
+		if err := c.Bind(body); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Failed to parse request body",
+			})
+		}
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := run(id, version)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to update order")
+	}
+
+	return oc.writeOrder(c, http.StatusOK, result)
+}
+
+func (oc *OrderController) writeOrder(c echo.Context, status int, order *common.OrderResult) error {
+	response := mapper.ToOrderResponse(order)
+	setETag(c, response.Version)
+
+	return c.JSON(status, response)
+}
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+)
+
+type OutboxController struct {
+	service interfaces.DeadLetterService
+}
+
+// NewOutboxController registers the admin endpoints for dead-lettered outbox
+// events. They are operator tools: expose them only behind the gateway's
+// admin authentication.
+func NewOutboxController(e *echo.Echo, service interfaces.DeadLetterService) *OutboxController {
+	controller := &OutboxController{service: service}
+
+	e.GET("/api/v1/admin/outbox/dead-letters", controller.ListDeadLettersController)
+	e.POST("/api/v1/admin/outbox/dead-letters/:id/replay", controller.ReplayDeadLetterController)
+	e.DELETE("/api/v1/admin/outbox/dead-letters/:id", controller.DiscardDeadLetterController)
+
+	return controller
+}
+
+func (oc *OutboxController) ListDeadLettersController(c echo.Context) error {
+	var deadLetterQuery query.GetDeadLettersQuery
+	if raw := c.QueryParam("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "limit must be a positive integer",
+			})
+		}
+		deadLetterQuery.Limit = parsed
+	}
+
+	deadLetters, err := oc.service.FindDeadLetters(c.Request().Context(), &deadLetterQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch dead letters")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToDeadLetterListResponse(deadLetters.Result))
+}
+
+func (oc *OutboxController) ReplayDeadLetterController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid event Id format",
+		})
+	}
+
+	if _, err := oc.service.ReplayDeadLetter(c.Request().Context(), &command.ReplayDeadLetterCommand{Id: id}); err != nil {
+		return writeCommandError(c, err, "Failed to replay dead letter")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (oc *OutboxController) DiscardDeadLetterController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid event Id format",
+		})
+	}
+
+	if _, err := oc.service.DiscardDeadLetter(c.Request().Context(), &command.DiscardDeadLetterCommand{Id: id}); err != nil {
+		return writeCommandError(c, err, "Failed to discard dead letter")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+type OutboxReplayController struct {
+	service interfaces.OutboxReplayService
+}
+
+// NewOutboxReplayController registers the admin endpoints for event
+// replays. Like the dead-letter endpoints, expose them only behind the
+// gateway's admin authentication.
+func NewOutboxReplayController(e *echo.Echo, service interfaces.OutboxReplayService) *OutboxReplayController {
+	controller := &OutboxReplayController{service: service}
+
+	e.POST("/api/v1/admin/outbox/replays", controller.StartReplayController)
+	e.GET("/api/v1/admin/outbox/replays", controller.ListReplaysController)
+	e.GET("/api/v1/admin/outbox/replays/:id", controller.GetReplayController)
+	e.POST("/api/v1/admin/outbox/replays/:id/resume", controller.ResumeReplayController)
+
+	return controller
+}
+
+func (rc *OutboxReplayController) StartReplayController(c echo.Context) error {
+	var startRequest request.CreateOutboxReplayRequest
+
+	if err := c.Bind(&startRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	replayCommand, err := startRequest.ToStartOutboxReplayCommand()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid aggregate Id format",
+		})
+	}
+
+	result, err := rc.service.StartOutboxReplay(c.Request().Context(), replayCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to start replay")
+	}
+
+	return c.JSON(http.StatusAccepted, mapper.ToOutboxReplayResponse(result.Result))
+}
+
+func (rc *OutboxReplayController) ListReplaysController(c echo.Context) error {
+	var replayQuery query.GetOutboxReplaysQuery
+	if raw := c.QueryParam("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "limit must be a positive integer",
+			})
+		}
+		replayQuery.Limit = parsed
+	}
+
+	replays, err := rc.service.FindOutboxReplays(c.Request().Context(), &replayQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch replays")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToOutboxReplayListResponse(replays.Result))
+}
+
+func (rc *OutboxReplayController) GetReplayController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid replay Id format",
+		})
+	}
+
+	replay, err := rc.service.FindOutboxReplayById(c.Request().Context(), &query.GetOutboxReplayByIdQuery{Id: id})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch replay")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToOutboxReplayResponse(replay.Result))
+}
+
+func (rc *OutboxReplayController) ResumeReplayController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid replay Id format",
+		})
+	}
+
+	replay, err := rc.service.ResumeOutboxReplay(c.Request().Context(), &command.ResumeOutboxReplayCommand{Id: id})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to resume replay")
+	}
+
+	return c.JSON(http.StatusAccepted, mapper.ToOutboxReplayResponse(replay.Result))
+}
@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+type PriceController struct {
+	service interfaces.PricingService
+}
+
+func NewPriceController(e *echo.Echo, service interfaces.PricingService) *PriceController {
+	controller := &PriceController{service: service}
+
+	e.GET("/api/v1/products/:id/price-history", controller.GetPriceHistoryController)
+	e.GET("/api/v1/products/:id/lowest-price", controller.GetLowestPriceController)
+	e.GET("/api/v1/products/:id/scheduled-prices", controller.GetScheduledPriceChangesController)
+	e.POST("/api/v1/products/:id/scheduled-prices", controller.SchedulePriceChangeController)
+	e.DELETE("/api/v1/products/:id/scheduled-prices/:schedule_id", controller.CancelScheduledPriceChangeController)
+
+	return controller
+}
+
+func (pc *PriceController) GetPriceHistoryController(c echo.Context) error {
+	productId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	var priceHistoryRequest request.PriceHistoryRequest
+	if err := c.Bind(&priceHistoryRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse query parameters",
+		})
+	}
+
+	historyQuery, err := priceHistoryRequest.ToGetPriceHistoryQuery(productId)
+	if err != nil {
+		return writeCommandError(c, err, "Invalid query parameters")
+	}
+
+	history, err := pc.service.GetPriceHistory(c.Request().Context(), historyQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch price history")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToPriceHistoryResponse(productId, history))
+}
+
+// GetLowestPriceController returns the lowest price of the last ?days=
+// days, 30 by default: the reference price for advertising a discount.
+func (pc *PriceController) GetLowestPriceController(c echo.Context) error {
+	productId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	var lowestPriceRequest request.LowestPriceRequest
+	if err := c.Bind(&lowestPriceRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse query parameters",
+		})
+	}
+
+	lowestQuery, err := lowestPriceRequest.ToGetLowestPriceQuery(productId)
+	if err != nil {
+		return writeCommandError(c, err, "Invalid query parameters")
+	}
+
+	lowest, err := pc.service.GetLowestPrice(c.Request().Context(), lowestQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch lowest price")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToLowestPriceResponse(productId, lowest))
+}
+
+func (pc *PriceController) GetScheduledPriceChangesController(c echo.Context) error {
+	productId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	changes, err := pc.service.GetScheduledPriceChanges(c.Request().Context(), &query.GetScheduledPriceChangesQuery{ProductId: productId})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch scheduled prices")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToListScheduledPriceChangesResponse(changes.Result))
+}
+
+// SchedulePriceChangeController schedules a price that a background worker
+// applies once effective_at has passed.
+func (pc *PriceController) SchedulePriceChangeController(c echo.Context) error {
+	productId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	var scheduleRequest request.SchedulePriceChangeRequest
+	if err := c.Bind(&scheduleRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	scheduleCommand := scheduleRequest.ToSchedulePriceChangeCommand(productId)
+	scheduleCommand.IdempotencyKey = idempotencyKey(c, scheduleCommand.IdempotencyKey)
+
+	result, err := pc.service.SchedulePriceChange(c.Request().Context(), scheduleCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to schedule price change")
+	}
+
+	return c.JSON(http.StatusCreated, mapper.ToScheduledPriceChangeResponse(result.Result))
+}
+
+func (pc *PriceController) CancelScheduledPriceChangeController(c echo.Context) error {
+	productId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+	scheduleId, err := uuid.Parse(c.Param("schedule_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid schedule Id format",
+		})
+	}
+
+	cancelCommand := &command.CancelScheduledPriceChangeCommand{
+		IdempotencyKey: idempotencyKey(c, ""),
+		ProductId:      productId,
+		Id:             scheduleId,
+	}
+	if _, err := pc.service.CancelScheduledPriceChange(c.Request().Context(), cancelCommand); err != nil {
+		return writeCommandError(c, err, "Failed to cancel scheduled price change")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -1,13 +1,16 @@
 package rest
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
 	"github.com/sklinkert/go-ddd/internal/application/interfaces"
 	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
 	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
 	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
 )
@@ -23,9 +26,18 @@ func NewProductController(e *echo.Echo, service interfaces.ProductService) *Prod
 
 	e.POST("/api/v1/products", controller.CreateProductController)
 	e.GET("/api/v1/products", controller.GetAllProductsController)
+	// Static segments win over parameters in echo's router, so this does
+	// not shadow product ids.
+	e.GET("/api/v1/products/search", controller.SearchProductsController)
 	e.GET("/api/v1/products/:id", controller.GetProductByIdController)
 	e.PUT("/api/v1/products/:id", controller.UpdateProductController)
 	e.DELETE("/api/v1/products/:id", controller.DeleteProductController)
+	e.POST("/api/v1/products/:id/publish", controller.PublishProductController)
+	e.POST("/api/v1/products/:id/archive", controller.ArchiveProductController)
+	e.PUT("/api/v1/products/:id/categories", controller.CategorizeProductController)
+	e.POST("/api/v1/products/:id/variants", controller.AddProductVariantController)
+	e.PUT("/api/v1/products/:id/variants/:variant_id", controller.UpdateProductVariantController)
+	e.DELETE("/api/v1/products/:id/variants/:variant_id", controller.RemoveProductVariantController)
 
 	return controller
 }
@@ -53,19 +65,52 @@ func (pc *ProductController) CreateProductController(c echo.Context) error {
 	}
 
 	response := mapper.ToProductResponse(result.Result)
+	setETag(c, response.Version)
 
 	return c.JSON(http.StatusCreated, response)
 }
 
 func (pc *ProductController) GetAllProductsController(c echo.Context) error {
-	products, err := pc.service.FindAllProducts(c.Request().Context())
+	var listProductsRequest request.ListProductsRequest
+	if err := c.Bind(&listProductsRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse query parameters",
+		})
+	}
+
+	productQuery, err := listProductsRequest.ToGetAllProductsQuery()
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to fetch products",
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid seller or category Id format",
 		})
 	}
 
+	products, err := pc.service.FindAllProducts(c.Request().Context(), productQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch products")
+	}
+
 	response := mapper.ToProductListResponse(products.Result)
+	response.NextCursor = products.NextCursor
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (pc *ProductController) SearchProductsController(c echo.Context) error {
+	var searchProductsRequest request.SearchProductsRequest
+	if err := c.Bind(&searchProductsRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse query parameters",
+		})
+	}
+
+	results, err := pc.service.SearchProducts(c.Request().Context(), searchProductsRequest.ToSearchProductsQuery())
+	if err != nil {
+		return writeCommandError(c, err, "Failed to search products")
+	}
+
+	response := mapper.ToSearchProductsResponse(results.Result)
+	response.NextCursor = results.NextCursor
 
 	return c.JSON(http.StatusOK, response)
 }
@@ -78,11 +123,12 @@ func (pc *ProductController) GetProductByIdController(c echo.Context) error {
 		})
 	}
 
-	product, err := pc.service.FindProductById(c.Request().Context(), &query.GetProductByIdQuery{Id: id})
+	product, err := pc.service.FindProductById(c.Request().Context(), &query.GetProductByIdQuery{
+		Id:              id,
+		DisplayCurrency: entities.Currency(c.QueryParam("display_currency")),
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to fetch product",
-		})
+		return writeCommandError(c, err, "Failed to fetch product")
 	}
 
 	if product == nil {
@@ -92,6 +138,7 @@ func (pc *ProductController) GetProductByIdController(c echo.Context) error {
 	}
 
 	response := mapper.ToProductResponse(product.Result)
+	setETag(c, response.Version)
 
 	return c.JSON(http.StatusOK, response)
 }
@@ -119,12 +166,20 @@ func (pc *ProductController) UpdateProductController(c echo.Context) error {
 	}
 	productCommand.IdempotencyKey = idempotencyKey(c, productCommand.IdempotencyKey)
 
+	productCommand.ExpectedVersion, err = expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	result, err := pc.service.UpdateProduct(c.Request().Context(), productCommand)
 	if err != nil {
 		return writeCommandError(c, err, "Failed to update product")
 	}
 
 	response := mapper.ToProductResponse(result.Result)
+	setETag(c, response.Version)
 
 	return c.JSON(http.StatusOK, response)
 }
@@ -137,9 +192,17 @@ func (pc *ProductController) DeleteProductController(c echo.Context) error {
 		})
 	}
 
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	_, err = pc.service.DeleteProduct(c.Request().Context(), &command.DeleteProductCommand{
-		IdempotencyKey: idempotencyKey(c, ""),
-		Id:             id,
+		IdempotencyKey:  idempotencyKey(c, ""),
+		Id:              id,
+		ExpectedVersion: version,
 	})
 	if err != nil {
 		return writeCommandError(c, err, "Failed to delete product")
@@ -147,3 +210,221 @@ func (pc *ProductController) DeleteProductController(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// CategorizeProductController replaces the product's category assignments.
+func (pc *ProductController) CategorizeProductController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	var categorizeProductRequest request.CategorizeProductRequest
+	if err := c.Bind(&categorizeProductRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	productCommand, err := categorizeProductRequest.ToCategorizeProductCommand(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	productCommand.IdempotencyKey = idempotencyKey(c, productCommand.IdempotencyKey)
+
+	productCommand.ExpectedVersion, err = expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := pc.service.CategorizeProduct(c.Request().Context(), productCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to categorize product")
+	}
+
+	response := mapper.ToProductResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// AddProductVariantController adds a variant and answers 201 with the whole
+// product, since the new variant's Id is only known from the response.
+func (pc *ProductController) AddProductVariantController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	var variantRequest request.ProductVariantRequest
+	if err := c.Bind(&variantRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	variantCommand := variantRequest.ToAddProductVariantCommand(id)
+	variantCommand.IdempotencyKey = idempotencyKey(c, variantCommand.IdempotencyKey)
+
+	variantCommand.ExpectedVersion, err = expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := pc.service.AddProductVariant(c.Request().Context(), variantCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to add product variant")
+	}
+
+	response := mapper.ToProductResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (pc *ProductController) UpdateProductVariantController(c echo.Context) error {
+	id, variantId, err := productVariantIds(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	var variantRequest request.ProductVariantRequest
+	if err := c.Bind(&variantRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	variantCommand := variantRequest.ToUpdateProductVariantCommand(id, variantId)
+	variantCommand.IdempotencyKey = idempotencyKey(c, variantCommand.IdempotencyKey)
+
+	variantCommand.ExpectedVersion, err = expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := pc.service.UpdateProductVariant(c.Request().Context(), variantCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to update product variant")
+	}
+
+	response := mapper.ToProductResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RemoveProductVariantController answers 200 with the remaining product.
+// The last variant cannot be removed (400), nor one with reserved stock
+// (409).
+func (pc *ProductController) RemoveProductVariantController(c echo.Context) error {
+	id, variantId, err := productVariantIds(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := pc.service.RemoveProductVariant(c.Request().Context(), &command.RemoveProductVariantCommand{
+		IdempotencyKey:  idempotencyKey(c, ""),
+		ProductId:       id,
+		VariantId:       variantId,
+		ExpectedVersion: version,
+	})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to remove product variant")
+	}
+
+	response := mapper.ToProductResponse(result.Result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func productVariantIds(c echo.Context) (uuid.UUID, uuid.UUID, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid product Id format")
+	}
+	variantId, err := uuid.Parse(c.Param("variant_id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid variant Id format")
+	}
+	return id, variantId, nil
+}
+
+func (pc *ProductController) PublishProductController(c echo.Context) error {
+	return pc.changeStatus(c, func(id uuid.UUID, version *int) (*common.ProductResult, error) {
+		result, err := pc.service.PublishProduct(c.Request().Context(), &command.PublishProductCommand{
+			IdempotencyKey:  idempotencyKey(c, ""),
+			Id:              id,
+			ExpectedVersion: version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Result, nil
+	})
+}
+
+func (pc *ProductController) ArchiveProductController(c echo.Context) error {
+	return pc.changeStatus(c, func(id uuid.UUID, version *int) (*common.ProductResult, error) {
+		result, err := pc.service.ArchiveProduct(c.Request().Context(), &command.ArchiveProductCommand{
+			IdempotencyKey:  idempotencyKey(c, ""),
+			Id:              id,
+			ExpectedVersion: version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Result, nil
+	})
+}
+
+// changeStatus parses the product id and the If-Match header, then runs the
+// lifecycle transition.
+func (pc *ProductController) changeStatus(c echo.Context, run func(id uuid.UUID, version *int) (*common.ProductResult, error)) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid product Id format",
+		})
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := run(id, version)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to update product")
+	}
+
+	response := mapper.ToProductResponse(result)
+	setETag(c, response.Version)
+
+	return c.JSON(http.StatusOK, response)
+}
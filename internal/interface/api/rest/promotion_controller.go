@@ -0,0 +1,163 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+type PromotionController struct {
+	service interfaces.PromotionService
+}
+
+// NewPromotionController registers the seller promotion endpoints. Ending
+// and redeeming accept If-Match like order status changes do.
+func NewPromotionController(e *echo.Echo, service interfaces.PromotionService) *PromotionController {
+	controller := &PromotionController{service: service}
+
+	e.POST("/api/v1/promotions", controller.CreatePromotionController)
+	e.GET("/api/v1/promotions", controller.GetPromotionsController)
+	e.GET("/api/v1/promotions/:id", controller.GetPromotionByIdController)
+	e.POST("/api/v1/promotions/:id/end", controller.EndPromotionController)
+	e.POST("/api/v1/promotions/:id/redemptions", controller.RedeemPromotionController)
+
+	return controller
+}
+
+func (pc *PromotionController) CreatePromotionController(c echo.Context) error {
+	var createPromotionRequest request.CreatePromotionRequest
+	if err := c.Bind(&createPromotionRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	promotionCommand, err := createPromotionRequest.ToCreatePromotionCommand()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	promotionCommand.IdempotencyKey = idempotencyKey(c, promotionCommand.IdempotencyKey)
+
+	result, err := pc.service.CreatePromotion(c.Request().Context(), promotionCommand)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to create promotion")
+	}
+
+	return pc.writePromotion(c, http.StatusCreated, result.Result)
+}
+
+func (pc *PromotionController) GetPromotionsController(c echo.Context) error {
+	var listPromotionsRequest request.ListPromotionsRequest
+	if err := c.Bind(&listPromotionsRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse query parameters",
+		})
+	}
+
+	promotionsQuery, err := listPromotionsRequest.ToGetPromotionsBySellerQuery()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	promotions, err := pc.service.FindPromotionsBySeller(c.Request().Context(), promotionsQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch promotions")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToPromotionListResponse(promotions.Result))
+}
+
+func (pc *PromotionController) GetPromotionByIdController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid promotion Id format",
+		})
+	}
+
+	promotion, err := pc.service.FindPromotionById(c.Request().Context(), &query.GetPromotionByIdQuery{Id: id})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch promotion",
+		})
+	}
+
+	if promotion == nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Promotion not found",
+		})
+	}
+
+	return pc.writePromotion(c, http.StatusOK, promotion.Result)
+}
+
+func (pc *PromotionController) EndPromotionController(c echo.Context) error {
+	return pc.modify(c, func(id uuid.UUID, version *int) (*common.PromotionResult, error) {
+		result, err := pc.service.EndPromotion(c.Request().Context(), &command.EndPromotionCommand{
+			IdempotencyKey:  idempotencyKey(c, ""),
+			Id:              id,
+			ExpectedVersion: version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Result, nil
+	})
+}
+
+func (pc *PromotionController) RedeemPromotionController(c echo.Context) error {
+	return pc.modify(c, func(id uuid.UUID, version *int) (*common.PromotionResult, error) {
+		result, err := pc.service.RedeemPromotion(c.Request().Context(), &command.RedeemPromotionCommand{
+			IdempotencyKey:  idempotencyKey(c, ""),
+			Id:              id,
+			ExpectedVersion: version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.Result, nil
+	})
+}
+
+// modify parses the promotion id and the If-Match header, then runs the
+// change.
+func (pc *PromotionController) modify(c echo.Context, run func(id uuid.UUID, version *int) (*common.PromotionResult, error)) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid promotion Id format",
+		})
+	}
+
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := run(id, version)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to update promotion")
+	}
+
+	return pc.writePromotion(c, http.StatusOK, result)
+}
+
+func (pc *PromotionController) writePromotion(c echo.Context, status int, promotion *common.PromotionResult) error {
+	response := mapper.ToPromotionResponse(promotion)
+	setETag(c, response.Version)
+
+	return c.JSON(status, response)
+}
@@ -53,19 +53,26 @@ func (sc *SellerController) CreateSellerController(c echo.Context) error {
 	}
 
 	response := mapper.ToSellerResponse(commandResult.Result)
+	setETag(c, response.Version)
 
 	return c.JSON(http.StatusCreated, response)
 }
 
 func (sc *SellerController) GetAllSellersController(c echo.Context) error {
-	sellers, err := sc.service.FindAllSellers(c.Request().Context())
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to fetch sellers",
+	var listSellersRequest request.ListSellersRequest
+	if err := c.Bind(&listSellersRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse query parameters",
 		})
 	}
 
+	sellers, err := sc.service.FindAllSellers(c.Request().Context(), listSellersRequest.ToGetAllSellersQuery())
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch sellers")
+	}
+
 	response := mapper.ToSellerListResponse(sellers.Result)
+	response.NextCursor = sellers.NextCursor
 
 	return c.JSON(http.StatusOK, response)
 }
@@ -92,6 +99,7 @@ func (sc *SellerController) GetSellerByIdController(c echo.Context) error {
 	}
 
 	response := mapper.ToSellerResponse(seller.Result)
+	setETag(c, response.Version)
 
 	return c.JSON(http.StatusOK, response)
 }
@@ -113,12 +121,20 @@ func (sc *SellerController) PutSellerController(c echo.Context) error {
 	}
 	updateSellerCommand.IdempotencyKey = idempotencyKey(c, updateSellerCommand.IdempotencyKey)
 
+	updateSellerCommand.ExpectedVersion, err = expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	commandResult, err := sc.service.UpdateSeller(c.Request().Context(), updateSellerCommand)
 	if err != nil {
 		return writeCommandError(c, err, "Failed to update seller")
 	}
 
 	response := mapper.ToSellerResponse(commandResult.Result)
+	setETag(c, response.Version)
 
 	return c.JSON(http.StatusOK, response)
 }
@@ -131,9 +147,17 @@ func (sc *SellerController) DeleteSellerController(c echo.Context) error {
 		})
 	}
 
+	version, err := expectedVersion(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	_, err = sc.service.DeleteSeller(c.Request().Context(), &command.DeleteSellerCommand{
-		IdempotencyKey: idempotencyKey(c, ""),
-		Id:             id,
+		IdempotencyKey:  idempotencyKey(c, ""),
+		Id:              id,
+		ExpectedVersion: version,
 	})
 	if err != nil {
 		return writeCommandError(c, err, "Failed to delete seller")
@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// variantIdParam reads the optional ?variant_id= query parameter. Without
+// it the result is uuid.Nil, which selects the product's only variant.
+func variantIdParam(c echo.Context) (uuid.UUID, error) {
+	raw := c.QueryParam("variant_id")
+	if raw == "" {
+		return uuid.Nil, nil
+	}
+	variantId, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid variant Id format")
+	}
+	return variantId, nil
+}
@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/interfaces"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/mapper"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/request"
+)
+
+type WebhookController struct {
+	service interfaces.WebhookService
+}
+
+// NewWebhookController registers the webhook subscription endpoints. A
+// subscription makes this service POST to an arbitrary URL, so protect them
+// like the admin endpoints.
+func NewWebhookController(e *echo.Echo, service interfaces.WebhookService) *WebhookController {
+	controller := &WebhookController{service: service}
+
+	e.POST("/api/v1/webhooks/subscriptions", controller.CreateSubscriptionController)
+	e.GET("/api/v1/webhooks/subscriptions", controller.ListSubscriptionsController)
+	e.GET("/api/v1/webhooks/subscriptions/:id", controller.GetSubscriptionController)
+	e.DELETE("/api/v1/webhooks/subscriptions/:id", controller.DeleteSubscriptionController)
+	e.GET("/api/v1/webhooks/subscriptions/:id/deliveries", controller.ListDeliveriesController)
+
+	return controller
+}
+
+func (wc *WebhookController) CreateSubscriptionController(c echo.Context) error {
+	var createRequest request.CreateWebhookSubscriptionRequest
+
+	if err := c.Bind(&createRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to parse request body",
+		})
+	}
+
+	result, err := wc.service.CreateWebhookSubscription(c.Request().Context(), createRequest.ToCreateWebhookSubscriptionCommand())
+	if err != nil {
+		return writeCommandError(c, err, "Failed to create webhook subscription")
+	}
+
+	return c.JSON(http.StatusCreated, mapper.ToWebhookSubscriptionResponse(result.Result))
+}
+
+func (wc *WebhookController) ListSubscriptionsController(c echo.Context) error {
+	subscriptions, err := wc.service.FindAllWebhookSubscriptions(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch webhook subscriptions",
+		})
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToWebhookSubscriptionListResponse(subscriptions.Result))
+}
+
+func (wc *WebhookController) GetSubscriptionController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid subscription Id format",
+		})
+	}
+
+	subscription, err := wc.service.FindWebhookSubscriptionById(c.Request().Context(), &query.GetWebhookSubscriptionByIdQuery{Id: id})
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch webhook subscription")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToWebhookSubscriptionResponse(subscription.Result))
+}
+
+func (wc *WebhookController) DeleteSubscriptionController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid subscription Id format",
+		})
+	}
+
+	if _, err := wc.service.DeleteWebhookSubscription(c.Request().Context(), &command.DeleteWebhookSubscriptionCommand{Id: id}); err != nil {
+		return writeCommandError(c, err, "Failed to delete webhook subscription")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (wc *WebhookController) ListDeliveriesController(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid subscription Id format",
+		})
+	}
+
+	deliveryQuery := query.GetWebhookDeliveriesQuery{SubscriptionId: id}
+	if raw := c.QueryParam("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "limit must be a positive integer",
+			})
+		}
+		deliveryQuery.Limit = parsed
+	}
+
+	deliveries, err := wc.service.FindWebhookDeliveries(c.Request().Context(), &deliveryQuery)
+	if err != nil {
+		return writeCommandError(c, err, "Failed to fetch webhook deliveries")
+	}
+
+	return c.JSON(http.StatusOK, mapper.ToWebhookDeliveryListResponse(deliveries.Result))
+}
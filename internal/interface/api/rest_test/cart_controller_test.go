@@ -0,0 +1,223 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCartService struct {
+	mock.Mock
+}
+
+func (m *MockCartService) GetCart(ctx context.Context, cartQuery *query.GetCartQuery) (*query.GetCartQueryResult, error) {
+	args := m.Called(cartQuery)
+	result, _ := args.Get(0).(*query.GetCartQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockCartService) AddCartItem(ctx context.Context, cartCommand *command.AddCartItemCommand) (*command.AddCartItemCommandResult, error) {
+	args := m.Called(cartCommand)
+	result, _ := args.Get(0).(*command.AddCartItemCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockCartService) UpdateCartItem(ctx context.Context, cartCommand *command.UpdateCartItemCommand) (*command.UpdateCartItemCommandResult, error) {
+	args := m.Called(cartCommand)
+	result, _ := args.Get(0).(*command.UpdateCartItemCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockCartService) RemoveCartItem(ctx context.Context, cartCommand *command.RemoveCartItemCommand) (*command.RemoveCartItemCommandResult, error) {
+	args := m.Called(cartCommand)
+	result, _ := args.Get(0).(*command.RemoveCartItemCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockCartService) CheckoutCart(ctx context.Context, cartCommand *command.CheckoutCartCommand) (*command.CheckoutCartCommandResult, error) {
+	args := m.Called(cartCommand)
+	result, _ := args.Get(0).(*command.CheckoutCartCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockCartService) DeleteExpiredCarts(ctx context.Context, limit int) (int, error) {
+	args := m.Called(limit)
+	return args.Int(0), args.Error(1)
+}
+
+func TestGetCart_Empty(t *testing.T) {
+	e := echo.New()
+	service := new(MockCartService)
+	rest.NewCartController(e, service)
+
+	buyerId := uuid.New()
+	service.On("GetCart", &query.GetCartQuery{BuyerId: buyerId}).Return(&query.GetCartQueryResult{
+		Result: &common.CartResult{BuyerId: buyerId, ExpiresAt: time.Now().Add(time.Hour)},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/carts/"+buyerId.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"), "an unsaved cart has no version")
+	var body response.CartResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Empty(t, body.Items)
+	assert.Nil(t, body.Total)
+}
+
+func TestAddCartItem(t *testing.T) {
+	e := echo.New()
+	service := new(MockCartService)
+	rest.NewCartController(e, service)
+
+	buyerId := uuid.New()
+	productId := uuid.New()
+	unitPrice, err := entities.NewMoney(999, entities.USD)
+	require.NoError(t, err)
+	total, err := entities.NewMoney(1998, entities.USD)
+	require.NoError(t, err)
+	service.On("AddCartItem", &command.AddCartItemCommand{
+		IdempotencyKey: "key-1",
+		BuyerId:        buyerId,
+		ProductId:      productId,
+		Quantity:       2,
+	}).Return(&command.AddCartItemCommandResult{Result: &common.CartResult{
+		BuyerId: buyerId,
+		Lines:   []common.CartLineResult{{ProductId: productId, ProductName: "Widget", UnitPrice: unitPrice, Quantity: 2, Total: total}},
+		Total:   total,
+		Version: 1,
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/carts/"+buyerId.String()+"/items",
+		strings.NewReader(fmt.Sprintf(`{"product_id":%q,"quantity":2}`, productId)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+	var body response.CartResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Items, 1)
+	assert.Equal(t, int64(1998), body.Items[0].TotalMinorUnits)
+	assert.Equal(t, &response.MoneyResponse{MinorUnits: 1998, Currency: "USD"}, body.Total)
+	service.AssertExpectations(t)
+}
+
+func TestUpdateCartItem(t *testing.T) {
+	e := echo.New()
+	service := new(MockCartService)
+	rest.NewCartController(e, service)
+
+	buyerId := uuid.New()
+	productId := uuid.New()
+	version := 3
+	service.On("UpdateCartItem", &command.UpdateCartItemCommand{
+		BuyerId:         buyerId,
+		ProductId:       productId,
+		Quantity:        0,
+		ExpectedVersion: &version,
+	}).Return(&command.UpdateCartItemCommandResult{Result: &common.CartResult{BuyerId: buyerId, Version: 4}}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/carts/"+buyerId.String()+"/items/"+productId.String(),
+		strings.NewReader(`{"quantity":0}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"3"`)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+	service.AssertExpectations(t)
+}
+
+func TestRemoveCartItem_Variant(t *testing.T) {
+	e := echo.New()
+	service := new(MockCartService)
+	rest.NewCartController(e, service)
+
+	buyerId, productId, variantId := uuid.New(), uuid.New(), uuid.New()
+	service.On("RemoveCartItem", &command.RemoveCartItemCommand{
+		BuyerId:   buyerId,
+		ProductId: productId,
+		VariantId: variantId,
+	}).Return(&command.RemoveCartItemCommandResult{Result: &common.CartResult{BuyerId: buyerId, Version: 2}}, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/carts/"+buyerId.String()+"/items/"+productId.String()+"?variant_id="+variantId.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestCheckoutCart(t *testing.T) {
+	e := echo.New()
+	service := new(MockCartService)
+	rest.NewCartController(e, service)
+
+	order := testOrderResult(t, entities.OrderPending, 1)
+	service.On("CheckoutCart", &command.CheckoutCartCommand{BuyerId: order.BuyerId}).
+		Return(&command.CheckoutCartCommandResult{Result: order}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/carts/"+order.BuyerId.String()+"/checkout", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var body response.OrderResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, order.Id.String(), body.Id)
+	service.AssertExpectations(t)
+}
+
+func TestCartCommands_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		method, path, serviceMethod string
+		err                         error
+		status                      int
+	}{
+		"repriced at checkout": {http.MethodPost, "/checkout", "CheckoutCart",
+			fmt.Errorf("%w: Widget now costs 12.99 USD instead of 9.99 USD", entities.ErrCartChanged), http.StatusConflict},
+		"empty cart":          {http.MethodPost, "/checkout", "CheckoutCart", fmt.Errorf("%w: the cart is empty", entities.ErrValidation), http.StatusBadRequest},
+		"mixed currencies":    {http.MethodPost, "/items", "AddCartItem", fmt.Errorf("%w: the cart holds USD items", entities.ErrValidation), http.StatusBadRequest},
+		"unknown product":     {http.MethodPost, "/items", "AddCartItem", entities.ErrProductNotFound, http.StatusNotFound},
+		"not in cart":         {http.MethodDelete, "/items/" + uuid.NewString(), "RemoveCartItem", entities.ErrCartItemNotFound, http.StatusNotFound},
+		"concurrent checkout": {http.MethodPost, "/checkout", "CheckoutCart", entities.ErrVersionConflict, http.StatusConflict},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			service := new(MockCartService)
+			rest.NewCartController(e, service)
+			service.On(tc.serviceMethod, mock.Anything).Return(nil, tc.err)
+
+			req := httptest.NewRequest(tc.method, "/api/v1/carts/"+uuid.NewString()+tc.path,
+				strings.NewReader(fmt.Sprintf(`{"product_id":%q,"quantity":1}`, uuid.New())))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCategoryService struct {
+	mock.Mock
+}
+
+func (m *MockCategoryService) CreateCategory(ctx context.Context, categoryCommand *command.CreateCategoryCommand) (*command.CreateCategoryCommandResult, error) {
+	args := m.Called(categoryCommand)
+	result, _ := args.Get(0).(*command.CreateCategoryCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockCategoryService) FindCategories(ctx context.Context, categoryQuery *query.GetCategoriesQuery) (*query.GetCategoriesQueryResult, error) {
+	args := m.Called(categoryQuery)
+	result, _ := args.Get(0).(*query.GetCategoriesQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockCategoryService) FindCategoryById(ctx context.Context, categoryQuery *query.GetCategoryByIdQuery) (*query.GetCategoryByIdQueryResult, error) {
+	args := m.Called(categoryQuery)
+	result, _ := args.Get(0).(*query.GetCategoryByIdQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockCategoryService) UpdateCategory(ctx context.Context, categoryCommand *command.UpdateCategoryCommand) (*command.UpdateCategoryCommandResult, error) {
+	args := m.Called(categoryCommand)
+	result, _ := args.Get(0).(*command.UpdateCategoryCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockCategoryService) DeleteCategory(ctx context.Context, categoryCommand *command.DeleteCategoryCommand) (*command.DeleteCategoryCommandResult, error) {
+	args := m.Called(categoryCommand)
+	result, _ := args.Get(0).(*command.DeleteCategoryCommandResult)
+	return result, args.Error(1)
+}
+
+func testCategoryResult(parentId *uuid.UUID, version int) *common.CategoryResult {
+	category := &common.CategoryResult{
+		Id:        uuid.New(),
+		Name:      "Chairs",
+		ParentId:  parentId,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Version:   version,
+	}
+	if parentId != nil {
+		category.AncestorIds = []uuid.UUID{*parentId}
+	}
+	return category
+}
+
+func TestCreateCategory(t *testing.T) {
+	e := echo.New()
+	service := new(MockCategoryService)
+	rest.NewCategoryController(e, service, new(MockProductService))
+
+	parentId := uuid.New()
+	created := testCategoryResult(&parentId, 1)
+	service.On("CreateCategory", &command.CreateCategoryCommand{
+		IdempotencyKey: "key-1",
+		Name:           "Chairs",
+		ParentId:       &parentId,
+	}).Return(&command.CreateCategoryCommandResult{Result: created}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/categories", strings.NewReader(fmt.Sprintf(`{"name":"Chairs","parent_id":%q}`, parentId)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+	var body response.CategoryResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, created.Id.String(), body.Id)
+	assert.Equal(t, parentId.String(), *body.ParentId)
+	assert.Equal(t, []string{parentId.String()}, body.AncestorIds)
+	service.AssertExpectations(t)
+}
+
+func TestGetCategories(t *testing.T) {
+	e := echo.New()
+	service := new(MockCategoryService)
+	rest.NewCategoryController(e, service, new(MockProductService))
+
+	parentId := uuid.New()
+	service.On("FindCategories", &query.GetCategoriesQuery{}).Return(&query.GetCategoriesQueryResult{
+		Result: []*common.CategoryResult{testCategoryResult(nil, 1)},
+	}, nil)
+	service.On("FindCategories", &query.GetCategoriesQuery{ParentId: parentId}).Return(&query.GetCategoriesQueryResult{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/categories", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.ListCategoriesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Categories, 1)
+	assert.Nil(t, body.Categories[0].ParentId)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/categories?parent_id="+parentId.String(), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"categories":[]}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/categories?parent_id=nope", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestUpdateCategory(t *testing.T) {
+	e := echo.New()
+	service := new(MockCategoryService)
+	rest.NewCategoryController(e, service, new(MockProductService))
+
+	moved := testCategoryResult(nil, 3)
+	version := 2
+	service.On("UpdateCategory", &command.UpdateCategoryCommand{
+		Id:              moved.Id,
+		Name:            "Chairs",
+		ExpectedVersion: &version,
+	}).Return(&command.UpdateCategoryCommandResult{Result: moved}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/categories/"+moved.Id.String(), strings.NewReader(`{"name":"Chairs"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"2"`)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	service.AssertExpectations(t)
+}
+
+func TestCategoryCommands_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		method     string
+		httpMethod string
+		body       string
+		err        error
+		status     int
+	}{
+		"cycle":            {"UpdateCategory", http.MethodPut, `{"name":"Chairs","parent_id":"` + uuid.NewString() + `"}`, entities.ErrCategoryCycle, http.StatusConflict},
+		"unknown parent":   {"UpdateCategory", http.MethodPut, `{"name":"Chairs","parent_id":"` + uuid.NewString() + `"}`, entities.ErrCategoryNotFound, http.StatusNotFound},
+		"invalid parent":   {"", http.MethodPut, `{"name":"Chairs","parent_id":"nope"}`, nil, http.StatusBadRequest},
+		"not empty":        {"DeleteCategory", http.MethodDelete, "", entities.ErrCategoryNotEmpty, http.StatusConflict},
+		"unknown category": {"DeleteCategory", http.MethodDelete, "", entities.ErrCategoryNotFound, http.StatusNotFound},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			service := new(MockCategoryService)
+			rest.NewCategoryController(e, service, new(MockProductService))
+			if tc.method != "" {
+				service.On(tc.method, mock.Anything).Return(nil, tc.err)
+			}
+
+			req := httptest.NewRequest(tc.httpMethod, "/api/v1/categories/"+uuid.NewString(), strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	e := echo.New()
+	service := new(MockCategoryService)
+	rest.NewCategoryController(e, service, new(MockProductService))
+
+	id := uuid.New()
+	service.On("DeleteCategory", &command.DeleteCategoryCommand{Id: id}).Return(&command.DeleteCategoryCommandResult{Success: true}, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/categories/"+id.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestGetCategoryProducts(t *testing.T) {
+	e := echo.New()
+	service := new(MockCategoryService)
+	productService := new(MockProductService)
+	rest.NewCategoryController(e, service, productService)
+
+	category := testCategoryResult(nil, 1)
+	service.On("FindCategoryById", &query.GetCategoryByIdQuery{Id: category.Id}).Return(&query.GetCategoryByIdQueryResult{Result: category}, nil)
+	service.On("FindCategoryById", mock.Anything).Return(nil, nil)
+	productService.On("FindAllProducts", &query.GetAllProductsQuery{
+		Limit:      10,
+		Status:     entities.ProductPublished,
+		CategoryId: category.Id,
+	}).Return([]*entities.Product{}, nil)
+
+	// The path wins over a category_id in the query string.
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/categories/"+category.Id.String()+"/products?limit=10&status=published&category_id="+uuid.NewString(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"products":[]}`, rec.Body.String())
+	productService.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/categories/"+uuid.NewString()+"/products", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	productService.AssertNumberOfCalls(t, "FindAllProducts", 1)
+}
@@ -0,0 +1,83 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockInboxService struct {
+	mock.Mock
+}
+
+func (m *MockInboxService) Receive(ctx context.Context, receiveCommand *command.ReceiveInboxMessageCommand) (*command.ReceiveInboxMessageCommandResult, error) {
+	args := m.Called(receiveCommand)
+	result, _ := args.Get(0).(*command.ReceiveInboxMessageCommandResult)
+	return result, args.Error(1)
+}
+
+const verificationEvent = `{
+	"specversion": "1.0",
+	"id": "kyc-123",
+	"source": "kyc",
+	"type": "kyc.verification_completed",
+	"data": {"seller_id": "0198c0de-0000-7000-8000-000000000000", "result": "verified"}
+}`
+
+func postInboxMessage(e *echo.Echo, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/inbox", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, "application/cloudevents+json")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReceiveInboxMessage(t *testing.T) {
+	for _, duplicate := range []bool{false, true} {
+		t.Run(fmt.Sprintf("duplicate=%v", duplicate), func(t *testing.T) {
+			e := echo.New()
+			service := new(MockInboxService)
+			rest.NewInboxController(e, service)
+
+			service.On("Receive", mock.MatchedBy(func(cmd *command.ReceiveInboxMessageCommand) bool {
+				return cmd.Source == "kyc" && cmd.MessageId == "kyc-123" && cmd.Type == "kyc.verification_completed" &&
+					strings.Contains(string(cmd.Payload), `"result": "verified"`)
+			})).Return(&command.ReceiveInboxMessageCommandResult{Duplicate: duplicate}, nil)
+
+			rec := postInboxMessage(e, verificationEvent)
+
+			assert.Equal(t, http.StatusAccepted, rec.Code, "redeliveries are acknowledged too")
+			var body response.ReceiveInboxMessageResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, "kyc-123", body.Id)
+			assert.Equal(t, duplicate, body.Duplicate)
+			service.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReceiveInboxMessage_Rejected(t *testing.T) {
+	e := echo.New()
+	service := new(MockInboxService)
+	rest.NewInboxController(e, service)
+	service.On("Receive", mock.Anything).Return(nil, fmt.Errorf("%w: unsupported message type", entities.ErrValidation))
+
+	assert.Equal(t, http.StatusBadRequest, postInboxMessage(e, `{"specversion":`).Code)
+	assert.Equal(t, http.StatusBadRequest, postInboxMessage(e, strings.Replace(verificationEvent, `"1.0"`, `"0.3"`, 1)).Code)
+	service.AssertNotCalled(t, "Receive", mock.Anything)
+
+	assert.Equal(t, http.StatusBadRequest, postInboxMessage(e, verificationEvent).Code)
+}
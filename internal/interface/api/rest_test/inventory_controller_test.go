@@ -0,0 +1,190 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockInventoryService struct {
+	mock.Mock
+}
+
+func (m *MockInventoryService) GetInventory(ctx context.Context, inventoryQuery *query.GetInventoryQuery) (*query.GetInventoryQueryResult, error) {
+	args := m.Called(inventoryQuery)
+	result, _ := args.Get(0).(*query.GetInventoryQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockInventoryService) AdjustStock(ctx context.Context, adjustCommand *command.AdjustStockCommand) (*command.AdjustStockCommandResult, error) {
+	args := m.Called(adjustCommand)
+	result, _ := args.Get(0).(*command.AdjustStockCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockInventoryService) ReserveStock(ctx context.Context, reserveCommand *command.ReserveStockCommand) (*command.ReserveStockCommandResult, error) {
+	args := m.Called(reserveCommand)
+	result, _ := args.Get(0).(*command.ReserveStockCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockInventoryService) ReleaseReservation(ctx context.Context, releaseCommand *command.ReleaseReservationCommand) (*command.ReleaseReservationCommandResult, error) {
+	args := m.Called(releaseCommand)
+	result, _ := args.Get(0).(*command.ReleaseReservationCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockInventoryService) CommitReservation(ctx context.Context, commitCommand *command.CommitReservationCommand) (*command.CommitReservationCommandResult, error) {
+	args := m.Called(commitCommand)
+	result, _ := args.Get(0).(*command.CommitReservationCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockInventoryService) ReleaseExpiredReservations(ctx context.Context, limit int) (int, error) {
+	args := m.Called(limit)
+	return args.Int(0), args.Error(1)
+}
+
+func TestGetInventory(t *testing.T) {
+	e := echo.New()
+	service := new(MockInventoryService)
+	rest.NewInventoryController(e, service)
+
+	productId := uuid.New()
+	reservationId := uuid.New()
+	service.On("GetInventory", &query.GetInventoryQuery{ProductId: productId}).Return(&query.GetInventoryQueryResult{
+		Result: &common.InventoryResult{
+			ProductId:    productId,
+			OnHand:       5,
+			Reserved:     2,
+			Available:    3,
+			Reservations: []common.ReservationResult{{Id: reservationId, Quantity: 2, ExpiresAt: time.Now().Add(time.Minute)}},
+			Version:      3,
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/inventory", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.InventoryResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, productId.String(), body.ProductId)
+	assert.Equal(t, 3, body.Available)
+	require.Len(t, body.Reservations, 1)
+	assert.Equal(t, reservationId.String(), body.Reservations[0].Id)
+	service.AssertExpectations(t)
+}
+
+func TestGetInventory_Variant(t *testing.T) {
+	e := echo.New()
+	service := new(MockInventoryService)
+	rest.NewInventoryController(e, service)
+
+	productId, variantId := uuid.New(), uuid.New()
+	service.On("GetInventory", &query.GetInventoryQuery{ProductId: productId, VariantId: variantId}).Return(&query.GetInventoryQueryResult{
+		Result: &common.InventoryResult{ProductId: productId, VariantId: variantId, OnHand: 1, Available: 1, Version: 1},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/inventory?variant_id="+variantId.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.InventoryResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, variantId.String(), body.VariantId)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/inventory?variant_id=nope", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestGetInventory_UnknownProduct(t *testing.T) {
+	e := echo.New()
+	service := new(MockInventoryService)
+	rest.NewInventoryController(e, service)
+	service.On("GetInventory", mock.Anything).Return(nil, entities.ErrProductNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+uuid.NewString()+"/inventory", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdjustStock(t *testing.T) {
+	e := echo.New()
+	service := new(MockInventoryService)
+	rest.NewInventoryController(e, service)
+
+	productId := uuid.New()
+	service.On("AdjustStock", &command.AdjustStockCommand{
+		IdempotencyKey: "key-1",
+		ProductId:      productId,
+		Delta:          10,
+		Reason:         "delivery",
+	}).Return(&command.AdjustStockCommandResult{
+		Result: &common.InventoryResult{ProductId: productId, OnHand: 10, Available: 10, Version: 2},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+productId.String()+"/inventory/adjustments",
+		strings.NewReader(`{"delta":10,"reason":"delivery"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.InventoryResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, 10, body.OnHand)
+	assert.Empty(t, body.Reservations)
+	service.AssertExpectations(t)
+}
+
+func TestAdjustStock_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err    error
+		status int
+	}{
+		"reserved units":  {fmt.Errorf("%w: 3 of 5 units on hand are reserved", entities.ErrInsufficientStock), http.StatusConflict},
+		"negative stock":  {fmt.Errorf("%w: only 5 units on hand", entities.ErrValidation), http.StatusBadRequest},
+		"unknown product": {entities.ErrProductNotFound, http.StatusNotFound},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			service := new(MockInventoryService)
+			rest.NewInventoryController(e, service)
+			service.On("AdjustStock", mock.Anything).Return(nil, tc.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+uuid.NewString()+"/inventory/adjustments",
+				strings.NewReader(`{"delta":-6}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
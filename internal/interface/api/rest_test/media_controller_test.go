@@ -0,0 +1,250 @@
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockMediaService struct {
+	mock.Mock
+}
+
+func (m *MockMediaService) GetProductImages(ctx context.Context, imagesQuery *query.GetProductImagesQuery) (*query.GetProductImagesQueryResult, error) {
+	args := m.Called(imagesQuery)
+	result, _ := args.Get(0).(*query.GetProductImagesQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockMediaService) OpenProductImage(ctx context.Context, imageQuery *query.GetProductImageQuery) (*query.GetProductImageQueryResult, error) {
+	args := m.Called(imageQuery)
+	result, _ := args.Get(0).(*query.GetProductImageQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockMediaService) UploadProductImage(ctx context.Context, uploadCommand *command.UploadProductImageCommand) (*command.UploadProductImageCommandResult, error) {
+	args := m.Called(uploadCommand)
+	result, _ := args.Get(0).(*command.UploadProductImageCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockMediaService) RemoveProductImage(ctx context.Context, removeCommand *command.RemoveProductImageCommand) (*command.RemoveProductImageCommandResult, error) {
+	args := m.Called(removeCommand)
+	result, _ := args.Get(0).(*command.RemoveProductImageCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockMediaService) ReorderProductImages(ctx context.Context, reorderCommand *command.ReorderProductImagesCommand) (*command.ReorderProductImagesCommandResult, error) {
+	args := m.Called(reorderCommand)
+	result, _ := args.Get(0).(*command.ReorderProductImagesCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockMediaService) SetPrimaryProductImage(ctx context.Context, primaryCommand *command.SetPrimaryProductImageCommand) (*command.SetPrimaryProductImageCommandResult, error) {
+	args := m.Called(primaryCommand)
+	result, _ := args.Get(0).(*command.SetPrimaryProductImageCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockMediaService) PurgeDeletedBlobs(ctx context.Context, limit int) (int, error) {
+	args := m.Called(limit)
+	return args.Int(0), args.Error(1)
+}
+
+var pngData = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 32)...)
+
+// multipartImage builds an upload body with the file in the "image" field
+// and the given extra form fields.
+func multipartImage(t *testing.T, data []byte, contentType string, fields map[string]string) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="image"; filename="front.png"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestUploadProductImage(t *testing.T) {
+	e := echo.New()
+	service := new(MockMediaService)
+	rest.NewMediaController(e, service, 1024)
+
+	productId, imageId := uuid.New(), uuid.New()
+	version := 2
+	image := common.ProductImageResult{Id: imageId, ContentType: "image/png", SizeBytes: int64(len(pngData)), Primary: true, CreatedAt: time.Now()}
+	service.On("UploadProductImage", &command.UploadProductImageCommand{
+		IdempotencyKey:  "upload-1",
+		ProductId:       productId,
+		Data:            pngData,
+		ContentType:     "image/png",
+		Primary:         true,
+		ExpectedVersion: &version,
+	}).Return(&command.UploadProductImageCommandResult{
+		Image:  &image,
+		Result: &common.ProductImagesResult{ProductId: productId, Images: []common.ProductImageResult{image}, Version: 3},
+	}, nil)
+
+	body, contentType := multipartImage(t, pngData, "image/png", map[string]string{"primary": "true", "idempotency_key": "upload-1"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+productId.String()+"/images", body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	req.Header.Set("If-Match", `"2"`)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	url := "/api/v1/products/" + productId.String() + "/images/" + imageId.String()
+	assert.Contains(t, rec.Body.String(), `"image":{"id":"`+imageId.String()+`","url":"`+url+`","content_type":"image/png","size_bytes":40,"position":0,"primary":true`)
+	assert.Contains(t, rec.Body.String(), `"product_id":"`+productId.String()+`"`)
+	service.AssertExpectations(t)
+}
+
+func TestUploadProductImage_OctetStreamIsSniffed(t *testing.T) {
+	e := echo.New()
+	service := new(MockMediaService)
+	rest.NewMediaController(e, service, 1024)
+
+	productId := uuid.New()
+	service.On("UploadProductImage", mock.MatchedBy(func(cmd *command.UploadProductImageCommand) bool {
+		return cmd.ContentType == "" && !cmd.Primary
+	})).Return(nil, entities.ErrUnsupportedMediaType)
+
+	body, contentType := multipartImage(t, []byte("%PDF-1.7"), echo.MIMEOctetStream, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+productId.String()+"/images", body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestUploadProductImage_Rejected(t *testing.T) {
+	e := echo.New()
+	service := new(MockMediaService)
+	rest.NewMediaController(e, service, 16)
+	productId := uuid.New()
+	service.On("UploadProductImage", mock.Anything).Return(nil, entities.ErrImageTooLarge)
+
+	upload := func(body io.Reader, contentType string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+productId.String()+"/images", body)
+		req.Header.Set(echo.HeaderContentType, contentType)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	huge, contentType := multipartImage(t, bytes.Repeat([]byte{1}, 128<<10), "image/png", nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, upload(huge, contentType).Code, "the body is cut off at the limit")
+	service.AssertNotCalled(t, "UploadProductImage", mock.Anything)
+
+	body, contentType := multipartImage(t, pngData, "image/png", nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, upload(body, contentType).Code)
+
+	body, contentType = multipartImage(t, pngData, "image/png", map[string]string{"primary": "maybe"})
+	assert.Equal(t, http.StatusBadRequest, upload(body, contentType).Code)
+
+	assert.Equal(t, http.StatusBadRequest, upload(strings.NewReader(`{}`), echo.MIMEApplicationJSON).Code, "the file is required")
+}
+
+func TestGetProductImage(t *testing.T) {
+	e := echo.New()
+	service := new(MockMediaService)
+	rest.NewMediaController(e, service, 1024)
+
+	productId, imageId := uuid.New(), uuid.New()
+	service.On("OpenProductImage", &query.GetProductImageQuery{ProductId: productId, ImageId: imageId}).Return(&query.GetProductImageQueryResult{
+		Image: &common.ProductImageResult{Id: imageId, ContentType: "image/png", SizeBytes: int64(len(pngData))},
+		Body:  io.NopCloser(bytes.NewReader(pngData)),
+	}, nil)
+	missingId := uuid.New()
+	service.On("OpenProductImage", &query.GetProductImageQuery{ProductId: productId, ImageId: missingId}).Return(nil, entities.ErrImageNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/images/"+imageId.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Header().Get("Cache-Control"), "immutable")
+	assert.Equal(t, pngData, rec.Body.Bytes())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/images/"+missingId.String(), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/images/not-a-uuid", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestArrangeProductImages(t *testing.T) {
+	e := echo.New()
+	service := new(MockMediaService)
+	rest.NewMediaController(e, service, 1024)
+
+	productId, front, back := uuid.New(), uuid.New(), uuid.New()
+	images := &common.ProductImagesResult{ProductId: productId, Version: 5, Images: []common.ProductImageResult{
+		{Id: back, ContentType: "image/png", SizeBytes: 1, Position: 0},
+		{Id: front, ContentType: "image/png", SizeBytes: 1, Position: 1, Primary: true},
+	}}
+	service.On("GetProductImages", &query.GetProductImagesQuery{ProductId: productId}).Return(&query.GetProductImagesQueryResult{Result: images}, nil)
+	service.On("ReorderProductImages", &command.ReorderProductImagesCommand{ProductId: productId, ImageIds: []uuid.UUID{back, front}}).
+		Return(&command.ReorderProductImagesCommandResult{Result: images}, nil)
+	service.On("SetPrimaryProductImage", &command.SetPrimaryProductImageCommand{ProductId: productId, ImageId: front}).
+		Return(&command.SetPrimaryProductImageCommandResult{Result: images}, nil)
+	service.On("RemoveProductImage", &command.RemoveProductImageCommand{ProductId: productId, ImageId: front}).
+		Return(nil, entities.ErrImageNotFound)
+
+	for _, tc := range []struct {
+		method, path, body string
+		status             int
+	}{
+		{http.MethodGet, "/images", "", http.StatusOK},
+		{http.MethodPut, "/images/order", `{"image_ids":["` + back.String() + `","` + front.String() + `"]}`, http.StatusOK},
+		{http.MethodPut, "/images/order", `{"image_ids":["nope"]}`, http.StatusBadRequest},
+		{http.MethodPost, "/images/" + front.String() + "/primary", "", http.StatusOK},
+		{http.MethodDelete, "/images/" + front.String(), "", http.StatusNotFound},
+	} {
+		req := httptest.NewRequest(tc.method, "/api/v1/products/"+productId.String()+tc.path, strings.NewReader(tc.body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.status, rec.Code, tc.method+" "+tc.path)
+		if tc.status == http.StatusOK {
+			assert.Equal(t, `"5"`, rec.Header().Get("ETag"))
+			assert.Contains(t, rec.Body.String(), `"images":[{"id":"`+back.String()+`"`)
+		}
+	}
+	service.AssertExpectations(t)
+}
@@ -54,8 +54,8 @@ func (m *MockProductService) CreateProduct(ctx context.Context, productCommand *
 	return &result, args.Error(1)
 }
 
-func (m *MockProductService) FindAllProducts(ctx context.Context) (*query.GetAllProductsQueryResult, error) {
-	args := m.Called()
+func (m *MockProductService) FindAllProducts(ctx context.Context, productQuery *query.GetAllProductsQuery) (*query.GetAllProductsQueryResult, error) {
+	args := m.Called(productQuery)
 
 	productQueryListResult := &query.GetAllProductsQueryResult{}
 
@@ -66,6 +66,13 @@ func (m *MockProductService) FindAllProducts(ctx context.Context) (*query.GetAll
 	return productQueryListResult, args.Error(1)
 }
 
+// SearchProducts returns the search results configured via On as is.
+func (m *MockProductService) SearchProducts(ctx context.Context, searchQuery *query.SearchProductsQuery) (*query.SearchProductsQueryResult, error) {
+	args := m.Called(searchQuery)
+	result, _ := args.Get(0).(*query.SearchProductsQueryResult)
+	return result, args.Error(1)
+}
+
 func (m *MockProductService) FindProductById(ctx context.Context, productQuery *query.GetProductByIdQuery) (*query.GetProductByIdQueryResult, error) {
 	args := m.Called(productQuery)
 
@@ -92,3 +99,39 @@ func (m *MockProductService) DeleteProduct(ctx context.Context, productCommand *
 	args := m.Called(productCommand)
 	return args.Get(0).(*command.DeleteProductCommandResult), args.Error(1)
 }
+
+func (m *MockProductService) PublishProduct(ctx context.Context, productCommand *command.PublishProductCommand) (*command.PublishProductCommandResult, error) {
+	args := m.Called(productCommand)
+	result, _ := args.Get(0).(*command.PublishProductCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockProductService) ArchiveProduct(ctx context.Context, productCommand *command.ArchiveProductCommand) (*command.ArchiveProductCommandResult, error) {
+	args := m.Called(productCommand)
+	result, _ := args.Get(0).(*command.ArchiveProductCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockProductService) AddProductVariant(ctx context.Context, variantCommand *command.AddProductVariantCommand) (*command.AddProductVariantCommandResult, error) {
+	args := m.Called(variantCommand)
+	result, _ := args.Get(0).(*command.AddProductVariantCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockProductService) UpdateProductVariant(ctx context.Context, variantCommand *command.UpdateProductVariantCommand) (*command.UpdateProductVariantCommandResult, error) {
+	args := m.Called(variantCommand)
+	result, _ := args.Get(0).(*command.UpdateProductVariantCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockProductService) RemoveProductVariant(ctx context.Context, variantCommand *command.RemoveProductVariantCommand) (*command.RemoveProductVariantCommandResult, error) {
+	args := m.Called(variantCommand)
+	result, _ := args.Get(0).(*command.RemoveProductVariantCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockProductService) CategorizeProduct(ctx context.Context, productCommand *command.CategorizeProductCommand) (*command.CategorizeProductCommandResult, error) {
+	args := m.Called(productCommand)
+	result, _ := args.Get(0).(*command.CategorizeProductCommandResult)
+	return result, args.Error(1)
+}
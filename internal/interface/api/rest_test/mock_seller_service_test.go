@@ -39,7 +39,7 @@ func (m *MockSellerService) CreateSeller(ctx context.Context, seller *command.Cr
 	return &result, nil
 }
 
-func (m *MockSellerService) FindAllSellers(ctx context.Context) (*query.GetAllSellersQueryResult, error) {
+func (m *MockSellerService) FindAllSellers(ctx context.Context, sellerQuery *query.GetAllSellersQuery) (*query.GetAllSellersQueryResult, error) {
 	var allSellers query.GetAllSellersQueryResult
 	for _, v := range m.sellers {
 		allSellers.Result = append(allSellers.Result, mapper.NewSellerResultFromEntity(&v.Seller))
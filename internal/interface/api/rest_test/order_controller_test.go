@@ -0,0 +1,280 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockOrderService struct {
+	mock.Mock
+}
+
+func (m *MockOrderService) CreateOrder(ctx context.Context, orderCommand *command.CreateOrderCommand) (*command.CreateOrderCommandResult, error) {
+	args := m.Called(orderCommand)
+	result, _ := args.Get(0).(*command.CreateOrderCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOrderService) PayOrder(ctx context.Context, orderCommand *command.PayOrderCommand) (*command.PayOrderCommandResult, error) {
+	args := m.Called(orderCommand)
+	result, _ := args.Get(0).(*command.PayOrderCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOrderService) ShipOrder(ctx context.Context, orderCommand *command.ShipOrderCommand) (*command.ShipOrderCommandResult, error) {
+	args := m.Called(orderCommand)
+	result, _ := args.Get(0).(*command.ShipOrderCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOrderService) DeliverOrder(ctx context.Context, orderCommand *command.DeliverOrderCommand) (*command.DeliverOrderCommandResult, error) {
+	args := m.Called(orderCommand)
+	result, _ := args.Get(0).(*command.DeliverOrderCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOrderService) CancelOrder(ctx context.Context, orderCommand *command.CancelOrderCommand) (*command.CancelOrderCommandResult, error) {
+	args := m.Called(orderCommand)
+	result, _ := args.Get(0).(*command.CancelOrderCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOrderService) RefundOrder(ctx context.Context, orderCommand *command.RefundOrderCommand) (*command.RefundOrderCommandResult, error) {
+	args := m.Called(orderCommand)
+	result, _ := args.Get(0).(*command.RefundOrderCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOrderService) FindAllOrders(ctx context.Context, orderQuery *query.GetAllOrdersQuery) (*query.GetAllOrdersQueryResult, error) {
+	args := m.Called(orderQuery)
+	result, _ := args.Get(0).(*query.GetAllOrdersQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOrderService) FindOrderById(ctx context.Context, orderQuery *query.GetOrderByIdQuery) (*query.GetOrderByIdQueryResult, error) {
+	args := m.Called(orderQuery)
+	result, _ := args.Get(0).(*query.GetOrderByIdQueryResult)
+	return result, args.Error(1)
+}
+
+func testOrderResult(t *testing.T, status entities.OrderStatus, version int) *common.OrderResult {
+	t.Helper()
+	unitPrice, err := entities.NewMoney(999, entities.USD)
+	require.NoError(t, err)
+	total, err := entities.NewMoney(1998, entities.USD)
+	require.NoError(t, err)
+
+	return &common.OrderResult{
+		Id:      uuid.New(),
+		BuyerId: uuid.New(),
+		Status:  string(status),
+		Items: []common.OrderItemResult{
+			{ProductId: uuid.New(), ProductName: "Widget", UnitPrice: unitPrice, Quantity: 2, Total: total},
+		},
+		Totals:    []entities.Money{total},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Version:   version,
+	}
+}
+
+func TestCreateOrder(t *testing.T) {
+	e := echo.New()
+	service := new(MockOrderService)
+	rest.NewOrderController(e, service)
+
+	buyerId := uuid.New()
+	productId := uuid.New()
+	created := testOrderResult(t, entities.OrderPending, 1)
+	service.On("CreateOrder", &command.CreateOrderCommand{
+		IdempotencyKey: "key-1",
+		BuyerId:        buyerId,
+		Items:          []command.CreateOrderItem{{ProductId: productId, Quantity: 2}},
+	}).Return(&command.CreateOrderCommandResult{Result: created}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(
+		fmt.Sprintf(`{"buyer_id":%q,"items":[{"product_id":%q,"quantity":2}]}`, buyerId, productId)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+	var body response.OrderResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "pending", body.Status)
+	require.Len(t, body.Items, 1)
+	assert.Equal(t, int64(999), body.Items[0].UnitPriceMinorUnits)
+	assert.Equal(t, int64(1998), body.Items[0].TotalMinorUnits)
+	assert.Equal(t, []response.MoneyResponse{{MinorUnits: 1998, Currency: "USD"}}, body.Totals)
+	service.AssertExpectations(t)
+}
+
+func TestCreateOrder_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err    error
+		status int
+	}{
+		"out of stock":    {fmt.Errorf("%w: only 1 units available", entities.ErrInsufficientStock), http.StatusConflict},
+		"unknown product": {entities.ErrProductNotFound, http.StatusNotFound},
+		"no items":        {fmt.Errorf("%w: an order needs at least one item", entities.ErrValidation), http.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			service := new(MockOrderService)
+			rest.NewOrderController(e, service)
+			service.On("CreateOrder", mock.Anything).Return(nil, tc.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders",
+				strings.NewReader(fmt.Sprintf(`{"buyer_id":%q}`, uuid.New())))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestCreateOrder_InvalidBuyerId(t *testing.T) {
+	e := echo.New()
+	service := new(MockOrderService)
+	rest.NewOrderController(e, service)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(`{"buyer_id":"nope"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	service.AssertNotCalled(t, "CreateOrder", mock.Anything)
+}
+
+func TestGetOrderById(t *testing.T) {
+	e := echo.New()
+	service := new(MockOrderService)
+	rest.NewOrderController(e, service)
+
+	order := testOrderResult(t, entities.OrderPaid, 2)
+	service.On("FindOrderById", &query.GetOrderByIdQuery{Id: order.Id}).Return(&query.GetOrderByIdQueryResult{Result: order}, nil)
+	service.On("FindOrderById", mock.Anything).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/"+order.Id.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/orders/"+uuid.NewString(), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGetAllOrders(t *testing.T) {
+	e := echo.New()
+	service := new(MockOrderService)
+	rest.NewOrderController(e, service)
+
+	buyerId := uuid.New()
+	service.On("FindAllOrders", &query.GetAllOrdersQuery{Limit: 1, BuyerId: buyerId}).Return(&query.GetAllOrdersQueryResult{
+		Result:     []*common.OrderResult{testOrderResult(t, entities.OrderPending, 1)},
+		NextCursor: "next",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?limit=1&buyer_id="+buyerId.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.ListOrdersResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(t, body.Orders, 1)
+	assert.Equal(t, "next", body.NextCursor)
+	service.AssertExpectations(t)
+}
+
+func TestShipOrder(t *testing.T) {
+	e := echo.New()
+	service := new(MockOrderService)
+	rest.NewOrderController(e, service)
+
+	shipped := testOrderResult(t, entities.OrderShipped, 3)
+	shipped.TrackingNumber = "1Z999"
+	version := 2
+	service.On("ShipOrder", &command.ShipOrderCommand{
+		Id:              shipped.Id,
+		TrackingNumber:  "1Z999",
+		ExpectedVersion: &version,
+	}).Return(&command.ShipOrderCommandResult{Result: shipped}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/"+shipped.Id.String()+"/ship",
+		strings.NewReader(`{"tracking_number":"1Z999"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"2"`)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	var body response.OrderResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "1Z999", body.TrackingNumber)
+	service.AssertExpectations(t)
+}
+
+func TestOrderTransitions_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		path    string
+		method  string
+		err     error
+		ifMatch string
+		status  int
+	}{
+		"pay twice":          {"pay", "PayOrder", fmt.Errorf("%w: a paid order cannot become paid", entities.ErrInvalidOrderTransition), "", http.StatusConflict},
+		"expired hold":       {"pay", "PayOrder", fmt.Errorf("%w: the reservation of Widget expired", entities.ErrInsufficientStock), "", http.StatusConflict},
+		"unknown order":      {"deliver", "DeliverOrder", entities.ErrOrderNotFound, "", http.StatusNotFound},
+		"stale version":      {"cancel", "CancelOrder", entities.ErrVersionConflict, `"1"`, http.StatusPreconditionFailed},
+		"refund unpaid":      {"refund", "RefundOrder", fmt.Errorf("%w: a pending order cannot become refunded", entities.ErrInvalidOrderTransition), "", http.StatusConflict},
+		"malformed If-Match": {"pay", "", nil, "1", http.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			service := new(MockOrderService)
+			rest.NewOrderController(e, service)
+			if tc.method != "" {
+				service.On(tc.method, mock.Anything).Return(nil, tc.err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/"+uuid.NewString()+"/"+tc.path, nil)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
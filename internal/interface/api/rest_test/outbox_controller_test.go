@@ -0,0 +1,157 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockDeadLetterService struct {
+	mock.Mock
+}
+
+func (m *MockDeadLetterService) FindDeadLetters(ctx context.Context, deadLetterQuery *query.GetDeadLettersQuery) (*query.GetDeadLettersQueryResult, error) {
+	args := m.Called(deadLetterQuery)
+	result, _ := args.Get(0).(*query.GetDeadLettersQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockDeadLetterService) ReplayDeadLetter(ctx context.Context, deadLetterCommand *command.ReplayDeadLetterCommand) (*command.ReplayDeadLetterCommandResult, error) {
+	args := m.Called(deadLetterCommand)
+	if err := args.Error(0); err != nil {
+		return nil, err
+	}
+	return &command.ReplayDeadLetterCommandResult{Success: true}, nil
+}
+
+func (m *MockDeadLetterService) DiscardDeadLetter(ctx context.Context, deadLetterCommand *command.DiscardDeadLetterCommand) (*command.DiscardDeadLetterCommandResult, error) {
+	args := m.Called(deadLetterCommand)
+	if err := args.Error(0); err != nil {
+		return nil, err
+	}
+	return &command.DiscardDeadLetterCommandResult{Success: true}, nil
+}
+
+func TestListDeadLetters(t *testing.T) {
+	e := echo.New()
+	service := new(MockDeadLetterService)
+	rest.NewOutboxController(e, service)
+
+	deadLetter := &common.DeadLetterResult{
+		Id:             uuid.New(),
+		AggregateId:    uuid.New(),
+		EventName:      "product.created",
+		Payload:        []byte(`{"name":"Widget"}`),
+		OccurredAt:     time.Now().UTC(),
+		Attempts:       10,
+		LastError:      "broker unavailable",
+		DeadLetteredAt: time.Now().UTC(),
+	}
+	service.On("FindDeadLetters", &query.GetDeadLettersQuery{Limit: 20}).
+		Return(&query.GetDeadLettersQueryResult{Result: []*common.DeadLetterResult{deadLetter}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/outbox/dead-letters?limit=20", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.ListDeadLettersResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.DeadLetters, 1)
+	assert.Equal(t, deadLetter.Id.String(), body.DeadLetters[0].Id)
+	assert.Equal(t, 10, body.DeadLetters[0].Attempts)
+	assert.JSONEq(t, `{"name":"Widget"}`, string(body.DeadLetters[0].Payload))
+	service.AssertExpectations(t)
+}
+
+func TestListDeadLetters_InvalidLimit(t *testing.T) {
+	e := echo.New()
+	rest.NewOutboxController(e, new(MockDeadLetterService))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/outbox/dead-letters?limit=0", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	e := echo.New()
+	service := new(MockDeadLetterService)
+	rest.NewOutboxController(e, service)
+
+	id := uuid.New()
+	service.On("ReplayDeadLetter", &command.ReplayDeadLetterCommand{Id: id}).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/outbox/dead-letters/"+id.String()+"/replay", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestReplayDeadLetter_NotFound(t *testing.T) {
+	e := echo.New()
+	service := new(MockDeadLetterService)
+	rest.NewOutboxController(e, service)
+
+	id := uuid.New()
+	service.On("ReplayDeadLetter", &command.ReplayDeadLetterCommand{Id: id}).Return(entities.ErrDeadLetterNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/outbox/dead-letters/"+id.String()+"/replay", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestDiscardDeadLetter(t *testing.T) {
+	e := echo.New()
+	service := new(MockDeadLetterService)
+	rest.NewOutboxController(e, service)
+
+	id := uuid.New()
+	service.On("DiscardDeadLetter", &command.DiscardDeadLetterCommand{Id: id}).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/outbox/dead-letters/"+id.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestDiscardDeadLetter_Errors(t *testing.T) {
+	e := echo.New()
+	service := new(MockDeadLetterService)
+	rest.NewOutboxController(e, service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/outbox/dead-letters/not-a-uuid", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	id := uuid.New()
+	service.On("DiscardDeadLetter", &command.DiscardDeadLetterCommand{Id: id}).Return(errors.New("connection reset"))
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/admin/outbox/dead-letters/"+id.String(), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,164 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockOutboxReplayService struct {
+	mock.Mock
+}
+
+func (m *MockOutboxReplayService) StartOutboxReplay(ctx context.Context, replayCommand *command.StartOutboxReplayCommand) (*command.StartOutboxReplayCommandResult, error) {
+	args := m.Called(replayCommand)
+	result, _ := args.Get(0).(*command.StartOutboxReplayCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOutboxReplayService) ResumeOutboxReplay(ctx context.Context, replayCommand *command.ResumeOutboxReplayCommand) (*command.ResumeOutboxReplayCommandResult, error) {
+	args := m.Called(replayCommand)
+	result, _ := args.Get(0).(*command.ResumeOutboxReplayCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOutboxReplayService) FindOutboxReplayById(ctx context.Context, replayQuery *query.GetOutboxReplayByIdQuery) (*query.GetOutboxReplayByIdQueryResult, error) {
+	args := m.Called(replayQuery)
+	result, _ := args.Get(0).(*query.GetOutboxReplayByIdQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOutboxReplayService) FindOutboxReplays(ctx context.Context, replayQuery *query.GetOutboxReplaysQuery) (*query.GetOutboxReplaysQueryResult, error) {
+	args := m.Called(replayQuery)
+	result, _ := args.Get(0).(*query.GetOutboxReplaysQueryResult)
+	return result, args.Error(1)
+}
+
+func TestStartReplay(t *testing.T) {
+	e := echo.New()
+	service := new(MockOutboxReplayService)
+	rest.NewOutboxReplayController(e, service)
+
+	aggregateId := uuid.New()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	replayCommand := &command.StartOutboxReplayCommand{Target: "kafka", AggregateId: aggregateId, EventName: "product.created", From: from}
+	replay := &common.OutboxReplayResult{Id: uuid.New(), Target: "kafka", AggregateId: &aggregateId, EventName: "product.created", From: &from, Status: "running", Total: 42}
+	service.On("StartOutboxReplay", replayCommand).Return(&command.StartOutboxReplayCommandResult{Result: replay}, nil)
+
+	body := fmt.Sprintf(`{"target":"kafka","aggregate_id":%q,"event_name":"product.created","from":"2026-01-01T00:00:00Z"}`, aggregateId)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/outbox/replays", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var replayResponse response.OutboxReplayResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &replayResponse))
+	assert.Equal(t, replay.Id.String(), replayResponse.Id)
+	assert.Equal(t, aggregateId.String(), replayResponse.AggregateId)
+	assert.Equal(t, "running", replayResponse.Status)
+	assert.Equal(t, int64(42), replayResponse.Total)
+	assert.Nil(t, replayResponse.To)
+	service.AssertExpectations(t)
+}
+
+func TestStartReplay_InvalidRequests(t *testing.T) {
+	e := echo.New()
+	service := new(MockOutboxReplayService)
+	rest.NewOutboxReplayController(e, service)
+	service.On("StartOutboxReplay", &command.StartOutboxReplayCommand{Target: "pigeon"}).
+		Return(nil, fmt.Errorf("%w %q", entities.ErrUnknownReplayTarget, "pigeon"))
+
+	for _, body := range []string{`{"target":"kafka","aggregate_id":"nope"}`, `{"target":"pigeon"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/outbox/replays", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestGetReplay(t *testing.T) {
+	e := echo.New()
+	service := new(MockOutboxReplayService)
+	rest.NewOutboxReplayController(e, service)
+
+	replay := &common.OutboxReplayResult{Id: uuid.New(), Target: "log", Status: "failed", Total: 10, Replayed: 4, LastError: "broker unavailable"}
+	service.On("FindOutboxReplayById", &query.GetOutboxReplayByIdQuery{Id: replay.Id}).Return(&query.GetOutboxReplayByIdQueryResult{Result: replay}, nil)
+	missing := uuid.New()
+	service.On("FindOutboxReplayById", &query.GetOutboxReplayByIdQuery{Id: missing}).Return(nil, entities.ErrOutboxReplayNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/outbox/replays/"+replay.Id.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var replayResponse response.OutboxReplayResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &replayResponse))
+	assert.Equal(t, int64(4), replayResponse.Replayed)
+	assert.Equal(t, "broker unavailable", replayResponse.LastError)
+	assert.Empty(t, replayResponse.AggregateId)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/outbox/replays/"+missing.String(), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestListReplays(t *testing.T) {
+	e := echo.New()
+	service := new(MockOutboxReplayService)
+	rest.NewOutboxReplayController(e, service)
+	service.On("FindOutboxReplays", &query.GetOutboxReplaysQuery{Limit: 1000}).Return(&query.GetOutboxReplaysQueryResult{
+		Result: []*common.OutboxReplayResult{{Id: uuid.New(), Status: "completed"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/outbox/replays?limit=1000", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var listResponse response.ListOutboxReplaysResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listResponse))
+	assert.Len(t, listResponse.Replays, 1)
+	service.AssertExpectations(t)
+}
+
+func TestResumeReplay(t *testing.T) {
+	e := echo.New()
+	service := new(MockOutboxReplayService)
+	rest.NewOutboxReplayController(e, service)
+
+	resumable, busy := uuid.New(), uuid.New()
+	service.On("ResumeOutboxReplay", &command.ResumeOutboxReplayCommand{Id: resumable}).
+		Return(&command.ResumeOutboxReplayCommandResult{Result: &common.OutboxReplayResult{Id: resumable, Status: "running"}}, nil)
+	service.On("ResumeOutboxReplay", &command.ResumeOutboxReplayCommand{Id: busy}).Return(nil, entities.ErrOutboxReplayNotResumable)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/outbox/replays/"+resumable.String()+"/resume", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/outbox/replays/"+busy.String()+"/resume", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
@@ -0,0 +1,177 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPricingService struct {
+	mock.Mock
+}
+
+func (m *MockPricingService) GetPriceHistory(ctx context.Context, historyQuery *query.GetPriceHistoryQuery) (*query.GetPriceHistoryQueryResult, error) {
+	args := m.Called(historyQuery)
+	result, _ := args.Get(0).(*query.GetPriceHistoryQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockPricingService) GetLowestPrice(ctx context.Context, lowestQuery *query.GetLowestPriceQuery) (*query.GetLowestPriceQueryResult, error) {
+	args := m.Called(lowestQuery)
+	result, _ := args.Get(0).(*query.GetLowestPriceQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockPricingService) GetScheduledPriceChanges(ctx context.Context, scheduleQuery *query.GetScheduledPriceChangesQuery) (*query.GetScheduledPriceChangesQueryResult, error) {
+	args := m.Called(scheduleQuery)
+	result, _ := args.Get(0).(*query.GetScheduledPriceChangesQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockPricingService) SchedulePriceChange(ctx context.Context, scheduleCommand *command.SchedulePriceChangeCommand) (*command.SchedulePriceChangeCommandResult, error) {
+	args := m.Called(scheduleCommand)
+	result, _ := args.Get(0).(*command.SchedulePriceChangeCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockPricingService) CancelScheduledPriceChange(ctx context.Context, cancelCommand *command.CancelScheduledPriceChangeCommand) (*command.CancelScheduledPriceChangeCommandResult, error) {
+	args := m.Called(cancelCommand)
+	result, _ := args.Get(0).(*command.CancelScheduledPriceChangeCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockPricingService) ApplyDuePriceChanges(ctx context.Context, limit int) (int, error) {
+	args := m.Called(limit)
+	return args.Int(0), args.Error(1)
+}
+
+func TestGetPriceHistory(t *testing.T) {
+	e := echo.New()
+	service := new(MockPricingService)
+	rest.NewPriceController(e, service)
+
+	productId := uuid.New()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	service.On("GetPriceHistory", &query.GetPriceHistoryQuery{ProductId: productId, From: from, To: to}).Return(&query.GetPriceHistoryQueryResult{
+		Result: []*common.PricePointResult{{Price: mustMoney(t, 999, entities.USD), EffectiveAt: from.Add(-time.Hour)}},
+		From:   from,
+		To:     to,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/price-history?from=2024-01-01T00:00:00Z&to=2024-02-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.PriceHistoryResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Prices, 1)
+	assert.Equal(t, int64(999), body.Prices[0].PriceMinorUnits)
+	assert.Equal(t, "USD", body.Prices[0].Currency)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/price-history?from=yesterday", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetLowestPrice(t *testing.T) {
+	e := echo.New()
+	service := new(MockPricingService)
+	rest.NewPriceController(e, service)
+
+	productId := uuid.New()
+	effectiveAt := time.Now().Add(-48 * time.Hour).UTC()
+	service.On("GetLowestPrice", &query.GetLowestPriceQuery{ProductId: productId, Window: 7 * 24 * time.Hour}).Return(&query.GetLowestPriceQueryResult{
+		Result: &common.PricePointResult{Price: mustMoney(t, 799, entities.USD), EffectiveAt: effectiveAt},
+		From:   time.Now().Add(-7 * 24 * time.Hour),
+		To:     time.Now(),
+	}, nil)
+	service.On("GetLowestPrice", &query.GetLowestPriceQuery{ProductId: productId}).Return(nil, entities.ErrProductNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/lowest-price?days=7", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.LowestPriceResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, int64(799), body.PriceMinorUnits)
+	assert.True(t, effectiveAt.Equal(body.EffectiveAt))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/products/"+productId.String()+"/lowest-price", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSchedulePriceChange(t *testing.T) {
+	e := echo.New()
+	service := new(MockPricingService)
+	rest.NewPriceController(e, service)
+
+	productId := uuid.New()
+	effectiveAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	scheduleId := uuid.New()
+	service.On("SchedulePriceChange", &command.SchedulePriceChangeCommand{
+		IdempotencyKey:  "key-1",
+		ProductId:       productId,
+		PriceMinorUnits: 1299,
+		Currency:        entities.USD,
+		EffectiveAt:     effectiveAt,
+	}).Return(&command.SchedulePriceChangeCommandResult{
+		Result: &common.ScheduledPriceChangeResult{Id: scheduleId, ProductId: productId, Price: mustMoney(t, 1299, entities.USD), EffectiveAt: effectiveAt},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+productId.String()+"/scheduled-prices",
+		strings.NewReader(`{"price_minor_units":1299,"currency":"USD","effective_at":"2030-01-01T00:00:00Z"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var body response.ScheduledPriceChangeResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, scheduleId.String(), body.Id)
+	assert.Equal(t, int64(1299), body.PriceMinorUnits)
+}
+
+func TestCancelScheduledPriceChange(t *testing.T) {
+	e := echo.New()
+	service := new(MockPricingService)
+	rest.NewPriceController(e, service)
+
+	productId := uuid.New()
+	scheduleId := uuid.New()
+	service.On("CancelScheduledPriceChange", &command.CancelScheduledPriceChangeCommand{ProductId: productId, Id: scheduleId}).
+		Return(&command.CancelScheduledPriceChangeCommandResult{}, nil).Once()
+	service.On("CancelScheduledPriceChange", &command.CancelScheduledPriceChangeCommand{ProductId: productId, Id: scheduleId}).
+		Return(nil, entities.ErrScheduledPriceChangeNotFound)
+
+	path := "/api/v1/products/" + productId.String() + "/scheduled-prices/" + scheduleId.String()
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, path, nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, path, nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
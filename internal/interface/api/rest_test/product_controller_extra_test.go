@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/sklinkert/go-ddd/internal/application/command"
 	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
 	"github.com/sklinkert/go-ddd/internal/domain/entities"
 	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
 	"github.com/stretchr/testify/assert"
@@ -70,6 +72,28 @@ func TestGetProductById_NotFound(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestGetProductById_DisplayCurrency(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	ctrl := rest.NewProductController(e, mockService)
+
+	id := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/"+id.String()+"?display_currency=EUR", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+
+	mockService.On("FindProductById", mock.MatchedBy(func(q *query.GetProductByIdQuery) bool {
+		return q.Id == id && q.DisplayCurrency == entities.EUR
+	})).Return((*entities.Product)(nil), fmt.Errorf("%w: USD to EUR", entities.ErrExchangeRateNotFound))
+
+	assert.NoError(t, ctrl.GetProductByIdController(c))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "USD to EUR")
+	mockService.AssertExpectations(t)
+}
+
 func TestGetProductById_InvalidId(t *testing.T) {
 	e := echo.New()
 	ctrl := rest.NewProductController(e, new(MockProductService))
@@ -84,6 +108,113 @@ func TestGetProductById_InvalidId(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestGetAllProducts_BindsQueryParameters(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	ctrl := rest.NewProductController(e, mockService)
+
+	sellerId := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?limit=10&sort=price&cursor=abc&currency=EUR&min_price_minor_units=100&max_price_minor_units=900&display_currency=JPY&status=draft&seller_id="+sellerId.String(), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("FindAllProducts", mock.MatchedBy(func(q *query.GetAllProductsQuery) bool {
+		return q.Limit == 10 && q.SortBy == "price" && q.Cursor == "abc" && q.Currency == entities.EUR &&
+			*q.MinPriceMinorUnits == 100 && *q.MaxPriceMinorUnits == 900 && q.SellerId == sellerId &&
+			q.Status == entities.ProductDraft && q.DisplayCurrency == entities.JPY
+	})).Return([]*entities.Product{}, nil)
+
+	assert.NoError(t, ctrl.GetAllProductsController(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAllProducts_InvalidQueryParameters(t *testing.T) {
+	for _, rawQuery := range []string{"limit=ten", "seller_id=not-a-uuid", "min_price_minor_units=cheap"} {
+		e := echo.New()
+		ctrl := rest.NewProductController(e, new(MockProductService))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products?"+rawQuery, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, ctrl.GetAllProductsController(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, rawQuery)
+	}
+}
+
+func TestGetAllProducts_ValidationErrorIsBadRequest(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	ctrl := rest.NewProductController(e, mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?sort=rating", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("FindAllProducts", mock.Anything).Return([]*entities.Product{}, fmt.Errorf("%w: unsupported sort", entities.ErrValidation))
+
+	assert.NoError(t, ctrl.GetAllProductsController(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSearchProducts(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	rest.NewProductController(e, mockService)
+
+	price, err := entities.NewMoney(1999, entities.EUR)
+	assert.NoError(t, err)
+	mockService.On("SearchProducts", &query.SearchProductsQuery{Q: "oak chair", Cursor: "abc", Limit: 5, DisplayCurrency: entities.JPY}).
+		Return(&query.SearchProductsQueryResult{
+			Result: []*common.ProductSearchResult{{
+				Product:   &common.ProductResult{Id: uuid.New(), Name: "Oak chair", Price: price},
+				Rank:      0.6,
+				Highlight: "<mark>Oak</mark> <mark>chair</mark>",
+			}},
+			NextCursor: "def",
+		}, nil)
+
+	// Routed through echo, so the search does not reach the :id route.
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/search?q=oak+chair&cursor=abc&limit=5&display_currency=JPY", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Results []struct {
+			Product   struct{ Name string } `json:"product"`
+			Rank      float32               `json:"rank"`
+			Highlight string                `json:"highlight"`
+		} `json:"results"`
+		NextCursor string `json:"next_cursor"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	if assert.Len(t, body.Results, 1) {
+		assert.Equal(t, "Oak chair", body.Results[0].Product.Name)
+		assert.Equal(t, float32(0.6), body.Results[0].Rank)
+		assert.Equal(t, "<mark>Oak</mark> <mark>chair</mark>", body.Results[0].Highlight)
+	}
+	assert.Equal(t, "def", body.NextCursor)
+	mockService.AssertExpectations(t)
+}
+
+func TestSearchProducts_Errors(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	rest.NewProductController(e, mockService)
+	mockService.On("SearchProducts", mock.Anything).Return(nil, fmt.Errorf("%w: search needs at least one word", entities.ErrValidation))
+
+	for _, rawQuery := range []string{"limit=ten", "q="} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/search?"+rawQuery, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, rawQuery)
+	}
+	mockService.AssertNumberOfCalls(t, "SearchProducts", 1)
+}
+
 func TestUpdateProduct_Success(t *testing.T) {
 	e := echo.New()
 	mockService := new(MockProductService)
@@ -140,3 +271,325 @@ func TestDeleteProduct_Success(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 	mockService.AssertExpectations(t)
 }
+
+func TestUpdateProduct_IfMatchSetsExpectedVersion(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	ctrl := rest.NewProductController(e, mockService)
+
+	id := uuid.New()
+	sellerId := uuid.New()
+	body, _ := json.Marshal(map[string]any{"name": "Widget v2", "price_minor_units": 1999, "currency": "USD", "seller_id": sellerId.String()})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/"+id.String(), bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"3"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+
+	mockService.On("UpdateProduct", mock.MatchedBy(func(cmd *command.UpdateProductCommand) bool {
+		return cmd.ExpectedVersion != nil && *cmd.ExpectedVersion == 3
+	})).Return(&command.UpdateProductCommandResult{
+		Result: &common.ProductResult{
+			Id:       id,
+			Name:     "Widget v2",
+			Price:    mustMoney(t, 1999, entities.USD),
+			SellerId: sellerId,
+			Version:  4,
+		},
+	}, nil)
+
+	assert.NoError(t, ctrl.UpdateProductController(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+
+	var responseBody map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseBody))
+	assert.Equal(t, float64(4), responseBody["version"])
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateProduct_StaleIfMatchIsPreconditionFailed(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	ctrl := rest.NewProductController(e, mockService)
+
+	id := uuid.New()
+	body, _ := json.Marshal(map[string]any{"name": "Widget v2", "price_minor_units": 1999, "currency": "USD", "seller_id": uuid.New().String()})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/"+id.String(), bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"1"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+
+	mockService.On("UpdateProduct", mock.Anything).Return((*command.UpdateProductCommandResult)(nil), entities.ErrVersionConflict)
+
+	assert.NoError(t, ctrl.UpdateProductController(c))
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+}
+
+func TestUpdateProduct_InvalidIfMatch(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	ctrl := rest.NewProductController(e, mockService)
+
+	id := uuid.New()
+	body, _ := json.Marshal(map[string]any{"name": "Widget v2", "price_minor_units": 1999, "currency": "USD", "seller_id": uuid.New().String()})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/"+id.String(), bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", "not-an-etag")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+
+	assert.NoError(t, ctrl.UpdateProductController(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockService.AssertNotCalled(t, "UpdateProduct", mock.Anything)
+}
+
+func TestDeleteProduct_ConcurrentWriteIsConflict(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	ctrl := rest.NewProductController(e, mockService)
+
+	id := uuid.New()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/"+id.String(), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+
+	// Without If-Match a lost race is reported as 409, not 412.
+	mockService.On("DeleteProduct", mock.Anything).Return((*command.DeleteProductCommandResult)(nil), entities.ErrVersionConflict)
+
+	assert.NoError(t, ctrl.DeleteProductController(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestPublishProduct_IfMatchSetsExpectedVersion(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	rest.NewProductController(e, mockService)
+
+	id := uuid.New()
+	version := 1
+	mockService.On("PublishProduct", &command.PublishProductCommand{Id: id, ExpectedVersion: &version}).Return(&command.PublishProductCommandResult{
+		Result: &common.ProductResult{Id: id, Name: "X", Price: mustMoney(t, 100, entities.EUR), SellerId: uuid.New(), Status: "published", Version: 2},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+id.String()+"/publish", nil)
+	req.Header.Set("If-Match", `"1"`)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+	assert.Contains(t, rec.Body.String(), `"status":"published"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestProductStatusChanges_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		path   string
+		method string
+		err    error
+		status int
+	}{
+		"publish twice":   {"publish", "PublishProduct", fmt.Errorf("%w: a published product cannot become published", entities.ErrInvalidProductTransition), http.StatusConflict},
+		"invalid product": {"publish", "PublishProduct", fmt.Errorf("%w: name must not be empty", entities.ErrValidation), http.StatusBadRequest},
+		"unknown product": {"archive", "ArchiveProduct", entities.ErrProductNotFound, http.StatusNotFound},
+		"stale version":   {"archive", "ArchiveProduct", entities.ErrVersionConflict, http.StatusPreconditionFailed},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			mockService := new(MockProductService)
+			rest.NewProductController(e, mockService)
+			mockService.On(tc.method, mock.Anything).Return(nil, tc.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+uuid.NewString()+"/"+tc.path, nil)
+			req.Header.Set("If-Match", `"1"`)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestCategorizeProduct(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	rest.NewProductController(e, mockService)
+
+	id, categoryId := uuid.New(), uuid.New()
+	version := 3
+	mockService.On("CategorizeProduct", &command.CategorizeProductCommand{
+		IdempotencyKey:  "key-1",
+		Id:              id,
+		CategoryIds:     []uuid.UUID{categoryId},
+		ExpectedVersion: &version,
+	}).Return(&command.CategorizeProductCommandResult{
+		Result: &common.ProductResult{Id: id, Name: "X", Price: mustMoney(t, 100, entities.EUR), SellerId: uuid.New(), CategoryIds: []uuid.UUID{categoryId}, Version: 4},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/"+id.String()+"/categories", bytes.NewBufferString(`{"category_ids":["`+categoryId.String()+`"]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"3"`)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+	assert.Contains(t, rec.Body.String(), `"category_ids":["`+categoryId.String()+`"]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestCategorizeProduct_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		body   string
+		err    error
+		status int
+	}{
+		"invalid category id": {`{"category_ids":["nope"]}`, nil, http.StatusBadRequest},
+		"unknown category":    {`{"category_ids":["` + uuid.NewString() + `"]}`, entities.ErrCategoryNotFound, http.StatusNotFound},
+		"duplicate category":  {`{"category_ids":[]}`, fmt.Errorf("%w: duplicate category", entities.ErrValidation), http.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			mockService := new(MockProductService)
+			rest.NewProductController(e, mockService)
+			if tc.err != nil {
+				mockService.On("CategorizeProduct", mock.Anything).Return(nil, tc.err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/products/"+uuid.NewString()+"/categories", bytes.NewBufferString(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+			if tc.err == nil {
+				mockService.AssertNotCalled(t, "CategorizeProduct", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAddProductVariant(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	rest.NewProductController(e, mockService)
+
+	id, variantId := uuid.New(), uuid.New()
+	version := 2
+	override := mustMoney(t, 1299, entities.EUR)
+	overrideMinorUnits := int64(1299)
+	mockService.On("AddProductVariant", &command.AddProductVariantCommand{
+		IdempotencyKey: "key-1",
+		ProductId:      id,
+		Variant: command.ProductVariantInput{
+			Sku:                     "TEE-L",
+			Attributes:              map[string]string{"size": "L"},
+			PriceOverrideMinorUnits: &overrideMinorUnits,
+		},
+		ExpectedVersion: &version,
+	}).Return(&command.AddProductVariantCommandResult{
+		Result: &common.ProductResult{Id: id, Name: "T-shirt", Price: mustMoney(t, 999, entities.EUR), SellerId: uuid.New(), Version: 3,
+			Variants: []common.ProductVariantResult{{Id: variantId, Sku: "TEE-L", Attributes: map[string]string{"size": "L"}, PriceOverride: &override, Price: override}}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+id.String()+"/variants",
+		bytes.NewBufferString(`{"sku":"TEE-L","attributes":{"size":"L"},"price_override_minor_units":1299}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"2"`)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	assert.Contains(t, rec.Body.String(), `"variants":[{"id":"`+variantId.String()+`","sku":"TEE-L","attributes":{"size":"L"},"price_override_minor_units":1299,"price_minor_units":1299,"currency":"EUR"}]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateAndRemoveProductVariant(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	rest.NewProductController(e, mockService)
+
+	id, variantId := uuid.New(), uuid.New()
+	result := &common.ProductResult{Id: id, Name: "T-shirt", Price: mustMoney(t, 999, entities.EUR), SellerId: uuid.New(), Version: 4}
+	mockService.On("UpdateProductVariant", &command.UpdateProductVariantCommand{
+		ProductId: id,
+		VariantId: variantId,
+		Variant:   command.ProductVariantInput{Sku: "TEE-XL", Attributes: map[string]string{"size": "XL"}},
+	}).Return(&command.UpdateProductVariantCommandResult{Result: result}, nil)
+	mockService.On("RemoveProductVariant", &command.RemoveProductVariantCommand{
+		ProductId: id,
+		VariantId: variantId,
+	}).Return(&command.RemoveProductVariantCommandResult{Result: result}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/"+id.String()+"/variants/"+variantId.String(),
+		bytes.NewBufferString(`{"sku":"TEE-XL","attributes":{"size":"XL"}}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/products/"+id.String()+"/variants/"+variantId.String(), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestProductVariants_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		variantId string
+		err       error
+		status    int
+	}{
+		"invalid variant id": {"nope", nil, http.StatusBadRequest},
+		"unknown variant":    {uuid.NewString(), entities.ErrVariantNotFound, http.StatusNotFound},
+		"last variant":       {uuid.NewString(), fmt.Errorf("%w: a product needs at least one variant", entities.ErrValidation), http.StatusBadRequest},
+		"reserved stock":     {uuid.NewString(), entities.ErrVariantReserved, http.StatusConflict},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			mockService := new(MockProductService)
+			rest.NewProductController(e, mockService)
+			if tc.err != nil {
+				mockService.On("RemoveProductVariant", mock.Anything).Return(nil, tc.err)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/"+uuid.NewString()+"/variants/"+tc.variantId, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+			if tc.err == nil {
+				mockService.AssertNotCalled(t, "RemoveProductVariant", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAddProductVariant_SkuTakenIsConflict(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockProductService)
+	rest.NewProductController(e, mockService)
+	mockService.On("AddProductVariant", mock.Anything).Return(nil, fmt.Errorf("%w: TEE-L", entities.ErrSkuTaken))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/"+uuid.NewString()+"/variants", bytes.NewBufferString(`{"sku":"TEE-L"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
@@ -95,7 +95,7 @@ func TestGetAllProducts(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	ctrl := rest.NewProductController(e, mockService)
-	mockService.On("FindAllProducts").Return(expectedProducts, nil)
+	mockService.On("FindAllProducts", mock.Anything).Return(expectedProducts, nil)
 
 	var expectedListResponse response.ListProductsResponse
 	for _, product := range expectedProducts {
@@ -106,6 +106,9 @@ func TestGetAllProducts(t *testing.T) {
 				PriceMinorUnits: product.Price.MinorUnits(),
 				Currency:        string(product.Price.Currency()),
 				SellerId:        product.SellerId.String(),
+				CategoryIds:     []string{},
+				Variants:        []*response.ProductVariantResponse{},
+				Images:          []*response.ProductImageResponse{},
 			})
 	}
 
@@ -130,7 +133,7 @@ func TestGetAllProducts_EmptyReturnsEmptyArray(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	mockService.On("FindAllProducts").Return([]*entities.Product{}, nil)
+	mockService.On("FindAllProducts", mock.Anything).Return([]*entities.Product{}, nil)
 
 	assert.NoError(t, ctrl.GetAllProductsController(c))
 	assert.Equal(t, http.StatusOK, rec.Code)
@@ -0,0 +1,254 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPromotionService struct {
+	mock.Mock
+}
+
+func (m *MockPromotionService) CreatePromotion(ctx context.Context, promotionCommand *command.CreatePromotionCommand) (*command.CreatePromotionCommandResult, error) {
+	args := m.Called(promotionCommand)
+	result, _ := args.Get(0).(*command.CreatePromotionCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockPromotionService) EndPromotion(ctx context.Context, promotionCommand *command.EndPromotionCommand) (*command.EndPromotionCommandResult, error) {
+	args := m.Called(promotionCommand)
+	result, _ := args.Get(0).(*command.EndPromotionCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockPromotionService) RedeemPromotion(ctx context.Context, promotionCommand *command.RedeemPromotionCommand) (*command.RedeemPromotionCommandResult, error) {
+	args := m.Called(promotionCommand)
+	result, _ := args.Get(0).(*command.RedeemPromotionCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockPromotionService) FindPromotionById(ctx context.Context, promotionQuery *query.GetPromotionByIdQuery) (*query.GetPromotionByIdQueryResult, error) {
+	args := m.Called(promotionQuery)
+	result, _ := args.Get(0).(*query.GetPromotionByIdQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockPromotionService) FindPromotionsBySeller(ctx context.Context, promotionQuery *query.GetPromotionsBySellerQuery) (*query.GetPromotionsBySellerQueryResult, error) {
+	args := m.Called(promotionQuery)
+	result, _ := args.Get(0).(*query.GetPromotionsBySellerQueryResult)
+	return result, args.Error(1)
+}
+
+func testPromotionResult(version int) *common.PromotionResult {
+	return &common.PromotionResult{
+		Id:           uuid.New(),
+		SellerId:     uuid.New(),
+		Name:         "Spring sale",
+		DiscountKind: string(entities.DiscountPercentage),
+		BasisPoints:  1500,
+		StartsAt:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		Version:      version,
+	}
+}
+
+func TestCreatePromotion(t *testing.T) {
+	e := echo.New()
+	service := new(MockPromotionService)
+	rest.NewPromotionController(e, service)
+
+	created := testPromotionResult(1)
+	productId := uuid.New()
+	endsAt := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	service.On("CreatePromotion", &command.CreatePromotionCommand{
+		IdempotencyKey: "key-1",
+		SellerId:       created.SellerId,
+		Name:           "Spring sale",
+		DiscountKind:   entities.DiscountPercentage,
+		BasisPoints:    1500,
+		ProductIds:     []uuid.UUID{productId},
+		StartsAt:       created.StartsAt,
+		EndsAt:         &endsAt,
+		Stackable:      true,
+		UsageLimit:     100,
+	}).Return(&command.CreatePromotionCommandResult{Result: created}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/promotions", strings.NewReader(fmt.Sprintf(
+		`{"seller_id":%q,"name":"Spring sale","discount_kind":"percentage","basis_points":1500,"product_ids":[%q],`+
+			`"starts_at":"2024-03-01T00:00:00Z","ends_at":"2024-04-01T00:00:00Z","stackable":true,"usage_limit":100}`,
+		created.SellerId, productId)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+	var body response.PromotionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, created.Id.String(), body.Id)
+	assert.Equal(t, int64(1500), body.BasisPoints)
+	service.AssertExpectations(t)
+}
+
+func TestCreatePromotion_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		body   string
+		err    error
+		status int
+	}{
+		"invalid seller id":  {`{"seller_id":"nope"}`, nil, http.StatusBadRequest},
+		"invalid product id": {fmt.Sprintf(`{"seller_id":%q,"product_ids":["nope"]}`, uuid.New()), nil, http.StatusBadRequest},
+		"invalid discount":   {fmt.Sprintf(`{"seller_id":%q}`, uuid.New()), fmt.Errorf("%w: unknown discount kind", entities.ErrValidation), http.StatusBadRequest},
+		"unknown seller":     {fmt.Sprintf(`{"seller_id":%q}`, uuid.New()), entities.ErrSellerNotFound, http.StatusNotFound},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			service := new(MockPromotionService)
+			rest.NewPromotionController(e, service)
+			if tc.err != nil {
+				service.On("CreatePromotion", mock.Anything).Return(nil, tc.err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/promotions", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+			if tc.err == nil {
+				service.AssertNotCalled(t, "CreatePromotion", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGetPromotions(t *testing.T) {
+	e := echo.New()
+	service := new(MockPromotionService)
+	rest.NewPromotionController(e, service)
+
+	sellerId := uuid.New()
+	service.On("FindPromotionsBySeller", &query.GetPromotionsBySellerQuery{SellerId: sellerId}).Return(&query.GetPromotionsBySellerQueryResult{
+		Result: []*common.PromotionResult{testPromotionResult(1), testPromotionResult(2)},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/promotions?seller_id="+sellerId.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.ListPromotionsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(t, body.Promotions, 2)
+
+	// seller_id is required.
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/promotions", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestGetPromotionById(t *testing.T) {
+	e := echo.New()
+	service := new(MockPromotionService)
+	rest.NewPromotionController(e, service)
+
+	promotion := testPromotionResult(2)
+	service.On("FindPromotionById", &query.GetPromotionByIdQuery{Id: promotion.Id}).Return(&query.GetPromotionByIdQueryResult{Result: promotion}, nil)
+	service.On("FindPromotionById", mock.Anything).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/promotions/"+promotion.Id.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/promotions/"+uuid.NewString(), nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRedeemPromotion(t *testing.T) {
+	e := echo.New()
+	service := new(MockPromotionService)
+	rest.NewPromotionController(e, service)
+
+	redeemed := testPromotionResult(3)
+	redeemed.UsageCount = 1
+	version := 2
+	service.On("RedeemPromotion", &command.RedeemPromotionCommand{
+		IdempotencyKey:  "key-1",
+		Id:              redeemed.Id,
+		ExpectedVersion: &version,
+	}).Return(&command.RedeemPromotionCommandResult{Result: redeemed}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/promotions/"+redeemed.Id.String()+"/redemptions", nil)
+	req.Header.Set("If-Match", `"2"`)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	var body response.PromotionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, 1, body.UsageCount)
+	service.AssertExpectations(t)
+}
+
+func TestPromotionChanges_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		path    string
+		method  string
+		err     error
+		ifMatch string
+		status  int
+	}{
+		"used up":            {"redemptions", "RedeemPromotion", entities.ErrPromotionExhausted, "", http.StatusConflict},
+		"unknown promotion":  {"end", "EndPromotion", entities.ErrPromotionNotFound, "", http.StatusNotFound},
+		"stale version":      {"end", "EndPromotion", entities.ErrVersionConflict, `"1"`, http.StatusPreconditionFailed},
+		"malformed If-Match": {"redemptions", "", nil, "1", http.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			service := new(MockPromotionService)
+			rest.NewPromotionController(e, service)
+			if tc.method != "" {
+				service.On(tc.method, mock.Anything).Return(nil, tc.err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/promotions/"+uuid.NewString()+"/"+tc.path, nil)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sklinkert/go-ddd/internal/application/command"
+	"github.com/sklinkert/go-ddd/internal/application/common"
+	"github.com/sklinkert/go-ddd/internal/application/query"
+	"github.com/sklinkert/go-ddd/internal/domain/entities"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest"
+	"github.com/sklinkert/go-ddd/internal/interface/api/rest/dto/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateWebhookSubscription(ctx context.Context, subscriptionCommand *command.CreateWebhookSubscriptionCommand) (*command.CreateWebhookSubscriptionCommandResult, error) {
+	args := m.Called(subscriptionCommand)
+	result, _ := args.Get(0).(*command.CreateWebhookSubscriptionCommandResult)
+	return result, args.Error(1)
+}
+
+func (m *MockWebhookService) FindAllWebhookSubscriptions(ctx context.Context) (*query.GetAllWebhookSubscriptionsQueryResult, error) {
+	args := m.Called()
+	result, _ := args.Get(0).(*query.GetAllWebhookSubscriptionsQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockWebhookService) FindWebhookSubscriptionById(ctx context.Context, subscriptionQuery *query.GetWebhookSubscriptionByIdQuery) (*query.GetWebhookSubscriptionByIdQueryResult, error) {
+	args := m.Called(subscriptionQuery)
+	result, _ := args.Get(0).(*query.GetWebhookSubscriptionByIdQueryResult)
+	return result, args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteWebhookSubscription(ctx context.Context, subscriptionCommand *command.DeleteWebhookSubscriptionCommand) (*command.DeleteWebhookSubscriptionCommandResult, error) {
+	args := m.Called(subscriptionCommand)
+	if err := args.Error(0); err != nil {
+		return nil, err
+	}
+	return &command.DeleteWebhookSubscriptionCommandResult{Success: true}, nil
+}
+
+func (m *MockWebhookService) FindWebhookDeliveries(ctx context.Context, deliveryQuery *query.GetWebhookDeliveriesQuery) (*query.GetWebhookDeliveriesQueryResult, error) {
+	args := m.Called(deliveryQuery)
+	result, _ := args.Get(0).(*query.GetWebhookDeliveriesQueryResult)
+	return result, args.Error(1)
+}
+
+func TestCreateWebhookSubscription(t *testing.T) {
+	e := echo.New()
+	service := new(MockWebhookService)
+	rest.NewWebhookController(e, service)
+
+	createCommand := &command.CreateWebhookSubscriptionCommand{
+		URL:        "https://partner.example/hooks",
+		EventTypes: []string{"product.*"},
+		Secret:     "0123456789abcdef",
+	}
+	subscription := &common.WebhookSubscriptionResult{
+		Id:         uuid.New(),
+		URL:        createCommand.URL,
+		EventTypes: createCommand.EventTypes,
+		CreatedAt:  time.Now(),
+	}
+	service.On("CreateWebhookSubscription", createCommand).Return(&command.CreateWebhookSubscriptionCommandResult{Result: subscription}, nil)
+
+	body, _ := json.Marshal(map[string]any{"url": createCommand.URL, "event_types": createCommand.EventTypes, "secret": createCommand.Secret})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/subscriptions", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.NotContains(t, rec.Body.String(), createCommand.Secret, "the secret is never echoed back")
+	var created response.WebhookSubscriptionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, subscription.Id.String(), created.Id)
+	assert.Equal(t, []string{"product.*"}, created.EventTypes)
+	service.AssertExpectations(t)
+}
+
+func TestCreateWebhookSubscription_Invalid(t *testing.T) {
+	e := echo.New()
+	service := new(MockWebhookService)
+	rest.NewWebhookController(e, service)
+
+	service.On("CreateWebhookSubscription", mock.Anything).
+		Return(nil, fmt.Errorf("%w: url must be an absolute http(s) URL", entities.ErrValidation))
+
+	body, _ := json.Marshal(map[string]any{"url": "ftp://partner.example", "event_types": []string{"*"}, "secret": "short"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/subscriptions", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "absolute http(s) URL")
+}
+
+func TestGetWebhookSubscription_NotFound(t *testing.T) {
+	e := echo.New()
+	service := new(MockWebhookService)
+	rest.NewWebhookController(e, service)
+
+	id := uuid.New()
+	service.On("FindWebhookSubscriptionById", &query.GetWebhookSubscriptionByIdQuery{Id: id}).Return(nil, entities.ErrWebhookSubscriptionNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/subscriptions/"+id.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestDeleteWebhookSubscription(t *testing.T) {
+	e := echo.New()
+	service := new(MockWebhookService)
+	rest.NewWebhookController(e, service)
+
+	id := uuid.New()
+	service.On("DeleteWebhookSubscription", &command.DeleteWebhookSubscriptionCommand{Id: id}).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/webhooks/subscriptions/"+id.String(), nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	service.AssertExpectations(t)
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	e := echo.New()
+	service := new(MockWebhookService)
+	rest.NewWebhookController(e, service)
+
+	id := uuid.New()
+	deliveredAt, nextAttemptAt := time.Now(), time.Now().Add(time.Minute)
+	service.On("FindWebhookDeliveries", &query.GetWebhookDeliveriesQuery{SubscriptionId: id, Limit: 10}).Return(&query.GetWebhookDeliveriesQueryResult{
+		Result: []*common.WebhookDeliveryResult{
+			{Id: uuid.New(), EventId: uuid.New(), EventName: "product.created", Status: "delivered", Attempts: 1, LastStatusCode: 200, DeliveredAt: &deliveredAt},
+			{Id: uuid.New(), EventId: uuid.New(), EventName: "product.renamed", Status: "pending", Attempts: 2, LastError: "endpoint responded with 503", NextAttemptAt: &nextAttemptAt},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/subscriptions/"+id.String()+"/deliveries?limit=10", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var listed response.ListWebhookDeliveriesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed))
+	require.Len(t, listed.Deliveries, 2)
+	assert.Equal(t, "delivered", listed.Deliveries[0].Status)
+	assert.Nil(t, listed.Deliveries[0].NextAttemptAt)
+	assert.Equal(t, "pending", listed.Deliveries[1].Status)
+	assert.NotNil(t, listed.Deliveries[1].NextAttemptAt)
+	assert.Equal(t, "endpoint responded with 503", listed.Deliveries[1].LastError)
+}
+
+func TestListWebhookDeliveries_InvalidLimit(t *testing.T) {
+	e := echo.New()
+	rest.NewWebhookController(e, new(MockWebhookService))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/subscriptions/"+uuid.NewString()+"/deliveries?limit=0", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
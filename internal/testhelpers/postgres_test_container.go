@@ -147,7 +147,7 @@ func (p *PostgresTestContainer) TruncateTables(t *testing.T) {
 	ctx := context.Background()
 
 	// Truncate tables in dependency order (child tables first)
-	tables := []string{"products", "idempotency_records", "outbox_events", "sellers"}
+	tables := []string{"product_categories", "categories", "promotions", "scheduled_price_changes", "product_price_history", "exchange_rates", "cart_lines", "carts", "order_items", "orders", "stock_reservations", "inventories", "products", "idempotency_records", "outbox_events", "outbox_events_archive", "outbox_replays", "inbox_messages", "webhook_deliveries", "webhook_subscriptions", "sellers"}
 
 	for _, table := range tables {
 		_, err := p.Pool.Exec(ctx, fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table))
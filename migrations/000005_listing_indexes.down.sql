@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_sellers_name_id;
+DROP INDEX IF EXISTS idx_sellers_created_at_id;
+DROP INDEX IF EXISTS idx_products_price_id;
+DROP INDEX IF EXISTS idx_products_name_id;
+DROP INDEX IF EXISTS idx_products_created_at_id;
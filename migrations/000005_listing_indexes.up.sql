@@ -0,0 +1,9 @@
+-- Keyset pagination walks these indexes instead of sorting the whole
+-- table. Each sort order pairs its column with id as a unique tiebreaker,
+-- matching the ORDER BY of the List* queries.
+CREATE INDEX idx_products_created_at_id ON products(created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_products_name_id ON products(name, id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_products_price_id ON products(price_minor_units, id) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_sellers_created_at_id ON sellers(created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_sellers_name_id ON sellers(name, id) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE sellers DROP COLUMN version;
+ALTER TABLE products DROP COLUMN version;
@@ -0,0 +1,5 @@
+-- Optimistic concurrency: every write bumps the version and only applies
+-- when the caller still holds the version it read. Existing rows start at 1,
+-- the same as freshly created aggregates.
+ALTER TABLE products ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE sellers ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
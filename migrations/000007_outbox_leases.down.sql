@@ -0,0 +1,3 @@
+ALTER TABLE outbox_events
+    DROP COLUMN claimed_until,
+    DROP COLUMN claimed_by;
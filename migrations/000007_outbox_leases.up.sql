@@ -0,0 +1,7 @@
+-- Outbox leases let several relays run side by side: a relay claims a batch
+-- (SELECT ... FOR UPDATE SKIP LOCKED) by stamping claimed_by/claimed_until,
+-- then publishes outside the claiming transaction. Other relays skip claimed
+-- rows until the lease expires, so a crashed relay's batch is picked up again.
+ALTER TABLE outbox_events
+    ADD COLUMN claimed_by TEXT,
+    ADD COLUMN claimed_until TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,10 @@
+DROP INDEX idx_outbox_events_dead_lettered;
+
+DROP INDEX idx_outbox_events_unpublished;
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events(occurred_at) WHERE published_at IS NULL;
+
+ALTER TABLE outbox_events
+    DROP COLUMN dead_lettered_at,
+    DROP COLUMN next_attempt_at,
+    DROP COLUMN last_error,
+    DROP COLUMN attempts;
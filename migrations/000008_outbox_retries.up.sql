@@ -0,0 +1,18 @@
+-- Per-event retry state. A failed publish bumps attempts, stores the error
+-- and pushes next_attempt_at out with exponential backoff, so one poison
+-- event no longer blocks the events behind it. After the configured maximum
+-- number of attempts the event is dead-lettered: the relay stops trying
+-- until an operator replays or discards it.
+ALTER TABLE outbox_events
+    ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN last_error TEXT,
+    ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN dead_lettered_at TIMESTAMP WITH TIME ZONE;
+
+-- Dead letters are no longer pending work; keep them out of the relay index.
+DROP INDEX idx_outbox_events_unpublished;
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events(occurred_at)
+    WHERE published_at IS NULL AND dead_lettered_at IS NULL;
+
+CREATE INDEX idx_outbox_events_dead_lettered ON outbox_events(dead_lettered_at, id)
+    WHERE dead_lettered_at IS NOT NULL;
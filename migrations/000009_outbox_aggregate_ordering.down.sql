@@ -0,0 +1,8 @@
+DROP INDEX idx_outbox_events_claimable;
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events(occurred_at)
+    WHERE published_at IS NULL AND dead_lettered_at IS NULL;
+
+DROP INDEX idx_outbox_events_pending_by_aggregate;
+
+-- Drops the owned sequence along with the column.
+ALTER TABLE outbox_events DROP COLUMN sequence_number;
@@ -0,0 +1,29 @@
+-- Per-aggregate delivery order. occurred_at comes from application clocks,
+-- which can skew between instances; sequence_number is assigned on insert.
+-- Writes to one aggregate are serialized by its row lock (and version
+-- check), so for a given aggregate_id insertion order is commit order.
+ALTER TABLE outbox_events ADD COLUMN sequence_number BIGINT;
+
+-- Number existing rows in the order the relay used to publish them.
+UPDATE outbox_events
+SET sequence_number = numbered.n
+FROM (SELECT id, row_number() OVER (ORDER BY occurred_at, id) AS n FROM outbox_events) AS numbered
+WHERE outbox_events.id = numbered.id;
+
+CREATE SEQUENCE outbox_events_sequence_number_seq OWNED BY outbox_events.sequence_number;
+SELECT setval('outbox_events_sequence_number_seq', COALESCE(MAX(sequence_number), 0) + 1, false)
+FROM outbox_events;
+
+ALTER TABLE outbox_events
+    ALTER COLUMN sequence_number SET DEFAULT nextval('outbox_events_sequence_number_seq'),
+    ALTER COLUMN sequence_number SET NOT NULL;
+
+-- Answers "does this aggregate have an earlier pending event?" for the claim.
+CREATE INDEX idx_outbox_events_pending_by_aggregate ON outbox_events(aggregate_id, sequence_number)
+    WHERE published_at IS NULL;
+
+-- The claim now scans pending events in sequence_number order, so the
+-- occurred_at index from 000008 no longer serves it.
+DROP INDEX idx_outbox_events_unpublished;
+CREATE INDEX idx_outbox_events_claimable ON outbox_events(sequence_number)
+    WHERE published_at IS NULL AND dead_lettered_at IS NULL;
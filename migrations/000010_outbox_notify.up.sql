@@ -0,0 +1,14 @@
+-- Wakes listening relays as soon as events commit, instead of on their next
+-- poll. NOTIFY is transactional: it is delivered on commit, dropped on
+-- rollback, and identical notifications within one transaction are folded
+-- into one. The payload is empty; relays just claim what is pending.
+CREATE FUNCTION notify_outbox_events() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('outbox_events', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER outbox_events_notify
+    AFTER INSERT ON outbox_events
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_outbox_events();
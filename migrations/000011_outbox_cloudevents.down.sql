@@ -0,0 +1,2 @@
+-- Payloads keep the snake_case shape; only the version column goes.
+ALTER TABLE outbox_events DROP COLUMN schema_version;
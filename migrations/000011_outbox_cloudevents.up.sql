@@ -0,0 +1,21 @@
+-- Outbox payloads become the CloudEvents "data": event fields only, under
+-- explicit snake_case names. Id, aggregate and time already live in their
+-- own columns; schema_version versions the data shape per event.
+ALTER TABLE outbox_events ADD COLUMN schema_version INTEGER NOT NULL DEFAULT 1;
+
+-- Rewrite stored payloads from the old json.Marshal output, e.g.
+-- {"Id":..,"Aggregate":..,"OccurredAtT":..,"OldPrice":{"MinorUnits":1}}
+-- to {"old_price":{"minor_units":1}}, so pending and replayed events use the
+-- new wire format. Event data is at most one object level deep (Money).
+UPDATE outbox_events
+SET payload = COALESCE((
+    SELECT jsonb_object_agg(
+        lower(regexp_replace(field.key, '([a-z0-9])([A-Z])', '\1_\2', 'g')),
+        CASE WHEN jsonb_typeof(field.value) = 'object' THEN COALESCE((
+            SELECT jsonb_object_agg(lower(regexp_replace(nested.key, '([a-z0-9])([A-Z])', '\1_\2', 'g')), nested.value)
+            FROM jsonb_each(field.value) AS nested
+        ), '{}'::jsonb) ELSE field.value END)
+    FROM jsonb_each(payload) AS field
+    WHERE field.key NOT IN ('Id', 'Aggregate', 'OccurredAtT')
+), '{}'::jsonb)
+WHERE payload ? 'OccurredAtT';
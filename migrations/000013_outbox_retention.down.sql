@@ -0,0 +1,2 @@
+DROP INDEX idx_outbox_events_published;
+DROP TABLE outbox_events_archive;
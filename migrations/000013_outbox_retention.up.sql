@@ -0,0 +1,22 @@
+-- Outbox retention: published events older than the retention period are
+-- moved out of outbox_events in small batches, so the relay's table (and
+-- its indexes) stay as small as the pending work. The archive keeps them
+-- for audits and replays; it is append-only and never read by the relay.
+CREATE TABLE outbox_events_archive (
+    id UUID PRIMARY KEY,
+    aggregate_id UUID NOT NULL,
+    event_name TEXT NOT NULL,
+    schema_version INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sequence_number BIGINT NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_outbox_events_archive_aggregate ON outbox_events_archive(aggregate_id, sequence_number);
+CREATE INDEX idx_outbox_events_archive_occurred_at ON outbox_events_archive(occurred_at);
+
+-- Lets the retention worker find expired rows without scanning the table.
+CREATE INDEX idx_outbox_events_published ON outbox_events(published_at)
+    WHERE published_at IS NOT NULL;
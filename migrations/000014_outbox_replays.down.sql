@@ -0,0 +1,3 @@
+DROP INDEX idx_outbox_events_archive_sequence_number;
+DROP INDEX idx_outbox_events_sequence_number;
+DROP TABLE outbox_replays;
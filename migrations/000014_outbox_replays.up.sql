@@ -0,0 +1,37 @@
+-- Outbox replays re-emit already published events (from outbox_events and
+-- outbox_events_archive) to a publisher, e.g. to rebuild a downstream
+-- consumer. Each row is one replay and its checkpoint: events are replayed
+-- in sequence_number order, and last_sequence_number records how far it
+-- got, so a failed or interrupted replay resumes where it stopped. A
+-- replay never changes the events' own publication state.
+CREATE TABLE outbox_replays (
+    id UUID PRIMARY KEY,
+    -- target names the publisher, e.g. "kafka" or "log".
+    target TEXT NOT NULL,
+    -- Filters; NULL means "any".
+    aggregate_id UUID,
+    event_name TEXT,
+    occurred_from TIMESTAMP WITH TIME ZONE,
+    occurred_to TIMESTAMP WITH TIME ZONE,
+    -- Events published later are not part of the replay; the relay sends
+    -- them anyway. This also keeps total stable.
+    published_before TIMESTAMP WITH TIME ZONE NOT NULL,
+    status TEXT NOT NULL,
+    total BIGINT NOT NULL,
+    replayed BIGINT NOT NULL DEFAULT 0,
+    last_sequence_number BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    -- Lease of the process running the replay, renewed with every
+    -- checkpoint. A replay whose runner died can be resumed once it expires.
+    claimed_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_outbox_replays_created_at ON outbox_replays(created_at);
+
+-- Replays page through both tables by sequence_number.
+CREATE INDEX idx_outbox_events_sequence_number ON outbox_events(sequence_number)
+    WHERE published_at IS NOT NULL;
+CREATE INDEX idx_outbox_events_archive_sequence_number ON outbox_events_archive(sequence_number);
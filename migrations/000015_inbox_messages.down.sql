@@ -0,0 +1 @@
+DROP TABLE inbox_messages;
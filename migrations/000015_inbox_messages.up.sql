@@ -0,0 +1,21 @@
+-- The inbox makes consumption of external messages idempotent: a message is
+-- stored once per (source, message_id), whatever the sender redelivers, and
+-- its handler runs in the same transaction that sets processed_at. A
+-- handler failure rolls its side effects back and schedules a retry; after
+-- the last attempt the message is parked (failed_at) for an operator.
+CREATE TABLE inbox_messages (
+    source TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    message_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (source, message_id)
+);
+
+CREATE INDEX idx_inbox_messages_due ON inbox_messages(next_attempt_at, received_at)
+    WHERE processed_at IS NULL AND failed_at IS NULL;
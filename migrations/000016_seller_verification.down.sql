@@ -0,0 +1 @@
+ALTER TABLE sellers DROP COLUMN verification_status;
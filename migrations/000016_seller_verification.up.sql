@@ -0,0 +1,3 @@
+-- Result of the seller's identity (KYC) check, reported by an external
+-- system through the inbox. Existing sellers have not been checked yet.
+ALTER TABLE sellers ADD COLUMN verification_status TEXT NOT NULL DEFAULT 'unverified';
@@ -0,0 +1,24 @@
+-- Stock per product. Writers lock the inventory row (SELECT ... FOR UPDATE)
+-- for the whole read-modify-write, so concurrent reservations of the last
+-- units serialize instead of overbooking. The CHECKs back the domain
+-- invariants up.
+CREATE TABLE inventories (
+    product_id UUID PRIMARY KEY REFERENCES products(id),
+    on_hand INTEGER NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+-- Units held for a buyer until committed, released or expired. Expired
+-- rows are released by the application, which records StockReleased.
+CREATE TABLE stock_reservations (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES inventories(product_id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_reservations_product ON stock_reservations(product_id);
+CREATE INDEX idx_stock_reservations_expires ON stock_reservations(expires_at);
@@ -0,0 +1,30 @@
+-- Orders reference their buyer and products by id only. Items snapshot the
+-- product name and price at purchase time, so later edits to the product
+-- leave past orders untouched.
+CREATE TABLE orders (
+    id UUID PRIMARY KEY,
+    buyer_id UUID NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'paid', 'shipped', 'delivered', 'cancelled', 'refunded')),
+    tracking_number TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE INDEX idx_orders_buyer_created ON orders(buyer_id, created_at DESC, id DESC);
+CREATE INDEX idx_orders_created ON orders(created_at DESC, id DESC);
+
+CREATE TABLE order_items (
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    product_id UUID NOT NULL,
+    product_name TEXT NOT NULL,
+    unit_price_minor_units BIGINT NOT NULL CHECK (unit_price_minor_units > 0),
+    currency TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    -- The stock reservation is gone once the order is paid or cancelled;
+    -- the id stays as a reference.
+    reservation_id UUID,
+    PRIMARY KEY (order_id, position),
+    UNIQUE (order_id, product_id)
+);
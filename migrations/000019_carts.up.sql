@@ -0,0 +1,24 @@
+-- One cart per buyer. Lines copy the product name and price when added;
+-- checkout compares them with the live products. Expired carts are deleted
+-- by the application.
+CREATE TABLE carts (
+    buyer_id UUID PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE INDEX idx_carts_expires ON carts(expires_at);
+
+CREATE TABLE cart_lines (
+    buyer_id UUID NOT NULL REFERENCES carts(buyer_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    product_id UUID NOT NULL,
+    product_name TEXT NOT NULL,
+    unit_price_minor_units BIGINT NOT NULL CHECK (unit_price_minor_units > 0),
+    currency TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (buyer_id, position),
+    UNIQUE (buyer_id, product_id)
+);
@@ -0,0 +1,10 @@
+-- Effective-dated exchange rates: 1 base_currency buys rate quote_currency
+-- from effective_at until the next row for the same pair. Old rows are kept
+-- so past conversions can be reproduced.
+CREATE TABLE exchange_rates (
+    base_currency TEXT NOT NULL,
+    quote_currency TEXT NOT NULL,
+    rate NUMERIC NOT NULL CHECK (rate > 0),
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (base_currency, quote_currency, effective_at)
+);
@@ -0,0 +1,2 @@
+DROP TABLE scheduled_price_changes;
+DROP TABLE product_price_history;
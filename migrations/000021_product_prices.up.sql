@@ -0,0 +1,30 @@
+-- Every price a product had, from effective_at until the next row. Rows are
+-- written with each price change, so "the lowest price of the last 30 days"
+-- can be answered from here.
+CREATE TABLE product_price_history (
+    product_id UUID NOT NULL REFERENCES products(id),
+    price_minor_units BIGINT NOT NULL CHECK (price_minor_units > 0),
+    currency TEXT NOT NULL,
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (product_id, effective_at)
+);
+
+-- When the current prices were set is unknown; they were in effect at the
+-- latest since the product's last update.
+INSERT INTO product_price_history (product_id, price_minor_units, currency, effective_at)
+SELECT id, price_minor_units, currency, updated_at
+FROM products
+WHERE deleted_at IS NULL;
+
+-- Price changes that a worker applies once effective_at has passed.
+CREATE TABLE scheduled_price_changes (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id),
+    price_minor_units BIGINT NOT NULL CHECK (price_minor_units > 0),
+    currency TEXT NOT NULL,
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_scheduled_price_changes_effective_at ON scheduled_price_changes(effective_at);
+CREATE INDEX idx_scheduled_price_changes_product ON scheduled_price_changes(product_id, effective_at);
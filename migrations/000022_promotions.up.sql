@@ -0,0 +1,28 @@
+-- Seller-defined discounts. A percentage promotion sets basis_points, a
+-- fixed-amount one amount_minor_units and currency.
+CREATE TABLE promotions (
+    id UUID PRIMARY KEY,
+    seller_id UUID NOT NULL REFERENCES sellers(id),
+    name TEXT NOT NULL,
+    discount_kind TEXT NOT NULL CHECK (discount_kind IN ('percentage', 'fixed_amount')),
+    basis_points BIGINT,
+    amount_minor_units BIGINT,
+    currency TEXT,
+    -- Empty applies the promotion to all of the seller's products.
+    product_ids UUID[] NOT NULL DEFAULT '{}',
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    stackable BOOLEAN NOT NULL,
+    -- 0 means unlimited.
+    usage_limit INTEGER NOT NULL CHECK (usage_limit >= 0),
+    usage_count INTEGER NOT NULL CHECK (usage_count >= 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    version INTEGER NOT NULL,
+    CHECK (
+        (discount_kind = 'percentage' AND basis_points BETWEEN 1 AND 10000 AND amount_minor_units IS NULL AND currency IS NULL)
+        OR (discount_kind = 'fixed_amount' AND basis_points IS NULL AND amount_minor_units > 0 AND currency IS NOT NULL)
+    )
+);
+
+CREATE INDEX idx_promotions_seller_starts_at ON promotions(seller_id, starts_at);
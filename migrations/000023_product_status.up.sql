@@ -0,0 +1,5 @@
+-- Products listed before the lifecycle existed stay listed; new products are
+-- written as drafts by the application, which always sets the status.
+ALTER TABLE products ADD COLUMN status TEXT NOT NULL DEFAULT 'published'
+    CHECK (status IN ('draft', 'published', 'archived'));
+ALTER TABLE products ALTER COLUMN status DROP DEFAULT;
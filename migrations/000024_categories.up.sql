@@ -0,0 +1,26 @@
+-- Product taxonomy. path is the materialized path of ids from the root down
+-- to the category, e.g. '/<root id>/<parent id>/<id>/'; the descendants of
+-- a category are the rows whose path starts with its own.
+CREATE TABLE categories (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    parent_id UUID REFERENCES categories(id),
+    path TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    version INTEGER NOT NULL,
+    CHECK (parent_id <> id)
+);
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+-- text_pattern_ops lets prefix LIKE queries use the index in any collation.
+CREATE UNIQUE INDEX idx_categories_path ON categories(path text_pattern_ops);
+
+-- The foreign keys back the rule that only empty categories are deleted.
+CREATE TABLE product_categories (
+    product_id UUID NOT NULL REFERENCES products(id),
+    category_id UUID NOT NULL REFERENCES categories(id),
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX idx_product_categories_category_id ON product_categories(category_id);
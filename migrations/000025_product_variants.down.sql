@@ -0,0 +1,28 @@
+-- Lossy: only the first variant of each product keeps its stock, cart
+-- lines and order items.
+DELETE FROM cart_lines l USING product_variants v
+WHERE v.id = l.variant_id AND v.id <> (SELECT min(id::text)::uuid FROM product_variants WHERE product_id = v.product_id);
+ALTER TABLE cart_lines DROP CONSTRAINT cart_lines_buyer_id_variant_id_key;
+ALTER TABLE cart_lines ADD UNIQUE (buyer_id, product_id);
+ALTER TABLE cart_lines DROP COLUMN variant_id, DROP COLUMN sku;
+
+DELETE FROM order_items o
+WHERE EXISTS (SELECT 1 FROM order_items other WHERE other.order_id = o.order_id AND other.product_id = o.product_id AND other.position < o.position);
+ALTER TABLE order_items DROP CONSTRAINT order_items_order_id_variant_id_key;
+ALTER TABLE order_items ADD UNIQUE (order_id, product_id);
+ALTER TABLE order_items DROP COLUMN variant_id, DROP COLUMN sku;
+
+DELETE FROM inventories i
+WHERE i.variant_id <> (SELECT min(id::text)::uuid FROM product_variants WHERE product_id = i.product_id);
+ALTER TABLE stock_reservations ADD COLUMN product_id UUID;
+UPDATE stock_reservations r SET product_id = i.product_id FROM inventories i WHERE i.variant_id = r.variant_id;
+ALTER TABLE stock_reservations DROP COLUMN variant_id;
+ALTER TABLE stock_reservations ALTER COLUMN product_id SET NOT NULL;
+DROP INDEX idx_inventories_product_id;
+ALTER TABLE inventories DROP CONSTRAINT inventories_pkey;
+ALTER TABLE inventories DROP COLUMN variant_id;
+ALTER TABLE inventories ADD PRIMARY KEY (product_id);
+ALTER TABLE stock_reservations ADD FOREIGN KEY (product_id) REFERENCES inventories(product_id) ON DELETE CASCADE;
+CREATE INDEX idx_stock_reservations_product ON stock_reservations(product_id);
+
+DROP TABLE product_variants;
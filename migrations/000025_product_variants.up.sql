@@ -0,0 +1,58 @@
+-- Sellable versions of a product, e.g. a T-shirt per size and colour. Every
+-- product has at least one variant; stock is kept per variant. SKUs are
+-- unique across all products, including soft-deleted ones.
+CREATE TABLE product_variants (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id),
+    sku TEXT NOT NULL,
+    attributes JSONB NOT NULL DEFAULT '{}',
+    price_override_minor_units BIGINT CHECK (price_override_minor_units > 0),
+    price_override_currency TEXT,
+    CHECK ((price_override_minor_units IS NULL) = (price_override_currency IS NULL)),
+    -- Backs the domain rule that sibling variants differ in their
+    -- attributes; jsonb equality ignores key order.
+    UNIQUE (product_id, attributes)
+);
+
+CREATE UNIQUE INDEX idx_product_variants_sku ON product_variants(sku);
+CREATE INDEX idx_product_variants_product_id ON product_variants(product_id);
+
+-- Existing products get a single default variant whose SKU is the product
+-- id, as new products without variants do.
+INSERT INTO product_variants (id, product_id, sku)
+SELECT gen_random_uuid(), id, id::text FROM products;
+
+-- Inventories move from one per product to one per variant. Removing a
+-- variant removes its stock.
+ALTER TABLE inventories ADD COLUMN variant_id UUID REFERENCES product_variants(id) ON DELETE CASCADE;
+UPDATE inventories i SET variant_id = v.id FROM product_variants v WHERE v.product_id = i.product_id;
+ALTER TABLE stock_reservations DROP CONSTRAINT stock_reservations_product_id_fkey;
+ALTER TABLE inventories DROP CONSTRAINT inventories_pkey;
+ALTER TABLE inventories ALTER COLUMN variant_id SET NOT NULL;
+ALTER TABLE inventories ADD PRIMARY KEY (variant_id);
+CREATE INDEX idx_inventories_product_id ON inventories(product_id);
+
+ALTER TABLE stock_reservations ADD COLUMN variant_id UUID;
+UPDATE stock_reservations r SET variant_id = i.variant_id FROM inventories i WHERE i.product_id = r.product_id;
+ALTER TABLE stock_reservations ALTER COLUMN variant_id SET NOT NULL;
+ALTER TABLE stock_reservations ADD FOREIGN KEY (variant_id) REFERENCES inventories(variant_id) ON DELETE CASCADE;
+DROP INDEX idx_stock_reservations_product;
+ALTER TABLE stock_reservations DROP COLUMN product_id;
+CREATE INDEX idx_stock_reservations_variant ON stock_reservations(variant_id);
+
+-- Cart lines and order items name the variant and snapshot its SKU. Order
+-- items keep no foreign key, so removing a variant leaves past orders intact.
+ALTER TABLE cart_lines ADD COLUMN variant_id UUID, ADD COLUMN sku TEXT;
+UPDATE cart_lines l SET variant_id = v.id, sku = v.sku FROM product_variants v WHERE v.product_id = l.product_id;
+DELETE FROM cart_lines WHERE variant_id IS NULL;
+ALTER TABLE cart_lines ALTER COLUMN variant_id SET NOT NULL, ALTER COLUMN sku SET NOT NULL;
+ALTER TABLE cart_lines DROP CONSTRAINT cart_lines_buyer_id_product_id_key;
+ALTER TABLE cart_lines ADD UNIQUE (buyer_id, variant_id);
+
+ALTER TABLE order_items ADD COLUMN variant_id UUID, ADD COLUMN sku TEXT;
+UPDATE order_items o SET variant_id = v.id, sku = v.sku FROM product_variants v WHERE v.product_id = o.product_id;
+-- Items of products that no longer exist keep their product id as variant.
+UPDATE order_items SET variant_id = product_id, sku = product_id::text WHERE variant_id IS NULL;
+ALTER TABLE order_items ALTER COLUMN variant_id SET NOT NULL, ALTER COLUMN sku SET NOT NULL;
+ALTER TABLE order_items DROP CONSTRAINT order_items_order_id_product_id_key;
+ALTER TABLE order_items ADD UNIQUE (order_id, variant_id);
@@ -0,0 +1,22 @@
+-- Pictures of a product in display order. The image data lives in the blob
+-- store under blob_key; one image per product is primary.
+CREATE TABLE product_images (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id),
+    blob_key TEXT NOT NULL UNIQUE,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    position INT NOT NULL DEFAULT 0,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_product_images_product_id ON product_images(product_id, position);
+
+-- Blobs whose image was removed or whose product was deleted. They are
+-- scheduled in the transaction that commits the removal and deleted from
+-- the blob store afterwards, so a rolled-back removal never loses data.
+CREATE TABLE blob_deletions (
+    blob_key TEXT PRIMARY KEY,
+    scheduled_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
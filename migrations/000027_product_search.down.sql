@@ -0,0 +1,3 @@
+DROP INDEX idx_products_search_vector;
+ALTER TABLE products DROP COLUMN search_vector;
+ALTER TABLE products DROP COLUMN category_names;
@@ -0,0 +1,22 @@
+-- Full-text search. A generated column can only read its own row, so
+-- category_names denormalizes the names of a product's categories and all
+-- their ancestors; the repositories refresh it whenever a product is
+-- recategorized or one of those categories is renamed or moved. The name
+-- weighs more than the categories when results are ranked.
+ALTER TABLE products ADD COLUMN category_names TEXT NOT NULL DEFAULT '';
+ALTER TABLE products ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', name), 'A') || setweight(to_tsvector('english', category_names), 'B')
+) STORED;
+
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
+
+UPDATE products p
+SET category_names = names.category_names
+FROM (
+    SELECT pc.product_id, string_agg(DISTINCT ancestor.name, ' ') AS category_names
+    FROM product_categories pc
+    JOIN categories c ON c.id = pc.category_id
+    JOIN categories ancestor ON c.path LIKE ancestor.path || '%'
+    GROUP BY pc.product_id
+) names
+WHERE names.product_id = p.id;
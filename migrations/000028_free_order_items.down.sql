@@ -0,0 +1,2 @@
+ALTER TABLE order_items DROP CONSTRAINT order_items_unit_price_minor_units_check;
+ALTER TABLE order_items ADD CONSTRAINT order_items_unit_price_minor_units_check CHECK (unit_price_minor_units > 0);
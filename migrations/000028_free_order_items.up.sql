@@ -0,0 +1,4 @@
+-- A promotion can discount a line to nothing, e.g. 100% off or a fixed
+-- amount at least the price; such items are ordered at zero.
+ALTER TABLE order_items DROP CONSTRAINT order_items_unit_price_minor_units_check;
+ALTER TABLE order_items ADD CONSTRAINT order_items_unit_price_minor_units_check CHECK (unit_price_minor_units >= 0);
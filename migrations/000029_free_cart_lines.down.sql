@@ -0,0 +1,2 @@
+ALTER TABLE cart_lines DROP CONSTRAINT cart_lines_unit_price_minor_units_check;
+ALTER TABLE cart_lines ADD CONSTRAINT cart_lines_unit_price_minor_units_check CHECK (unit_price_minor_units > 0);
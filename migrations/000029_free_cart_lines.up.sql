@@ -0,0 +1,4 @@
+-- Cart lines are priced like order items, at the effective price, which a
+-- promotion can discount to nothing.
+ALTER TABLE cart_lines DROP CONSTRAINT cart_lines_unit_price_minor_units_check;
+ALTER TABLE cart_lines ADD CONSTRAINT cart_lines_unit_price_minor_units_check CHECK (unit_price_minor_units >= 0);
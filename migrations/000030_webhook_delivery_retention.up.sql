@@ -0,0 +1,3 @@
+-- The retention worker deletes deliveries by the time they finished,
+-- delivered or failed for good.
+CREATE INDEX idx_webhook_deliveries_finished ON webhook_deliveries ((COALESCE(delivered_at, failed_at)));
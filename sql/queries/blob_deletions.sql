@@ -0,0 +1,11 @@
+-- name: ScheduleBlobDeletion :exec
+INSERT INTO blob_deletions (blob_key) VALUES ($1)
+ON CONFLICT (blob_key) DO NOTHING;
+
+-- name: ListDueBlobDeletions :many
+SELECT blob_key FROM blob_deletions
+ORDER BY scheduled_at, blob_key
+LIMIT $1;
+
+-- name: DeleteBlobDeletion :exec
+DELETE FROM blob_deletions WHERE blob_key = $1;
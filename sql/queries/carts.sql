@@ -0,0 +1,36 @@
+-- name: GetCart :one
+SELECT buyer_id, created_at, updated_at, expires_at, version
+FROM carts
+WHERE buyer_id = $1;
+
+-- name: ListCartLines :many
+SELECT buyer_id, position, product_id, product_name, unit_price_minor_units, currency, quantity, variant_id, sku
+FROM cart_lines
+WHERE buyer_id = $1
+ORDER BY position;
+
+-- name: UpsertCart :execrows
+-- Inserts a new cart or updates a stored one while its version still
+-- matches. A cart that was never saved passes version 0, which no stored
+-- row has, so two concurrent first writes cannot both succeed.
+INSERT INTO carts (buyer_id, created_at, updated_at, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (buyer_id) DO UPDATE
+SET updated_at = EXCLUDED.updated_at, expires_at = EXCLUDED.expires_at, version = carts.version + 1
+WHERE carts.version = $5;
+
+-- name: DeleteCartLines :exec
+DELETE FROM cart_lines WHERE buyer_id = $1;
+
+-- name: InsertCartLine :exec
+INSERT INTO cart_lines (buyer_id, position, product_id, variant_id, sku, product_name, unit_price_minor_units, currency, quantity)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: DeleteCart :execrows
+DELETE FROM carts WHERE buyer_id = $1 AND version = $2;
+
+-- name: DeleteExpiredCarts :execrows
+DELETE FROM carts
+WHERE buyer_id IN (
+    SELECT buyer_id FROM carts WHERE expires_at <= NOW() ORDER BY expires_at LIMIT $1
+);
@@ -0,0 +1,67 @@
+-- name: CreateCategory :exec
+INSERT INTO categories (id, name, parent_id, path, created_at, updated_at, version)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetCategoryById :one
+SELECT id, name, parent_id, path, created_at, updated_at, version
+FROM categories
+WHERE id = $1;
+
+-- name: ListCategoriesByParent :many
+-- A null parent_id lists the root categories.
+SELECT id, name, parent_id, path, created_at, updated_at, version
+FROM categories
+WHERE (sqlc.narg('parent_id')::uuid IS NULL AND parent_id IS NULL) OR parent_id = sqlc.narg('parent_id')::uuid
+ORDER BY name, id;
+
+-- name: LockCategoryPaths :many
+-- Locks the categories in id order, so concurrent moves cannot deadlock,
+-- and returns their current paths.
+SELECT id, path
+FROM categories
+WHERE id = ANY(sqlc.arg('ids')::uuid[])
+ORDER BY id
+FOR UPDATE;
+
+-- name: UpdateCategory :execrows
+-- Applies only while the row still has the version the caller read; zero
+-- rows means the category is gone or was modified concurrently.
+UPDATE categories
+SET name = $2, parent_id = $3, updated_at = $4, version = version + 1
+WHERE id = $1 AND version = $5;
+
+-- name: MoveCategorySubtree :exec
+-- Replaces the old_path prefix of a category and all its descendants.
+UPDATE categories
+SET path = sqlc.arg('new_path')::text || substr(path, length(sqlc.arg('old_path')::text) + 1)
+WHERE path LIKE sqlc.arg('old_path')::text || '%';
+
+-- name: RefreshCategorizedProductNames :exec
+-- Recomputes products.category_names for the products in the subtree under
+-- path, after a category in it was renamed or moved.
+UPDATE products p
+SET category_names = coalesce((
+        SELECT string_agg(DISTINCT ancestor.name, ' ')
+        FROM product_categories pc
+        JOIN categories c ON c.id = pc.category_id
+        JOIN categories ancestor ON c.path LIKE ancestor.path || '%'
+        WHERE pc.product_id = p.id), '')
+WHERE p.id IN (
+    SELECT pc.product_id
+    FROM product_categories pc
+    JOIN categories c ON c.id = pc.category_id
+    WHERE c.path LIKE sqlc.arg('path')::text || '%');
+
+-- name: DeleteCategory :execrows
+-- Deletes only an empty category: no subcategories and no products.
+DELETE FROM categories c
+WHERE c.id = $1 AND c.version = $2
+  AND NOT EXISTS (SELECT 1 FROM categories child WHERE child.parent_id = c.id)
+  AND NOT EXISTS (SELECT 1 FROM product_categories pc WHERE pc.category_id = c.id);
+
+-- name: CategoryExists :one
+SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1);
+
+-- name: CategoryHasContents :one
+SELECT EXISTS(SELECT 1 FROM categories WHERE parent_id = sqlc.arg('id')::uuid)
+    OR EXISTS(SELECT 1 FROM product_categories WHERE category_id = sqlc.arg('id')::uuid) AS has_contents;
@@ -0,0 +1,13 @@
+-- name: GetExchangeRate :one
+-- Returns the latest rate for the pair that took effect at or before $3.
+SELECT base_currency, quote_currency, rate, effective_at
+FROM exchange_rates
+WHERE base_currency = $1 AND quote_currency = $2 AND effective_at <= $3
+ORDER BY effective_at DESC
+LIMIT 1;
+
+-- name: UpsertExchangeRate :exec
+INSERT INTO exchange_rates (base_currency, quote_currency, rate, effective_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (base_currency, quote_currency, effective_at) DO UPDATE
+SET rate = EXCLUDED.rate;
@@ -0,0 +1,35 @@
+-- name: InsertInboxMessage :execrows
+-- Stores a received message. Zero rows means the message was received
+-- before, i.e. this is a redelivery.
+INSERT INTO inbox_messages (source, message_id, message_type, payload)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (source, message_id) DO NOTHING;
+
+-- name: ClaimInboxMessage :one
+-- Locks the oldest due message for the rest of the caller's transaction;
+-- concurrent dispatchers skip it and take the next one.
+SELECT *
+FROM inbox_messages
+WHERE processed_at IS NULL
+  AND failed_at IS NULL
+  AND next_attempt_at <= NOW()
+ORDER BY next_attempt_at, received_at
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkInboxMessageProcessed :exec
+UPDATE inbox_messages
+SET attempts = attempts + 1,
+    last_error = NULL,
+    processed_at = NOW()
+WHERE source = $1 AND message_id = $2;
+
+-- name: RecordInboxMessageFailure :exec
+-- Counts a failed attempt and schedules the next one; give_up parks the
+-- message for good.
+UPDATE inbox_messages
+SET attempts = attempts + 1,
+    last_error = sqlc.arg('last_error')::text,
+    next_attempt_at = NOW() + sqlc.arg('backoff_ms')::bigint * INTERVAL '1 millisecond',
+    failed_at = CASE WHEN sqlc.arg('give_up')::boolean THEN NOW() END
+WHERE source = sqlc.arg('source') AND message_id = sqlc.arg('message_id');
@@ -0,0 +1,45 @@
+-- name: EnsureInventory :execrows
+-- Variants start without an inventory row; the first writer creates it.
+-- Zero rows means the variant does not exist, or its row already does.
+INSERT INTO inventories (variant_id, product_id)
+SELECT v.id, v.product_id FROM product_variants v WHERE v.id = $1
+ON CONFLICT (variant_id) DO NOTHING;
+
+-- name: LockInventory :one
+SELECT product_id, on_hand, created_at, updated_at, version, variant_id
+FROM inventories
+WHERE variant_id = $1
+FOR UPDATE;
+
+-- name: GetInventory :one
+SELECT product_id, on_hand, created_at, updated_at, version, variant_id
+FROM inventories
+WHERE variant_id = $1;
+
+-- name: UpdateInventory :exec
+UPDATE inventories
+SET on_hand = $2, updated_at = $3, version = version + 1
+WHERE variant_id = $1;
+
+-- name: ListStockReservations :many
+SELECT id, quantity, expires_at, created_at, variant_id
+FROM stock_reservations
+WHERE variant_id = $1
+ORDER BY created_at, id;
+
+-- name: InsertStockReservation :exec
+INSERT INTO stock_reservations (id, variant_id, quantity, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: DeleteStockReservation :exec
+DELETE FROM stock_reservations WHERE id = $1;
+
+-- name: ListVariantsWithExpiredReservations :many
+SELECT variant_id
+FROM stock_reservations
+WHERE expires_at <= NOW()
+GROUP BY variant_id
+LIMIT $1;
+
+-- name: VariantHasReservations :one
+SELECT EXISTS(SELECT 1 FROM stock_reservations WHERE variant_id = $1);
@@ -0,0 +1,37 @@
+-- name: CreateOrder :exec
+INSERT INTO orders (id, buyer_id, status, tracking_number, created_at, updated_at, version)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: InsertOrderItem :exec
+INSERT INTO order_items (order_id, position, product_id, variant_id, sku, product_name, unit_price_minor_units, currency, quantity, reservation_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetOrderById :one
+SELECT id, buyer_id, status, tracking_number, created_at, updated_at, version
+FROM orders
+WHERE id = $1;
+
+-- name: ListOrders :many
+SELECT id, buyer_id, status, tracking_number, created_at, updated_at, version
+FROM orders
+WHERE (sqlc.narg('buyer_id')::uuid IS NULL OR buyer_id = sqlc.narg('buyer_id')::uuid)
+  AND (sqlc.narg('after_id')::uuid IS NULL OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListOrderItems :many
+-- Loads the items of a whole page of orders in one query.
+SELECT order_id, position, product_id, product_name, unit_price_minor_units, currency, quantity, reservation_id, variant_id, sku
+FROM order_items
+WHERE order_id = ANY(sqlc.arg('order_ids')::uuid[])
+ORDER BY order_id, position;
+
+-- name: UpdateOrder :execrows
+-- Items never change after creation; only the status moves on. Applies
+-- only while the row still has the version the caller read.
+UPDATE orders
+SET status = $2, tracking_number = $3, updated_at = $4, version = version + 1
+WHERE id = $1 AND version = $5;
+
+-- name: OrderExists :one
+SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1);
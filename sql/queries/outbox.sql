@@ -1,6 +1,6 @@
 -- name: InsertOutboxEvent :exec
-INSERT INTO outbox_events (id, aggregate_id, event_name, payload, occurred_at)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO outbox_events (id, aggregate_id, event_name, schema_version, payload, occurred_at)
+VALUES ($1, $2, $3, $4, $5, $6);
 
 -- name: GetUnpublishedOutboxEvents :many
 SELECT id, aggregate_id, event_name, payload, occurred_at, published_at
@@ -10,4 +10,104 @@ ORDER BY occurred_at
 LIMIT $1;
 
 -- name: MarkOutboxEventPublished :exec
-UPDATE outbox_events SET published_at = NOW() WHERE id = $1;
+UPDATE outbox_events SET published_at = NOW(), claimed_by = NULL, claimed_until = NULL WHERE id = $1;
+
+-- name: ClaimOutboxEvents :many
+-- Leases up to batch_size unpublished events to one relay. SKIP LOCKED makes
+-- concurrent relays claim disjoint batches instead of waiting on each other;
+-- rows with a live lease, a pending backoff or a dead letter are skipped.
+-- Only the oldest pending event of each aggregate is eligible, so at most one
+-- event per aggregate is in flight and a failing (or dead-lettered) event
+-- holds back the later events of its aggregate until it is published,
+-- replayed or discarded.
+UPDATE outbox_events
+SET claimed_by = sqlc.arg('relay_id')::text,
+    claimed_until = NOW() + sqlc.arg('lease_ms')::bigint * INTERVAL '1 millisecond'
+WHERE id IN (
+    SELECT e.id
+    FROM outbox_events e
+    WHERE e.published_at IS NULL
+      AND e.dead_lettered_at IS NULL
+      AND (e.next_attempt_at IS NULL OR e.next_attempt_at <= NOW())
+      AND (e.claimed_until IS NULL OR e.claimed_until < NOW())
+      AND NOT EXISTS (
+          SELECT 1
+          FROM outbox_events earlier
+          WHERE earlier.aggregate_id = e.aggregate_id
+            AND earlier.published_at IS NULL
+            AND earlier.sequence_number < e.sequence_number
+      )
+    ORDER BY e.sequence_number
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ReleaseOutboxEventClaim :exec
+-- Hands an unpublished event back before the lease runs out, e.g. after a
+-- failed publish. Only the relay holding the lease can release it.
+UPDATE outbox_events
+SET claimed_by = NULL, claimed_until = NULL
+WHERE id = sqlc.arg('id') AND claimed_by = sqlc.arg('relay_id')::text AND published_at IS NULL;
+
+-- name: RecordOutboxEventFailure :exec
+-- Counts a failed publish, schedules the next attempt and releases the
+-- lease. dead_letter parks the event for good once attempts are exhausted.
+UPDATE outbox_events
+SET attempts = attempts + 1,
+    last_error = sqlc.arg('last_error')::text,
+    next_attempt_at = NOW() + sqlc.arg('backoff_ms')::bigint * INTERVAL '1 millisecond',
+    dead_lettered_at = CASE WHEN sqlc.arg('dead_letter')::boolean THEN NOW() END,
+    claimed_by = NULL,
+    claimed_until = NULL
+WHERE id = sqlc.arg('id') AND claimed_by = sqlc.arg('relay_id')::text;
+
+-- name: ListDeadLetteredOutboxEvents :many
+SELECT *
+FROM outbox_events
+WHERE dead_lettered_at IS NOT NULL
+ORDER BY dead_lettered_at, id
+LIMIT $1;
+
+-- name: ReplayDeadLetteredOutboxEvent :execrows
+-- Puts a dead letter back into the relay's queue with a fresh attempt
+-- budget. last_error is kept for reference until the next failure.
+UPDATE outbox_events
+SET dead_lettered_at = NULL, attempts = 0, next_attempt_at = NULL
+WHERE id = $1 AND dead_lettered_at IS NOT NULL;
+
+-- name: DiscardDeadLetteredOutboxEvent :execrows
+DELETE FROM outbox_events WHERE id = $1 AND dead_lettered_at IS NOT NULL;
+
+-- name: ArchivePublishedOutboxEvents :execrows
+-- Moves up to batch_size events published before published_before into
+-- outbox_events_archive, in one statement so a row is never in both tables
+-- or in neither. Only published rows qualify, which the relay never touches
+-- again; SKIP LOCKED keeps concurrent workers on disjoint rows.
+WITH moved AS (
+    DELETE FROM outbox_events
+    WHERE id IN (
+        SELECT id
+        FROM outbox_events
+        WHERE published_at < sqlc.arg('published_before')::timestamptz
+        ORDER BY published_at
+        LIMIT sqlc.arg('batch_size')
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, aggregate_id, event_name, schema_version, payload, occurred_at, published_at, sequence_number
+)
+INSERT INTO outbox_events_archive (id, aggregate_id, event_name, schema_version, payload, occurred_at, published_at, sequence_number)
+SELECT id, aggregate_id, event_name, schema_version, payload, occurred_at, published_at, sequence_number
+FROM moved;
+
+-- name: DeletePublishedOutboxEvents :execrows
+-- Like ArchivePublishedOutboxEvents, for deployments that keep no archive.
+DELETE FROM outbox_events
+WHERE id IN (
+    SELECT id
+    FROM outbox_events
+    WHERE published_at < sqlc.arg('published_before')::timestamptz
+    ORDER BY published_at
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+);
@@ -0,0 +1,83 @@
+-- name: CreateOutboxReplay :one
+INSERT INTO outbox_replays (id, target, aggregate_id, event_name, occurred_from, occurred_to, published_before, status, total)
+VALUES ($1, $2, $3, $4, $5, $6, $7, 'pending', $8)
+RETURNING *;
+
+-- name: GetOutboxReplay :one
+SELECT * FROM outbox_replays WHERE id = $1;
+
+-- name: ListOutboxReplays :many
+SELECT * FROM outbox_replays ORDER BY created_at DESC, id LIMIT $1;
+
+-- name: ClaimOutboxReplay :one
+-- Takes the lease on a replay that is not completed and not being run by
+-- someone else (or whose runner stopped renewing its lease).
+UPDATE outbox_replays
+SET status = 'running',
+    last_error = NULL,
+    claimed_until = NOW() + sqlc.arg('lease_ms')::bigint * INTERVAL '1 millisecond',
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')
+  AND status <> 'completed'
+  AND (status <> 'running' OR claimed_until < NOW())
+RETURNING *;
+
+-- name: SaveOutboxReplayCheckpoint :exec
+-- Records progress and renews the lease.
+UPDATE outbox_replays
+SET replayed = sqlc.arg('replayed')::bigint,
+    last_sequence_number = sqlc.arg('last_sequence_number')::bigint,
+    claimed_until = NOW() + sqlc.arg('lease_ms')::bigint * INTERVAL '1 millisecond',
+    updated_at = NOW()
+WHERE id = sqlc.arg('id');
+
+-- name: FinishOutboxReplay :exec
+-- Ends a run as 'completed' or 'failed' and gives up the lease.
+UPDATE outbox_replays
+SET status = sqlc.arg('status')::text,
+    last_error = sqlc.narg('last_error')::text,
+    claimed_until = NULL,
+    updated_at = NOW(),
+    completed_at = CASE WHEN sqlc.arg('status')::text = 'completed' THEN NOW() END
+WHERE id = sqlc.arg('id');
+
+-- name: CountReplayableOutboxEvents :one
+-- Published events matching a replay's filters; NULL filters match all.
+SELECT (
+    (SELECT count(*) FROM outbox_events e
+     WHERE e.published_at < sqlc.arg('published_before')::timestamptz
+       AND (sqlc.narg('aggregate_id')::uuid IS NULL OR e.aggregate_id = sqlc.narg('aggregate_id')::uuid)
+       AND (sqlc.narg('event_name')::text IS NULL OR e.event_name = sqlc.narg('event_name')::text)
+       AND (sqlc.narg('occurred_from')::timestamptz IS NULL OR e.occurred_at >= sqlc.narg('occurred_from')::timestamptz)
+       AND (sqlc.narg('occurred_to')::timestamptz IS NULL OR e.occurred_at < sqlc.narg('occurred_to')::timestamptz))
+  + (SELECT count(*) FROM outbox_events_archive a
+     WHERE a.published_at < sqlc.arg('published_before')::timestamptz
+       AND (sqlc.narg('aggregate_id')::uuid IS NULL OR a.aggregate_id = sqlc.narg('aggregate_id')::uuid)
+       AND (sqlc.narg('event_name')::text IS NULL OR a.event_name = sqlc.narg('event_name')::text)
+       AND (sqlc.narg('occurred_from')::timestamptz IS NULL OR a.occurred_at >= sqlc.narg('occurred_from')::timestamptz)
+       AND (sqlc.narg('occurred_to')::timestamptz IS NULL OR a.occurred_at < sqlc.narg('occurred_to')::timestamptz))
+)::bigint AS count;
+
+-- name: ListReplayableOutboxEvents :many
+-- The next batch_size published events after after_sequence_number, from
+-- both the outbox and its archive. The retention worker moves rows in one
+-- statement, so this statement's snapshot sees each row exactly once.
+SELECT e.id, e.aggregate_id, e.event_name, e.schema_version, e.payload, e.occurred_at, e.sequence_number
+FROM outbox_events e
+WHERE e.sequence_number > sqlc.arg('after_sequence_number')::bigint
+  AND e.published_at < sqlc.arg('published_before')::timestamptz
+  AND (sqlc.narg('aggregate_id')::uuid IS NULL OR e.aggregate_id = sqlc.narg('aggregate_id')::uuid)
+  AND (sqlc.narg('event_name')::text IS NULL OR e.event_name = sqlc.narg('event_name')::text)
+  AND (sqlc.narg('occurred_from')::timestamptz IS NULL OR e.occurred_at >= sqlc.narg('occurred_from')::timestamptz)
+  AND (sqlc.narg('occurred_to')::timestamptz IS NULL OR e.occurred_at < sqlc.narg('occurred_to')::timestamptz)
+UNION ALL
+SELECT a.id, a.aggregate_id, a.event_name, a.schema_version, a.payload, a.occurred_at, a.sequence_number
+FROM outbox_events_archive a
+WHERE a.sequence_number > sqlc.arg('after_sequence_number')::bigint
+  AND a.published_at < sqlc.arg('published_before')::timestamptz
+  AND (sqlc.narg('aggregate_id')::uuid IS NULL OR a.aggregate_id = sqlc.narg('aggregate_id')::uuid)
+  AND (sqlc.narg('event_name')::text IS NULL OR a.event_name = sqlc.narg('event_name')::text)
+  AND (sqlc.narg('occurred_from')::timestamptz IS NULL OR a.occurred_at >= sqlc.narg('occurred_from')::timestamptz)
+  AND (sqlc.narg('occurred_to')::timestamptz IS NULL OR a.occurred_at < sqlc.narg('occurred_to')::timestamptz)
+ORDER BY sequence_number
+LIMIT sqlc.arg('batch_size');
@@ -0,0 +1,39 @@
+-- name: InsertProductPrice :exec
+-- Two changes within the same microsecond keep the later one.
+INSERT INTO product_price_history (product_id, price_minor_units, currency, effective_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (product_id, effective_at) DO UPDATE
+SET price_minor_units = EXCLUDED.price_minor_units, currency = EXCLUDED.currency;
+
+-- name: ListProductPriceHistory :many
+-- Returns the price already in effect at "from" and every change up to
+-- "to", oldest first.
+SELECT product_id, price_minor_units, currency, effective_at
+FROM product_price_history
+WHERE product_id = sqlc.arg('product_id')::uuid
+  AND effective_at <= sqlc.arg('to')::timestamptz
+  AND effective_at >= COALESCE(
+    (SELECT MAX(h.effective_at) FROM product_price_history h
+     WHERE h.product_id = sqlc.arg('product_id')::uuid AND h.effective_at <= sqlc.arg('from')::timestamptz),
+    sqlc.arg('from')::timestamptz)
+ORDER BY effective_at;
+
+-- name: InsertScheduledPriceChange :exec
+INSERT INTO scheduled_price_changes (id, product_id, price_minor_units, currency, effective_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListScheduledPriceChanges :many
+SELECT id, product_id, price_minor_units, currency, effective_at, created_at
+FROM scheduled_price_changes
+WHERE product_id = $1
+ORDER BY effective_at, id;
+
+-- name: ListDueScheduledPriceChanges :many
+SELECT id, product_id, price_minor_units, currency, effective_at, created_at
+FROM scheduled_price_changes
+WHERE effective_at <= $1
+ORDER BY effective_at, id
+LIMIT $2;
+
+-- name: DeleteScheduledPriceChange :execrows
+DELETE FROM scheduled_price_changes WHERE id = $1 AND product_id = $2;
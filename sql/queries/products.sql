@@ -1,25 +1,177 @@
 -- name: CreateProduct :one
-INSERT INTO products (id, name, price_minor_units, currency, seller_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO products (id, name, price_minor_units, currency, seller_id, status, created_at, updated_at, version)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: GetProductById :one
-SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.created_at, p.updated_at
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids
 FROM products p
 JOIN sellers s ON p.seller_id = s.id
 WHERE p.id = $1 AND p.deleted_at IS NULL AND s.deleted_at IS NULL;
 
--- name: GetAllProducts :many
-SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.created_at, p.updated_at
+-- name: ListProductsByCreatedAt :many
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids
 FROM products p
 JOIN sellers s ON p.seller_id = s.id
 WHERE p.deleted_at IS NULL AND s.deleted_at IS NULL
-ORDER BY p.created_at DESC;
+  AND (sqlc.narg('seller_id')::uuid IS NULL OR p.seller_id = sqlc.narg('seller_id')::uuid)
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status')::text)
+  AND (sqlc.narg('category_id')::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM product_categories pc JOIN categories c ON c.id = pc.category_id
+        WHERE pc.product_id = p.id
+          AND c.path LIKE (SELECT root.path FROM categories root WHERE root.id = sqlc.narg('category_id')::uuid) || '%'))
+  AND (sqlc.narg('currency')::text IS NULL OR p.currency = sqlc.narg('currency')::text)
+  AND (sqlc.narg('min_price_minor_units')::bigint IS NULL OR p.price_minor_units >= sqlc.narg('min_price_minor_units')::bigint)
+  AND (sqlc.narg('max_price_minor_units')::bigint IS NULL OR p.price_minor_units <= sqlc.narg('max_price_minor_units')::bigint)
+  AND (sqlc.narg('after_id')::uuid IS NULL OR (p.created_at, p.id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListProductsByName :many
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids
+FROM products p
+JOIN sellers s ON p.seller_id = s.id
+WHERE p.deleted_at IS NULL AND s.deleted_at IS NULL
+  AND (sqlc.narg('seller_id')::uuid IS NULL OR p.seller_id = sqlc.narg('seller_id')::uuid)
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status')::text)
+  AND (sqlc.narg('category_id')::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM product_categories pc JOIN categories c ON c.id = pc.category_id
+        WHERE pc.product_id = p.id
+          AND c.path LIKE (SELECT root.path FROM categories root WHERE root.id = sqlc.narg('category_id')::uuid) || '%'))
+  AND (sqlc.narg('currency')::text IS NULL OR p.currency = sqlc.narg('currency')::text)
+  AND (sqlc.narg('min_price_minor_units')::bigint IS NULL OR p.price_minor_units >= sqlc.narg('min_price_minor_units')::bigint)
+  AND (sqlc.narg('max_price_minor_units')::bigint IS NULL OR p.price_minor_units <= sqlc.narg('max_price_minor_units')::bigint)
+  AND (sqlc.narg('after_id')::uuid IS NULL OR (p.name, p.id) > (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+ORDER BY p.name, p.id
+LIMIT sqlc.arg('limit');
+
+-- name: ListProductsByPrice :many
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids
+FROM products p
+JOIN sellers s ON p.seller_id = s.id
+WHERE p.deleted_at IS NULL AND s.deleted_at IS NULL
+  AND (sqlc.narg('seller_id')::uuid IS NULL OR p.seller_id = sqlc.narg('seller_id')::uuid)
+  AND (sqlc.narg('status')::text IS NULL OR p.status = sqlc.narg('status')::text)
+  AND (sqlc.narg('category_id')::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM product_categories pc JOIN categories c ON c.id = pc.category_id
+        WHERE pc.product_id = p.id
+          AND c.path LIKE (SELECT root.path FROM categories root WHERE root.id = sqlc.narg('category_id')::uuid) || '%'))
+  AND (sqlc.narg('currency')::text IS NULL OR p.currency = sqlc.narg('currency')::text)
+  AND (sqlc.narg('min_price_minor_units')::bigint IS NULL OR p.price_minor_units >= sqlc.narg('min_price_minor_units')::bigint)
+  AND (sqlc.narg('max_price_minor_units')::bigint IS NULL OR p.price_minor_units <= sqlc.narg('max_price_minor_units')::bigint)
+  AND (sqlc.narg('after_id')::uuid IS NULL OR (p.price_minor_units, p.id) > (sqlc.narg('after_price_minor_units')::bigint, sqlc.narg('after_id')::uuid))
+ORDER BY p.price_minor_units, p.id
+LIMIT sqlc.arg('limit');
+
+-- name: SearchProducts :many
+-- Ranks the published products whose search vector matches the tsquery,
+-- best match first. The headline is the product name with every matching
+-- word wrapped in <mark> and </mark>.
+SELECT p.id, p.name, p.price_minor_units, p.currency, p.seller_id, p.status, p.created_at, p.updated_at, p.version,
+       ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = p.id ORDER BY pc.category_id)::uuid[] AS category_ids,
+       ts_rank(p.search_vector, query)::real AS rank,
+       ts_headline('english', p.name, query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::text AS headline
+FROM products p
+JOIN sellers s ON p.seller_id = s.id
+CROSS JOIN to_tsquery('english', sqlc.arg('query')::text) query
+WHERE p.deleted_at IS NULL AND s.deleted_at IS NULL AND p.status = 'published'
+  AND p.search_vector @@ query
+  AND (sqlc.narg('after_id')::uuid IS NULL
+       OR ts_rank(p.search_vector, query) < sqlc.narg('after_rank')::real
+       OR (ts_rank(p.search_vector, query) = sqlc.narg('after_rank')::real AND p.id > sqlc.narg('after_id')::uuid))
+ORDER BY rank DESC, p.id
+LIMIT sqlc.arg('limit');
 
 -- name: UpdateProduct :execrows
+-- Applies only while the row still has the version the caller read; zero
+-- rows means the product is gone or was modified concurrently.
 UPDATE products
-SET name = $2, price_minor_units = $3, currency = $4, seller_id = $5, updated_at = $6
-WHERE id = $1 AND deleted_at IS NULL;
+SET name = $2, price_minor_units = $3, currency = $4, seller_id = $5, status = $6, updated_at = $7, version = version + 1
+WHERE id = $1 AND version = $8 AND deleted_at IS NULL;
+
+-- name: DeleteProduct :execrows
+UPDATE products
+SET deleted_at = NOW(), version = version + 1
+WHERE id = $1 AND version = $2 AND deleted_at IS NULL;
+
+-- name: ProductExists :one
+SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL);
+
+-- name: DeleteProductCategories :exec
+DELETE FROM product_categories WHERE product_id = $1;
+
+-- name: InsertProductCategories :execrows
+-- Inserts only the categories that exist; fewer rows than ids means some
+-- category is missing.
+INSERT INTO product_categories (product_id, category_id)
+SELECT sqlc.arg('product_id')::uuid, c.id
+FROM categories c
+WHERE c.id = ANY(sqlc.arg('category_ids')::uuid[]);
+
+-- name: RefreshProductCategoryNames :exec
+-- Recomputes category_names, the category part of the search vector, from
+-- the product's categories and all their ancestors.
+UPDATE products p
+SET category_names = coalesce((
+        SELECT string_agg(DISTINCT ancestor.name, ' ')
+        FROM product_categories pc
+        JOIN categories c ON c.id = pc.category_id
+        JOIN categories ancestor ON c.path LIKE ancestor.path || '%'
+        WHERE pc.product_id = p.id), '')
+WHERE p.id = $1;
+
+-- name: ListProductVariants :many
+-- Loads the variants of a whole page of products in one query.
+SELECT id, product_id, sku, attributes, price_override_minor_units, price_override_currency
+FROM product_variants
+WHERE product_id = ANY(sqlc.arg('product_ids')::uuid[])
+ORDER BY product_id, id;
+
+-- name: InsertProductVariant :exec
+INSERT INTO product_variants (id, product_id, sku, attributes, price_override_minor_units, price_override_currency)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: UpdateProductVariant :exec
+UPDATE product_variants
+SET sku = $2, attributes = $3, price_override_minor_units = $4, price_override_currency = $5
+WHERE id = $1;
+
+-- name: DeleteProductVariant :exec
+DELETE FROM product_variants WHERE id = $1;
+
+-- name: SkuTaken :one
+-- SKUs of soft-deleted products stay taken.
+SELECT EXISTS(SELECT 1 FROM product_variants WHERE sku = $1 AND id <> $2);
+
+-- name: ListProductImages :many
+-- Loads the images of a whole page of products in one query.
+SELECT id, product_id, blob_key, content_type, size_bytes, position, is_primary, created_at
+FROM product_images
+WHERE product_id = ANY(sqlc.arg('product_ids')::uuid[])
+ORDER BY product_id, position, id;
+
+-- name: InsertProductImage :exec
+INSERT INTO product_images (id, product_id, blob_key, content_type, size_bytes, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteProductImage :exec
+DELETE FROM product_images WHERE id = $1;
+
+-- name: ArrangeProductImages :exec
+-- Stores the display order of the product's images and which is primary.
+UPDATE product_images i
+SET position = arranged.position, is_primary = (i.id = sqlc.arg('primary_image_id')::uuid)
+FROM unnest(sqlc.arg('image_ids')::uuid[]) WITH ORDINALITY AS arranged(id, position)
+WHERE i.id = arranged.id AND i.product_id = sqlc.arg('product_id')::uuid;
+
+-- name: ScheduleProductImageDeletions :exec
+INSERT INTO blob_deletions (blob_key)
+SELECT blob_key FROM product_images WHERE product_id = $1
+ON CONFLICT (blob_key) DO NOTHING;
 
--- name: DeleteProduct :exec
-UPDATE products SET deleted_at = NOW() WHERE id = $1;
+-- name: DeleteProductImages :exec
+DELETE FROM product_images WHERE product_id = $1;
@@ -0,0 +1,47 @@
+-- name: CreatePromotion :exec
+INSERT INTO promotions (id, seller_id, name, discount_kind, basis_points, amount_minor_units, currency, product_ids, starts_at, ends_at, stackable, usage_limit, usage_count, created_at, updated_at, version)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16);
+
+-- name: GetPromotionById :one
+SELECT id, seller_id, name, discount_kind, basis_points, amount_minor_units, currency, product_ids, starts_at, ends_at, stackable, usage_limit, usage_count, created_at, updated_at, version
+FROM promotions
+WHERE id = $1;
+
+-- name: ListPromotionsBySeller :many
+SELECT id, seller_id, name, discount_kind, basis_points, amount_minor_units, currency, product_ids, starts_at, ends_at, stackable, usage_limit, usage_count, created_at, updated_at, version
+FROM promotions
+WHERE seller_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListActivePromotions :many
+-- Promotions of the given sellers that run at the given time and have
+-- redemptions left.
+SELECT id, seller_id, name, discount_kind, basis_points, amount_minor_units, currency, product_ids, starts_at, ends_at, stackable, usage_limit, usage_count, created_at, updated_at, version
+FROM promotions
+WHERE seller_id = ANY(sqlc.arg('seller_ids')::uuid[])
+  AND starts_at <= sqlc.arg('at')::timestamptz
+  AND (ends_at IS NULL OR ends_at > sqlc.arg('at')::timestamptz)
+  AND (usage_limit = 0 OR usage_count < usage_limit)
+ORDER BY starts_at, id;
+
+-- name: UpdatePromotion :execrows
+-- Only the end and the usage count change after creation. Applies only
+-- while the row still has the version the caller read.
+UPDATE promotions
+SET ends_at = $2, usage_count = $3, updated_at = $4, version = version + 1
+WHERE id = $1 AND version = $5;
+
+-- name: RedeemPromotion :one
+-- Counts one use without a version check, so concurrent redemptions do not
+-- conflict; the condition keeps the count within the usage limit. No row
+-- means the promotion is unknown, not running at the given time or used up.
+UPDATE promotions
+SET usage_count = usage_count + 1, updated_at = sqlc.arg('at')::timestamptz, version = version + 1
+WHERE id = sqlc.arg('id')
+  AND starts_at <= sqlc.arg('at')::timestamptz
+  AND (ends_at IS NULL OR ends_at > sqlc.arg('at')::timestamptz)
+  AND (usage_limit = 0 OR usage_count < usage_limit)
+RETURNING id, seller_id, name, discount_kind, basis_points, amount_minor_units, currency, product_ids, starts_at, ends_at, stackable, usage_limit, usage_count, created_at, updated_at, version;
+
+-- name: PromotionExists :one
+SELECT EXISTS(SELECT 1 FROM promotions WHERE id = $1);
@@ -1,23 +1,40 @@
 -- name: CreateSeller :one
-INSERT INTO sellers (id, name, created_at, updated_at)
-VALUES ($1, $2, $3, $4)
+INSERT INTO sellers (id, name, verification_status, created_at, updated_at, version)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetSellerById :one
-SELECT id, name, created_at, updated_at
+SELECT id, name, verification_status, created_at, updated_at, version
 FROM sellers
 WHERE id = $1 AND deleted_at IS NULL;
 
--- name: GetAllSellers :many
-SELECT id, name, created_at, updated_at
+-- name: ListSellersByCreatedAt :many
+SELECT id, name, verification_status, created_at, updated_at, version
 FROM sellers
 WHERE deleted_at IS NULL
-ORDER BY created_at DESC;
+  AND (sqlc.narg('after_id')::uuid IS NULL OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListSellersByName :many
+SELECT id, name, verification_status, created_at, updated_at, version
+FROM sellers
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('after_id')::uuid IS NULL OR (name, id) > (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+ORDER BY name, id
+LIMIT sqlc.arg('limit');
 
 -- name: UpdateSeller :execrows
+-- Applies only while the row still has the version the caller read; zero
+-- rows means the seller is gone or was modified concurrently.
 UPDATE sellers
-SET name = $2, updated_at = $3
-WHERE id = $1 AND deleted_at IS NULL;
+SET name = $2, verification_status = $3, updated_at = $4, version = version + 1
+WHERE id = $1 AND version = $5 AND deleted_at IS NULL;
+
+-- name: DeleteSeller :execrows
+UPDATE sellers
+SET deleted_at = NOW(), version = version + 1
+WHERE id = $1 AND version = $2 AND deleted_at IS NULL;
 
--- name: DeleteSeller :exec
-UPDATE sellers SET deleted_at = NOW() WHERE id = $1;
\ No newline at end of file
+-- name: SellerExists :one
+SELECT EXISTS(SELECT 1 FROM sellers WHERE id = $1 AND deleted_at IS NULL);
\ No newline at end of file
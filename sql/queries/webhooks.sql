@@ -0,0 +1,93 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (id, url, event_types, secret)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions WHERE id = $1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions ORDER BY created_at, id;
+
+-- name: DeleteWebhookSubscription :execrows
+-- Pending deliveries go with it (ON DELETE CASCADE).
+DELETE FROM webhook_subscriptions WHERE id = $1;
+
+-- name: EnqueueWebhookDeliveries :execrows
+-- Creates a delivery for every subscription whose filter matches the event.
+-- Idempotent per (subscription, event), so a republished outbox event does
+-- not notify a subscriber twice.
+INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_name, payload)
+SELECT gen_random_uuid(), s.id, sqlc.arg('event_id')::uuid, sqlc.arg('event_name')::text, sqlc.arg('payload')::jsonb
+FROM webhook_subscriptions s
+WHERE EXISTS (
+    SELECT 1
+    FROM unnest(s.event_types) AS pattern
+    WHERE pattern = '*'
+       OR pattern = sqlc.arg('event_name')::text
+       OR (pattern LIKE '%.*' AND starts_with(sqlc.arg('event_name')::text, rtrim(pattern, '*')))
+)
+ON CONFLICT (subscription_id, event_id) DO NOTHING;
+
+-- name: ClaimWebhookDeliveries :many
+-- Leases due deliveries to one dispatcher, the same way ClaimOutboxEvents
+-- leases outbox events.
+UPDATE webhook_deliveries
+SET claimed_by = sqlc.arg('dispatcher_id')::text,
+    claimed_until = NOW() + sqlc.arg('lease_ms')::bigint * INTERVAL '1 millisecond'
+WHERE id IN (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE delivered_at IS NULL
+      AND failed_at IS NULL
+      AND next_attempt_at <= NOW()
+      AND (claimed_until IS NULL OR claimed_until < NOW())
+    ORDER BY next_attempt_at, id
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkWebhookDelivered :exec
+UPDATE webhook_deliveries
+SET attempts = attempts + 1,
+    last_status_code = sqlc.arg('status_code')::integer,
+    last_error = NULL,
+    delivered_at = NOW(),
+    claimed_by = NULL,
+    claimed_until = NULL
+WHERE id = sqlc.arg('id') AND claimed_by = sqlc.arg('dispatcher_id')::text;
+
+-- name: RecordWebhookDeliveryFailure :exec
+-- Counts a failed attempt and schedules the next one; give_up marks the
+-- delivery as failed for good. status_code is NULL when no response came.
+UPDATE webhook_deliveries
+SET attempts = attempts + 1,
+    last_status_code = sqlc.narg('status_code')::integer,
+    last_error = sqlc.arg('last_error')::text,
+    next_attempt_at = NOW() + sqlc.arg('backoff_ms')::bigint * INTERVAL '1 millisecond',
+    failed_at = CASE WHEN sqlc.arg('give_up')::boolean THEN NOW() END,
+    claimed_by = NULL,
+    claimed_until = NULL
+WHERE id = sqlc.arg('id') AND claimed_by = sqlc.arg('dispatcher_id')::text;
+
+-- name: ListWebhookDeliveries :many
+SELECT *
+FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
+
+-- name: DeleteFinishedWebhookDeliveries :execrows
+-- Deletes up to batch_size deliveries that were delivered, or failed for
+-- good, before finished_before. Pending deliveries are never touched, and
+-- SKIP LOCKED keeps concurrent retention workers on disjoint rows.
+DELETE FROM webhook_deliveries
+WHERE id IN (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE COALESCE(delivered_at, failed_at) < sqlc.arg('finished_before')::timestamptz
+    ORDER BY COALESCE(delivered_at, failed_at)
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+);